@@ -0,0 +1,1135 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: book.proto
+
+// The book catalog over gRPC, for internal services. It offers the book
+// operations of the HTTP API (api/openapi.yaml) and is served by the same
+// service, with the same validation, credentials and tenants: send an API
+// key as x-api-key or a bearer token as authorization metadata, and the
+// tenant header when the server runs with -tenants.
+
+package bookpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Book struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Isbn          *string                `protobuf:"bytes,3,opt,name=isbn,proto3,oneof" json:"isbn,omitempty"`
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Subtitle      *string                `protobuf:"bytes,5,opt,name=subtitle,proto3,oneof" json:"subtitle,omitempty"`
+	PublishedYear *int32                 `protobuf:"varint,6,opt,name=published_year,json=publishedYear,proto3,oneof" json:"published_year,omitempty"`
+	PageCount     *int32                 `protobuf:"varint,7,opt,name=page_count,json=pageCount,proto3,oneof" json:"page_count,omitempty"`
+	CoverUrl      *string                `protobuf:"bytes,8,opt,name=cover_url,json=coverUrl,proto3,oneof" json:"cover_url,omitempty"`
+	Tags          []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	Authors       []string               `protobuf:"bytes,10,rep,name=authors,proto3" json:"authors,omitempty"`
+	Description   *string                `protobuf:"bytes,11,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Subjects      []string               `protobuf:"bytes,12,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	Enrichment    *Enrichment            `protobuf:"bytes,13,opt,name=enrichment,proto3" json:"enrichment,omitempty"`
+	Forthcoming   bool                   `protobuf:"varint,14,opt,name=forthcoming,proto3" json:"forthcoming,omitempty"`
+	ReleaseDate   *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Set while the book is in the trash.
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Book) Reset() {
+	*x = Book{}
+	mi := &file_book_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Book) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Book) ProtoMessage() {}
+
+func (x *Book) ProtoReflect() protoreflect.Message {
+	mi := &file_book_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Book.ProtoReflect.Descriptor instead.
+func (*Book) Descriptor() ([]byte, []int) {
+	return file_book_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Book) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Book) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Book) GetIsbn() string {
+	if x != nil && x.Isbn != nil {
+		return *x.Isbn
+	}
+	return ""
+}
+
+func (x *Book) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Book) GetSubtitle() string {
+	if x != nil && x.Subtitle != nil {
+		return *x.Subtitle
+	}
+	return ""
+}
+
+func (x *Book) GetPublishedYear() int32 {
+	if x != nil && x.PublishedYear != nil {
+		return *x.PublishedYear
+	}
+	return 0
+}
+
+func (x *Book) GetPageCount() int32 {
+	if x != nil && x.PageCount != nil {
+		return *x.PageCount
+	}
+	return 0
+}
+
+func (x *Book) GetCoverUrl() string {
+	if x != nil && x.CoverUrl != nil {
+		return *x.CoverUrl
+	}
+	return ""
+}
+
+func (x *Book) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Book) GetAuthors() []string {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *Book) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Book) GetSubjects() []string {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+func (x *Book) GetEnrichment() *Enrichment {
+	if x != nil {
+		return x.Enrichment
+	}
+	return nil
+}
+
+func (x *Book) GetForthcoming() bool {
+	if x != nil {
+		return x.Forthcoming
+	}
+	return false
+}
+
+func (x *Book) GetReleaseDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return nil
+}
+
+func (x *Book) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Book) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Book) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+type Enrichment struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Attempted bool                   `protobuf:"varint,1,opt,name=attempted,proto3" json:"attempted,omitempty"`
+	// Provider that answered, e.g. openlibrary.
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// not_requested, ok, partial or pending.
+	Status        string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Enrichment) Reset() {
+	*x = Enrichment{}
+	mi := &file_book_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Enrichment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Enrichment) ProtoMessage() {}
+
+func (x *Enrichment) ProtoReflect() protoreflect.Message {
+	mi := &file_book_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Enrichment.ProtoReflect.Descriptor instead.
+func (*Enrichment) Descriptor() ([]byte, []int) {
+	return file_book_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Enrichment) GetAttempted() bool {
+	if x != nil {
+		return x.Attempted
+	}
+	return false
+}
+
+func (x *Enrichment) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Enrichment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type CreateBookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ISBN-10 or ISBN-13, stored as a plain ISBN-13.
+	Isbn          *string                `protobuf:"bytes,1,opt,name=isbn,proto3,oneof" json:"isbn,omitempty"`
+	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Subtitle      *string                `protobuf:"bytes,3,opt,name=subtitle,proto3,oneof" json:"subtitle,omitempty"`
+	PublishedYear *int32                 `protobuf:"varint,4,opt,name=published_year,json=publishedYear,proto3,oneof" json:"published_year,omitempty"`
+	PageCount     *int32                 `protobuf:"varint,5,opt,name=page_count,json=pageCount,proto3,oneof" json:"page_count,omitempty"`
+	CoverUrl      *string                `protobuf:"bytes,6,opt,name=cover_url,json=coverUrl,proto3,oneof" json:"cover_url,omitempty"`
+	Tags          []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Authors       []string               `protobuf:"bytes,8,rep,name=authors,proto3" json:"authors,omitempty"`
+	Forthcoming   bool                   `protobuf:"varint,9,opt,name=forthcoming,proto3" json:"forthcoming,omitempty"`
+	ReleaseDate   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	// Look the ISBN up to fill in missing fields; the title may then be left out.
+	Enrich bool `protobuf:"varint,11,opt,name=enrich,proto3" json:"enrich,omitempty"`
+	// Fail with UNAVAILABLE instead of creating the book unenriched.
+	RequireEnrichment bool `protobuf:"varint,12,opt,name=require_enrichment,json=requireEnrichment,proto3" json:"require_enrichment,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateBookRequest) Reset() {
+	*x = CreateBookRequest{}
+	mi := &file_book_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBookRequest) ProtoMessage() {}
+
+func (x *CreateBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBookRequest.ProtoReflect.Descriptor instead.
+func (*CreateBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateBookRequest) GetIsbn() string {
+	if x != nil && x.Isbn != nil {
+		return *x.Isbn
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetSubtitle() string {
+	if x != nil && x.Subtitle != nil {
+		return *x.Subtitle
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetPublishedYear() int32 {
+	if x != nil && x.PublishedYear != nil {
+		return *x.PublishedYear
+	}
+	return 0
+}
+
+func (x *CreateBookRequest) GetPageCount() int32 {
+	if x != nil && x.PageCount != nil {
+		return *x.PageCount
+	}
+	return 0
+}
+
+func (x *CreateBookRequest) GetCoverUrl() string {
+	if x != nil && x.CoverUrl != nil {
+		return *x.CoverUrl
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateBookRequest) GetAuthors() []string {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *CreateBookRequest) GetForthcoming() bool {
+	if x != nil {
+		return x.Forthcoming
+	}
+	return false
+}
+
+func (x *CreateBookRequest) GetReleaseDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return nil
+}
+
+func (x *CreateBookRequest) GetEnrich() bool {
+	if x != nil {
+		return x.Enrich
+	}
+	return false
+}
+
+func (x *CreateBookRequest) GetRequireEnrichment() bool {
+	if x != nil {
+		return x.RequireEnrichment
+	}
+	return false
+}
+
+type GetBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookRequest) Reset() {
+	*x = GetBookRequest{}
+	mi := &file_book_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookRequest) ProtoMessage() {}
+
+func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
+func (*GetBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListBooksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Search in title and subtitle.
+	Q      *string `protobuf:"bytes,1,opt,name=q,proto3,oneof" json:"q,omitempty"`
+	Author *string `protobuf:"bytes,2,opt,name=author,proto3,oneof" json:"author,omitempty"`
+	Year   *int32  `protobuf:"varint,3,opt,name=year,proto3,oneof" json:"year,omitempty"`
+	Tag    *string `protobuf:"bytes,4,opt,name=tag,proto3,oneof" json:"tag,omitempty"`
+	// 1-based; defaults to 1.
+	Page int32 `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+	// Defaults to 20.
+	PageSize int32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Continues after the last book of an earlier page; page is ignored.
+	Cursor string `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Comma-separated fields, - for descending, e.g. -created_at,title.
+	Sort string `protobuf:"bytes,8,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Also list books in the trash; needs the admin role.
+	IncludeDeleted bool `protobuf:"varint,9,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListBooksRequest) Reset() {
+	*x = ListBooksRequest{}
+	mi := &file_book_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksRequest) ProtoMessage() {}
+
+func (x *ListBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksRequest.ProtoReflect.Descriptor instead.
+func (*ListBooksRequest) Descriptor() ([]byte, []int) {
+	return file_book_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListBooksRequest) GetQ() string {
+	if x != nil && x.Q != nil {
+		return *x.Q
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetAuthor() string {
+	if x != nil && x.Author != nil {
+		return *x.Author
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetYear() int32 {
+	if x != nil && x.Year != nil {
+		return *x.Year
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetTag() string {
+	if x != nil && x.Tag != nil {
+		return *x.Tag
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type ListBooksResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Books    []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	Page     int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Total    int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	// Empty on the last page.
+	NextCursor    string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksResponse) Reset() {
+	*x = ListBooksResponse{}
+	mi := &file_book_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksResponse) ProtoMessage() {}
+
+func (x *ListBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksResponse.ProtoReflect.Descriptor instead.
+func (*ListBooksResponse) Descriptor() ([]byte, []int) {
+	return file_book_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListBooksResponse) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+func (x *ListBooksResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBooksResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBooksResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListBooksResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type UpdateBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Isbn          *string                `protobuf:"bytes,2,opt,name=isbn,proto3,oneof" json:"isbn,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Subtitle      *string                `protobuf:"bytes,4,opt,name=subtitle,proto3,oneof" json:"subtitle,omitempty"`
+	PublishedYear *int32                 `protobuf:"varint,5,opt,name=published_year,json=publishedYear,proto3,oneof" json:"published_year,omitempty"`
+	PageCount     *int32                 `protobuf:"varint,6,opt,name=page_count,json=pageCount,proto3,oneof" json:"page_count,omitempty"`
+	CoverUrl      *string                `protobuf:"bytes,7,opt,name=cover_url,json=coverUrl,proto3,oneof" json:"cover_url,omitempty"`
+	Tags          []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	Authors       []string               `protobuf:"bytes,9,rep,name=authors,proto3" json:"authors,omitempty"`
+	Forthcoming   bool                   `protobuf:"varint,10,opt,name=forthcoming,proto3" json:"forthcoming,omitempty"`
+	ReleaseDate   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	// The version the change is based on; when the book has changed since,
+	// the update fails with ABORTED and nothing is written.
+	Version       *int32 `protobuf:"varint,12,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBookRequest) Reset() {
+	*x = UpdateBookRequest{}
+	mi := &file_book_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBookRequest) ProtoMessage() {}
+
+func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetIsbn() string {
+	if x != nil && x.Isbn != nil {
+		return *x.Isbn
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetSubtitle() string {
+	if x != nil && x.Subtitle != nil {
+		return *x.Subtitle
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetPublishedYear() int32 {
+	if x != nil && x.PublishedYear != nil {
+		return *x.PublishedYear
+	}
+	return 0
+}
+
+func (x *UpdateBookRequest) GetPageCount() int32 {
+	if x != nil && x.PageCount != nil {
+		return *x.PageCount
+	}
+	return 0
+}
+
+func (x *UpdateBookRequest) GetCoverUrl() string {
+	if x != nil && x.CoverUrl != nil {
+		return *x.CoverUrl
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateBookRequest) GetAuthors() []string {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *UpdateBookRequest) GetForthcoming() bool {
+	if x != nil {
+		return x.Forthcoming
+	}
+	return false
+}
+
+func (x *UpdateBookRequest) GetReleaseDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return nil
+}
+
+func (x *UpdateBookRequest) GetVersion() int32 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+type DeleteBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBookRequest) Reset() {
+	*x = DeleteBookRequest{}
+	mi := &file_book_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBookRequest) ProtoMessage() {}
+
+func (x *DeleteBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestoreBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreBookRequest) Reset() {
+	*x = RestoreBookRequest{}
+	mi := &file_book_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreBookRequest) ProtoMessage() {}
+
+func (x *RestoreBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreBookRequest.ProtoReflect.Descriptor instead.
+func (*RestoreBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RestoreBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type EnrichBookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Let the looked up data replace fields already set.
+	Overwrite     bool `protobuf:"varint,2,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrichBookRequest) Reset() {
+	*x = EnrichBookRequest{}
+	mi := &file_book_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrichBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrichBookRequest) ProtoMessage() {}
+
+func (x *EnrichBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrichBookRequest.ProtoReflect.Descriptor instead.
+func (*EnrichBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EnrichBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EnrichBookRequest) GetOverwrite() bool {
+	if x != nil {
+		return x.Overwrite
+	}
+	return false
+}
+
+var File_book_proto protoreflect.FileDescriptor
+
+const file_book_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"book.proto\x12\x0ebookmanager.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x87\x06\n" +
+	"\x04Book\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x17\n" +
+	"\x04isbn\x18\x03 \x01(\tH\x00R\x04isbn\x88\x01\x01\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x1f\n" +
+	"\bsubtitle\x18\x05 \x01(\tH\x01R\bsubtitle\x88\x01\x01\x12*\n" +
+	"\x0epublished_year\x18\x06 \x01(\x05H\x02R\rpublishedYear\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_count\x18\a \x01(\x05H\x03R\tpageCount\x88\x01\x01\x12 \n" +
+	"\tcover_url\x18\b \x01(\tH\x04R\bcoverUrl\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12\x18\n" +
+	"\aauthors\x18\n" +
+	" \x03(\tR\aauthors\x12%\n" +
+	"\vdescription\x18\v \x01(\tH\x05R\vdescription\x88\x01\x01\x12\x1a\n" +
+	"\bsubjects\x18\f \x03(\tR\bsubjects\x12:\n" +
+	"\n" +
+	"enrichment\x18\r \x01(\v2\x1a.bookmanager.v1.EnrichmentR\n" +
+	"enrichment\x12 \n" +
+	"\vforthcoming\x18\x0e \x01(\bR\vforthcoming\x12=\n" +
+	"\frelease_date\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vreleaseDate\x129\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAtB\a\n" +
+	"\x05_isbnB\v\n" +
+	"\t_subtitleB\x11\n" +
+	"\x0f_published_yearB\r\n" +
+	"\v_page_countB\f\n" +
+	"\n" +
+	"_cover_urlB\x0e\n" +
+	"\f_description\"Z\n" +
+	"\n" +
+	"Enrichment\x12\x1c\n" +
+	"\tattempted\x18\x01 \x01(\bR\tattempted\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\x80\x04\n" +
+	"\x11CreateBookRequest\x12\x17\n" +
+	"\x04isbn\x18\x01 \x01(\tH\x00R\x04isbn\x88\x01\x01\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x01R\x05title\x88\x01\x01\x12\x1f\n" +
+	"\bsubtitle\x18\x03 \x01(\tH\x02R\bsubtitle\x88\x01\x01\x12*\n" +
+	"\x0epublished_year\x18\x04 \x01(\x05H\x03R\rpublishedYear\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_count\x18\x05 \x01(\x05H\x04R\tpageCount\x88\x01\x01\x12 \n" +
+	"\tcover_url\x18\x06 \x01(\tH\x05R\bcoverUrl\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x18\n" +
+	"\aauthors\x18\b \x03(\tR\aauthors\x12 \n" +
+	"\vforthcoming\x18\t \x01(\bR\vforthcoming\x12=\n" +
+	"\frelease_date\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vreleaseDate\x12\x16\n" +
+	"\x06enrich\x18\v \x01(\bR\x06enrich\x12-\n" +
+	"\x12require_enrichment\x18\f \x01(\bR\x11requireEnrichmentB\a\n" +
+	"\x05_isbnB\b\n" +
+	"\x06_titleB\v\n" +
+	"\t_subtitleB\x11\n" +
+	"\x0f_published_yearB\r\n" +
+	"\v_page_countB\f\n" +
+	"\n" +
+	"_cover_url\" \n" +
+	"\x0eGetBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x9a\x02\n" +
+	"\x10ListBooksRequest\x12\x11\n" +
+	"\x01q\x18\x01 \x01(\tH\x00R\x01q\x88\x01\x01\x12\x1b\n" +
+	"\x06author\x18\x02 \x01(\tH\x01R\x06author\x88\x01\x01\x12\x17\n" +
+	"\x04year\x18\x03 \x01(\x05H\x02R\x04year\x88\x01\x01\x12\x15\n" +
+	"\x03tag\x18\x04 \x01(\tH\x03R\x03tag\x88\x01\x01\x12\x12\n" +
+	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\b \x01(\tR\x04sort\x12'\n" +
+	"\x0finclude_deleted\x18\t \x01(\bR\x0eincludeDeletedB\x04\n" +
+	"\x02_qB\t\n" +
+	"\a_authorB\a\n" +
+	"\x05_yearB\x06\n" +
+	"\x04_tag\"\xa7\x01\n" +
+	"\x11ListBooksResponse\x12*\n" +
+	"\x05books\x18\x01 \x03(\v2\x14.bookmanager.v1.BookR\x05books\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\"\xe5\x03\n" +
+	"\x11UpdateBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04isbn\x18\x02 \x01(\tH\x00R\x04isbn\x88\x01\x01\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1f\n" +
+	"\bsubtitle\x18\x04 \x01(\tH\x01R\bsubtitle\x88\x01\x01\x12*\n" +
+	"\x0epublished_year\x18\x05 \x01(\x05H\x02R\rpublishedYear\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_count\x18\x06 \x01(\x05H\x03R\tpageCount\x88\x01\x01\x12 \n" +
+	"\tcover_url\x18\a \x01(\tH\x04R\bcoverUrl\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12\x18\n" +
+	"\aauthors\x18\t \x03(\tR\aauthors\x12 \n" +
+	"\vforthcoming\x18\n" +
+	" \x01(\bR\vforthcoming\x12=\n" +
+	"\frelease_date\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vreleaseDate\x12\x1d\n" +
+	"\aversion\x18\f \x01(\x05H\x05R\aversion\x88\x01\x01B\a\n" +
+	"\x05_isbnB\v\n" +
+	"\t_subtitleB\x11\n" +
+	"\x0f_published_yearB\r\n" +
+	"\v_page_countB\f\n" +
+	"\n" +
+	"_cover_urlB\n" +
+	"\n" +
+	"\b_version\"#\n" +
+	"\x11DeleteBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"$\n" +
+	"\x12RestoreBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"A\n" +
+	"\x11EnrichBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\toverwrite\x18\x02 \x01(\bR\toverwrite2\x87\x04\n" +
+	"\vBookService\x12E\n" +
+	"\n" +
+	"CreateBook\x12!.bookmanager.v1.CreateBookRequest\x1a\x14.bookmanager.v1.Book\x12?\n" +
+	"\aGetBook\x12\x1e.bookmanager.v1.GetBookRequest\x1a\x14.bookmanager.v1.Book\x12P\n" +
+	"\tListBooks\x12 .bookmanager.v1.ListBooksRequest\x1a!.bookmanager.v1.ListBooksResponse\x12E\n" +
+	"\n" +
+	"UpdateBook\x12!.bookmanager.v1.UpdateBookRequest\x1a\x14.bookmanager.v1.Book\x12G\n" +
+	"\n" +
+	"DeleteBook\x12!.bookmanager.v1.DeleteBookRequest\x1a\x16.google.protobuf.Empty\x12G\n" +
+	"\vRestoreBook\x12\".bookmanager.v1.RestoreBookRequest\x1a\x14.bookmanager.v1.Book\x12E\n" +
+	"\n" +
+	"EnrichBook\x12!.bookmanager.v1.EnrichBookRequest\x1a\x14.bookmanager.v1.BookB\x19Z\x17book-manager/api/bookpbb\x06proto3"
+
+var (
+	file_book_proto_rawDescOnce sync.Once
+	file_book_proto_rawDescData []byte
+)
+
+func file_book_proto_rawDescGZIP() []byte {
+	file_book_proto_rawDescOnce.Do(func() {
+		file_book_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_book_proto_rawDesc), len(file_book_proto_rawDesc)))
+	})
+	return file_book_proto_rawDescData
+}
+
+var file_book_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_book_proto_goTypes = []any{
+	(*Book)(nil),                  // 0: bookmanager.v1.Book
+	(*Enrichment)(nil),            // 1: bookmanager.v1.Enrichment
+	(*CreateBookRequest)(nil),     // 2: bookmanager.v1.CreateBookRequest
+	(*GetBookRequest)(nil),        // 3: bookmanager.v1.GetBookRequest
+	(*ListBooksRequest)(nil),      // 4: bookmanager.v1.ListBooksRequest
+	(*ListBooksResponse)(nil),     // 5: bookmanager.v1.ListBooksResponse
+	(*UpdateBookRequest)(nil),     // 6: bookmanager.v1.UpdateBookRequest
+	(*DeleteBookRequest)(nil),     // 7: bookmanager.v1.DeleteBookRequest
+	(*RestoreBookRequest)(nil),    // 8: bookmanager.v1.RestoreBookRequest
+	(*EnrichBookRequest)(nil),     // 9: bookmanager.v1.EnrichBookRequest
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 11: google.protobuf.Empty
+}
+var file_book_proto_depIdxs = []int32{
+	1,  // 0: bookmanager.v1.Book.enrichment:type_name -> bookmanager.v1.Enrichment
+	10, // 1: bookmanager.v1.Book.release_date:type_name -> google.protobuf.Timestamp
+	10, // 2: bookmanager.v1.Book.created_at:type_name -> google.protobuf.Timestamp
+	10, // 3: bookmanager.v1.Book.updated_at:type_name -> google.protobuf.Timestamp
+	10, // 4: bookmanager.v1.Book.deleted_at:type_name -> google.protobuf.Timestamp
+	10, // 5: bookmanager.v1.CreateBookRequest.release_date:type_name -> google.protobuf.Timestamp
+	0,  // 6: bookmanager.v1.ListBooksResponse.books:type_name -> bookmanager.v1.Book
+	10, // 7: bookmanager.v1.UpdateBookRequest.release_date:type_name -> google.protobuf.Timestamp
+	2,  // 8: bookmanager.v1.BookService.CreateBook:input_type -> bookmanager.v1.CreateBookRequest
+	3,  // 9: bookmanager.v1.BookService.GetBook:input_type -> bookmanager.v1.GetBookRequest
+	4,  // 10: bookmanager.v1.BookService.ListBooks:input_type -> bookmanager.v1.ListBooksRequest
+	6,  // 11: bookmanager.v1.BookService.UpdateBook:input_type -> bookmanager.v1.UpdateBookRequest
+	7,  // 12: bookmanager.v1.BookService.DeleteBook:input_type -> bookmanager.v1.DeleteBookRequest
+	8,  // 13: bookmanager.v1.BookService.RestoreBook:input_type -> bookmanager.v1.RestoreBookRequest
+	9,  // 14: bookmanager.v1.BookService.EnrichBook:input_type -> bookmanager.v1.EnrichBookRequest
+	0,  // 15: bookmanager.v1.BookService.CreateBook:output_type -> bookmanager.v1.Book
+	0,  // 16: bookmanager.v1.BookService.GetBook:output_type -> bookmanager.v1.Book
+	5,  // 17: bookmanager.v1.BookService.ListBooks:output_type -> bookmanager.v1.ListBooksResponse
+	0,  // 18: bookmanager.v1.BookService.UpdateBook:output_type -> bookmanager.v1.Book
+	11, // 19: bookmanager.v1.BookService.DeleteBook:output_type -> google.protobuf.Empty
+	0,  // 20: bookmanager.v1.BookService.RestoreBook:output_type -> bookmanager.v1.Book
+	0,  // 21: bookmanager.v1.BookService.EnrichBook:output_type -> bookmanager.v1.Book
+	15, // [15:22] is the sub-list for method output_type
+	8,  // [8:15] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_book_proto_init() }
+func file_book_proto_init() {
+	if File_book_proto != nil {
+		return
+	}
+	file_book_proto_msgTypes[0].OneofWrappers = []any{}
+	file_book_proto_msgTypes[2].OneofWrappers = []any{}
+	file_book_proto_msgTypes[4].OneofWrappers = []any{}
+	file_book_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_book_proto_rawDesc), len(file_book_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_book_proto_goTypes,
+		DependencyIndexes: file_book_proto_depIdxs,
+		MessageInfos:      file_book_proto_msgTypes,
+	}.Build()
+	File_book_proto = out.File
+	file_book_proto_goTypes = nil
+	file_book_proto_depIdxs = nil
+}
@@ -13,24 +13,555 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Query the audit log
+	// (GET /api/v1/admin/audit)
+	QueryAuditLog(w http.ResponseWriter, r *http.Request, params QueryAuditLogParams)
+	// List auto-tag rules
+	// (GET /api/v1/admin/autotag-rules)
+	ListAutoTagRules(w http.ResponseWriter, r *http.Request)
+	// Create an auto-tag rule
+	// (POST /api/v1/admin/autotag-rules)
+	CreateAutoTagRule(w http.ResponseWriter, r *http.Request)
+	// Apply the auto-tag rules to all existing books
+	// (POST /api/v1/admin/autotag-rules/backfill)
+	BackfillAutoTags(w http.ResponseWriter, r *http.Request)
+	// Delete an auto-tag rule
+	// (DELETE /api/v1/admin/autotag-rules/{id})
+	DeleteAutoTagRule(w http.ResponseWriter, r *http.Request, id RuleId)
+	// Permanently remove books from the trash
+	// (POST /api/v1/admin/books/purge)
+	PurgeBooks(w http.ResponseWriter, r *http.Request, params PurgeBooksParams)
+	// State of the circuit breakers around enrichment providers
+	// (GET /api/v1/admin/circuit-breakers)
+	ListCircuitBreakers(w http.ResponseWriter, r *http.Request)
+	// Progress of the current or latest cover prefetch
+	// (GET /api/v1/admin/covers/prefetch)
+	GetCoverPrefetch(w http.ResponseWriter, r *http.Request)
+	// Download and cache every remote cover
+	// (POST /api/v1/admin/covers/prefetch)
+	StartCoverPrefetch(w http.ResponseWriter, r *http.Request)
+	// List dead letters
+	// (GET /api/v1/admin/deadletters)
+	ListDeadLetters(w http.ResponseWriter, r *http.Request)
+	// Replay every dead letter
+	// (POST /api/v1/admin/deadletters/replay)
+	ReplayDeadLetters(w http.ResponseWriter, r *http.Request)
+	// Discard a dead letter without delivering it
+	// (DELETE /api/v1/admin/deadletters/{id})
+	DeleteDeadLetter(w http.ResponseWriter, r *http.Request, id DeadLetterId)
+	// Replay a dead letter
+	// (POST /api/v1/admin/deadletters/{id}/replay)
+	ReplayDeadLetter(w http.ResponseWriter, r *http.Request, id DeadLetterId)
+	// List overdue escalation policies
+	// (GET /api/v1/admin/escalation-policies)
+	ListEscalationPolicies(w http.ResponseWriter, r *http.Request)
+	// Create an overdue escalation policy
+	// (POST /api/v1/admin/escalation-policies)
+	CreateEscalationPolicy(w http.ResponseWriter, r *http.Request)
+	// Apply the escalation policies now
+	// (POST /api/v1/admin/escalation-policies/run)
+	RunEscalations(w http.ResponseWriter, r *http.Request)
+	// Delete an overdue escalation policy
+	// (DELETE /api/v1/admin/escalation-policies/{policyId})
+	DeleteEscalationPolicy(w http.ResponseWriter, r *http.Request, policyId PolicyId)
+	// Audit trail of an escalation policy
+	// (GET /api/v1/admin/escalation-policies/{policyId}/runs)
+	ListEscalationRuns(w http.ResponseWriter, r *http.Request, policyId PolicyId)
+	// List shelf sync reports
+	// (GET /api/v1/admin/shelf-syncs)
+	ListShelfSyncs(w http.ResponseWriter, r *http.Request, params ListShelfSyncsParams)
+	// Sync the shelf feeds now
+	// (POST /api/v1/admin/shelf-syncs)
+	SyncShelves(w http.ResponseWriter, r *http.Request)
+	// List authors
+	// (GET /api/v1/authors)
+	ListAuthors(w http.ResponseWriter, r *http.Request, params ListAuthorsParams)
+	// Create an author
+	// (POST /api/v1/authors)
+	CreateAuthor(w http.ResponseWriter, r *http.Request)
+	// Rename an author across all books
+	// (POST /api/v1/authors/rename)
+	RenameAuthor(w http.ResponseWriter, r *http.Request)
+	// List past author renames
+	// (GET /api/v1/authors/renames)
+	ListAuthorRenames(w http.ResponseWriter, r *http.Request)
+	// Delete an author
+	// (DELETE /api/v1/authors/{id})
+	DeleteAuthor(w http.ResponseWriter, r *http.Request, id AuthorId)
+	// Get an author by id
+	// (GET /api/v1/authors/{id})
+	GetAuthor(w http.ResponseWriter, r *http.Request, id AuthorId)
+	// Rename an author
+	// (PUT /api/v1/authors/{id})
+	UpdateAuthor(w http.ResponseWriter, r *http.Request, id AuthorId)
 	// List books
 	// (GET /api/v1/books)
 	ListBooks(w http.ResponseWriter, r *http.Request, params ListBooksParams)
 	// Create a book (optionally enrich by ISBN)
 	// (POST /api/v1/books)
 	CreateBook(w http.ResponseWriter, r *http.Request, params CreateBookParams)
+	// List books as plain text
+	// (GET /api/v1/books.txt)
+	ListBooksText(w http.ResponseWriter, r *http.Request, params ListBooksTextParams)
+	// Compare a list of ISBNs with the catalog
+	// (POST /api/v1/books/compare)
+	CompareBooks(w http.ResponseWriter, r *http.Request)
+	// Report likely duplicate books
+	// (GET /api/v1/books/duplicates)
+	ListDuplicateBooks(w http.ResponseWriter, r *http.Request, params ListDuplicateBooksParams)
+	// List confirmed non-duplicates
+	// (GET /api/v1/books/duplicates/exclusions)
+	ListDuplicateExclusions(w http.ResponseWriter, r *http.Request)
+	// Confirm books are not duplicates
+	// (POST /api/v1/books/duplicates/exclusions)
+	CreateDuplicateExclusion(w http.ResponseWriter, r *http.Request)
+	// Withdraw a non-duplicate confirmation
+	// (DELETE /api/v1/books/duplicates/exclusions/{id})
+	DeleteDuplicateExclusion(w http.ResponseWriter, r *http.Request, id ExclusionId)
+	// Export the catalog
+	// (GET /api/v1/books/export)
+	ExportBooks(w http.ResponseWriter, r *http.Request, params ExportBooksParams)
+	// Import books from CSV
+	// (POST /api/v1/books/import)
+	ImportBooks(w http.ResponseWriter, r *http.Request, params ImportBooksParams)
+	// Create a book from an Open Library search result
+	// (POST /api/v1/books/import-external)
+	ImportExternalBook(w http.ResponseWriter, r *http.Request)
+	// Import an Open Library reading log or list
+	// (POST /api/v1/books/import/openlibrary)
+	ImportReadingList(w http.ResponseWriter, r *http.Request)
+	// Extract book fields from free text
+	// (POST /api/v1/books/parse)
+	ParseBook(w http.ResponseWriter, r *http.Request)
+	// Forget the books the caller viewed
+	// (DELETE /api/v1/books/recent-views)
+	ClearRecentViews(w http.ResponseWriter, r *http.Request)
+	// Books the caller viewed last
+	// (GET /api/v1/books/recent-views)
+	ListRecentViews(w http.ResponseWriter, r *http.Request, params ListRecentViewsParams)
+	// Search books by meaning
+	// (GET /api/v1/books/semantic-search)
+	SemanticSearchBooks(w http.ResponseWriter, r *http.Request, params SemanticSearchBooksParams)
 	// Delete a book by id
 	// (DELETE /api/v1/books/{id})
-	DeleteBookById(w http.ResponseWriter, r *http.Request, id BookId)
+	DeleteBookById(w http.ResponseWriter, r *http.Request, id BookId, params DeleteBookByIdParams)
 	// Get a book by id
 	// (GET /api/v1/books/{id})
-	GetBookById(w http.ResponseWriter, r *http.Request, id BookId)
+	GetBookById(w http.ResponseWriter, r *http.Request, id BookId, params GetBookByIdParams)
+	// Partially update a book
+	// (PATCH /api/v1/books/{id})
+	PatchBook(w http.ResponseWriter, r *http.Request, id BookId, params PatchBookParams)
+	// Replace a book
+	// (PUT /api/v1/books/{id})
+	UpdateBook(w http.ResponseWriter, r *http.Request, id BookId, params UpdateBookParams)
+	// Check whether the local library can lend a book
+	// (GET /api/v1/books/{id}/availability)
+	GetBookAvailability(w http.ResponseWriter, r *http.Request, id BookId)
+	// Set how many copies of a book a branch holds
+	// (PUT /api/v1/books/{id}/copies/{branchId})
+	SetBookCopies(w http.ResponseWriter, r *http.Request, id BookId, branchId BranchId)
+	// Cover image of a book
+	// (GET /api/v1/books/{id}/cover)
+	GetBookCover(w http.ResponseWriter, r *http.Request, id BookId, params GetBookCoverParams)
+	// Upload a cover image
+	// (POST /api/v1/books/{id}/cover)
+	UploadBookCover(w http.ResponseWriter, r *http.Request, id BookId)
+	// Re-run enrichment for a stored book
+	// (POST /api/v1/books/{id}/enrich)
+	EnrichBook(w http.ResponseWriter, r *http.Request, id BookId, params EnrichBookParams)
+	// Audit history of a book
+	// (GET /api/v1/books/{id}/history)
+	GetBookHistory(w http.ResponseWriter, r *http.Request, id BookId)
+	// List the holds of a book
+	// (GET /api/v1/books/{id}/holds)
+	ListBookHolds(w http.ResponseWriter, r *http.Request, id BookId)
+	// Place a hold on a book
+	// (POST /api/v1/books/{id}/holds)
+	PlaceHold(w http.ResponseWriter, r *http.Request, id BookId)
+	// Add a physical copy of a book
+	// (POST /api/v1/books/{id}/items)
+	AddBookItem(w http.ResponseWriter, r *http.Request, id BookId)
+	// Remove a physical copy of a book
+	// (DELETE /api/v1/books/{id}/items/{barcode})
+	RemoveBookItem(w http.ResponseWriter, r *http.Request, id BookId, barcode string)
+	// Update a physical copy of a book
+	// (PATCH /api/v1/books/{id}/items/{barcode})
+	UpdateBookItem(w http.ResponseWriter, r *http.Request, id BookId, barcode string)
+	// Check out a book
+	// (POST /api/v1/books/{id}/loans)
+	CheckoutBook(w http.ResponseWriter, r *http.Request, id BookId)
+	// Price history of a watched book
+	// (GET /api/v1/books/{id}/prices)
+	GetBookPrices(w http.ResponseWriter, r *http.Request, id BookId)
+	// Restore a book from the trash
+	// (POST /api/v1/books/{id}/restore)
+	RestoreBook(w http.ResponseWriter, r *http.Request, id BookId)
+	// The caller's progress in a book
+	// (GET /api/v1/books/{id}/progress)
+	GetBookProgress(w http.ResponseWriter, r *http.Request, id BookId)
+	// Record reading progress
+	// (PUT /api/v1/books/{id}/progress)
+	UpdateBookProgress(w http.ResponseWriter, r *http.Request, id BookId)
+	// List the reviews of a book
+	// (GET /api/v1/books/{id}/reviews)
+	ListBookReviews(w http.ResponseWriter, r *http.Request, id BookId, params ListBookReviewsParams)
+	// Review a book
+	// (POST /api/v1/books/{id}/reviews)
+	CreateBookReview(w http.ResponseWriter, r *http.Request, id BookId)
+	// Delete a review
+	// (DELETE /api/v1/books/{id}/reviews/{reviewId})
+	DeleteBookReview(w http.ResponseWriter, r *http.Request, id BookId, reviewId ReviewId)
+	// Books like this one
+	// (GET /api/v1/books/{id}/similar)
+	GetSimilarBooks(w http.ResponseWriter, r *http.Request, id BookId, params GetSimilarBooksParams)
+	// Short spoken-style description of a book
+	// (GET /api/v1/books/{id}/summary)
+	GetBookSummary(w http.ResponseWriter, r *http.Request, id BookId, params GetBookSummaryParams)
+	// Replace the tags of a book
+	// (PUT /api/v1/books/{id}/tags)
+	ReplaceBookTags(w http.ResponseWriter, r *http.Request, id BookId, params ReplaceBookTagsParams)
+	// Move copies of a book between branches
+	// (POST /api/v1/books/{id}/transfers)
+	TransferBookCopies(w http.ResponseWriter, r *http.Request, id BookId)
+	// Create many books at once
+	// (POST /api/v1/books:batch)
+	CreateBooksBatch(w http.ResponseWriter, r *http.Request)
+	// List borrowers
+	// (GET /api/v1/borrowers)
+	ListBorrowers(w http.ResponseWriter, r *http.Request, params ListBorrowersParams)
+	// Register a borrower
+	// (POST /api/v1/borrowers)
+	CreateBorrower(w http.ResponseWriter, r *http.Request)
+	// Import borrowers from CSV
+	// (POST /api/v1/borrowers/import)
+	ImportBorrowers(w http.ResponseWriter, r *http.Request)
+	// Delete a borrower
+	// (DELETE /api/v1/borrowers/{borrower})
+	DeleteBorrower(w http.ResponseWriter, r *http.Request, borrower BorrowerId)
+	// Get a borrower
+	// (GET /api/v1/borrowers/{borrower})
+	GetBorrower(w http.ResponseWriter, r *http.Request, borrower BorrowerId)
+	// Replace a borrower's details
+	// (PUT /api/v1/borrowers/{borrower})
+	UpdateBorrower(w http.ResponseWriter, r *http.Request, borrower BorrowerId)
+	// Fees ledger of a borrower
+	// (GET /api/v1/borrowers/{borrower}/fees)
+	GetBorrowerFees(w http.ResponseWriter, r *http.Request, borrower BorrowerId)
+	// Record a payment or waiver
+	// (POST /api/v1/borrowers/{borrower}/fees)
+	RecordBorrowerFee(w http.ResponseWriter, r *http.Request, borrower BorrowerId)
+	// Merge a duplicate borrower into this one
+	// (POST /api/v1/borrowers/{borrower}/merge)
+	MergeBorrower(w http.ResponseWriter, r *http.Request, borrower BorrowerId)
+	// List branches
+	// (GET /api/v1/branches)
+	ListBranches(w http.ResponseWriter, r *http.Request)
+	// Create a branch
+	// (POST /api/v1/branches)
+	CreateBranch(w http.ResponseWriter, r *http.Request)
+	// Delete a branch
+	// (DELETE /api/v1/branches/{branchId})
+	DeleteBranch(w http.ResponseWriter, r *http.Request, branchId BranchId)
+	// Get a branch
+	// (GET /api/v1/branches/{branchId})
+	GetBranch(w http.ResponseWriter, r *http.Request, branchId BranchId)
+	// Update a branch
+	// (PATCH /api/v1/branches/{branchId})
+	UpdateBranch(w http.ResponseWriter, r *http.Request, branchId BranchId)
+	// Preview what enrichment finds for an ISBN
+	// (GET /api/v1/enrichment/isbn/{isbn})
+	PreviewEnrichment(w http.ResponseWriter, r *http.Request, isbn string)
+	// Cancel a hold
+	// (DELETE /api/v1/holds/{holdId})
+	CancelHold(w http.ResponseWriter, r *http.Request, holdId HoldId)
+	// Get a CSV import
+	// (GET /api/v1/imports/{importId})
+	GetImportJob(w http.ResponseWriter, r *http.Request, importId ImportId)
+	// Scan a borrower card
+	// (POST /api/v1/kiosk/card)
+	KioskScanCard(w http.ResponseWriter, r *http.Request)
+	// Confirm a kiosk checkout
+	// (POST /api/v1/kiosk/checkout)
+	KioskCheckout(w http.ResponseWriter, r *http.Request)
+	// Scan a book barcode
+	// (POST /api/v1/kiosk/scan)
+	KioskScanItem(w http.ResponseWriter, r *http.Request)
+	// Sync checkouts queued offline
+	// (POST /api/v1/kiosk/sync)
+	KioskSync(w http.ResponseWriter, r *http.Request)
+	// Print labels
+	// (POST /api/v1/labels)
+	PrintLabels(w http.ResponseWriter, r *http.Request)
+	// List label sheet layouts
+	// (GET /api/v1/labels/layouts)
+	ListLabelLayouts(w http.ResponseWriter, r *http.Request)
+	// List loans
+	// (GET /api/v1/loans)
+	ListLoans(w http.ResponseWriter, r *http.Request, params ListLoansParams)
+	// Get a loan
+	// (GET /api/v1/loans/{loanId})
+	GetLoan(w http.ResponseWriter, r *http.Request, loanId LoanId)
+	// Fine of a loan
+	// (GET /api/v1/loans/{loanId}/fine)
+	GetLoanFine(w http.ResponseWriter, r *http.Request, loanId LoanId)
+	// Return a loaned book
+	// (POST /api/v1/loans/{loanId}/return)
+	ReturnLoan(w http.ResponseWriter, r *http.Request, loanId LoanId)
+	// This document, as JSON
+	// (GET /api/v1/openapi.json)
+	GetOpenAPIDocument(w http.ResponseWriter, r *http.Request)
+	// The caller's reading stats
+	// (GET /api/v1/reading-stats)
+	GetReadingStats(w http.ResponseWriter, r *http.Request, params GetReadingStatsParams)
+	// List the JSON Schemas of the resources
+	// (GET /api/v1/schemas)
+	ListSchemas(w http.ResponseWriter, r *http.Request)
+	// Get the JSON Schema of a resource
+	// (GET /api/v1/schemas/{name})
+	GetSchema(w http.ResponseWriter, r *http.Request, name SchemaName)
+	// Search Open Library for books to import
+	// (GET /api/v1/search/external)
+	SearchExternal(w http.ResponseWriter, r *http.Request, params SearchExternalParams)
+	// The caller's shelves
+	// (GET /api/v1/shelves)
+	ListShelves(w http.ResponseWriter, r *http.Request)
+	// Create a shelf
+	// (POST /api/v1/shelves)
+	CreateShelf(w http.ResponseWriter, r *http.Request)
+	// Delete a shelf
+	// (DELETE /api/v1/shelves/{shelfId})
+	DeleteShelf(w http.ResponseWriter, r *http.Request, shelfId ShelfId)
+	// Get one of the caller's shelves
+	// (GET /api/v1/shelves/{shelfId})
+	GetShelf(w http.ResponseWriter, r *http.Request, shelfId ShelfId)
+	// Books on a shelf
+	// (GET /api/v1/shelves/{shelfId}/books)
+	ListShelfBooks(w http.ResponseWriter, r *http.Request, shelfId ShelfId, params ListShelfBooksParams)
+	// Take a book off a shelf
+	// (DELETE /api/v1/shelves/{shelfId}/books/{id})
+	UnshelveBook(w http.ResponseWriter, r *http.Request, shelfId ShelfId, id BookId)
+	// Put a book on a shelf
+	// (PUT /api/v1/shelves/{shelfId}/books/{id})
+	ShelveBook(w http.ResponseWriter, r *http.Request, shelfId ShelfId, id BookId)
+	// List stocktakes
+	// (GET /api/v1/stocktakes)
+	ListStocktakes(w http.ResponseWriter, r *http.Request)
+	// Start a stocktake
+	// (POST /api/v1/stocktakes)
+	StartStocktake(w http.ResponseWriter, r *http.Request)
+	// Get a stocktake
+	// (GET /api/v1/stocktakes/{stocktakeId})
+	GetStocktake(w http.ResponseWriter, r *http.Request, stocktakeId StocktakeId)
+	// Close a stocktake
+	// (POST /api/v1/stocktakes/{stocktakeId}/close)
+	CloseStocktake(w http.ResponseWriter, r *http.Request, stocktakeId StocktakeId)
+	// Stocktake report
+	// (GET /api/v1/stocktakes/{stocktakeId}/report)
+	GetStocktakeReport(w http.ResponseWriter, r *http.Request, stocktakeId StocktakeId)
+	// Scan barcodes
+	// (POST /api/v1/stocktakes/{stocktakeId}/scans)
+	ScanStocktake(w http.ResponseWriter, r *http.Request, stocktakeId StocktakeId)
+	// List tags with the number of books carrying each
+	// (GET /api/v1/tags)
+	ListTags(w http.ResponseWriter, r *http.Request)
+	// Merge tags into one on all books
+	// (POST /api/v1/tags/merge)
+	MergeTags(w http.ResponseWriter, r *http.Request)
+	// Rename a tag on all books
+	// (POST /api/v1/tags/rename)
+	RenameTag(w http.ResponseWriter, r *http.Request)
+	// List tags as a tree of their '/'-separated levels
+	// (GET /api/v1/tags/tree)
+	GetTagTree(w http.ResponseWriter, r *http.Request)
+	// Interactive console for this API
+	// (GET /docs)
+	GetDocs(w http.ResponseWriter, r *http.Request)
+	// Health check
+	// (GET /healthz)
+	GetHealth(w http.ResponseWriter, r *http.Request)
+	// Metrics in the Prometheus text format
+	// (GET /metrics)
+	GetMetrics(w http.ResponseWriter, r *http.Request)
+	// Swagger UI for this API
+	// (GET /swagger)
+	GetSwaggerUI(w http.ResponseWriter, r *http.Request)
+	// Version of the running server
+	// (GET /version)
+	GetVersion(w http.ResponseWriter, r *http.Request)
+	// Calendar of loan due dates
+	// (GET /feeds/loans.ics)
+	LoansFeed(w http.ResponseWriter, r *http.Request)
+	// Live catalog counters over WebSocket
+	// (GET /ws)
+	LiveCounters(w http.ResponseWriter, r *http.Request)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// Query the audit log
+// (GET /api/v1/admin/audit)
+func (_ Unimplemented) QueryAuditLog(w http.ResponseWriter, r *http.Request, params QueryAuditLogParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List auto-tag rules
+// (GET /api/v1/admin/autotag-rules)
+func (_ Unimplemented) ListAutoTagRules(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an auto-tag rule
+// (POST /api/v1/admin/autotag-rules)
+func (_ Unimplemented) CreateAutoTagRule(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Apply the auto-tag rules to all existing books
+// (POST /api/v1/admin/autotag-rules/backfill)
+func (_ Unimplemented) BackfillAutoTags(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete an auto-tag rule
+// (DELETE /api/v1/admin/autotag-rules/{id})
+func (_ Unimplemented) DeleteAutoTagRule(w http.ResponseWriter, r *http.Request, id RuleId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Permanently remove books from the trash
+// (POST /api/v1/admin/books/purge)
+func (_ Unimplemented) PurgeBooks(w http.ResponseWriter, r *http.Request, params PurgeBooksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// State of the circuit breakers around enrichment providers
+// (GET /api/v1/admin/circuit-breakers)
+func (_ Unimplemented) ListCircuitBreakers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Progress of the current or latest cover prefetch
+// (GET /api/v1/admin/covers/prefetch)
+func (_ Unimplemented) GetCoverPrefetch(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download and cache every remote cover
+// (POST /api/v1/admin/covers/prefetch)
+func (_ Unimplemented) StartCoverPrefetch(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List dead letters
+// (GET /api/v1/admin/deadletters)
+func (_ Unimplemented) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replay every dead letter
+// (POST /api/v1/admin/deadletters/replay)
+func (_ Unimplemented) ReplayDeadLetters(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Discard a dead letter without delivering it
+// (DELETE /api/v1/admin/deadletters/{id})
+func (_ Unimplemented) DeleteDeadLetter(w http.ResponseWriter, r *http.Request, id DeadLetterId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replay a dead letter
+// (POST /api/v1/admin/deadletters/{id}/replay)
+func (_ Unimplemented) ReplayDeadLetter(w http.ResponseWriter, r *http.Request, id DeadLetterId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List overdue escalation policies
+// (GET /api/v1/admin/escalation-policies)
+func (_ Unimplemented) ListEscalationPolicies(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an overdue escalation policy
+// (POST /api/v1/admin/escalation-policies)
+func (_ Unimplemented) CreateEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Apply the escalation policies now
+// (POST /api/v1/admin/escalation-policies/run)
+func (_ Unimplemented) RunEscalations(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete an overdue escalation policy
+// (DELETE /api/v1/admin/escalation-policies/{policyId})
+func (_ Unimplemented) DeleteEscalationPolicy(w http.ResponseWriter, r *http.Request, policyId PolicyId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Audit trail of an escalation policy
+// (GET /api/v1/admin/escalation-policies/{policyId}/runs)
+func (_ Unimplemented) ListEscalationRuns(w http.ResponseWriter, r *http.Request, policyId PolicyId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List shelf sync reports
+// (GET /api/v1/admin/shelf-syncs)
+func (_ Unimplemented) ListShelfSyncs(w http.ResponseWriter, r *http.Request, params ListShelfSyncsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Sync the shelf feeds now
+// (POST /api/v1/admin/shelf-syncs)
+func (_ Unimplemented) SyncShelves(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List authors
+// (GET /api/v1/authors)
+func (_ Unimplemented) ListAuthors(w http.ResponseWriter, r *http.Request, params ListAuthorsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an author
+// (POST /api/v1/authors)
+func (_ Unimplemented) CreateAuthor(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rename an author across all books
+// (POST /api/v1/authors/rename)
+func (_ Unimplemented) RenameAuthor(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List past author renames
+// (GET /api/v1/authors/renames)
+func (_ Unimplemented) ListAuthorRenames(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete an author
+// (DELETE /api/v1/authors/{id})
+func (_ Unimplemented) DeleteAuthor(w http.ResponseWriter, r *http.Request, id AuthorId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get an author by id
+// (GET /api/v1/authors/{id})
+func (_ Unimplemented) GetAuthor(w http.ResponseWriter, r *http.Request, id AuthorId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rename an author
+// (PUT /api/v1/authors/{id})
+func (_ Unimplemented) UpdateAuthor(w http.ResponseWriter, r *http.Request, id AuthorId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List books
 // (GET /api/v1/books)
 func (_ Unimplemented) ListBooks(w http.ResponseWriter, r *http.Request, params ListBooksParams) {
@@ -43,93 +574,3838 @@ func (_ Unimplemented) CreateBook(w http.ResponseWriter, r *http.Request, params
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List books as plain text
+// (GET /api/v1/books.txt)
+func (_ Unimplemented) ListBooksText(w http.ResponseWriter, r *http.Request, params ListBooksTextParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Compare a list of ISBNs with the catalog
+// (POST /api/v1/books/compare)
+func (_ Unimplemented) CompareBooks(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Report likely duplicate books
+// (GET /api/v1/books/duplicates)
+func (_ Unimplemented) ListDuplicateBooks(w http.ResponseWriter, r *http.Request, params ListDuplicateBooksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List confirmed non-duplicates
+// (GET /api/v1/books/duplicates/exclusions)
+func (_ Unimplemented) ListDuplicateExclusions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Confirm books are not duplicates
+// (POST /api/v1/books/duplicates/exclusions)
+func (_ Unimplemented) CreateDuplicateExclusion(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Withdraw a non-duplicate confirmation
+// (DELETE /api/v1/books/duplicates/exclusions/{id})
+func (_ Unimplemented) DeleteDuplicateExclusion(w http.ResponseWriter, r *http.Request, id ExclusionId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export the catalog
+// (GET /api/v1/books/export)
+func (_ Unimplemented) ExportBooks(w http.ResponseWriter, r *http.Request, params ExportBooksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Import books from CSV
+// (POST /api/v1/books/import)
+func (_ Unimplemented) ImportBooks(w http.ResponseWriter, r *http.Request, params ImportBooksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a book from an Open Library search result
+// (POST /api/v1/books/import-external)
+func (_ Unimplemented) ImportExternalBook(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Import an Open Library reading log or list
+// (POST /api/v1/books/import/openlibrary)
+func (_ Unimplemented) ImportReadingList(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Extract book fields from free text
+// (POST /api/v1/books/parse)
+func (_ Unimplemented) ParseBook(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Forget the books the caller viewed
+// (DELETE /api/v1/books/recent-views)
+func (_ Unimplemented) ClearRecentViews(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Books the caller viewed last
+// (GET /api/v1/books/recent-views)
+func (_ Unimplemented) ListRecentViews(w http.ResponseWriter, r *http.Request, params ListRecentViewsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Search books by meaning
+// (GET /api/v1/books/semantic-search)
+func (_ Unimplemented) SemanticSearchBooks(w http.ResponseWriter, r *http.Request, params SemanticSearchBooksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Delete a book by id
 // (DELETE /api/v1/books/{id})
-func (_ Unimplemented) DeleteBookById(w http.ResponseWriter, r *http.Request, id BookId) {
+func (_ Unimplemented) DeleteBookById(w http.ResponseWriter, r *http.Request, id BookId, params DeleteBookByIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a book by id
+// (GET /api/v1/books/{id})
+func (_ Unimplemented) GetBookById(w http.ResponseWriter, r *http.Request, id BookId, params GetBookByIdParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Get a book by id
-// (GET /api/v1/books/{id})
-func (_ Unimplemented) GetBookById(w http.ResponseWriter, r *http.Request, id BookId) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// Partially update a book
+// (PATCH /api/v1/books/{id})
+func (_ Unimplemented) PatchBook(w http.ResponseWriter, r *http.Request, id BookId, params PatchBookParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace a book
+// (PUT /api/v1/books/{id})
+func (_ Unimplemented) UpdateBook(w http.ResponseWriter, r *http.Request, id BookId, params UpdateBookParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Check whether the local library can lend a book
+// (GET /api/v1/books/{id}/availability)
+func (_ Unimplemented) GetBookAvailability(w http.ResponseWriter, r *http.Request, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set how many copies of a book a branch holds
+// (PUT /api/v1/books/{id}/copies/{branchId})
+func (_ Unimplemented) SetBookCopies(w http.ResponseWriter, r *http.Request, id BookId, branchId BranchId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cover image of a book
+// (GET /api/v1/books/{id}/cover)
+func (_ Unimplemented) GetBookCover(w http.ResponseWriter, r *http.Request, id BookId, params GetBookCoverParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Upload a cover image
+// (POST /api/v1/books/{id}/cover)
+func (_ Unimplemented) UploadBookCover(w http.ResponseWriter, r *http.Request, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Re-run enrichment for a stored book
+// (POST /api/v1/books/{id}/enrich)
+func (_ Unimplemented) EnrichBook(w http.ResponseWriter, r *http.Request, id BookId, params EnrichBookParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Audit history of a book
+// (GET /api/v1/books/{id}/history)
+func (_ Unimplemented) GetBookHistory(w http.ResponseWriter, r *http.Request, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the holds of a book
+// (GET /api/v1/books/{id}/holds)
+func (_ Unimplemented) ListBookHolds(w http.ResponseWriter, r *http.Request, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Place a hold on a book
+// (POST /api/v1/books/{id}/holds)
+func (_ Unimplemented) PlaceHold(w http.ResponseWriter, r *http.Request, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add a physical copy of a book
+// (POST /api/v1/books/{id}/items)
+func (_ Unimplemented) AddBookItem(w http.ResponseWriter, r *http.Request, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove a physical copy of a book
+// (DELETE /api/v1/books/{id}/items/{barcode})
+func (_ Unimplemented) RemoveBookItem(w http.ResponseWriter, r *http.Request, id BookId, barcode string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a physical copy of a book
+// (PATCH /api/v1/books/{id}/items/{barcode})
+func (_ Unimplemented) UpdateBookItem(w http.ResponseWriter, r *http.Request, id BookId, barcode string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Check out a book
+// (POST /api/v1/books/{id}/loans)
+func (_ Unimplemented) CheckoutBook(w http.ResponseWriter, r *http.Request, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Price history of a watched book
+// (GET /api/v1/books/{id}/prices)
+func (_ Unimplemented) GetBookPrices(w http.ResponseWriter, r *http.Request, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Restore a book from the trash
+// (POST /api/v1/books/{id}/restore)
+func (_ Unimplemented) RestoreBook(w http.ResponseWriter, r *http.Request, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// The caller's progress in a book
+// (GET /api/v1/books/{id}/progress)
+func (_ Unimplemented) GetBookProgress(w http.ResponseWriter, r *http.Request, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Record reading progress
+// (PUT /api/v1/books/{id}/progress)
+func (_ Unimplemented) UpdateBookProgress(w http.ResponseWriter, r *http.Request, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the reviews of a book
+// (GET /api/v1/books/{id}/reviews)
+func (_ Unimplemented) ListBookReviews(w http.ResponseWriter, r *http.Request, id BookId, params ListBookReviewsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Review a book
+// (POST /api/v1/books/{id}/reviews)
+func (_ Unimplemented) CreateBookReview(w http.ResponseWriter, r *http.Request, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a review
+// (DELETE /api/v1/books/{id}/reviews/{reviewId})
+func (_ Unimplemented) DeleteBookReview(w http.ResponseWriter, r *http.Request, id BookId, reviewId ReviewId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Books like this one
+// (GET /api/v1/books/{id}/similar)
+func (_ Unimplemented) GetSimilarBooks(w http.ResponseWriter, r *http.Request, id BookId, params GetSimilarBooksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Short spoken-style description of a book
+// (GET /api/v1/books/{id}/summary)
+func (_ Unimplemented) GetBookSummary(w http.ResponseWriter, r *http.Request, id BookId, params GetBookSummaryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace the tags of a book
+// (PUT /api/v1/books/{id}/tags)
+func (_ Unimplemented) ReplaceBookTags(w http.ResponseWriter, r *http.Request, id BookId, params ReplaceBookTagsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Move copies of a book between branches
+// (POST /api/v1/books/{id}/transfers)
+func (_ Unimplemented) TransferBookCopies(w http.ResponseWriter, r *http.Request, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create many books at once
+// (POST /api/v1/books:batch)
+func (_ Unimplemented) CreateBooksBatch(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List borrowers
+// (GET /api/v1/borrowers)
+func (_ Unimplemented) ListBorrowers(w http.ResponseWriter, r *http.Request, params ListBorrowersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Register a borrower
+// (POST /api/v1/borrowers)
+func (_ Unimplemented) CreateBorrower(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Import borrowers from CSV
+// (POST /api/v1/borrowers/import)
+func (_ Unimplemented) ImportBorrowers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a borrower
+// (DELETE /api/v1/borrowers/{borrower})
+func (_ Unimplemented) DeleteBorrower(w http.ResponseWriter, r *http.Request, borrower BorrowerId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a borrower
+// (GET /api/v1/borrowers/{borrower})
+func (_ Unimplemented) GetBorrower(w http.ResponseWriter, r *http.Request, borrower BorrowerId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace a borrower's details
+// (PUT /api/v1/borrowers/{borrower})
+func (_ Unimplemented) UpdateBorrower(w http.ResponseWriter, r *http.Request, borrower BorrowerId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Fees ledger of a borrower
+// (GET /api/v1/borrowers/{borrower}/fees)
+func (_ Unimplemented) GetBorrowerFees(w http.ResponseWriter, r *http.Request, borrower BorrowerId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Record a payment or waiver
+// (POST /api/v1/borrowers/{borrower}/fees)
+func (_ Unimplemented) RecordBorrowerFee(w http.ResponseWriter, r *http.Request, borrower BorrowerId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Merge a duplicate borrower into this one
+// (POST /api/v1/borrowers/{borrower}/merge)
+func (_ Unimplemented) MergeBorrower(w http.ResponseWriter, r *http.Request, borrower BorrowerId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List branches
+// (GET /api/v1/branches)
+func (_ Unimplemented) ListBranches(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a branch
+// (POST /api/v1/branches)
+func (_ Unimplemented) CreateBranch(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a branch
+// (DELETE /api/v1/branches/{branchId})
+func (_ Unimplemented) DeleteBranch(w http.ResponseWriter, r *http.Request, branchId BranchId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a branch
+// (GET /api/v1/branches/{branchId})
+func (_ Unimplemented) GetBranch(w http.ResponseWriter, r *http.Request, branchId BranchId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a branch
+// (PATCH /api/v1/branches/{branchId})
+func (_ Unimplemented) UpdateBranch(w http.ResponseWriter, r *http.Request, branchId BranchId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Preview what enrichment finds for an ISBN
+// (GET /api/v1/enrichment/isbn/{isbn})
+func (_ Unimplemented) PreviewEnrichment(w http.ResponseWriter, r *http.Request, isbn string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel a hold
+// (DELETE /api/v1/holds/{holdId})
+func (_ Unimplemented) CancelHold(w http.ResponseWriter, r *http.Request, holdId HoldId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a CSV import
+// (GET /api/v1/imports/{importId})
+func (_ Unimplemented) GetImportJob(w http.ResponseWriter, r *http.Request, importId ImportId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Scan a borrower card
+// (POST /api/v1/kiosk/card)
+func (_ Unimplemented) KioskScanCard(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Confirm a kiosk checkout
+// (POST /api/v1/kiosk/checkout)
+func (_ Unimplemented) KioskCheckout(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Scan a book barcode
+// (POST /api/v1/kiosk/scan)
+func (_ Unimplemented) KioskScanItem(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Sync checkouts queued offline
+// (POST /api/v1/kiosk/sync)
+func (_ Unimplemented) KioskSync(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Print labels
+// (POST /api/v1/labels)
+func (_ Unimplemented) PrintLabels(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List label sheet layouts
+// (GET /api/v1/labels/layouts)
+func (_ Unimplemented) ListLabelLayouts(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List loans
+// (GET /api/v1/loans)
+func (_ Unimplemented) ListLoans(w http.ResponseWriter, r *http.Request, params ListLoansParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a loan
+// (GET /api/v1/loans/{loanId})
+func (_ Unimplemented) GetLoan(w http.ResponseWriter, r *http.Request, loanId LoanId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Fine of a loan
+// (GET /api/v1/loans/{loanId}/fine)
+func (_ Unimplemented) GetLoanFine(w http.ResponseWriter, r *http.Request, loanId LoanId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Return a loaned book
+// (POST /api/v1/loans/{loanId}/return)
+func (_ Unimplemented) ReturnLoan(w http.ResponseWriter, r *http.Request, loanId LoanId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// This document, as JSON
+// (GET /api/v1/openapi.json)
+func (_ Unimplemented) GetOpenAPIDocument(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// The caller's reading stats
+// (GET /api/v1/reading-stats)
+func (_ Unimplemented) GetReadingStats(w http.ResponseWriter, r *http.Request, params GetReadingStatsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the JSON Schemas of the resources
+// (GET /api/v1/schemas)
+func (_ Unimplemented) ListSchemas(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the JSON Schema of a resource
+// (GET /api/v1/schemas/{name})
+func (_ Unimplemented) GetSchema(w http.ResponseWriter, r *http.Request, name SchemaName) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Search Open Library for books to import
+// (GET /api/v1/search/external)
+func (_ Unimplemented) SearchExternal(w http.ResponseWriter, r *http.Request, params SearchExternalParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// The caller's shelves
+// (GET /api/v1/shelves)
+func (_ Unimplemented) ListShelves(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a shelf
+// (POST /api/v1/shelves)
+func (_ Unimplemented) CreateShelf(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a shelf
+// (DELETE /api/v1/shelves/{shelfId})
+func (_ Unimplemented) DeleteShelf(w http.ResponseWriter, r *http.Request, shelfId ShelfId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get one of the caller's shelves
+// (GET /api/v1/shelves/{shelfId})
+func (_ Unimplemented) GetShelf(w http.ResponseWriter, r *http.Request, shelfId ShelfId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Books on a shelf
+// (GET /api/v1/shelves/{shelfId}/books)
+func (_ Unimplemented) ListShelfBooks(w http.ResponseWriter, r *http.Request, shelfId ShelfId, params ListShelfBooksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Take a book off a shelf
+// (DELETE /api/v1/shelves/{shelfId}/books/{id})
+func (_ Unimplemented) UnshelveBook(w http.ResponseWriter, r *http.Request, shelfId ShelfId, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Put a book on a shelf
+// (PUT /api/v1/shelves/{shelfId}/books/{id})
+func (_ Unimplemented) ShelveBook(w http.ResponseWriter, r *http.Request, shelfId ShelfId, id BookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List stocktakes
+// (GET /api/v1/stocktakes)
+func (_ Unimplemented) ListStocktakes(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start a stocktake
+// (POST /api/v1/stocktakes)
+func (_ Unimplemented) StartStocktake(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a stocktake
+// (GET /api/v1/stocktakes/{stocktakeId})
+func (_ Unimplemented) GetStocktake(w http.ResponseWriter, r *http.Request, stocktakeId StocktakeId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Close a stocktake
+// (POST /api/v1/stocktakes/{stocktakeId}/close)
+func (_ Unimplemented) CloseStocktake(w http.ResponseWriter, r *http.Request, stocktakeId StocktakeId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stocktake report
+// (GET /api/v1/stocktakes/{stocktakeId}/report)
+func (_ Unimplemented) GetStocktakeReport(w http.ResponseWriter, r *http.Request, stocktakeId StocktakeId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Scan barcodes
+// (POST /api/v1/stocktakes/{stocktakeId}/scans)
+func (_ Unimplemented) ScanStocktake(w http.ResponseWriter, r *http.Request, stocktakeId StocktakeId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List tags with the number of books carrying each
+// (GET /api/v1/tags)
+func (_ Unimplemented) ListTags(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Merge tags into one on all books
+// (POST /api/v1/tags/merge)
+func (_ Unimplemented) MergeTags(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rename a tag on all books
+// (POST /api/v1/tags/rename)
+func (_ Unimplemented) RenameTag(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List tags as a tree of their '/'-separated levels
+// (GET /api/v1/tags/tree)
+func (_ Unimplemented) GetTagTree(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Interactive console for this API
+// (GET /docs)
+func (_ Unimplemented) GetDocs(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Health check
+// (GET /healthz)
+func (_ Unimplemented) GetHealth(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Metrics in the Prometheus text format
+// (GET /metrics)
+func (_ Unimplemented) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Swagger UI for this API
+// (GET /swagger)
+func (_ Unimplemented) GetSwaggerUI(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Version of the running server
+// (GET /version)
+func (_ Unimplemented) GetVersion(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Calendar of loan due dates
+// (GET /feeds/loans.ics)
+func (_ Unimplemented) LoansFeed(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Live catalog counters over WebSocket
+// (GET /ws)
+func (_ Unimplemented) LiveCounters(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// QueryAuditLog operation middleware
+func (siw *ServerInterfaceWrapper) QueryAuditLog(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params QueryAuditLogParams
+
+	// ------------- Optional query parameter "book_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "book_id", r.URL.Query(), &params.BookId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "book_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", r.URL.Query(), &params.Actor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", r.URL.Query(), &params.Action)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "action", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", r.URL.Query(), &params.Until)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "until", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.QueryAuditLog(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListAutoTagRules operation middleware
+func (siw *ServerInterfaceWrapper) ListAutoTagRules(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAutoTagRules(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateAutoTagRule operation middleware
+func (siw *ServerInterfaceWrapper) CreateAutoTagRule(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAutoTagRule(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BackfillAutoTags operation middleware
+func (siw *ServerInterfaceWrapper) BackfillAutoTags(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BackfillAutoTags(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAutoTagRule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAutoTagRule(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id RuleId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAutoTagRule(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PurgeBooks operation middleware
+func (siw *ServerInterfaceWrapper) PurgeBooks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PurgeBooksParams
+
+	// ------------- Optional query parameter "deleted_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "deleted_before", r.URL.Query(), &params.DeletedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deleted_before", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PurgeBooks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListCircuitBreakers operation middleware
+func (siw *ServerInterfaceWrapper) ListCircuitBreakers(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCircuitBreakers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCoverPrefetch operation middleware
+func (siw *ServerInterfaceWrapper) GetCoverPrefetch(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCoverPrefetch(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StartCoverPrefetch operation middleware
+func (siw *ServerInterfaceWrapper) StartCoverPrefetch(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartCoverPrefetch(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListDeadLetters operation middleware
+func (siw *ServerInterfaceWrapper) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDeadLetters(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReplayDeadLetters operation middleware
+func (siw *ServerInterfaceWrapper) ReplayDeadLetters(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplayDeadLetters(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteDeadLetter operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDeadLetter(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id DeadLetterId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDeadLetter(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReplayDeadLetter operation middleware
+func (siw *ServerInterfaceWrapper) ReplayDeadLetter(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id DeadLetterId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplayDeadLetter(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListEscalationPolicies operation middleware
+func (siw *ServerInterfaceWrapper) ListEscalationPolicies(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListEscalationPolicies(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateEscalationPolicy operation middleware
+func (siw *ServerInterfaceWrapper) CreateEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateEscalationPolicy(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RunEscalations operation middleware
+func (siw *ServerInterfaceWrapper) RunEscalations(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RunEscalations(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteEscalationPolicy operation middleware
+func (siw *ServerInterfaceWrapper) DeleteEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "policyId" -------------
+	var policyId PolicyId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "policyId", chi.URLParam(r, "policyId"), &policyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "policyId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteEscalationPolicy(w, r, policyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListEscalationRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListEscalationRuns(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "policyId" -------------
+	var policyId PolicyId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "policyId", chi.URLParam(r, "policyId"), &policyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "policyId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListEscalationRuns(w, r, policyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListShelfSyncs operation middleware
+func (siw *ServerInterfaceWrapper) ListShelfSyncs(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListShelfSyncsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListShelfSyncs(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SyncShelves operation middleware
+func (siw *ServerInterfaceWrapper) SyncShelves(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SyncShelves(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListAuthors operation middleware
+func (siw *ServerInterfaceWrapper) ListAuthors(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuthorsParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page_size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page_size", r.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page_size", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAuthors(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateAuthor operation middleware
+func (siw *ServerInterfaceWrapper) CreateAuthor(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAuthor(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenameAuthor operation middleware
+func (siw *ServerInterfaceWrapper) RenameAuthor(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenameAuthor(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListAuthorRenames operation middleware
+func (siw *ServerInterfaceWrapper) ListAuthorRenames(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAuthorRenames(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAuthor operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAuthor(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id AuthorId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAuthor(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAuthor operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthor(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id AuthorId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthor(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateAuthor operation middleware
+func (siw *ServerInterfaceWrapper) UpdateAuthor(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id AuthorId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateAuthor(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListBooks operation middleware
+func (siw *ServerInterfaceWrapper) ListBooks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListBooksParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "year" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year", r.URL.Query(), &params.Year)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "year_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_from", r.URL.Query(), &params.YearFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "year_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_to", r.URL.Query(), &params.YearTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "updated_since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "updated_since", r.URL.Query(), &params.UpdatedSince)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "updated_since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_children" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_children", r.URL.Query(), &params.IncludeChildren)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_children", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "branch" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "branch", r.URL.Query(), &params.Branch)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "branch", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "barcode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "barcode", r.URL.Query(), &params.Barcode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "barcode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "available" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "available", r.URL.Query(), &params.Available)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "available", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page_size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page_size", r.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page_size", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "snapshot" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "snapshot", r.URL.Query(), &params.Snapshot)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "snapshot", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "snapshot_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "snapshot_id", r.URL.Query(), &params.SnapshotId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "snapshot_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_deleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_deleted", r.URL.Query(), &params.IncludeDeleted)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_deleted", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "count" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "count", r.URL.Query(), &params.Count)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "count", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "inline_covers" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "inline_covers", r.URL.Query(), &params.InlineCovers)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "inline_covers", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBooks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateBook operation middleware
+func (siw *ServerInterfaceWrapper) CreateBook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateBookParams
+
+	// ------------- Optional query parameter "enrich" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "enrich", r.URL.Query(), &params.Enrich)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "enrich", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "require_enrichment" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "require_enrichment", r.URL.Query(), &params.RequireEnrichment)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "require_enrichment", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "auto_correct" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "auto_correct", r.URL.Query(), &params.AutoCorrect)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "auto_correct", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBook(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListBooksText operation middleware
+func (siw *ServerInterfaceWrapper) ListBooksText(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListBooksTextParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "year" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year", r.URL.Query(), &params.Year)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "year_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_from", r.URL.Query(), &params.YearFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "year_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_to", r.URL.Query(), &params.YearTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "updated_since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "updated_since", r.URL.Query(), &params.UpdatedSince)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "updated_since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_children" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_children", r.URL.Query(), &params.IncludeChildren)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_children", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "branch" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "branch", r.URL.Query(), &params.Branch)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "branch", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "barcode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "barcode", r.URL.Query(), &params.Barcode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "barcode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "available" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "available", r.URL.Query(), &params.Available)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "available", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_deleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_deleted", r.URL.Query(), &params.IncludeDeleted)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_deleted", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tz" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tz", r.URL.Query(), &params.Tz)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tz", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBooksText(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CompareBooks operation middleware
+func (siw *ServerInterfaceWrapper) CompareBooks(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CompareBooks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListDuplicateBooks operation middleware
+func (siw *ServerInterfaceWrapper) ListDuplicateBooks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDuplicateBooksParams
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", r.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page_size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page_size", r.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page_size", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDuplicateBooks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListDuplicateExclusions operation middleware
+func (siw *ServerInterfaceWrapper) ListDuplicateExclusions(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDuplicateExclusions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateDuplicateExclusion operation middleware
+func (siw *ServerInterfaceWrapper) CreateDuplicateExclusion(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateDuplicateExclusion(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteDuplicateExclusion operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDuplicateExclusion(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ExclusionId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDuplicateExclusion(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportBooks operation middleware
+func (siw *ServerInterfaceWrapper) ExportBooks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportBooksParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "year" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year", r.URL.Query(), &params.Year)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "year_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_from", r.URL.Query(), &params.YearFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "year_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_to", r.URL.Query(), &params.YearTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "updated_since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "updated_since", r.URL.Query(), &params.UpdatedSince)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "updated_since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_children" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_children", r.URL.Query(), &params.IncludeChildren)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_children", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "branch" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "branch", r.URL.Query(), &params.Branch)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "branch", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "barcode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "barcode", r.URL.Query(), &params.Barcode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "barcode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "available" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "available", r.URL.Query(), &params.Available)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "available", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_deleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_deleted", r.URL.Query(), &params.IncludeDeleted)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_deleted", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tz" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tz", r.URL.Query(), &params.Tz)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tz", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportBooks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ImportBooks operation middleware
+func (siw *ServerInterfaceWrapper) ImportBooks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportBooksParams
+
+	// ------------- Optional query parameter "enrich" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "enrich", r.URL.Query(), &params.Enrich)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "enrich", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportBooks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ImportExternalBook operation middleware
+func (siw *ServerInterfaceWrapper) ImportExternalBook(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportExternalBook(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ImportReadingList operation middleware
+func (siw *ServerInterfaceWrapper) ImportReadingList(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportReadingList(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ParseBook operation middleware
+func (siw *ServerInterfaceWrapper) ParseBook(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ParseBook(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ClearRecentViews operation middleware
+func (siw *ServerInterfaceWrapper) ClearRecentViews(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ClearRecentViews(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListRecentViews operation middleware
+func (siw *ServerInterfaceWrapper) ListRecentViews(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListRecentViewsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRecentViews(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SemanticSearchBooks operation middleware
+func (siw *ServerInterfaceWrapper) SemanticSearchBooks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SemanticSearchBooksParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SemanticSearchBooks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteBookById operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBookById(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteBookByIdParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBookById(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBookById operation middleware
+func (siw *ServerInterfaceWrapper) GetBookById(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBookByIdParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBookById(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchBook operation middleware
+func (siw *ServerInterfaceWrapper) PatchBook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchBookParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchBook(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateBook operation middleware
+func (siw *ServerInterfaceWrapper) UpdateBook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateBookParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateBook(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBookAvailability operation middleware
+func (siw *ServerInterfaceWrapper) GetBookAvailability(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBookAvailability(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetBookCopies operation middleware
+func (siw *ServerInterfaceWrapper) SetBookCopies(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "branchId" -------------
+	var branchId BranchId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "branchId", chi.URLParam(r, "branchId"), &branchId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "branchId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetBookCopies(w, r, id, branchId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBookCover operation middleware
+func (siw *ServerInterfaceWrapper) GetBookCover(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBookCoverParams
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", r.URL.Query(), &params.Size)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "size", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBookCover(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UploadBookCover operation middleware
+func (siw *ServerInterfaceWrapper) UploadBookCover(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadBookCover(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// EnrichBook operation middleware
+func (siw *ServerInterfaceWrapper) EnrichBook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params EnrichBookParams
+
+	// ------------- Optional query parameter "overwrite" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "overwrite", r.URL.Query(), &params.Overwrite)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "overwrite", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EnrichBook(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBookHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetBookHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBookHistory(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListBookHolds operation middleware
+func (siw *ServerInterfaceWrapper) ListBookHolds(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBookHolds(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PlaceHold operation middleware
+func (siw *ServerInterfaceWrapper) PlaceHold(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PlaceHold(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddBookItem operation middleware
+func (siw *ServerInterfaceWrapper) AddBookItem(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddBookItem(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveBookItem operation middleware
+func (siw *ServerInterfaceWrapper) RemoveBookItem(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "barcode" -------------
+	var barcode string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "barcode", chi.URLParam(r, "barcode"), &barcode, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "barcode", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveBookItem(w, r, id, barcode)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateBookItem operation middleware
+func (siw *ServerInterfaceWrapper) UpdateBookItem(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "barcode" -------------
+	var barcode string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "barcode", chi.URLParam(r, "barcode"), &barcode, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "barcode", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateBookItem(w, r, id, barcode)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CheckoutBook operation middleware
+func (siw *ServerInterfaceWrapper) CheckoutBook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CheckoutBook(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBookPrices operation middleware
+func (siw *ServerInterfaceWrapper) GetBookPrices(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBookPrices(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RestoreBook operation middleware
+func (siw *ServerInterfaceWrapper) RestoreBook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreBook(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBookProgress operation middleware
+func (siw *ServerInterfaceWrapper) GetBookProgress(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBookProgress(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateBookProgress operation middleware
+func (siw *ServerInterfaceWrapper) UpdateBookProgress(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateBookProgress(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListBookReviews operation middleware
+func (siw *ServerInterfaceWrapper) ListBookReviews(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListBookReviewsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page_size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page_size", r.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page_size", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBookReviews(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateBookReview operation middleware
+func (siw *ServerInterfaceWrapper) CreateBookReview(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBookReview(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteBookReview operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBookReview(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "reviewId" -------------
+	var reviewId ReviewId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "reviewId", chi.URLParam(r, "reviewId"), &reviewId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBookReview(w, r, id, reviewId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSimilarBooks operation middleware
+func (siw *ServerInterfaceWrapper) GetSimilarBooks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSimilarBooksParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSimilarBooks(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBookSummary operation middleware
+func (siw *ServerInterfaceWrapper) GetBookSummary(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBookSummaryParams
+
+	// ------------- Optional query parameter "include_description" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_description", r.URL.Query(), &params.IncludeDescription)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_description", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBookSummary(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReplaceBookTags operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceBookTags(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReplaceBookTagsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceBookTags(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TransferBookCopies operation middleware
+func (siw *ServerInterfaceWrapper) TransferBookCopies(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id BookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TransferBookCopies(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateBooksBatch operation middleware
+func (siw *ServerInterfaceWrapper) CreateBooksBatch(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBooksBatch(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListBorrowers operation middleware
+func (siw *ServerInterfaceWrapper) ListBorrowers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListBorrowersParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page_size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page_size", r.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page_size", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBorrowers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateBorrower operation middleware
+func (siw *ServerInterfaceWrapper) CreateBorrower(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBorrower(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ImportBorrowers operation middleware
+func (siw *ServerInterfaceWrapper) ImportBorrowers(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportBorrowers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteBorrower operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBorrower(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "borrower" -------------
+	var borrower BorrowerId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "borrower", chi.URLParam(r, "borrower"), &borrower, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "borrower", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBorrower(w, r, borrower)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBorrower operation middleware
+func (siw *ServerInterfaceWrapper) GetBorrower(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "borrower" -------------
+	var borrower BorrowerId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "borrower", chi.URLParam(r, "borrower"), &borrower, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "borrower", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBorrower(w, r, borrower)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateBorrower operation middleware
+func (siw *ServerInterfaceWrapper) UpdateBorrower(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "borrower" -------------
+	var borrower BorrowerId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "borrower", chi.URLParam(r, "borrower"), &borrower, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "borrower", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateBorrower(w, r, borrower)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBorrowerFees operation middleware
+func (siw *ServerInterfaceWrapper) GetBorrowerFees(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "borrower" -------------
+	var borrower BorrowerId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "borrower", chi.URLParam(r, "borrower"), &borrower, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "borrower", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBorrowerFees(w, r, borrower)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RecordBorrowerFee operation middleware
+func (siw *ServerInterfaceWrapper) RecordBorrowerFee(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "borrower" -------------
+	var borrower BorrowerId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "borrower", chi.URLParam(r, "borrower"), &borrower, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "borrower", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RecordBorrowerFee(w, r, borrower)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MergeBorrower operation middleware
+func (siw *ServerInterfaceWrapper) MergeBorrower(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "borrower" -------------
+	var borrower BorrowerId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "borrower", chi.URLParam(r, "borrower"), &borrower, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "borrower", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MergeBorrower(w, r, borrower)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListBranches operation middleware
+func (siw *ServerInterfaceWrapper) ListBranches(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBranches(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateBranch operation middleware
+func (siw *ServerInterfaceWrapper) CreateBranch(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBranch(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteBranch operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBranch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "branchId" -------------
+	var branchId BranchId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "branchId", chi.URLParam(r, "branchId"), &branchId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "branchId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBranch(w, r, branchId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBranch operation middleware
+func (siw *ServerInterfaceWrapper) GetBranch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "branchId" -------------
+	var branchId BranchId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "branchId", chi.URLParam(r, "branchId"), &branchId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "branchId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBranch(w, r, branchId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateBranch operation middleware
+func (siw *ServerInterfaceWrapper) UpdateBranch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "branchId" -------------
+	var branchId BranchId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "branchId", chi.URLParam(r, "branchId"), &branchId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "branchId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateBranch(w, r, branchId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PreviewEnrichment operation middleware
+func (siw *ServerInterfaceWrapper) PreviewEnrichment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "isbn" -------------
+	var isbn string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "isbn", chi.URLParam(r, "isbn"), &isbn, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "isbn", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PreviewEnrichment(w, r, isbn)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelHold operation middleware
+func (siw *ServerInterfaceWrapper) CancelHold(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "holdId" -------------
+	var holdId HoldId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "holdId", chi.URLParam(r, "holdId"), &holdId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "holdId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelHold(w, r, holdId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetImportJob operation middleware
+func (siw *ServerInterfaceWrapper) GetImportJob(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "importId" -------------
+	var importId ImportId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "importId", chi.URLParam(r, "importId"), &importId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "importId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImportJob(w, r, importId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// KioskScanCard operation middleware
+func (siw *ServerInterfaceWrapper) KioskScanCard(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.KioskScanCard(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// KioskCheckout operation middleware
+func (siw *ServerInterfaceWrapper) KioskCheckout(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.KioskCheckout(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// KioskScanItem operation middleware
+func (siw *ServerInterfaceWrapper) KioskScanItem(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.KioskScanItem(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// KioskSync operation middleware
+func (siw *ServerInterfaceWrapper) KioskSync(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.KioskSync(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PrintLabels operation middleware
+func (siw *ServerInterfaceWrapper) PrintLabels(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PrintLabels(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListLabelLayouts operation middleware
+func (siw *ServerInterfaceWrapper) ListLabelLayouts(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListLabelLayouts(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListLoans operation middleware
+func (siw *ServerInterfaceWrapper) ListLoans(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListLoansParams
+
+	// ------------- Optional query parameter "borrower" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "borrower", r.URL.Query(), &params.Borrower)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "borrower", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "book_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "book_id", r.URL.Query(), &params.BookId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "book_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListLoans(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLoan operation middleware
+func (siw *ServerInterfaceWrapper) GetLoan(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "loanId" -------------
+	var loanId LoanId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "loanId", chi.URLParam(r, "loanId"), &loanId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "loanId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLoan(w, r, loanId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLoanFine operation middleware
+func (siw *ServerInterfaceWrapper) GetLoanFine(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "loanId" -------------
+	var loanId LoanId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "loanId", chi.URLParam(r, "loanId"), &loanId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "loanId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLoanFine(w, r, loanId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReturnLoan operation middleware
+func (siw *ServerInterfaceWrapper) ReturnLoan(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "loanId" -------------
+	var loanId LoanId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "loanId", chi.URLParam(r, "loanId"), &loanId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "loanId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReturnLoan(w, r, loanId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOpenAPIDocument operation middleware
+func (siw *ServerInterfaceWrapper) GetOpenAPIDocument(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOpenAPIDocument(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetReadingStats operation middleware
+func (siw *ServerInterfaceWrapper) GetReadingStats(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReadingStatsParams
+
+	// ------------- Optional query parameter "weeks" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "weeks", r.URL.Query(), &params.Weeks)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "weeks", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReadingStats(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListSchemas operation middleware
+func (siw *ServerInterfaceWrapper) ListSchemas(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSchemas(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSchema operation middleware
+func (siw *ServerInterfaceWrapper) GetSchema(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name SchemaName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSchema(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchExternal operation middleware
+func (siw *ServerInterfaceWrapper) SearchExternal(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchExternalParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchExternal(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListShelves operation middleware
+func (siw *ServerInterfaceWrapper) ListShelves(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListShelves(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateShelf operation middleware
+func (siw *ServerInterfaceWrapper) CreateShelf(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateShelf(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteShelf operation middleware
+func (siw *ServerInterfaceWrapper) DeleteShelf(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "shelfId" -------------
+	var shelfId ShelfId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "shelfId", chi.URLParam(r, "shelfId"), &shelfId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "shelfId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteShelf(w, r, shelfId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetShelf operation middleware
+func (siw *ServerInterfaceWrapper) GetShelf(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "shelfId" -------------
+	var shelfId ShelfId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "shelfId", chi.URLParam(r, "shelfId"), &shelfId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "shelfId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetShelf(w, r, shelfId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+	handler.ServeHTTP(w, r)
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
-
-// ListBooks operation middleware
-func (siw *ServerInterfaceWrapper) ListBooks(w http.ResponseWriter, r *http.Request) {
+// ListShelfBooks operation middleware
+func (siw *ServerInterfaceWrapper) ListShelfBooks(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "shelfId" -------------
+	var shelfId ShelfId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "shelfId", chi.URLParam(r, "shelfId"), &shelfId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "shelfId", Err: err})
+		return
+	}
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListBooksParams
+	var params ListShelfBooksParams
 
-	// ------------- Optional query parameter "q" -------------
+	// ------------- Optional query parameter "page" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "author" -------------
+	// ------------- Optional query parameter "page_size" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	err = runtime.BindQueryParameter("form", true, false, "page_size", r.URL.Query(), &params.PageSize)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page_size", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "year" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListShelfBooks(w, r, shelfId, params)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "year", r.URL.Query(), &params.Year)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UnshelveBook operation middleware
+func (siw *ServerInterfaceWrapper) UnshelveBook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "shelfId" -------------
+	var shelfId ShelfId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "shelfId", chi.URLParam(r, "shelfId"), &shelfId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "shelfId", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "tag" -------------
+	// ------------- Path parameter "id" -------------
+	var id BookId
 
-	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "sort" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnshelveBook(w, r, shelfId, id)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ShelveBook operation middleware
+func (siw *ServerInterfaceWrapper) ShelveBook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "shelfId" -------------
+	var shelfId ShelfId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "shelfId", chi.URLParam(r, "shelfId"), &shelfId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "shelfId", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "page" -------------
+	// ------------- Path parameter "id" -------------
+	var id BookId
 
-	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "page_size" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ShelveBook(w, r, shelfId, id)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "page_size", r.URL.Query(), &params.PageSize)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListStocktakes operation middleware
+func (siw *ServerInterfaceWrapper) ListStocktakes(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListStocktakes(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StartStocktake operation middleware
+func (siw *ServerInterfaceWrapper) StartStocktake(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartStocktake(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStocktake operation middleware
+func (siw *ServerInterfaceWrapper) GetStocktake(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "stocktakeId" -------------
+	var stocktakeId StocktakeId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "stocktakeId", chi.URLParam(r, "stocktakeId"), &stocktakeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page_size", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "stocktakeId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListBooks(w, r, params)
+		siw.Handler.GetStocktake(w, r, stocktakeId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -139,32 +4415,47 @@ func (siw *ServerInterfaceWrapper) ListBooks(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// CreateBook operation middleware
-func (siw *ServerInterfaceWrapper) CreateBook(w http.ResponseWriter, r *http.Request) {
+// CloseStocktake operation middleware
+func (siw *ServerInterfaceWrapper) CloseStocktake(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params CreateBookParams
-
-	// ------------- Optional query parameter "enrich" -------------
+	// ------------- Path parameter "stocktakeId" -------------
+	var stocktakeId StocktakeId
 
-	err = runtime.BindQueryParameter("form", true, false, "enrich", r.URL.Query(), &params.Enrich)
+	err = runtime.BindStyledParameterWithOptions("simple", "stocktakeId", chi.URLParam(r, "stocktakeId"), &stocktakeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "enrich", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "stocktakeId", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "require_enrichment" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CloseStocktake(w, r, stocktakeId)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "require_enrichment", r.URL.Query(), &params.RequireEnrichment)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStocktakeReport operation middleware
+func (siw *ServerInterfaceWrapper) GetStocktakeReport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "stocktakeId" -------------
+	var stocktakeId StocktakeId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "stocktakeId", chi.URLParam(r, "stocktakeId"), &stocktakeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "require_enrichment", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "stocktakeId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateBook(w, r, params)
+		siw.Handler.GetStocktakeReport(w, r, stocktakeId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -174,22 +4465,22 @@ func (siw *ServerInterfaceWrapper) CreateBook(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteBookById operation middleware
-func (siw *ServerInterfaceWrapper) DeleteBookById(w http.ResponseWriter, r *http.Request) {
+// ScanStocktake operation middleware
+func (siw *ServerInterfaceWrapper) ScanStocktake(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id BookId
+	// ------------- Path parameter "stocktakeId" -------------
+	var stocktakeId StocktakeId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "stocktakeId", chi.URLParam(r, "stocktakeId"), &stocktakeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "stocktakeId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteBookById(w, r, id)
+		siw.Handler.ScanStocktake(w, r, stocktakeId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -199,22 +4490,151 @@ func (siw *ServerInterfaceWrapper) DeleteBookById(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// GetBookById operation middleware
-func (siw *ServerInterfaceWrapper) GetBookById(w http.ResponseWriter, r *http.Request) {
+// ListTags operation middleware
+func (siw *ServerInterfaceWrapper) ListTags(w http.ResponseWriter, r *http.Request) {
 
-	var err error
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTags(w, r)
+	}))
 
-	// ------------- Path parameter "id" -------------
-	var id BookId
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MergeTags operation middleware
+func (siw *ServerInterfaceWrapper) MergeTags(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MergeTags(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenameTag operation middleware
+func (siw *ServerInterfaceWrapper) RenameTag(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenameTag(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTagTree operation middleware
+func (siw *ServerInterfaceWrapper) GetTagTree(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTagTree(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDocs operation middleware
+func (siw *ServerInterfaceWrapper) GetDocs(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDocs(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealth(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMetrics operation middleware
+func (siw *ServerInterfaceWrapper) GetMetrics(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMetrics(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSwaggerUI operation middleware
+func (siw *ServerInterfaceWrapper) GetSwaggerUI(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSwaggerUI(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetVersion operation middleware
+func (siw *ServerInterfaceWrapper) GetVersion(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetVersion(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LoansFeed operation middleware
+func (siw *ServerInterfaceWrapper) LoansFeed(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LoansFeed(w, r)
+	}))
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// LiveCounters operation middleware
+func (siw *ServerInterfaceWrapper) LiveCounters(w http.ResponseWriter, r *http.Request) {
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetBookById(w, r, id)
+		siw.Handler.LiveCounters(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -337,18 +4757,387 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/admin/audit", wrapper.QueryAuditLog)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/admin/autotag-rules", wrapper.ListAutoTagRules)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/autotag-rules", wrapper.CreateAutoTagRule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/autotag-rules/backfill", wrapper.BackfillAutoTags)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/admin/autotag-rules/{id}", wrapper.DeleteAutoTagRule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/books/purge", wrapper.PurgeBooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/admin/circuit-breakers", wrapper.ListCircuitBreakers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/admin/covers/prefetch", wrapper.GetCoverPrefetch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/covers/prefetch", wrapper.StartCoverPrefetch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/admin/deadletters", wrapper.ListDeadLetters)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/deadletters/replay", wrapper.ReplayDeadLetters)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/admin/deadletters/{id}", wrapper.DeleteDeadLetter)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/deadletters/{id}/replay", wrapper.ReplayDeadLetter)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/admin/escalation-policies", wrapper.ListEscalationPolicies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/escalation-policies", wrapper.CreateEscalationPolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/escalation-policies/run", wrapper.RunEscalations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/admin/escalation-policies/{policyId}", wrapper.DeleteEscalationPolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/admin/escalation-policies/{policyId}/runs", wrapper.ListEscalationRuns)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/admin/shelf-syncs", wrapper.ListShelfSyncs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/shelf-syncs", wrapper.SyncShelves)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/authors", wrapper.ListAuthors)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/authors", wrapper.CreateAuthor)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/authors/rename", wrapper.RenameAuthor)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/authors/renames", wrapper.ListAuthorRenames)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/authors/{id}", wrapper.DeleteAuthor)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/authors/{id}", wrapper.GetAuthor)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/authors/{id}", wrapper.UpdateAuthor)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/books", wrapper.ListBooks)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/v1/books", wrapper.CreateBook)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books.txt", wrapper.ListBooksText)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/compare", wrapper.CompareBooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/duplicates", wrapper.ListDuplicateBooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/duplicates/exclusions", wrapper.ListDuplicateExclusions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/duplicates/exclusions", wrapper.CreateDuplicateExclusion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/books/duplicates/exclusions/{id}", wrapper.DeleteDuplicateExclusion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/export", wrapper.ExportBooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/import", wrapper.ImportBooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/import-external", wrapper.ImportExternalBook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/import/openlibrary", wrapper.ImportReadingList)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/parse", wrapper.ParseBook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/books/recent-views", wrapper.ClearRecentViews)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/recent-views", wrapper.ListRecentViews)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/semantic-search", wrapper.SemanticSearchBooks)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/api/v1/books/{id}", wrapper.DeleteBookById)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/books/{id}", wrapper.GetBookById)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/api/v1/books/{id}", wrapper.PatchBook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/books/{id}", wrapper.UpdateBook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/{id}/availability", wrapper.GetBookAvailability)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/books/{id}/copies/{branchId}", wrapper.SetBookCopies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/{id}/cover", wrapper.GetBookCover)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/{id}/cover", wrapper.UploadBookCover)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/{id}/enrich", wrapper.EnrichBook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/{id}/history", wrapper.GetBookHistory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/{id}/holds", wrapper.ListBookHolds)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/{id}/holds", wrapper.PlaceHold)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/{id}/items", wrapper.AddBookItem)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/books/{id}/items/{barcode}", wrapper.RemoveBookItem)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/api/v1/books/{id}/items/{barcode}", wrapper.UpdateBookItem)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/{id}/loans", wrapper.CheckoutBook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/{id}/prices", wrapper.GetBookPrices)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/{id}/restore", wrapper.RestoreBook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/{id}/progress", wrapper.GetBookProgress)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/books/{id}/progress", wrapper.UpdateBookProgress)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/{id}/reviews", wrapper.ListBookReviews)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/{id}/reviews", wrapper.CreateBookReview)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/books/{id}/reviews/{reviewId}", wrapper.DeleteBookReview)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/{id}/similar", wrapper.GetSimilarBooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/books/{id}/summary", wrapper.GetBookSummary)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/books/{id}/tags", wrapper.ReplaceBookTags)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books/{id}/transfers", wrapper.TransferBookCopies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/books:batch", wrapper.CreateBooksBatch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/borrowers", wrapper.ListBorrowers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/borrowers", wrapper.CreateBorrower)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/borrowers/import", wrapper.ImportBorrowers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/borrowers/{borrower}", wrapper.DeleteBorrower)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/borrowers/{borrower}", wrapper.GetBorrower)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/borrowers/{borrower}", wrapper.UpdateBorrower)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/borrowers/{borrower}/fees", wrapper.GetBorrowerFees)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/borrowers/{borrower}/fees", wrapper.RecordBorrowerFee)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/borrowers/{borrower}/merge", wrapper.MergeBorrower)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/branches", wrapper.ListBranches)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/branches", wrapper.CreateBranch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/branches/{branchId}", wrapper.DeleteBranch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/branches/{branchId}", wrapper.GetBranch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/api/v1/branches/{branchId}", wrapper.UpdateBranch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/enrichment/isbn/{isbn}", wrapper.PreviewEnrichment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/holds/{holdId}", wrapper.CancelHold)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/imports/{importId}", wrapper.GetImportJob)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/kiosk/card", wrapper.KioskScanCard)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/kiosk/checkout", wrapper.KioskCheckout)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/kiosk/scan", wrapper.KioskScanItem)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/kiosk/sync", wrapper.KioskSync)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/labels", wrapper.PrintLabels)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/labels/layouts", wrapper.ListLabelLayouts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/loans", wrapper.ListLoans)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/loans/{loanId}", wrapper.GetLoan)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/loans/{loanId}/fine", wrapper.GetLoanFine)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/loans/{loanId}/return", wrapper.ReturnLoan)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/openapi.json", wrapper.GetOpenAPIDocument)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/reading-stats", wrapper.GetReadingStats)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/schemas", wrapper.ListSchemas)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/schemas/{name}", wrapper.GetSchema)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/search/external", wrapper.SearchExternal)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/shelves", wrapper.ListShelves)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/shelves", wrapper.CreateShelf)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/shelves/{shelfId}", wrapper.DeleteShelf)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/shelves/{shelfId}", wrapper.GetShelf)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/shelves/{shelfId}/books", wrapper.ListShelfBooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/shelves/{shelfId}/books/{id}", wrapper.UnshelveBook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/shelves/{shelfId}/books/{id}", wrapper.ShelveBook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/stocktakes", wrapper.ListStocktakes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/stocktakes", wrapper.StartStocktake)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/stocktakes/{stocktakeId}", wrapper.GetStocktake)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/stocktakes/{stocktakeId}/close", wrapper.CloseStocktake)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/stocktakes/{stocktakeId}/report", wrapper.GetStocktakeReport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/stocktakes/{stocktakeId}/scans", wrapper.ScanStocktake)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/tags", wrapper.ListTags)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/tags/merge", wrapper.MergeTags)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/tags/rename", wrapper.RenameTag)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/tags/tree", wrapper.GetTagTree)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/docs", wrapper.GetDocs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/healthz", wrapper.GetHealth)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/metrics", wrapper.GetMetrics)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/swagger", wrapper.GetSwaggerUI)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/version", wrapper.GetVersion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/feeds/loans.ics", wrapper.LoansFeed)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/ws", wrapper.LiveCounters)
+	})
 
 	return r
 }
@@ -0,0 +1,9 @@
+package api
+
+import _ "embed"
+
+// Spec is the OpenAPI document the types and the server are generated
+// from, as YAML.
+//
+//go:embed openapi.yaml
+var Spec []byte
@@ -5,141 +5,1722 @@ package api
 
 import (
 	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for AutoTagField.
+const (
+	AutoTagFieldAuthor AutoTagField = "author"
+	AutoTagFieldTag    AutoTagField = "tag"
+	AutoTagFieldTitle  AutoTagField = "title"
+)
+
+// Defines values for BatchItemStatus.
+const (
+	Created BatchItemStatus = "created"
+	Failed  BatchItemStatus = "failed"
+)
+
+// Defines values for EnrichmentMetaSource.
+const (
+	Googlebooks EnrichmentMetaSource = "googlebooks"
+	Isbndb      EnrichmentMetaSource = "isbndb"
+	Openlibrary EnrichmentMetaSource = "openlibrary"
+	Sru         EnrichmentMetaSource = "sru"
+)
+
+// Defines values for EnrichmentMetaStatus.
+const (
+	NotRequested EnrichmentMetaStatus = "not_requested"
+	Ok           EnrichmentMetaStatus = "ok"
+	Partial      EnrichmentMetaStatus = "partial"
+	Pending      EnrichmentMetaStatus = "pending"
 )
 
-// Defines values for EnrichmentMetaSource.
-const (
-	Openlibrary EnrichmentMetaSource = "openlibrary"
-)
+// Defines values for ErrorCode.
+const (
+	CONFLICT           ErrorCode = "CONFLICT"
+	FORBIDDEN          ErrorCode = "FORBIDDEN"
+	INTERNAL           ErrorCode = "INTERNAL"
+	NOTFOUND           ErrorCode = "NOT_FOUND"
+	PRECONDITIONFAILED ErrorCode = "PRECONDITION_FAILED"
+	QUOTAEXCEEDED      ErrorCode = "QUOTA_EXCEEDED"
+	RATELIMITED        ErrorCode = "RATE_LIMITED"
+	UNAUTHORIZED       ErrorCode = "UNAUTHORIZED"
+	UPSTREAM           ErrorCode = "UPSTREAM"
+	VALIDATION         ErrorCode = "VALIDATION"
+)
+
+// Defines values for EscalationAction.
+const (
+	Escalate EscalationAction = "escalate"
+	MarkLost EscalationAction = "mark_lost"
+	Remind   EscalationAction = "remind"
+)
+
+// Defines values for FeeKind.
+const (
+	Fine    FeeKind = "fine"
+	Payment FeeKind = "payment"
+	Waiver  FeeKind = "waiver"
+)
+
+// Defines values for ImportJobStatus.
+const (
+	Done    ImportJobStatus = "done"
+	Paused  ImportJobStatus = "paused"
+	Running ImportJobStatus = "running"
+)
+
+// Defines values for ItemCondition.
+const (
+	ItemConditionDamaged ItemCondition = "damaged"
+	ItemConditionFair    ItemCondition = "fair"
+	ItemConditionGood    ItemCondition = "good"
+	ItemConditionLost    ItemCondition = "lost"
+	ItemConditionMissing ItemCondition = "missing"
+	ItemConditionNew     ItemCondition = "new"
+	ItemConditionPoor    ItemCondition = "poor"
+)
+
+// Defines values for LabelRequestKind.
+const (
+	Barcode LabelRequestKind = "barcode"
+	Spine   LabelRequestKind = "spine"
+)
+
+// Defines values for ListBooksParamsInlineCovers.
+const (
+	Thumb ListBooksParamsInlineCovers = "thumb"
+)
+
+// Defines values for ListBooksParamsCount.
+const (
+	Exact ListBooksParamsCount = "exact"
+	None  ListBooksParamsCount = "none"
+)
+
+// Defines values for ListLoansParamsStatus.
+const (
+	ListLoansParamsStatusActive   ListLoansParamsStatus = "active"
+	ListLoansParamsStatusLost     ListLoansParamsStatus = "lost"
+	ListLoansParamsStatusOverdue  ListLoansParamsStatus = "overdue"
+	ListLoansParamsStatusReturned ListLoansParamsStatus = "returned"
+)
+
+// Defines values for StocktakeCopyReason.
+const (
+	OnLoan      StocktakeCopyReason = "on_loan"
+	OtherBranch StocktakeCopyReason = "other_branch"
+	Unknown     StocktakeCopyReason = "unknown"
+)
+
+// Defines values for SuggestionField.
+const (
+	Authors SuggestionField = "authors"
+	Tags    SuggestionField = "tags"
+)
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	// Action create, update, enrich, delete, restore or purge
+	Action string `json:"action"`
+
+	// Actor api-key:<id>, jwt:<subject>, anonymous, or system for background jobs
+	Actor   string        `json:"actor"`
+	At      time.Time     `json:"at"`
+	BookId  string        `json:"book_id"`
+	Changes []FieldChange `json:"changes"`
+	Id      string        `json:"id"`
+}
+
+// AuditEntryList defines model for AuditEntryList.
+type AuditEntryList struct {
+	Data []AuditEntry `json:"data"`
+}
+
+// Author defines model for Author.
+type Author struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AuthorRename defines model for AuthorRename.
+type AuthorRename struct {
+	BooksUpdated int       `json:"books_updated"`
+	From         string    `json:"from"`
+	RenamedAt    time.Time `json:"renamed_at"`
+	To           string    `json:"to"`
+}
+
+// AuthorRenameList defines model for AuthorRenameList.
+type AuthorRenameList struct {
+	Data []AuthorRename `json:"data"`
+}
+
+// AuthorRenameRequest defines model for AuthorRenameRequest.
+type AuthorRenameRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// AuthorSummary defines model for AuthorSummary.
+type AuthorSummary struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AuthorWrite defines model for AuthorWrite.
+type AuthorWrite struct {
+	Name string `json:"name"`
+}
+
+// AutoTagBackfillResult defines model for AutoTagBackfillResult.
+type AutoTagBackfillResult struct {
+	// Updated Number of books that gained at least one tag.
+	Updated int `json:"updated"`
+}
+
+// AutoTagField Book field an auto-tag rule inspects.
+type AutoTagField string
+
+// AutoTagRule defines model for AutoTagRule.
+type AutoTagRule struct {
+	AddTag    string       `json:"add_tag"`
+	Contains  string       `json:"contains"`
+	CreatedAt time.Time    `json:"created_at"`
+	Field     AutoTagField `json:"field"`
+	Id        string       `json:"id"`
+}
+
+// AutoTagRuleCreate defines model for AutoTagRuleCreate.
+type AutoTagRuleCreate struct {
+	AddTag string `json:"add_tag"`
+
+	// Contains Case-insensitive substring to look for in the field.
+	Contains string       `json:"contains"`
+	Field    AutoTagField `json:"field"`
+}
+
+// AutoTagRuleList defines model for AutoTagRuleList.
+type AutoTagRuleList struct {
+	Data []AutoTagRule `json:"data"`
+}
+
+// Availability defines model for Availability.
+type Availability struct {
+	Available *int `json:"available"`
+
+	// Borrowable A copy is on the shelf now; when copy counts are unknown, same as held.
+	Borrowable bool      `json:"borrowable"`
+	CheckedAt  time.Time `json:"checked_at"`
+	Copies     *int      `json:"copies"`
+
+	// Held The catalog lists the book.
+	Held    bool   `json:"held"`
+	Library string `json:"library"`
+}
+
+// BatchCreateItem defines model for BatchCreateItem.
+type BatchCreateItem struct {
+	AutoCorrect *bool      `json:"auto_correct,omitempty"`
+	Book        BookCreate `json:"book"`
+
+	// Enrich Same as the enrich query parameter of createBook, for this item.
+	Enrich            *bool `json:"enrich,omitempty"`
+	RequireEnrichment *bool `json:"require_enrichment,omitempty"`
+}
+
+// BatchCreateRequest defines model for BatchCreateRequest.
+type BatchCreateRequest struct {
+	Items []BatchCreateItem `json:"items"`
+}
+
+// BatchCreateResult defines model for BatchCreateResult.
+type BatchCreateResult struct {
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Results []BatchItemResult `json:"results"`
+}
+
+// BatchItemError defines model for BatchItemError.
+type BatchItemError struct {
+	Code string `json:"code"`
+
+	// Details As in ErrorResponse.
+	Details *map[string]interface{} `json:"details,omitempty"`
+	Message string                  `json:"message"`
+}
+
+// BatchItemResult defines model for BatchItemResult.
+type BatchItemResult struct {
+	Book  *Book           `json:"book,omitempty"`
+	Error *BatchItemError `json:"error,omitempty"`
+
+	// Index Position of the item in the request.
+	Index  int             `json:"index"`
+	Status BatchItemStatus `json:"status"`
+}
+
+// BatchItemStatus defines model for BatchItemStatus.
+type BatchItemStatus string
+
+// Book defines model for Book.
+type Book struct {
+	Links   *BookLinks      `json:"_links,omitempty"`
+	Authors []AuthorSummary `json:"authors"`
+
+	// Copies Copies held per branch, by branch id; absent when no branch holds any.
+	Copies *map[string]int `json:"copies,omitempty"`
+
+	// CoverThumb The small cover as a data URI; only in listings with inline_covers=thumb.
+	CoverThumb *string   `json:"cover_thumb,omitempty"`
+	CoverUrl   *string   `json:"cover_url"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// DeletedAt When the book was moved to the trash; absent for books not in it.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// Description Summary from the enrichment source that answered.
+	Description *string         `json:"description,omitempty"`
+	Enrichment  *EnrichmentMeta `json:"enrichment,omitempty"`
+
+	// Forthcoming Not released yet; see BookCreate.forthcoming.
+	Forthcoming bool   `json:"forthcoming"`
+	Id          string `json:"id"`
+
+	// Isbn ISBN-13 without dashes
+	Isbn *string `json:"isbn"`
+
+	// Items Physical copies, when the book tracks them; they make up copies.
+	Items   *[]Item  `json:"items,omitempty"`
+	Lending *Lending `json:"lending,omitempty"`
+
+	// Owner The user who created the book, when books are kept per user (-per-user-libraries).
+	Owner         *string `json:"owner,omitempty"`
+	PageCount     *int    `json:"page_count"`
+	PriceTarget   *Price  `json:"price_target,omitempty"`
+	PublishedYear *int    `json:"published_year"`
+
+	// Rating The book's reviews summed up; absent when reviews are not kept.
+	Rating      *Rating             `json:"rating,omitempty"`
+	ReleaseDate *openapi_types.Date `json:"release_date"`
+
+	// Subjects Subject headings or categories from the enrichment source.
+	Subjects    *[]string    `json:"subjects,omitempty"`
+	Subtitle    *string      `json:"subtitle"`
+	Tags        *[]string    `json:"tags,omitempty"`
+	Title       string       `json:"title"`
+	Translation *Translation `json:"translation,omitempty"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+
+	// Version Incremented on every change; send it back with an update to detect concurrent edits.
+	Version int `json:"version"`
+
+	// Warnings Spelling suggestions for submitted tags and authors; only on create responses.
+	Warnings *[]Suggestion `json:"warnings,omitempty"`
+}
+
+// BookCreate defines model for BookCreate.
+type BookCreate struct {
+	// Authors Names of authors; if enrichment is used, will be merged case-insensitively.
+	Authors  *[]string `json:"authors,omitempty"`
+	CoverUrl *string   `json:"cover_url,omitempty"`
+
+	// Forthcoming Pre-ordered or wished-for book that is not released yet. Forthcoming books with an ISBN are re-checked against the enrichment sources periodically and marked released once a source lists them with a release date that has passed.
+	Forthcoming *bool `json:"forthcoming,omitempty"`
+
+	// Isbn ISBN-10 or ISBN-13 (digits and dashes allowed). The check digit is validated and the ISBN is stored as a plain ISBN-13.
+	Isbn          *string `json:"isbn,omitempty"`
+	PageCount     *int    `json:"page_count,omitempty"`
+	PriceTarget   *Price  `json:"price_target,omitempty"`
+	PublishedYear *int    `json:"published_year,omitempty"`
+
+	// ReleaseDate Release date, if known; filled from enrichment when empty.
+	ReleaseDate *openapi_types.Date `json:"release_date,omitempty"`
+	Subtitle    *string             `json:"subtitle,omitempty"`
+
+	// Tags Tags may be nested with '/', as in programming/go. Spaces around each level are trimmed; empty levels (a leading, trailing or doubled '/') are rejected.
+	Tags  *[]string `json:"tags,omitempty"`
+	Title string    `json:"title"`
+
+	// Version On update, the version the change is based on. If the book has changed since, the update fails with 409 CONFLICT and nothing is written. Ignored on create.
+	Version *int `json:"version,omitempty"`
+}
+
+// BookLinks Navigation links, present when the server runs with -links or the request sends `Accept: application/hal+json`. cover is absent when the book has no cover and enrich when it has no ISBN; related lists the book's authors in the author registry.
+type BookLinks struct {
+	Collection Link    `json:"collection"`
+	Cover      *Link   `json:"cover,omitempty"`
+	Enrich     *Link   `json:"enrich,omitempty"`
+	Related    *[]Link `json:"related,omitempty"`
+	Self       Link    `json:"self"`
+}
+
+// BookPatch defines model for BookPatch.
+type BookPatch struct {
+	Authors     *[]string `json:"authors,omitempty"`
+	CoverUrl    *string   `json:"cover_url,omitempty"`
+	Forthcoming *bool     `json:"forthcoming,omitempty"`
+
+	// Isbn Validated and stored like BookCreate.isbn; an empty string removes the ISBN.
+	Isbn          *string             `json:"isbn,omitempty"`
+	PageCount     *int                `json:"page_count,omitempty"`
+	PriceTarget   *Price              `json:"price_target,omitempty"`
+	PublishedYear *int                `json:"published_year,omitempty"`
+	ReleaseDate   *openapi_types.Date `json:"release_date,omitempty"`
+	Subtitle      *string             `json:"subtitle,omitempty"`
+	Tags          *[]string           `json:"tags,omitempty"`
+	Title         *string             `json:"title,omitempty"`
+
+	// Version As in BookCreate.version.
+	Version *int `json:"version,omitempty"`
+}
+
+// BookSummary defines model for BookSummary.
+type BookSummary struct {
+	Id   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// BookTags defines model for BookTags.
+type BookTags struct {
+	Tags []string `json:"tags"`
+
+	// Version Expected current version; the If-Match header can be used instead.
+	Version *int `json:"version,omitempty"`
+}
+
+// Borrower defines model for Borrower.
+type Borrower struct {
+	CreatedAt  time.Time `json:"created_at"`
+	Email      *string   `json:"email,omitempty"`
+	ExternalId *string   `json:"external_id,omitempty"`
+	Id         string    `json:"id"`
+	Name       string    `json:"name"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// BorrowerMerge defines model for BorrowerMerge.
+type BorrowerMerge struct {
+	// From Id of the duplicate borrower, deleted by the merge
+	From string `json:"from"`
+}
+
+// BorrowerWrite defines model for BorrowerWrite.
+type BorrowerWrite struct {
+	Email *string `json:"email,omitempty"`
+
+	// ExternalId E.g. a library card or student number
+	ExternalId *string `json:"external_id,omitempty"`
+	Name       string  `json:"name"`
+}
+
+// Branch defines model for Branch.
+type Branch struct {
+	Address   *string   `json:"address,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+}
+
+// BranchCopies defines model for BranchCopies.
+type BranchCopies struct {
+	Copies int `json:"copies"`
+}
+
+// BranchCreate defines model for BranchCreate.
+type BranchCreate struct {
+	Address *string `json:"address,omitempty"`
+
+	// Id Short code of lowercase letters, digits and dashes, such as main or east-side.
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// BranchList defines model for BranchList.
+type BranchList struct {
+	Data []Branch `json:"data"`
+}
+
+// BranchPatch The fields to change; those left out are kept.
+type BranchPatch struct {
+	Address *string `json:"address,omitempty"`
+	Name    *string `json:"name,omitempty"`
+}
+
+// BuildInfo defines model for BuildInfo.
+type BuildInfo struct {
+	// Commit VCS revision the binary was built from
+	Commit *string `json:"commit,omitempty"`
+
+	// Date Build time, RFC 3339
+	Date      *string `json:"date,omitempty"`
+	GoVersion string  `json:"go_version"`
+
+	// Modified Built from a checkout with uncommitted changes
+	Modified *bool `json:"modified,omitempty"`
+
+	// Platform GOOS/GOARCH
+	Platform string `json:"platform"`
+
+	// Version Semantic version, or "dev" for unreleased builds
+	Version string `json:"version"`
+}
+
+// CatalogCounters defines model for CatalogCounters.
+type CatalogCounters struct {
+	// ActiveImports CSV imports in progress
+	ActiveImports int       `json:"active_imports"`
+	At            time.Time `json:"at"`
+
+	// BooksAddedToday Books created since midnight, server time
+	BooksAddedToday int `json:"books_added_today"`
+	TotalBooks      int `json:"total_books"`
+}
+
+// CatalogEvent defines model for CatalogEvent.
+type CatalogEvent struct {
+	At     time.Time `json:"at"`
+	BookId string    `json:"book_id"`
+	Price  *Price    `json:"price,omitempty"`
+	Title  *string   `json:"title,omitempty"`
+	Type   string    `json:"type"`
+}
+
+// CircuitBreaker defines model for CircuitBreaker.
+type CircuitBreaker struct {
+	ConsecutiveFailures int `json:"consecutive_failures"`
+
+	// CooldownSeconds How long it stays open before a probe
+	CooldownSeconds int `json:"cooldown_seconds"`
+
+	// Failures Failed lookups since start
+	Failures  int64   `json:"failures"`
+	LastError *string `json:"last_error"`
+	Name      string  `json:"name"`
+
+	// OpenedAt When it last opened
+	OpenedAt *time.Time `json:"opened_at"`
+
+	// Opens Times it opened since start
+	Opens int64 `json:"opens"`
+
+	// Rejected Lookups failed at once while open
+	Rejected int64 `json:"rejected"`
+
+	// State closed, open or half_open (the next lookup probes)
+	State string `json:"state"`
+
+	// Threshold Consecutive failures that open the breaker
+	Threshold int `json:"threshold"`
+}
+
+// CircuitBreakerList defines model for CircuitBreakerList.
+type CircuitBreakerList struct {
+	Data []CircuitBreaker `json:"data"`
+}
+
+// CompareMatch defines model for CompareMatch.
+type CompareMatch struct {
+	BookId string `json:"book_id"`
+
+	// Isbn The ISBN as submitted.
+	Isbn  string `json:"isbn"`
+	Title string `json:"title"`
+}
+
+// CompareRequest defines model for CompareRequest.
+type CompareRequest struct {
+	Isbns []string `json:"isbns"`
+}
+
+// CompareResult defines model for CompareResult.
+type CompareResult struct {
+	// Duplicated ISBNs that were submitted more than once, each listed once.
+	Duplicated []string       `json:"duplicated"`
+	Missing    []string       `json:"missing"`
+	Present    []CompareMatch `json:"present"`
+}
+
+// CoverPrefetch defines model for CoverPrefetch.
+type CoverPrefetch struct {
+	// Books Books with a remote cover when the run started
+	Books  int `json:"books"`
+	Cached int `json:"cached"`
+	Failed int `json:"failed"`
+
+	// FinishedAt Absent while running
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Running    bool       `json:"running"`
+
+	// StartedAt Absent before the first run
+	StartedAt *time.Time `json:"started_at,omitempty"`
+}
+
+// CoverUpload defines model for CoverUpload.
+type CoverUpload struct {
+	File openapi_types.File `json:"file"`
+}
+
+// DeadLetter defines model for DeadLetter.
+type DeadLetter struct {
+	Attempts int `json:"attempts"`
+
+	// Error Error of the last attempt
+	Error string       `json:"error"`
+	Event CatalogEvent `json:"event"`
+
+	// FailedAt Time of the last attempt
+	FailedAt time.Time `json:"failed_at"`
+	Id       string    `json:"id"`
+}
+
+// DeadLetterList defines model for DeadLetterList.
+type DeadLetterList struct {
+	Data []DeadLetter `json:"data"`
+}
+
+// DeadLetterReplayResult defines model for DeadLetterReplayResult.
+type DeadLetterReplayResult struct {
+	Delivered int `json:"delivered"`
+	Failed    int `json:"failed"`
+}
+
+// DuplicateExclusion defines model for DuplicateExclusion.
+type DuplicateExclusion struct {
+	BookIds   []string  `json:"book_ids"`
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+}
+
+// DuplicateExclusionCreate defines model for DuplicateExclusionCreate.
+type DuplicateExclusionCreate struct {
+	BookIds []string `json:"book_ids"`
+}
+
+// DuplicateExclusionList defines model for DuplicateExclusionList.
+type DuplicateExclusionList struct {
+	Data []DuplicateExclusion `json:"data"`
+}
+
+// DuplicateGroup defines model for DuplicateGroup.
+type DuplicateGroup struct {
+	Books []Book `json:"books"`
+}
+
+// DuplicateReport defines model for DuplicateReport.
+type DuplicateReport struct {
+	Data     []DuplicateGroup `json:"data"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"page_size"`
+
+	// Total Number of groups.
+	Total int `json:"total"`
+}
+
+// EnrichmentMeta defines model for EnrichmentMeta.
+type EnrichmentMeta struct {
+	Attempted    bool                  `json:"attempted"`
+	LookedUpIsbn *string               `json:"looked_up_isbn"`
+	Source       *EnrichmentMetaSource `json:"source"`
+	Status       EnrichmentMetaStatus  `json:"status"`
+}
+
+// EnrichmentMetaSource defines model for EnrichmentMeta.Source.
+type EnrichmentMetaSource string
+
+// EnrichmentMetaStatus defines model for EnrichmentMeta.Status.
+type EnrichmentMetaStatus string
+
+// EnrichmentPreview defines model for EnrichmentPreview.
+type EnrichmentPreview struct {
+	Authors     []string `json:"authors"`
+	CoverUrl    *string  `json:"cover_url,omitempty"`
+	Description *string  `json:"description,omitempty"`
+
+	// Isbn The ISBN-13 looked up, without dashes
+	Isbn          string              `json:"isbn"`
+	PageCount     *int                `json:"page_count,omitempty"`
+	PublishedYear *int                `json:"published_year,omitempty"`
+	ReleaseDate   *openapi_types.Date `json:"release_date,omitempty"`
+
+	// Source The enrichment source that answered
+	Source   string   `json:"source"`
+	Subjects []string `json:"subjects"`
+	Subtitle *string  `json:"subtitle,omitempty"`
+	Title    *string  `json:"title,omitempty"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	// Code What kind of error it is, by the status it comes with: VALIDATION 400, UNAUTHORIZED 401, FORBIDDEN and QUOTA_EXCEEDED 403, NOT_FOUND 404, CONFLICT 409, PRECONDITION_FAILED 412, RATE_LIMITED 429, INTERNAL 500 and UPSTREAM 502.
+	Code ErrorCode `json:"code"`
+
+	// Details For VALIDATION errors of a book's fields (title, isbn, published_year, page_count, price_target, tags), a map from each failing field to its reason, all reported at once; for other validation errors the failing `field` and its `reason`.
+	Details *map[string]interface{} `json:"details,omitempty"`
+
+	// Message What went wrong, for people; clients should act on code instead.
+	Message string `json:"message"`
+
+	// RequestId The request's X-Request-ID, sent by the client or made up by the server; quote it when reporting the error.
+	RequestId *string `json:"request_id,omitempty"`
+}
+
+// ErrorCode What kind of error it is, by the status it comes with: VALIDATION 400, UNAUTHORIZED 401, FORBIDDEN and QUOTA_EXCEEDED 403, NOT_FOUND 404, CONFLICT 409, PRECONDITION_FAILED 412, RATE_LIMITED 429, INTERNAL 500 and UPSTREAM 502.
+type ErrorCode string
+
+// ErrorResponse The body of every error answer, except those in JSON:API format.
+type ErrorResponse struct {
+	Error Error `json:"error"`
+}
+
+// EscalationAction defines model for EscalationAction.
+type EscalationAction string
+
+// EscalationPolicy defines model for EscalationPolicy.
+type EscalationPolicy struct {
+	Action    EscalationAction `json:"action"`
+	AfterDays int              `json:"after_days"`
+	CreatedAt time.Time        `json:"created_at"`
+	Id        string           `json:"id"`
+	Name      *string          `json:"name,omitempty"`
+}
+
+// EscalationPolicyCreate defines model for EscalationPolicyCreate.
+type EscalationPolicyCreate struct {
+	Action EscalationAction `json:"action"`
+
+	// AfterDays Days past the due date; 0 applies on the due date
+	AfterDays int     `json:"after_days"`
+	Name      *string `json:"name,omitempty"`
+}
+
+// EscalationPolicyList defines model for EscalationPolicyList.
+type EscalationPolicyList struct {
+	Data []EscalationPolicy `json:"data"`
+}
+
+// EscalationRun One application of a policy to a loan.
+type EscalationRun struct {
+	Action   EscalationAction `json:"action"`
+	At       time.Time        `json:"at"`
+	BookId   string           `json:"book_id"`
+	Borrower string           `json:"borrower"`
+	LoanId   string           `json:"loan_id"`
+	PolicyId string           `json:"policy_id"`
+}
+
+// EscalationRunList defines model for EscalationRunList.
+type EscalationRunList struct {
+	Data []EscalationRun `json:"data"`
+}
+
+// EscalationRunResult defines model for EscalationRunResult.
+type EscalationRunResult struct {
+	// Applied Policy applications made by this run
+	Applied int `json:"applied"`
+}
+
+// ExternalBook defines model for ExternalBook.
+type ExternalBook struct {
+	Authors          []string `json:"authors"`
+	CoverUrl         *string  `json:"cover_url,omitempty"`
+	FirstPublishYear *int     `json:"first_publish_year,omitempty"`
+	Isbns            []string `json:"isbns"`
+
+	// Key The Open Library work
+	Key   string `json:"key"`
+	Title string `json:"title"`
+}
+
+// ExternalBookList defines model for ExternalBookList.
+type ExternalBookList struct {
+	Data []ExternalBook `json:"data"`
+}
+
+// ExternalImportRequest defines model for ExternalImportRequest.
+type ExternalImportRequest struct {
+	// Isbn The edition to create; defaults to the preferred edition
+	Isbn *string `json:"isbn,omitempty"`
+
+	// Key The key of a search result
+	Key  string    `json:"key"`
+	Tags *[]string `json:"tags,omitempty"`
+}
+
+// FeeAccount defines model for FeeAccount.
+type FeeAccount struct {
+	Balance  Price      `json:"balance"`
+	Borrower string     `json:"borrower"`
+	Entries  []FeeEntry `json:"entries"`
+}
+
+// FeeEntry defines model for FeeEntry.
+type FeeEntry struct {
+	Amount   Price     `json:"amount"`
+	At       time.Time `json:"at"`
+	Borrower string    `json:"borrower"`
+	Id       string    `json:"id"`
+	Kind     FeeKind   `json:"kind"`
+	LoanId   *string   `json:"loan_id,omitempty"`
+	Note     *string   `json:"note,omitempty"`
+}
+
+// FeeEntryCreate defines model for FeeEntryCreate.
+type FeeEntryCreate struct {
+	// Amount In the currency of the fine policy
+	Amount float64 `json:"amount"`
+	Kind   FeeKind `json:"kind"`
+
+	// LoanId The loan a waiver forgives
+	LoanId *string `json:"loan_id,omitempty"`
+	Note   *string `json:"note,omitempty"`
+}
+
+// FeeKind Fines are charged on return; payments and waivers are credited.
+type FeeKind string
+
+// FieldChange defines model for FieldChange.
+type FieldChange struct {
+	// After Value after the change; absent when unset.
+	After *interface{} `json:"after,omitempty"`
+
+	// Before Value before the change; absent when unset.
+	Before *interface{} `json:"before,omitempty"`
+
+	// Field Name of the book field as in Book, plus enrichment_status.
+	Field string `json:"field"`
+}
+
+// Health defines model for Health.
+type Health struct {
+	Error *string `json:"error,omitempty"`
+
+	// Status "ok" or "unavailable"
+	Status string `json:"status"`
+}
+
+// Hold defines model for Hold.
+type Hold struct {
+	BookId   string `json:"book_id"`
+	Borrower string `json:"borrower"`
+	Id       string `json:"id"`
+
+	// LoanId The loan the copy was lent in, with -hold-auto-checkout; the hold has ended.
+	LoanId   *string   `json:"loan_id,omitempty"`
+	PlacedAt time.Time `json:"placed_at"`
+
+	// Position 1-based place in the book's queue
+	Position int `json:"position"`
+
+	// ReadyAt Since when a returned copy is kept for the holder.
+	ReadyAt *time.Time `json:"ready_at,omitempty"`
+}
+
+// HoldCreate defines model for HoldCreate.
+type HoldCreate struct {
+	// Borrower Id of a registered borrower
+	Borrower string `json:"borrower"`
+}
+
+// HoldList defines model for HoldList.
+type HoldList struct {
+	Data []Hold `json:"data"`
+}
+
+// ImportJob defines model for ImportJob.
+type ImportJob struct {
+	Created    int              `json:"created"`
+	Errors     []ImportRowError `json:"errors"`
+	Failed     int              `json:"failed"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty"`
+	Id         string           `json:"id"`
+
+	// Processed Rows processed so far, in order
+	Processed int `json:"processed"`
+
+	// Rows Data rows read, excluding the header
+	Rows      int       `json:"rows"`
+	StartedAt time.Time `json:"started_at"`
+
+	// Status paused while the service is down
+	Status    ImportJobStatus `json:"status"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// ImportJobStatus paused while the service is down
+type ImportJobStatus string
+
+// ImportResult defines model for ImportResult.
+type ImportResult struct {
+	Created int              `json:"created"`
+	Errors  []ImportRowError `json:"errors"`
+	Failed  int              `json:"failed"`
+
+	// Rows Data rows read, excluding the header
+	Rows int `json:"rows"`
+}
+
+// ImportRowError defines model for ImportRowError.
+type ImportRowError struct {
+	Code string  `json:"code"`
+	Isbn *string `json:"isbn,omitempty"`
+
+	// Line Line number in the CSV document; the header is line 1
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// Item A physical copy of a book.
+type Item struct {
+	AddedAt   time.Time     `json:"added_at"`
+	Barcode   string        `json:"barcode"`
+	Branch    *string       `json:"branch,omitempty"`
+	Condition ItemCondition `json:"condition"`
+	Location  *string       `json:"location,omitempty"`
+
+	// OnLoan Lent out now; absent when lending is not configured
+	OnLoan *bool `json:"on_loan,omitempty"`
+}
+
+// ItemCondition lost is set by an escalation policy and missing by a stocktake; neither can be given.
+type ItemCondition string
+
+// ItemCreate defines model for ItemCreate.
+type ItemCreate struct {
+	Barcode string `json:"barcode"`
+
+	// Branch Branch id the item is kept at
+	Branch    *string        `json:"branch,omitempty"`
+	Condition *ItemCondition `json:"condition,omitempty"`
+
+	// Location Shelf or call number
+	Location *string `json:"location,omitempty"`
+}
+
+// ItemPatch The fields to change; those left out are kept.
+type ItemPatch struct {
+	// Branch Branch id the item is kept at; empty for none
+	Branch *string `json:"branch,omitempty"`
+
+	// Condition lost is set by an escalation policy and missing by a stocktake; neither can be given.
+	Condition *ItemCondition `json:"condition,omitempty"`
+
+	// Location Shelf or call number
+	Location *string `json:"location,omitempty"`
+}
+
+// KioskCard defines model for KioskCard.
+type KioskCard struct {
+	Card string `json:"card"`
+}
+
+// KioskCheckout defines model for KioskCheckout.
+type KioskCheckout struct {
+	Barcode string `json:"barcode"`
+	Card    string `json:"card"`
+
+	// ClientId Set by the kiosk; needed for checkouts queued offline
+	ClientId *string `json:"client_id,omitempty"`
+
+	// ScannedAt When a checkout queued offline was scanned
+	ScannedAt *time.Time `json:"scanned_at,omitempty"`
+}
+
+// KioskReply defines model for KioskReply.
+type KioskReply struct {
+	Book     *Book     `json:"book,omitempty"`
+	Borrower *Borrower `json:"borrower,omitempty"`
+	ClientId *string   `json:"client_id,omitempty"`
+
+	// Code Why ok is false: VALIDATION, NOT_FOUND, CONFLICT or INTERNAL
+	Code *string `json:"code,omitempty"`
+	Loan *Loan   `json:"loan,omitempty"`
+
+	// Message What to show the borrower
+	Message string `json:"message"`
+	Ok      bool   `json:"ok"`
+}
+
+// KioskScan defines model for KioskScan.
+type KioskScan struct {
+	Barcode string `json:"barcode"`
+}
+
+// KioskSync defines model for KioskSync.
+type KioskSync struct {
+	// Checkouts Each with client_id and scanned_at.
+	Checkouts []KioskCheckout `json:"checkouts"`
+}
+
+// KioskSyncResult defines model for KioskSyncResult.
+type KioskSyncResult struct {
+	Results []KioskReply `json:"results"`
+}
+
+// LabelLayout A sheet of equal labels in a grid; lengths are in millimetres. Used instead of layout when given.
+type LabelLayout struct {
+	Columns     int     `json:"columns"`
+	Description *string `json:"description,omitempty"`
+
+	// GapXMm Space between columns.
+	GapXMm *float64 `json:"gap_x_mm,omitempty"`
+
+	// GapYMm Space between rows.
+	GapYMm        *float64 `json:"gap_y_mm,omitempty"`
+	LabelHeightMm float64  `json:"label_height_mm"`
+	LabelWidthMm  float64  `json:"label_width_mm"`
+
+	// LeftMm Page edge to the first column.
+	LeftMm       float64 `json:"left_mm"`
+	Name         *string `json:"name,omitempty"`
+	PageHeightMm float64 `json:"page_height_mm"`
+	PageWidthMm  float64 `json:"page_width_mm"`
+	Rows         int     `json:"rows"`
+
+	// TopMm Page edge to the first row.
+	TopMm float64 `json:"top_mm"`
+}
+
+// LabelLayoutList defines model for LabelLayoutList.
+type LabelLayoutList struct {
+	Data []LabelLayout `json:"data"`
+}
+
+// LabelRequest defines model for LabelRequest.
+type LabelRequest struct {
+	// Barcodes Copies that get labels, after those of book_ids.
+	Barcodes *[]string `json:"barcodes,omitempty"`
+
+	// BookIds Books whose copies get labels, in order.
+	BookIds *[]string `json:"book_ids,omitempty"`
+
+	// CustomLayout A sheet of equal labels in a grid; lengths are in millimetres. Used instead of layout when given.
+	CustomLayout *LabelLayout     `json:"custom_layout,omitempty"`
+	Kind         LabelRequestKind `json:"kind"`
+
+	// Layout Name of a layout from GET /api/v1/labels/layouts; avery-l7651 by default.
+	Layout *string `json:"layout,omitempty"`
+
+	// Skip Label positions to leave empty at the start of the first sheet, to use up a partly used one.
+	Skip *int `json:"skip,omitempty"`
+}
+
+// LabelRequestKind defines model for LabelRequest.Kind.
+type LabelRequestKind string
+
+// Lending Loan state of the book; absent when lending is not configured. Borrowers are not shown here, see /api/v1/loans.
+type Lending struct {
+	// Available Copies neither lent nor kept for a holder
+	Available  int `json:"available"`
+	CheckedOut int `json:"checked_out"`
+
+	// Copies Copies that can be lent, at least 1
+	Copies int `json:"copies"`
+
+	// Holds Borrowers in the hold queue, including those whose copy is kept
+	Holds int `json:"holds"`
+
+	// NextDueAt When the first active loan is due; absent when none is active.
+	NextDueAt *time.Time `json:"next_due_at,omitempty"`
+
+	// Overdue Active loans past their due date
+	Overdue int `json:"overdue"`
+}
+
+// Link defines model for Link.
+type Link struct {
+	Href string `json:"href"`
+
+	// Method HTTP method to use when it is not GET.
+	Method *string `json:"method,omitempty"`
+	Title  *string `json:"title,omitempty"`
+}
+
+// Loan defines model for Loan.
+type Loan struct {
+	// Barcode The item lent, for books that track items
+	Barcode      *string   `json:"barcode,omitempty"`
+	BookId       string    `json:"book_id"`
+	Borrower     string    `json:"borrower"`
+	CheckedOutAt time.Time `json:"checked_out_at"`
+	DueAt        time.Time `json:"due_at"`
+	Id           string    `json:"id"`
+
+	// LostAt Set when an escalation policy marked the loan lost
+	LostAt *time.Time `json:"lost_at,omitempty"`
+
+	// Overdue Not returned and past due
+	Overdue    bool       `json:"overdue"`
+	ReturnedAt *time.Time `json:"returned_at,omitempty"`
+}
+
+// LoanCreate defines model for LoanCreate.
+type LoanCreate struct {
+	// Borrower Id of a registered borrower
+	Borrower string `json:"borrower"`
+
+	// DueDate Last day of the loan (UTC); defaults to the server's loan period from now.
+	DueDate *openapi_types.Date `json:"due_date,omitempty"`
+}
+
+// LoanFine defines model for LoanFine.
+type LoanFine struct {
+	Amount Price `json:"amount"`
+
+	// DaysLate Days past due, counting a started day
+	DaysLate int    `json:"days_late"`
+	LoanId   string `json:"loan_id"`
+}
+
+// LoanList defines model for LoanList.
+type LoanList struct {
+	Data []Loan `json:"data"`
+}
+
+// PaginatedAuthors defines model for PaginatedAuthors.
+type PaginatedAuthors struct {
+	Data []Author `json:"data"`
+
+	// Page Requested page number; 0 for pages fetched with a cursor.
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+	Total    int `json:"total"`
+}
+
+// PaginatedBooks defines model for PaginatedBooks.
+type PaginatedBooks struct {
+	Data []Book `json:"data"`
+
+	// NextCursor Pass as cursor to get the books after this page; null on the last page.
+	NextCursor *string `json:"next_cursor"`
+
+	// Page Requested page number; 0 for pages fetched with a cursor.
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+
+	// SnapshotId Present when the page was served from a pinned snapshot.
+	SnapshotId *string `json:"snapshot_id,omitempty"`
+
+	// Total Books matching the filters; left out with count=none.
+	Total *int `json:"total,omitempty"`
+}
+
+// PaginatedBorrowers defines model for PaginatedBorrowers.
+type PaginatedBorrowers struct {
+	Data     []Borrower `json:"data"`
+	Page     int        `json:"page"`
+	PageSize int        `json:"page_size"`
+	Total    int        `json:"total"`
+}
+
+// PaginatedReviews defines model for PaginatedReviews.
+type PaginatedReviews struct {
+	Data     []Review `json:"data"`
+	Page     int      `json:"page"`
+	PageSize int      `json:"page_size"`
+	Total    int      `json:"total"`
+}
+
+// PaginatedShelfBooks defines model for PaginatedShelfBooks.
+type PaginatedShelfBooks struct {
+	Data     []ShelfBook `json:"data"`
+	Page     int         `json:"page"`
+	PageSize int         `json:"page_size"`
+	Total    int         `json:"total"`
+}
+
+// ParseRequest defines model for ParseRequest.
+type ParseRequest struct {
+	Text string `json:"text"`
+}
+
+// ParseResult defines model for ParseResult.
+type ParseResult struct {
+	Book BookCreate `json:"book"`
+
+	// Extractor Extractor that produced the fields, rules or openai.
+	Extractor string `json:"extractor"`
+}
+
+// Price defines model for Price.
+type Price struct {
+	// Amount Amount in the currency's major unit, e.g. 19.99
+	Amount float64 `json:"amount"`
+
+	// Currency ISO 4217 code, e.g. EUR
+	Currency string `json:"currency"`
+}
+
+// PriceHistory defines model for PriceHistory.
+type PriceHistory struct {
+	Data []PricePoint `json:"data"`
+}
+
+// PricePoint defines model for PricePoint.
+type PricePoint struct {
+	Price      Price     `json:"price"`
+	RecordedAt time.Time `json:"recorded_at"`
+	Source     string    `json:"source"`
+}
+
+// ProgressHistory defines model for ProgressHistory.
+type ProgressHistory struct {
+	Current ReadingProgress `json:"current"`
+
+	// History Every update, oldest first.
+	History []ReadingProgress `json:"history"`
+}
+
+// ProgressUpdate Either page or percent.
+type ProgressUpdate struct {
+	Page    *int `json:"page,omitempty"`
+	Percent *int `json:"percent,omitempty"`
+}
+
+// PurgeResult defines model for PurgeResult.
+type PurgeResult struct {
+	Purged int `json:"purged"`
+}
+
+// Rating The book's reviews summed up; absent when reviews are not kept.
+type Rating struct {
+	// Average Average stars, 1 to 5; absent when the book has no reviews.
+	Average *float64 `json:"average,omitempty"`
+
+	// Count Reviews of the book
+	Count int `json:"count"`
+}
+
+// ReadingListImportItem defines model for ReadingListImportItem.
+type ReadingListImportItem struct {
+	// BookId The created or existing book
+	BookId     *string         `json:"book_id,omitempty"`
+	EditionKey *string         `json:"edition_key,omitempty"`
+	Error      *BatchItemError `json:"error,omitempty"`
+	Isbn       *string         `json:"isbn,omitempty"`
+
+	// Status created, existing or failed
+	Status  string  `json:"status"`
+	Title   string  `json:"title"`
+	WorkKey *string `json:"work_key,omitempty"`
+}
+
+// ReadingListImportRequest Either username, optionally with shelf, or list_url.
+type ReadingListImportRequest struct {
+	ListUrl *string `json:"list_url,omitempty"`
+
+	// Shelf want-to-read, currently-reading or already-read
+	Shelf    *string `json:"shelf,omitempty"`
+	Username *string `json:"username,omitempty"`
+}
+
+// ReadingListImportResult defines model for ReadingListImportResult.
+type ReadingListImportResult struct {
+	Created int `json:"created"`
+
+	// Existing Books whose ISBN was already in the catalog
+	Existing int                     `json:"existing"`
+	Failed   int                     `json:"failed"`
+	Items    []ReadingListImportItem `json:"items"`
+}
+
+// ReadingProgress defines model for ReadingProgress.
+type ReadingProgress struct {
+	At     time.Time `json:"at"`
+	BookId string    `json:"book_id"`
+
+	// Finished At 100 percent
+	Finished bool `json:"finished"`
+
+	// Page Absent when given as a percentage of a book without page_count
+	Page *int `json:"page,omitempty"`
+
+	// Percent Absent when given as a page of a book without page_count
+	Percent *int `json:"percent,omitempty"`
+}
+
+// ReadingStats defines model for ReadingStats.
+type ReadingStats struct {
+	BooksFinishedPerYear []struct {
+		Books int `json:"books"`
+		Year  int `json:"year"`
+	} `json:"books_finished_per_year"`
+
+	// PagesPerWeek Oldest first, weeks without reading included.
+	PagesPerWeek []struct {
+		Pages int `json:"pages"`
+
+		// WeekStart Monday
+		WeekStart openapi_types.Date `json:"week_start"`
+	} `json:"pages_per_week"`
+}
+
+// RecentView defines model for RecentView.
+type RecentView struct {
+	Book     Book      `json:"book"`
+	ViewedAt time.Time `json:"viewed_at"`
+}
+
+// RecentViewList defines model for RecentViewList.
+type RecentViewList struct {
+	Data []RecentView `json:"data"`
+}
+
+// Review defines model for Review.
+type Review struct {
+	BookId    string    `json:"book_id"`
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+	Rating    int       `json:"rating"`
+
+	// Reviewer Who wrote it, e.g. jwt:alice or anonymous
+	Reviewer string  `json:"reviewer"`
+	Text     *string `json:"text,omitempty"`
+}
+
+// ReviewCreate defines model for ReviewCreate.
+type ReviewCreate struct {
+	Rating int     `json:"rating"`
+	Text   *string `json:"text,omitempty"`
+}
+
+// ScoredBook defines model for ScoredBook.
+type ScoredBook struct {
+	Book Book `json:"book"`
+
+	// Score Cosine similarity to the query, from -1 to 1.
+	Score float64 `json:"score"`
+}
+
+// ScoredBooks defines model for ScoredBooks.
+type ScoredBooks struct {
+	Data []ScoredBook `json:"data"`
+}
+
+// SchemaList defines model for SchemaList.
+type SchemaList struct {
+	Data []SchemaRef `json:"data"`
+}
+
+// SchemaRef defines model for SchemaRef.
+type SchemaRef struct {
+	// Href Where the JSON Schema is, e.g. /api/v1/schemas/Book
+	Href string `json:"href"`
+	Name string `json:"name"`
+}
+
+// Shelf defines model for Shelf.
+type Shelf struct {
+	// Books Books on the shelf, including those in the trash
+	Books int `json:"books"`
+
+	// CreatedAt Absent for reading status shelves
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Id        string     `json:"id"`
+	Name      string     `json:"name"`
+
+	// Status A reading status shelf
+	Status bool `json:"status"`
+}
+
+// ShelfBook defines model for ShelfBook.
+type ShelfBook struct {
+	AddedAt time.Time `json:"added_at"`
+	Book    Book      `json:"book"`
+}
+
+// ShelfCreate defines model for ShelfCreate.
+type ShelfCreate struct {
+	Name string `json:"name"`
+}
+
+// ShelfList defines model for ShelfList.
+type ShelfList struct {
+	Data []Shelf `json:"data"`
+}
+
+// ShelfSyncItem defines model for ShelfSyncItem.
+type ShelfSyncItem struct {
+	Authors *[]string       `json:"authors,omitempty"`
+	BookId  *string         `json:"book_id,omitempty"`
+	Error   *BatchItemError `json:"error,omitempty"`
+	Isbn    *string         `json:"isbn,omitempty"`
+
+	// Status created, tagged, existing or failed
+	Status string `json:"status"`
+	Title  string `json:"title"`
+}
+
+// ShelfSyncReport defines model for ShelfSyncReport.
+type ShelfSyncReport struct {
+	Created int             `json:"created"`
+	Error   *BatchItemError `json:"error,omitempty"`
+
+	// Existing Books already tagged or in the trash
+	Existing   int             `json:"existing"`
+	Failed     int             `json:"failed"`
+	FinishedAt time.Time       `json:"finished_at"`
+	Id         string          `json:"id"`
+	Items      []ShelfSyncItem `json:"items"`
+
+	// Shelf Missing when the feed could not be read
+	Shelf *string `json:"shelf,omitempty"`
+
+	// Source Feed URL without its query, which may carry a key
+	Source    string    `json:"source"`
+	StartedAt time.Time `json:"started_at"`
+
+	// Tagged Books already in the catalog that were tagged with the shelf
+	Tagged int `json:"tagged"`
+}
+
+// ShelfSyncReportList defines model for ShelfSyncReportList.
+type ShelfSyncReportList struct {
+	Data []ShelfSyncReport `json:"data"`
+}
+
+// Stocktake defines model for Stocktake.
+type Stocktake struct {
+	Branch   *string          `json:"branch,omitempty"`
+	ClosedAt *time.Time       `json:"closed_at,omitempty"`
+	ClosedBy *string          `json:"closed_by,omitempty"`
+	Id       string           `json:"id"`
+	Report   *StocktakeReport `json:"report,omitempty"`
+
+	// Scanned Distinct barcodes scanned
+	Scanned   int       `json:"scanned"`
+	StartedAt time.Time `json:"started_at"`
+	StartedBy string    `json:"started_by"`
+}
+
+// StocktakeClose defines model for StocktakeClose.
+type StocktakeClose struct {
+	MarkMissing *bool `json:"mark_missing,omitempty"`
+}
+
+// StocktakeCopy defines model for StocktakeCopy.
+type StocktakeCopy struct {
+	Barcode string `json:"barcode"`
+
+	// BookId Absent for an unknown barcode
+	BookId   *string `json:"book_id,omitempty"`
+	Branch   *string `json:"branch,omitempty"`
+	Location *string `json:"location,omitempty"`
+
+	// Reason Why an unexpected copy is; unknown when no copy has the barcode.
+	Reason *StocktakeCopyReason `json:"reason,omitempty"`
+	Title  *string              `json:"title,omitempty"`
+}
 
-// Defines values for EnrichmentMetaStatus.
-const (
-	NotRequested EnrichmentMetaStatus = "not_requested"
-	Ok           EnrichmentMetaStatus = "ok"
-	Partial      EnrichmentMetaStatus = "partial"
-)
+// StocktakeCopyReason Why an unexpected copy is; unknown when no copy has the barcode.
+type StocktakeCopyReason string
 
-// Defines values for ErrorResponseErrorCode.
-const (
-	CONFLICT   ErrorResponseErrorCode = "CONFLICT"
-	NOTFOUND   ErrorResponseErrorCode = "NOT_FOUND"
-	UPSTREAM   ErrorResponseErrorCode = "UPSTREAM"
-	VALIDATION ErrorResponseErrorCode = "VALIDATION"
-)
+// StocktakeCreate defines model for StocktakeCreate.
+type StocktakeCreate struct {
+	// Branch Branch id; every copy when absent
+	Branch *string `json:"branch,omitempty"`
+}
 
-// AuthorSummary defines model for AuthorSummary.
-type AuthorSummary struct {
-	Id   string `json:"id"`
-	Name string `json:"name"`
+// StocktakeList defines model for StocktakeList.
+type StocktakeList struct {
+	Data []Stocktake `json:"data"`
 }
 
-// Book defines model for Book.
-type Book struct {
-	Authors       []AuthorSummary `json:"authors"`
-	CoverUrl      *string         `json:"cover_url"`
-	CreatedAt     time.Time       `json:"created_at"`
-	Enrichment    *EnrichmentMeta `json:"enrichment,omitempty"`
-	Id            string          `json:"id"`
-	Isbn          *string         `json:"isbn"`
-	PageCount     *int            `json:"page_count"`
-	PublishedYear *int            `json:"published_year"`
-	Subtitle      *string         `json:"subtitle"`
-	Tags          *[]string       `json:"tags,omitempty"`
-	Title         string          `json:"title"`
-	UpdatedAt     time.Time       `json:"updated_at"`
+// StocktakeReport defines model for StocktakeReport.
+type StocktakeReport struct {
+	// Expected Copies expected on the shelves
+	Expected int `json:"expected"`
+
+	// Found Missing copies closing set back to good
+	Found int `json:"found"`
+
+	// MarkedMissing Copies closing set to missing
+	MarkedMissing int             `json:"marked_missing"`
+	Missing       []StocktakeCopy `json:"missing"`
+	Seen          []StocktakeCopy `json:"seen"`
+	Unexpected    []StocktakeCopy `json:"unexpected"`
 }
 
-// BookCreate defines model for BookCreate.
-type BookCreate struct {
-	// Authors Names of authors; if enrichment is used, will be merged case-insensitively.
-	Authors  *[]string `json:"authors,omitempty"`
-	CoverUrl *string   `json:"cover_url,omitempty"`
+// StocktakeScanResult defines model for StocktakeScanResult.
+type StocktakeScanResult struct {
+	// Added Barcodes not scanned before
+	Added  int              `json:"added"`
+	Errors []ImportRowError `json:"errors"`
+	Failed int              `json:"failed"`
 
-	// Isbn ISBN-10 or ISBN-13 (digits and dashes allowed).
-	Isbn          *string   `json:"isbn,omitempty"`
-	PageCount     *int      `json:"page_count,omitempty"`
-	PublishedYear *int      `json:"published_year,omitempty"`
-	Subtitle      *string   `json:"subtitle,omitempty"`
-	Tags          *[]string `json:"tags,omitempty"`
-	Title         string    `json:"title"`
+	// Lines Barcodes read, blank lines excluded
+	Lines int `json:"lines"`
 }
 
-// EnrichmentMeta defines model for EnrichmentMeta.
-type EnrichmentMeta struct {
-	Attempted    bool                  `json:"attempted"`
-	LookedUpIsbn *string               `json:"looked_up_isbn"`
-	Source       *EnrichmentMetaSource `json:"source"`
-	Status       EnrichmentMetaStatus  `json:"status"`
+// Suggestion defines model for Suggestion.
+type Suggestion struct {
+	// Applied True when auto_correct replaced the submitted value.
+	Applied bool            `json:"applied"`
+	Field   SuggestionField `json:"field"`
+	Message string          `json:"message"`
+
+	// Suggestion Existing value within edit distance 1-2.
+	Suggestion string `json:"suggestion"`
+
+	// Value Value as submitted.
+	Value string `json:"value"`
 }
 
-// EnrichmentMetaSource defines model for EnrichmentMeta.Source.
-type EnrichmentMetaSource string
+// SuggestionField defines model for Suggestion.Field.
+type SuggestionField string
 
-// EnrichmentMetaStatus defines model for EnrichmentMeta.Status.
-type EnrichmentMetaStatus string
+// TagCount defines model for TagCount.
+type TagCount struct {
+	Books int    `json:"books"`
+	Tag   string `json:"tag"`
+}
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
-	Error struct {
-		Code    ErrorResponseErrorCode  `json:"code"`
-		Details *map[string]interface{} `json:"details,omitempty"`
-		Message string                  `json:"message"`
-	} `json:"error"`
+// TagList defines model for TagList.
+type TagList struct {
+	Data []TagCount `json:"data"`
 }
 
-// ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
-type ErrorResponseErrorCode string
+// TagMergeRequest defines model for TagMergeRequest.
+type TagMergeRequest struct {
+	Sources []string `json:"sources"`
+	Target  string   `json:"target"`
+}
 
-// PaginatedBooks defines model for PaginatedBooks.
-type PaginatedBooks struct {
-	Data     []Book `json:"data"`
-	Page     int    `json:"page"`
-	PageSize int    `json:"page_size"`
-	Total    int    `json:"total"`
+// TagNode defines model for TagNode.
+type TagNode struct {
+	// Books Books carrying exactly this tag
+	Books    int       `json:"books"`
+	Children []TagNode `json:"children"`
+
+	// Name The last level of the tag, e.g. go
+	Name string `json:"name"`
+
+	// Tag The full tag, e.g. programming/go
+	Tag string `json:"tag"`
+
+	// TotalBooks Books carrying this tag or one nested under it, each counted once
+	TotalBooks int `json:"total_books"`
 }
 
+// TagRename defines model for TagRename.
+type TagRename struct {
+	BooksUpdated int       `json:"books_updated"`
+	From         []string  `json:"from"`
+	RenamedAt    time.Time `json:"renamed_at"`
+	To           string    `json:"to"`
+}
+
+// TagRenameRequest defines model for TagRenameRequest.
+type TagRenameRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// TagTree defines model for TagTree.
+type TagTree struct {
+	Data []TagNode `json:"data"`
+}
+
+// TransferRequest defines model for TransferRequest.
+type TransferRequest struct {
+	Copies int `json:"copies"`
+
+	// From Branch id the copies leave
+	From string `json:"from"`
+
+	// To Branch id the copies go to
+	To string `json:"to"`
+}
+
+// Translation The description and subjects in the language the server translates enriched metadata into (-translate-to). Absent when translation is off or has not succeeded yet.
+type Translation struct {
+	Description *string `json:"description,omitempty"`
+	Language    string  `json:"language"`
+
+	// Subjects Parallel to the book's subjects.
+	Subjects *[]string `json:"subjects,omitempty"`
+}
+
+// AuthorId defines model for AuthorId.
+type AuthorId = string
+
 // AuthorName defines model for AuthorName.
 type AuthorName = string
 
+// AuthorSearch defines model for AuthorSearch.
+type AuthorSearch = string
+
+// AutoCorrect defines model for AutoCorrect.
+type AutoCorrect = bool
+
+// AvailableFilter defines model for AvailableFilter.
+type AvailableFilter = bool
+
+// BarcodeFilter defines model for BarcodeFilter.
+type BarcodeFilter = string
+
 // BookId defines model for BookId.
 type BookId = string
 
+// BorrowerId defines model for BorrowerId.
+type BorrowerId = string
+
+// BranchFilter defines model for BranchFilter.
+type BranchFilter = string
+
+// BranchId defines model for BranchId.
+type BranchId = string
+
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// DeadLetterId defines model for DeadLetterId.
+type DeadLetterId = string
+
 // Enrich defines model for Enrich.
 type Enrich = bool
 
+// ExclusionId defines model for ExclusionId.
+type ExclusionId = string
+
+// HoldId defines model for HoldId.
+type HoldId = string
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
+// ImportId defines model for ImportId.
+type ImportId = string
+
+// IncludeChildren defines model for IncludeChildren.
+type IncludeChildren = bool
+
+// IncludeDeleted defines model for IncludeDeleted.
+type IncludeDeleted = bool
+
+// LoanId defines model for LoanId.
+type LoanId = string
+
 // Page defines model for Page.
 type Page = int
 
 // PageSize defines model for PageSize.
 type PageSize = int
 
+// PolicyId defines model for PolicyId.
+type PolicyId = string
+
 // Q defines model for Q.
 type Q = string
 
 // RequireEnrichment defines model for RequireEnrichment.
 type RequireEnrichment = bool
 
+// ReviewId defines model for ReviewId.
+type ReviewId = string
+
+// RuleId defines model for RuleId.
+type RuleId = string
+
+// SchemaName defines model for SchemaName.
+type SchemaName = string
+
+// ShelfId defines model for ShelfId.
+type ShelfId = string
+
+// Snapshot defines model for Snapshot.
+type Snapshot = bool
+
+// SnapshotId defines model for SnapshotId.
+type SnapshotId = string
+
 // Sort defines model for Sort.
 type Sort = string
 
+// StocktakeId defines model for StocktakeId.
+type StocktakeId = string
+
+// TZ defines model for TZ.
+type TZ = string
+
 // Tag defines model for Tag.
 type Tag = string
 
+// UpdatedSince defines model for UpdatedSince.
+type UpdatedSince = time.Time
+
 // Year defines model for Year.
 type Year = int
 
+// YearFrom defines model for YearFrom.
+type YearFrom = int
+
+// YearTo defines model for YearTo.
+type YearTo = int
+
 // BadRequest defines model for BadRequest.
 type BadRequest = ErrorResponse
 
 // Conflict defines model for Conflict.
 type Conflict = ErrorResponse
 
+// Forbidden defines model for Forbidden.
+type Forbidden = ErrorResponse
+
 // NotFound defines model for NotFound.
 type NotFound = ErrorResponse
 
+// PreconditionFailed defines model for PreconditionFailed.
+type PreconditionFailed = ErrorResponse
+
 // UpstreamFailed defines model for UpstreamFailed.
 type UpstreamFailed = ErrorResponse
 
+// QueryAuditLogParams defines parameters for QueryAuditLog.
+type QueryAuditLogParams struct {
+	BookId *string `form:"book_id,omitempty" json:"book_id,omitempty"`
+
+	// Actor e.g. api-key:ci, jwt:alice, anonymous or system
+	Actor *string `form:"actor,omitempty" json:"actor,omitempty"`
+
+	// Action create, update, enrich, delete, restore or purge
+	Action *string    `form:"action,omitempty" json:"action,omitempty"`
+	Since  *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Exclusive.
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+	Limit *int       `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PurgeBooksParams defines parameters for PurgeBooks.
+type PurgeBooksParams struct {
+	DeletedBefore *time.Time `form:"deleted_before,omitempty" json:"deleted_before,omitempty"`
+}
+
+// ListShelfSyncsParams defines parameters for ListShelfSyncs.
+type ListShelfSyncsParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListAuthorsParams defines parameters for ListAuthors.
+type ListAuthorsParams struct {
+	// Q Filter by author name (contains, case-insensitive).
+	Q        *AuthorSearch `form:"q,omitempty" json:"q,omitempty"`
+	Page     *Page         `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize     `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
 // ListBooksParams defines parameters for ListBooks.
 type ListBooksParams struct {
 	// Q Free-text search over title/subtitle.
@@ -151,23 +1732,407 @@ type ListBooksParams struct {
 	// Year Filter by exact published year.
 	Year *Year `form:"year,omitempty" json:"year,omitempty"`
 
-	// Tag Filter by tag (exact match).
+	// YearFrom Only books published in this year or later; books without a year are left out.
+	YearFrom *YearFrom `form:"year_from,omitempty" json:"year_from,omitempty"`
+
+	// YearTo Only books published in this year or earlier; books without a year are left out.
+	YearTo *YearTo `form:"year_to,omitempty" json:"year_to,omitempty"`
+
+	// UpdatedSince Only books changed at or after this time, e.g. 2024-05-01T00:00:00Z, for fetching what changed since an earlier sync.
+	UpdatedSince *UpdatedSince `form:"updated_since,omitempty" json:"updated_since,omitempty"`
+
+	// Tag Filter by tag (exact match). Tags may be nested with '/', as in programming/go; see include_children.
 	Tag *Tag `form:"tag,omitempty" json:"tag,omitempty"`
 
-	// Sort Comma-separated fields. Prefix with '-' for descending. Supported: title, published_year, created_at, updated_at.
+	// IncludeChildren If true, the tag filter also matches the tags nested under it, so tag=programming matches programming/go and programming/go/testing.
+	IncludeChildren *IncludeChildren `form:"include_children,omitempty" json:"include_children,omitempty"`
+
+	// Branch Only books of which this branch holds copies.
+	Branch *BranchFilter `form:"branch,omitempty" json:"branch,omitempty"`
+
+	// Barcode Only the book with the item of this barcode.
+	Barcode *BarcodeFilter `form:"barcode,omitempty" json:"barcode,omitempty"`
+
+	// Available true keeps the books of which a copy can be lent now, false those whose copies are all on loan or kept for holders.
+	Available *AvailableFilter `form:"available,omitempty" json:"available,omitempty"`
+
+	// Sort Comma-separated fields. Prefix with '-' for descending. Supported: title, published_year, created_at, updated_at, rating (the average rating; unreviewed books sort first ascending).
 	Sort     *Sort     `form:"sort,omitempty" json:"sort,omitempty"`
 	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
 	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+
+	// Snapshot If true, pin the filtered and sorted result for a few minutes and return its snapshot_id, so later pages are read from the same consistent view.
+	Snapshot *Snapshot `form:"snapshot,omitempty" json:"snapshot,omitempty"`
+
+	// SnapshotId Continue a paginated walk over a pinned result. Filters and sort are taken from the request that created the snapshot. Unknown or expired ids return 404.
+	SnapshotId *SnapshotId `form:"snapshot_id,omitempty" json:"snapshot_id,omitempty"`
+
+	// Cursor Continue after the last book of an earlier page, from its next_cursor. Filters and sort are taken from the request that returned the cursor, and page is ignored. Unlike page numbers, cursors neither skip nor repeat books when others are created or deleted during the walk. Cannot be combined with snapshots.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// IncludeDeleted Also include books in the trash; they carry deleted_at. Needs the admin role. A cursor from such a listing must be sent with include_deleted=true again.
+	IncludeDeleted *IncludeDeleted `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+
+	// Count exact (the default) counts every matching book into total; none skips counting and leaves total out, which is cheaper on large catalogs.
+	Count *ListBooksParamsCount `form:"count,omitempty" json:"count,omitempty"`
+
+	// InlineCovers thumb adds each book's small stored cover as a base64 data URI in cover_thumb, for kiosk and offline clients. Only covers kept by the server are inlined, none over 16 KiB, and no more than 256 KiB per page; other books only carry cover_url.
+	InlineCovers *ListBooksParamsInlineCovers `form:"inline_covers,omitempty" json:"inline_covers,omitempty"`
+
+	// IfNoneMatch ETags of a cached response; a match answers 304 Not Modified without a body.
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
 }
 
+// ListBooksParamsCount defines parameters for ListBooks.
+type ListBooksParamsCount string
+
+// ListBooksParamsInlineCovers defines parameters for ListBooks.
+type ListBooksParamsInlineCovers string
+
 // CreateBookParams defines parameters for CreateBook.
 type CreateBookParams struct {
-	// Enrich If true and an ISBN is provided, attempt external enrichment.
+	// IdempotencyKey Client-chosen key, at most 255 characters, that makes retries of the request safe.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+
+	// Enrich If true and an ISBN is provided, attempt external enrichment. Unless enrichment is required, the server may enrich in the background: the book is returned with status pending and updated once the lookup finishes.
 	Enrich *Enrich `form:"enrich,omitempty" json:"enrich,omitempty"`
 
 	// RequireEnrichment If true, fail when enrichment is unavailable or fails.
 	RequireEnrichment *RequireEnrichment `form:"require_enrichment,omitempty" json:"require_enrichment,omitempty"`
+
+	// AutoCorrect If true, tags and authors that are a near miss of existing ones are replaced by the existing spelling (reported in the book's warnings).
+	AutoCorrect *AutoCorrect `form:"auto_correct,omitempty" json:"auto_correct,omitempty"`
+}
+
+// ListBooksTextParams defines parameters for ListBooksText.
+type ListBooksTextParams struct {
+	// Q Free-text search over title/subtitle.
+	Q *Q `form:"q,omitempty" json:"q,omitempty"`
+
+	// Author Filter by author name (contains, case-insensitive).
+	Author *AuthorName `form:"author,omitempty" json:"author,omitempty"`
+
+	// Year Filter by exact published year.
+	Year *Year `form:"year,omitempty" json:"year,omitempty"`
+
+	// YearFrom Only books published in this year or later; books without a year are left out.
+	YearFrom *YearFrom `form:"year_from,omitempty" json:"year_from,omitempty"`
+
+	// YearTo Only books published in this year or earlier; books without a year are left out.
+	YearTo *YearTo `form:"year_to,omitempty" json:"year_to,omitempty"`
+
+	// UpdatedSince Only books changed at or after this time, e.g. 2024-05-01T00:00:00Z, for fetching what changed since an earlier sync.
+	UpdatedSince *UpdatedSince `form:"updated_since,omitempty" json:"updated_since,omitempty"`
+
+	// Tag Filter by tag (exact match). Tags may be nested with '/', as in programming/go; see include_children.
+	Tag *Tag `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// IncludeChildren If true, the tag filter also matches the tags nested under it, so tag=programming matches programming/go and programming/go/testing.
+	IncludeChildren *IncludeChildren `form:"include_children,omitempty" json:"include_children,omitempty"`
+
+	// Branch Only books of which this branch holds copies.
+	Branch *BranchFilter `form:"branch,omitempty" json:"branch,omitempty"`
+
+	// Barcode Only the book with the item of this barcode.
+	Barcode *BarcodeFilter `form:"barcode,omitempty" json:"barcode,omitempty"`
+
+	// Available true keeps the books of which a copy can be lent now, false those whose copies are all on loan or kept for holders.
+	Available *AvailableFilter `form:"available,omitempty" json:"available,omitempty"`
+
+	// Sort Comma-separated fields. Prefix with '-' for descending. Supported: title, published_year, created_at, updated_at, rating (the average rating; unreviewed books sort first ascending).
+	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// IncludeDeleted Also include books in the trash; they carry deleted_at. Needs the admin role. A cursor from such a listing must be sent with include_deleted=true again.
+	IncludeDeleted *IncludeDeleted `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+
+	// Tz IANA time zone, such as Europe/Berlin, to write times in; UTC by default. The JSON API always writes times in UTC.
+	Tz *TZ `form:"tz,omitempty" json:"tz,omitempty"`
+}
+
+// ListDuplicateBooksParams defines parameters for ListDuplicateBooks.
+type ListDuplicateBooksParams struct {
+	// Mode How books are compared; only title is supported.
+	Mode     *string   `form:"mode,omitempty" json:"mode,omitempty"`
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// ExportBooksParams defines parameters for ExportBooks.
+type ExportBooksParams struct {
+	// Format Export format, one of csv, markdown or org.
+	Format *string `form:"format,omitempty" json:"format,omitempty"`
+
+	// Q Free-text search over title/subtitle.
+	Q *Q `form:"q,omitempty" json:"q,omitempty"`
+
+	// Author Filter by author name (contains, case-insensitive).
+	Author *AuthorName `form:"author,omitempty" json:"author,omitempty"`
+
+	// Year Filter by exact published year.
+	Year *Year `form:"year,omitempty" json:"year,omitempty"`
+
+	// YearFrom Only books published in this year or later; books without a year are left out.
+	YearFrom *YearFrom `form:"year_from,omitempty" json:"year_from,omitempty"`
+
+	// YearTo Only books published in this year or earlier; books without a year are left out.
+	YearTo *YearTo `form:"year_to,omitempty" json:"year_to,omitempty"`
+
+	// UpdatedSince Only books changed at or after this time, e.g. 2024-05-01T00:00:00Z, for fetching what changed since an earlier sync.
+	UpdatedSince *UpdatedSince `form:"updated_since,omitempty" json:"updated_since,omitempty"`
+
+	// Tag Filter by tag (exact match). Tags may be nested with '/', as in programming/go; see include_children.
+	Tag *Tag `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// IncludeChildren If true, the tag filter also matches the tags nested under it, so tag=programming matches programming/go and programming/go/testing.
+	IncludeChildren *IncludeChildren `form:"include_children,omitempty" json:"include_children,omitempty"`
+
+	// Branch Only books of which this branch holds copies.
+	Branch *BranchFilter `form:"branch,omitempty" json:"branch,omitempty"`
+
+	// Barcode Only the book with the item of this barcode.
+	Barcode *BarcodeFilter `form:"barcode,omitempty" json:"barcode,omitempty"`
+
+	// Available true keeps the books of which a copy can be lent now, false those whose copies are all on loan or kept for holders.
+	Available *AvailableFilter `form:"available,omitempty" json:"available,omitempty"`
+
+	// Sort Comma-separated fields. Prefix with '-' for descending. Supported: title, published_year, created_at, updated_at, rating (the average rating; unreviewed books sort first ascending).
+	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// IncludeDeleted Also include books in the trash; they carry deleted_at. Needs the admin role. A cursor from such a listing must be sent with include_deleted=true again.
+	IncludeDeleted *IncludeDeleted `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+
+	// Tz IANA time zone, such as Europe/Berlin, to write times in; UTC by default. The JSON API always writes times in UTC.
+	Tz *TZ `form:"tz,omitempty" json:"tz,omitempty"`
+}
+
+// ImportBooksParams defines parameters for ImportBooks.
+type ImportBooksParams struct {
+	// Enrich If true and an ISBN is provided, attempt external enrichment. Unless enrichment is required, the server may enrich in the background: the book is returned with status pending and updated once the lookup finishes.
+	Enrich *Enrich `form:"enrich,omitempty" json:"enrich,omitempty"`
+}
+
+// ListRecentViewsParams defines parameters for ListRecentViews.
+type ListRecentViewsParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// SemanticSearchBooksParams defines parameters for SemanticSearchBooks.
+type SemanticSearchBooksParams struct {
+	Q     string `form:"q" json:"q"`
+	Limit *int   `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// DeleteBookByIdParams defines parameters for DeleteBookById.
+type DeleteBookByIdParams struct {
+	// IfMatch ETag the change is based on, from a previous response for the book. When the book has changed since, the request fails with 412 and nothing is written.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetBookByIdParams defines parameters for GetBookById.
+type GetBookByIdParams struct {
+	// IfNoneMatch ETags of a cached response; a match answers 304 Not Modified without a body.
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// PatchBookParams defines parameters for PatchBook.
+type PatchBookParams struct {
+	// IfMatch ETag the change is based on, from a previous response for the book. When the book has changed since, the request fails with 412 and nothing is written.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// UpdateBookParams defines parameters for UpdateBook.
+type UpdateBookParams struct {
+	// IfMatch ETag the change is based on, from a previous response for the book. When the book has changed since, the request fails with 412 and nothing is written.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetBookCoverParams defines parameters for GetBookCover.
+type GetBookCoverParams struct {
+	Size *string `form:"size,omitempty" json:"size,omitempty"`
+}
+
+// EnrichBookParams defines parameters for EnrichBook.
+type EnrichBookParams struct {
+	// Overwrite Let external data replace user-provided fields.
+	Overwrite *bool `form:"overwrite,omitempty" json:"overwrite,omitempty"`
+}
+
+// GetSimilarBooksParams defines parameters for GetSimilarBooks.
+type GetSimilarBooksParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetBookSummaryParams defines parameters for GetBookSummary.
+type GetBookSummaryParams struct {
+	IncludeDescription *bool `form:"include_description,omitempty" json:"include_description,omitempty"`
+}
+
+// ListBookReviewsParams defines parameters for ListBookReviews.
+type ListBookReviewsParams struct {
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// ReplaceBookTagsParams defines parameters for ReplaceBookTags.
+type ReplaceBookTagsParams struct {
+	// IfMatch ETag the change is based on, from a previous response for the book. When the book has changed since, the request fails with 412 and nothing is written.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ListBorrowersParams defines parameters for ListBorrowers.
+type ListBorrowersParams struct {
+	// Q Matches name, email or external id, case-insensitively
+	Q        *string   `form:"q,omitempty" json:"q,omitempty"`
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// ListLoansParams defines parameters for ListLoans.
+type ListLoansParams struct {
+	Borrower *string `form:"borrower,omitempty" json:"borrower,omitempty"`
+	BookId   *string `form:"book_id,omitempty" json:"book_id,omitempty"`
+
+	// Status active loans are not returned yet, overdue ones are active and past their due date, lost ones active and marked lost; all loans when absent.
+	Status *ListLoansParamsStatus `form:"status,omitempty" json:"status,omitempty"`
 }
 
+// ListLoansParamsStatus defines parameters for ListLoans.
+type ListLoansParamsStatus string
+
+// GetReadingStatsParams defines parameters for GetReadingStats.
+type GetReadingStatsParams struct {
+	// Weeks Weeks to report, up to the current one
+	Weeks *int `form:"weeks,omitempty" json:"weeks,omitempty"`
+}
+
+// SearchExternalParams defines parameters for SearchExternal.
+type SearchExternalParams struct {
+	Q     string `form:"q" json:"q"`
+	Limit *int   `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListShelfBooksParams defines parameters for ListShelfBooks.
+type ListShelfBooksParams struct {
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// CreateAutoTagRuleJSONRequestBody defines body for CreateAutoTagRule for application/json ContentType.
+type CreateAutoTagRuleJSONRequestBody = AutoTagRuleCreate
+
+// CreateEscalationPolicyJSONRequestBody defines body for CreateEscalationPolicy for application/json ContentType.
+type CreateEscalationPolicyJSONRequestBody = EscalationPolicyCreate
+
+// CreateAuthorJSONRequestBody defines body for CreateAuthor for application/json ContentType.
+type CreateAuthorJSONRequestBody = AuthorWrite
+
+// RenameAuthorJSONRequestBody defines body for RenameAuthor for application/json ContentType.
+type RenameAuthorJSONRequestBody = AuthorRenameRequest
+
+// UpdateAuthorJSONRequestBody defines body for UpdateAuthor for application/json ContentType.
+type UpdateAuthorJSONRequestBody = AuthorWrite
+
 // CreateBookJSONRequestBody defines body for CreateBook for application/json ContentType.
 type CreateBookJSONRequestBody = BookCreate
+
+// CompareBooksJSONRequestBody defines body for CompareBooks for application/json ContentType.
+type CompareBooksJSONRequestBody = CompareRequest
+
+// CreateDuplicateExclusionJSONRequestBody defines body for CreateDuplicateExclusion for application/json ContentType.
+type CreateDuplicateExclusionJSONRequestBody = DuplicateExclusionCreate
+
+// ImportExternalBookJSONRequestBody defines body for ImportExternalBook for application/json ContentType.
+type ImportExternalBookJSONRequestBody = ExternalImportRequest
+
+// ImportReadingListJSONRequestBody defines body for ImportReadingList for application/json ContentType.
+type ImportReadingListJSONRequestBody = ReadingListImportRequest
+
+// ParseBookJSONRequestBody defines body for ParseBook for application/json ContentType.
+type ParseBookJSONRequestBody = ParseRequest
+
+// PatchBookJSONRequestBody defines body for PatchBook for application/json ContentType.
+type PatchBookJSONRequestBody = BookPatch
+
+// UpdateBookJSONRequestBody defines body for UpdateBook for application/json ContentType.
+type UpdateBookJSONRequestBody = BookCreate
+
+// SetBookCopiesJSONRequestBody defines body for SetBookCopies for application/json ContentType.
+type SetBookCopiesJSONRequestBody = BranchCopies
+
+// UploadBookCoverMultipartRequestBody defines body for UploadBookCover for multipart/form-data ContentType.
+type UploadBookCoverMultipartRequestBody = CoverUpload
+
+// PlaceHoldJSONRequestBody defines body for PlaceHold for application/json ContentType.
+type PlaceHoldJSONRequestBody = HoldCreate
+
+// AddBookItemJSONRequestBody defines body for AddBookItem for application/json ContentType.
+type AddBookItemJSONRequestBody = ItemCreate
+
+// UpdateBookItemJSONRequestBody defines body for UpdateBookItem for application/json ContentType.
+type UpdateBookItemJSONRequestBody = ItemPatch
+
+// CheckoutBookJSONRequestBody defines body for CheckoutBook for application/json ContentType.
+type CheckoutBookJSONRequestBody = LoanCreate
+
+// UpdateBookProgressJSONRequestBody defines body for UpdateBookProgress for application/json ContentType.
+type UpdateBookProgressJSONRequestBody = ProgressUpdate
+
+// CreateBookReviewJSONRequestBody defines body for CreateBookReview for application/json ContentType.
+type CreateBookReviewJSONRequestBody = ReviewCreate
+
+// ReplaceBookTagsJSONRequestBody defines body for ReplaceBookTags for application/json ContentType.
+type ReplaceBookTagsJSONRequestBody = BookTags
+
+// TransferBookCopiesJSONRequestBody defines body for TransferBookCopies for application/json ContentType.
+type TransferBookCopiesJSONRequestBody = TransferRequest
+
+// CreateBooksBatchJSONRequestBody defines body for CreateBooksBatch for application/json ContentType.
+type CreateBooksBatchJSONRequestBody = BatchCreateRequest
+
+// CreateBorrowerJSONRequestBody defines body for CreateBorrower for application/json ContentType.
+type CreateBorrowerJSONRequestBody = BorrowerWrite
+
+// UpdateBorrowerJSONRequestBody defines body for UpdateBorrower for application/json ContentType.
+type UpdateBorrowerJSONRequestBody = BorrowerWrite
+
+// RecordBorrowerFeeJSONRequestBody defines body for RecordBorrowerFee for application/json ContentType.
+type RecordBorrowerFeeJSONRequestBody = FeeEntryCreate
+
+// MergeBorrowerJSONRequestBody defines body for MergeBorrower for application/json ContentType.
+type MergeBorrowerJSONRequestBody = BorrowerMerge
+
+// CreateBranchJSONRequestBody defines body for CreateBranch for application/json ContentType.
+type CreateBranchJSONRequestBody = BranchCreate
+
+// UpdateBranchJSONRequestBody defines body for UpdateBranch for application/json ContentType.
+type UpdateBranchJSONRequestBody = BranchPatch
+
+// KioskScanCardJSONRequestBody defines body for KioskScanCard for application/json ContentType.
+type KioskScanCardJSONRequestBody = KioskCard
+
+// KioskCheckoutJSONRequestBody defines body for KioskCheckout for application/json ContentType.
+type KioskCheckoutJSONRequestBody = KioskCheckout
+
+// KioskScanItemJSONRequestBody defines body for KioskScanItem for application/json ContentType.
+type KioskScanItemJSONRequestBody = KioskScan
+
+// KioskSyncJSONRequestBody defines body for KioskSync for application/json ContentType.
+type KioskSyncJSONRequestBody = KioskSync
+
+// PrintLabelsJSONRequestBody defines body for PrintLabels for application/json ContentType.
+type PrintLabelsJSONRequestBody = LabelRequest
+
+// CreateShelfJSONRequestBody defines body for CreateShelf for application/json ContentType.
+type CreateShelfJSONRequestBody = ShelfCreate
+
+// StartStocktakeJSONRequestBody defines body for StartStocktake for application/json ContentType.
+type StartStocktakeJSONRequestBody = StocktakeCreate
+
+// CloseStocktakeJSONRequestBody defines body for CloseStocktake for application/json ContentType.
+type CloseStocktakeJSONRequestBody = StocktakeClose
+
+// MergeTagsJSONRequestBody defines body for MergeTags for application/json ContentType.
+type MergeTagsJSONRequestBody = TagMergeRequest
+
+// RenameTagJSONRequestBody defines body for RenameTag for application/json ContentType.
+type RenameTagJSONRequestBody = TagRenameRequest
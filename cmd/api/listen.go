@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// listen opens the server's listener: the socket systemd passed in when the
+// process was socket-activated, else a Unix socket for "unix:/path", else
+// TCP.
+func listen(addr string) (net.Listener, error) {
+	if ln, err := activationListener(); ln != nil || err != nil {
+		return ln, err
+	}
+	path, ok := strings.CutPrefix(addr, "unix:")
+	if !ok {
+		return net.Listen("tcp", addr)
+	}
+	// a socket left behind by a previous run blocks the bind; anything
+	// else at the path is not ours to remove
+	if fi, err := os.Lstat(path); err == nil && fi.Mode()&fs.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	// the reverse proxy usually runs as another user in the same group
+	if err := os.Chmod(path, 0o660); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+// sdListenFDsStart is the first file descriptor systemd passes.
+const sdListenFDsStart = 3
+
+// activationListener returns the socket systemd passed in (LISTEN_FDS,
+// LISTEN_PID), or nil when the process was not socket-activated.
+func activationListener() (net.Listener, error) {
+	pid, fds := os.Getenv("LISTEN_PID"), os.Getenv("LISTEN_FDS")
+	if fds == "" || pid != strconv.Itoa(os.Getpid()) {
+		return nil, nil
+	}
+	// not for child processes
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+	n, err := strconv.Atoi(fds)
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("LISTEN_FDS=%q: want a positive count", fds)
+	}
+	if n > 1 {
+		return nil, errors.New("socket activation: exactly one socket is supported")
+	}
+	f := os.NewFile(sdListenFDsStart, "systemd-socket")
+	defer f.Close() // FileListener dups the descriptor
+	return net.FileListener(f)
+}
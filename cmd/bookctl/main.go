@@ -0,0 +1,128 @@
+// Command bookctl is a small command-line client for the book-manager API.
+//
+//	bookctl -version
+//	bookctl [-server url] [-key key] export [-format csv|markdown|org] [-q text] [-author name]
+//	        [-tag tag] [-year n] [-sort fields] [-tz zone] [-o file]
+//	bookctl [-server url] [-key key] sync -from url [-from-key key] [-since time] [-tag tag]
+package main
+
+import (
+	"book-manager/api"
+	"book-manager/pkg/buildinfo"
+	"book-manager/pkg/client"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("bookctl: ")
+	def := os.Getenv("BOOKCTL_SERVER")
+	if def == "" {
+		def = "http://localhost:8080"
+	}
+	server := flag.String("server", def, "API base url (default from BOOKCTL_SERVER)")
+	key := flag.String("key", os.Getenv("BOOKCTL_API_KEY"), "API key (default from BOOKCTL_API_KEY)")
+	showVersion := flag.Bool("version", false, "Print the version and exit")
+	flag.Usage = usage
+	flag.Parse()
+	if *showVersion {
+		fmt.Println("bookctl", buildinfo.Get())
+		return
+	}
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	c := newClient(*server, *key)
+	switch cmd, args := flag.Arg(0), flag.Args()[1:]; cmd {
+	case "export":
+		if err := export(c, args); err != nil {
+			log.Fatal(err)
+		}
+	case "sync":
+		if err := syncCmd(c, *key, args); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Printf("unknown command %q", cmd)
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "usage: bookctl [-version] [-server url] [-key key] <command> [flags]\n\ncommands:\n  export    write the (filtered) catalog as CSV, Markdown or Org-mode\n  sync      copy the books changed on another server into this one\n\n")
+	flag.PrintDefaults()
+}
+
+// newClient is a client of the API at server, with a timeout long enough
+// for large exports.
+func newClient(server, key string) *client.Client {
+	opts := []client.Option{
+		client.WithHTTPClient(&http.Client{Timeout: 5 * time.Minute}),
+		client.WithHeader("User-Agent", buildinfo.UserAgent("bookctl")),
+	}
+	if key != "" {
+		opts = append(opts, client.WithAPIKey(key))
+	}
+	return client.New(server, opts...)
+}
+
+func export(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "csv", "Output format: csv, markdown or org")
+	q := fs.String("q", "", "Free-text search over title/subtitle")
+	author := fs.String("author", "", "Filter by author name (contains)")
+	tag := fs.String("tag", "", "Filter by tag")
+	year := fs.Int("year", 0, "Filter by published year")
+	sort := fs.String("sort", "", "Sort fields, e.g. title or -published_year")
+	tz := fs.String("tz", "", "IANA time zone to write times in, e.g. Europe/Berlin (default UTC)")
+	out := fs.String("o", "", "Output file (default stdout)")
+	_ = fs.Parse(args)
+
+	params := api.ExportBooksParams{Format: format, Q: flagValue(*q), Author: flagValue(*author),
+		Tag: flagValue(*tag), Sort: flagValue(*sort), Tz: flagValue(*tz)}
+	if *year != 0 {
+		params.Year = year
+	}
+
+	body, err := c.ExportBooks(context.Background(), &params)
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	defer body.Close()
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		return err
+	}
+	if f, ok := w.(*os.File); ok && f != os.Stdout {
+		return f.Close()
+	}
+	return nil
+}
+
+// flagValue is an optional parameter set by a string flag: nil when the
+// flag is empty.
+func flagValue(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
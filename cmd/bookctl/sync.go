@@ -0,0 +1,134 @@
+package main
+
+import (
+	"book-manager/api"
+	"book-manager/pkg/client"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+)
+
+// syncBatch is how many books are matched against the target at once.
+const syncBatch = 100
+
+func syncCmd(dst *client.Client, dstKey string, args []string) error {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	from := fs.String("from", "", "Base url of the server to copy from (required)")
+	fromKey := fs.String("from-key", "", "API key for -from (default -key)")
+	since := fs.String("since", "", "Only books changed at or after this RFC 3339 time, e.g. the time the last sync printed")
+	tag := fs.String("tag", "", "Only books with this tag")
+	_ = fs.Parse(args)
+	if *from == "" {
+		return fmt.Errorf("sync: -from is required")
+	}
+	params := api.ListBooksParams{Tag: flagValue(*tag)}
+	if *since != "" {
+		t, err := time.Parse(time.RFC3339, *since)
+		if err != nil {
+			return fmt.Errorf("sync: -since: %w", err)
+		}
+		params.UpdatedSince = &t
+	}
+	key := *fromKey
+	if key == "" {
+		key = dstKey
+	}
+
+	started := time.Now().UTC()
+	rep, err := syncBooks(context.Background(), dst, newClient(*from, key), params)
+	log.Printf("sync: %d created, %d updated, %d without ISBN skipped, %d failed", rep.created, rep.updated, rep.skipped, rep.failed)
+	if err != nil {
+		return fmt.Errorf("sync: %w", err)
+	}
+	if rep.failed > 0 {
+		return fmt.Errorf("sync: %d books failed", rep.failed)
+	}
+	log.Printf("sync: next time pass -since=%s", started.Format(time.RFC3339))
+	return nil
+}
+
+type syncReport struct{ created, updated, skipped, failed int }
+
+// syncBooks copies the books params selects on src to dst, matching them
+// by ISBN: a book dst has is overwritten, others are created. Books
+// without an ISBN cannot be matched and are skipped. Failures of single
+// books are logged and counted; an error stops the sync.
+func syncBooks(ctx context.Context, dst, src *client.Client, params api.ListBooksParams) (syncReport, error) {
+	var rep syncReport
+	var batch []api.Book
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		isbns := make([]string, len(batch))
+		for i, b := range batch {
+			isbns[i] = *b.Isbn
+		}
+		cmp, err := dst.CompareBooks(ctx, isbns)
+		if err != nil {
+			return err
+		}
+		ids := make(map[string]string, len(cmp.Present))
+		for _, m := range cmp.Present {
+			ids[m.Isbn] = m.BookId
+		}
+		for _, b := range batch {
+			id, ok := ids[*b.Isbn]
+			if ok {
+				_, err = dst.UpdateBook(ctx, id, bookCreate(b))
+			} else {
+				_, err = dst.CreateBook(ctx, bookCreate(b), nil)
+			}
+			switch {
+			case err != nil:
+				log.Printf("sync: %s (%s): %v", b.Title, *b.Isbn, err)
+				rep.failed++
+			case ok:
+				rep.updated++
+			default:
+				rep.created++
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+	for b, err := range src.Books(ctx, &params) {
+		if err != nil {
+			return rep, err
+		}
+		if b.Isbn == nil || *b.Isbn == "" {
+			rep.skipped++
+			continue
+		}
+		if batch = append(batch, b); len(batch) == syncBatch {
+			if err := flush(); err != nil {
+				return rep, err
+			}
+		}
+	}
+	return rep, flush()
+}
+
+// bookCreate is the input that writes b's own fields; what the server
+// keeps itself (id, times, version, lending) is left out.
+func bookCreate(b api.Book) api.BookCreate {
+	authors := make([]string, len(b.Authors))
+	for i, a := range b.Authors {
+		authors[i] = a.Name
+	}
+	return api.BookCreate{
+		Title:         b.Title,
+		Subtitle:      b.Subtitle,
+		Isbn:          b.Isbn,
+		Authors:       &authors,
+		PublishedYear: b.PublishedYear,
+		PageCount:     b.PageCount,
+		Tags:          b.Tags,
+		CoverUrl:      b.CoverUrl,
+		PriceTarget:   b.PriceTarget,
+		ReleaseDate:   b.ReleaseDate,
+		Forthcoming:   &b.Forthcoming,
+	}
+}
@@ -0,0 +1,52 @@
+// Command sdkgen writes a typed client SDK for the book-manager API from
+// its OpenAPI document (api/openapi.yaml), in Go or TypeScript.
+//
+//	sdkgen -lang go [-package name] [-o file]
+//	sdkgen -lang ts [-o file]
+package main
+
+import (
+	"book-manager/api"
+	"book-manager/internal/apispec"
+	"book-manager/internal/sdkgen"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("sdkgen: ")
+	lang := flag.String("lang", "go", "SDK language: go or ts")
+	pkg := flag.String("package", "bookmanager", "Go package name")
+	out := flag.String("o", "", "Output file (default stdout)")
+	flag.Parse()
+
+	d, err := apispec.Parse(api.Spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var src []byte
+	switch *lang {
+	case "go":
+		src, err = sdkgen.Go(d, *pkg)
+	case "ts":
+		src, err = sdkgen.TypeScript(d)
+	default:
+		log.Fatalf("unknown language %q", *lang)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,109 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// AuditRepo keeps the audit log in memory and, when opened on a file,
+// appends every entry to it, synced before Add returns. Entries never
+// change, so unlike the book journal the file needs no compaction.
+type AuditRepo struct {
+	mu      sync.RWMutex
+	entries []model.AuditEntry // oldest first
+	path    string
+	f       *os.File
+}
+
+func NewAuditRepo() *AuditRepo {
+	return &AuditRepo{}
+}
+
+// OpenAuditRepo loads the audit log kept in path, which need not exist yet,
+// dropping an entry torn by a crash.
+func OpenAuditRepo(path string) (*AuditRepo, error) {
+	r := &AuditRepo{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if i := bytes.LastIndexByte(data, '\n'); i < len(data)-1 {
+		data = data[:i+1]
+		if err := os.Truncate(path, int64(len(data))); err != nil {
+			return nil, err
+		}
+	}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		var e model.AuditEntry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("audit log %s line %d: %w", path, n, err)
+		}
+		r.entries = append(r.entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if r.f, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *AuditRepo) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return nil
+	}
+	return r.f.Close()
+}
+
+func (r *AuditRepo) Add(_ context.Context, e model.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f != nil {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if _, err = r.f.Write(append(line, '\n')); err == nil {
+			err = r.f.Sync()
+		}
+		if err != nil {
+			return fmt.Errorf("audit log %s: %w", r.path, err)
+		}
+	}
+	r.entries = append(r.entries, e)
+	return nil
+}
+
+func (r *AuditRepo) List(ctx context.Context, q model.AuditQuery) ([]model.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []model.AuditEntry
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		e := r.entries[i]
+		switch {
+		case !ofTenant(ctx, e.Tenant),
+			q.BookID != "" && e.BookID != q.BookID,
+			q.Actor != "" && e.Actor != q.Actor,
+			q.Action != "" && e.Action != q.Action,
+			!q.Since.IsZero() && e.At.Before(q.Since),
+			!q.Until.IsZero() && !e.At.Before(q.Until):
+			continue
+		}
+		out = append(out, e)
+		if q.Limit > 0 && len(out) == q.Limit {
+			break
+		}
+	}
+	return out, nil
+}
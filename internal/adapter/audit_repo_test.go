@@ -0,0 +1,67 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRepo_Persists(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	r, err := OpenAuditRepo(path)
+	require.NoError(t, err)
+	at := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	created := model.AuditEntry{ID: "1", BookID: "b1", Action: model.AuditCreate, Actor: "api-key:ci", At: at,
+		Changes: []model.FieldChange{{Field: "title", After: "One"}}}
+	updated := model.AuditEntry{ID: "2", BookID: "b1", Action: model.AuditUpdate, Actor: "jwt:alice", At: at.Add(time.Minute),
+		Changes: []model.FieldChange{{Field: "title", Before: "One", After: "Two"}}}
+	other := model.AuditEntry{ID: "3", BookID: "b2", Action: model.AuditDelete, Actor: "api-key:ci", At: at.Add(2 * time.Minute)}
+	for _, e := range []model.AuditEntry{created, updated, other} {
+		require.NoError(t, r.Add(ctx, e))
+	}
+	require.NoError(t, r.Close())
+
+	// a record torn by a crash is dropped, and appending continues cleanly
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"ID":"4","Book`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	r, err = OpenAuditRepo(path)
+	require.NoError(t, err)
+	require.NoError(t, r.Add(ctx, model.AuditEntry{ID: "5", BookID: "b2", Action: model.AuditRestore, Actor: "system", At: at.Add(3 * time.Minute)}))
+	require.NoError(t, r.Close())
+	r, err = OpenAuditRepo(path)
+	require.NoError(t, err)
+	defer r.Close()
+
+	ids := func(q model.AuditQuery) []string {
+		entries, err := r.List(ctx, q)
+		require.NoError(t, err)
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.ID)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"5", "3", "2", "1"}, ids(model.AuditQuery{}))
+	assert.Equal(t, []string{"2", "1"}, ids(model.AuditQuery{BookID: "b1"}))
+	assert.Equal(t, []string{"3", "1"}, ids(model.AuditQuery{Actor: "api-key:ci"}))
+	assert.Equal(t, []string{"2"}, ids(model.AuditQuery{Action: model.AuditUpdate}))
+	assert.Equal(t, []string{"3", "2"}, ids(model.AuditQuery{Since: at.Add(time.Minute), Until: at.Add(3 * time.Minute)}))
+	assert.Equal(t, []string{"5", "3"}, ids(model.AuditQuery{Limit: 2}))
+
+	entries, err := r.List(ctx, model.AuditQuery{BookID: "b1", Action: model.AuditUpdate})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, updated.Changes, entries[0].Changes)
+}
@@ -0,0 +1,158 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// AuthorRepo keeps the author registry in memory, indexed by id and by
+// case-folded name. Every tenant has its own registry: names are unique
+// and looked up within the tenant.
+type AuthorRepo struct {
+	mu      sync.RWMutex
+	byID    map[string]model.Author
+	byName  map[string]string // authorKey -> id
+	records recordStore[model.Author]
+}
+
+func NewAuthorRepo() *AuthorRepo {
+	return &AuthorRepo{
+		byID:    make(map[string]model.Author),
+		byName:  make(map[string]string),
+		records: memoryRecords[model.Author]{},
+	}
+}
+
+// OpenBoltAuthorRepo loads the authors kept in db's authors bucket.
+func OpenBoltAuthorRepo(db *bolt.DB) (*AuthorRepo, error) {
+	b, authors, err := openBoltRecords[model.Author](db, "authors")
+	if err != nil {
+		return nil, err
+	}
+	r := &AuthorRepo{byID: authors, byName: make(map[string]string, len(authors)), records: b}
+	for id, a := range authors {
+		r.byName[authorKey(a.Tenant, a.Name)] = id
+	}
+	return r, nil
+}
+
+func (r *AuthorRepo) Create(_ context.Context, a model.Author) (model.Author, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := authorKey(a.Tenant, a.Name)
+	if _, ok := r.byID[a.ID]; ok || a.ID == "" {
+		return model.Author{}, errConflict
+	}
+	if _, ok := r.byName[key]; ok {
+		return model.Author{}, errConflict
+	}
+	if err := r.records.writable(); err != nil {
+		return model.Author{}, err
+	}
+	r.byID[a.ID] = a
+	r.byName[key] = a.ID
+	return a, r.records.put(a.ID, a)
+}
+
+func (r *AuthorRepo) Update(ctx context.Context, a model.Author) (model.Author, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	old, ok := r.byID[a.ID]
+	if !ok || !ofTenant(ctx, old.Tenant) {
+		return model.Author{}, errNotFound
+	}
+	a.Tenant = old.Tenant
+	key := authorKey(a.Tenant, a.Name)
+	if id, ok := r.byName[key]; ok && id != a.ID {
+		return model.Author{}, errConflict
+	}
+	if err := r.records.writable(); err != nil {
+		return model.Author{}, err
+	}
+	delete(r.byName, authorKey(old.Tenant, old.Name))
+	r.byName[key] = a.ID
+	r.byID[a.ID] = a
+	return a, r.records.put(a.ID, a)
+}
+
+func (r *AuthorRepo) GetByID(ctx context.Context, id string) (model.Author, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	a, ok := r.byID[id]
+	if !ok || !ofTenant(ctx, a.Tenant) {
+		return model.Author{}, errNotFound
+	}
+	return a, nil
+}
+
+// GetByName looks an author of ctx's tenant up case-insensitively.
+func (r *AuthorRepo) GetByName(ctx context.Context, name string) (model.Author, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id, ok := r.byName[authorKey(model.TenantFromContext(ctx), name)]
+	if !ok {
+		return model.Author{}, errNotFound
+	}
+	return r.byID[id], nil
+}
+
+// List returns authors sorted by name, optionally filtered by q.
+func (r *AuthorRepo) List(ctx context.Context, q model.AuthorQuery) (model.Page[model.Author], error) {
+	r.mu.RLock()
+	items := make([]model.Author, 0, len(r.byID))
+	for _, a := range r.byID {
+		if !ofTenant(ctx, a.Tenant) {
+			continue
+		}
+		if q.Q != nil && !strings.Contains(strings.ToLower(a.Name), strings.ToLower(*q.Q)) {
+			continue
+		}
+		items = append(items, a)
+	}
+	r.mu.RUnlock()
+	sort.Slice(items, func(i, j int) bool {
+		li, lj := strings.ToLower(items[i].Name), strings.ToLower(items[j].Name)
+		if li != lj {
+			return li < lj
+		}
+		return items[i].ID < items[j].ID
+	})
+
+	page := q.Page
+	if page < 1 {
+		page = 1
+	}
+	size := q.PageSize
+	if size < 1 {
+		size = 20
+	}
+	total := len(items)
+	start := min((page-1)*size, total)
+	end := min(start+size, total)
+	return model.Page[model.Author]{Data: items[start:end], Page: page, PageSize: size, Total: total}, nil
+}
+
+func (r *AuthorRepo) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.byID[id]
+	if !ok || !ofTenant(ctx, a.Tenant) {
+		return errNotFound
+	}
+	if err := r.records.writable(); err != nil {
+		return err
+	}
+	delete(r.byName, authorKey(a.Tenant, a.Name))
+	delete(r.byID, id)
+	return r.records.delete(id)
+}
+
+// authorKey indexes the names of a tenant's authors.
+func authorKey(tenant, name string) string {
+	return tenant + "/" + strings.ToLower(name)
+}
@@ -0,0 +1,42 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorRepo_NamesAreUniqueCaseInsensitively(t *testing.T) {
+	r := NewAuthorRepo()
+	ctx := context.Background()
+	_, err := r.Create(ctx, model.Author{ID: "a1", Name: "Jane Doe"})
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Author{ID: "a2", Name: "JANE DOE"})
+	assert.Error(t, err)
+	_, err = r.Create(ctx, model.Author{ID: "a2", Name: "Eric Evans"})
+	require.NoError(t, err)
+
+	got, err := r.GetByName(ctx, "jane doe")
+	require.NoError(t, err)
+	assert.Equal(t, "a1", got.ID)
+
+	_, err = r.Update(ctx, model.Author{ID: "a2", Name: "jane doe"})
+	assert.Error(t, err, "name taken by a1")
+	_, err = r.Update(ctx, model.Author{ID: "a1", Name: "Jane Q. Doe"})
+	require.NoError(t, err)
+	_, err = r.GetByName(ctx, "Jane Doe")
+	assert.Error(t, err, "old name is released")
+
+	page, err := r.List(ctx, model.AuthorQuery{Q: util.GetPtr("jane"), Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+
+	require.NoError(t, r.Delete(ctx, "a1"))
+	assert.Error(t, r.Delete(ctx, "a1"))
+}
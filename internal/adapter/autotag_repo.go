@@ -0,0 +1,59 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"sync"
+)
+
+// AutoTagRuleRepo keeps auto-tag rules in memory, in creation order. Rules
+// loaded from the config file are held separately and listed first; they can
+// only be changed by editing the file.
+type AutoTagRuleRepo struct {
+	mu         sync.RWMutex
+	configured []model.AutoTagRule
+	rules      []model.AutoTagRule
+}
+
+func NewAutoTagRuleRepo() *AutoTagRuleRepo {
+	return &AutoTagRuleRepo{}
+}
+
+func (r *AutoTagRuleRepo) Create(_ context.Context, rule model.AutoTagRule) (model.AutoTagRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.rules {
+		if existing.ID == rule.ID {
+			return model.AutoTagRule{}, errConflict
+		}
+	}
+	r.rules = append(r.rules, rule)
+	return rule, nil
+}
+
+func (r *AutoTagRuleRepo) List(_ context.Context) ([]model.AutoTagRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]model.AutoTagRule, 0, len(r.configured)+len(r.rules))
+	out = append(out, r.configured...)
+	return append(out, r.rules...), nil
+}
+
+func (r *AutoTagRuleRepo) ReplaceConfigured(_ context.Context, rules []model.AutoTagRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.configured = append([]model.AutoTagRule(nil), rules...)
+	return nil
+}
+
+func (r *AutoTagRuleRepo) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, rule := range r.rules {
+		if rule.ID == id {
+			r.rules = append(r.rules[:i], r.rules[i+1:]...)
+			return nil
+		}
+	}
+	return errNotFound
+}
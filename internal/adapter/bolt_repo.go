@@ -0,0 +1,708 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Buckets of the books in a bbolt database. Books are kept by ID; each
+// index has a bucket of its own, so it can be scanned without reading the
+// books.
+var (
+	boltBooks        = []byte("books")
+	boltBooksCreated = []byte("books_created")  // created_at + ID → ID
+	boltBooksISBN    = []byte("books_isbn")     // ISBN index key → ID, of books outside the trash
+	boltBooksTrashed = []byte("books_trashed")  // ISBN index key + 0xff + deleted_at + ID → ID
+	boltBooksTenant  = []byte("books_tenant")   // tenant + 0 + ID → ID
+	boltRenames      = []byte("author_renames") // sequence → rename, oldest first
+	boltOutbox       = []byte("outbox")         // sequence → unpublished event, oldest first
+)
+
+// OpenBoltDB opens the bbolt database in path, creating it if need be. It
+// waits up to a second for another process to release the file.
+func OpenBoltDB(path string) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0o644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("bolt %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// BoltBookRepo is a durable BookRepository on a bbolt database. Every
+// change is one transaction, synced before the call returns, so a crash
+// keeps all of it or none, and a failed change leaves nothing behind; a
+// rename rewrites its books and records itself in the same transaction.
+// Reads are served from the database too: lookups by ISBN use the ISBN
+// index buckets, lists read the books of a tenant through its index and
+// filter and sort them like BookRepo. Only list snapshots are kept in
+// memory.
+//
+// It is also an outbox: a write whose context carries an EventStage stores
+// the events of its changes in its transaction, so an event is kept if and
+// only if its change is.
+type BoltBookRepo struct {
+	db    *bolt.DB
+	snaps *listSnapshots
+}
+
+// OpenBoltBookRepo creates the buckets of the books in db if need be, and
+// the indexes of books stored before they had them.
+func OpenBoltBookRepo(db *bolt.DB) (*BoltBookRepo, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		reindex := tx.Bucket(boltBooks) != nil && tx.Bucket(boltBooksISBN) == nil
+		for _, name := range [][]byte{boltBooks, boltBooksCreated, boltBooksISBN, boltBooksTrashed, boltBooksTenant, boltRenames, boltOutbox} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		if reindex {
+			return reindexBoltBooks(tx)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bolt %s: %w", db.Path(), err)
+	}
+	return &BoltBookRepo{db: db, snaps: newListSnapshots(snapshotTTL)}, nil
+}
+
+func (r *BoltBookRepo) Create(ctx context.Context, b model.Book) (model.Book, error) {
+	return r.create(ctx, b, -1)
+}
+
+// CreateCapped counts the books of b's tenant by its index in the same
+// transaction as the insert.
+func (r *BoltBookRepo) CreateCapped(ctx context.Context, b model.Book, limit int) (model.Book, error) {
+	return r.create(ctx, b, limit)
+}
+
+// create stores b unless limit is not negative and b's tenant keeps that
+// many books.
+func (r *BoltBookRepo) create(ctx context.Context, b model.Book, limit int) (model.Book, error) {
+	if b.ID == "" {
+		return model.Book{}, errConflict
+	}
+	if b.Version == 0 {
+		b.Version = 1
+	}
+	err := r.update(func(tx *bolt.Tx) error {
+		if tx.Bucket(boltBooks).Get([]byte(b.ID)) != nil {
+			return errConflict
+		}
+		if limit >= 0 {
+			n := 0
+			c := tx.Bucket(boltBooksTenant).Cursor()
+			prefix := boltTenantPrefix(b.Tenant)
+			for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+				n++
+			}
+			if n >= limit {
+				return fmt.Errorf("%w: tenant %s keeps its limit of %d books", model.ErrQuota, b.Tenant, limit)
+			}
+		}
+		if err := checkBoltISBN(tx, b); err != nil {
+			return err
+		}
+		if err := putBoltBook(tx, nil, b); err != nil {
+			return err
+		}
+		return stageBoltEvent(ctx, tx, nil, &b)
+	})
+	if err != nil {
+		return model.Book{}, err
+	}
+	return copyBook(b), nil
+}
+
+// Update fails with errStale unless the stored book is at b.Version.
+func (r *BoltBookRepo) Update(ctx context.Context, b model.Book) (model.Book, error) {
+	err := r.update(func(tx *bolt.Tx) error {
+		old, err := getBoltBook(tx, b.ID)
+		if err != nil || !inTenant(ctx, old) {
+			return errNotFound
+		}
+		if b.Version != old.Version {
+			return errStale
+		}
+		b.Version = old.Version + 1
+		if err := checkBoltISBN(tx, b); err != nil {
+			return err
+		}
+		if err := putBoltBook(tx, &old, b); err != nil {
+			return err
+		}
+		return stageBoltEvent(ctx, tx, &old, &b)
+	})
+	if err != nil {
+		return model.Book{}, err
+	}
+	return copyBook(b), nil
+}
+
+func (r *BoltBookRepo) GetByID(ctx context.Context, id string) (model.Book, error) {
+	var b model.Book
+	err := r.db.View(func(tx *bolt.Tx) error {
+		var err error
+		b, err = getBoltBook(tx, id)
+		return err
+	})
+	if err != nil || !inTenant(ctx, b) {
+		return model.Book{}, fmt.Errorf("%w: book %s", model.ErrNotFound, id)
+	}
+	return b, nil
+}
+
+// GetByISBN returns the book outside the trash with isbn, or else the one
+// trashed last.
+func (r *BoltBookRepo) GetByISBN(ctx context.Context, owner, isbn string) (model.Book, error) {
+	key := []byte(isbnIndexKey(isbnScope(model.TenantFromContext(ctx), owner), normalizeISBN(isbn)))
+	var b model.Book
+	err := r.db.View(func(tx *bolt.Tx) error {
+		id := tx.Bucket(boltBooksISBN).Get(key)
+		if id == nil {
+			prefix := append(key, 0xff)
+			c := tx.Bucket(boltBooksTrashed).Cursor()
+			for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+				id = v
+			}
+		}
+		if id == nil {
+			return errNotFound
+		}
+		var err error
+		b, err = getBoltBook(tx, string(id))
+		return err
+	})
+	if err != nil {
+		return model.Book{}, errNotFound
+	}
+	return b, nil
+}
+
+// List pages the books of ctx's tenant as BookRepo.List does.
+func (r *BoltBookRepo) List(ctx context.Context, q model.ListQuery) (model.Page[model.Book], error) {
+	return listBooks(ctx, q, r.snaps, func(q model.ListQuery) ([]model.Book, error) {
+		var items []model.Book
+		err := r.db.View(func(tx *bolt.Tx) error {
+			return eachBoltBook(ctx, tx, func(b model.Book) error {
+				items = append(items, b)
+				return nil
+			})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("bolt %s: %w", r.db.Path(), err)
+		}
+		return filterAndSort(items, q), nil
+	})
+}
+
+func (r *BoltBookRepo) Count(ctx context.Context, q model.ListQuery) (int, error) {
+	return countBooks(ctx, q, r.snaps, func(match func(model.Book) bool) (int, error) {
+		n := 0
+		err := r.db.View(func(tx *bolt.Tx) error {
+			return eachBoltBook(ctx, tx, func(b model.Book) error {
+				if match(b) {
+					n++
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return 0, fmt.Errorf("bolt %s: %w", r.db.Path(), err)
+		}
+		return n, nil
+	})
+}
+
+func (r *BoltBookRepo) Delete(ctx context.Context, id string) error {
+	return r.update(func(tx *bolt.Tx) error {
+		old, err := getBoltBook(tx, id)
+		if err != nil || !inTenant(ctx, old) {
+			return errNotFound
+		}
+		if err := deleteBoltBook(tx, old); err != nil {
+			return err
+		}
+		return stageBoltEvent(ctx, tx, &old, nil)
+	})
+}
+
+// RenameAuthor renames the author in the books of ctx's tenant as
+// BookRepo.RenameAuthor does, and records the rename, in one transaction.
+func (r *BoltBookRepo) RenameAuthor(ctx context.Context, rn model.AuthorRename) (model.AuthorRename, error) {
+	rn.Tenant = model.TenantFromContext(ctx)
+	err := r.update(func(tx *bolt.Tx) error {
+		rn.BooksUpdated, rn.Changes = 0, nil
+		err := renameBoltBooks(ctx, tx, rn.RenamedAt, func(b *model.Book) bool {
+			authors, ids, changed := renameAuthor(b.Authors, b.AuthorIDs, rn.From, rn.To, rn.ToID)
+			b.Authors, b.AuthorIDs = authors, ids
+			return changed
+		}, func(c model.BookChange) {
+			rn.BooksUpdated++
+			rn.Changes = append(rn.Changes, c)
+		})
+		if err != nil || rn.BooksUpdated == 0 {
+			return err
+		}
+		// the history does not keep the changes
+		h := rn
+		h.Changes = nil
+		data, err := json.Marshal(h)
+		if err != nil {
+			return err
+		}
+		renames := tx.Bucket(boltRenames)
+		seq, err := renames.NextSequence()
+		if err != nil {
+			return err
+		}
+		return renames.Put(binary.BigEndian.AppendUint64(nil, seq), data)
+	})
+	if err != nil {
+		return model.AuthorRename{}, err
+	}
+	return rn, nil
+}
+
+// ListAuthorRenames returns the renames of ctx's tenant's books, oldest
+// first.
+func (r *BoltBookRepo) ListAuthorRenames(ctx context.Context) ([]model.AuthorRename, error) {
+	t := model.TenantFromContext(ctx)
+	out := []model.AuthorRename{}
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltRenames).ForEach(func(_, data []byte) error {
+			var rn model.AuthorRename
+			if err := json.Unmarshal(data, &rn); err != nil {
+				return fmt.Errorf("author rename: %w", err)
+			}
+			if t == "" || rn.Tenant == t {
+				out = append(out, rn)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bolt %s: %w", r.db.Path(), err)
+	}
+	return out, nil
+}
+
+// RenameTags renames the tags in the books of ctx's tenant as
+// BookRepo.RenameTags does, in one transaction.
+func (r *BoltBookRepo) RenameTags(ctx context.Context, rn model.TagRename) (model.TagRename, error) {
+	rn.Tenant = model.TenantFromContext(ctx)
+	err := r.update(func(tx *bolt.Tx) error {
+		rn.BooksUpdated, rn.Changes = 0, nil
+		return renameBoltBooks(ctx, tx, rn.RenamedAt, func(b *model.Book) bool {
+			tags, changed := renameTags(b.Tags, rn.From, rn.To)
+			b.Tags = tags
+			return changed
+		}, func(c model.BookChange) {
+			rn.BooksUpdated++
+			rn.Changes = append(rn.Changes, c)
+		})
+	})
+	if err != nil {
+		return model.TagRename{}, err
+	}
+	return rn, nil
+}
+
+// CheckConsistency checks the index buckets against the books, and with
+// repair rebuilds them from the books.
+func (r *BoltBookRepo) CheckConsistency(_ context.Context, repair bool) (model.ConsistencyReport, error) {
+	var rep model.ConsistencyReport
+	check := func(tx *bolt.Tx) error {
+		rep = model.ConsistencyReport{}
+		want, err := boltIndexEntries(tx)
+		if err != nil {
+			return err
+		}
+		for _, name := range [][]byte{boltBooksCreated, boltBooksISBN, boltBooksTrashed, boltBooksTenant} {
+			entries := want[string(name)]
+			err := tx.Bucket(name).ForEach(func(k, v []byte) error {
+				if id, ok := entries[string(k)]; !ok || id != string(v) {
+					rep.Issues = append(rep.Issues, model.ConsistencyIssue{Kind: "stale_index", Detail: fmt.Sprintf("%s entry %q points to %q", name, k, v), Repaired: repair})
+				}
+				delete(entries, string(k))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for k, id := range entries {
+				rep.Issues = append(rep.Issues, model.ConsistencyIssue{Kind: "missing_index", Detail: fmt.Sprintf("%s has no entry %q for book %q", name, k, id), Repaired: repair})
+			}
+		}
+		if repair && len(rep.Issues) > 0 {
+			return reindexBoltBooks(tx)
+		}
+		return nil
+	}
+	var err error
+	if repair {
+		err = r.db.Update(check)
+	} else {
+		err = r.db.View(check)
+	}
+	if err != nil {
+		return rep, fmt.Errorf("bolt %s: %w", r.db.Path(), err)
+	}
+	return rep, nil
+}
+
+// Add stores an event of a change made without an EventStage.
+func (r *BoltBookRepo) Add(_ context.Context, ev model.Event) error {
+	return r.update(func(tx *bolt.Tx) error { return putBoltEvent(tx, ev) })
+}
+
+func (r *BoltBookRepo) Pending(_ context.Context, limit int) ([]model.Event, error) {
+	var out []model.Event
+	err := r.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(boltOutbox).Cursor()
+		for k, data := c.First(); k != nil && len(out) < limit; k, data = c.Next() {
+			var ev model.Event
+			if err := json.Unmarshal(data, &ev); err != nil {
+				return fmt.Errorf("outbox event: %w", err)
+			}
+			out = append(out, ev)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bolt %s: %w", r.db.Path(), err)
+	}
+	return out, nil
+}
+
+// Ack drops published events. They are the oldest, so the scan stops once
+// it has found them all.
+func (r *BoltBookRepo) Ack(_ context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.update(func(tx *bolt.Tx) error {
+		acked := map[string]bool{}
+		for _, id := range ids {
+			acked[id] = true
+		}
+		var keys [][]byte
+		c := tx.Bucket(boltOutbox).Cursor()
+		for k, data := c.First(); k != nil && len(keys) < len(acked); k, data = c.Next() {
+			var ev struct{ ID string }
+			if err := json.Unmarshal(data, &ev); err != nil {
+				return fmt.Errorf("outbox event: %w", err)
+			}
+			if acked[ev.ID] {
+				keys = append(keys, bytes.Clone(k))
+			}
+		}
+		for _, k := range keys {
+			if err := tx.Bucket(boltOutbox).Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// update runs fn in a write transaction. The repo's own errors are returned
+// as is; those of the database say which it is.
+func (r *BoltBookRepo) update(fn func(tx *bolt.Tx) error) error {
+	var fnErr error
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		fnErr = fn(tx)
+		return fnErr
+	})
+	if err != nil && !errors.Is(err, fnErr) {
+		return fmt.Errorf("bolt %s: %w", r.db.Path(), err)
+	}
+	return err
+}
+
+func getBoltBook(tx *bolt.Tx, id string) (model.Book, error) {
+	data := tx.Bucket(boltBooks).Get([]byte(id))
+	if data == nil {
+		return model.Book{}, errNotFound
+	}
+	var b model.Book
+	if err := json.Unmarshal(data, &b); err != nil {
+		return model.Book{}, fmt.Errorf("book %s: %w", id, err)
+	}
+	return b, nil
+}
+
+// eachBoltBook calls fn with every book of ctx's tenant, read through the
+// tenant index; work outside a tenant reads them all.
+func eachBoltBook(ctx context.Context, tx *bolt.Tx, fn func(b model.Book) error) error {
+	books := tx.Bucket(boltBooks)
+	each := func(data []byte) error {
+		var b model.Book
+		if err := json.Unmarshal(data, &b); err != nil {
+			return err
+		}
+		return fn(b)
+	}
+	t := model.TenantFromContext(ctx)
+	if t == "" {
+		return books.ForEach(func(_, data []byte) error { return each(data) })
+	}
+	prefix := boltTenantPrefix(t)
+	c := tx.Bucket(boltBooksTenant).Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		data := books.Get(k[len(prefix):])
+		if data == nil {
+			return fmt.Errorf("tenant index entry of missing book %s", k[len(prefix):])
+		}
+		if err := each(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameBoltBooks applies rename to the books of ctx's tenant and stores
+// those it changes, as of at, reporting each change.
+func renameBoltBooks(ctx context.Context, tx *bolt.Tx, at time.Time, rename func(b *model.Book) bool, changed func(model.BookChange)) error {
+	var changes []model.BookChange
+	err := eachBoltBook(ctx, tx, func(b model.Book) error {
+		after := copyBook(b)
+		if !rename(&after) {
+			return nil
+		}
+		after.UpdatedAt = at
+		after.Version++
+		changes = append(changes, model.BookChange{Before: b, After: after})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// written after the scan: a bucket must not change while it is iterated
+	for _, c := range changes {
+		if err := putBoltBook(tx, &c.Before, c.After); err != nil {
+			return err
+		}
+		if err := stageBoltEvent(ctx, tx, &c.Before, &c.After); err != nil {
+			return err
+		}
+		changed(c)
+	}
+	return nil
+}
+
+// stageBoltEvent stores the event of the change of before to after in the
+// outbox when ctx carries an EventStage.
+func stageBoltEvent(ctx context.Context, tx *bolt.Tx, before, after *model.Book) error {
+	st := model.EventStageFromContext(ctx)
+	if st == nil {
+		return nil
+	}
+	ev, ok := st.Event(before, after)
+	if !ok {
+		return nil
+	}
+	return putBoltEvent(tx, ev)
+}
+
+func putBoltEvent(tx *bolt.Tx, ev model.Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	outbox := tx.Bucket(boltOutbox)
+	seq, err := outbox.NextSequence()
+	if err != nil {
+		return err
+	}
+	return outbox.Put(binary.BigEndian.AppendUint64(nil, seq), data)
+}
+
+// checkBoltISBN fails when another book outside the trash has b's ISBN.
+func checkBoltISBN(tx *bolt.Tx, b model.Book) error {
+	key := liveISBNKey(b)
+	if key == "" {
+		return nil
+	}
+	if id := tx.Bucket(boltBooksISBN).Get([]byte(key)); id != nil && string(id) != b.ID {
+		return fmt.Errorf("%w: isbn belongs to book %s", model.ErrConflict, id)
+	}
+	return nil
+}
+
+// putBoltBook stores b, which was old unless old is nil, and moves its
+// index entries.
+func putBoltBook(tx *bolt.Tx, old *model.Book, b model.Book) error {
+	if old != nil {
+		if err := unindexBoltBook(tx, *old); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(boltBooks).Put([]byte(b.ID), data); err != nil {
+		return err
+	}
+	for name, key := range boltIndexKeys(b) {
+		if err := tx.Bucket([]byte(name)).Put(key, []byte(b.ID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteBoltBook(tx *bolt.Tx, b model.Book) error {
+	if err := unindexBoltBook(tx, b); err != nil {
+		return err
+	}
+	return tx.Bucket(boltBooks).Delete([]byte(b.ID))
+}
+
+func unindexBoltBook(tx *bolt.Tx, b model.Book) error {
+	for name, key := range boltIndexKeys(b) {
+		if err := tx.Bucket([]byte(name)).Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// boltIndexKeys are the keys b has in each index bucket, by bucket name.
+func boltIndexKeys(b model.Book) map[string][]byte {
+	created := binary.BigEndian.AppendUint64(nil, uint64(b.CreatedAt.UnixNano()))
+	keys := map[string][]byte{
+		string(boltBooksCreated): append(created, b.ID...),
+		string(boltBooksTenant):  append(boltTenantPrefix(b.Tenant), b.ID...),
+	}
+	switch key := bookISBNKey(b); {
+	case key == "":
+	case b.DeletedAt == nil:
+		keys[string(boltBooksISBN)] = []byte(key)
+	default:
+		k := append([]byte(key), 0xff)
+		k = binary.BigEndian.AppendUint64(k, uint64(b.DeletedAt.UnixNano()))
+		keys[string(boltBooksTrashed)] = append(k, b.ID...)
+	}
+	return keys
+}
+
+func boltTenantPrefix(tenant string) []byte {
+	return append([]byte(tenant), 0)
+}
+
+// boltIndexEntries are the entries the index buckets should hold, by
+// bucket name and then key.
+func boltIndexEntries(tx *bolt.Tx) (map[string]map[string]string, error) {
+	want := map[string]map[string]string{}
+	for _, name := range [][]byte{boltBooksCreated, boltBooksISBN, boltBooksTrashed, boltBooksTenant} {
+		want[string(name)] = map[string]string{}
+	}
+	err := tx.Bucket(boltBooks).ForEach(func(id, data []byte) error {
+		var b model.Book
+		if err := json.Unmarshal(data, &b); err != nil {
+			return fmt.Errorf("book %s: %w", id, err)
+		}
+		for name, key := range boltIndexKeys(b) {
+			want[name][string(key)] = b.ID
+		}
+		return nil
+	})
+	return want, err
+}
+
+// reindexBoltBooks rebuilds the index buckets from the books.
+func reindexBoltBooks(tx *bolt.Tx) error {
+	want, err := boltIndexEntries(tx)
+	if err != nil {
+		return err
+	}
+	for name, entries := range want {
+		if err := tx.DeleteBucket([]byte(name)); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+			return err
+		}
+		bk, err := tx.CreateBucket([]byte(name))
+		if err != nil {
+			return err
+		}
+		for k, id := range entries {
+			if err := bk.Put([]byte(k), []byte(id)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// boltRecords keeps the records of a store in a bucket of a bbolt
+// database, one transaction per write.
+type boltRecords[T any] struct {
+	db     *bolt.DB
+	bucket []byte
+	broken error
+}
+
+// openBoltRecords creates bucket in db if need be and returns the records
+// it keeps by ID.
+func openBoltRecords[T any](db *bolt.DB, bucket string) (*boltRecords[T], map[string]T, error) {
+	b := &boltRecords[T]{db: db, bucket: []byte(bucket)}
+	records := map[string]T{}
+	err := db.Update(func(tx *bolt.Tx) error {
+		bk, err := tx.CreateBucketIfNotExists(b.bucket)
+		if err != nil {
+			return err
+		}
+		return bk.ForEach(func(id, data []byte) error {
+			var v T
+			if err := json.Unmarshal(data, &v); err != nil {
+				return fmt.Errorf("%s %s: %w", bucket, id, err)
+			}
+			records[string(id)] = v
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("bolt %s: %w", db.Path(), err)
+	}
+	return b, records, nil
+}
+
+// Close leaves the database open for the other stores; its opener closes
+// it.
+func (b *boltRecords[T]) Close() error { return nil }
+
+func (b *boltRecords[T]) writable() error { return b.broken }
+
+func (b *boltRecords[T]) put(id string, v T) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.write(func(bk *bolt.Bucket) error { return bk.Put([]byte(id), data) })
+}
+
+func (b *boltRecords[T]) delete(id string) error {
+	return b.write(func(bk *bolt.Bucket) error { return bk.Delete([]byte(id)) })
+}
+
+// write runs fn on the bucket in a transaction; callers hold the store's
+// lock.
+func (b *boltRecords[T]) write(fn func(bk *bolt.Bucket) error) error {
+	if b.broken != nil {
+		return b.broken
+	}
+	if err := b.db.Update(func(tx *bolt.Tx) error { return fn(tx.Bucket(b.bucket)) }); err != nil {
+		b.broken = fmt.Errorf("bolt %s: %w", b.db.Path(), err)
+		return b.broken
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestBoltBookRepo_SurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "books.db")
+	ctx := context.Background()
+	db, err := OpenBoltDB(path)
+	require.NoError(t, err)
+	r, err := OpenBoltBookRepo(db)
+	require.NoError(t, err)
+
+	// created out of order; the index brings them back by created_at
+	_, err = r.Create(ctx, model.Book{ID: "b2", Title: "Two", CreatedAt: time.Unix(2, 0)})
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Book{ID: "b1", Title: "One", ISBN: util.GetPtr("9780134494166"), Authors: []string{"Bob"}, CreatedAt: time.Unix(1, 0)})
+	require.NoError(t, err)
+	_, err = r.Update(ctx, model.Book{ID: "b2", Version: 1, Title: "Two v2", Tags: []string{"scifi"}, CreatedAt: time.Unix(2, 0)})
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Book{ID: "b3", Title: "Three", CreatedAt: time.Unix(3, 0)})
+	require.NoError(t, err)
+	require.NoError(t, r.Delete(ctx, "b3"))
+	_, err = r.Create(ctx, model.Book{ID: "b2", Title: "Dup"})
+	assert.Error(t, err)
+	_, err = r.RenameAuthor(ctx, model.AuthorRename{From: "bob", To: "Robert", RenamedAt: time.Unix(4, 0)})
+	require.NoError(t, err)
+	_, err = r.RenameTags(ctx, model.TagRename{From: []string{"scifi"}, To: "sf", RenamedAt: time.Unix(5, 0)})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = OpenBoltDB(path)
+	require.NoError(t, err)
+	defer db.Close()
+	r, err = OpenBoltBookRepo(db)
+	require.NoError(t, err)
+	page, err := r.List(ctx, model.ListQuery{Page: 1, PageSize: 10, Sort: []model.SortKey{{Field: "created_at"}}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b1", "b2"}, ids(page.Data))
+	assert.Equal(t, "Two v2", page.Data[1].Title)
+	assert.Equal(t, []string{"Robert"}, page.Data[0].Authors)
+	assert.Equal(t, 2, page.Data[0].Version, "renames count as a write")
+	assert.Equal(t, []string{"sf"}, page.Data[1].Tags)
+	assert.Equal(t, 3, page.Data[1].Version)
+
+	got, err := r.GetByISBN(ctx, "", "978-0-13-449416-6")
+	require.NoError(t, err)
+	assert.Equal(t, "b1", got.ID)
+	renames, err := r.ListAuthorRenames(ctx)
+	require.NoError(t, err)
+	require.Len(t, renames, 1)
+	assert.Equal(t, 1, renames[0].BooksUpdated)
+	assert.Empty(t, renames[0].Changes)
+
+	err = db.View(func(tx *bolt.Tx) error {
+		assert.Equal(t, 2, tx.Bucket(boltBooks).Stats().KeyN)
+		assert.Equal(t, 2, tx.Bucket(boltBooksCreated).Stats().KeyN, "the index follows deletes")
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestBoltRecords_LendingSurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "books.db")
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	db, err := OpenBoltDB(path)
+	require.NoError(t, err)
+	loans, err := OpenBoltLoanRepo(db)
+	require.NoError(t, err)
+	fees, err := OpenBoltFeeRepo(db)
+	require.NoError(t, err)
+	_, err = loans.Checkout(ctx, model.Loan{ID: "l-1", BookID: "b1", Borrower: "ada", CheckedOutAt: now, DueAt: now.Add(time.Hour)}, 1)
+	require.NoError(t, err)
+	_, err = loans.MarkLost(ctx, "l-1", now)
+	require.NoError(t, err)
+	_, err = fees.Add(ctx, model.FeeEntry{ID: "f-1", Borrower: "ada", Kind: model.FeeFine, Amount: model.Price{Amount: 50, Currency: "EUR"}, LoanID: "l-1", At: now})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = OpenBoltDB(path)
+	require.NoError(t, err)
+	defer db.Close()
+	loans, err = OpenBoltLoanRepo(db)
+	require.NoError(t, err)
+	l, err := loans.Get(ctx, "l-1")
+	require.NoError(t, err)
+	assert.NotNil(t, l.LostAt)
+	fees, err = OpenBoltFeeRepo(db)
+	require.NoError(t, err)
+	entries, err := fees.List(ctx, "ada")
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+	holds, err := OpenBoltHoldRepo(db)
+	require.NoError(t, err)
+	got, err := holds.List(ctx, "b1")
+	require.NoError(t, err)
+	assert.Empty(t, got, "buckets are created on first open")
+}
+
+func TestBoltBookRepo_ReadsFromTheDatabase(t *testing.T) {
+	db, err := OpenBoltDB(filepath.Join(t.TempDir(), "books.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	east := model.WithTenant(context.Background(), "east")
+	w, err := OpenBoltBookRepo(db)
+	require.NoError(t, err)
+	r, err := OpenBoltBookRepo(db)
+	require.NoError(t, err)
+
+	// written through one repo, read through the other
+	isbn := util.GetPtr("9780134494166")
+	_, err = w.Create(east, model.Book{ID: "b1", Title: "One", ISBN: isbn, Tenant: "east", CreatedAt: time.Unix(1, 0)})
+	require.NoError(t, err)
+	got, err := r.GetByISBN(east, "", "978-0134494166")
+	require.NoError(t, err)
+	assert.Equal(t, "b1", got.ID)
+	_, err = r.GetByISBN(model.WithTenant(context.Background(), "west"), "", *isbn)
+	assert.ErrorIs(t, err, errNotFound, "ISBNs are indexed per tenant")
+
+	// the trashed book is found by its ISBN until another one takes it
+	deleted := time.Unix(2, 0)
+	stale := got
+	got.DeletedAt = &deleted
+	got, err = r.Update(east, got)
+	require.NoError(t, err)
+	_, err = w.Create(east, model.Book{ID: "b2", Title: "Two", ISBN: isbn, Tenant: "east", CreatedAt: time.Unix(3, 0)})
+	require.NoError(t, err)
+	again, err := r.GetByISBN(east, "", *isbn)
+	require.NoError(t, err)
+	assert.Equal(t, "b2", again.ID)
+	_, err = r.Update(east, stale)
+	assert.ErrorIs(t, err, model.ErrConflict, "stale version")
+	got.DeletedAt = nil
+	_, err = r.Update(east, got)
+	assert.ErrorIs(t, err, model.ErrConflict, "the ISBN is taken")
+
+	_, err = w.CreateCapped(east, model.Book{ID: "b3", Title: "Three", Tenant: "east"}, 2)
+	assert.ErrorIs(t, err, model.ErrQuota, "trashed books count")
+	_, err = w.CreateCapped(east, model.Book{ID: "b3", Title: "Three", Tenant: "east"}, 3)
+	require.NoError(t, err)
+	n, err := r.Count(east, model.ListQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	n, err = r.Count(model.WithTenant(context.Background(), "west"), model.ListQuery{IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	rep, err := r.CheckConsistency(context.Background(), false)
+	require.NoError(t, err)
+	assert.Empty(t, rep.Issues)
+}
+
+func TestBoltBookRepo_Reindex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "books.db")
+	ctx := context.Background()
+	db, err := OpenBoltDB(path)
+	require.NoError(t, err)
+	r, err := OpenBoltBookRepo(db)
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Book{ID: "b1", Title: "One", ISBN: util.GetPtr("9780134494166")})
+	require.NoError(t, err)
+
+	// a database written before the ISBN and tenant indexes
+	require.NoError(t, db.Update(func(tx *bolt.Tx) error {
+		require.NoError(t, tx.DeleteBucket(boltBooksISBN))
+		return tx.DeleteBucket(boltBooksTenant)
+	}))
+	r, err = OpenBoltBookRepo(db)
+	require.NoError(t, err)
+	got, err := r.GetByISBN(ctx, "", "9780134494166")
+	require.NoError(t, err)
+	assert.Equal(t, "b1", got.ID)
+
+	// an index entry lost otherwise is reported and repaired
+	require.NoError(t, db.Update(func(tx *bolt.Tx) error { return tx.Bucket(boltBooksISBN).Delete([]byte("9780134494166")) }))
+	rep, err := r.CheckConsistency(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, rep.Issues, 1)
+	assert.Equal(t, "missing_index", rep.Issues[0].Kind)
+	_, err = r.CheckConsistency(ctx, true)
+	require.NoError(t, err)
+	rep, err = r.CheckConsistency(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, rep.Issues)
+	require.NoError(t, db.Close())
+}
+
+func TestBoltRecords_CatalogSurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "books.db")
+	ctx := model.WithTenant(context.Background(), "east")
+	now := time.Now().UTC().Truncate(time.Second)
+	db, err := OpenBoltDB(path)
+	require.NoError(t, err)
+	authors, err := OpenBoltAuthorRepo(db)
+	require.NoError(t, err)
+	branches, err := OpenBoltBranchRepo(db)
+	require.NoError(t, err)
+	reviews, err := OpenBoltReviewRepo(db)
+	require.NoError(t, err)
+	shelves, err := OpenBoltBookshelfRepo(db)
+	require.NoError(t, err)
+	progress, err := OpenBoltProgressRepo(db)
+	require.NoError(t, err)
+
+	_, err = authors.Create(ctx, model.Author{ID: "a1", Name: "Ursula K. Le Guin", Tenant: "east"})
+	require.NoError(t, err)
+	_, err = authors.Create(ctx, model.Author{ID: "a2", Name: "Gone", Tenant: "east"})
+	require.NoError(t, err)
+	require.NoError(t, authors.Delete(ctx, "a2"))
+	_, err = branches.Create(ctx, model.Branch{ID: "main", Name: "Main", Tenant: "east"})
+	require.NoError(t, err)
+	_, err = reviews.Add(ctx, model.Review{ID: "r1", BookID: "b1", Reviewer: "jwt:ada", Rating: 5, Tenant: "east"})
+	require.NoError(t, err)
+	_, err = shelves.Create(ctx, model.Bookshelf{ID: "s1", User: "ada", Name: "Favourites"})
+	require.NoError(t, err)
+	require.NoError(t, shelves.Put(ctx, "ada", "s1", model.ShelfItem{BookID: "b1", AddedAt: now}, nil))
+	require.NoError(t, progress.Add(ctx, "ada", model.ReadingProgress{BookID: "b1", Percent: util.GetPtr(40), At: now}))
+	require.NoError(t, db.Close())
+
+	db, err = OpenBoltDB(path)
+	require.NoError(t, err)
+	defer db.Close()
+	authors, err = OpenBoltAuthorRepo(db)
+	require.NoError(t, err)
+	a, err := authors.GetByName(ctx, "ursula k. le guin")
+	require.NoError(t, err, "the name index is rebuilt")
+	assert.Equal(t, "a1", a.ID)
+	_, err = authors.GetByID(ctx, "a2")
+	assert.Error(t, err)
+	branches, err = OpenBoltBranchRepo(db)
+	require.NoError(t, err)
+	_, err = branches.Get(ctx, "main")
+	assert.NoError(t, err)
+	reviews, err = OpenBoltReviewRepo(db)
+	require.NoError(t, err)
+	ratings, err := reviews.Ratings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, model.Rating{Average: 5, Count: 1}, ratings["b1"])
+	shelves, err = OpenBoltBookshelfRepo(db)
+	require.NoError(t, err)
+	list, err := shelves.List(ctx, "ada")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	items, err := shelves.Items(ctx, "ada", "s1")
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+	progress, err = OpenBoltProgressRepo(db)
+	require.NoError(t, err)
+	history, err := progress.History(ctx, "ada", "b1")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, 40, *history[0].Percent)
+}
@@ -4,6 +4,9 @@ import (
 	"book-manager/internal/core/model"
 	"context"
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -12,22 +15,53 @@ import (
 var (
 	errNotFound = errors.New("not found")
 	errConflict = errors.New("conflict")
+	// errStale matches model.ErrConflict so the service can report it as is.
+	errStale = fmt.Errorf("%w: book was changed concurrently", model.ErrConflict)
 )
 
 type BookRepo struct {
-	mu     sync.RWMutex
-	byID   map[string]model.Book // id -> Book
-	byISBN map[string]string     // normalized ISBN -> id
+	mu      sync.RWMutex
+	byID    map[string]model.Book // id -> Book
+	byISBN  map[string]string     // isbnIndexKey -> id, of books outside the trash
+	trashed map[string][]string   // isbnIndexKey -> ids of trashed books, oldest first
+	renames []model.AuthorRename  // author rename history, oldest first
+	snaps   *listSnapshots
 }
 
 func NewBookRepo() *BookRepo {
-	return &BookRepo{byID: make(map[string]model.Book), byISBN: make(map[string]string)}
+	return &BookRepo{
+		byID:    make(map[string]model.Book),
+		byISBN:  make(map[string]string),
+		trashed: make(map[string][]string),
+		snaps:   newListSnapshots(snapshotTTL),
+	}
 }
 
 func (r *BookRepo) Create(_ context.Context, b model.Book) (model.Book, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	return r.create(b)
+}
 
+// CreateCapped stores b unless its tenant already keeps limit books, counted
+// under the same lock as the insert.
+func (r *BookRepo) CreateCapped(_ context.Context, b model.Book, limit int) (model.Book, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, other := range r.byID {
+		if other.Tenant == b.Tenant {
+			n++
+		}
+	}
+	if n >= limit {
+		return model.Book{}, fmt.Errorf("%w: tenant %s keeps its limit of %d books", model.ErrQuota, b.Tenant, limit)
+	}
+	return r.create(b)
+}
+
+// create stores b; callers hold mu.
+func (r *BookRepo) create(b model.Book) (model.Book, error) {
 	if b.ID == "" {
 		return model.Book{}, errConflict
 	}
@@ -35,36 +69,78 @@ func (r *BookRepo) Create(_ context.Context, b model.Book) (model.Book, error) {
 		return model.Book{}, errConflict
 	}
 
-	if b.ISBN != nil {
-		key := normalizeISBN(*b.ISBN)
-		if key != "" {
-			if _, exists := r.byISBN[key]; exists {
-				return model.Book{}, errConflict
-			}
-			r.byISBN[key] = b.ID
+	if key := liveISBNKey(b); key != "" {
+		if id, exists := r.byISBN[key]; exists {
+			return model.Book{}, fmt.Errorf("%w: isbn belongs to book %s", model.ErrConflict, id)
+		}
+	}
+	r.index(b)
+	if b.Version == 0 {
+		b.Version = 1
+	}
+	r.byID[b.ID] = b
+	return copyBook(b), nil
+}
+
+// Update replaces the stored book with the same ID, keeping the ISBN index in
+// sync. It fails with errStale unless the stored book is at b.Version.
+func (r *BookRepo) Update(ctx context.Context, b model.Book) (model.Book, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.byID[b.ID]; ok && !inTenant(ctx, old) {
+		return model.Book{}, errNotFound
+	}
+	return r.replace(b, true)
+}
+
+// replace stores b over the book with the same ID. With checkVersion, b must
+// be based on the stored version and gets the next one; otherwise b keeps
+// its own version, as when replaying a journal, and journals written before
+// books had versions get the next one.
+func (r *BookRepo) replace(b model.Book, checkVersion bool) (model.Book, error) {
+	old, ok := r.byID[b.ID]
+	if !ok {
+		return model.Book{}, errNotFound
+	}
+	if checkVersion && b.Version != old.Version {
+		return model.Book{}, errStale
+	}
+	if checkVersion || b.Version == 0 {
+		b.Version = old.Version + 1
+	}
+	if key := liveISBNKey(b); key != "" {
+		if id, exists := r.byISBN[key]; exists && id != b.ID {
+			return model.Book{}, fmt.Errorf("%w: isbn belongs to book %s", model.ErrConflict, id)
 		}
 	}
+	r.unindex(old)
+	r.index(b)
+	b = copyBook(b)
 	r.byID[b.ID] = b
 	return copyBook(b), nil
 }
 
-func (r *BookRepo) GetByID(_ context.Context, id string) (model.Book, error) {
+func (r *BookRepo) GetByID(ctx context.Context, id string) (model.Book, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	b, ok := r.byID[id]
-	if !ok {
-		return model.Book{}, errNotFound
+	if !ok || !inTenant(ctx, b) {
+		return model.Book{}, fmt.Errorf("%w: book %s", model.ErrNotFound, id)
 	}
 	return copyBook(b), nil
 }
 
-func (r *BookRepo) GetByISBN(_ context.Context, isbn string) (model.Book, error) {
+func (r *BookRepo) GetByISBN(ctx context.Context, owner, isbn string) (model.Book, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	key := normalizeISBN(isbn)
+	key := isbnIndexKey(isbnScope(model.TenantFromContext(ctx), owner), normalizeISBN(isbn))
 	id, ok := r.byISBN[key]
 	if !ok {
-		return model.Book{}, errNotFound
+		ids := r.trashed[key]
+		if len(ids) == 0 {
+			return model.Book{}, errNotFound
+		}
+		id = ids[len(ids)-1]
 	}
 	b, ok := r.byID[id]
 	if !ok {
@@ -81,26 +157,58 @@ func (r *BookRepo) GetByISBN(_ context.Context, isbn string) (model.Book, error)
 //  3. Sort the filtered books according to the provided sort keys
 //     (supports multi-field, ASC/DESC). Defaults to created_at DESC.
 //  4. Apply pagination (page / page_size).
-func (r *BookRepo) List(_ context.Context, q model.ListQuery) (model.Page[model.Book], error) {
-	r.mu.RLock()
-	// snapshot ids to avoid holding lock during sort
-	items := make([]model.Book, 0, len(r.byID))
-	for _, b := range r.byID {
-		items = append(items, copyBook(b))
-	}
-	r.mu.RUnlock()
+//
+// With q.SnapshotID steps 1-3 are skipped and the pinned result of an earlier
+// q.Snapshot request is paginated instead, keeping that request's filters and
+// sort order.
+//
+// With q.Cursor the filters and sort come from the cursor and the page
+// starts after the book it was made from; the snapshot options do not apply.
+//
+// Only the books of ctx's tenant are listed, pinned ones included.
+func (r *BookRepo) List(ctx context.Context, q model.ListQuery) (model.Page[model.Book], error) {
+	return listBooks(ctx, q, r.snaps, func(q model.ListQuery) ([]model.Book, error) {
+		return filterAndSort(r.tenantBooks(ctx), q), nil
+	})
+}
 
-	// filters
-	out := items[:0]
-	for _, b := range items {
-		if !matchFilters(b, q) {
-			continue
+// listBooks pages the books of a repository as described at BookRepo.List:
+// query returns the books matching q in order, snaps keeps the pinned
+// results.
+func listBooks(ctx context.Context, q model.ListQuery, snaps *listSnapshots, query func(q model.ListQuery) ([]model.Book, error)) (model.Page[model.Book], error) {
+	var after *model.Book
+	if q.Cursor != "" {
+		if q.Snapshot || q.SnapshotID != "" {
+			return model.Page[model.Book]{}, fmt.Errorf("%w: cursor cannot be combined with snapshots", model.ErrValidation)
 		}
-		out = append(out, b)
+		last, err := applyCursor(&q)
+		if err != nil {
+			return model.Page[model.Book]{}, err
+		}
+		after = &last
+	}
+	keys := q.Sort
+	if len(keys) == 0 {
+		keys = defaultSort
 	}
 
-	// sort
-	sortBooks(out, q.Sort)
+	var out []model.Book
+	snapshotID := q.SnapshotID
+	if snapshotID != "" {
+		pinned, ok := snaps.get(snapshotID)
+		if !ok {
+			return model.Page[model.Book]{}, errNotFound
+		}
+		out = ownedBy(ctx, pinned, q.Owner)
+	} else {
+		var err error
+		if out, err = query(q); err != nil {
+			return model.Page[model.Book]{}, err
+		}
+		if q.Snapshot {
+			snapshotID = snaps.save(out)
+		}
+	}
 
 	// pagination
 	page := q.Page
@@ -113,6 +221,10 @@ func (r *BookRepo) List(_ context.Context, q model.ListQuery) (model.Page[model.
 	}
 	total := len(out)
 	start := (page - 1) * size
+	if after != nil {
+		page = 0
+		start = sort.Search(total, func(i int) bool { return bookLess(after, &out[i], keys) })
+	}
 	if start > total {
 		start = total
 	}
@@ -120,31 +232,274 @@ func (r *BookRepo) List(_ context.Context, q model.ListQuery) (model.Page[model.
 	if end > total {
 		end = total
 	}
-	paged := make([]model.Book, end-start)
-	copy(paged, out[start:end])
+	paged := make([]model.Book, 0, end-start)
+	for _, b := range out[start:end] {
+		paged = append(paged, copyBook(b))
+	}
+	next := ""
+	if end < total && snapshotID == "" {
+		next = encodeCursor(q, keys, out[end-1])
+	}
+
+	return model.Page[model.Book]{Data: paged, Page: page, PageSize: size, SnapshotID: snapshotID, NextCursor: next}, nil
+}
+
+func (r *BookRepo) Count(ctx context.Context, q model.ListQuery) (int, error) {
+	return countBooks(ctx, q, r.snaps, func(match func(model.Book) bool) (int, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		n := 0
+		for _, b := range r.byID {
+			if inTenant(ctx, b) && match(b) {
+				n++
+			}
+		}
+		return n, nil
+	})
+}
+
+// countBooks counts the books matching q, pinned or as count counts the
+// books of ctx's tenant that match.
+func countBooks(ctx context.Context, q model.ListQuery, snaps *listSnapshots, count func(match func(model.Book) bool) (int, error)) (int, error) {
+	if q.SnapshotID != "" {
+		pinned, ok := snaps.get(q.SnapshotID)
+		if !ok {
+			return 0, errNotFound
+		}
+		return len(ownedBy(ctx, pinned, q.Owner)), nil
+	}
+	if q.Cursor != "" {
+		if _, err := applyCursor(&q); err != nil {
+			return 0, err
+		}
+	}
+	return count(func(b model.Book) bool { return matchFilters(b, q) })
+}
+
+// ownedBy keeps the books of owner and ctx's tenant from a pinned result,
+// or all of them when neither is set: snapshots do not belong to a user or
+// tenant.
+func ownedBy(ctx context.Context, books []model.Book, owner *string) []model.Book {
+	if owner == nil && model.TenantFromContext(ctx) == "" {
+		return books
+	}
+	return slices.DeleteFunc(slices.Clone(books), func(b model.Book) bool {
+		return !inTenant(ctx, b) || owner != nil && b.Owner != *owner
+	})
+}
+
+// inTenant reports whether b belongs to ctx's tenant; work outside a
+// tenant's requests sees every book.
+func inTenant(ctx context.Context, b model.Book) bool {
+	return ofTenant(ctx, b.Tenant)
+}
+
+// ofTenant reports whether a record of tenant is visible to ctx: the
+// record belongs to ctx's tenant, or ctx is outside a tenant. Every repo
+// filters by it, so a tenant never sees another tenant's records.
+func ofTenant(ctx context.Context, tenant string) bool {
+	t := model.TenantFromContext(ctx)
+	return t == "" || tenant == t
+}
+
+// tenantKey is what records named uniquely within a tenant, such as a
+// user's shelves or a branch, are kept by, so each tenant has its own.
+// Tenant ids hold no slash, so keys never collide.
+func tenantKey(ctx context.Context, name string) string {
+	return model.TenantFromContext(ctx) + "/" + name
+}
+
+// tenantBooks copies the books of ctx's tenant, so they can be sorted
+// without holding the lock.
+func (r *BookRepo) tenantBooks(ctx context.Context) []model.Book {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	items := make([]model.Book, 0, len(r.byID))
+	for _, b := range r.byID {
+		if inTenant(ctx, b) {
+			items = append(items, copyBook(b))
+		}
+	}
+	return items
+}
+
+// filterAndSort keeps the items matching q, in q's order; it reuses items.
+func filterAndSort(items []model.Book, q model.ListQuery) []model.Book {
+	// filters
+	out := items[:0]
+	for _, b := range items {
+		if !matchFilters(b, q) {
+			continue
+		}
+		if rt, ok := q.Ratings[b.ID]; ok {
+			b.Rating = &rt
+		}
+		out = append(out, b)
+	}
 
-	return model.Page[model.Book]{Data: paged, Page: page, PageSize: size, Total: total}, nil
+	// sort
+	sortBooks(out, q.Sort)
+	return out
 }
 
-func (r *BookRepo) Delete(_ context.Context, id string) error {
+func (r *BookRepo) Delete(ctx context.Context, id string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	b, ok := r.byID[id]
-	if !ok {
+	if !ok || !inTenant(ctx, b) {
 		return errNotFound
 	}
-	if b.ISBN != nil {
-		key := normalizeISBN(*b.ISBN)
-		delete(r.byISBN, key)
-	}
+	r.unindex(b)
 	delete(r.byID, id)
 	return nil
 }
 
+// RenameAuthor replaces rn.From (case-insensitive) with rn.To in every book
+// while holding the write lock, so readers never see a half-renamed catalog.
+// Books that already list rn.To keep a single entry. The rename is added to the
+// history only when at least one book changed, and rn.Changes lists the
+// changed books. Only the books of ctx's tenant are renamed.
+func (r *BookRepo) RenameAuthor(ctx context.Context, rn model.AuthorRename) (model.AuthorRename, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rn.Tenant = model.TenantFromContext(ctx)
+	for id, b := range r.byID {
+		if !inTenant(ctx, b) {
+			continue
+		}
+		authors, authorIDs, changed := renameAuthor(b.Authors, b.AuthorIDs, rn.From, rn.To, rn.ToID)
+		if !changed {
+			continue
+		}
+		before := b
+		b = copyBook(b)
+		b.Authors = authors
+		b.AuthorIDs = authorIDs
+		b.UpdatedAt = rn.RenamedAt
+		b.Version++
+		r.byID[id] = b
+		rn.BooksUpdated++
+		rn.Changes = append(rn.Changes, model.BookChange{Before: copyBook(before), After: copyBook(b)})
+	}
+	if rn.BooksUpdated > 0 {
+		kept := rn
+		kept.Changes = nil
+		r.renames = append(r.renames, kept)
+	}
+	return rn, nil
+}
+
+// ListAuthorRenames returns the renames of ctx's tenant's books.
+func (r *BookRepo) ListAuthorRenames(ctx context.Context) ([]model.AuthorRename, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t := model.TenantFromContext(ctx)
+	out := make([]model.AuthorRename, 0, len(r.renames))
+	for _, rn := range r.renames {
+		if t == "" || rn.Tenant == t {
+			out = append(out, rn)
+		}
+	}
+	return out, nil
+}
+
+// RenameTags replaces the tags in rn.From with rn.To in every book under one
+// lock, so readers see all books renamed or none. A book that ends up with
+// To twice keeps one, and rn.Changes lists the changed books. Only the books
+// of ctx's tenant are renamed.
+func (r *BookRepo) RenameTags(ctx context.Context, rn model.TagRename) (model.TagRename, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rn.Tenant = model.TenantFromContext(ctx)
+	for id, b := range r.byID {
+		if !inTenant(ctx, b) {
+			continue
+		}
+		tags, changed := renameTags(b.Tags, rn.From, rn.To)
+		if !changed {
+			continue
+		}
+		before := b
+		b = copyBook(b)
+		b.Tags = tags
+		b.UpdatedAt = rn.RenamedAt
+		b.Version++
+		r.byID[id] = b
+		rn.BooksUpdated++
+		rn.Changes = append(rn.Changes, model.BookChange{Before: copyBook(before), After: copyBook(b)})
+	}
+	return rn, nil
+}
+
+func renameTags(tags, from []string, to string) ([]string, bool) {
+	if !slices.ContainsFunc(tags, func(t string) bool { return slices.Contains(from, t) }) {
+		return tags, false
+	}
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if slices.Contains(from, t) {
+			t = to
+		}
+		if !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out, true
+}
+
+// renameAuthor replaces from with to in authors. When ids is linked
+// (parallel to authors) it is kept aligned, and renamed entries get toID if
+// one is given.
+func renameAuthor(authors, ids []string, from, to, toID string) ([]string, []string, bool) {
+	linked := len(ids) == len(authors)
+	found := false
+	out := make([]string, 0, len(authors))
+	var outIDs []string
+	for i, a := range authors {
+		id := ""
+		if linked {
+			id = ids[i]
+		}
+		if strings.EqualFold(a, from) {
+			a = to
+			if toID != "" {
+				id = toID
+			}
+			found = true
+		}
+		if containsFold(out, a) {
+			continue
+		}
+		out = append(out, a)
+		if linked {
+			outIDs = append(outIDs, id)
+		}
+	}
+	if !found {
+		return authors, ids, false
+	}
+	if !linked {
+		outIDs = ids
+	}
+	return out, outIDs, true
+}
+
+func containsFold(values []string, v string) bool {
+	for _, x := range values {
+		if strings.EqualFold(x, v) {
+			return true
+		}
+	}
+	return false
+}
+
 // safe copy for slices
 func copyBook(b model.Book) model.Book {
 	b.Tags = append([]string(nil), b.Tags...)
 	b.Authors = append([]string(nil), b.Authors...)
+	b.AuthorIDs = append([]string(nil), b.AuthorIDs...)
+	b.Copies = maps.Clone(b.Copies)
+	b.Items = slices.Clone(b.Items)
 	return b
 }
 
@@ -154,8 +509,98 @@ func normalizeISBN(s string) string {
 	return s
 }
 
+// isbnIndexKey is the ISBN index key of a normalized ISBN: the ISBN itself
+// for books outside any scope, else prefixed by the scope, as ISBNs are
+// unique per scope; see isbnScope.
+func isbnIndexKey(scope, norm string) string {
+	if scope == "" {
+		return norm
+	}
+	return scope + "\x00" + norm
+}
+
+// isbnScope is the scope ISBNs are unique in: the owner within the
+// tenant.
+func isbnScope(tenant, owner string) string {
+	if tenant == "" {
+		return owner
+	}
+	return tenant + "\x00" + owner
+}
+
+// splitISBNIndexKey is the reverse of isbnIndexKey, up to normalization.
+func splitISBNIndexKey(key string) (scope, isbn string) {
+	if i := strings.LastIndexByte(key, 0); i >= 0 {
+		return key[:i], key[i+1:]
+	}
+	return "", key
+}
+
+// bookISBNKey is b's ISBN index key, or "" when it has no ISBN.
+func bookISBNKey(b model.Book) string {
+	if b.ISBN == nil || normalizeISBN(*b.ISBN) == "" {
+		return ""
+	}
+	return isbnIndexKey(isbnScope(b.Tenant, b.Owner), normalizeISBN(*b.ISBN))
+}
+
+// liveISBNKey is the key b holds in byISBN, or "" when it has no ISBN or is
+// in the trash: only books outside the trash keep their ISBNs unique.
+func liveISBNKey(b model.Book) string {
+	if b.DeletedAt != nil {
+		return ""
+	}
+	return bookISBNKey(b)
+}
+
+// index adds b to the ISBN index of its kind. Callers hold mu.
+func (r *BookRepo) index(b model.Book) {
+	key := bookISBNKey(b)
+	switch {
+	case key == "":
+	case b.DeletedAt == nil:
+		r.byISBN[key] = b.ID
+	default:
+		r.trashed[key] = append(r.trashed[key], b.ID)
+	}
+}
+
+// unindex removes b, as stored, from the ISBN indexes. Callers hold mu.
+func (r *BookRepo) unindex(b model.Book) {
+	key := bookISBNKey(b)
+	switch {
+	case key == "":
+	case b.DeletedAt == nil:
+		if r.byISBN[key] == b.ID {
+			delete(r.byISBN, key)
+		}
+	default:
+		ids := slices.DeleteFunc(r.trashed[key], func(id string) bool { return id == b.ID })
+		if len(ids) == 0 {
+			delete(r.trashed, key)
+		} else {
+			r.trashed[key] = ids
+		}
+	}
+}
+
 // matchFilters checks whether a book matches the given query filters.
 func matchFilters(b model.Book, q model.ListQuery) bool {
+	if b.DeletedAt != nil && !q.IncludeDeleted {
+		return false
+	}
+	if q.Branch != nil && b.Copies[*q.Branch] == 0 {
+		return false
+	}
+	if q.Owner != nil && b.Owner != *q.Owner {
+		return false
+	}
+	if q.Barcode != nil && !slices.ContainsFunc(b.Items, func(it model.Item) bool { return it.Barcode == *q.Barcode }) {
+		return false
+	}
+	if q.Available != nil && *q.Available == (q.LentOut[b.ID] || len(b.Items) > 0 && !slices.ContainsFunc(b.Items, model.Item.Lendable)) {
+		return false
+	}
 	// Full-text search: title or subtitle contains the query (case-insensitive)
 	// q: title or subtitle contains (case-insensitive)
 	if q.Q != nil {
@@ -186,11 +631,11 @@ func matchFilters(b model.Book, q model.ListQuery) bool {
 		}
 	}
 
-	// tag: exact match
+	// tag: exact match, or also nested tags
 	if q.Tag != nil {
 		found := false
 		for _, t := range b.Tags {
-			if t == *q.Tag {
+			if t == *q.Tag || q.IncludeChildren && strings.HasPrefix(t, *q.Tag+model.TagSeparator) {
 				found = true
 				break
 			}
@@ -200,76 +645,98 @@ func matchFilters(b model.Book, q model.ListQuery) bool {
 		}
 	}
 
-	// year: exact
+	// year: exact, or within the range, which leaves out books without one
 	if q.Year != nil {
 		if b.PublishedYear == nil || *b.PublishedYear != *q.Year {
 			return false
 		}
 	}
+	if q.YearFrom != nil && (b.PublishedYear == nil || *b.PublishedYear < *q.YearFrom) {
+		return false
+	}
+	if q.YearTo != nil && (b.PublishedYear == nil || *b.PublishedYear > *q.YearTo) {
+		return false
+	}
+	if q.UpdatedSince != nil && b.UpdatedAt.Before(*q.UpdatedSince) {
+		return false
+	}
 	return true
 }
 
+// defaultSort is the list order when the query sets none.
+var defaultSort = []model.SortKey{{Field: "created_at", Desc: true}}
+
 // sortBooks sorts books in-place by the provided sort keys.
-// Supports multiple fields (title, published_year, created_at, updated_at).
+// Supports multiple fields (title, published_year, created_at, updated_at, rating).
 // Falls back to ID for stability.
 func sortBooks(bs []model.Book, keys []model.SortKey) {
 	if len(keys) == 0 {
-		// default: created_at desc
-		sort.Slice(bs, func(i, j int) bool { return bs[i].CreatedAt.After(bs[j].CreatedAt) })
-		return
+		keys = defaultSort
 	}
+	sort.SliceStable(bs, func(i, j int) bool { return bookLess(&bs[i], &bs[j], keys) })
+}
 
-	// Apply multi-key sorting, respecting ASC/DESC
-	sort.SliceStable(bs, func(i, j int) bool {
-		for _, k := range keys {
-			switch k.Field {
-			case "title":
-				if bs[i].Title != bs[j].Title {
-					if k.Desc {
-						return bs[i].Title > bs[j].Title
-					}
-					return bs[i].Title < bs[j].Title
+// bookLess orders books by keys, respecting ASC/DESC, then by ID, so any two
+// distinct books compare unequal. Missing years and unrated books sort
+// first ascending.
+func bookLess(a, b *model.Book, keys []model.SortKey) bool {
+	for _, k := range keys {
+		switch k.Field {
+		case "title":
+			if a.Title != b.Title {
+				if k.Desc {
+					return a.Title > b.Title
 				}
-			case "published_year":
-				ai, bi := bs[i].PublishedYear, bs[j].PublishedYear
-				switch {
-				case ai == nil && bi == nil:
-					// equal, continue to next key
-				case ai == nil:
-					if k.Desc {
-						return false
-					} // nil < val
-					return true
-				case bi == nil:
+				return a.Title < b.Title
+			}
+		case "published_year":
+			ai, bi := a.PublishedYear, b.PublishedYear
+			switch {
+			case ai == nil && bi == nil:
+				// equal, continue to next key
+			case ai == nil:
+				return !k.Desc // nil < val
+			case bi == nil:
+				return k.Desc
+			default:
+				if *ai != *bi {
 					if k.Desc {
-						return true
-					}
-					return false
-				default:
-					if *ai != *bi {
-						if k.Desc {
-							return *ai > *bi
-						}
-						return *ai < *bi
+						return *ai > *bi
 					}
+					return *ai < *bi
 				}
-			case "created_at":
-				if !bs[i].CreatedAt.Equal(bs[j].CreatedAt) {
-					if k.Desc {
-						return bs[i].CreatedAt.After(bs[j].CreatedAt)
-					}
-					return bs[i].CreatedAt.Before(bs[j].CreatedAt)
+			}
+		case "rating":
+			ar, br := a.Rating, b.Rating
+			switch {
+			case ar == nil && br == nil:
+				// equal, continue to next key
+			case ar == nil:
+				return !k.Desc
+			case br == nil:
+				return k.Desc
+			case ar.Average != br.Average:
+				if k.Desc {
+					return ar.Average > br.Average
 				}
-			case "updated_at":
-				if !bs[i].UpdatedAt.Equal(bs[j].UpdatedAt) {
-					if k.Desc {
-						return bs[i].UpdatedAt.After(bs[j].UpdatedAt)
-					}
-					return bs[i].UpdatedAt.Before(bs[j].UpdatedAt)
+				return ar.Average < br.Average
+			}
+		case "created_at":
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				if k.Desc {
+					return a.CreatedAt.After(b.CreatedAt)
+				}
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		case "updated_at":
+			if !a.UpdatedAt.Equal(b.UpdatedAt) {
+				if k.Desc {
+					return a.UpdatedAt.After(b.UpdatedAt)
 				}
+				return a.UpdatedAt.Before(b.UpdatedAt)
 			}
 		}
-		// sort by ID for deterministic ordering
-		return bs[i].ID < bs[j].ID
-	})
+	}
+	// sort by ID for deterministic ordering
+	return a.ID < b.ID
 }
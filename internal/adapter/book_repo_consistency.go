@@ -0,0 +1,87 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+)
+
+// CheckConsistency validates the invariants between byID and byISBN:
+//
+//   - every book is stored under its own ID;
+//   - ISBN index keys are normalized and point to an existing book with that ISBN;
+//   - every book with an ISBN outside the trash is indexed, and no two such
+//     books of an owner within a tenant share one.
+//
+// With repair set, fixable issues are corrected in place. Two books sharing an
+// ISBN cannot be resolved automatically and are reported as unrepaired.
+func (r *BookRepo) CheckConsistency(_ context.Context, repair bool) (model.ConsistencyReport, error) {
+	if repair {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+	} else {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+	}
+
+	var rep model.ConsistencyReport
+	issue := func(kind, detail string, fixable bool) {
+		rep.Issues = append(rep.Issues, model.ConsistencyIssue{Kind: kind, Detail: detail, Repaired: repair && fixable})
+	}
+
+	for key, b := range r.byID {
+		if b.ID != key {
+			issue("id_mismatch", fmt.Sprintf("book stored under %q has id %q", key, b.ID), true)
+			if repair {
+				b.ID = key
+				r.byID[key] = b
+			}
+		}
+	}
+
+	indexed := make(map[string]string, len(r.byISBN)) // normalized key -> id, valid entries only
+	for key, id := range r.byISBN {
+		scope, isbn := splitISBNIndexKey(key)
+		norm := isbnIndexKey(scope, normalizeISBN(isbn))
+		b, ok := r.byID[id]
+		switch {
+		case !ok:
+			issue("dangling_isbn_index", fmt.Sprintf("isbn %q points to missing book %q", key, id), true)
+		case liveISBNKey(b) != norm:
+			issue("stale_isbn_index", fmt.Sprintf("isbn %q points to book %q with a different isbn", key, id), true)
+		case norm != key:
+			issue("unnormalized_isbn_key", fmt.Sprintf("isbn key %q is not normalized", key), true)
+			indexed[norm] = id
+			if repair {
+				delete(r.byISBN, key)
+				r.byISBN[norm] = id
+			}
+			continue
+		default:
+			indexed[norm] = id
+			continue
+		}
+		if repair {
+			delete(r.byISBN, key)
+		}
+	}
+
+	for id, b := range r.byID {
+		key := liveISBNKey(b)
+		if key == "" {
+			continue
+		}
+		other, ok := indexed[key]
+		switch {
+		case !ok:
+			issue("unindexed_isbn", fmt.Sprintf("book %q isbn %q is not indexed", id, normalizeISBN(*b.ISBN)), true)
+			indexed[key] = id
+			if repair {
+				r.byISBN[key] = id
+			}
+		case other != id:
+			issue("duplicate_isbn", fmt.Sprintf("books %q and %q share isbn %q", other, id, normalizeISBN(*b.ISBN)), false)
+		}
+	}
+	return rep, nil
+}
@@ -0,0 +1,63 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckConsistency_CleanRepo(t *testing.T) {
+	r := NewBookRepo()
+	ctx := context.Background()
+	_, err := r.Create(ctx, model.Book{ID: "b1", ISBN: util.GetPtr("978-0-12-345678-9")})
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Book{ID: "b2", Owner: "jwt:ada-l", ISBN: util.GetPtr("978-0-12-345678-9")})
+	require.NoError(t, err, "ISBNs are unique per owner")
+
+	rep, err := r.CheckConsistency(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, rep.Issues)
+}
+
+func TestCheckConsistency_ReportAndRepair(t *testing.T) {
+	r := NewBookRepo()
+	ctx := context.Background()
+	// corrupt the indexes directly
+	r.byID["b1"] = model.Book{ID: "b1", ISBN: util.GetPtr("9780000000001")}
+	r.byID["b2"] = model.Book{ID: "b2", ISBN: util.GetPtr("978-0-00-000000-2")}
+	r.byISBN["978-0-00-000000-2"] = "b2" // not normalized
+	r.byISBN["9780000000009"] = "gone"   // dangling
+
+	rep, err := r.CheckConsistency(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, 3, rep.Unrepaired())
+	assert.Len(t, r.byISBN, 2, "check without repair does not modify")
+
+	rep, err = r.CheckConsistency(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, 0, rep.Unrepaired())
+	assert.Equal(t, map[string]string{"9780000000001": "b1", "9780000000002": "b2"}, r.byISBN)
+
+	rep, err = r.CheckConsistency(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, rep.Issues)
+}
+
+func TestCheckConsistency_DuplicateISBNIsUnrepairable(t *testing.T) {
+	r := NewBookRepo()
+	r.byID["b1"] = model.Book{ID: "b1", ISBN: util.GetPtr("9780000000001")}
+	r.byID["b2"] = model.Book{ID: "b2", ISBN: util.GetPtr("9780000000001")}
+	r.byISBN["9780000000001"] = "b1"
+
+	rep, err := r.CheckConsistency(context.Background(), true)
+	require.NoError(t, err)
+	require.Len(t, rep.Issues, 1)
+	assert.Equal(t, "duplicate_isbn", rep.Issues[0].Kind)
+	assert.False(t, rep.Issues[0].Repaired)
+}
@@ -6,6 +6,7 @@ import (
 	"book-manager/internal/core/model"
 	"book-manager/pkg/util"
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -27,7 +28,7 @@ func TestCreateAndGet(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "T1", got.Title)
 
-	got2, err := r.GetByISBN(ctx, "9780123456789")
+	got2, err := r.GetByISBN(ctx, "", "9780123456789")
 	require.NoError(t, err)
 	assert.Equal(t, "b1", got2.ID)
 }
@@ -41,7 +42,7 @@ func TestDuplicateISBN(t *testing.T) {
 	_, err := r.Create(ctx, b1)
 	require.NoError(t, err)
 	_, err = r.Create(ctx, b2)
-	assert.ErrorIs(t, err, errConflict)
+	assert.ErrorIs(t, err, model.ErrConflict)
 }
 
 func TestListFiltersAndPagination(t *testing.T) {
@@ -77,14 +78,195 @@ func TestListFiltersAndPagination(t *testing.T) {
 
 	page, err = r.List(ctx, model.ListQuery{Page: 1, PageSize: 2})
 	require.NoError(t, err)
-	assert.Equal(t, 4, page.Total)
 	assert.Len(t, page.Data, 2)
+	n, err := r.Count(ctx, model.ListQuery{Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	n, err = r.Count(ctx, model.ListQuery{Tag: util.GetPtr("ddd")})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = r.Count(ctx, model.ListQuery{Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, 4, n, "a cursor counts its listing's books")
 
 	page2, err := r.List(ctx, model.ListQuery{Page: 2, PageSize: 2})
 	require.NoError(t, err)
 	assert.Len(t, page2.Data, 2)
 }
 
+func TestList_YearRangeAndUpdatedSince(t *testing.T) {
+	r := NewBookRepo()
+	ctx := context.Background()
+	for i, year := range []int{2003, 2015, 2016, 2017, 0} {
+		b := model.Book{ID: fmt.Sprintf("b%d", i), Title: fmt.Sprintf("T%d", i), CreatedAt: time.Unix(int64(i), 0), UpdatedAt: time.Unix(int64(100+i), 0)}
+		if year != 0 {
+			b.PublishedYear = util.GetPtr(year)
+		}
+		_, err := r.Create(ctx, b)
+		require.NoError(t, err)
+	}
+	sorted := []model.SortKey{{Field: "created_at"}}
+
+	page, err := r.List(ctx, model.ListQuery{YearFrom: util.GetPtr(2015), YearTo: util.GetPtr(2016), PageSize: 10, Sort: sorted})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b1", "b2"}, ids(page.Data), "inclusive, without the book lacking a year")
+	page, err = r.List(ctx, model.ListQuery{YearTo: util.GetPtr(2015), PageSize: 10, Sort: sorted})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b0", "b1"}, ids(page.Data))
+
+	page, err = r.List(ctx, model.ListQuery{UpdatedSince: util.GetPtr(time.Unix(102, 0)), PageSize: 2, Sort: sorted})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b2", "b3"}, ids(page.Data))
+	page, err = r.List(ctx, model.ListQuery{Cursor: page.NextCursor, PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b4"}, ids(page.Data), "the cursor keeps the filter")
+}
+
+func TestList_CursorSurvivesInserts(t *testing.T) {
+	r := NewBookRepo()
+	ctx := context.Background()
+	for i := 1; i <= 5; i++ {
+		_, err := r.Create(ctx, model.Book{ID: fmt.Sprintf("b%d", i), Title: fmt.Sprintf("T%d", i), CreatedAt: time.Unix(int64(i), 0)})
+		require.NoError(t, err)
+	}
+	q := model.ListQuery{PageSize: 2, Sort: []model.SortKey{{Field: "created_at"}}}
+	page, err := r.List(ctx, q)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b1", "b2"}, ids(page.Data))
+	require.NotEmpty(t, page.NextCursor)
+
+	// a book sorting before the cursor and a deleted one do not shift the walk
+	_, err = r.Create(ctx, model.Book{ID: "b0", Title: "T0", CreatedAt: time.Unix(0, 0)})
+	require.NoError(t, err)
+	require.NoError(t, r.Delete(ctx, "b2"))
+
+	var seen []string
+	cursor := page.NextCursor
+	for cursor != "" {
+		// filters and sort come from the cursor
+		page, err = r.List(ctx, model.ListQuery{PageSize: 2, Cursor: cursor})
+		require.NoError(t, err)
+		assert.Zero(t, page.Page)
+		seen = append(seen, ids(page.Data)...)
+		cursor = page.NextCursor
+	}
+	assert.Equal(t, []string{"b3", "b4", "b5"}, seen)
+
+	_, err = r.List(ctx, model.ListQuery{Cursor: "bogus"})
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = r.List(ctx, model.ListQuery{Cursor: "bogus", Snapshot: true})
+	assert.ErrorIs(t, err, model.ErrValidation)
+}
+
+func TestList_TrashCursorNeedsIncludeDeleted(t *testing.T) {
+	r := NewBookRepo()
+	ctx := context.Background()
+	now := time.Now()
+	for i := 1; i <= 3; i++ {
+		_, err := r.Create(ctx, model.Book{ID: fmt.Sprintf("b%d", i), Title: fmt.Sprintf("T%d", i), CreatedAt: time.Unix(int64(i), 0), DeletedAt: &now})
+		require.NoError(t, err)
+	}
+	page, err := r.List(ctx, model.ListQuery{PageSize: 2, Sort: []model.SortKey{{Field: "created_at"}}, IncludeDeleted: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextCursor)
+
+	// the cursor alone must not reveal the trash to a request that did not ask for it
+	_, err = r.List(ctx, model.ListQuery{PageSize: 2, Cursor: page.NextCursor})
+	assert.ErrorIs(t, err, model.ErrValidation)
+	page, err = r.List(ctx, model.ListQuery{PageSize: 2, Cursor: page.NextCursor, IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b3"}, ids(page.Data))
+}
+
+func TestUpdate_RejectsStaleVersion(t *testing.T) {
+	r := NewBookRepo()
+	ctx := context.Background()
+	b, err := r.Create(ctx, model.Book{ID: "b1", Title: "A"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, b.Version)
+
+	b.Title = "A2"
+	b2, err := r.Update(ctx, b)
+	require.NoError(t, err)
+	assert.Equal(t, 2, b2.Version)
+
+	b.Title = "lost update"
+	_, err = r.Update(ctx, b)
+	assert.ErrorIs(t, err, model.ErrConflict)
+	got, err := r.GetByID(ctx, "b1")
+	require.NoError(t, err)
+	assert.Equal(t, "A2", got.Title)
+	assert.Equal(t, 2, got.Version)
+}
+
+func TestUpdate_ReindexesISBN(t *testing.T) {
+	r := NewBookRepo()
+	ctx := context.Background()
+	_, err := r.Create(ctx, model.Book{ID: "b1", Title: "A", ISBN: util.GetPtr("9781230000000")})
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Book{ID: "b2", Title: "B", ISBN: util.GetPtr("9781230000001")})
+	require.NoError(t, err)
+
+	_, err = r.Update(ctx, model.Book{ID: "b1", Version: 1, Title: "A2", ISBN: util.GetPtr("978-1-23-000000-1")})
+	assert.ErrorIs(t, err, model.ErrConflict)
+
+	_, err = r.Update(ctx, model.Book{ID: "b1", Version: 1, Title: "A2", ISBN: util.GetPtr("9781230000002")})
+	require.NoError(t, err)
+	_, err = r.GetByISBN(ctx, "", "9781230000000")
+	assert.ErrorIs(t, err, errNotFound)
+	got, err := r.GetByISBN(ctx, "", "9781230000002")
+	require.NoError(t, err)
+	assert.Equal(t, "A2", got.Title)
+
+	_, err = r.Update(ctx, model.Book{ID: "missing"})
+	assert.ErrorIs(t, err, errNotFound)
+}
+
+func TestList_SnapshotIsStableAcrossWrites(t *testing.T) {
+	r := NewBookRepo()
+	ctx := context.Background()
+	for i, id := range []string{"b1", "b2", "b3"} {
+		_, err := r.Create(ctx, model.Book{ID: id, Title: id, CreatedAt: time.Unix(int64(1000+i), 0)})
+		require.NoError(t, err)
+	}
+
+	first, err := r.List(ctx, model.ListQuery{Page: 1, PageSize: 2, Snapshot: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, first.SnapshotID)
+	assert.Equal(t, []string{"b3", "b2"}, ids(first.Data))
+
+	// a new book would shift the live second page
+	_, err = r.Create(ctx, model.Book{ID: "b4", Title: "b4", CreatedAt: time.Unix(2000, 0)})
+	require.NoError(t, err)
+
+	second, err := r.List(ctx, model.ListQuery{Page: 2, PageSize: 2, SnapshotID: first.SnapshotID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b1"}, ids(second.Data))
+	n, err := r.Count(ctx, model.ListQuery{SnapshotID: first.SnapshotID})
+	require.NoError(t, err)
+	assert.Equal(t, 3, n, "the pinned result, not the live one")
+	assert.Equal(t, first.SnapshotID, second.SnapshotID)
+
+	_, err = r.List(ctx, model.ListQuery{Page: 1, SnapshotID: "unknown"})
+	assert.ErrorIs(t, err, errNotFound)
+}
+
+func TestListSnapshots_Expire(t *testing.T) {
+	s := newListSnapshots(time.Millisecond)
+	id := s.save([]model.Book{{ID: "b1"}})
+	time.Sleep(5 * time.Millisecond)
+	_, ok := s.get(id)
+	assert.False(t, ok)
+}
+
+func ids(bs []model.Book) []string {
+	out := make([]string, 0, len(bs))
+	for _, b := range bs {
+		out = append(out, b.ID)
+	}
+	return out
+}
+
 func TestDelete(t *testing.T) {
 	r := NewBookRepo()
 	ctx := context.Background()
@@ -92,5 +274,39 @@ func TestDelete(t *testing.T) {
 	require.NoError(t, err)
 	assert.NoError(t, r.Delete(ctx, "b1"))
 	_, err = r.GetByID(ctx, "b1")
-	assert.ErrorIs(t, err, errNotFound)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestRenameAuthor_KeepsAuthorIDsAligned(t *testing.T) {
+	r := NewBookRepo()
+	ctx := context.Background()
+	_, err := r.Create(ctx, model.Book{ID: "b1", Title: "A", Authors: []string{"Bob", "Robert", "Jane"}, AuthorIDs: []string{"a-bob", "a-robert", "a-jane"}})
+	require.NoError(t, err)
+
+	_, err = r.RenameAuthor(ctx, model.AuthorRename{From: "bob", To: "Robert", ToID: "a-robert"})
+	require.NoError(t, err)
+	got, err := r.GetByID(ctx, "b1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Robert", "Jane"}, got.Authors)
+	assert.Equal(t, []string{"a-robert", "a-jane"}, got.AuthorIDs)
+}
+
+func TestRenameTags_MergesDuplicates(t *testing.T) {
+	r := NewBookRepo()
+	ctx := context.Background()
+	_, err := r.Create(ctx, model.Book{ID: "b1", Title: "A", Tags: []string{"sci-fi", "classic", "scifi"}})
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Book{ID: "b2", Title: "B", Tags: []string{"poetry"}})
+	require.NoError(t, err)
+
+	rn, err := r.RenameTags(ctx, model.TagRename{From: []string{"sci-fi", "scifi"}, To: "sf"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, rn.BooksUpdated)
+	got, err := r.GetByID(ctx, "b1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sf", "classic"}, got.Tags)
+	assert.Equal(t, 2, got.Version)
+	got, err = r.GetByID(ctx, "b2")
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Version, "untouched books keep their version")
 }
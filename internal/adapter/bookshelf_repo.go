@@ -0,0 +1,139 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BookshelfRepo keeps the users' shelves in memory.
+type BookshelfRepo struct {
+	mu      sync.RWMutex
+	shelves map[string][]model.Bookshelf            // by tenantKey
+	items   map[string]map[string][]model.ShelfItem // by tenantKey, then shelf id, oldest first
+	records recordStore[userShelves]
+}
+
+// userShelves is the durable record of a user's shelves and what is on
+// them.
+type userShelves struct {
+	Shelves []model.Bookshelf
+	Items   map[string][]model.ShelfItem
+}
+
+// NewBookshelfRepo keeps nothing durably; the shelves are lost on restart.
+func NewBookshelfRepo() *BookshelfRepo {
+	return &BookshelfRepo{shelves: map[string][]model.Bookshelf{}, items: map[string]map[string][]model.ShelfItem{}, records: memoryRecords[userShelves]{}}
+}
+
+// OpenBoltBookshelfRepo loads the shelves kept in db's bookshelves bucket,
+// one record per user.
+func OpenBoltBookshelfRepo(db *bolt.DB) (*BookshelfRepo, error) {
+	b, users, err := openBoltRecords[userShelves](db, "bookshelves")
+	if err != nil {
+		return nil, err
+	}
+	r := &BookshelfRepo{shelves: map[string][]model.Bookshelf{}, items: map[string]map[string][]model.ShelfItem{}, records: b}
+	for user, u := range users {
+		r.shelves[user] = u.Shelves
+		if u.Items != nil {
+			r.items[user] = u.Items
+		}
+	}
+	return r, nil
+}
+
+func (r *BookshelfRepo) Create(ctx context.Context, s model.Bookshelf) (model.Bookshelf, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user := tenantKey(ctx, s.User)
+	if slices.ContainsFunc(r.shelves[user], func(other model.Bookshelf) bool { return other.ID == s.ID }) {
+		return model.Bookshelf{}, fmt.Errorf("%w: shelf %s exists", model.ErrConflict, s.ID)
+	}
+	if err := r.records.writable(); err != nil {
+		return model.Bookshelf{}, err
+	}
+	r.shelves[user] = append(r.shelves[user], s)
+	return s, r.save(user)
+}
+
+func (r *BookshelfRepo) List(ctx context.Context, user string) ([]model.Bookshelf, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	user = tenantKey(ctx, user)
+	out := slices.Clone(r.shelves[user])
+	slices.SortFunc(out, func(a, b model.Bookshelf) int { return strings.Compare(a.Name, b.Name) })
+	return out, nil
+}
+
+func (r *BookshelfRepo) Delete(ctx context.Context, user, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user = tenantKey(ctx, user)
+	i := slices.IndexFunc(r.shelves[user], func(s model.Bookshelf) bool { return s.ID == id })
+	if i < 0 {
+		return fmt.Errorf("%w: shelf %s", model.ErrNotFound, id)
+	}
+	if err := r.records.writable(); err != nil {
+		return err
+	}
+	r.shelves[user] = slices.Delete(r.shelves[user], i, i+1)
+	delete(r.items[user], id)
+	return r.save(user)
+}
+
+func (r *BookshelfRepo) Put(ctx context.Context, user, shelfID string, it model.ShelfItem, off []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.records.writable(); err != nil {
+		return err
+	}
+	user = tenantKey(ctx, user)
+	shelves := r.items[user]
+	if shelves == nil {
+		shelves = map[string][]model.ShelfItem{}
+		r.items[user] = shelves
+	}
+	for _, id := range off {
+		shelves[id] = slices.DeleteFunc(shelves[id], func(other model.ShelfItem) bool { return other.BookID == it.BookID })
+	}
+	if !slices.ContainsFunc(shelves[shelfID], func(other model.ShelfItem) bool { return other.BookID == it.BookID }) {
+		shelves[shelfID] = append(shelves[shelfID], it)
+	}
+	return r.save(user)
+}
+
+func (r *BookshelfRepo) Remove(ctx context.Context, user, shelfID, bookID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user = tenantKey(ctx, user)
+	items := r.items[user][shelfID]
+	i := slices.IndexFunc(items, func(it model.ShelfItem) bool { return it.BookID == bookID })
+	if i < 0 {
+		return fmt.Errorf("%w: book %s is not on shelf %s", model.ErrNotFound, bookID, shelfID)
+	}
+	if err := r.records.writable(); err != nil {
+		return err
+	}
+	r.items[user][shelfID] = slices.Delete(items, i, i+1)
+	return r.save(user)
+}
+
+func (r *BookshelfRepo) Items(ctx context.Context, user, shelfID string) ([]model.ShelfItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	user = tenantKey(ctx, user)
+	out := slices.Clone(r.items[user][shelfID])
+	slices.Reverse(out)
+	return out, nil
+}
+
+// save writes the record of user; callers hold mu.
+func (r *BookshelfRepo) save(user string) error {
+	return r.records.put(user, userShelves{Shelves: r.shelves[user], Items: r.items[user]})
+}
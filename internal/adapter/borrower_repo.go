@@ -0,0 +1,159 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BorrowerRepo keeps the borrower directory in memory and, when opened on
+// a journal file or a bbolt database, there too, so it survives a restart.
+type BorrowerRepo struct {
+	mu      sync.RWMutex
+	byID    map[string]model.Borrower
+	records recordStore[model.Borrower]
+}
+
+func NewBorrowerRepo() *BorrowerRepo {
+	return &BorrowerRepo{byID: map[string]model.Borrower{}, records: memoryRecords[model.Borrower]{}}
+}
+
+// OpenBorrowerRepo loads the borrowers kept in path, which need not exist
+// yet.
+func OpenBorrowerRepo(path string) (*BorrowerRepo, error) {
+	j, borrowers, err := openRecordJournal[model.Borrower](path)
+	if err != nil {
+		return nil, err
+	}
+	return &BorrowerRepo{byID: borrowers, records: j}, nil
+}
+
+// OpenBoltBorrowerRepo loads the borrowers kept in db's borrowers bucket.
+func OpenBoltBorrowerRepo(db *bolt.DB) (*BorrowerRepo, error) {
+	b, borrowers, err := openBoltRecords[model.Borrower](db, "borrowers")
+	if err != nil {
+		return nil, err
+	}
+	return &BorrowerRepo{byID: borrowers, records: b}, nil
+}
+
+func (r *BorrowerRepo) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.records.Close()
+}
+
+func (r *BorrowerRepo) Create(_ context.Context, b model.Borrower) (model.Borrower, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byID[b.ID]; ok {
+		return model.Borrower{}, fmt.Errorf("%w: borrower %s exists", model.ErrConflict, b.ID)
+	}
+	if err := r.checkUnique(b); err != nil {
+		return model.Borrower{}, err
+	}
+	if err := r.records.writable(); err != nil {
+		return model.Borrower{}, err
+	}
+	r.byID[b.ID] = b
+	return b, r.records.put(b.ID, b)
+}
+
+func (r *BorrowerRepo) Get(ctx context.Context, id string) (model.Borrower, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	b, ok := r.byID[id]
+	if !ok || !ofTenant(ctx, b.Tenant) {
+		return model.Borrower{}, fmt.Errorf("%w: borrower %s", model.ErrNotFound, id)
+	}
+	return b, nil
+}
+
+func (r *BorrowerRepo) GetByExternalID(ctx context.Context, externalID string) (model.Borrower, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, b := range r.byID {
+		if externalID != "" && b.ExternalID == externalID && ofTenant(ctx, b.Tenant) {
+			return b, nil
+		}
+	}
+	return model.Borrower{}, fmt.Errorf("%w: no borrower has external id %s", model.ErrNotFound, externalID)
+}
+
+func (r *BorrowerRepo) Update(ctx context.Context, b model.Borrower) (model.Borrower, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.byID[b.ID]; !ok || !ofTenant(ctx, old.Tenant) {
+		return model.Borrower{}, fmt.Errorf("%w: borrower %s", model.ErrNotFound, b.ID)
+	}
+	if err := r.checkUnique(b); err != nil {
+		return model.Borrower{}, err
+	}
+	if err := r.records.writable(); err != nil {
+		return model.Borrower{}, err
+	}
+	r.byID[b.ID] = b
+	return b, r.records.put(b.ID, b)
+}
+
+func (r *BorrowerRepo) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if b, ok := r.byID[id]; !ok || !ofTenant(ctx, b.Tenant) {
+		return fmt.Errorf("%w: borrower %s", model.ErrNotFound, id)
+	}
+	if err := r.records.writable(); err != nil {
+		return err
+	}
+	delete(r.byID, id)
+	return r.records.delete(id)
+}
+
+func (r *BorrowerRepo) List(ctx context.Context, q model.BorrowerQuery) (model.Page[model.Borrower], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	needle := strings.ToLower(q.Q)
+	var all []model.Borrower
+	for _, b := range r.byID {
+		if !ofTenant(ctx, b.Tenant) {
+			continue
+		}
+		if needle == "" || strings.Contains(strings.ToLower(b.Name), needle) ||
+			strings.Contains(strings.ToLower(b.Email), needle) || strings.Contains(strings.ToLower(b.ExternalID), needle) {
+			all = append(all, b)
+		}
+	}
+	slices.SortFunc(all, func(a, b model.Borrower) int {
+		if c := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	out := model.Page[model.Borrower]{Page: q.Page, PageSize: q.PageSize, Total: len(all)}
+	if start := (q.Page - 1) * q.PageSize; start < len(all) {
+		out.Data = all[start:min(start+q.PageSize, len(all))]
+	}
+	return out, nil
+}
+
+// checkUnique fails for an email or external ID another borrower of the
+// same tenant has.
+func (r *BorrowerRepo) checkUnique(b model.Borrower) error {
+	for _, other := range r.byID {
+		if other.ID == b.ID || other.Tenant != b.Tenant {
+			continue
+		}
+		if b.Email != "" && strings.EqualFold(other.Email, b.Email) {
+			return fmt.Errorf("%w: email %s is registered to borrower %s", model.ErrConflict, b.Email, other.ID)
+		}
+		if b.ExternalID != "" && other.ExternalID == b.ExternalID {
+			return fmt.Errorf("%w: external id %s is registered to borrower %s", model.ErrConflict, b.ExternalID, other.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BranchRepo keeps the branches in memory. Every tenant has its own
+// branches, so ids are looked up within ctx's tenant.
+type BranchRepo struct {
+	mu      sync.RWMutex
+	byID    map[string]model.Branch // by tenantKey
+	records recordStore[model.Branch]
+}
+
+// NewBranchRepo keeps nothing durably; the branches are lost on restart.
+func NewBranchRepo() *BranchRepo {
+	return &BranchRepo{byID: map[string]model.Branch{}, records: memoryRecords[model.Branch]{}}
+}
+
+// OpenBoltBranchRepo loads the branches kept in db's branches bucket.
+func OpenBoltBranchRepo(db *bolt.DB) (*BranchRepo, error) {
+	b, branches, err := openBoltRecords[model.Branch](db, "branches")
+	if err != nil {
+		return nil, err
+	}
+	return &BranchRepo{byID: branches, records: b}, nil
+}
+
+func (r *BranchRepo) Create(ctx context.Context, b model.Branch) (model.Branch, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := tenantKey(ctx, b.ID)
+	if _, ok := r.byID[key]; ok {
+		return model.Branch{}, fmt.Errorf("%w: branch %s exists", model.ErrConflict, b.ID)
+	}
+	if err := r.records.writable(); err != nil {
+		return model.Branch{}, err
+	}
+	r.byID[key] = b
+	return b, r.records.put(key, b)
+}
+
+func (r *BranchRepo) Get(ctx context.Context, id string) (model.Branch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	b, ok := r.byID[tenantKey(ctx, id)]
+	if !ok {
+		return model.Branch{}, fmt.Errorf("%w: branch %s", model.ErrNotFound, id)
+	}
+	return b, nil
+}
+
+func (r *BranchRepo) Update(ctx context.Context, b model.Branch) (model.Branch, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := tenantKey(ctx, b.ID)
+	old, ok := r.byID[key]
+	if !ok {
+		return model.Branch{}, fmt.Errorf("%w: branch %s", model.ErrNotFound, b.ID)
+	}
+	if err := r.records.writable(); err != nil {
+		return model.Branch{}, err
+	}
+	b.Tenant = old.Tenant
+	r.byID[key] = b
+	return b, r.records.put(key, b)
+}
+
+func (r *BranchRepo) List(ctx context.Context) ([]model.Branch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []model.Branch
+	for _, b := range r.byID {
+		if ofTenant(ctx, b.Tenant) {
+			out = append(out, b)
+		}
+	}
+	slices.SortFunc(out, func(a, b model.Branch) int {
+		if c := strings.Compare(a.ID, b.ID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Tenant, b.Tenant)
+	})
+	return out, nil
+}
+
+func (r *BranchRepo) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := tenantKey(ctx, id)
+	if _, ok := r.byID[key]; !ok {
+		return fmt.Errorf("%w: branch %s", model.ErrNotFound, id)
+	}
+	if err := r.records.writable(); err != nil {
+		return err
+	}
+	delete(r.byID, key)
+	return r.records.delete(key)
+}
@@ -0,0 +1,114 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var errCircuitOpen = errors.New("circuit open")
+
+// CircuitBreaker stops calling an enrichment provider that keeps failing.
+// After Threshold consecutive failures it opens and fails lookups at once
+// for Cooldown; then a single probe is let through (half-open), closing
+// the breaker when it succeeds and opening it again when it fails. An
+// unknown book is an answer, not a failure, and neither is a lookup the
+// caller cancelled.
+type CircuitBreaker struct {
+	Name      string
+	Threshold int
+	Cooldown  time.Duration
+
+	client enrichmentClient
+	now    func() time.Time
+
+	mu          sync.Mutex
+	state       string
+	consecutive int
+	openedAt    time.Time
+	probing     bool
+	lastErr     string
+	failures    int64
+	opens       int64
+	rejected    int64
+}
+
+// NewCircuitBreaker wraps client; a threshold below 1 is taken as 1.
+func NewCircuitBreaker(name string, client enrichmentClient, threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		Name: name, Threshold: max(threshold, 1), Cooldown: cooldown,
+		client: client, now: time.Now, state: model.BreakerClosed,
+	}
+}
+
+func (b *CircuitBreaker) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	if err := b.allow(); err != nil {
+		return model.EnrichedBook{}, err
+	}
+	eb, err := b.client.FetchByISBN(ctx, isbn)
+	b.record(err)
+	return eb, err
+}
+
+// allow reports whether a call may go through, moving an open breaker
+// whose cooldown has passed to half-open for one probe.
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == model.BreakerOpen && !b.now().Before(b.openedAt.Add(b.Cooldown)) {
+		b.state = model.BreakerHalfOpen
+	}
+	switch {
+	case b.state == model.BreakerOpen:
+		b.rejected++
+		return fmt.Errorf("%s: %w until %s", b.Name, errCircuitOpen, b.openedAt.Add(b.Cooldown).UTC().Format(time.RFC3339))
+	case b.state == model.BreakerHalfOpen && b.probing:
+		b.rejected++
+		return fmt.Errorf("%s: %w, probe in flight", b.Name, errCircuitOpen)
+	case b.state == model.BreakerHalfOpen:
+		b.probing = true
+	}
+	return nil
+}
+
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	probe := b.probing
+	b.probing = false
+	switch {
+	case err == nil || errors.Is(err, errNotFound):
+		b.state, b.consecutive = model.BreakerClosed, 0
+		return
+	case errors.Is(err, context.Canceled):
+		return // no verdict; a half-open breaker lets the next call probe
+	}
+	b.failures++
+	b.consecutive++
+	b.lastErr = err.Error()
+	if probe || b.state == model.BreakerClosed && b.consecutive >= b.Threshold {
+		b.state, b.openedAt = model.BreakerOpen, b.now()
+		b.opens++
+	}
+}
+
+func (b *CircuitBreaker) Status() model.CircuitBreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st := model.CircuitBreakerStatus{
+		Name: b.Name, State: b.state, Threshold: b.Threshold, Cooldown: b.Cooldown,
+		ConsecutiveFailures: b.consecutive, Failures: b.failures, Opens: b.opens, Rejected: b.rejected,
+		LastError: b.lastErr,
+	}
+	if b.state == model.BreakerOpen && !b.now().Before(b.openedAt.Add(b.Cooldown)) {
+		st.State = model.BreakerHalfOpen // the next call probes
+	}
+	if b.opens > 0 {
+		opened := b.openedAt
+		st.OpenedAt = &opened
+	}
+	return st
+}
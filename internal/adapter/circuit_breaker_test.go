@@ -0,0 +1,93 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	down := &fakeEnrich{err: errors.New("openlibrary: status 503")}
+	b := NewCircuitBreaker("openlibrary", down, 2, time.Minute)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	b.now = func() time.Time { return now }
+
+	for range 2 {
+		_, err := b.FetchByISBN(ctx, "9780441013593")
+		assert.ErrorContains(t, err, "status 503")
+	}
+	assert.Equal(t, model.BreakerOpen, b.Status().State)
+	_, err := b.FetchByISBN(ctx, "9780441013593")
+	assert.ErrorIs(t, err, errCircuitOpen)
+	assert.Equal(t, 2, down.calls, "an open breaker does not call the provider")
+
+	// after the cooldown one probe goes through; it fails and reopens
+	now = now.Add(time.Minute)
+	assert.Equal(t, model.BreakerHalfOpen, b.Status().State)
+	_, err = b.FetchByISBN(ctx, "9780441013593")
+	assert.ErrorContains(t, err, "status 503")
+	assert.Equal(t, 3, down.calls)
+	assert.Equal(t, model.BreakerOpen, b.Status().State)
+
+	// a cancelled probe gives no verdict; an unknown book closes it
+	now = now.Add(time.Minute)
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	down.delay = time.Second
+	_, err = b.FetchByISBN(cancelled, "9780441013593")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, model.BreakerHalfOpen, b.Status().State)
+	down.delay, down.err = 0, errNotFound
+	_, err = b.FetchByISBN(ctx, "9780441013593")
+	assert.ErrorIs(t, err, errNotFound)
+
+	st := b.Status()
+	assert.Equal(t, model.BreakerClosed, st.State)
+	assert.Zero(t, st.ConsecutiveFailures)
+	assert.Equal(t, []int64{3, 2, 1}, []int64{st.Failures, st.Opens, st.Rejected})
+	assert.Equal(t, "openlibrary: status 503", st.LastError)
+	require.NotNil(t, st.OpenedAt)
+	assert.Equal(t, now.Add(-time.Minute), *st.OpenedAt)
+}
+
+func TestCircuitBreaker_OneProbe(t *testing.T) {
+	b := NewCircuitBreaker("openlibrary", &fakeEnrich{}, 1, 0)
+	b.state = model.BreakerOpen
+	require.NoError(t, b.allow())
+	assert.ErrorIs(t, b.allow(), errCircuitOpen, "a second call waits for the probe")
+	b.record(nil)
+	assert.Equal(t, model.BreakerClosed, b.Status().State)
+}
+
+func TestGetMetrics(t *testing.T) {
+	srv, svc := newServer(t)
+	b := NewCircuitBreaker("openlibrary", &fakeEnrich{err: errors.New("timeout")}, 1, time.Minute)
+	_, _ = b.FetchByISBN(context.Background(), "9780441013593")
+	svc.Breakers = append(svc.Breakers, b)
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	body := rec.Body.String()
+	assert.Contains(t, body, `bookmanager_circuit_breaker_state{name="openlibrary",state="open"} 1`)
+	assert.Contains(t, body, `bookmanager_circuit_breaker_state{name="openlibrary",state="closed"} 0`)
+	assert.Contains(t, body, "# TYPE bookmanager_circuit_breaker_opens_total counter\n"+
+		`bookmanager_circuit_breaker_opens_total{name="openlibrary"} 1`)
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/circuit-breakers", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"state":"open"`)
+	assert.Contains(t, rec.Body.String(), `"cooldown_seconds":60`)
+}
@@ -0,0 +1,77 @@
+package adapter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// code128Patterns are the widths, in modules, of the alternating bars and
+// spaces of each Code 128 symbol by value: 103 to 105 start code sets A to
+// C and 106 stops.
+var code128Patterns = [107]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	code128CodeC  = 99
+	code128StartB = 104
+	code128StartC = 105
+	code128Stop   = 106
+)
+
+// code128 encodes s, printable ASCII, as the values of its Code 128
+// symbols from start to stop. Barcodes of four or more digits use code
+// set C, two digits a symbol, after a leading digit in set B when their
+// number is odd; others use set B.
+func code128(s string) ([]int, error) {
+	if s == "" {
+		return nil, fmt.Errorf("code 128: nothing to encode")
+	}
+	var vals []int
+	if len(s) >= 4 && strings.Trim(s, "0123456789") == "" {
+		if len(s)%2 == 1 {
+			vals = append(vals, code128StartB, int(s[0]-' '), code128CodeC)
+			s = s[1:]
+		} else {
+			vals = append(vals, code128StartC)
+		}
+		for i := 0; i < len(s); i += 2 {
+			vals = append(vals, int(s[i]-'0')*10+int(s[i+1]-'0'))
+		}
+	} else {
+		vals = append(vals, code128StartB)
+		for i := 0; i < len(s); i++ {
+			if s[i] < ' ' || s[i] > '~' {
+				return nil, fmt.Errorf("code 128: %q is not printable ASCII", s[i])
+			}
+			vals = append(vals, int(s[i]-' '))
+		}
+	}
+	sum := vals[0]
+	for i, v := range vals[1:] {
+		sum += (i + 1) * v
+	}
+	return append(vals, sum%103, code128Stop), nil
+}
+
+// code128Widths are the widths, in modules, of the alternating bars and
+// spaces of the symbols vals, starting with a bar.
+func code128Widths(vals []int) []int {
+	var out []int
+	for _, v := range vals {
+		for _, c := range code128Patterns[v] {
+			out = append(out, int(c-'0'))
+		}
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+//go:build unit
+
+package adapter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode128(t *testing.T) {
+	for v, p := range code128Patterns {
+		n := 0
+		for _, c := range p {
+			n += int(c - '0')
+		}
+		if v == code128Stop {
+			assert.Equal(t, 13, n, "stop")
+		} else {
+			assert.Equal(t, 11, n, "symbol %d", v)
+		}
+	}
+
+	tests := []struct {
+		in   string
+		want []int
+	}{
+		{"PJJ123C", []int{104, 48, 42, 42, 17, 18, 19, 35, 55, 106}},
+		{"1234", []int{105, 12, 34, 82, 106}},
+		{"31234000001", []int{104, 19, 99, 12, 34, 0, 0, 1, 88, 106}},
+		{"123", []int{104, 17, 18, 19, 8, 106}},
+	}
+	for _, tt := range tests {
+		got, err := code128(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+	widths := code128Widths([]int{105, 12, 34, 82, 106})
+	assert.Len(t, widths, 4*6+7)
+
+	_, err := code128("é")
+	assert.Error(t, err)
+	_, err = code128("")
+	assert.Error(t, err)
+}
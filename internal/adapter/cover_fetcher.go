@@ -0,0 +1,66 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// maxCoverDownload matches the upload limit; real covers are far smaller.
+const maxCoverDownload = 10 << 20
+
+// HTTPCoverFetcher downloads cover images found by enrichment, such as
+// covers.openlibrary.org URLs, for the local cover cache.
+type HTTPCoverFetcher struct {
+	Client *http.Client
+	Retry  int
+}
+
+func NewHTTPCoverFetcher(retry int, httpClient *http.Client) *HTTPCoverFetcher {
+	if retry < 0 {
+		retry = 0
+	}
+	return &HTTPCoverFetcher{Client: httpClient, Retry: retry}
+}
+
+func (c *HTTPCoverFetcher) FetchCover(ctx context.Context, u string) ([]byte, error) {
+	if !strings.HasPrefix(u, "https://") && !strings.HasPrefix(u, "http://") {
+		return nil, fmt.Errorf("cover: %q is not an http(s) URL", u)
+	}
+	if strings.Contains(u, "://covers.openlibrary.org/") && !strings.Contains(u, "?") {
+		// missing covers are a 1x1 placeholder unless asked to 404
+		u += "?default=false"
+	}
+	return fetchWithRetry(ctx, c.Retry, func() ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "image/*")
+		resp, err := c.Client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("cover: %s: %w", u, errNotFound)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("cover: %s: status %d", u, resp.StatusCode)
+		}
+		if mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); !strings.HasPrefix(mt, "image/") {
+			return nil, fmt.Errorf("cover: %s: content type %q is not an image", u, mt)
+		}
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxCoverDownload+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxCoverDownload {
+			return nil, fmt.Errorf("cover: %s: larger than %d bytes", u, maxCoverDownload)
+		}
+		return data, nil
+	})
+}
@@ -0,0 +1,42 @@
+//go:build unit
+
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPCoverFetcher(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cover.jpg":
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write([]byte("\xff\xd8\xff jpeg"))
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c := NewHTTPCoverFetcher(0, srv.Client())
+	ctx := context.Background()
+
+	data, err := c.FetchCover(ctx, srv.URL+"/cover.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, "\xff\xd8\xff jpeg", string(data))
+
+	_, err = c.FetchCover(ctx, srv.URL+"/page.html")
+	assert.ErrorContains(t, err, "not an image")
+	_, err = c.FetchCover(ctx, srv.URL+"/missing.jpg")
+	assert.ErrorIs(t, err, errNotFound)
+	_, err = c.FetchCover(ctx, "file:///etc/passwd")
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// maxDeadLetters bounds the list; the oldest letters are dropped first.
+const maxDeadLetters = 10000
+
+// DeadLetterRepo keeps dead letters in memory and, when opened on a file,
+// rewrites that file after every change, so they survive a restart. Dead
+// letters are few and rarely change, which makes rewriting cheaper to
+// reason about than a journal.
+type DeadLetterRepo struct {
+	mu      sync.Mutex
+	letters []model.DeadLetter // oldest first
+	path    string
+}
+
+func NewDeadLetterRepo() *DeadLetterRepo {
+	return &DeadLetterRepo{}
+}
+
+// OpenDeadLetterRepo loads the dead letters kept in path, which need not
+// exist yet.
+func OpenDeadLetterRepo(path string) (*DeadLetterRepo, error) {
+	r := &DeadLetterRepo{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &r.letters); err != nil {
+		return nil, fmt.Errorf("dead letters %s: %w", path, err)
+	}
+	return r, nil
+}
+
+func (r *DeadLetterRepo) Add(_ context.Context, d model.DeadLetter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	letters := append(r.letters, d)
+	if len(letters) > maxDeadLetters {
+		letters = append([]model.DeadLetter(nil), letters[len(letters)-maxDeadLetters:]...)
+	}
+	return r.save(letters)
+}
+
+func (r *DeadLetterRepo) List(_ context.Context) ([]model.DeadLetter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]model.DeadLetter(nil), r.letters...), nil
+}
+
+func (r *DeadLetterRepo) Get(_ context.Context, id string) (model.DeadLetter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.index(id)
+	if i < 0 {
+		return model.DeadLetter{}, fmt.Errorf("%w: dead letter %s", model.ErrNotFound, id)
+	}
+	return r.letters[i], nil
+}
+
+func (r *DeadLetterRepo) Update(_ context.Context, d model.DeadLetter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.index(d.ID)
+	if i < 0 {
+		return fmt.Errorf("%w: dead letter %s", model.ErrNotFound, d.ID)
+	}
+	letters := append([]model.DeadLetter(nil), r.letters...)
+	letters[i] = d
+	return r.save(letters)
+}
+
+func (r *DeadLetterRepo) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.index(id)
+	if i < 0 {
+		return fmt.Errorf("%w: dead letter %s", model.ErrNotFound, id)
+	}
+	letters := append(append([]model.DeadLetter(nil), r.letters[:i]...), r.letters[i+1:]...)
+	return r.save(letters)
+}
+
+func (r *DeadLetterRepo) index(id string) int {
+	for i, d := range r.letters {
+		if d.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// save writes letters to the file, if any, and only then makes them
+// current, so a failed write changes nothing.
+func (r *DeadLetterRepo) save(letters []model.DeadLetter) error {
+	if r.path != "" {
+		data, err := json.Marshal(letters)
+		if err != nil {
+			return err
+		}
+		f, err := os.CreateTemp(filepath.Dir(r.path), ".deadletters-*")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(f.Name())
+		if _, err := f.Write(data); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Sync(); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		if err := os.Rename(f.Name(), r.path); err != nil {
+			return err
+		}
+	}
+	r.letters = letters
+	return nil
+}
@@ -0,0 +1,50 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterRepo_Persists(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "deadletters.json")
+	r, err := OpenDeadLetterRepo(path)
+	require.NoError(t, err)
+	at := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	price := model.Price{Amount: 999, Currency: "EUR"}
+	a := model.DeadLetter{ID: "a", Event: model.Event{Type: model.EventPriceBelowTarget, BookID: "b1", Book: model.Book{ID: "b1", Title: "One"}, Price: &price, At: at}, Error: "timeout", Attempts: 3, FailedAt: at}
+	b := model.DeadLetter{ID: "b", Event: model.Event{Type: model.EventBookReleased, BookID: "b2", At: at}, Error: "503", Attempts: 3, FailedAt: at}
+	require.NoError(t, r.Add(ctx, a))
+	require.NoError(t, r.Add(ctx, b))
+	b.Attempts = 4
+	require.NoError(t, r.Update(ctx, b))
+
+	r, err = OpenDeadLetterRepo(path)
+	require.NoError(t, err)
+	all, err := r.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.DeadLetter{a, b}, all)
+
+	require.NoError(t, r.Delete(ctx, "a"))
+	assert.ErrorIs(t, r.Delete(ctx, "a"), model.ErrNotFound)
+	_, err = r.Get(ctx, "a")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	assert.ErrorIs(t, r.Update(ctx, a), model.ErrNotFound)
+
+	r, err = OpenDeadLetterRepo(path)
+	require.NoError(t, err)
+	got, err := r.Get(ctx, "b")
+	require.NoError(t, err)
+	assert.Equal(t, 4, got.Attempts)
+	all, err = r.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
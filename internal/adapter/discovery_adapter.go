@@ -0,0 +1,181 @@
+package adapter
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ServiceRegistration describes this instance to a service registry.
+type ServiceRegistration struct {
+	ID      string   `json:"id"` // unique per instance
+	Name    string   `json:"name"`
+	Address string   `json:"address"`
+	Port    int      `json:"port"`
+	Tags    []string `json:"tags,omitempty"`
+	// HealthURL is polled by registries that check health themselves.
+	HealthURL     string        `json:"health_url"`
+	CheckInterval time.Duration `json:"-"`
+}
+
+// ConsulRegistrar registers the service with the local Consul agent
+// (PUT /v1/agent/service/register) along with an HTTP health check; the
+// agent drops the instance when the check stays critical.
+type ConsulRegistrar struct {
+	BaseURL string
+	Token   string // ACL token, optional
+	Client  *http.Client
+
+	mu sync.Mutex
+	id string
+}
+
+func NewConsulRegistrar(baseURL, token string, httpClient *http.Client) *ConsulRegistrar {
+	if baseURL == "" {
+		baseURL = "http://127.0.0.1:8500"
+	}
+	return &ConsulRegistrar{BaseURL: strings.TrimRight(baseURL, "/"), Token: token, Client: httpClient}
+}
+
+func (c *ConsulRegistrar) Register(ctx context.Context, reg ServiceRegistration) error {
+	body, err := json.Marshal(map[string]any{
+		"ID":      reg.ID,
+		"Name":    reg.Name,
+		"Address": reg.Address,
+		"Port":    reg.Port,
+		"Tags":    reg.Tags,
+		"Check": map[string]any{
+			"HTTP":     reg.HealthURL,
+			"Interval": reg.CheckInterval.String(),
+			"Timeout":  "5s",
+			// clean up after instances that died without deregistering
+			"DeregisterCriticalServiceAfter": "10m",
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := doJSON(ctx, c.Client, http.MethodPut, c.BaseURL+"/v1/agent/service/register", c.header(), body, "consul", nil); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.id = reg.ID
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *ConsulRegistrar) Deregister(ctx context.Context) error {
+	c.mu.Lock()
+	id := c.id
+	c.id = ""
+	c.mu.Unlock()
+	if id == "" {
+		return nil
+	}
+	return doJSON(ctx, c.Client, http.MethodPut, c.BaseURL+"/v1/agent/service/deregister/"+url.PathEscape(id), c.header(), nil, "consul", nil)
+}
+
+func (c *ConsulRegistrar) header() http.Header {
+	if c.Token == "" {
+		return nil
+	}
+	return http.Header{"X-Consul-Token": {c.Token}}
+}
+
+// EtcdRegistrar writes the registration as JSON under Prefix/name/id in
+// etcd, through its v3 JSON gateway. etcd does not check health, so the key
+// is bound to a lease that is kept alive while the process runs: if it dies,
+// the key expires after TTL.
+type EtcdRegistrar struct {
+	BaseURL string
+	Prefix  string
+	TTL     time.Duration
+	Client  *http.Client
+	Log     *slog.Logger
+
+	mu     sync.Mutex
+	lease  string
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewEtcdRegistrar(baseURL string, httpClient *http.Client, logger *slog.Logger) *EtcdRegistrar {
+	if baseURL == "" {
+		baseURL = "http://127.0.0.1:2379"
+	}
+	return &EtcdRegistrar{BaseURL: strings.TrimRight(baseURL, "/"), Prefix: "/services", TTL: 30 * time.Second, Client: httpClient, Log: logger}
+}
+
+func (e *EtcdRegistrar) Register(ctx context.Context, reg ServiceRegistration) error {
+	var grant struct {
+		ID string `json:"ID"` // int64 as a string in the gateway's JSON
+	}
+	body, _ := json.Marshal(map[string]any{"TTL": int64(e.TTL / time.Second)})
+	if err := postJSON(ctx, e.Client, e.BaseURL+"/v3/lease/grant", nil, body, "etcd", &grant); err != nil {
+		return err
+	}
+	value, err := json.Marshal(reg)
+	if err != nil {
+		return err
+	}
+	key := strings.TrimRight(e.Prefix, "/") + "/" + reg.Name + "/" + reg.ID
+	body, _ = json.Marshal(map[string]any{
+		"key":   base64.StdEncoding.EncodeToString([]byte(key)),
+		"value": base64.StdEncoding.EncodeToString(value),
+		"lease": grant.ID,
+	})
+	if err := postJSON(ctx, e.Client, e.BaseURL+"/v3/kv/put", nil, body, "etcd", &struct{}{}); err != nil {
+		return err
+	}
+
+	kctx, cancel := context.WithCancel(context.Background())
+	e.mu.Lock()
+	e.lease, e.cancel, e.done = grant.ID, cancel, make(chan struct{})
+	e.mu.Unlock()
+	go e.keepAlive(kctx, grant.ID, e.done)
+	return nil
+}
+
+// keepAlive renews the lease at a third of its TTL, so one failed renewal
+// does not let it expire.
+func (e *EtcdRegistrar) keepAlive(ctx context.Context, lease string, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(e.TTL / 3)
+	defer ticker.Stop()
+	body, _ := json.Marshal(map[string]any{"ID": lease})
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := postJSON(ctx, e.Client, e.BaseURL+"/v3/lease/keepalive", nil, body, "etcd", &struct{}{}); err != nil && ctx.Err() == nil {
+				e.Log.With("error", err).Warn("etcd lease renewal failed", "lease", lease)
+			}
+		}
+	}
+}
+
+// Deregister stops renewing and revokes the lease, which deletes the key.
+func (e *EtcdRegistrar) Deregister(ctx context.Context) error {
+	e.mu.Lock()
+	lease, cancel, done := e.lease, e.cancel, e.done
+	e.lease = ""
+	e.mu.Unlock()
+	if lease == "" {
+		return nil
+	}
+	cancel()
+	<-done
+	body, _ := json.Marshal(map[string]any{"ID": lease})
+	if err := postJSON(ctx, e.Client, e.BaseURL+"/v3/lease/revoke", nil, body, "etcd", &struct{}{}); err != nil {
+		return fmt.Errorf("revoke lease %s: %w", lease, err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+//go:build unit
+
+package adapter
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRegistration = ServiceRegistration{
+	ID: "book-manager-host1-8080", Name: "book-manager", Address: "host1", Port: 8080,
+	Tags: []string{"v1"}, HealthURL: "http://host1:8080/healthz", CheckInterval: 10 * time.Second,
+}
+
+func TestConsulRegistrar(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "tok", r.Header.Get("X-Consul-Token"))
+		calls = append(calls, r.URL.Path)
+		if r.URL.Path == "/v1/agent/service/register" {
+			var body struct {
+				ID, Name, Address string
+				Port              int
+				Tags              []string
+				Check             struct{ HTTP, Interval string }
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "book-manager-host1-8080", body.ID)
+			assert.Equal(t, 8080, body.Port)
+			assert.Equal(t, []string{"v1"}, body.Tags)
+			assert.Equal(t, "http://host1:8080/healthz", body.Check.HTTP)
+			assert.Equal(t, "10s", body.Check.Interval)
+		}
+	}))
+	defer srv.Close()
+	c := NewConsulRegistrar(srv.URL, "tok", srv.Client())
+
+	require.NoError(t, c.Register(context.Background(), testRegistration))
+	require.NoError(t, c.Deregister(context.Background()))
+	require.NoError(t, c.Deregister(context.Background()), "second deregister is a no-op")
+	assert.Equal(t, []string{"/v1/agent/service/register", "/v1/agent/service/deregister/book-manager-host1-8080"}, calls)
+}
+
+func TestEtcdRegistrar(t *testing.T) {
+	var mu sync.Mutex
+	var keepalives int
+	var put map[string]string
+	revoked := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(b, &body))
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/v3/lease/grant":
+			assert.EqualValues(t, 3, body["TTL"])
+			_, _ = w.Write([]byte(`{"ID":"7587862","TTL":"3"}`))
+		case "/v3/kv/put":
+			put = map[string]string{"lease": body["lease"].(string)}
+			for _, f := range []string{"key", "value"} {
+				v, _ := base64.StdEncoding.DecodeString(body[f].(string))
+				put[f] = string(v)
+			}
+			_, _ = w.Write([]byte(`{}`))
+		case "/v3/lease/keepalive":
+			keepalives++
+			_, _ = w.Write([]byte(`{}`))
+		case "/v3/lease/revoke":
+			revoked <- body["ID"].(string)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+	e := NewEtcdRegistrar(srv.URL, srv.Client(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	e.TTL = 3 * time.Second
+	e.Prefix = "/svc/"
+
+	require.NoError(t, e.Register(context.Background(), testRegistration))
+	mu.Lock()
+	assert.Equal(t, "/svc/book-manager/book-manager-host1-8080", put["key"])
+	assert.Equal(t, "7587862", put["lease"])
+	assert.JSONEq(t, `{"id":"book-manager-host1-8080","name":"book-manager","address":"host1","port":8080,"tags":["v1"],"health_url":"http://host1:8080/healthz"}`, put["value"])
+	mu.Unlock()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return keepalives > 0
+	}, 3*time.Second, 50*time.Millisecond)
+
+	require.NoError(t, e.Deregister(context.Background()))
+	assert.Equal(t, "7587862", <-revoked)
+}
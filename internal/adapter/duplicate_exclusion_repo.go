@@ -0,0 +1,52 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// DuplicateExclusionRepo keeps duplicate exclusions in memory, in creation
+// order; they are lost on restart.
+type DuplicateExclusionRepo struct {
+	mu         sync.RWMutex
+	exclusions []model.DuplicateExclusion
+}
+
+func NewDuplicateExclusionRepo() *DuplicateExclusionRepo {
+	return &DuplicateExclusionRepo{}
+}
+
+func (r *DuplicateExclusionRepo) Add(_ context.Context, e model.DuplicateExclusion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e.BookIDs = slices.Clone(e.BookIDs)
+	r.exclusions = append(r.exclusions, e)
+	return nil
+}
+
+func (r *DuplicateExclusionRepo) List(ctx context.Context) ([]model.DuplicateExclusion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []model.DuplicateExclusion
+	for _, e := range r.exclusions {
+		if ofTenant(ctx, e.Tenant) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (r *DuplicateExclusionRepo) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, e := range r.exclusions {
+		if e.ID == id && ofTenant(ctx, e.Tenant) {
+			r.exclusions = slices.Delete(r.exclusions, i, i+1)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: duplicate exclusion %s", model.ErrNotFound, id)
+}
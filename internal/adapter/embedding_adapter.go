@@ -0,0 +1,108 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// HashingEmbedder vectorizes text locally, without a model: words and word
+// pairs are hashed into Dims buckets with a random sign ("feature
+// hashing"). It finds books sharing vocabulary with the query, not
+// synonyms, but needs no service.
+type HashingEmbedder struct {
+	Dims int
+}
+
+func NewHashingEmbedder(dims int) HashingEmbedder {
+	if dims <= 0 {
+		dims = 512
+	}
+	return HashingEmbedder{Dims: dims}
+}
+
+func (e HashingEmbedder) Model() string { return fmt.Sprintf("hashing-%d", e.Dims) }
+
+func (e HashingEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	for i, t := range texts {
+		v := make([]float32, e.Dims)
+		words := strings.FieldsFunc(strings.ToLower(t), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for j, w := range words {
+			e.add(v, w, 1)
+			if j > 0 {
+				e.add(v, words[j-1]+" "+w, 0.5)
+			}
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+func (e HashingEmbedder) add(v []float32, feature string, weight float32) {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(feature))
+	sum := h.Sum32()
+	if sum&(1<<31) != 0 {
+		weight = -weight
+	}
+	v[int(sum%uint32(e.Dims))] += weight
+}
+
+// OpenAIEmbeddingClient calls an OpenAI-compatible embeddings API
+// (POST /v1/embeddings), which OpenAI and local model servers such as
+// Ollama or llama.cpp offer.
+type OpenAIEmbeddingClient struct {
+	BaseURL   string
+	APIKey    string // optional for local servers
+	ModelName string
+	Client    *http.Client
+	Retry     int
+}
+
+func NewOpenAIEmbeddingClient(baseURL, apiKey, model string, retry int, httpClient *http.Client) *OpenAIEmbeddingClient {
+	if baseURL == "" {
+		baseURL = "https://api.openai.com"
+	}
+	if retry < 0 {
+		retry = 0
+	}
+	return &OpenAIEmbeddingClient{BaseURL: baseURL, APIKey: apiKey, ModelName: model, Client: httpClient, Retry: retry}
+}
+
+func (c *OpenAIEmbeddingClient) Model() string { return c.ModelName }
+
+func (c *OpenAIEmbeddingClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(map[string]any{"model": c.ModelName, "input": texts})
+	if err != nil {
+		return nil, err
+	}
+	var header http.Header
+	if c.APIKey != "" {
+		header = http.Header{"Authorization": {"Bearer " + c.APIKey}}
+	}
+	return fetchWithRetry(ctx, c.Retry, func() ([][]float32, error) {
+		var res struct {
+			Data []struct {
+				Index     int       `json:"index"`
+				Embedding []float32 `json:"embedding"`
+			} `json:"data"`
+		}
+		if err := postJSON(ctx, c.Client, c.BaseURL+"/v1/embeddings", header, body, "embeddings", &res); err != nil {
+			return nil, err
+		}
+		sort.Slice(res.Data, func(i, j int) bool { return res.Data[i].Index < res.Data[j].Index })
+		out := make([][]float32, len(res.Data))
+		for i, d := range res.Data {
+			out[i] = d.Embedding
+		}
+		return out, nil
+	})
+}
@@ -0,0 +1,47 @@
+//go:build unit
+
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIEmbedding_Embed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/embeddings", r.URL.Path)
+		assert.Equal(t, "Bearer k", r.Header.Get("Authorization"))
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "nomic-embed-text", req.Model)
+		assert.Equal(t, []string{"a", "b"}, req.Input)
+		// out of order on purpose; index says which input a vector is for
+		_, _ = w.Write([]byte(`{"data":[{"index":1,"embedding":[0,1]},{"index":0,"embedding":[1,0]}]}`))
+	}))
+	defer srv.Close()
+	c := NewOpenAIEmbeddingClient(srv.URL, "k", "nomic-embed-text", 0, srv.Client())
+	assert.Equal(t, "nomic-embed-text", c.Model())
+
+	vs, err := c.Embed(context.Background(), []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 0}, {0, 1}}, vs)
+}
+
+func TestHashingEmbedder_Deterministic(t *testing.T) {
+	e := NewHashingEmbedder(32)
+	vs, err := e.Embed(context.Background(), []string{"Clean Code", "clean, code!", "Dune"})
+	require.NoError(t, err)
+	require.Len(t, vs, 3)
+	assert.Len(t, vs[0], 32)
+	assert.Equal(t, vs[0], vs[1], "case and punctuation are ignored")
+	assert.NotEqual(t, vs[0], vs[2])
+}
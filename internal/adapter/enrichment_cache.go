@@ -0,0 +1,95 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// EnrichmentCache remembers the answers of an enrichment provider for TTL,
+// so that a book previewed and then created, or imported again, is looked
+// up once. Unknown ISBNs are answers and are remembered too; failures are
+// not. At most Size ISBNs are kept, the least recently used going first.
+type EnrichmentCache struct {
+	TTL  time.Duration
+	Size int
+
+	client enrichmentClient
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     list.List // of *enrichmentCacheEntry, most recent first
+}
+
+type enrichmentCacheEntry struct {
+	isbn    string
+	book    model.EnrichedBook
+	err     error // an unknown ISBN
+	expires time.Time
+}
+
+// NewEnrichmentCache wraps client; a size below 1 is taken as 1.
+func NewEnrichmentCache(client enrichmentClient, ttl time.Duration, size int) *EnrichmentCache {
+	return &EnrichmentCache{TTL: ttl, Size: max(size, 1), client: client, now: time.Now, entries: map[string]*list.Element{}}
+}
+
+func (c *EnrichmentCache) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	if eb, err, ok := c.get(isbn); ok {
+		return eb, err
+	}
+	eb, err := c.client.FetchByISBN(ctx, isbn)
+	if err == nil || errors.Is(err, errNotFound) || errors.Is(err, model.ErrNotFound) {
+		c.put(isbn, eb, err)
+	}
+	return cloneEnriched(eb), err
+}
+
+func (c *EnrichmentCache) get(isbn string) (model.EnrichedBook, error, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[isbn]
+	if !ok {
+		return model.EnrichedBook{}, nil, false
+	}
+	e := el.Value.(*enrichmentCacheEntry)
+	if !c.now().Before(e.expires) {
+		c.lru.Remove(el)
+		delete(c.entries, isbn)
+		return model.EnrichedBook{}, nil, false
+	}
+	c.lru.MoveToFront(el)
+	return cloneEnriched(e.book), e.err, true
+}
+
+func (c *EnrichmentCache) put(isbn string, eb model.EnrichedBook, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := &enrichmentCacheEntry{isbn: isbn, book: cloneEnriched(eb), err: err, expires: c.now().Add(c.TTL)}
+	if el, ok := c.entries[isbn]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[isbn] = c.lru.PushFront(e)
+	for c.lru.Len() > c.Size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*enrichmentCacheEntry).isbn)
+	}
+}
+
+// cloneEnriched copies the slices of eb, which callers merge into books
+// and may change.
+func cloneEnriched(eb model.EnrichedBook) model.EnrichedBook {
+	if eb.Authors != nil {
+		eb.Authors = append([]string(nil), eb.Authors...)
+	}
+	if eb.Subjects != nil {
+		eb.Subjects = append([]string(nil), eb.Subjects...)
+	}
+	return eb
+}
@@ -0,0 +1,66 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrichmentCache(t *testing.T) {
+	ctx := context.Background()
+	src := &fakeEnrich{book: model.EnrichedBook{Source: "openlibrary", Title: util.GetPtr("Dune"), Authors: []string{"Frank Herbert"}}}
+	c := NewEnrichmentCache(src, time.Minute, 2)
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	eb, err := c.FetchByISBN(ctx, "9780441013593")
+	require.NoError(t, err)
+	eb.Authors[0] = "changed by the caller"
+	eb, err = c.FetchByISBN(ctx, "9780441013593")
+	require.NoError(t, err)
+	assert.Equal(t, 1, src.calls)
+	assert.Equal(t, "Dune", *eb.Title)
+	assert.Equal(t, []string{"Frank Herbert"}, eb.Authors, "cached answers are copies")
+
+	now = now.Add(time.Minute)
+	_, err = c.FetchByISBN(ctx, "9780441013593")
+	require.NoError(t, err)
+	assert.Equal(t, 2, src.calls, "expired")
+
+	t.Run("unknown ISBNs are cached, failures are not", func(t *testing.T) {
+		src := &fakeEnrich{err: errNotFound}
+		c := NewEnrichmentCache(src, time.Minute, 10)
+		for range 2 {
+			_, err := c.FetchByISBN(ctx, "9780000000002")
+			assert.ErrorIs(t, err, errNotFound)
+		}
+		assert.Equal(t, 1, src.calls)
+
+		src.err = errors.New("status 503")
+		for range 2 {
+			_, err := c.FetchByISBN(ctx, "9780441013593")
+			assert.Error(t, err)
+		}
+		assert.Equal(t, 3, src.calls)
+	})
+
+	t.Run("least recently used goes first", func(t *testing.T) {
+		src := &fakeEnrich{}
+		c := NewEnrichmentCache(src, time.Minute, 2)
+		for _, isbn := range []string{"a", "b", "a", "c", "a", "b"} {
+			_, err := c.FetchByISBN(ctx, isbn)
+			require.NoError(t, err)
+		}
+		// a, b, c miss; c evicts b, which misses again
+		assert.Equal(t, 4, src.calls)
+		assert.Len(t, c.entries, 2)
+	})
+}
@@ -0,0 +1,64 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ChainSource is one provider of an EnrichmentChain. A zero Timeout leaves
+// the lookup bounded only by the caller's context.
+type ChainSource struct {
+	Name    string
+	Client  enrichmentClient
+	Timeout time.Duration
+}
+
+// EnrichmentChain asks its sources in order and returns the first answer.
+// The winning provider is reported in EnrichedBook.Source.
+type EnrichmentChain struct {
+	sources []ChainSource
+}
+
+func NewEnrichmentChain(sources ...ChainSource) *EnrichmentChain {
+	return &EnrichmentChain{sources: sources}
+}
+
+// FetchByISBN fails with an error matching model.ErrNotFound only when
+// every source reported the book as unknown (errNotFound or
+// model.ErrNotFound); otherwise the failures of all sources are joined.
+func (c *EnrichmentChain) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	var errs []error
+	notFound := 0
+	for _, src := range c.sources {
+		eb, err := c.fetch(ctx, src, isbn)
+		if err == nil {
+			if eb.Source == "" {
+				eb.Source = src.Name
+			}
+			return eb, nil
+		}
+		if ctx.Err() != nil {
+			return model.EnrichedBook{}, ctx.Err()
+		}
+		if errors.Is(err, errNotFound) || errors.Is(err, model.ErrNotFound) {
+			notFound++
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", src.Name, err))
+	}
+	if notFound == len(c.sources) {
+		return model.EnrichedBook{}, fmt.Errorf("%w: no enrichment source knows isbn %s", model.ErrNotFound, isbn)
+	}
+	return model.EnrichedBook{}, errors.Join(errs...)
+}
+
+func (c *EnrichmentChain) fetch(ctx context.Context, src ChainSource, isbn string) (model.EnrichedBook, error) {
+	if src.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, src.Timeout)
+		defer cancel()
+	}
+	return src.Client.FetchByISBN(ctx, isbn)
+}
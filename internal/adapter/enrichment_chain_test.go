@@ -0,0 +1,60 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEnrich struct {
+	book  model.EnrichedBook
+	err   error
+	delay time.Duration
+	calls int
+}
+
+func (f *fakeEnrich) FetchByISBN(ctx context.Context, _ string) (model.EnrichedBook, error) {
+	f.calls++
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return model.EnrichedBook{}, ctx.Err()
+	}
+	return f.book, f.err
+}
+
+func TestEnrichmentChain(t *testing.T) {
+	ctx := context.Background()
+	slow := &fakeEnrich{delay: time.Second, book: model.EnrichedBook{Source: "openlibrary"}}
+	missing := &fakeEnrich{err: errNotFound}
+	hit := &fakeEnrich{book: model.EnrichedBook{Title: util.GetPtr("Dune")}}
+
+	chain := NewEnrichmentChain(
+		ChainSource{Name: "openlibrary", Client: slow, Timeout: 10 * time.Millisecond},
+		ChainSource{Name: "googlebooks", Client: missing},
+		ChainSource{Name: "isbndb", Client: hit},
+	)
+	eb, err := chain.FetchByISBN(ctx, "9780441013593")
+	require.NoError(t, err)
+	assert.Equal(t, "isbndb", eb.Source, "winner is recorded when the client leaves Source empty")
+	assert.Equal(t, "Dune", *eb.Title)
+	assert.Equal(t, []int{1, 1, 1}, []int{slow.calls, missing.calls, hit.calls})
+
+	chain = NewEnrichmentChain(ChainSource{Name: "a", Client: missing}, ChainSource{Name: "b", Client: missing})
+	_, err = chain.FetchByISBN(ctx, "9780441013593")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	boom := errors.New("boom")
+	chain = NewEnrichmentChain(ChainSource{Name: "a", Client: missing}, ChainSource{Name: "b", Client: &fakeEnrich{err: boom}})
+	_, err = chain.FetchByISBN(ctx, "9780441013593")
+	assert.ErrorIs(t, err, boom)
+	assert.Contains(t, err.Error(), "b: boom")
+}
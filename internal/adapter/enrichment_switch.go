@@ -0,0 +1,36 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+var errEnrichmentDisabled = errors.New("enrichment disabled")
+
+type enrichmentClient interface {
+	FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error)
+}
+
+// EnrichmentSwitch lets an enrichment provider be turned off at runtime.
+// While off, lookups fail as if the provider were unavailable.
+type EnrichmentSwitch struct {
+	client   enrichmentClient
+	disabled atomic.Bool
+}
+
+func NewEnrichmentSwitch(client enrichmentClient) *EnrichmentSwitch {
+	return &EnrichmentSwitch{client: client}
+}
+
+func (s *EnrichmentSwitch) SetEnabled(enabled bool) {
+	s.disabled.Store(!enabled)
+}
+
+func (s *EnrichmentSwitch) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	if s.disabled.Load() {
+		return model.EnrichedBook{}, errEnrichmentDisabled
+	}
+	return s.client.FetchByISBN(ctx, isbn)
+}
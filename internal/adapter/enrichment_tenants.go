@@ -0,0 +1,43 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"sync"
+)
+
+// TenantEnrichment looks ISBNs up with the provider of the lookup's
+// tenant, or the default provider for tenants without one of their own
+// and for work outside a tenant. Tenant providers are switches, so a
+// tenant's enrichment can be turned off alone, and can be replaced at
+// runtime, e.g. on config reload.
+type TenantEnrichment struct {
+	def enrichmentClient
+
+	mu      sync.RWMutex
+	tenants map[string]*EnrichmentSwitch
+}
+
+func NewTenantEnrichment(def enrichmentClient) *TenantEnrichment {
+	return &TenantEnrichment{def: def}
+}
+
+// SetTenants replaces the tenant providers, by tenant id.
+func (t *TenantEnrichment) SetTenants(tenants map[string]*EnrichmentSwitch) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tenants = tenants
+}
+
+func (t *TenantEnrichment) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	return t.provider(model.TenantFromContext(ctx)).FetchByISBN(ctx, isbn)
+}
+
+func (t *TenantEnrichment) provider(tenant string) enrichmentClient {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if sw, ok := t.tenants[tenant]; ok && tenant != "" {
+		return sw
+	}
+	return t.def
+}
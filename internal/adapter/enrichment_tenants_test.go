@@ -0,0 +1,41 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantEnrichment(t *testing.T) {
+	def := &fakeEnrich{book: model.EnrichedBook{Source: "default"}}
+	acme := &fakeEnrich{book: model.EnrichedBook{Source: "acme"}}
+	te := NewTenantEnrichment(def)
+	source := func(ctx context.Context) string {
+		t.Helper()
+		res, err := te.FetchByISBN(ctx, "9780441013593")
+		require.NoError(t, err)
+		return res.Source
+	}
+	ctx := context.Background()
+	acmeCtx := model.WithTenant(ctx, "acme")
+	assert.Equal(t, "default", source(acmeCtx), "no tenant providers yet")
+
+	sw := NewEnrichmentSwitch(acme)
+	te.SetTenants(map[string]*EnrichmentSwitch{"acme": sw})
+	assert.Equal(t, "acme", source(acmeCtx))
+	assert.Equal(t, "default", source(model.WithTenant(ctx, "globex")))
+	assert.Equal(t, "default", source(ctx), "work outside a tenant")
+
+	sw.SetEnabled(false)
+	_, err := te.FetchByISBN(acmeCtx, "9780441013593")
+	assert.ErrorIs(t, err, errEnrichmentDisabled)
+	assert.Equal(t, "default", source(model.WithTenant(ctx, "globex")), "only acme is off")
+
+	te.SetTenants(nil)
+	assert.Equal(t, "default", source(acmeCtx))
+}
@@ -0,0 +1,79 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// EscalationRepo keeps escalation policies and their runs in memory; they
+// are lost on restart.
+type EscalationRepo struct {
+	mu       sync.RWMutex
+	policies []model.EscalationPolicy
+	runs     []model.EscalationRun
+}
+
+func NewEscalationRepo() *EscalationRepo {
+	return &EscalationRepo{}
+}
+
+func (r *EscalationRepo) CreatePolicy(_ context.Context, p model.EscalationPolicy) (model.EscalationPolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if slices.ContainsFunc(r.policies, func(other model.EscalationPolicy) bool { return other.ID == p.ID }) {
+		return model.EscalationPolicy{}, fmt.Errorf("%w: escalation policy %s exists", model.ErrConflict, p.ID)
+	}
+	r.policies = append(r.policies, p)
+	return p, nil
+}
+
+func (r *EscalationRepo) ListPolicies(ctx context.Context) ([]model.EscalationPolicy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []model.EscalationPolicy
+	for _, p := range r.policies {
+		if ofTenant(ctx, p.Tenant) {
+			out = append(out, p)
+		}
+	}
+	slices.SortStableFunc(out, func(a, b model.EscalationPolicy) int { return a.AfterDays - b.AfterDays })
+	return out, nil
+}
+
+func (r *EscalationRepo) DeletePolicy(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := slices.IndexFunc(r.policies, func(p model.EscalationPolicy) bool { return p.ID == id && ofTenant(ctx, p.Tenant) })
+	if i < 0 {
+		return fmt.Errorf("%w: escalation policy %s", model.ErrNotFound, id)
+	}
+	r.policies = slices.Delete(r.policies, i, i+1)
+	return nil
+}
+
+func (r *EscalationRepo) Record(_ context.Context, run model.EscalationRun) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if slices.ContainsFunc(r.runs, func(other model.EscalationRun) bool {
+		return other.PolicyID == run.PolicyID && other.LoanID == run.LoanID
+	}) {
+		return false, nil
+	}
+	r.runs = append(r.runs, run)
+	return true, nil
+}
+
+func (r *EscalationRepo) Runs(ctx context.Context, policyID string) ([]model.EscalationRun, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []model.EscalationRun
+	for _, run := range r.runs {
+		if run.PolicyID == policyID && ofTenant(ctx, run.Tenant) {
+			out = append(out, run)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,74 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// busTimeout bounds a publish, including connecting, when ctx has no
+// earlier deadline.
+const busTimeout = 5 * time.Second
+
+// BusTLS configures TLS to a message bus. Without a CA file the system
+// roots verify the server.
+type BusTLS struct {
+	Enabled  bool
+	CAFile   string // PEM roots the server certificate is verified against
+	CertFile string // PEM client certificate, with KeyFile, for mutual TLS
+	KeyFile  string
+}
+
+// config returns the TLS configuration, or nil when TLS is off.
+func (t BusTLS) config() (*tls.Config, error) {
+	if !t.Enabled {
+		return nil, nil
+	}
+	conf := &tls.Config{MinVersion: tls.VersionTLS12}
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootCAs = x509.NewCertPool()
+		if !conf.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s holds no PEM certificate", t.CAFile)
+		}
+	}
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return conf, nil
+}
+
+// busEvent is the wire form of a domain event.
+type busEvent struct {
+	ID      string            `json:"id"`
+	Type    string            `json:"type"`
+	BookID  string            `json:"book_id"`
+	Actor   string            `json:"actor,omitempty"`
+	At      time.Time         `json:"at"`
+	Book    api.Book          `json:"book"`
+	Changes []api.FieldChange `json:"changes,omitempty"`
+}
+
+func marshalBusEvent(ev model.Event) ([]byte, error) {
+	return json.Marshal(busEvent{
+		ID:      ev.ID,
+		Type:    ev.Type,
+		BookID:  ev.BookID,
+		Actor:   ev.Actor,
+		At:      ev.At.UTC(),
+		Book:    fromDomainBook(ev.Book),
+		Changes: fromDomainChanges(ev.Changes),
+	})
+}
@@ -0,0 +1,105 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// extractPrompt asks for the fields of model.CreateBookInput that a
+// citation can contain, as a JSON object.
+const extractPrompt = `You extract bibliographic data from text a user pasted, such as a citation.
+Answer with one JSON object with these keys, leaving out what the text does not state:
+"title" (string, without subtitle), "subtitle" (string), "authors" (array of names in
+"Given Surname" order), "isbn" (string), "published_year" (integer), "page_count" (integer).
+Do not guess values that are not in the text.`
+
+// OpenAIExtractor extracts book fields from free text with a chat model
+// behind an OpenAI-compatible API (POST /v1/chat/completions), e.g. OpenAI
+// or a local Ollama.
+type OpenAIExtractor struct {
+	BaseURL   string
+	APIKey    string // optional for local servers
+	ModelName string
+	Client    *http.Client
+	Retry     int
+}
+
+func NewOpenAIExtractor(baseURL, apiKey, model string, retry int, httpClient *http.Client) *OpenAIExtractor {
+	if baseURL == "" {
+		baseURL = "https://api.openai.com"
+	}
+	if retry < 0 {
+		retry = 0
+	}
+	return &OpenAIExtractor{BaseURL: baseURL, APIKey: apiKey, ModelName: model, Client: httpClient, Retry: retry}
+}
+
+func (c *OpenAIExtractor) Name() string { return "openai" }
+
+func (c *OpenAIExtractor) Extract(ctx context.Context, text string) (model.CreateBookInput, error) {
+	body, err := json.Marshal(map[string]any{
+		"model": c.ModelName,
+		"messages": []map[string]string{
+			{"role": "system", "content": extractPrompt},
+			{"role": "user", "content": text},
+		},
+		"response_format": map[string]string{"type": "json_object"},
+		"temperature":     0,
+	})
+	if err != nil {
+		return model.CreateBookInput{}, err
+	}
+	var header http.Header
+	if c.APIKey != "" {
+		header = http.Header{"Authorization": {"Bearer " + c.APIKey}}
+	}
+	return fetchWithRetry(ctx, c.Retry, func() (model.CreateBookInput, error) {
+		var res struct {
+			Choices []struct {
+				Message struct {
+					Content string `json:"content"`
+				} `json:"message"`
+			} `json:"choices"`
+		}
+		if err := postJSON(ctx, c.Client, c.BaseURL+"/v1/chat/completions", header, body, "extractor", &res); err != nil {
+			return model.CreateBookInput{}, err
+		}
+		if len(res.Choices) == 0 {
+			return model.CreateBookInput{}, errors.New("extractor: no choices in response")
+		}
+		return parseExtracted(res.Choices[0].Message.Content)
+	})
+}
+
+type extractedBook struct {
+	Title         *string  `json:"title"`
+	Subtitle      *string  `json:"subtitle"`
+	Authors       []string `json:"authors"`
+	ISBN          *string  `json:"isbn"`
+	PublishedYear *int     `json:"published_year"`
+	PageCount     *int     `json:"page_count"`
+}
+
+// parseExtracted reads the model's answer, tolerating a Markdown code
+// fence around the JSON.
+func parseExtracted(content string) (model.CreateBookInput, error) {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.Trim(content, "`\n ")
+	var eb extractedBook
+	if err := json.Unmarshal([]byte(content), &eb); err != nil {
+		return model.CreateBookInput{}, err
+	}
+	return model.CreateBookInput{
+		Title:         strPtrOrNil(strings.TrimSpace(deref(eb.Title))),
+		Subtitle:      strPtrOrNil(strings.TrimSpace(deref(eb.Subtitle))),
+		Authors:       nonEmpty(eb.Authors),
+		ISBN:          strPtrOrNil(strings.TrimSpace(deref(eb.ISBN))),
+		PublishedYear: eb.PublishedYear,
+		PageCount:     eb.PageCount,
+	}, nil
+}
@@ -0,0 +1,47 @@
+//go:build unit
+
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIExtractor_Extract(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer k", r.Header.Get("Authorization"))
+		var req struct {
+			Model    string `json:"model"`
+			Messages []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "gpt-4o-mini", req.Model)
+		require.Len(t, req.Messages, 2)
+		assert.Equal(t, "Herbert, F. Dune (1965)", req.Messages[1].Content)
+		content := "```json\n{\"title\":\"Dune\",\"authors\":[\"Frank Herbert\",\"\"],\"published_year\":1965,\"isbn\":\"\"}\n```"
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []any{map[string]any{"message": map[string]any{"role": "assistant", "content": content}}},
+		})
+	}))
+	defer srv.Close()
+	c := NewOpenAIExtractor(srv.URL, "k", "gpt-4o-mini", 0, srv.Client())
+
+	in, err := c.Extract(context.Background(), "Herbert, F. Dune (1965)")
+	require.NoError(t, err)
+	require.NotNil(t, in.Title)
+	assert.Equal(t, "Dune", *in.Title)
+	assert.Equal(t, []string{"Frank Herbert"}, in.Authors)
+	assert.Nil(t, in.ISBN)
+	require.NotNil(t, in.PublishedYear)
+	assert.Equal(t, 1965, *in.PublishedYear)
+}
@@ -0,0 +1,132 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// FeeRepo keeps the fees ledgers in memory and, when opened on a journal
+// file or a bbolt database, their entries there too, so they survive a
+// restart. A borrower has a ledger per tenant.
+type FeeRepo struct {
+	mu         sync.RWMutex
+	byBorrower map[string][]model.FeeEntry
+	records    recordStore[model.FeeEntry]
+}
+
+func NewFeeRepo() *FeeRepo {
+	return &FeeRepo{byBorrower: map[string][]model.FeeEntry{}, records: memoryRecords[model.FeeEntry]{}}
+}
+
+// OpenFeeRepo loads the ledgers kept in path, which need not exist yet.
+func OpenFeeRepo(path string) (*FeeRepo, error) {
+	j, entries, err := openRecordJournal[model.FeeEntry](path)
+	if err != nil {
+		return nil, err
+	}
+	return loadFeeRepo(j, entries), nil
+}
+
+// OpenBoltFeeRepo loads the ledgers kept in db's fees bucket.
+func OpenBoltFeeRepo(db *bolt.DB) (*FeeRepo, error) {
+	b, entries, err := openBoltRecords[model.FeeEntry](db, "fees")
+	if err != nil {
+		return nil, err
+	}
+	return loadFeeRepo(b, entries), nil
+}
+
+// loadFeeRepo files the entries kept in records into their ledgers, in
+// the order they were added.
+func loadFeeRepo(records recordStore[model.FeeEntry], entries map[string]model.FeeEntry) *FeeRepo {
+	r := &FeeRepo{byBorrower: map[string][]model.FeeEntry{}, records: records}
+	for _, e := range entries {
+		r.byBorrower[e.Borrower] = append(r.byBorrower[e.Borrower], e)
+	}
+	for _, ledger := range r.byBorrower {
+		slices.SortFunc(ledger, func(a, b model.FeeEntry) int {
+			if c := a.At.Compare(b.At); c != 0 {
+				return c
+			}
+			return strings.Compare(a.ID, b.ID)
+		})
+	}
+	return r
+}
+
+func (r *FeeRepo) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.records.Close()
+}
+
+func (r *FeeRepo) Add(_ context.Context, e model.FeeEntry) (model.FeeEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.records.writable(); err != nil {
+		return model.FeeEntry{}, err
+	}
+	entries := r.byBorrower[e.Borrower]
+	var balance int64
+	for _, other := range entries {
+		if other.Tenant != e.Tenant {
+			continue
+		}
+		if e.Kind == model.FeeFine && other.Kind == model.FeeFine && other.LoanID == e.LoanID {
+			return model.FeeEntry{}, fmt.Errorf("%w: loan %s was fined already", model.ErrConflict, e.LoanID)
+		}
+		balance += other.Signed()
+	}
+	if e.Kind != model.FeeFine && e.Amount.Amount > balance {
+		return model.FeeEntry{}, fmt.Errorf("%w: %s owes %d.%02d %s", model.ErrConflict, e.Borrower, balance/100, balance%100, e.Amount.Currency)
+	}
+	r.byBorrower[e.Borrower] = append(entries, e)
+	return e, r.records.put(e.ID, e)
+}
+
+func (r *FeeRepo) List(ctx context.Context, borrower string) ([]model.FeeEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []model.FeeEntry
+	for _, e := range r.byBorrower[borrower] {
+		if ofTenant(ctx, e.Tenant) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (r *FeeRepo) Reassign(ctx context.Context, from, to string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.records.writable(); err != nil {
+		return 0, err
+	}
+	var moved, kept []model.FeeEntry
+	for _, e := range r.byBorrower[from] {
+		if !ofTenant(ctx, e.Tenant) {
+			kept = append(kept, e)
+			continue
+		}
+		e.Borrower = to
+		moved = append(moved, e)
+	}
+	merged := append(r.byBorrower[to], moved...)
+	slices.SortStableFunc(merged, func(a, b model.FeeEntry) int { return a.At.Compare(b.At) })
+	r.byBorrower[to] = merged
+	if r.byBorrower[from] = kept; kept == nil {
+		delete(r.byBorrower, from)
+	}
+	for _, e := range moved {
+		if err := r.records.put(e.ID, e); err != nil {
+			return len(moved), err
+		}
+	}
+	return len(moved), nil
+}
@@ -0,0 +1,336 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// FileBookRepo is a durable BookRepository without external dependencies. It
+// serves reads from the embedded in-memory BookRepo and appends every change
+// to a journal file, synced before the call returns. On open the journal is
+// replayed and compacted, which also drops a record torn by a crash.
+//
+// If a journal write fails, the change is still visible in memory but is
+// lost on restart; the repo then refuses further writes.
+//
+// It is also an outbox: a write whose context carries an EventStage keeps
+// the events of its changes in the journal record of the change, so an
+// event is kept if and only if its change is.
+type FileBookRepo struct {
+	*BookRepo
+	path string
+
+	wmu    sync.Mutex // serializes writes so the journal order matches memory
+	f      *os.File
+	broken error
+	outbox []model.Event // unpublished, oldest first; guarded by wmu
+}
+
+type journalRecord struct {
+	Op        string              `json:"op"` // put | delete | rename | rename_history | rename_tags | events | ack
+	Book      *model.Book         `json:"book,omitempty"`
+	ID        string              `json:"id,omitempty"`
+	Rename    *model.AuthorRename `json:"rename,omitempty"`
+	TagRename *model.TagRename    `json:"tag_rename,omitempty"`
+	Events    []model.Event       `json:"events,omitempty"` // added to the outbox with the change
+	Acked     []string            `json:"acked,omitempty"`  // events dropped from the outbox
+}
+
+func OpenFileBookRepo(path string) (*FileBookRepo, error) {
+	r := &FileBookRepo{BookRepo: NewBookRepo(), path: path}
+	if err := r.replay(); err != nil {
+		return nil, err
+	}
+	if err := r.compact(); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	r.f = f
+	return r, nil
+}
+
+func (r *FileBookRepo) Close() error {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+	return r.f.Close()
+}
+
+func (r *FileBookRepo) Create(ctx context.Context, b model.Book) (model.Book, error) {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+	if r.broken != nil {
+		return model.Book{}, r.broken
+	}
+	out, err := r.BookRepo.Create(ctx, b)
+	if err != nil {
+		return model.Book{}, err
+	}
+	return out, r.append(journalRecord{Op: "put", Book: &out, Events: stageEvents(ctx, nil, &out)})
+}
+
+func (r *FileBookRepo) CreateCapped(ctx context.Context, b model.Book, limit int) (model.Book, error) {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+	if r.broken != nil {
+		return model.Book{}, r.broken
+	}
+	out, err := r.BookRepo.CreateCapped(ctx, b, limit)
+	if err != nil {
+		return model.Book{}, err
+	}
+	return out, r.append(journalRecord{Op: "put", Book: &out, Events: stageEvents(ctx, nil, &out)})
+}
+
+func (r *FileBookRepo) Update(ctx context.Context, b model.Book) (model.Book, error) {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+	if r.broken != nil {
+		return model.Book{}, r.broken
+	}
+	old, _ := r.BookRepo.GetByID(ctx, b.ID)
+	out, err := r.BookRepo.Update(ctx, b)
+	if err != nil {
+		return model.Book{}, err
+	}
+	return out, r.append(journalRecord{Op: "put", Book: &out, Events: stageEvents(ctx, &old, &out)})
+}
+
+func (r *FileBookRepo) Delete(ctx context.Context, id string) error {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+	if r.broken != nil {
+		return r.broken
+	}
+	old, _ := r.BookRepo.GetByID(ctx, id)
+	if err := r.BookRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.append(journalRecord{Op: "delete", ID: id, Events: stageEvents(ctx, &old, nil)})
+}
+
+func (r *FileBookRepo) RenameAuthor(ctx context.Context, rn model.AuthorRename) (model.AuthorRename, error) {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+	if r.broken != nil {
+		return model.AuthorRename{}, r.broken
+	}
+	out, err := r.BookRepo.RenameAuthor(ctx, rn)
+	if err != nil || out.BooksUpdated == 0 {
+		return out, err
+	}
+	rn.Tenant = out.Tenant
+	return out, r.append(journalRecord{Op: "rename", Rename: &rn, Events: stageChanges(ctx, out.Changes)})
+}
+
+func (r *FileBookRepo) RenameTags(ctx context.Context, rn model.TagRename) (model.TagRename, error) {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+	if r.broken != nil {
+		return model.TagRename{}, r.broken
+	}
+	out, err := r.BookRepo.RenameTags(ctx, rn)
+	if err != nil || out.BooksUpdated == 0 {
+		return out, err
+	}
+	rn.Tenant = out.Tenant
+	return out, r.append(journalRecord{Op: "rename_tags", TagRename: &rn, Events: stageChanges(ctx, out.Changes)})
+}
+
+// Add keeps an event of a change made without an EventStage.
+func (r *FileBookRepo) Add(_ context.Context, ev model.Event) error {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+	if r.broken != nil {
+		return r.broken
+	}
+	return r.append(journalRecord{Op: "events", Events: []model.Event{ev}})
+}
+
+func (r *FileBookRepo) Pending(_ context.Context, limit int) ([]model.Event, error) {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+	return slices.Clone(r.outbox[:min(limit, len(r.outbox))]), nil
+}
+
+func (r *FileBookRepo) Ack(_ context.Context, ids []string) error {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+	if r.broken != nil {
+		return r.broken
+	}
+	return r.append(journalRecord{Op: "ack", Acked: ids})
+}
+
+// stageEvents returns the event of the change of before to after for the
+// EventStage in ctx, if any.
+func stageEvents(ctx context.Context, before, after *model.Book) []model.Event {
+	st := model.EventStageFromContext(ctx)
+	if st == nil {
+		return nil
+	}
+	if ev, ok := st.Event(before, after); ok {
+		return []model.Event{ev}
+	}
+	return nil
+}
+
+func stageChanges(ctx context.Context, changes []model.BookChange) []model.Event {
+	var out []model.Event
+	for _, c := range changes {
+		out = append(out, stageEvents(ctx, &c.Before, &c.After)...)
+	}
+	return out
+}
+
+// append writes one record and syncs it, then applies its outbox changes;
+// callers hold wmu.
+func (r *FileBookRepo) append(rec journalRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if _, err = r.f.Write(append(line, '\n')); err == nil {
+		err = r.f.Sync()
+	}
+	if err != nil {
+		r.broken = fmt.Errorf("journal %s: %w", r.path, err)
+		return r.broken
+	}
+	r.applyOutbox(rec)
+	return nil
+}
+
+func (r *FileBookRepo) applyOutbox(rec journalRecord) {
+	r.outbox = append(r.outbox, rec.Events...)
+	if len(rec.Acked) > 0 {
+		r.outbox = slices.DeleteFunc(r.outbox, func(ev model.Event) bool { return slices.Contains(rec.Acked, ev.ID) })
+	}
+}
+
+func (r *FileBookRepo) replay() error {
+	data, err := os.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// anything after the last newline is a record torn by a crash
+	if i := bytes.LastIndexByte(data, '\n'); i < len(data)-1 {
+		data = data[:i+1]
+	}
+	ctx := context.Background()
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		var rec journalRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return fmt.Errorf("journal %s line %d: %w", r.path, n, err)
+		}
+		if err := r.apply(ctx, rec); err != nil {
+			return fmt.Errorf("journal %s line %d: %w", r.path, n, err)
+		}
+	}
+	return sc.Err()
+}
+
+func (r *FileBookRepo) apply(ctx context.Context, rec journalRecord) error {
+	r.applyOutbox(rec)
+	switch {
+	case rec.Op == "put" && rec.Book != nil:
+		if _, err := r.BookRepo.GetByID(ctx, rec.Book.ID); err == nil {
+			r.BookRepo.mu.Lock()
+			defer r.BookRepo.mu.Unlock()
+			_, err = r.BookRepo.replace(*rec.Book, false)
+			return err
+		}
+		_, err := r.BookRepo.Create(ctx, *rec.Book)
+		return err
+	case rec.Op == "delete":
+		return r.BookRepo.Delete(ctx, rec.ID)
+	case rec.Op == "rename" && rec.Rename != nil:
+		_, err := r.BookRepo.RenameAuthor(model.WithTenant(ctx, rec.Rename.Tenant), *rec.Rename)
+		return err
+	case rec.Op == "rename_tags" && rec.TagRename != nil:
+		_, err := r.BookRepo.RenameTags(model.WithTenant(ctx, rec.TagRename.Tenant), *rec.TagRename)
+		return err
+	case rec.Op == "rename_history" && rec.Rename != nil:
+		r.BookRepo.mu.Lock()
+		r.BookRepo.renames = append(r.BookRepo.renames, *rec.Rename)
+		r.BookRepo.mu.Unlock()
+		return nil
+	case rec.Op == "events" || rec.Op == "ack":
+		return nil
+	}
+	return fmt.Errorf("unknown journal record %q", rec.Op)
+}
+
+// compact rewrites the journal as one put per book plus the rename history
+// and the unpublished events, replacing the old file atomically.
+func (r *FileBookRepo) compact() error {
+	r.BookRepo.mu.RLock()
+	books := make([]model.Book, 0, len(r.BookRepo.byID))
+	for _, b := range r.BookRepo.byID {
+		books = append(books, b)
+	}
+	renames := append([]model.AuthorRename(nil), r.BookRepo.renames...)
+	r.BookRepo.mu.RUnlock()
+	sort.Slice(books, func(i, j int) bool {
+		if !books[i].CreatedAt.Equal(books[j].CreatedAt) {
+			return books[i].CreatedAt.Before(books[j].CreatedAt)
+		}
+		return books[i].ID < books[j].ID
+	})
+
+	tmp := r.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for i := range books {
+		if err = enc.Encode(journalRecord{Op: "put", Book: &books[i]}); err != nil {
+			break
+		}
+	}
+	for i := 0; err == nil && i < len(renames); i++ {
+		err = enc.Encode(journalRecord{Op: "rename_history", Rename: &renames[i]})
+	}
+	if err == nil && len(r.outbox) > 0 {
+		err = enc.Encode(journalRecord{Op: "events", Events: r.outbox})
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, r.path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if dir, err := os.Open(filepath.Dir(r.path)); err == nil {
+		_ = dir.Sync()
+		_ = dir.Close()
+	}
+	return nil
+}
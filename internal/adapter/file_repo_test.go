@@ -0,0 +1,141 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileBookRepo_SurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "books.journal")
+	ctx := context.Background()
+	r, err := OpenFileBookRepo(path)
+	require.NoError(t, err)
+
+	_, err = r.Create(ctx, model.Book{ID: "b1", Title: "One", ISBN: util.GetPtr("9780134494166"), Authors: []string{"Bob"}, CreatedAt: time.Unix(1, 0)})
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Book{ID: "b2", Title: "Two", CreatedAt: time.Unix(2, 0)})
+	require.NoError(t, err)
+	_, err = r.Update(ctx, model.Book{ID: "b2", Version: 1, Title: "Two v2", Tags: []string{"scifi"}, CreatedAt: time.Unix(2, 0)})
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Book{ID: "b3", Title: "Three", CreatedAt: time.Unix(3, 0)})
+	require.NoError(t, err)
+	require.NoError(t, r.Delete(ctx, "b3"))
+	_, err = r.RenameAuthor(ctx, model.AuthorRename{From: "bob", To: "Robert", RenamedAt: time.Unix(4, 0)})
+	require.NoError(t, err)
+	_, err = r.RenameTags(ctx, model.TagRename{From: []string{"scifi"}, To: "sf", RenamedAt: time.Unix(5, 0)})
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	r, err = OpenFileBookRepo(path)
+	require.NoError(t, err)
+	defer r.Close()
+	page, err := r.List(ctx, model.ListQuery{Page: 1, PageSize: 10, Sort: []model.SortKey{{Field: "created_at"}}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b1", "b2"}, ids(page.Data))
+	assert.Equal(t, "Two v2", page.Data[1].Title)
+	assert.Equal(t, []string{"Robert"}, page.Data[0].Authors)
+	assert.Equal(t, 2, page.Data[0].Version, "renames count as a write")
+	assert.Equal(t, []string{"sf"}, page.Data[1].Tags)
+	assert.Equal(t, 3, page.Data[1].Version)
+
+	got, err := r.GetByISBN(ctx, "", "978-0-13-449416-6")
+	require.NoError(t, err)
+	assert.Equal(t, "b1", got.ID)
+	renames, err := r.ListAuthorRenames(ctx)
+	require.NoError(t, err)
+	require.Len(t, renames, 1)
+	assert.Equal(t, 1, renames[0].BooksUpdated)
+}
+
+func TestFileBookRepo_DropsTornRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "books.journal")
+	ctx := context.Background()
+	r, err := OpenFileBookRepo(path)
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Book{ID: "b1", Title: "One"})
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"op":"put","book":{"ID":"b2"`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	r, err = OpenFileBookRepo(path)
+	require.NoError(t, err)
+	defer r.Close()
+	_, err = r.GetByID(ctx, "b1")
+	assert.NoError(t, err)
+	_, err = r.GetByID(ctx, "b2")
+	assert.Error(t, err)
+}
+
+func TestFileBookRepo_RejectsCorruptJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "books.journal")
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o644))
+	_, err := OpenFileBookRepo(path)
+	assert.Error(t, err)
+}
+
+func TestFileBookRepo_ConflictIsNotJournaled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "books.journal")
+	ctx := context.Background()
+	r, err := OpenFileBookRepo(path)
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Book{ID: "b1", Title: "One"})
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Book{ID: "b1", Title: "Dup"})
+	assert.Error(t, err)
+	require.NoError(t, r.Close())
+
+	r, err = OpenFileBookRepo(path)
+	require.NoError(t, err)
+	defer r.Close()
+	got, err := r.GetByID(ctx, "b1")
+	require.NoError(t, err)
+	assert.Equal(t, "One", got.Title)
+}
+
+func TestFileBookRepo_RenamesStayInTheirTenant(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "books.journal")
+	east := model.WithTenant(context.Background(), "east")
+	r, err := OpenFileBookRepo(path)
+	require.NoError(t, err)
+	_, err = r.Create(east, model.Book{ID: "b1", Tenant: "east", Authors: []string{"Bob"}, Tags: []string{"scifi"}})
+	require.NoError(t, err)
+	_, err = r.Create(east, model.Book{ID: "b2", Tenant: "west", Authors: []string{"Bob"}, Tags: []string{"scifi"}})
+	require.NoError(t, err)
+	_, err = r.RenameAuthor(east, model.AuthorRename{From: "bob", To: "Robert"})
+	require.NoError(t, err)
+	_, err = r.RenameTags(east, model.TagRename{From: []string{"scifi"}, To: "sf"})
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	r, err = OpenFileBookRepo(path)
+	require.NoError(t, err)
+	defer r.Close()
+	ctx := context.Background()
+	b2, err := r.GetByID(ctx, "b2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bob"}, b2.Authors)
+	assert.Equal(t, []string{"scifi"}, b2.Tags)
+	_, err = r.GetByID(east, "b2")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	renames, err := r.ListAuthorRenames(model.WithTenant(ctx, "west"))
+	require.NoError(t, err)
+	assert.Empty(t, renames)
+	renames, err = r.ListAuthorRenames(east)
+	require.NoError(t, err)
+	assert.Len(t, renames, 1)
+}
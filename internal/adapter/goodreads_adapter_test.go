@@ -0,0 +1,76 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const goodreadsFeed = `<?xml version="1.0"?>
+<rss version="2.0">
+<channel>
+  <title>Alice's bookshelf: to-read</title>
+  <item>
+    <guid><![CDATA[https://www.goodreads.com/review/show/1]]></guid>
+    <title>The Way of Kings (The Stormlight Archive, #1)</title>
+    <book_id>7235533</book_id>
+    <author_name>Brandon Sanderson</author_name>
+    <isbn>0765326353</isbn>
+    <book_published>2010</book_published>
+  </item>
+  <item>
+    <title><![CDATA[Piranesi]]></title>
+    <author_name>Susanna Clarke</author_name>
+    <isbn></isbn>
+    <book_published></book_published>
+  </item>
+</channel>
+</rss>`
+
+func TestGoodreads_Shelf(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/review/list_rss/42":
+			assert.Equal(t, "secret", r.URL.Query().Get("key"))
+			w.Header().Set("Content-Type", "application/rss+xml")
+			_, _ = w.Write([]byte(goodreadsFeed))
+		case "/review/list_rss/500":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c := NewGoodreadsClient(1, srv.Client())
+
+	shelf, err := c.Shelf(context.Background(), srv.URL+"/review/list_rss/42?key=secret&shelf=to-read")
+	require.NoError(t, err)
+	assert.Equal(t, model.Shelf{Name: "to-read", Entries: []model.ShelfEntry{
+		{Title: "The Way of Kings", Authors: []string{"Brandon Sanderson"}, ISBN: "0765326353", PublishedYear: util.GetPtr(2010)},
+		{Title: "Piranesi", Authors: []string{"Susanna Clarke"}},
+	}}, shelf)
+
+	shelf, err = c.Shelf(context.Background(), srv.URL+"/review/list_rss/42?key=secret")
+	require.NoError(t, err)
+	assert.Equal(t, "goodreads", shelf.Name, "a feed of all shelves")
+
+	calls = 0
+	_, err = c.Shelf(context.Background(), srv.URL+"/review/list_rss/7")
+	assert.ErrorIs(t, err, errNotFound)
+	assert.Equal(t, 1, calls)
+	_, err = c.Shelf(context.Background(), srv.URL+"/review/list_rss/500")
+	assert.Error(t, err)
+
+	_, err = c.Shelf(context.Background(), "goodreads.com/review/list_rss/42")
+	assert.Error(t, err)
+}
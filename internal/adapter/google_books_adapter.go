@@ -0,0 +1,184 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GoogleBooksClient looks books up in the Google Books volumes API. The API
+// works without a key at a low quota; set APIKey for production use.
+type GoogleBooksClient struct {
+	BaseURL string
+	APIKey  string
+	Country string // ISO 3166 country for sale info, e.g. "DE"; optional
+	Client  *http.Client
+	Retry   int
+}
+
+func NewGoogleBooksClient(baseURL, apiKey string, retry int, httpClient *http.Client) *GoogleBooksClient {
+	if baseURL == "" {
+		baseURL = "https://www.googleapis.com"
+	}
+	if retry < 0 {
+		retry = 0
+	}
+	return &GoogleBooksClient{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+		Client:  httpClient,
+		Retry:   retry,
+	}
+}
+
+func (c *GoogleBooksClient) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	v, err := c.fetchVolume(ctx, isbn)
+	if err != nil {
+		return model.EnrichedBook{}, err
+	}
+	return mapGoogleVolume(v.VolumeInfo), nil
+}
+
+// FetchPrice quotes the retail price from the volume's sale info, as sold in
+// Country (or the country of the caller's IP when empty).
+func (c *GoogleBooksClient) FetchPrice(ctx context.Context, isbn string) (model.PricePoint, error) {
+	v, err := c.fetchVolume(ctx, isbn)
+	if err != nil {
+		return model.PricePoint{}, err
+	}
+	p := v.SaleInfo.RetailPrice
+	if p == nil {
+		p = v.SaleInfo.ListPrice
+	}
+	if p == nil || p.CurrencyCode == "" {
+		return model.PricePoint{}, errNotFound // not for sale
+	}
+	return model.PricePoint{
+		Price:  model.Price{Amount: int64(math.Round(p.Amount * 100)), Currency: p.CurrencyCode},
+		Source: "googlebooks",
+	}, nil
+}
+
+func (c *GoogleBooksClient) fetchVolume(ctx context.Context, isbn string) (googleVolume, error) {
+	q := url.Values{"q": {"isbn:" + isbn}}
+	if c.APIKey != "" {
+		q.Set("key", c.APIKey)
+	}
+	if c.Country != "" {
+		q.Set("country", c.Country)
+	}
+	u := c.BaseURL + "/books/v1/volumes?" + q.Encode()
+	return fetchWithRetry(ctx, c.Retry, func() (googleVolume, error) {
+		return c.fetchOnce(ctx, u)
+	})
+}
+
+func (c *GoogleBooksClient) fetchOnce(ctx context.Context, u string) (googleVolume, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return googleVolume{}, err
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return googleVolume{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return googleVolume{}, fmt.Errorf("googlebooks: status %d: %s", resp.StatusCode, string(b))
+	}
+
+	var res googleVolumes
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return googleVolume{}, err
+	}
+	// an unknown ISBN is an empty result, not a 404
+	if len(res.Items) == 0 {
+		return googleVolume{}, errNotFound
+	}
+	return res.Items[0], nil
+}
+
+type googleVolumes struct {
+	TotalItems int            `json:"totalItems"`
+	Items      []googleVolume `json:"items"`
+}
+
+type googleVolume struct {
+	VolumeInfo googleVolumeInfo `json:"volumeInfo"`
+	SaleInfo   struct {
+		ListPrice   *googlePrice `json:"listPrice"`
+		RetailPrice *googlePrice `json:"retailPrice"`
+	} `json:"saleInfo"`
+}
+
+type googlePrice struct {
+	Amount       float64 `json:"amount"`
+	CurrencyCode string  `json:"currencyCode"`
+}
+
+type googleVolumeInfo struct {
+	Title         *string  `json:"title"`
+	Subtitle      *string  `json:"subtitle"`
+	Authors       []string `json:"authors"`
+	PublishedDate *string  `json:"publishedDate"` // "2017", "2017-09" or "2017-09-10"
+	PageCount     *int     `json:"pageCount"`
+	Description   *string  `json:"description"`
+	Categories    []string `json:"categories"`
+	ImageLinks    struct {
+		SmallThumbnail string `json:"smallThumbnail"`
+		Thumbnail      string `json:"thumbnail"`
+	} `json:"imageLinks"`
+}
+
+func mapGoogleVolume(v googleVolumeInfo) model.EnrichedBook {
+	var year *int
+	if v.PublishedDate != nil {
+		if y, err := parseYear(*v.PublishedDate); err == nil {
+			year = &y
+		}
+	}
+
+	var cover *string
+	img := v.ImageLinks.Thumbnail
+	if img == "" {
+		img = v.ImageLinks.SmallThumbnail
+	}
+	if img != "" {
+		// the API still hands out plain http image links
+		img = strings.Replace(img, "http://", "https://", 1)
+		cover = &img
+	}
+
+	pages := v.PageCount
+	if pages != nil && *pages < 1 {
+		pages = nil // unknown is reported as 0
+	}
+
+	authors := make([]string, 0, len(v.Authors))
+	for _, a := range v.Authors {
+		if a != "" {
+			authors = append(authors, a)
+		}
+	}
+
+	return model.EnrichedBook{
+		Source:        "googlebooks",
+		Description:   strPtrOrNil(strings.TrimSpace(deref(v.Description))),
+		Subjects:      nonEmpty(v.Categories),
+		Title:         v.Title,
+		Subtitle:      v.Subtitle,
+		PublishedYear: year,
+		PageCount:     pages,
+		CoverURL:      cover,
+		Authors:       authors,
+		ReleaseDate:   parseDate(v.PublishedDate),
+	}
+}
@@ -0,0 +1,82 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoogleBooks_FetchByISBN(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/books/v1/volumes", r.URL.Path)
+		assert.Equal(t, "secret", r.URL.Query().Get("key"))
+		switch r.URL.Query().Get("q") {
+		case "isbn:9780134494166":
+			_, _ = w.Write([]byte(`{"totalItems":1,"items":[{"volumeInfo":{
+				"title":"Clean Architecture","subtitle":"A Craftsman's Guide",
+				"authors":["Robert C. Martin"],"publishedDate":"2017-09-10","pageCount":0,
+				"imageLinks":{"thumbnail":"http://books.google.com/books/content?id=x&zoom=1"}}}]}`))
+		case "isbn:9790000000001":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{"totalItems":0}`))
+		}
+	}))
+	defer srv.Close()
+	c := NewGoogleBooksClient(srv.URL, "secret", 1, srv.Client())
+
+	eb, err := c.FetchByISBN(context.Background(), "9780134494166")
+	require.NoError(t, err)
+	assert.Equal(t, "googlebooks", eb.Source)
+	assert.Equal(t, "Clean Architecture", *eb.Title)
+	assert.Equal(t, 2017, *eb.PublishedYear)
+	assert.Equal(t, "2017-09-10", eb.ReleaseDate.Format(time.DateOnly))
+	assert.Nil(t, eb.PageCount)
+	assert.Equal(t, "https://books.google.com/books/content?id=x&zoom=1", *eb.CoverURL)
+	assert.Equal(t, []string{"Robert C. Martin"}, eb.Authors)
+
+	calls = 0
+	_, err = c.FetchByISBN(context.Background(), "9780000000000")
+	assert.ErrorIs(t, err, errNotFound)
+	assert.Equal(t, 1, calls, "not found is not retried")
+
+	calls = 0
+	_, err = c.FetchByISBN(context.Background(), "9790000000001")
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestGoogleBooks_FetchPrice(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DE", r.URL.Query().Get("country"))
+		switch r.URL.Query().Get("q") {
+		case "isbn:9780134494166":
+			_, _ = w.Write([]byte(`{"totalItems":1,"items":[{"volumeInfo":{"title":"Clean Architecture"},
+				"saleInfo":{"saleability":"FOR_SALE","listPrice":{"amount":29.99,"currencyCode":"EUR"},
+				"retailPrice":{"amount":19.99,"currencyCode":"EUR"}}}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"totalItems":1,"items":[{"volumeInfo":{"title":"Free"},"saleInfo":{"saleability":"NOT_FOR_SALE"}}]}`))
+		}
+	}))
+	defer srv.Close()
+	c := NewGoogleBooksClient(srv.URL, "", 0, srv.Client())
+	c.Country = "DE"
+
+	p, err := c.FetchPrice(context.Background(), "9780134494166")
+	require.NoError(t, err)
+	assert.Equal(t, model.Price{Amount: 1999, Currency: "EUR"}, p.Price)
+	assert.Equal(t, "googlebooks", p.Source)
+
+	_, err = c.FetchPrice(context.Background(), "9780123456786")
+	assert.ErrorIs(t, err, errNotFound)
+}
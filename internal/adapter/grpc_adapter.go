@@ -0,0 +1,357 @@
+package adapter
+
+import (
+	"book-manager/api/bookpb"
+	"book-manager/internal/core/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCServer serves the book operations of BookService over gRPC
+// (api/bookpb/book.proto), for internal services that would rather not
+// pay for JSON. It shares the service, and so the validation, events and
+// audit log, with the HTTP API.
+type GRPCServer struct {
+	bookpb.UnimplementedBookServiceServer
+	Svc BookService
+	log *slog.Logger
+}
+
+func NewGRPCServer(svc BookService, logger *slog.Logger) *GRPCServer {
+	return &GRPCServer{Svc: svc, log: logger}
+}
+
+// Server returns a gRPC server of s with reflection on. Each call first
+// passes through mw, the HTTP middleware that identifies callers and their
+// tenant (auth.Authenticator.Middleware, Tenancy.Middleware,
+// ActorMiddleware), as the request of the HTTP API it stands for, so the
+// same credentials, roles and tenants apply; the metadata are its headers.
+func (s *GRPCServer) Server(mw ...func(http.Handler) http.Handler) *grpc.Server {
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(s.logCalls, httpMiddleware(mw)))
+	bookpb.RegisterBookServiceServer(srv, s)
+	reflection.Register(srv)
+	return srv
+}
+
+func (s *GRPCServer) logCalls(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		s.log.With("error", err).Info("grpc call failed", "method", info.FullMethod, "code", status.Code(err).String())
+	}
+	return res, err
+}
+
+// httpRoutes maps the methods of BookService to the HTTP API's method and
+// path, {id} standing for the request's id.
+var httpRoutes = map[string][2]string{
+	bookpb.BookService_CreateBook_FullMethodName:  {http.MethodPost, "/api/v1/books"},
+	bookpb.BookService_GetBook_FullMethodName:     {http.MethodGet, "/api/v1/books/{id}"},
+	bookpb.BookService_ListBooks_FullMethodName:   {http.MethodGet, "/api/v1/books"},
+	bookpb.BookService_UpdateBook_FullMethodName:  {http.MethodPut, "/api/v1/books/{id}"},
+	bookpb.BookService_DeleteBook_FullMethodName:  {http.MethodDelete, "/api/v1/books/{id}"},
+	bookpb.BookService_RestoreBook_FullMethodName: {http.MethodPost, "/api/v1/books/{id}/restore"},
+	bookpb.BookService_EnrichBook_FullMethodName:  {http.MethodPost, "/api/v1/books/{id}/enrich"},
+}
+
+// httpMiddleware runs mw on the HTTP request each call stands for and
+// continues the call with the context it reaches the end with, or fails it
+// as the middleware answered.
+func httpMiddleware(mw []func(http.Handler) http.Handler) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		route, ok := httpRoutes[info.FullMethod]
+		if !ok {
+			return nil, status.Errorf(codes.Unimplemented, "method %s not implemented", info.FullMethod)
+		}
+		path := route[1]
+		if r, ok := req.(interface{ GetId() string }); ok {
+			path = strings.Replace(path, "{id}", url.PathEscape(r.GetId()), 1)
+		}
+		u := &url.URL{Path: path}
+		if r, ok := req.(*bookpb.ListBooksRequest); ok && r.GetIncludeDeleted() {
+			u.RawQuery = "include_deleted=true"
+		}
+		r, err := http.NewRequestWithContext(ctx, route[0], u.String(), nil)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		for k, vs := range md {
+			if strings.HasPrefix(k, ":") {
+				continue
+			}
+			r.Header[http.CanonicalHeaderKey(k)] = vs
+		}
+		if a := md.Get(":authority"); len(a) > 0 {
+			r.Host = a[0]
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			r.RemoteAddr = p.Addr.String()
+		}
+
+		var passed context.Context // nil while rejected
+		var next http.Handler = http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			passed = r.Context()
+		})
+		for i := len(mw) - 1; i >= 0; i-- {
+			next = mw[i](next)
+		}
+		rw := &grpcResponse{header: http.Header{}}
+		next.ServeHTTP(rw, r)
+		if passed == nil {
+			return nil, rw.err()
+		}
+		return handler(passed, req)
+	}
+}
+
+// grpcResponse takes the answer of middleware that rejected a call.
+type grpcResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *grpcResponse) Header() http.Header {
+	return w.header
+}
+
+func (w *grpcResponse) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+func (w *grpcResponse) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *grpcResponse) err() error {
+	var e errBody
+	msg := http.StatusText(w.status)
+	if json.Unmarshal(w.body.Bytes(), &e) == nil && e.Error.Message != "" {
+		msg = e.Error.Message
+	}
+	code := codes.Unavailable
+	switch w.status {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	}
+	return status.Error(code, msg)
+}
+
+// grpcErr maps service errors to status codes as mapSvcErr does to HTTP
+// statuses.
+func grpcErr(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, model.ErrValidation):
+		code = codes.InvalidArgument
+	case errors.Is(err, model.ErrConflict):
+		code = codes.Aborted
+	case errors.Is(err, model.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, model.ErrUpstream):
+		code = codes.Unavailable
+	case errors.Is(err, model.ErrQuota):
+		code = codes.ResourceExhausted
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	}
+	return status.Error(code, err.Error())
+}
+
+func (s *GRPCServer) CreateBook(ctx context.Context, in *bookpb.CreateBookRequest) (*bookpb.Book, error) {
+	b, err := s.Svc.CreateBook(ctx, model.CreateBookInput{
+		ISBN:              in.Isbn,
+		Title:             in.Title,
+		Subtitle:          in.Subtitle,
+		PublishedYear:     fromProtoInt(in.PublishedYear),
+		PageCount:         fromProtoInt(in.PageCount),
+		CoverURL:          in.CoverUrl,
+		Tags:              in.Tags,
+		Authors:           in.Authors,
+		Forthcoming:       in.Forthcoming,
+		ReleaseDate:       fromProtoTime(in.ReleaseDate),
+		Enrich:            in.Enrich,
+		RequireEnrichment: in.RequireEnrichment,
+	})
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+	return toProtoBook(b), nil
+}
+
+func (s *GRPCServer) GetBook(ctx context.Context, in *bookpb.GetBookRequest) (*bookpb.Book, error) {
+	b, err := s.Svc.GetBook(ctx, in.Id)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+	return toProtoBook(b), nil
+}
+
+func (s *GRPCServer) ListBooks(ctx context.Context, in *bookpb.ListBooksRequest) (*bookpb.ListBooksResponse, error) {
+	q := model.ListQuery{
+		Q:              in.Q,
+		Author:         in.Author,
+		Year:           fromProtoInt(in.Year),
+		Tag:            in.Tag,
+		Page:           max(int(in.Page), 1),
+		PageSize:       int(in.PageSize),
+		Cursor:         in.Cursor,
+		IncludeDeleted: in.IncludeDeleted,
+	}
+	if q.PageSize == 0 {
+		q.PageSize = 20
+	}
+	for _, f := range strings.Split(in.Sort, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			desc := strings.HasPrefix(f, "-")
+			q.Sort = append(q.Sort, model.SortKey{Field: strings.TrimPrefix(f, "-"), Desc: desc})
+		}
+	}
+	page, err := s.Svc.ListBooks(ctx, q)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+	out := &bookpb.ListBooksResponse{
+		Books:      make([]*bookpb.Book, 0, len(page.Data)),
+		Page:       int32(page.Page),
+		PageSize:   int32(page.PageSize),
+		Total:      int32(page.Total),
+		NextCursor: page.NextCursor,
+	}
+	for _, b := range page.Data {
+		out.Books = append(out.Books, toProtoBook(b))
+	}
+	return out, nil
+}
+
+func (s *GRPCServer) UpdateBook(ctx context.Context, in *bookpb.UpdateBookRequest) (*bookpb.Book, error) {
+	b, err := s.Svc.UpdateBook(ctx, in.Id, model.UpdateBookInput{
+		ISBN:          in.Isbn,
+		Title:         &in.Title,
+		Subtitle:      in.Subtitle,
+		PublishedYear: fromProtoInt(in.PublishedYear),
+		PageCount:     fromProtoInt(in.PageCount),
+		CoverURL:      in.CoverUrl,
+		Tags:          in.Tags,
+		Authors:       in.Authors,
+		Forthcoming:   in.Forthcoming,
+		ReleaseDate:   fromProtoTime(in.ReleaseDate),
+		Version:       fromProtoInt(in.Version),
+	})
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+	return toProtoBook(b), nil
+}
+
+func (s *GRPCServer) DeleteBook(ctx context.Context, in *bookpb.DeleteBookRequest) (*emptypb.Empty, error) {
+	if err := s.Svc.DeleteBook(ctx, in.Id); err != nil {
+		return nil, grpcErr(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *GRPCServer) RestoreBook(ctx context.Context, in *bookpb.RestoreBookRequest) (*bookpb.Book, error) {
+	b, err := s.Svc.RestoreBook(ctx, in.Id)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+	return toProtoBook(b), nil
+}
+
+func (s *GRPCServer) EnrichBook(ctx context.Context, in *bookpb.EnrichBookRequest) (*bookpb.Book, error) {
+	b, err := s.Svc.EnrichBook(ctx, in.Id, in.Overwrite)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+	return toProtoBook(b), nil
+}
+
+func toProtoBook(b model.Book) *bookpb.Book {
+	return &bookpb.Book{
+		Id:            b.ID,
+		Version:       int32(b.Version),
+		Isbn:          b.ISBN,
+		Title:         b.Title,
+		Subtitle:      b.Subtitle,
+		PublishedYear: toProtoInt(b.PublishedYear),
+		PageCount:     toProtoInt(b.PageCount),
+		CoverUrl:      b.CoverURL,
+		Tags:          b.Tags,
+		Authors:       b.Authors,
+		Description:   b.Description,
+		Subjects:      b.Subjects,
+		Enrichment: &bookpb.Enrichment{
+			Attempted: b.Enrichment.Attempted,
+			Source:    b.Enrichment.Source,
+			Status:    string(b.Enrichment.Status),
+		},
+		Forthcoming: b.Forthcoming,
+		ReleaseDate: toProtoTime(b.ReleaseDate),
+		CreatedAt:   timestamppb.New(b.CreatedAt),
+		UpdatedAt:   timestamppb.New(b.UpdatedAt),
+		DeletedAt:   toProtoTime(b.DeletedAt),
+	}
+}
+
+func toProtoInt(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
+
+func fromProtoInt(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
+
+func toProtoTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromProtoTime(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.AsTime()
+	return &v
+}
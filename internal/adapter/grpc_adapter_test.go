@@ -0,0 +1,137 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api/bookpb"
+	"book-manager/internal/auth"
+	"book-manager/internal/core"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+func newGRPCClient(t *testing.T, mw ...func(http.Handler) http.Handler) (*grpc.ClientConn, *core.Service) {
+	t.Helper()
+	svc := core.NewService(NewBookRepo(), mockEnrich{})
+	svc.Audit = NewAuditRepo()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	srv := NewGRPCServer(svc, logger).Server(mw...)
+	ln := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, svc
+}
+
+func TestGRPC_BookLifecycle(t *testing.T) {
+	conn, svc := newGRPCClient(t)
+	c := bookpb.NewBookServiceClient(conn)
+	ctx := context.Background()
+
+	b, err := c.CreateBook(ctx, &bookpb.CreateBookRequest{
+		Title: proto.String("Dune"), Isbn: proto.String("978-0-441-17271-9"),
+		PublishedYear: proto.Int32(1965), Authors: []string{"Frank Herbert"}, Tags: []string{"sf"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "9780441172719", b.GetIsbn())
+	assert.Equal(t, int32(1965), b.GetPublishedYear())
+	assert.Equal(t, int32(1), b.Version)
+	assert.Nil(t, b.PageCount)
+	assert.NotNil(t, b.CreatedAt)
+
+	_, err = c.CreateBook(ctx, &bookpb.CreateBookRequest{Title: proto.String("Dune again"), Isbn: proto.String("9780441172719")})
+	assert.Equal(t, codes.Aborted, status.Code(err), "duplicate ISBN")
+	_, err = c.CreateBook(ctx, &bookpb.CreateBookRequest{PageCount: proto.Int32(-1)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	got, err := c.GetBook(ctx, &bookpb.GetBookRequest{Id: b.Id})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(b, got))
+
+	up, err := c.UpdateBook(ctx, &bookpb.UpdateBookRequest{Id: b.Id, Title: "Dune", PageCount: proto.Int32(412), Version: proto.Int32(1)})
+	require.NoError(t, err)
+	assert.Equal(t, int32(412), up.GetPageCount())
+	assert.Equal(t, int32(2), up.Version)
+	_, err = c.UpdateBook(ctx, &bookpb.UpdateBookRequest{Id: b.Id, Title: "Dune", Version: proto.Int32(1)})
+	assert.Equal(t, codes.Aborted, status.Code(err), "stale version")
+
+	page, err := c.ListBooks(ctx, &bookpb.ListBooksRequest{Q: proto.String("dune"), Sort: "-created_at"})
+	require.NoError(t, err)
+	require.Len(t, page.Books, 1)
+	assert.Equal(t, b.Id, page.Books[0].Id)
+	assert.Equal(t, int32(1), page.Total)
+	assert.Equal(t, int32(20), page.PageSize)
+
+	_, err = c.DeleteBook(ctx, &bookpb.DeleteBookRequest{Id: b.Id})
+	require.NoError(t, err)
+	_, err = c.GetBook(ctx, &bookpb.GetBookRequest{Id: b.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	restored, err := c.RestoreBook(ctx, &bookpb.RestoreBookRequest{Id: b.Id})
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+
+	history, err := svc.BookHistory(ctx, b.Id)
+	require.NoError(t, err)
+	assert.Len(t, history, 4, "calls are audited as HTTP requests are")
+}
+
+func TestGRPC_PassesHTTPMiddleware(t *testing.T) {
+	authn := auth.NewAuthenticator(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	authn.SetKeys(map[string]string{"ci": "secret"}, true)
+	conn, svc := newGRPCClient(t, authn.Middleware, ActorMiddleware)
+	c := bookpb.NewBookServiceClient(conn)
+	ctx := context.Background()
+
+	_, err := c.CreateBook(ctx, &bookpb.CreateBookRequest{Title: proto.String("Dune")})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "missing credentials")
+	_, err = c.CreateBook(metadata.AppendToOutgoingContext(ctx, "x-api-key", "wrong"), &bookpb.CreateBookRequest{Title: proto.String("Dune")})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	b, err := c.CreateBook(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret"), &bookpb.CreateBookRequest{Title: proto.String("Dune")})
+	require.NoError(t, err)
+	history, err := svc.BookHistory(ctx, b.Id)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "api-key:ci", history[0].Actor)
+
+	_, err = c.GetBook(ctx, &bookpb.GetBookRequest{Id: b.Id})
+	assert.NoError(t, err, "public reads")
+	_, err = c.ListBooks(ctx, &bookpb.ListBooksRequest{IncludeDeleted: true})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "the trash needs credentials")
+}
+
+func TestGRPC_Reflection(t *testing.T) {
+	conn, _ := newGRPCClient(t)
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	var names []string
+	for _, s := range res.GetListServicesResponse().GetService() {
+		names = append(names, s.Name)
+	}
+	assert.Contains(t, names, "bookmanager.v1.BookService")
+}
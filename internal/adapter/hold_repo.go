@@ -0,0 +1,175 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// HoldRepo keeps the hold queues in memory and, when opened on a journal
+// file or a bbolt database, there too, so they survive a restart.
+type HoldRepo struct {
+	mu      sync.RWMutex
+	byID    map[string]model.Hold
+	records recordStore[model.Hold]
+}
+
+func NewHoldRepo() *HoldRepo {
+	return &HoldRepo{byID: map[string]model.Hold{}, records: memoryRecords[model.Hold]{}}
+}
+
+// OpenHoldRepo loads the holds kept in path, which need not exist yet.
+func OpenHoldRepo(path string) (*HoldRepo, error) {
+	j, holds, err := openRecordJournal[model.Hold](path)
+	if err != nil {
+		return nil, err
+	}
+	return &HoldRepo{byID: holds, records: j}, nil
+}
+
+// OpenBoltHoldRepo loads the holds kept in db's holds bucket.
+func OpenBoltHoldRepo(db *bolt.DB) (*HoldRepo, error) {
+	b, holds, err := openBoltRecords[model.Hold](db, "holds")
+	if err != nil {
+		return nil, err
+	}
+	return &HoldRepo{byID: holds, records: b}, nil
+}
+
+func (r *HoldRepo) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.records.Close()
+}
+
+func (r *HoldRepo) Place(_ context.Context, h model.Hold) (model.Hold, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.records.writable(); err != nil {
+		return model.Hold{}, err
+	}
+	for _, other := range r.byID {
+		if other.BookID == h.BookID && other.Borrower == h.Borrower {
+			return model.Hold{}, fmt.Errorf("%w: %s already holds book %s", model.ErrConflict, h.Borrower, h.BookID)
+		}
+	}
+	r.byID[h.ID] = h
+	return h, r.records.put(h.ID, h)
+}
+
+func (r *HoldRepo) Get(ctx context.Context, id string) (model.Hold, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	h, ok := r.byID[id]
+	if !ok || !ofTenant(ctx, h.Tenant) {
+		return model.Hold{}, fmt.Errorf("%w: hold %s", model.ErrNotFound, id)
+	}
+	return h, nil
+}
+
+func (r *HoldRepo) List(ctx context.Context, bookID string) ([]model.Hold, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []model.Hold
+	for _, h := range r.byID {
+		if h.BookID == bookID && ofTenant(ctx, h.Tenant) {
+			out = append(out, h)
+		}
+	}
+	slices.SortFunc(out, func(a, b model.Hold) int { return a.PlacedAt.Compare(b.PlacedAt) })
+	return out, nil
+}
+
+func (r *HoldRepo) Ready(ctx context.Context, id string, at time.Time) (model.Hold, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.byID[id]
+	if !ok || !ofTenant(ctx, h.Tenant) {
+		return model.Hold{}, fmt.Errorf("%w: hold %s", model.ErrNotFound, id)
+	}
+	if h.ReadyAt != nil {
+		return model.Hold{}, fmt.Errorf("%w: hold %s is ready already", model.ErrConflict, id)
+	}
+	if err := r.records.writable(); err != nil {
+		return model.Hold{}, err
+	}
+	h.ReadyAt = &at
+	r.byID[id] = h
+	return h, r.records.put(id, h)
+}
+
+func (r *HoldRepo) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if h, ok := r.byID[id]; !ok || !ofTenant(ctx, h.Tenant) {
+		return fmt.Errorf("%w: hold %s", model.ErrNotFound, id)
+	}
+	if err := r.records.writable(); err != nil {
+		return err
+	}
+	delete(r.byID, id)
+	return r.records.delete(id)
+}
+
+func (r *HoldRepo) ListByBorrower(ctx context.Context, borrower string) ([]model.Hold, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []model.Hold
+	for _, h := range r.byID {
+		if h.Borrower == borrower && ofTenant(ctx, h.Tenant) {
+			out = append(out, h)
+		}
+	}
+	slices.SortFunc(out, func(a, b model.Hold) int { return a.PlacedAt.Compare(b.PlacedAt) })
+	return out, nil
+}
+
+func (r *HoldRepo) Reassign(ctx context.Context, from, to string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.records.writable(); err != nil {
+		return 0, err
+	}
+	n := 0
+	for id, h := range r.byID {
+		if h.Borrower != from || !ofTenant(ctx, h.Tenant) {
+			continue
+		}
+		n++
+		if other, ok := r.holding(to, h.BookID); ok {
+			if other.PlacedAt.After(h.PlacedAt) {
+				delete(r.byID, other.ID)
+				if err := r.records.delete(other.ID); err != nil {
+					return n, err
+				}
+			} else {
+				delete(r.byID, id)
+				if err := r.records.delete(id); err != nil {
+					return n, err
+				}
+				continue
+			}
+		}
+		h.Borrower = to
+		r.byID[id] = h
+		if err := r.records.put(id, h); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// holding returns the hold of borrower on a book, if any.
+func (r *HoldRepo) holding(borrower, bookID string) (model.Hold, bool) {
+	for _, h := range r.byID {
+		if h.Borrower == borrower && h.BookID == bookID {
+			return h, true
+		}
+	}
+	return model.Hold{}, false
+}
@@ -4,84 +4,355 @@ import (
 	"book-manager/api"
 	"book-manager/internal/core/model"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
 	"strings"
+	"text/template"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 type BookService interface {
 	CreateBook(ctx context.Context, in model.CreateBookInput) (model.Book, error)
+	CreateBookOnce(ctx context.Context, key string, in model.CreateBookInput) (model.Book, bool, error)
+	CreateBooks(ctx context.Context, inputs []model.CreateBookInput) ([]model.BatchResult, error)
 	ListBooks(ctx context.Context, q model.ListQuery) (model.Page[model.Book], error)
 	GetBook(ctx context.Context, id string) (model.Book, error)
+	ViewBook(ctx context.Context, id string) (model.Book, error)
+	RecentlyViewed(ctx context.Context, limit int) ([]model.ViewedBook, error)
+	ClearRecentViews(ctx context.Context) error
+	UpdateBook(ctx context.Context, id string, in model.UpdateBookInput) (model.Book, error)
+	PatchBook(ctx context.Context, id string, p model.BookPatch) (model.Book, error)
 	DeleteBook(ctx context.Context, id string) error
+	RestoreBook(ctx context.Context, id string) (model.Book, error)
+	PurgeBooks(ctx context.Context, before time.Time) (int, error)
+	BookHistory(ctx context.Context, id string) ([]model.AuditEntry, error)
+	AuditLog(ctx context.Context, q model.AuditQuery) ([]model.AuditEntry, error)
+	DuplicateBooks(ctx context.Context, mode string, page, size int) (model.Page[model.DuplicateGroup], error)
+	ListDuplicateExclusions(ctx context.Context) ([]model.DuplicateExclusion, error)
+	ExcludeDuplicates(ctx context.Context, bookIDs []string) (model.DuplicateExclusion, error)
+	DeleteDuplicateExclusion(ctx context.Context, id string) error
+	ImportReadingList(ctx context.Context, ref model.ReadingListRef) (model.ReadingListImport, error)
+	ImportReadingState(ctx context.Context, bookID string, st model.ReadingState) error
+	SearchExternal(ctx context.Context, q string, limit int) ([]model.ExternalBook, error)
+	ImportExternal(ctx context.Context, in model.ExternalImport) (model.Book, error)
+	SyncShelves(ctx context.Context) ([]model.ShelfSyncReport, error)
+	ShelfSyncReports(ctx context.Context, limit int) ([]model.ShelfSyncReport, error)
+	EnrichBook(ctx context.Context, id string, overwrite bool) (model.Book, error)
+	PreviewEnrichment(ctx context.Context, isbn string) (model.EnrichmentPreview, error)
+	PriceHistory(ctx context.Context, id string) ([]model.PricePoint, error)
+	BookAvailability(ctx context.Context, id string) (model.Availability, error)
+	SemanticSearch(ctx context.Context, q string, limit int) ([]model.ScoredBook, error)
+	SimilarBooks(ctx context.Context, id string, limit int) ([]model.ScoredBook, error)
+	CompareISBNs(ctx context.Context, isbns []string) (model.CatalogComparison, error)
+	ParseBook(ctx context.Context, text string) (model.ParsedBook, error)
+	BookSummary(ctx context.Context, id string, withDescription bool) (string, error)
+	UploadCover(ctx context.Context, id string, data []byte) (model.Book, error)
+	BookCover(ctx context.Context, id string, size model.CoverSize) (model.Cover, error)
+	CoverThumbnails(ctx context.Context, books []model.Book) (map[string]model.Cover, error)
+	PrefetchCovers(ctx context.Context) (model.CoverPrefetch, error)
+	CoverPrefetchStatus(ctx context.Context) (model.CoverPrefetch, error)
+	Health(ctx context.Context) error
+	CircuitBreakers(ctx context.Context) ([]model.CircuitBreakerStatus, error)
+	ListDeadLetters(ctx context.Context) ([]model.DeadLetter, error)
+	ReplayDeadLetter(ctx context.Context, id string) (model.DeadLetter, error)
+	ReplayDeadLetters(ctx context.Context) (delivered, failed int, err error)
+	DeleteDeadLetter(ctx context.Context, id string) error
+	CatalogCounters(ctx context.Context) (model.CatalogCounters, error)
+	Subscribe(ctx context.Context, buffer int) (events <-chan model.Event, cancel func())
+	StartBookImport(ctx context.Context, rows []model.ImportRow) (model.ImportJob, error)
+	ImportJob(ctx context.Context, id string) (model.ImportJob, error)
+	ExportBooks(ctx context.Context, q model.ListQuery, fn func(model.Book) error) error
+	WalkBooks(ctx context.Context, q model.ListQuery, fn func(model.Book) error) error
+
+	CreateAutoTagRule(ctx context.Context, in model.AutoTagRule) (model.AutoTagRule, error)
+	ListAutoTagRules(ctx context.Context) ([]model.AutoTagRule, error)
+	DeleteAutoTagRule(ctx context.Context, id string) error
+	BackfillAutoTags(ctx context.Context) (int, error)
+
+	CreateAuthor(ctx context.Context, name string) (model.Author, error)
+	GetAuthor(ctx context.Context, id string) (model.Author, error)
+	ListAuthors(ctx context.Context, q model.AuthorQuery) (model.Page[model.Author], error)
+	UpdateAuthor(ctx context.Context, id, name string) (model.Author, error)
+	DeleteAuthor(ctx context.Context, id string) error
+	RenameAuthor(ctx context.Context, from, to string) (model.AuthorRename, error)
+	ListAuthorRenames(ctx context.Context) ([]model.AuthorRename, error)
+	ListTags(ctx context.Context) ([]model.TagCount, error)
+	TagTree(ctx context.Context) ([]model.TagNode, error)
+	ReplaceBookTags(ctx context.Context, id string, tags []string, version *int) (model.Book, error)
+	RenameTag(ctx context.Context, from, to string) (model.TagRename, error)
+	MergeTags(ctx context.Context, sources []string, target string) (model.TagRename, error)
+
+	CreateBranch(ctx context.Context, b model.Branch) (model.Branch, error)
+	GetBranch(ctx context.Context, id string) (model.Branch, error)
+	ListBranches(ctx context.Context) ([]model.Branch, error)
+	UpdateBranch(ctx context.Context, id string, p model.BranchPatch) (model.Branch, error)
+	DeleteBranch(ctx context.Context, id string) error
+	SetCopies(ctx context.Context, bookID, branch string, copies int) (model.Book, error)
+	TransferCopies(ctx context.Context, t model.Transfer) (model.Book, error)
+	AddItem(ctx context.Context, bookID string, it model.Item) (model.Book, error)
+	UpdateItem(ctx context.Context, bookID, barcode string, p model.ItemPatch) (model.Book, error)
+	RemoveItem(ctx context.Context, bookID, barcode string) (model.Book, error)
+
+	CheckoutBook(ctx context.Context, bookID, borrower string, due *time.Time) (model.Loan, error)
+	ReturnLoan(ctx context.Context, id string) (model.Loan, error)
+	GetLoan(ctx context.Context, id string) (model.Loan, error)
+	ListLoans(ctx context.Context, q model.LoanQuery) ([]model.Loan, error)
+	PlaceHold(ctx context.Context, bookID, borrower string) (model.Hold, error)
+	ListHolds(ctx context.Context, bookID string) ([]model.Hold, error)
+	CancelHold(ctx context.Context, id string) error
+	CreateEscalationPolicy(ctx context.Context, p model.EscalationPolicy) (model.EscalationPolicy, error)
+	ListEscalationPolicies(ctx context.Context) ([]model.EscalationPolicy, error)
+	DeleteEscalationPolicy(ctx context.Context, id string) error
+	EscalationRuns(ctx context.Context, policyID string) ([]model.EscalationRun, error)
+	RunEscalations(ctx context.Context, now time.Time) (int, error)
+	LoanFine(ctx context.Context, id string) (int, model.Price, error)
+	FeeAccount(ctx context.Context, borrower string) (model.FeeAccount, error)
+	RecordFee(ctx context.Context, e model.FeeEntry) (model.FeeEntry, error)
+	CreateBorrower(ctx context.Context, b model.Borrower) (model.Borrower, error)
+	GetBorrower(ctx context.Context, id string) (model.Borrower, error)
+	UpdateBorrower(ctx context.Context, b model.Borrower) (model.Borrower, error)
+	DeleteBorrower(ctx context.Context, id string) error
+	ListBorrowers(ctx context.Context, q model.BorrowerQuery) (model.Page[model.Borrower], error)
+	MergeBorrowers(ctx context.Context, into, from string) (model.Borrower, error)
+	ListBookshelves(ctx context.Context) ([]model.Bookshelf, error)
+	GetBookshelf(ctx context.Context, id string) (model.Bookshelf, error)
+	CreateBookshelf(ctx context.Context, name string) (model.Bookshelf, error)
+	DeleteBookshelf(ctx context.Context, id string) error
+	ShelveBook(ctx context.Context, shelfID, bookID string) error
+	UnshelveBook(ctx context.Context, shelfID, bookID string) error
+	ShelfBooks(ctx context.Context, shelfID string, page, pageSize int) (model.Page[model.ShelvedBook], error)
+	AddReview(ctx context.Context, r model.Review) (model.Review, error)
+	GetReview(ctx context.Context, bookID, id string) (model.Review, error)
+	DeleteReview(ctx context.Context, bookID, id string) error
+	ListReviews(ctx context.Context, bookID string, page, pageSize int) (model.Page[model.Review], error)
+	UpdateProgress(ctx context.Context, bookID string, page, percent *int) (model.ReadingProgress, error)
+	ProgressHistory(ctx context.Context, bookID string) ([]model.ReadingProgress, error)
+	ReadingStats(ctx context.Context, weeks int, now time.Time) (model.ReadingStats, error)
+	StartStocktake(ctx context.Context, branch string) (model.Stocktake, error)
+	GetStocktake(ctx context.Context, id string) (model.Stocktake, error)
+	ListStocktakes(ctx context.Context) ([]model.Stocktake, error)
+	ScanStocktake(ctx context.Context, id, code string) (bool, error)
+	StocktakeReport(ctx context.Context, id string) (model.StocktakeReport, error)
+	CloseStocktake(ctx context.Context, id string, markMissing bool) (model.Stocktake, error)
+	KioskBorrower(ctx context.Context, card string) (model.Borrower, error)
+	KioskItem(ctx context.Context, code string) (model.Book, error)
+	KioskCheckout(ctx context.Context, c model.KioskCheckout) (model.Loan, error)
+	SyncKiosk(ctx context.Context, checkouts []model.KioskCheckout) ([]model.KioskSyncResult, error)
+	Labels(ctx context.Context, sel model.LabelSelection) ([]model.Label, error)
 }
 
 type HTTPHandler struct {
-	Svc BookService
-	log *slog.Logger
+	Svc   BookService
+	Links bool // add _links to every book response, not only to hal+json requests
+	// TextTemplate renders each book of GET /api/v1/books.txt; nil uses
+	// the built-in one-line format.
+	TextTemplate *template.Template
+	// LiveInterval is the least time between two counter pushes to one /ws
+	// connection; zero means one second.
+	LiveInterval time.Duration
+	log          *slog.Logger
 }
 
 func NewHTTPHandler(svc BookService, logger *slog.Logger) *HTTPHandler {
 	return &HTTPHandler{Svc: svc, log: logger}
 }
 
-func (h *HTTPHandler) CreateBook(w http.ResponseWriter, r *http.Request, _ api.CreateBookParams) {
+func (h *HTTPHandler) CreateBook(w http.ResponseWriter, r *http.Request, p api.CreateBookParams) {
 	q := r.URL.Query()
 	enrich := q.Get("enrich") == "true"
 	require := q.Get("require_enrichment") == "true"
+	autoCorrect := q.Get("auto_correct") == "true"
 
 	var in api.BookCreate
 	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
-		writeErr(w, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
-		h.log.With("error", err).Info("invalid JSON body")
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
 		return
 	}
 	din := toCreateInput(in, enrich, require)
-	b, err := h.Svc.CreateBook(r.Context(), din)
+	din.AutoCorrect = autoCorrect
+	key := ""
+	if p.IdempotencyKey != nil {
+		key = *p.IdempotencyKey
+	}
+	b, replayed, err := h.Svc.CreateBookOnce(r.Context(), key, din)
 	if err != nil {
 		status, code := mapSvcErr(err)
-		writeErr(w, status, code, err.Error(), nil)
-		h.log.With("error", err).Info("create book failed")
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("create book failed")
 		return
 	}
 	out := fromDomainBook(b)
 	w.Header().Set("Location", "/api/v1/books/"+b.ID)
-	h.log.Info("create request processed", "book-id", out.Id)
-	writeJSON(w, http.StatusCreated, out)
+	if replayed {
+		w.Header().Set("Idempotent-Replayed", "true")
+	}
+	h.logFor(r).Info("create request processed", "book-id", out.Id, "replayed", replayed)
+	h.writeBook(w, r, http.StatusCreated, out)
 }
 
 func (h *HTTPHandler) ListBooks(w http.ResponseWriter, r *http.Request, p api.ListBooksParams) {
+	if p.Count != nil && *p.Count != api.Exact && *p.Count != api.None {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "count must be exact or none", map[string]any{"count": *p.Count})
+		return
+	}
+	if p.InlineCovers != nil && *p.InlineCovers != api.Thumb {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "inline_covers must be thumb", map[string]any{"inline_covers": *p.InlineCovers})
+		return
+	}
 	q := toListQuery(p)
 	page, err := h.Svc.ListBooks(r.Context(), q)
 	if err != nil {
-		writeErr(w, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
-		h.log.With("error", err).Info("list books failed")
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("list books failed")
 		return
 	}
-	writeJSON(w, http.StatusOK, fromDomainPage(page))
+	out := fromDomainPage(page)
+	if p.InlineCovers != nil {
+		thumbs, err := h.Svc.CoverThumbnails(r.Context(), page.Data)
+		if err != nil {
+			status, code := mapSvcErr(err)
+			writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+			h.logFor(r).With("error", err).Info("inline covers failed")
+			return
+		}
+		for i := range out.Data {
+			if c, ok := thumbs[out.Data[i].Id]; ok {
+				uri := "data:" + c.ContentType + ";base64," + base64.StdEncoding.EncodeToString(c.Data)
+				out.Data[i].CoverThumb = &uri
+			}
+		}
+	}
+	if notModified(w, p.IfNoneMatch, h.pageETag(r, out)) {
+		return
+	}
+	h.writeBookPage(w, r, out)
 }
 
-func (h *HTTPHandler) GetBookById(w http.ResponseWriter, r *http.Request, id string) {
-	b, err := h.Svc.GetBook(r.Context(), id)
+func (h *HTTPHandler) GetBookById(w http.ResponseWriter, r *http.Request, id string, p api.GetBookByIdParams) {
+	b, err := h.Svc.ViewBook(r.Context(), id)
 	if err != nil {
-		writeErr(w, http.StatusNotFound, "NOT_FOUND", "book not found", nil)
-		h.log.With("error", err).Info("get book failed")
+		writeErrFor(w, r, http.StatusNotFound, "NOT_FOUND", "book not found", nil)
+		h.logFor(r).With("error", err).Info("get book failed")
+		return
+	}
+	out := fromDomainBook(b)
+	if notModified(w, p.IfNoneMatch, h.bookETag(r, out)) {
 		return
 	}
-	writeJSON(w, http.StatusOK, fromDomainBook(b))
+	h.writeBook(w, r, http.StatusOK, out)
 }
 
-func (h *HTTPHandler) DeleteBookById(w http.ResponseWriter, r *http.Request, id string) {
+func (h *HTTPHandler) UpdateBook(w http.ResponseWriter, r *http.Request, id string, p api.UpdateBookParams) {
+	var in api.BookCreate
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	version, ok := h.preconditionOK(w, r, id, p.IfMatch)
+	if !ok {
+		return
+	}
+	if in.Version == nil {
+		in.Version = version
+	}
+	b, err := h.Svc.UpdateBook(r.Context(), id, toUpdateInput(in))
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("update book failed")
+		return
+	}
+	h.writeBookETag(w, r, http.StatusOK, fromDomainBook(b))
+}
+
+func (h *HTTPHandler) PatchBook(w http.ResponseWriter, r *http.Request, id string, p api.PatchBookParams) {
+	var in api.BookPatch
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	version, ok := h.preconditionOK(w, r, id, p.IfMatch)
+	if !ok {
+		return
+	}
+	if in.Version == nil {
+		in.Version = version
+	}
+	b, err := h.Svc.PatchBook(r.Context(), id, toBookPatch(in))
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("patch book failed")
+		return
+	}
+	h.writeBookETag(w, r, http.StatusOK, fromDomainBook(b))
+}
+
+func (h *HTTPHandler) EnrichBook(w http.ResponseWriter, r *http.Request, id string, p api.EnrichBookParams) {
+	b, err := h.Svc.EnrichBook(r.Context(), id, boolOr(p.Overwrite))
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("enrich book failed")
+		return
+	}
+	h.writeBook(w, r, http.StatusOK, fromDomainBook(b))
+}
+
+func (h *HTTPHandler) DeleteBookById(w http.ResponseWriter, r *http.Request, id string, p api.DeleteBookByIdParams) {
+	if _, ok := h.preconditionOK(w, r, id, p.IfMatch); !ok {
+		return
+	}
 	if err := h.Svc.DeleteBook(r.Context(), id); err != nil {
-		writeErr(w, http.StatusNotFound, "NOT_FOUND", "book not found", nil)
-		h.log.With("error", err).Info("delete book failed")
+		if errors.Is(err, model.ErrNotFound) {
+			writeErrFor(w, r, http.StatusNotFound, "NOT_FOUND", "book not found", nil)
+		} else {
+			status, code := mapSvcErr(err)
+			writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		}
+		h.logFor(r).With("error", err).Info("delete book failed")
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *HTTPHandler) RestoreBook(w http.ResponseWriter, r *http.Request, id string) {
+	b, err := h.Svc.RestoreBook(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("restore book failed")
+		return
+	}
+	h.writeBookETag(w, r, http.StatusOK, fromDomainBook(b))
+}
+
+func (h *HTTPHandler) PurgeBooks(w http.ResponseWriter, r *http.Request, p api.PurgeBooksParams) {
+	var before time.Time
+	if p.DeletedBefore != nil {
+		before = *p.DeletedBefore
+	}
+	n, err := h.Svc.PurgeBooks(r.Context(), before)
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("purge books failed")
+		return
+	}
+	h.logFor(r).Info("books purged", "purged", n)
+	writeJSON(w, http.StatusOK, api.PurgeResult{Purged: n})
+}
+
 // mappers
 func toCreateInput(in api.BookCreate, enrich, require bool) model.CreateBookInput {
 	var title *string
@@ -95,6 +366,9 @@ func toCreateInput(in api.BookCreate, enrich, require bool) model.CreateBookInpu
 		PublishedYear:     in.PublishedYear,
 		PageCount:         in.PageCount,
 		CoverURL:          in.CoverUrl,
+		Forthcoming:       boolOr(in.Forthcoming),
+		ReleaseDate:       fromAPIDate(in.ReleaseDate),
+		PriceTarget:       toDomainPrice(in.PriceTarget),
 		Enrich:            enrich,
 		RequireEnrichment: require,
 	}
@@ -108,6 +382,45 @@ func toCreateInput(in api.BookCreate, enrich, require bool) model.CreateBookInpu
 	return out
 }
 
+func toUpdateInput(in api.BookCreate) model.UpdateBookInput {
+	out := model.UpdateBookInput{
+		ISBN:          in.Isbn,
+		Title:         &in.Title,
+		Subtitle:      in.Subtitle,
+		PublishedYear: in.PublishedYear,
+		PageCount:     in.PageCount,
+		CoverURL:      in.CoverUrl,
+		Forthcoming:   boolOr(in.Forthcoming),
+		ReleaseDate:   fromAPIDate(in.ReleaseDate),
+		PriceTarget:   toDomainPrice(in.PriceTarget),
+		Version:       in.Version,
+	}
+	if in.Tags != nil {
+		out.Tags = *in.Tags
+	}
+	if in.Authors != nil {
+		out.Authors = *in.Authors
+	}
+	return out
+}
+
+func toBookPatch(in api.BookPatch) model.BookPatch {
+	return model.BookPatch{
+		ISBN:          in.Isbn,
+		Title:         in.Title,
+		Subtitle:      in.Subtitle,
+		PublishedYear: in.PublishedYear,
+		PageCount:     in.PageCount,
+		CoverURL:      in.CoverUrl,
+		Tags:          in.Tags,
+		Authors:       in.Authors,
+		Forthcoming:   in.Forthcoming,
+		ReleaseDate:   fromAPIDate(in.ReleaseDate),
+		PriceTarget:   toDomainPrice(in.PriceTarget),
+		Version:       in.Version,
+	}
+}
+
 func toListQuery(p api.ListBooksParams) model.ListQuery {
 	q := model.ListQuery{Page: 1, PageSize: 20}
 	if p.Page != nil {
@@ -119,7 +432,28 @@ func toListQuery(p api.ListBooksParams) model.ListQuery {
 	q.Q = p.Q
 	q.Author = p.Author
 	q.Tag = p.Tag
+	q.Branch = p.Branch
+	q.Barcode = p.Barcode
+	q.Available = p.Available
 	q.Year = p.Year
+	q.YearFrom, q.YearTo = p.YearFrom, p.YearTo
+	q.UpdatedSince = p.UpdatedSince
+	if p.Snapshot != nil {
+		q.Snapshot = *p.Snapshot
+	}
+	if p.SnapshotId != nil {
+		q.SnapshotID = *p.SnapshotId
+	}
+	if p.Cursor != nil {
+		q.Cursor = *p.Cursor
+	}
+	if p.IncludeDeleted != nil {
+		q.IncludeDeleted = *p.IncludeDeleted
+	}
+	if p.IncludeChildren != nil {
+		q.IncludeChildren = *p.IncludeChildren
+	}
+	q.SkipCount = p.Count != nil && *p.Count == api.None
 	if p.Sort != nil {
 		parts := strings.Split(*p.Sort, ",")
 		for _, s := range parts {
@@ -143,8 +477,9 @@ func fromDomainBook(b model.Book) api.Book {
 	looked := strPtrOrNil(b.Enrichment.LookedUpISBN)
 	status := statusFromDomain(b.Enrichment.Status)
 
-	return api.Book{
+	out := api.Book{
 		Id:            b.ID,
+		Version:       b.Version,
 		Isbn:          b.ISBN,
 		Title:         b.Title,
 		Subtitle:      b.Subtitle,
@@ -152,16 +487,95 @@ func fromDomainBook(b model.Book) api.Book {
 		PageCount:     b.PageCount,
 		CoverUrl:      b.CoverURL,
 		Tags:          &b.Tags,
-		Authors:       toAuthorSummaries(b.Authors),
+		Authors:       toAuthorSummaries(b.Authors, b.AuthorIDs),
 		Enrichment: &api.EnrichmentMeta{
 			Attempted:    b.Enrichment.Attempted,
 			Source:       src,
 			Status:       status,
 			LookedUpIsbn: looked,
 		},
-		CreatedAt: b.CreatedAt,
-		UpdatedAt: b.UpdatedAt,
+		Forthcoming: b.Forthcoming,
+		CreatedAt:   b.CreatedAt.UTC(),
+		UpdatedAt:   b.UpdatedAt.UTC(),
+		DeletedAt:   utcPtr(b.DeletedAt),
+		Owner:       strPtrOrNil(b.Owner),
+	}
+	if len(b.Copies) > 0 {
+		copies := maps.Clone(b.Copies)
+		out.Copies = &copies
+	}
+	if len(b.Items) > 0 {
+		items := make([]api.Item, len(b.Items))
+		for i, it := range b.Items {
+			items[i] = api.Item{
+				Barcode:   it.Barcode,
+				Branch:    strPtrOrNil(it.Branch),
+				Location:  strPtrOrNil(it.Location),
+				Condition: api.ItemCondition(it.Condition),
+				AddedAt:   it.AddedAt.UTC(),
+			}
+			if b.Lending != nil {
+				items[i].OnLoan = &it.OnLoan
+			}
+		}
+		out.Items = &items
+	}
+	if l := b.Lending; l != nil {
+		out.Lending = &api.Lending{
+			Copies:     l.Copies,
+			CheckedOut: l.CheckedOut,
+			Available:  max(l.Copies-l.CheckedOut-l.Ready, 0),
+			Overdue:    l.Overdue,
+			Holds:      l.Holds,
+			NextDueAt:  utcPtr(l.NextDueAt),
+		}
 	}
+	if rt := b.Rating; rt != nil {
+		out.Rating = &api.Rating{Count: rt.Count}
+		if rt.Count > 0 {
+			out.Rating.Average = &rt.Average
+		}
+	}
+	if b.ReleaseDate != nil {
+		out.ReleaseDate = &openapi_types.Date{Time: *b.ReleaseDate}
+	}
+	if b.PriceTarget != nil {
+		p := fromDomainPrice(*b.PriceTarget)
+		out.PriceTarget = &p
+	}
+	out.Description = b.Description
+	if len(b.Subjects) > 0 {
+		out.Subjects = &b.Subjects
+	}
+	if t := b.Translation; t != nil {
+		out.Translation = &api.Translation{Language: t.Language, Description: t.Description}
+		if len(t.Subjects) > 0 {
+			out.Translation.Subjects = &t.Subjects
+		}
+	}
+	if len(b.Suggestions) > 0 {
+		warnings := make([]api.Suggestion, 0, len(b.Suggestions))
+		for _, sg := range b.Suggestions {
+			warnings = append(warnings, api.Suggestion{
+				Field:      api.SuggestionField(sg.Field),
+				Value:      sg.Value,
+				Suggestion: sg.Suggestion,
+				Message:    fmt.Sprintf("did you mean '%s'?", sg.Suggestion),
+				Applied:    sg.Applied,
+			})
+		}
+		out.Warnings = &warnings
+	}
+	return out
+}
+
+// utcPtr is t in UTC; the API writes every timestamp as RFC 3339 in UTC.
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
 }
 
 func strPtrOrNil(s string) *string {
@@ -180,6 +594,15 @@ func sourceFromDomain(src string) *api.EnrichmentMetaSource {
 	case "openlibrary":
 		v := api.Openlibrary
 		return &v
+	case "googlebooks":
+		v := api.Googlebooks
+		return &v
+	case "isbndb":
+		v := api.Isbndb
+		return &v
+	case "sru":
+		v := api.Sru
+		return &v
 	default:
 		return nil
 	}
@@ -191,6 +614,8 @@ func statusFromDomain(st model.EnrichmentStatus) api.EnrichmentMetaStatus {
 		return api.Ok
 	case "partial":
 		return api.Partial
+	case "pending":
+		return api.Pending
 	case "not_requested", "":
 		fallthrough
 	default:
@@ -198,16 +623,26 @@ func statusFromDomain(st model.EnrichmentStatus) api.EnrichmentMetaStatus {
 	}
 }
 
-func toAuthorSummaries(names []string) []api.AuthorSummary {
+// toAuthorSummaries pairs names with their author ids; ids stay empty for
+// books that are not linked to the author registry.
+func toAuthorSummaries(names, ids []string) []api.AuthorSummary {
+	linked := len(ids) == len(names)
 	out := make([]api.AuthorSummary, 0, len(names))
-	for _, n := range names {
-		out = append(out, api.AuthorSummary{Id: "", Name: n})
+	for i, n := range names {
+		id := ""
+		if linked {
+			id = ids[i]
+		}
+		out = append(out, api.AuthorSummary{Id: id, Name: n})
 	}
 	return out
 }
 
 func fromDomainPage(p model.Page[model.Book]) api.PaginatedBooks {
-	out := api.PaginatedBooks{Page: p.Page, PageSize: p.PageSize, Total: p.Total}
+	out := api.PaginatedBooks{Page: p.Page, PageSize: p.PageSize, SnapshotId: strPtrOrNil(p.SnapshotID), NextCursor: strPtrOrNil(p.NextCursor)}
+	if p.Total >= 0 {
+		out.Total = &p.Total
+	}
 	for _, b := range p.Data {
 		bb := fromDomainBook(b)
 		out.Data = append(out.Data, bb)
@@ -215,11 +650,15 @@ func fromDomainPage(p model.Page[model.Book]) api.PaginatedBooks {
 	return out
 }
 
+// errBody is the ErrorResponse of the OpenAPI document, its fields in the
+// order clients have always seen them.
 type errBody struct {
 	Error struct {
 		Code    string         `json:"code"`
 		Message string         `json:"message"`
 		Details map[string]any `json:"details,omitempty"`
+		// RequestID is the one RequestIDMiddleware set on the response.
+		RequestID string `json:"request_id,omitempty"`
 	} `json:"error"`
 }
 
@@ -234,9 +673,38 @@ func writeErr(w http.ResponseWriter, status int, code, msg string, det map[strin
 	e.Error.Code = code
 	e.Error.Message = msg
 	e.Error.Details = det
+	e.Error.RequestID = w.Header().Get(RequestIDHeader)
 	writeJSON(w, status, e)
 }
 
+// errDetails describes field validation failures for the error body: a
+// field to reason map when a book's fields were checked together, the
+// field and reason of the failure otherwise. A conflict with a book in the
+// trash names that book.
+func errDetails(err error) map[string]any {
+	var ve model.ValidationErrors
+	if errors.As(err, &ve) {
+		out := make(map[string]any, len(ve))
+		for _, fe := range ve {
+			if prev, ok := out[fe.Field]; ok {
+				out[fe.Field] = prev.(string) + "; " + fe.Reason
+			} else {
+				out[fe.Field] = fe.Reason
+			}
+		}
+		return out
+	}
+	var fe *model.FieldError
+	if errors.As(err, &fe) {
+		return map[string]any{"field": fe.Field, "reason": fe.Reason}
+	}
+	var td *model.TrashedDuplicateError
+	if errors.As(err, &td) {
+		return map[string]any{"isbn": td.ISBN, "trashed_book_id": td.BookID}
+	}
+	return nil
+}
+
 func mapSvcErr(err error) (int, string) {
 	switch {
 	case errors.Is(err, model.ErrValidation):
@@ -247,7 +715,16 @@ func mapSvcErr(err error) (int, string) {
 		return http.StatusNotFound, "NOT_FOUND"
 	case errors.Is(err, model.ErrUpstream):
 		return http.StatusBadGateway, "UPSTREAM"
+	case errors.Is(err, model.ErrQuota):
+		return http.StatusForbidden, "QUOTA_EXCEEDED"
 	default:
 		return http.StatusInternalServerError, "INTERNAL"
 	}
 }
+
+func fromAPIDate(d *openapi_types.Date) *time.Time {
+	if d == nil {
+		return nil
+	}
+	return &d.Time
+}
@@ -0,0 +1,81 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"net/http"
+)
+
+// defaultAuditLimit is the page size of audit queries without a limit.
+const defaultAuditLimit = 100
+
+func (h *HTTPHandler) GetBookHistory(w http.ResponseWriter, r *http.Request, id string) {
+	entries, err := h.Svc.BookHistory(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("book history failed", "book-id", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainAuditEntries(entries))
+}
+
+func (h *HTTPHandler) QueryAuditLog(w http.ResponseWriter, r *http.Request, p api.QueryAuditLogParams) {
+	q := model.AuditQuery{Limit: defaultAuditLimit}
+	if p.BookId != nil {
+		q.BookID = *p.BookId
+	}
+	if p.Actor != nil {
+		q.Actor = *p.Actor
+	}
+	if p.Action != nil {
+		q.Action = *p.Action
+	}
+	if p.Since != nil {
+		q.Since = *p.Since
+	}
+	if p.Until != nil {
+		q.Until = *p.Until
+	}
+	if p.Limit != nil {
+		q.Limit = *p.Limit
+	}
+	entries, err := h.Svc.AuditLog(r.Context(), q)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("audit query failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainAuditEntries(entries))
+}
+
+func fromDomainAuditEntries(entries []model.AuditEntry) api.AuditEntryList {
+	out := api.AuditEntryList{Data: make([]api.AuditEntry, 0, len(entries))}
+	for _, e := range entries {
+		out.Data = append(out.Data, api.AuditEntry{
+			Id:      e.ID,
+			BookId:  e.BookID,
+			Action:  e.Action,
+			Actor:   e.Actor,
+			At:      e.At.UTC(),
+			Changes: fromDomainChanges(e.Changes),
+		})
+	}
+	return out
+}
+
+func fromDomainChanges(cs []model.FieldChange) []api.FieldChange {
+	out := make([]api.FieldChange, 0, len(cs))
+	for _, c := range cs {
+		fc := api.FieldChange{Field: c.Field}
+		if c.Before != nil {
+			fc.Before = &c.Before
+		}
+		if c.After != nil {
+			fc.After = &c.After
+		}
+		out = append(out, fc)
+	}
+	return out
+}
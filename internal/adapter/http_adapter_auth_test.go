@@ -0,0 +1,124 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/auth"
+	"book-manager/internal/core"
+	"book-manager/internal/core/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuth_LogsCaller(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	authn := auth.NewAuthenticator(logger)
+	authn.WriteError = WriteError
+	authn.SetKeys(map[string]string{"ci": "s3cret"}, true)
+	svc := core.NewService(NewBookRepo(), mockEnrich{})
+	router := chi.NewRouter()
+	router.Use(authn.Middleware)
+	api.HandlerFromMux(NewHTTPHandler(svc, logger), router)
+
+	create := func(key string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/books", strings.NewReader(`{"title":"My Book"}`))
+		if key != "" {
+			r.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := create("")
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.JSONEq(t, `{"error":{"code":"UNAUTHORIZED","message":"missing credentials"}}`, w.Body.String())
+
+	require.Equal(t, http.StatusCreated, create("s3cret").Code)
+	assert.Contains(t, logs.String(), "key-id=ci")
+	assert.NotContains(t, logs.String(), "s3cret")
+}
+
+func TestActorMiddleware_AuditsCaller(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	authn := auth.NewAuthenticator(logger)
+	authn.WriteError = WriteError
+	authn.SetKeys(map[string]string{"ci": "s3cret"}, true)
+	svc := core.NewService(NewBookRepo(), mockEnrich{})
+	svc.Audit = NewAuditRepo()
+	router := chi.NewRouter()
+	router.Use(authn.Middleware)
+	router.Use(ActorMiddleware)
+	api.HandlerFromMux(NewHTTPHandler(svc, logger), router)
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/books", strings.NewReader(`{"title":"My Book"}`))
+	r.Header.Set("X-API-Key", "s3cret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	entries, err := svc.AuditLog(context.Background(), model.AuditQuery{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "api-key:ci", entries[0].Actor)
+	assert.Equal(t, model.AuditCreate, entries[0].Action)
+}
+
+func TestActorMiddleware_ScopesBooksPerUser(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	svc := core.NewService(NewBookRepo(), mockEnrich{})
+	svc.PerUser = true
+	router := chi.NewRouter()
+	router.Use(ActorMiddleware)
+	api.HandlerFromMux(NewHTTPHandler(svc, logger), router)
+
+	do := func(p auth.Principal, method, path, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r = r.WithContext(auth.NewContext(r.Context(), p))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+	ada := auth.Principal{ID: "ada", Method: "jwt", Role: auth.RoleEditor}
+	bob := auth.Principal{ID: "bob", Method: "jwt", Role: auth.RoleEditor}
+	admin := auth.Principal{ID: "root", Method: "jwt", Role: auth.RoleAdmin}
+
+	w := do(ada, http.MethodPost, "/api/v1/books", `{"title":"Dune","isbn":"9780441013593"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var b api.Book
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&b))
+	require.NotNil(t, b.Owner)
+	assert.Equal(t, "jwt:ada", *b.Owner)
+	assert.Equal(t, http.StatusCreated, do(bob, http.MethodPost, "/api/v1/books", `{"title":"Dune","isbn":"9780441013593"}`).Code,
+		"ISBNs are unique per user")
+
+	assert.Equal(t, http.StatusOK, do(ada, http.MethodGet, "/api/v1/books/"+b.Id, "").Code)
+	assert.Equal(t, http.StatusNotFound, do(bob, http.MethodGet, "/api/v1/books/"+b.Id, "").Code)
+	assert.Equal(t, http.StatusNotFound, do(bob, http.MethodDelete, "/api/v1/books/"+b.Id, "").Code)
+	assert.Equal(t, http.StatusOK, do(admin, http.MethodGet, "/api/v1/books/"+b.Id, "").Code)
+
+	total := func(p auth.Principal) int {
+		w := do(p, http.MethodGet, "/api/v1/books", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var page api.PaginatedBooks
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+		require.NotNil(t, page.Total)
+		return *page.Total
+	}
+	assert.Equal(t, 1, total(ada))
+	assert.Equal(t, 1, total(bob))
+	assert.Equal(t, 2, total(admin))
+	assert.Equal(t, http.StatusNoContent, do(ada, http.MethodDelete, "/api/v1/books/"+b.Id, "").Code)
+}
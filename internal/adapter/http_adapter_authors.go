@@ -0,0 +1,135 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+)
+
+func (h *HTTPHandler) ListAuthors(w http.ResponseWriter, r *http.Request, p api.ListAuthorsParams) {
+	q := model.AuthorQuery{Q: p.Q, Page: 1, PageSize: 20}
+	if p.Page != nil {
+		q.Page = *p.Page
+	}
+	if p.PageSize != nil {
+		q.PageSize = *p.PageSize
+	}
+	page, err := h.Svc.ListAuthors(r.Context(), q)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list authors failed")
+		return
+	}
+	out := api.PaginatedAuthors{Data: make([]api.Author, 0, len(page.Data)), Page: page.Page, PageSize: page.PageSize, Total: page.Total}
+	for _, a := range page.Data {
+		out.Data = append(out.Data, fromDomainAuthor(a))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) CreateAuthor(w http.ResponseWriter, r *http.Request) {
+	var in api.AuthorWrite
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	a, err := h.Svc.CreateAuthor(r.Context(), in.Name)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("create author failed")
+		return
+	}
+	w.Header().Set("Location", "/api/v1/authors/"+a.ID)
+	writeJSON(w, http.StatusCreated, fromDomainAuthor(a))
+}
+
+func (h *HTTPHandler) GetAuthor(w http.ResponseWriter, r *http.Request, id string) {
+	a, err := h.Svc.GetAuthor(r.Context(), id)
+	if err != nil {
+		writeErrFor(w, r, http.StatusNotFound, "NOT_FOUND", "author not found", nil)
+		h.logFor(r).With("error", err).Info("get author failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainAuthor(a))
+}
+
+func (h *HTTPHandler) UpdateAuthor(w http.ResponseWriter, r *http.Request, id string) {
+	var in api.AuthorWrite
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	a, err := h.Svc.UpdateAuthor(r.Context(), id, in.Name)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("update author failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainAuthor(a))
+}
+
+func (h *HTTPHandler) DeleteAuthor(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.Svc.DeleteAuthor(r.Context(), id); err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("delete author failed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HTTPHandler) RenameAuthor(w http.ResponseWriter, r *http.Request) {
+	var in api.AuthorRenameRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	rn, err := h.Svc.RenameAuthor(r.Context(), in.From, in.To)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("rename author failed")
+		return
+	}
+	h.logFor(r).Info("author renamed", "from", rn.From, "to", rn.To, "books", rn.BooksUpdated)
+	writeJSON(w, http.StatusOK, fromDomainRename(rn))
+}
+
+func (h *HTTPHandler) ListAuthorRenames(w http.ResponseWriter, r *http.Request) {
+	renames, err := h.Svc.ListAuthorRenames(r.Context())
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list author renames failed")
+		return
+	}
+	out := api.AuthorRenameList{Data: make([]api.AuthorRename, 0, len(renames))}
+	for _, rn := range renames {
+		out.Data = append(out.Data, fromDomainRename(rn))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func fromDomainRename(rn model.AuthorRename) api.AuthorRename {
+	return api.AuthorRename{
+		From:         rn.From,
+		To:           rn.To,
+		BooksUpdated: rn.BooksUpdated,
+		RenamedAt:    rn.RenamedAt.UTC(),
+	}
+}
+
+func fromDomainAuthor(a model.Author) api.Author {
+	return api.Author{
+		Id:        a.ID,
+		Name:      a.Name,
+		CreatedAt: a.CreatedAt.UTC(),
+		UpdatedAt: a.UpdatedAt.UTC(),
+	}
+}
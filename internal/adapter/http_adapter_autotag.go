@@ -0,0 +1,74 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+)
+
+func (h *HTTPHandler) ListAutoTagRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.Svc.ListAutoTagRules(r.Context())
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list auto-tag rules failed")
+		return
+	}
+	out := api.AutoTagRuleList{Data: make([]api.AutoTagRule, 0, len(rules))}
+	for _, rule := range rules {
+		out.Data = append(out.Data, fromDomainRule(rule))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) CreateAutoTagRule(w http.ResponseWriter, r *http.Request) {
+	var in api.AutoTagRuleCreate
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	rule, err := h.Svc.CreateAutoTagRule(r.Context(), model.AutoTagRule{
+		Field:    string(in.Field),
+		Contains: in.Contains,
+		AddTag:   in.AddTag,
+	})
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("create auto-tag rule failed")
+		return
+	}
+	h.logFor(r).Info("auto-tag rule created", "rule-id", rule.ID)
+	writeJSON(w, http.StatusCreated, fromDomainRule(rule))
+}
+
+func (h *HTTPHandler) DeleteAutoTagRule(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.Svc.DeleteAutoTagRule(r.Context(), id); err != nil {
+		writeErrFor(w, r, http.StatusNotFound, "NOT_FOUND", "rule not found", nil)
+		h.logFor(r).With("error", err).Info("delete auto-tag rule failed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HTTPHandler) BackfillAutoTags(w http.ResponseWriter, r *http.Request) {
+	n, err := h.Svc.BackfillAutoTags(r.Context())
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("auto-tag backfill failed")
+		return
+	}
+	h.logFor(r).Info("auto-tag backfill processed", "updated", n)
+	writeJSON(w, http.StatusOK, api.AutoTagBackfillResult{Updated: n})
+}
+
+func fromDomainRule(rule model.AutoTagRule) api.AutoTagRule {
+	return api.AutoTagRule{
+		Id:        rule.ID,
+		Field:     api.AutoTagField(rule.Field),
+		Contains:  rule.Contains,
+		AddTag:    rule.AddTag,
+		CreatedAt: rule.CreatedAt.UTC(),
+	}
+}
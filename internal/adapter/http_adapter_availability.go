@@ -0,0 +1,24 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"net/http"
+)
+
+func (h *HTTPHandler) GetBookAvailability(w http.ResponseWriter, r *http.Request, id string) {
+	a, err := h.Svc.BookAvailability(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("get book availability failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, api.Availability{
+		Library:    a.Library,
+		Held:       a.Held,
+		Borrowable: a.Borrowable(),
+		Copies:     a.Copies,
+		Available:  a.Available,
+		CheckedAt:  a.CheckedAt.UTC(),
+	})
+}
@@ -0,0 +1,56 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+)
+
+func (h *HTTPHandler) CreateBooksBatch(w http.ResponseWriter, r *http.Request) {
+	var in api.BatchCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	inputs := make([]model.CreateBookInput, 0, len(in.Items))
+	for _, item := range in.Items {
+		din := toCreateInput(item.Book, boolOr(item.Enrich), boolOr(item.RequireEnrichment))
+		din.AutoCorrect = boolOr(item.AutoCorrect)
+		inputs = append(inputs, din)
+	}
+	results, err := h.Svc.CreateBooks(r.Context(), inputs)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("batch create failed")
+		return
+	}
+
+	out := api.BatchCreateResult{Results: make([]api.BatchItemResult, 0, len(results))}
+	for i, res := range results {
+		item := api.BatchItemResult{Index: i}
+		if res.Err != nil {
+			_, code := mapSvcErr(res.Err)
+			item.Status = api.Failed
+			item.Error = &api.BatchItemError{Code: code, Message: res.Err.Error()}
+			if det := errDetails(res.Err); det != nil {
+				item.Error.Details = &det
+			}
+			out.Failed++
+		} else {
+			b := fromDomainBook(res.Book)
+			item.Status = api.Created
+			item.Book = &b
+			out.Created++
+		}
+		out.Results = append(out.Results, item)
+	}
+	h.logFor(r).Info("batch create processed", "created", out.Created, "failed", out.Failed)
+	writeJSON(w, http.StatusOK, out)
+}
+
+func boolOr(p *bool) bool {
+	return p != nil && *p
+}
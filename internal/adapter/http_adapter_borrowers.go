@@ -0,0 +1,224 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// borrowerColumns are the columns a borrower import accepts.
+var borrowerColumns = []string{"name", "email", "external_id"}
+
+func (h *HTTPHandler) ListBorrowers(w http.ResponseWriter, r *http.Request, p api.ListBorrowersParams) {
+	q := model.BorrowerQuery{Page: 1, PageSize: 20}
+	if p.Q != nil {
+		q.Q = *p.Q
+	}
+	if p.Page != nil {
+		q.Page = *p.Page
+	}
+	if p.PageSize != nil {
+		q.PageSize = *p.PageSize
+	}
+	page, err := h.Svc.ListBorrowers(r.Context(), q)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("list borrowers failed")
+		return
+	}
+	out := api.PaginatedBorrowers{Data: make([]api.Borrower, 0, len(page.Data)), Page: page.Page, PageSize: page.PageSize, Total: page.Total}
+	for _, b := range page.Data {
+		out.Data = append(out.Data, fromDomainBorrower(b))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) CreateBorrower(w http.ResponseWriter, r *http.Request) {
+	var in api.BorrowerWrite
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	b, err := h.Svc.CreateBorrower(r.Context(), toDomainBorrower(in))
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("create borrower failed")
+		return
+	}
+	h.logFor(r).Info("borrower created", "borrower", b.ID)
+	w.Header().Set("Location", "/api/v1/borrowers/"+b.ID)
+	writeJSON(w, http.StatusCreated, fromDomainBorrower(b))
+}
+
+func (h *HTTPHandler) GetBorrower(w http.ResponseWriter, r *http.Request, id string) {
+	b, err := h.Svc.GetBorrower(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("get borrower failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainBorrower(b))
+}
+
+func (h *HTTPHandler) UpdateBorrower(w http.ResponseWriter, r *http.Request, id string) {
+	var in api.BorrowerWrite
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	b := toDomainBorrower(in)
+	b.ID = id
+	b, err := h.Svc.UpdateBorrower(r.Context(), b)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("update borrower failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainBorrower(b))
+}
+
+func (h *HTTPHandler) DeleteBorrower(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.Svc.DeleteBorrower(r.Context(), id); err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("delete borrower failed")
+		return
+	}
+	h.logFor(r).Info("borrower deleted", "borrower", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HTTPHandler) MergeBorrower(w http.ResponseWriter, r *http.Request, id string) {
+	var in api.BorrowerMerge
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	b, err := h.Svc.MergeBorrowers(r.Context(), id, in.From)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("merge borrowers failed")
+		return
+	}
+	h.logFor(r).Info("borrowers merged", "borrower", id, "from", in.From)
+	writeJSON(w, http.StatusOK, fromDomainBorrower(b))
+}
+
+func (h *HTTPHandler) ImportBorrowers(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBody)
+	cr := csv.NewReader(r.Body)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err == nil {
+		err = checkBorrowerHeader(header)
+	}
+	if err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid CSV header", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid CSV header")
+		return
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	out := api.ImportResult{Errors: []api.ImportRowError{}}
+	fail := func(line int, code, msg string) {
+		out.Failed++
+		out.Errors = append(out.Errors, api.ImportRowError{Line: line, Code: code, Message: msg})
+	}
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			out.Rows++
+			fail(perr.StartLine, "VALIDATION", perr.Err.Error())
+			continue
+		}
+		if err != nil {
+			writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "import aborted", map[string]any{
+				"cause": err.Error(), "rows": out.Rows, "created": out.Created,
+			})
+			h.logFor(r).With("error", err).Info("borrower import aborted", "rows", out.Rows, "created", out.Created)
+			return
+		}
+		out.Rows++
+		line, _ := cr.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := cols[name]; ok && i < len(rec) {
+				return rec[i]
+			}
+			return ""
+		}
+		b := model.Borrower{Name: field("name"), Email: field("email"), ExternalID: field("external_id")}
+		if _, err := h.Svc.CreateBorrower(r.Context(), b); err != nil {
+			_, code := mapSvcErr(err)
+			fail(line, code, err.Error())
+			continue
+		}
+		out.Created++
+	}
+	h.logFor(r).Info("borrower import processed", "rows", out.Rows, "created", out.Created, "failed", out.Failed)
+	writeJSON(w, http.StatusOK, out)
+}
+
+func checkBorrowerHeader(header []string) error {
+	seen := make(map[string]bool, len(header))
+	for _, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(borrowerColumns, name) {
+			return fmt.Errorf("unknown column %q", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate column %q", name)
+		}
+		seen[name] = true
+	}
+	if !seen["name"] {
+		return errors.New("a name column is required")
+	}
+	return nil
+}
+
+func toDomainBorrower(in api.BorrowerWrite) model.Borrower {
+	b := model.Borrower{Name: in.Name}
+	if in.Email != nil {
+		b.Email = *in.Email
+	}
+	if in.ExternalId != nil {
+		b.ExternalID = *in.ExternalId
+	}
+	return b
+}
+
+func fromDomainBorrower(b model.Borrower) api.Borrower {
+	return api.Borrower{
+		Id:         b.ID,
+		Name:       b.Name,
+		Email:      strPtrOrNil(b.Email),
+		ExternalId: strPtrOrNil(b.ExternalID),
+		CreatedAt:  b.CreatedAt.UTC(),
+		UpdatedAt:  b.UpdatedAt.UTC(),
+	}
+}
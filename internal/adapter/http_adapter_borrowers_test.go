@@ -0,0 +1,68 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBorrowersHTTP(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Borrowers = NewBorrowerRepo()
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/borrowers", `{"name":"Ada Lovelace","email":"ada@example.org"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var ada api.Borrower
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&ada))
+	assert.Equal(t, "/api/v1/borrowers/"+ada.Id, w.Header().Get("Location"))
+	assert.Nil(t, ada.ExternalId)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/v1/borrowers", `{"name":""}`).Code)
+
+	w = do(http.MethodPost, "/api/v1/borrowers/import", "email,name,external_id\n"+
+		"cb@example.org,Charles Babbage,1042\n"+
+		"ada@example.org,Ada,\n"+
+		",,\n")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var res api.ImportResult
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+	assert.Equal(t, 3, res.Rows)
+	assert.Equal(t, 1, res.Created)
+	require.Len(t, res.Errors, 2)
+	assert.Equal(t, 3, res.Errors[0].Line)
+	assert.Equal(t, "CONFLICT", res.Errors[0].Code)
+	assert.Equal(t, "VALIDATION", res.Errors[1].Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/v1/borrowers/import", "name,phone\n").Code)
+
+	w = do(http.MethodGet, "/api/v1/borrowers?q=1042", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var page api.PaginatedBorrowers
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	require.Equal(t, 1, page.Total)
+	cb := page.Data[0]
+	assert.Equal(t, "Charles Babbage", cb.Name)
+
+	w = do(http.MethodPost, "/api/v1/borrowers/"+ada.Id+"/merge", `{"from":"`+cb.Id+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"external_id":"1042"`)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/v1/borrowers/"+cb.Id, "").Code)
+
+	w = do(http.MethodPut, "/api/v1/borrowers/"+ada.Id, `{"name":"Ada King"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "email", "a put replaces the details")
+	require.Equal(t, http.StatusNoContent, do(http.MethodDelete, "/api/v1/borrowers/"+ada.Id, "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, "/api/v1/borrowers/"+ada.Id, "").Code)
+}
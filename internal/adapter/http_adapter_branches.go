@@ -0,0 +1,145 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/auth"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+)
+
+func (h *HTTPHandler) ListBranches(w http.ResponseWriter, r *http.Request) {
+	branches, err := h.Svc.ListBranches(r.Context())
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list branches failed")
+		return
+	}
+	out := api.BranchList{Data: make([]api.Branch, 0, len(branches))}
+	for _, b := range branches {
+		out.Data = append(out.Data, fromDomainBranch(b))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) CreateBranch(w http.ResponseWriter, r *http.Request) {
+	var in api.BranchCreate
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	b := model.Branch{ID: in.Id, Name: in.Name}
+	if in.Address != nil {
+		b.Address = *in.Address
+	}
+	b, err := h.Svc.CreateBranch(r.Context(), b)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("create branch failed")
+		return
+	}
+	h.logFor(r).Info("branch created", "branch", b.ID)
+	w.Header().Set("Location", "/api/v1/branches/"+b.ID)
+	writeJSON(w, http.StatusCreated, fromDomainBranch(b))
+}
+
+func (h *HTTPHandler) GetBranch(w http.ResponseWriter, r *http.Request, id string) {
+	b, err := h.Svc.GetBranch(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("get branch failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainBranch(b))
+}
+
+func (h *HTTPHandler) UpdateBranch(w http.ResponseWriter, r *http.Request, id string) {
+	var in api.BranchPatch
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	b, err := h.Svc.UpdateBranch(r.Context(), id, model.BranchPatch{Name: in.Name, Address: in.Address})
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("update branch failed")
+		return
+	}
+	h.logFor(r).Info("branch updated", "branch", id)
+	writeJSON(w, http.StatusOK, fromDomainBranch(b))
+}
+
+func (h *HTTPHandler) DeleteBranch(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.Svc.DeleteBranch(r.Context(), id); err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("delete branch failed")
+		return
+	}
+	h.logFor(r).Info("branch deleted", "branch", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HTTPHandler) SetBookCopies(w http.ResponseWriter, r *http.Request, id string, branchID string) {
+	var in api.BranchCopies
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	if !h.allowBranches(w, r, branchID) {
+		return
+	}
+	b, err := h.Svc.SetCopies(r.Context(), id, branchID, in.Copies)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("set book copies failed")
+		return
+	}
+	h.logFor(r).Info("book copies set", "book", id, "branch", branchID, "copies", in.Copies)
+	h.writeBook(w, r, http.StatusOK, fromDomainBook(b))
+}
+
+func (h *HTTPHandler) TransferBookCopies(w http.ResponseWriter, r *http.Request, id string) {
+	var in api.TransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	if !h.allowBranches(w, r, in.From, in.To) {
+		return
+	}
+	b, err := h.Svc.TransferCopies(r.Context(), model.Transfer{BookID: id, From: in.From, To: in.To, Copies: in.Copies})
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("transfer book copies failed")
+		return
+	}
+	h.logFor(r).Info("book copies transferred", "book", id, "from", in.From, "to", in.To, "copies", in.Copies)
+	h.writeBook(w, r, http.StatusOK, fromDomainBook(b))
+}
+
+// allowBranches answers 403 unless the caller may change copies at every
+// one of the branches.
+func (h *HTTPHandler) allowBranches(w http.ResponseWriter, r *http.Request, branches ...string) bool {
+	for _, b := range branches {
+		if !auth.AllowsBranch(r.Context(), b) {
+			writeErrFor(w, r, http.StatusForbidden, "FORBIDDEN", "not allowed at branch "+b, map[string]any{"branch": b})
+			h.logFor(r).Info("branch forbidden", "branch", b)
+			return false
+		}
+	}
+	return true
+}
+
+func fromDomainBranch(b model.Branch) api.Branch {
+	return api.Branch{Id: b.ID, Name: b.Name, Address: strPtrOrNil(b.Address), CreatedAt: b.CreatedAt.UTC()}
+}
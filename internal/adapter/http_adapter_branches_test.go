@@ -0,0 +1,77 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/auth"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranchCopies(t *testing.T) {
+	h, svc := newServer(t)
+	ctx := context.Background()
+	for _, id := range []string{"east", "west"} {
+		_, err := svc.CreateBranch(ctx, model.Branch{ID: id, Name: id})
+		require.NoError(t, err)
+	}
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+
+	do := func(p auth.Principal, method, path, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r = r.WithContext(auth.NewContext(r.Context(), p))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+	eastOnly := auth.Principal{ID: "clerk", Role: auth.RoleEditor, Branches: []string{"east"}}
+
+	w := do(eastOnly, http.MethodPut, "/api/v1/books/"+b.ID+"/copies/east", `{"copies":3}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var got api.Book
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	require.NotNil(t, got.Copies)
+	assert.Equal(t, map[string]int{"east": 3}, *got.Copies)
+
+	w = do(eastOnly, http.MethodPut, "/api/v1/books/"+b.ID+"/copies/west", `{"copies":1}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = do(eastOnly, http.MethodPost, "/api/v1/books/"+b.ID+"/transfers", `{"from":"east","to":"west","copies":1}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, "the clerk may not add copies at west")
+
+	admin := auth.Principal{ID: "root", Role: auth.RoleAdmin}
+	w = do(admin, http.MethodPost, "/api/v1/books/"+b.ID+"/transfers", `{"from":"east","to":"west","copies":5}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = do(admin, http.MethodPost, "/api/v1/books/"+b.ID+"/transfers", `{"from":"east","to":"west","copies":2}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	assert.Equal(t, map[string]int{"east": 1, "west": 2}, *got.Copies)
+
+	w = do(admin, http.MethodGet, "/api/v1/books?branch=west", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var page api.PaginatedBooks
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	assert.Len(t, page.Data, 1)
+
+	w = do(admin, http.MethodDelete, "/api/v1/branches/west", "")
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = do(admin, http.MethodPatch, "/api/v1/branches/west", `{"address":"2 Side St"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var branch api.Branch
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&branch))
+	assert.Equal(t, "west", branch.Name, "left out, kept")
+	assert.Equal(t, util.GetPtr("2 Side St"), branch.Address)
+	assert.Equal(t, http.StatusBadRequest, do(admin, http.MethodPatch, "/api/v1/branches/west", `{"name":""}`).Code)
+	assert.Equal(t, http.StatusNotFound, do(admin, http.MethodPatch, "/api/v1/branches/north", `{"name":"North"}`).Code)
+}
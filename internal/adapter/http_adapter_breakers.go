@@ -0,0 +1,77 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+func (h *HTTPHandler) ListCircuitBreakers(w http.ResponseWriter, r *http.Request) {
+	breakers, err := h.Svc.CircuitBreakers(r.Context())
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list circuit breakers failed")
+		return
+	}
+	out := api.CircuitBreakerList{Data: make([]api.CircuitBreaker, 0, len(breakers))}
+	for _, b := range breakers {
+		out.Data = append(out.Data, api.CircuitBreaker{
+			Name:                b.Name,
+			State:               b.State,
+			Threshold:           b.Threshold,
+			CooldownSeconds:     int(b.Cooldown.Seconds()),
+			ConsecutiveFailures: b.ConsecutiveFailures,
+			Failures:            b.Failures,
+			Opens:               b.Opens,
+			Rejected:            b.Rejected,
+			OpenedAt:            utcPtr(b.OpenedAt),
+			LastError:           strPtrOrNil(b.LastError),
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+var breakerStates = []string{model.BreakerClosed, model.BreakerOpen, model.BreakerHalfOpen}
+
+// GetMetrics writes the circuit breakers in the Prometheus text format.
+func (h *HTTPHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	breakers, err := h.Svc.CircuitBreakers(r.Context())
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("metrics failed")
+		return
+	}
+	var sb strings.Builder
+	metric := func(name, typ, help string, value func(b model.CircuitBreakerStatus) int64) {
+		fmt.Fprintf(&sb, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+		for _, b := range breakers {
+			fmt.Fprintf(&sb, "%s{name=%q} %d\n", name, b.Name, value(b))
+		}
+	}
+	fmt.Fprint(&sb, "# HELP bookmanager_circuit_breaker_state 1 for the state each circuit breaker is in.\n")
+	fmt.Fprint(&sb, "# TYPE bookmanager_circuit_breaker_state gauge\n")
+	for _, b := range breakers {
+		for _, st := range breakerStates {
+			v := 0
+			if b.State == st {
+				v = 1
+			}
+			fmt.Fprintf(&sb, "bookmanager_circuit_breaker_state{name=%q,state=%q} %d\n", b.Name, st, v)
+		}
+	}
+	metric("bookmanager_circuit_breaker_consecutive_failures", "gauge", "Failed calls since the last success.",
+		func(b model.CircuitBreakerStatus) int64 { return int64(b.ConsecutiveFailures) })
+	metric("bookmanager_circuit_breaker_failures_total", "counter", "Failed calls.",
+		func(b model.CircuitBreakerStatus) int64 { return b.Failures })
+	metric("bookmanager_circuit_breaker_opens_total", "counter", "Times the breaker opened.",
+		func(b model.CircuitBreakerStatus) int64 { return b.Opens })
+	metric("bookmanager_circuit_breaker_rejected_total", "counter", "Calls failed at once while open.",
+		func(b model.CircuitBreakerStatus) int64 { return b.Rejected })
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(sb.String()))
+}
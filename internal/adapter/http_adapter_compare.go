@@ -0,0 +1,94 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// maxCompareBody bounds the request body; 10000 ISBNs with some extra CSV
+// columns fit comfortably.
+const maxCompareBody = 1 << 20
+
+func (h *HTTPHandler) CompareBooks(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxCompareBody)
+	isbns, err := readISBNList(r)
+	if err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid ISBN list", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid ISBN list")
+		return
+	}
+	res, err := h.Svc.CompareISBNs(r.Context(), isbns)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("compare books failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainComparison(res))
+}
+
+// readISBNList accepts a CompareRequest as JSON or a CSV document. For CSV the
+// column headed "isbn" is used, or the first column when there is no header.
+func readISBNList(r *http.Request) ([]string, error) {
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if ct != "text/csv" {
+		var in api.CompareRequest
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			return nil, err
+		}
+		return in.Isbns, nil
+	}
+
+	cr := csv.NewReader(r.Body)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	col, first := 0, true
+	var out []string
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if first {
+			first = false
+			if i := headerIndex(rec, "isbn"); i >= 0 {
+				col = i
+				continue
+			}
+		}
+		if col < len(rec) {
+			out = append(out, rec[col])
+		}
+	}
+}
+
+func headerIndex(rec []string, name string) int {
+	for i, v := range rec {
+		if strings.EqualFold(strings.TrimSpace(v), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func fromDomainComparison(c model.CatalogComparison) api.CompareResult {
+	out := api.CompareResult{
+		Present:    make([]api.CompareMatch, 0, len(c.Present)),
+		Missing:    append([]string{}, c.Missing...),
+		Duplicated: append([]string{}, c.Duplicated...),
+	}
+	for _, m := range c.Present {
+		out.Present = append(out.Present, api.CompareMatch{Isbn: m.ISBN, BookId: m.Book.ID, Title: m.Book.Title})
+	}
+	return out
+}
@@ -0,0 +1,139 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// maxCoverUpload bounds a cover image; multipart overhead comes on top.
+const maxCoverUpload = 10 << 20
+
+func (h *HTTPHandler) GetBookCover(w http.ResponseWriter, r *http.Request, id string, params api.GetBookCoverParams) {
+	var size string
+	if params.Size != nil {
+		size = *params.Size
+	}
+	cs, err := model.ParseCoverSize(size)
+	var c model.Cover
+	if err == nil {
+		c, err = h.Svc.BookCover(r.Context(), id, cs)
+	}
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("get book cover failed")
+		return
+	}
+	writeCover(w, r, c)
+}
+
+func writeCover(w http.ResponseWriter, r *http.Request, c model.Cover) {
+	if c.URL != "" {
+		http.Redirect(w, r, c.URL, http.StatusFound)
+		return
+	}
+	sum := sha256.Sum256(c.Data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if inm := r.Header.Get("If-None-Match"); inm != "" && etagListed(inm, etag, true) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", c.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(c.Data)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(c.Data)
+	}
+}
+
+func (h *HTTPHandler) UploadBookCover(w http.ResponseWriter, r *http.Request, id string) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxCoverUpload+64<<10)
+	data, err := readCoverUpload(r)
+	if err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid cover upload", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid cover upload")
+		return
+	}
+	b, err := h.Svc.UploadCover(r.Context(), id, data)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("upload book cover failed")
+		return
+	}
+	h.logFor(r).Info("cover uploaded", "id", id, "bytes", len(data))
+	h.writeBookETag(w, r, http.StatusOK, fromDomainBook(b))
+}
+
+// readCoverUpload returns the file part of a multipart form, streaming it
+// rather than letting ParseMultipartForm spool it to disk.
+func readCoverUpload(r *http.Request) ([]byte, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("missing file field")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(part, maxCoverUpload+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxCoverUpload {
+			return nil, errors.New("file exceeds 10 MiB")
+		}
+		return data, nil
+	}
+}
+
+func (h *HTTPHandler) StartCoverPrefetch(w http.ResponseWriter, r *http.Request) {
+	st, err := h.Svc.PrefetchCovers(r.Context())
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("start cover prefetch failed")
+		return
+	}
+	h.logFor(r).Info("cover prefetch requested", "books", st.Books, "running", st.Running)
+	writeJSON(w, http.StatusAccepted, fromDomainPrefetch(st))
+}
+
+func (h *HTTPHandler) GetCoverPrefetch(w http.ResponseWriter, r *http.Request) {
+	st, err := h.Svc.CoverPrefetchStatus(r.Context())
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("get cover prefetch failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainPrefetch(st))
+}
+
+func fromDomainPrefetch(st model.CoverPrefetch) api.CoverPrefetch {
+	out := api.CoverPrefetch{Running: st.Running, Books: st.Books, Cached: st.Cached, Failed: st.Failed}
+	if !st.StartedAt.IsZero() {
+		t := st.StartedAt.UTC()
+		out.StartedAt = &t
+	}
+	if !st.FinishedAt.IsZero() {
+		t := st.FinishedAt.UTC()
+		out.FinishedAt = &t
+	}
+	return out
+}
@@ -0,0 +1,335 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxImportBody bounds an import request, which is read in full before the
+// import starts.
+const maxImportBody = 32 << 20
+
+// csvColumns is the export column order. Imports accept the same columns in
+// any order; the export-only ones are ignored.
+var csvColumns = []string{
+	"id", "isbn", "title", "subtitle", "published_year", "page_count",
+	"cover_url", "authors", "tags", "created_at", "updated_at",
+}
+
+// csvImportColumns are accepted by imports only: the importing caller's
+// reading status, as a reading status shelf or the name of a shelf of
+// their own, and the date they finished the book.
+var csvImportColumns = []string{"reading_status", "date_read"}
+
+// goodreadsColumns maps the columns of a Goodreads library export to ours,
+// so the export imports as is; those mapped to "" are ignored. Authors
+// beyond the first and ISBN-13s are Goodreads-only columns.
+var goodreadsColumns = map[string]string{
+	"book id": "", "author": "authors", "author l-f": "", "additional authors": "additional_authors",
+	"isbn13": "isbn13", "my rating": "", "average rating": "", "publisher": "", "binding": "",
+	"number of pages": "page_count", "year published": "published_year", "original publication year": "",
+	"date read": "date_read", "date added": "", "bookshelves": "", "bookshelves with positions": "",
+	"exclusive shelf": "reading_status", "my review": "", "spoiler": "", "private notes": "",
+	"read count": "", "owned copies": "",
+}
+
+// goodreadsShelves maps the Goodreads reading status shelves to ours.
+var goodreadsShelves = map[string]string{
+	"to-read":           model.BookshelfWantToRead,
+	"currently-reading": model.BookshelfReading,
+	"read":              model.BookshelfRead,
+}
+
+// csvListSep separates authors and tags within a single CSV field.
+const csvListSep = ";"
+
+func (h *HTTPHandler) ImportBooks(w http.ResponseWriter, r *http.Request, p api.ImportBooksParams) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBody)
+	cr := csv.NewReader(r.Body)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err == nil {
+		err = checkCSVHeader(header)
+	}
+	if err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid CSV header", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid CSV header")
+		return
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		if name := csvColumnName(name); name != "" {
+			cols[name] = i
+		}
+	}
+	enrich := boolOr(p.Enrich)
+
+	// the document is read in full before the import starts, so one whose
+	// body fails creates nothing; rows that fail to parse are reported by
+	// the import in their turn
+	var rows []model.ImportRow
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			rows = append(rows, model.ImportRow{Line: perr.StartLine, Error: perr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			// the body itself failed (too large, connection dropped)
+			writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "import aborted", map[string]any{
+				"cause": err.Error(), "rows": len(rows),
+			})
+			h.logFor(r).With("error", err).Info("import aborted", "rows", len(rows))
+			return
+		}
+		line, _ := cr.FieldPos(0)
+		row := model.ImportRow{Line: line}
+		row.Input, err = fromCSVRecord(rec, cols)
+		row.Input.Enrich = enrich
+		if err == nil {
+			row.State, err = readingStateFromCSV(rec, cols)
+		}
+		if err != nil {
+			row.Error = err.Error()
+		}
+		rows = append(rows, row)
+	}
+	job, err := h.Svc.StartBookImport(r.Context(), rows)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("import failed", "rows", len(rows))
+		return
+	}
+	h.logFor(r).Info("import started", "import_id", job.ID, "rows", job.Rows)
+	w.Header().Set("Location", "/api/v1/imports/"+job.ID)
+	writeJSON(w, http.StatusAccepted, toAPIImportJob(job))
+}
+
+func (h *HTTPHandler) GetImportJob(w http.ResponseWriter, r *http.Request, id string) {
+	job, err := h.Svc.ImportJob(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("get import failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, toAPIImportJob(job))
+}
+
+func toAPIImportJob(job model.ImportJob) api.ImportJob {
+	out := api.ImportJob{
+		Id:         job.ID,
+		Status:     api.ImportJobStatus(job.Status),
+		Rows:       job.Rows,
+		Processed:  job.Offset,
+		Created:    job.Created,
+		Failed:     job.Failed,
+		Errors:     make([]api.ImportRowError, 0, len(job.Errors)),
+		StartedAt:  job.StartedAt,
+		UpdatedAt:  job.UpdatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+	for _, e := range job.Errors {
+		_, code := mapSvcErr(model.KindError(e.Kind))
+		out.Errors = append(out.Errors, api.ImportRowError{Line: e.Line, Isbn: e.ISBN, Code: code, Message: e.Message})
+	}
+	return out
+}
+
+// csvColumnName is the column a header names, with Goodreads columns
+// mapped to ours; it is empty for a column that is ignored.
+func csvColumnName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if c, ok := goodreadsColumns[name]; ok {
+		return c
+	}
+	return name
+}
+
+func checkCSVHeader(header []string) error {
+	known := make(map[string]bool, len(csvColumns))
+	for _, c := range csvColumns {
+		known[c] = true
+	}
+	for _, c := range csvImportColumns {
+		known[c] = true
+	}
+	for _, c := range goodreadsColumns {
+		known[c] = true
+	}
+	seen := make(map[string]bool, len(header))
+	for _, name := range header {
+		name = csvColumnName(name)
+		if name == "" {
+			continue
+		}
+		if !known[name] {
+			return fmt.Errorf("unknown column %q", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate column %q", name)
+		}
+		seen[name] = true
+	}
+	if !seen["title"] && !seen["isbn"] {
+		return errors.New("a title or isbn column is required")
+	}
+	return nil
+}
+
+// toCSVRecord writes b's times in loc.
+func toCSVRecord(b model.Book, loc *time.Location) []string {
+	return []string{
+		b.ID,
+		deref(b.ISBN),
+		b.Title,
+		deref(b.Subtitle),
+		itoaOrEmpty(b.PublishedYear),
+		itoaOrEmpty(b.PageCount),
+		deref(b.CoverURL),
+		strings.Join(b.Authors, csvListSep),
+		strings.Join(b.Tags, csvListSep),
+		b.CreatedAt.In(loc).Format(time.RFC3339),
+		b.UpdatedAt.In(loc).Format(time.RFC3339),
+	}
+}
+
+// csvField returns the field of column name in rec, or nil when it is
+// absent or empty.
+func csvField(rec []string, cols map[string]int, name string) *string {
+	i, ok := cols[name]
+	if !ok || i >= len(rec) {
+		return nil
+	}
+	v := strings.TrimSpace(rec[i])
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// fromCSVRecord maps a data row using the header positions in cols. Empty
+// fields are treated as absent.
+func fromCSVRecord(rec []string, cols map[string]int) (model.CreateBookInput, error) {
+	field := func(name string) *string { return csvField(rec, cols, name) }
+	in := model.CreateBookInput{
+		ISBN:     csvISBN(field("isbn")),
+		Title:    field("title"),
+		Subtitle: field("subtitle"),
+		CoverURL: field("cover_url"),
+		Authors:  splitCSVList(field("authors")),
+		Tags:     splitCSVList(field("tags")),
+	}
+	if in.ISBN == nil {
+		in.ISBN = csvISBN(field("isbn13"))
+	}
+	if more := field("additional_authors"); more != nil {
+		for _, a := range strings.Split(*more, ",") {
+			if a = strings.TrimSpace(a); a != "" {
+				in.Authors = append(in.Authors, a)
+			}
+		}
+	}
+	var err error
+	if in.PublishedYear, err = atoiField(field("published_year"), "published_year"); err != nil {
+		return in, err
+	}
+	if in.PageCount, err = atoiField(field("page_count"), "page_count"); err != nil {
+		return in, err
+	}
+	return in, nil
+}
+
+// csvISBN unwraps an ISBN written as a spreadsheet formula, ="0441013597",
+// as Goodreads exports them.
+func csvISBN(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	s := strings.TrimSpace(strings.Trim(strings.TrimPrefix(*v, "="), `"`))
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// readingStateFromCSV reads the reading_status and date_read columns of a
+// row; it returns nil when both are empty. Goodreads shelf names and dates
+// (2006/01/02) are accepted along with ours.
+func readingStateFromCSV(rec []string, cols map[string]int) (*model.ReadingState, error) {
+	shelf, read := csvField(rec, cols, "reading_status"), csvField(rec, cols, "date_read")
+	if shelf == nil && read == nil {
+		return nil, nil
+	}
+	var st model.ReadingState
+	if shelf != nil {
+		st.Shelf = *shelf
+		if id, ok := goodreadsShelves[strings.ToLower(*shelf)]; ok {
+			st.Shelf = id
+		}
+	}
+	if read != nil {
+		t, err := time.Parse(time.DateOnly, *read)
+		if err != nil {
+			if t, err = time.Parse("2006/01/02", *read); err != nil {
+				return nil, fmt.Errorf("invalid date_read %q", *read)
+			}
+		}
+		st.FinishedAt = &t
+	}
+	return &st, nil
+}
+
+func atoiField(v *string, name string) (*int, error) {
+	if v == nil {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(*v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", name, *v)
+	}
+	return &n, nil
+}
+
+func splitCSVList(v *string) []string {
+	if v == nil {
+		return nil
+	}
+	var out []string
+	for _, s := range strings.Split(*v, csvListSep) {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func itoaOrEmpty(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
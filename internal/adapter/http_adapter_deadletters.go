@@ -0,0 +1,64 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"net/http"
+)
+
+func (h *HTTPHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	letters, err := h.Svc.ListDeadLetters(r.Context())
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list dead letters failed")
+		return
+	}
+	out := api.DeadLetterList{Data: make([]api.DeadLetter, 0, len(letters))}
+	for _, d := range letters {
+		out.Data = append(out.Data, fromDomainDeadLetter(d))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) ReplayDeadLetter(w http.ResponseWriter, r *http.Request, id string) {
+	d, err := h.Svc.ReplayDeadLetter(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("replay dead letter failed", "dead-letter-id", id)
+		return
+	}
+	h.logFor(r).Info("dead letter delivered", "dead-letter-id", id, "type", d.Event.Type)
+	writeJSON(w, http.StatusOK, fromDomainDeadLetter(d))
+}
+
+func (h *HTTPHandler) ReplayDeadLetters(w http.ResponseWriter, r *http.Request) {
+	delivered, failed, err := h.Svc.ReplayDeadLetters(r.Context())
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), map[string]any{"delivered": delivered, "failed": failed})
+		h.logFor(r).With("error", err).Info("replay dead letters failed", "delivered", delivered, "failed", failed)
+		return
+	}
+	h.logFor(r).Info("dead letters replayed", "delivered", delivered, "failed", failed)
+	writeJSON(w, http.StatusOK, api.DeadLetterReplayResult{Delivered: delivered, Failed: failed})
+}
+
+func (h *HTTPHandler) DeleteDeadLetter(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.Svc.DeleteDeadLetter(r.Context(), id); err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("delete dead letter failed", "dead-letter-id", id)
+		return
+	}
+	h.logFor(r).Info("dead letter discarded", "dead-letter-id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func fromDomainDeadLetter(d model.DeadLetter) api.DeadLetter {
+	ev := api.CatalogEvent{Type: d.Event.Type, BookId: d.Event.BookID, Title: strPtrOrNil(d.Event.Book.Title), At: d.Event.At.UTC()}
+	if d.Event.Price != nil {
+		p := fromDomainPrice(*d.Event.Price)
+		ev.Price = &p
+	}
+	return api.DeadLetter{Id: d.ID, Event: ev, Error: d.Error, Attempts: d.Attempts, FailedAt: d.FailedAt.UTC()}
+}
@@ -0,0 +1,83 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+)
+
+func (h *HTTPHandler) ListDuplicateBooks(w http.ResponseWriter, r *http.Request, p api.ListDuplicateBooksParams) {
+	mode, page, size := model.DuplicateModeTitle, 1, 20
+	if p.Mode != nil {
+		mode = *p.Mode
+	}
+	if p.Page != nil {
+		page = *p.Page
+	}
+	if p.PageSize != nil {
+		size = *p.PageSize
+	}
+	res, err := h.Svc.DuplicateBooks(r.Context(), mode, page, size)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("duplicate report failed")
+		return
+	}
+	out := api.DuplicateReport{Data: make([]api.DuplicateGroup, 0, len(res.Data)), Page: res.Page, PageSize: res.PageSize, Total: res.Total}
+	for _, g := range res.Data {
+		group := api.DuplicateGroup{Books: make([]api.Book, 0, len(g.Books))}
+		for _, b := range g.Books {
+			group.Books = append(group.Books, fromDomainBook(b))
+		}
+		out.Data = append(out.Data, group)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) ListDuplicateExclusions(w http.ResponseWriter, r *http.Request) {
+	all, err := h.Svc.ListDuplicateExclusions(r.Context())
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list duplicate exclusions failed")
+		return
+	}
+	out := api.DuplicateExclusionList{Data: make([]api.DuplicateExclusion, 0, len(all))}
+	for _, e := range all {
+		out.Data = append(out.Data, fromDomainExclusion(e))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) CreateDuplicateExclusion(w http.ResponseWriter, r *http.Request) {
+	var in api.DuplicateExclusionCreate
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	e, err := h.Svc.ExcludeDuplicates(r.Context(), in.BookIds)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("create duplicate exclusion failed")
+		return
+	}
+	h.logFor(r).Info("duplicate exclusion created", "exclusion-id", e.ID, "books", len(e.BookIDs))
+	writeJSON(w, http.StatusCreated, fromDomainExclusion(e))
+}
+
+func (h *HTTPHandler) DeleteDuplicateExclusion(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.Svc.DeleteDuplicateExclusion(r.Context(), id); err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("delete duplicate exclusion failed", "exclusion-id", id)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func fromDomainExclusion(e model.DuplicateExclusion) api.DuplicateExclusion {
+	return api.DuplicateExclusion{Id: e.ID, BookIds: e.BookIDs, CreatedAt: e.CreatedAt.UTC()}
+}
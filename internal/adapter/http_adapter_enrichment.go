@@ -0,0 +1,35 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"net/http"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (h *HTTPHandler) PreviewEnrichment(w http.ResponseWriter, r *http.Request, isbn string) {
+	res, err := h.Svc.PreviewEnrichment(r.Context(), isbn)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("enrichment preview failed")
+		return
+	}
+	out := api.EnrichmentPreview{
+		Isbn:          res.ISBN,
+		Source:        res.Source,
+		Title:         res.Title,
+		Subtitle:      res.Subtitle,
+		Authors:       append([]string{}, res.Authors...),
+		PublishedYear: res.PublishedYear,
+		PageCount:     res.PageCount,
+		CoverUrl:      res.CoverURL,
+		Description:   res.Description,
+		Subjects:      append([]string{}, res.Subjects...),
+	}
+	if res.ReleaseDate != nil {
+		out.ReleaseDate = &openapi_types.Date{Time: *res.ReleaseDate}
+	}
+	h.logFor(r).Info("enrichment preview processed", "source", res.Source)
+	writeJSON(w, http.StatusOK, out)
+}
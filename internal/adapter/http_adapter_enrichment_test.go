@@ -0,0 +1,60 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_PreviewEnrichment(t *testing.T) {
+	src := &fakeEnrich{book: model.EnrichedBook{
+		Title: util.GetPtr("Dune"), Authors: []string{"Frank Herbert"}, PageCount: util.GetPtr(528),
+		ReleaseDate: util.GetPtr(time.Date(2005, 8, 2, 15, 0, 0, 0, time.UTC)),
+	}}
+	missing := &fakeEnrich{err: errNotFound}
+	chain := NewEnrichmentChain(ChainSource{Name: "none", Client: missing}, ChainSource{Name: "openlibrary", Client: src})
+	svc := core.NewService(NewBookRepo(), NewEnrichmentCache(chain, time.Minute, 100))
+	r := chi.NewRouter()
+	api.HandlerFromMux(NewHTTPHandler(svc, slog.New(slog.NewTextHandler(io.Discard, nil))), r)
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := do(http.MethodGet, "/api/v1/enrichment/isbn/0-441-01359-7", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, map[string]any{
+		"isbn": "9780441013593", "source": "openlibrary", "title": "Dune", "authors": []any{"Frank Herbert"},
+		"page_count": 528.0, "release_date": "2005-08-02", "subjects": []any{},
+	}, got)
+
+	w = do(http.MethodPost, "/api/v1/books?enrich=true&require_enrichment=true", `{"isbn":"9780441013593"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"title":"Dune"`)
+	assert.Equal(t, 1, src.calls, "the create reuses the previewed answer")
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/api/v1/enrichment/isbn/9780441013590", "").Code)
+	src.err = errNotFound
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/v1/enrichment/isbn/9780134494166", "").Code)
+	src.err = errors.New("status 503")
+	assert.Equal(t, http.StatusBadGateway, do(http.MethodGet, "/api/v1/enrichment/isbn/9780596007126", "").Code)
+}
@@ -0,0 +1,97 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+func (h *HTTPHandler) ListEscalationPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.Svc.ListEscalationPolicies(r.Context())
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list escalation policies failed")
+		return
+	}
+	out := api.EscalationPolicyList{Data: make([]api.EscalationPolicy, 0, len(policies))}
+	for _, p := range policies {
+		out.Data = append(out.Data, fromDomainEscalationPolicy(p))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) CreateEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	var in api.EscalationPolicyCreate
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	p := model.EscalationPolicy{Action: model.EscalationAction(in.Action), AfterDays: in.AfterDays}
+	if in.Name != nil {
+		p.Name = *in.Name
+	}
+	p, err := h.Svc.CreateEscalationPolicy(r.Context(), p)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("create escalation policy failed")
+		return
+	}
+	h.logFor(r).Info("escalation policy created", "policy", p.ID, "action", p.Action, "after-days", p.AfterDays)
+	writeJSON(w, http.StatusCreated, fromDomainEscalationPolicy(p))
+}
+
+func (h *HTTPHandler) DeleteEscalationPolicy(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.Svc.DeleteEscalationPolicy(r.Context(), id); err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("delete escalation policy failed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HTTPHandler) ListEscalationRuns(w http.ResponseWriter, r *http.Request, id string) {
+	runs, err := h.Svc.EscalationRuns(r.Context(), id)
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list escalation runs failed")
+		return
+	}
+	out := api.EscalationRunList{Data: make([]api.EscalationRun, 0, len(runs))}
+	for _, run := range runs {
+		out.Data = append(out.Data, api.EscalationRun{
+			PolicyId: run.PolicyID,
+			LoanId:   run.LoanID,
+			BookId:   run.BookID,
+			Borrower: run.Borrower,
+			Action:   api.EscalationAction(run.Action),
+			At:       run.At.UTC(),
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) RunEscalations(w http.ResponseWriter, r *http.Request) {
+	n, err := h.Svc.RunEscalations(r.Context(), time.Now())
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("loan escalation failed", "applied", n)
+		return
+	}
+	h.logFor(r).Info("loan escalation run", "applied", n)
+	writeJSON(w, http.StatusOK, api.EscalationRunResult{Applied: n})
+}
+
+func fromDomainEscalationPolicy(p model.EscalationPolicy) api.EscalationPolicy {
+	return api.EscalationPolicy{
+		Id:        p.ID,
+		Name:      strPtrOrNil(p.Name),
+		Action:    api.EscalationAction(p.Action),
+		AfterDays: p.AfterDays,
+		CreatedAt: p.CreatedAt.UTC(),
+	}
+}
@@ -0,0 +1,74 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscalationHTTP(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Loans = NewLoanRepo()
+	svc.Escalations = NewEscalationRepo()
+	ctx := context.Background()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/admin/escalation-policies", `{"action":"mark_lost","after_days":-2}`)
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	w = do(http.MethodPost, "/api/v1/admin/escalation-policies", `{"name":"lost","action":"mark_lost","after_days":0}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var policy api.EscalationPolicy
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&policy))
+	assert.Equal(t, api.MarkLost, policy.Action)
+
+	w = do(http.MethodGet, "/api/v1/admin/escalation-policies", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var policies api.EscalationPolicyList
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&policies))
+	assert.Len(t, policies.Data, 1)
+
+	l, err := svc.CheckoutBook(ctx, b.ID, "card-1", util.GetPtr(time.Now().AddDate(0, 0, 1)))
+	require.NoError(t, err)
+	w = do(http.MethodPost, "/api/v1/admin/escalation-policies/run", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"applied":0}`, w.Body.String(), "not due yet")
+	_, err = svc.RunEscalations(ctx, l.DueAt)
+	require.NoError(t, err)
+
+	w = do(http.MethodGet, "/api/v1/loans?status=lost", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var loans api.LoanList
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&loans))
+	require.Len(t, loans.Data, 1)
+	assert.NotNil(t, loans.Data[0].LostAt)
+
+	w = do(http.MethodDelete, "/api/v1/admin/escalation-policies/"+policy.Id, "")
+	require.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, "/api/v1/admin/escalation-policies/"+policy.Id, "").Code)
+	w = do(http.MethodGet, "/api/v1/admin/escalation-policies/"+policy.Id+"/runs", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var runs api.EscalationRunList
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&runs))
+	require.Len(t, runs.Data, 1)
+	assert.Equal(t, l.ID, runs.Data[0].LoanId)
+	assert.Equal(t, "card-1", runs.Data[0].Borrower)
+}
@@ -0,0 +1,125 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// A book's ETag has two parts: the version tag, derived from its ID and
+// version, which every write to the book bumps, and a hash of the whole
+// representation served, so that derived fields (lending status, ratings)
+// and the format asked for (plain JSON, HAL, JSON:API) change it too.
+// If-None-Match compares the whole tag, so a cached book is never served
+// stale; If-Match compares only the version tag, so a change to a derived
+// field does not fail a write. A page's ETag is a hash of the whole page.
+// Responses carry Vary: Accept.
+
+// versionTag identifies the stored version of a book.
+func versionTag(id string, version int) string {
+	return hashOf(fmt.Sprintf("%s/%d", id, version))
+}
+
+func (h *HTTPHandler) bookETag(r *http.Request, b api.Book) string {
+	return `"` + versionTag(b.Id, b.Version) + "-" + hashOf(h.representation(r, b)) + `"`
+}
+
+func (h *HTTPHandler) pageETag(r *http.Request, p api.PaginatedBooks) string {
+	return `"` + hashOf(h.representation(r, p)) + `"`
+}
+
+// representation is v as served to r: the format is part of it, links are
+// derived from v.
+func (h *HTTPHandler) representation(r *http.Request, v any) any {
+	return struct {
+		JSONAPI, HAL, Links bool
+		Body                any
+	}{wantsJSONAPI(r), accepts(r, halMediaType), h.wantsLinks(r), v}
+}
+
+func hashOf(v any) string {
+	raw, _ := json.Marshal(v)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:16])
+}
+
+// notModified sets the ETag header and, when If-None-Match lists it,
+// answers 304 and reports true.
+func notModified(w http.ResponseWriter, ifNoneMatch *string, etag string) bool {
+	w.Header().Set("ETag", etag)
+	w.Header().Add("Vary", "Accept")
+	if ifNoneMatch == nil || !etagListed(*ifNoneMatch, etag, true) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// preconditionOK checks If-Match against the stored book and writes the
+// error response when it does not hold. It returns the version of the
+// matched book, so an update can make the check atomic; a delete is not
+// protected against a change made right after the check.
+func (h *HTTPHandler) preconditionOK(w http.ResponseWriter, r *http.Request, id string, ifMatch *string) (*int, bool) {
+	if ifMatch == nil {
+		return nil, true
+	}
+	b, err := h.Svc.GetBook(r.Context(), id)
+	if err != nil {
+		writeErrFor(w, r, http.StatusNotFound, "NOT_FOUND", "book not found", nil)
+		h.logFor(r).With("error", err).Info("precondition check failed")
+		return nil, false
+	}
+	etag := h.bookETag(r, fromDomainBook(b))
+	if !versionListed(*ifMatch, versionTag(b.ID, b.Version)) {
+		w.Header().Set("ETag", etag)
+		writeErrFor(w, r, http.StatusPreconditionFailed, "PRECONDITION_FAILED", "book changed since the given ETag", map[string]any{"etag": etag})
+		h.logFor(r).Info("stale If-Match", "book-id", id)
+		return nil, false
+	}
+	return &b.Version, true
+}
+
+// versionListed reports whether header, a comma-separated list of entity
+// tags or "*", contains a strong book ETag of the version tagged version.
+func versionListed(header, version string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || t == `"`+version+`"` || strings.HasPrefix(t, `"`+version+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// etagListed reports whether header, a comma-separated list of entity tags
+// or "*", contains etag. Weak tags match only in weak comparison, as used by
+// If-None-Match.
+func etagListed(header, etag string, weak bool) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" {
+			return true
+		}
+		if strings.HasPrefix(t, "W/") {
+			if !weak {
+				continue
+			}
+			t = t[2:]
+		}
+		if t == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// writeBookETag writes a book response with its ETag.
+func (h *HTTPHandler) writeBookETag(w http.ResponseWriter, r *http.Request, status int, b api.Book) {
+	w.Header().Set("ETag", h.bookETag(r, b))
+	w.Header().Add("Vary", "Accept")
+	h.writeBook(w, r, status, b)
+}
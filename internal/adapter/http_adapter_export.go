@@ -0,0 +1,210 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// bookExporter writes the catalog in one export format, one book at a time.
+type bookExporter interface {
+	begin() error
+	book(b model.Book) error
+	end() error
+}
+
+type exportFormat struct {
+	contentType string
+	ext         string
+	open        func(w io.Writer, loc *time.Location) bookExporter
+}
+
+var exportFormats = map[string]exportFormat{
+	"csv":      {"text/csv; charset=utf-8", "csv", newCSVExporter},
+	"markdown": {"text/markdown; charset=utf-8", "md", newMarkdownExporter},
+	"org":      {"text/org; charset=utf-8", "org", newOrgExporter},
+}
+
+func (h *HTTPHandler) ExportBooks(w http.ResponseWriter, r *http.Request, p api.ExportBooksParams) {
+	name := "csv"
+	if p.Format != nil {
+		name = *p.Format
+	}
+	format, ok := exportFormats[name]
+	if !ok {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "unsupported export format", map[string]any{"format": name})
+		return
+	}
+	loc, err := timeZone(p.Tz)
+	if err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", err.Error(), map[string]any{"tz": *p.Tz})
+		return
+	}
+	q := toListQuery(api.ListBooksParams{Q: p.Q, Author: p.Author, Year: p.Year, YearFrom: p.YearFrom, YearTo: p.YearTo, UpdatedSince: p.UpdatedSince, Tag: p.Tag, IncludeChildren: p.IncludeChildren, Branch: p.Branch, Barcode: p.Barcode, Available: p.Available, Sort: p.Sort, IncludeDeleted: p.IncludeDeleted})
+
+	w.Header().Set("Content-Type", format.contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="books.`+format.ext+`"`)
+	bw := bufio.NewWriter(w)
+	ex := format.open(bw, loc)
+	n := 0
+	err = ex.begin()
+	if err == nil {
+		err = h.Svc.ExportBooks(r.Context(), q, func(b model.Book) error {
+			n++
+			return ex.book(b)
+		})
+	}
+	if err == nil {
+		err = ex.end()
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		// the status line is gone by now; the client sees a truncated body
+		h.logFor(r).With("error", err).Warn("export books failed", "format", name, "rows", n)
+		return
+	}
+	h.logFor(r).Info("export request processed", "format", name, "rows", n)
+}
+
+// timeZone is the location named by the tz parameter, UTC when it is
+// absent. "Local" is refused: the output must not depend on the server.
+func timeZone(tz *string) (*time.Location, error) {
+	if tz == nil || *tz == "" {
+		return time.UTC, nil
+	}
+	if *tz == "Local" {
+		return nil, fmt.Errorf("unknown time zone %q", *tz)
+	}
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", *tz)
+	}
+	return loc, nil
+}
+
+type csvExporter struct {
+	w   *csv.Writer
+	loc *time.Location
+}
+
+func newCSVExporter(w io.Writer, loc *time.Location) bookExporter {
+	return csvExporter{csv.NewWriter(w), loc}
+}
+
+func (e csvExporter) begin() error { return e.w.Write(csvColumns) }
+
+func (e csvExporter) book(b model.Book) error { return e.w.Write(toCSVRecord(b, e.loc)) }
+
+func (e csvExporter) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// markdownExporter writes a GitHub-flavoured Markdown table.
+type markdownExporter struct{ w io.Writer }
+
+func newMarkdownExporter(w io.Writer, _ *time.Location) bookExporter { return markdownExporter{w} }
+
+func (e markdownExporter) begin() error {
+	_, err := io.WriteString(e.w, "| Title | Authors | Year | ISBN | Tags |\n| --- | --- | --- | --- | --- |\n")
+	return err
+}
+
+func (e markdownExporter) book(b model.Book) error {
+	title := b.Title
+	if b.Subtitle != nil {
+		title += ": " + *b.Subtitle
+	}
+	_, err := fmt.Fprintf(e.w, "| %s | %s | %s | %s | %s |\n",
+		mdCell(title), mdCell(strings.Join(b.Authors, ", ")), itoaOrEmpty(b.PublishedYear),
+		mdCell(deref(b.ISBN)), mdCell(strings.Join(b.Tags, ", ")))
+	return err
+}
+
+func (e markdownExporter) end() error { return nil }
+
+// mdCell keeps a value inside its table cell.
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// orgExporter writes one top-level heading per book with its details as
+// properties and its tags as Org tags.
+type orgExporter struct {
+	w   io.Writer
+	loc *time.Location
+}
+
+func newOrgExporter(w io.Writer, loc *time.Location) bookExporter { return orgExporter{w, loc} }
+
+func (e orgExporter) begin() error {
+	_, err := io.WriteString(e.w, "#+TITLE: Books\n\n")
+	return err
+}
+
+func (e orgExporter) book(b model.Book) error {
+	var sb strings.Builder
+	sb.WriteString("* ")
+	sb.WriteString(orgLine(b.Title))
+	if b.Subtitle != nil {
+		sb.WriteString(": " + orgLine(*b.Subtitle))
+	}
+	if tags := orgTags(b.Tags); tags != "" {
+		sb.WriteString(" " + tags)
+	}
+	sb.WriteString("\n  :PROPERTIES:\n")
+	prop := func(k, v string) {
+		if v != "" {
+			fmt.Fprintf(&sb, "  :%s: %s\n", k, orgLine(v))
+		}
+	}
+	prop("ID", b.ID)
+	prop("CREATED", b.CreatedAt.In(e.loc).Format(time.RFC3339))
+	prop("UPDATED", b.UpdatedAt.In(e.loc).Format(time.RFC3339))
+	prop("AUTHORS", strings.Join(b.Authors, ", "))
+	prop("YEAR", itoaOrEmpty(b.PublishedYear))
+	prop("PAGES", itoaOrEmpty(b.PageCount))
+	prop("ISBN", deref(b.ISBN))
+	prop("COVER", deref(b.CoverURL))
+	sb.WriteString("  :END:\n")
+	_, err := io.WriteString(e.w, sb.String())
+	return err
+}
+
+func (e orgExporter) end() error { return nil }
+
+func orgLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// orgTags renders tags as ":a:b:". Org tags allow only letters, digits and
+// _@#%, so other characters become underscores.
+func orgTags(tags []string) string {
+	var out []string
+	for _, t := range tags {
+		t = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_@#%", r) {
+				return r
+			}
+			return '_'
+		}, t)
+		if t != "" {
+			out = append(out, t)
+		}
+	}
+	if len(out) == 0 {
+		return ""
+	}
+	return ":" + strings.Join(out, ":") + ":"
+}
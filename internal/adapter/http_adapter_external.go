@@ -0,0 +1,63 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+)
+
+func (h *HTTPHandler) SearchExternal(w http.ResponseWriter, r *http.Request, p api.SearchExternalParams) {
+	limit := 0
+	if p.Limit != nil {
+		limit = *p.Limit
+	}
+	books, err := h.Svc.SearchExternal(r.Context(), p.Q, limit)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("external search failed")
+		return
+	}
+	out := api.ExternalBookList{Data: make([]api.ExternalBook, len(books))}
+	for i, b := range books {
+		out.Data[i] = api.ExternalBook{
+			Key:      b.Key,
+			Title:    b.Title,
+			Authors:  append([]string{}, b.Authors...),
+			Isbns:    append([]string{}, b.ISBNs...),
+			CoverUrl: strPtrOrNil(b.CoverURL),
+		}
+		if b.FirstPublishYear != 0 {
+			out.Data[i].FirstPublishYear = &b.FirstPublishYear
+		}
+	}
+	h.logFor(r).Info("external search processed", "hits", len(books))
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) ImportExternalBook(w http.ResponseWriter, r *http.Request) {
+	var in api.ExternalImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	ref := model.ExternalImport{Key: in.Key}
+	if in.Isbn != nil {
+		ref.ISBN = *in.Isbn
+	}
+	if in.Tags != nil {
+		ref.Tags = *in.Tags
+	}
+	b, err := h.Svc.ImportExternal(r.Context(), ref)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("external import failed")
+		return
+	}
+	w.Header().Set("Location", "/api/v1/books/"+b.ID)
+	h.logFor(r).Info("external book imported", "book-id", b.ID, "key", in.Key)
+	h.writeBook(w, r, http.StatusCreated, fromDomainBook(b))
+}
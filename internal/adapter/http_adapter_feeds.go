@@ -0,0 +1,82 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LoansFeed serves the due dates of active loans as an iCalendar feed
+// (RFC 5545), one all-day event per loan. The event UID is the loan ID, so
+// a calendar app drops the event once the loan is returned.
+func (h *HTTPHandler) LoansFeed(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().UTC()
+	loans, err := h.Svc.ListLoans(r.Context(), model.LoanQuery{Status: model.LoanActive, Now: now})
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("loans feed failed")
+		return
+	}
+	titles := map[string]string{}
+	var buf bytes.Buffer
+	icsLine(&buf, "BEGIN:VCALENDAR")
+	icsLine(&buf, "VERSION:2.0")
+	icsLine(&buf, "PRODID:-//book-manager//loans//EN")
+	icsLine(&buf, "CALSCALE:GREGORIAN")
+	icsLine(&buf, "METHOD:PUBLISH")
+	icsLine(&buf, "X-WR-CALNAME:Loans due")
+	for _, l := range loans {
+		title, ok := titles[l.BookID]
+		if !ok {
+			// a book deleted while on loan is named by its ID
+			title = l.BookID
+			if b, err := h.Svc.GetBook(r.Context(), l.BookID); err == nil {
+				title = b.Title
+			}
+			titles[l.BookID] = title
+		}
+		due := l.DueAt.UTC()
+		icsLine(&buf, "BEGIN:VEVENT")
+		icsLine(&buf, "UID:"+l.ID+"@book-manager")
+		icsLine(&buf, "DTSTAMP:"+l.CheckedOutAt.UTC().Format("20060102T150405Z"))
+		icsLine(&buf, "DTSTART;VALUE=DATE:"+due.Format("20060102"))
+		icsLine(&buf, "DTEND;VALUE=DATE:"+due.AddDate(0, 0, 1).Format("20060102"))
+		icsLine(&buf, "SUMMARY:"+icsText(fmt.Sprintf("Due: %s (%s)", title, l.Borrower)))
+		desc := fmt.Sprintf("Book %s lent to %s on %s.", l.BookID, l.Borrower, l.CheckedOutAt.UTC().Format(time.DateOnly))
+		if l.Barcode != "" {
+			desc += " Copy " + l.Barcode + "."
+		}
+		icsLine(&buf, "DESCRIPTION:"+icsText(desc))
+		icsLine(&buf, "END:VEVENT")
+	}
+	icsLine(&buf, "END:VCALENDAR")
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
+
+// icsText escapes a TEXT value.
+func icsText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// icsLine writes a content line, folded so no line exceeds 75 octets
+// without splitting a UTF-8 sequence.
+func icsLine(buf *bytes.Buffer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // continuation lines start with a space
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
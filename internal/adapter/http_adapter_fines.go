@@ -0,0 +1,76 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"math"
+	"net/http"
+)
+
+func (h *HTTPHandler) GetLoanFine(w http.ResponseWriter, r *http.Request, id string) {
+	days, fine, err := h.Svc.LoanFine(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("get loan fine failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, api.LoanFine{LoanId: id, DaysLate: days, Amount: fromDomainPrice(fine)})
+}
+
+func (h *HTTPHandler) GetBorrowerFees(w http.ResponseWriter, r *http.Request, borrower string) {
+	a, err := h.Svc.FeeAccount(r.Context(), borrower)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("get borrower fees failed")
+		return
+	}
+	out := api.FeeAccount{Borrower: a.Borrower, Balance: fromDomainPrice(a.Balance), Entries: make([]api.FeeEntry, 0, len(a.Entries))}
+	for _, e := range a.Entries {
+		out.Entries = append(out.Entries, fromDomainFeeEntry(e))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) RecordBorrowerFee(w http.ResponseWriter, r *http.Request, borrower string) {
+	var in api.FeeEntryCreate
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	e := model.FeeEntry{
+		Borrower: borrower,
+		Kind:     model.FeeKind(in.Kind),
+		Amount:   model.Price{Amount: int64(math.Round(in.Amount * 100))},
+	}
+	if in.LoanId != nil {
+		e.LoanID = *in.LoanId
+	}
+	if in.Note != nil {
+		e.Note = *in.Note
+	}
+	e, err := h.Svc.RecordFee(r.Context(), e)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("record fee failed")
+		return
+	}
+	h.logFor(r).Info("fee recorded", "kind", e.Kind, "entry", e.ID)
+	writeJSON(w, http.StatusCreated, fromDomainFeeEntry(e))
+}
+
+func fromDomainFeeEntry(e model.FeeEntry) api.FeeEntry {
+	return api.FeeEntry{
+		Id:       e.ID,
+		Borrower: e.Borrower,
+		Kind:     api.FeeKind(e.Kind),
+		Amount:   fromDomainPrice(e.Amount),
+		LoanId:   strPtrOrNil(e.LoanID),
+		Note:     strPtrOrNil(e.Note),
+		At:       e.At.UTC(),
+	}
+}
@@ -0,0 +1,62 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinesHTTP(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Loans = NewLoanRepo()
+	ctx := context.Background()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/v1/borrowers/card-1/fees", "").Code, "fines are off")
+
+	svc.Fines = &model.FinePolicy{PerDay: model.Price{Amount: 50, Currency: "EUR"}}
+	svc.Fees = NewFeeRepo()
+	now := time.Now().UTC()
+	l, err := svc.Loans.Checkout(ctx, model.Loan{ID: "l-1", BookID: b.ID, Borrower: "card-1", CheckedOutAt: now.AddDate(0, 0, -5), DueAt: now.Add(-47 * time.Hour)}, 1)
+	require.NoError(t, err)
+
+	w := do(http.MethodGet, "/api/v1/loans/"+l.ID+"/fine", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"loan_id":"l-1","days_late":2,"amount":{"amount":1,"currency":"EUR"}}`, w.Body.String())
+	require.Equal(t, http.StatusOK, do(http.MethodPost, "/api/v1/loans/"+l.ID+"/return", "").Code)
+
+	w = do(http.MethodPost, "/api/v1/borrowers/card-1/fees", `{"kind":"payment","amount":0}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do(http.MethodPost, "/api/v1/borrowers/card-1/fees", `{"kind":"waiver","amount":2}`)
+	assert.Equal(t, http.StatusConflict, w.Code, "more than owed")
+	w = do(http.MethodPost, "/api/v1/borrowers/card-1/fees", `{"kind":"payment","amount":0.4,"note":"cash"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = do(http.MethodGet, "/api/v1/borrowers/card-1/fees", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var a api.FeeAccount
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&a))
+	assert.Equal(t, api.Price{Amount: 0.6, Currency: "EUR"}, a.Balance)
+	require.Len(t, a.Entries, 2)
+	assert.Equal(t, api.Fine, a.Entries[0].Kind)
+	assert.Equal(t, "l-1", *a.Entries[0].LoanId)
+	assert.Equal(t, api.Payment, a.Entries[1].Kind)
+}
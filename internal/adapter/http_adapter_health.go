@@ -0,0 +1,16 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"net/http"
+)
+
+func (h *HTTPHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
+	if err := h.Svc.Health(r.Context()); err != nil {
+		h.logFor(r).With("error", err).Warn("health check failed")
+		msg := err.Error()
+		writeJSON(w, http.StatusServiceUnavailable, api.Health{Status: "unavailable", Error: &msg})
+		return
+	}
+	writeJSON(w, http.StatusOK, api.Health{Status: "ok"})
+}
@@ -0,0 +1,71 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+)
+
+func (h *HTTPHandler) AddBookItem(w http.ResponseWriter, r *http.Request, id string) {
+	var in api.ItemCreate
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	it := model.Item{Barcode: in.Barcode}
+	if in.Branch != nil {
+		it.Branch = *in.Branch
+	}
+	if in.Location != nil {
+		it.Location = *in.Location
+	}
+	if in.Condition != nil {
+		it.Condition = model.ItemCondition(*in.Condition)
+	}
+	b, err := h.Svc.AddItem(r.Context(), id, it)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("add item failed")
+		return
+	}
+	h.logFor(r).Info("item added", "book", id, "barcode", it.Barcode)
+	h.writeBook(w, r, http.StatusCreated, fromDomainBook(b))
+}
+
+func (h *HTTPHandler) UpdateBookItem(w http.ResponseWriter, r *http.Request, id string, barcode string) {
+	var in api.ItemPatch
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	p := model.ItemPatch{Branch: in.Branch, Location: in.Location}
+	if in.Condition != nil {
+		c := model.ItemCondition(*in.Condition)
+		p.Condition = &c
+	}
+	b, err := h.Svc.UpdateItem(r.Context(), id, barcode, p)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("update item failed")
+		return
+	}
+	h.logFor(r).Info("item updated", "book", id, "barcode", barcode)
+	h.writeBook(w, r, http.StatusOK, fromDomainBook(b))
+}
+
+func (h *HTTPHandler) RemoveBookItem(w http.ResponseWriter, r *http.Request, id string, barcode string) {
+	b, err := h.Svc.RemoveItem(r.Context(), id, barcode)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("remove item failed")
+		return
+	}
+	h.logFor(r).Info("item removed", "book", id, "barcode", barcode)
+	h.writeBook(w, r, http.StatusOK, fromDomainBook(b))
+}
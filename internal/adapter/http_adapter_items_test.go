@@ -0,0 +1,84 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemsHTTP(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Loans = NewLoanRepo()
+	ctx := context.Background()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	for _, title := range []string{"Emma", "Ulysses"} {
+		_, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr(title)})
+		require.NoError(t, err)
+	}
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/books/"+b.ID+"/items", `{"barcode":"31234000001","location":"PS3561","condition":"fair"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var book api.Book
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&book))
+	require.NotNil(t, book.Items)
+	require.Len(t, *book.Items, 1)
+	assert.Equal(t, api.ItemConditionFair, (*book.Items)[0].Condition)
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/api/v1/books/"+b.ID+"/items", `{"barcode":"31234000001"}`).Code)
+
+	_, err = svc.CheckoutBook(ctx, b.ID, "card-1", nil)
+	require.NoError(t, err)
+	w = do(http.MethodGet, "/api/v1/books/"+b.ID, "")
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&book))
+	assert.Equal(t, util.GetPtr(true), (*book.Items)[0].OnLoan)
+
+	var page api.PaginatedBooks
+	w = do(http.MethodGet, "/api/v1/books?available=false", "")
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, b.ID, page.Data[0].Id)
+
+	w = do(http.MethodGet, "/api/v1/books?available=true&page_size=1&sort=title", "")
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "Emma", page.Data[0].Title)
+	require.NotNil(t, page.NextCursor)
+	w = do(http.MethodGet, "/api/v1/books?cursor="+*page.NextCursor, "")
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "Ulysses", page.Data[0].Title, "the cursor keeps the filter")
+
+	w = do(http.MethodGet, "/api/v1/books?updated_since=2000-01-01T00:00:00Z&year_from=2000", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	assert.Empty(t, page.Data, "no book has a year")
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/api/v1/books?updated_since=yesterday", "").Code)
+
+	w = do(http.MethodPatch, "/api/v1/books/"+b.ID+"/items/31234000001", `{"location":"PS3562","condition":"poor"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&book))
+	assert.Equal(t, util.GetPtr("PS3562"), (*book.Items)[0].Location)
+	assert.Equal(t, api.ItemConditionPoor, (*book.Items)[0].Condition)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPatch, "/api/v1/books/"+b.ID+"/items/31234000001", `{"condition":"missing"}`).Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodPatch, "/api/v1/books/"+b.ID+"/items/nope", `{}`).Code)
+
+	assert.Equal(t, http.StatusConflict, do(http.MethodDelete, "/api/v1/books/"+b.ID+"/items/31234000001", "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, "/api/v1/books/"+b.ID+"/items/nope", "").Code)
+}
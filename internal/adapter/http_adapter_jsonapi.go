@@ -0,0 +1,224 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// JSON:API (https://jsonapi.org) is an alternative serialization of book
+// responses, chosen by clients that send Accept: application/vnd.api+json.
+// Attributes are the v1 book fields minus id and authors, which become the
+// resource id and an "authors" relationship with the authors included.
+const jsonAPIMediaType = "application/vnd.api+json"
+
+type jsonAPIDocument struct {
+	Data     any               `json:"data"`
+	Included []jsonAPIResource `json:"included,omitempty"`
+	Meta     map[string]any    `json:"meta,omitempty"`
+	Links    map[string]string `json:"links,omitempty"`
+}
+
+type jsonAPIResource struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id"`
+	Attributes    map[string]any                 `json:"attributes,omitempty"`
+	Relationships map[string]jsonAPIRelationship `json:"relationships,omitempty"`
+	Links         map[string]string              `json:"links,omitempty"`
+}
+
+type jsonAPIIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type jsonAPIRelationship struct {
+	Data []jsonAPIIdentifier `json:"data"`
+}
+
+type jsonAPIErrors struct {
+	Errors []jsonAPIError `json:"errors"`
+}
+
+type jsonAPIError struct {
+	ID     string         `json:"id,omitempty"` // the request ID
+	Status string         `json:"status"`
+	Code   string         `json:"code"`
+	Title  string         `json:"title"`
+	Meta   map[string]any `json:"meta,omitempty"`
+}
+
+func wantsJSONAPI(r *http.Request) bool {
+	return accepts(r, jsonAPIMediaType)
+}
+
+// accepts reports whether the Accept header lists media type mt.
+func accepts(r *http.Request, mt string) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		if got, _, err := mime.ParseMediaType(strings.TrimSpace(part)); err == nil && got == mt {
+			return true
+		}
+	}
+	return false
+}
+
+// writeBook writes a single book in the format the client asked for.
+func (h *HTTPHandler) writeBook(w http.ResponseWriter, r *http.Request, status int, b api.Book) {
+	if !wantsJSONAPI(r) {
+		if h.wantsLinks(r) {
+			b.Links = bookLinks(b)
+		}
+		writeJSONFor(w, r, status, b)
+		return
+	}
+	res, included := toJSONAPIBook(b)
+	writeJSONAPI(w, status, jsonAPIDocument{Data: res, Included: included})
+}
+
+// writeBookPage writes a page of books in the format the client asked for.
+func (h *HTTPHandler) writeBookPage(w http.ResponseWriter, r *http.Request, p api.PaginatedBooks) {
+	if !wantsJSONAPI(r) {
+		if h.wantsLinks(r) {
+			for i := range p.Data {
+				p.Data[i].Links = bookLinks(p.Data[i])
+			}
+		}
+		writeJSONFor(w, r, http.StatusOK, p)
+		return
+	}
+	doc := jsonAPIDocument{
+		Meta:  map[string]any{"page": p.Page, "page_size": p.PageSize},
+		Links: pageLinks(r, p),
+	}
+	if p.Total != nil {
+		doc.Meta["total"] = *p.Total
+	}
+	if p.SnapshotId != nil {
+		doc.Meta["snapshot_id"] = *p.SnapshotId
+	}
+	if p.NextCursor != nil {
+		doc.Meta["next_cursor"] = *p.NextCursor
+	}
+	data := make([]jsonAPIResource, 0, len(p.Data))
+	seen := make(map[string]bool)
+	for _, b := range p.Data {
+		res, included := toJSONAPIBook(b)
+		data = append(data, res)
+		for _, inc := range included {
+			if !seen[inc.ID] {
+				seen[inc.ID] = true
+				doc.Included = append(doc.Included, inc)
+			}
+		}
+	}
+	doc.Data = data
+	writeJSONAPI(w, http.StatusOK, doc)
+}
+
+// writeErrFor writes an error in the format the client asked for.
+func writeErrFor(w http.ResponseWriter, r *http.Request, status int, code, msg string, det map[string]any) {
+	if !wantsJSONAPI(r) {
+		writeErr(w, status, code, msg, det)
+		return
+	}
+	writeJSONAPI(w, status, jsonAPIErrors{Errors: []jsonAPIError{{
+		ID:     w.Header().Get(RequestIDHeader),
+		Status: strconv.Itoa(status),
+		Code:   code,
+		Title:  msg,
+		Meta:   det,
+	}}})
+}
+
+func writeJSONAPI(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", jsonAPIMediaType)
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false) // keep '&' readable in links
+	_ = enc.Encode(v)
+}
+
+func toJSONAPIBook(b api.Book) (jsonAPIResource, []jsonAPIResource) {
+	res := jsonAPIResource{
+		Type:       "books",
+		ID:         b.Id,
+		Attributes: attributesOf(b),
+		Links:      map[string]string{"self": "/api/v1/books/" + b.Id},
+	}
+	delete(res.Attributes, "id")
+
+	rel := jsonAPIRelationship{Data: []jsonAPIIdentifier{}}
+	var included []jsonAPIResource
+	linked := true
+	for _, a := range b.Authors {
+		if a.Id == "" {
+			linked = false
+			continue
+		}
+		rel.Data = append(rel.Data, jsonAPIIdentifier{Type: "authors", ID: a.Id})
+		included = append(included, jsonAPIResource{
+			Type:       "authors",
+			ID:         a.Id,
+			Attributes: map[string]any{"name": a.Name},
+			Links:      map[string]string{"self": "/api/v1/authors/" + a.Id},
+		})
+	}
+	res.Relationships = map[string]jsonAPIRelationship{"authors": rel}
+	// books that are not linked to the author registry keep their names
+	if linked {
+		delete(res.Attributes, "authors")
+	}
+	return res, included
+}
+
+// attributesOf returns the JSON fields of v as a map.
+func attributesOf(v any) map[string]any {
+	var m map[string]any
+	data, _ := json.Marshal(v)
+	_ = json.Unmarshal(data, &m)
+	return m
+}
+
+func pageLinks(r *http.Request, p api.PaginatedBooks) map[string]string {
+	size := p.PageSize
+	link := func(page int) string {
+		q := r.URL.Query()
+		q.Del("cursor")
+		q.Set("page", strconv.Itoa(page))
+		q.Set("page_size", strconv.Itoa(size))
+		return r.URL.Path + "?" + q.Encode()
+	}
+	if r.URL.Query().Get("cursor") != "" {
+		// cursor pages have no number; only first and next make sense
+		links := map[string]string{"self": r.URL.RequestURI(), "first": link(1)}
+		if p.NextCursor != nil {
+			q := url.Values{"cursor": {*p.NextCursor}, "page_size": {strconv.Itoa(size)}}
+			links["next"] = r.URL.Path + "?" + q.Encode()
+		}
+		return links
+	}
+	links := map[string]string{"self": link(p.Page), "first": link(1)}
+	if p.Total == nil {
+		// uncounted: there is a next page exactly when there is a cursor to it
+		if p.Page > 1 {
+			links["prev"] = link(p.Page - 1)
+		}
+		if p.NextCursor != nil {
+			links["next"] = link(p.Page + 1)
+		}
+		return links
+	}
+	last := max(1, (*p.Total+size-1)/size)
+	links["last"] = link(last)
+	if p.Page > 1 {
+		links["prev"] = link(min(p.Page-1, last))
+	}
+	if p.Page < last {
+		links["next"] = link(p.Page + 1)
+	}
+	return links
+}
@@ -0,0 +1,134 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+func (h *HTTPHandler) KioskScanCard(w http.ResponseWriter, r *http.Request) {
+	var in api.KioskCard
+	if !h.decodeKiosk(w, r, &in) {
+		return
+	}
+	b, err := h.Svc.KioskBorrower(r.Context(), in.Card)
+	if err != nil {
+		h.logFor(r).With("error", err).Info("kiosk card scan failed")
+		writeJSON(w, http.StatusOK, kioskRefusal(err, "Card not recognised. Please ask at the desk.", ""))
+		return
+	}
+	out := fromDomainBorrower(b)
+	writeJSON(w, http.StatusOK, api.KioskReply{Ok: true, Message: "Hello, " + b.Name + ". Please scan a book.", Borrower: &out})
+}
+
+func (h *HTTPHandler) KioskScanItem(w http.ResponseWriter, r *http.Request) {
+	var in api.KioskScan
+	if !h.decodeKiosk(w, r, &in) {
+		return
+	}
+	b, err := h.Svc.KioskItem(r.Context(), in.Barcode)
+	if err != nil {
+		h.logFor(r).With("error", err).Info("kiosk book scan failed")
+		writeJSON(w, http.StatusOK, kioskRefusal(err, "Book not recognised. Please ask at the desk.",
+			"This copy is already on loan. Please ask at the desk."))
+		return
+	}
+	out := fromDomainBook(b)
+	writeJSON(w, http.StatusOK, api.KioskReply{Ok: true, Message: b.Title + ". Confirm to borrow it.", Book: &out})
+}
+
+func (h *HTTPHandler) KioskCheckout(w http.ResponseWriter, r *http.Request) {
+	var in api.KioskCheckout
+	if !h.decodeKiosk(w, r, &in) {
+		return
+	}
+	l, err := h.Svc.KioskCheckout(r.Context(), toDomainKioskCheckout(in))
+	if err != nil {
+		h.logFor(r).With("error", err).Info("kiosk checkout failed")
+		reply := kioskRefusal(err, "Card or book not recognised. Please ask at the desk.",
+			"This copy cannot be lent now. Please ask at the desk.")
+		reply.ClientId = in.ClientId
+		writeJSON(w, http.StatusOK, reply)
+		return
+	}
+	h.logFor(r).Info("book checked out at kiosk", "book", l.BookID, "loan", l.ID)
+	reply := kioskLoanReply(l)
+	reply.ClientId = in.ClientId
+	writeJSON(w, http.StatusOK, reply)
+}
+
+func (h *HTTPHandler) KioskSync(w http.ResponseWriter, r *http.Request) {
+	var in api.KioskSync
+	if !h.decodeKiosk(w, r, &in) {
+		return
+	}
+	checkouts := make([]model.KioskCheckout, 0, len(in.Checkouts))
+	for _, c := range in.Checkouts {
+		checkouts = append(checkouts, toDomainKioskCheckout(c))
+	}
+	results, err := h.Svc.SyncKiosk(r.Context(), checkouts)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("kiosk sync failed")
+		return
+	}
+	out := api.KioskSyncResult{Results: make([]api.KioskReply, 0, len(results))}
+	for _, res := range results {
+		reply := kioskLoanReply(res.Loan)
+		if res.Err != nil {
+			h.logFor(r).With("error", res.Err).Info("kiosk sync checkout failed", "client_id", res.ClientID)
+			reply = kioskRefusal(res.Err, "Card or book not recognised.", "This copy could not be lent.")
+		}
+		reply.ClientId = &res.ClientID
+		out.Results = append(out.Results, reply)
+	}
+	h.logFor(r).Info("kiosk checkouts synced", "count", len(results))
+	writeJSON(w, http.StatusOK, out)
+}
+
+// decodeKiosk reads a kiosk request body into v, answering 400 when it is
+// not JSON.
+func (h *HTTPHandler) decodeKiosk(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return false
+	}
+	return true
+}
+
+func kioskLoanReply(l model.Loan) api.KioskReply {
+	out := fromDomainLoan(l, time.Now())
+	return api.KioskReply{Ok: true, Message: "Borrowed until " + l.DueAt.Format("Monday 2 January") + ".", Loan: &out}
+}
+
+// kioskRefusal is the reply to a failed kiosk request. Kiosks get 200 but
+// for malformed JSON, and a message for the borrower instead of the
+// service error, which is logged: notFound for an unknown card or book,
+// conflict for a copy that cannot be lent.
+func kioskRefusal(err error, notFound, conflict string) api.KioskReply {
+	_, code := mapSvcErr(err)
+	msg := "Something went wrong. Please try again or ask at the desk."
+	switch code {
+	case "VALIDATION":
+		msg = "Please scan again."
+	case "NOT_FOUND":
+		msg = notFound
+	case "CONFLICT":
+		if conflict != "" {
+			msg = conflict
+		}
+	}
+	return api.KioskReply{Ok: false, Code: &code, Message: msg}
+}
+
+func toDomainKioskCheckout(in api.KioskCheckout) model.KioskCheckout {
+	c := model.KioskCheckout{Card: in.Card, Barcode: in.Barcode, ScannedAt: in.ScannedAt}
+	if in.ClientId != nil {
+		c.ClientID = *in.ClientId
+	}
+	return c
+}
@@ -0,0 +1,86 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKioskHTTP(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Loans = NewLoanRepo()
+	svc.Kiosks = NewKioskRepo()
+	ctx := context.Background()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	for _, code := range []string{"A-1", "A-2"} {
+		_, err = svc.AddItem(ctx, b.ID, model.Item{Barcode: code})
+		require.NoError(t, err)
+	}
+
+	reply := func(method, path, body string) api.KioskReply {
+		t.Helper()
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var out api.KioskReply
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&out))
+		return out
+	}
+
+	card := reply(http.MethodPost, "/api/v1/kiosk/card", `{"card":"2077"}`)
+	assert.True(t, card.Ok)
+	require.NotNil(t, card.Borrower)
+	assert.Equal(t, "2077", card.Borrower.Id, "the card names the borrower without a directory")
+
+	scan := reply(http.MethodPost, "/api/v1/kiosk/scan", `{"barcode":"A-1"}`)
+	assert.True(t, scan.Ok)
+	require.NotNil(t, scan.Book)
+	assert.Equal(t, b.ID, scan.Book.Id)
+	unknown := reply(http.MethodPost, "/api/v1/kiosk/scan", `{"barcode":"B-1"}`)
+	assert.False(t, unknown.Ok)
+	assert.Equal(t, "NOT_FOUND", *unknown.Code)
+	assert.Equal(t, "Book not recognised. Please ask at the desk.", unknown.Message)
+
+	out := reply(http.MethodPost, "/api/v1/kiosk/checkout", `{"card":"2077","barcode":"A-1","client_id":"c-1"}`)
+	require.True(t, out.Ok, out.Message)
+	require.NotNil(t, out.Loan)
+	assert.Equal(t, "A-1", *out.Loan.Barcode)
+	assert.Equal(t, "c-1", *out.ClientId)
+	onLoan := reply(http.MethodPost, "/api/v1/kiosk/scan", `{"barcode":"A-1"}`)
+	assert.False(t, onLoan.Ok)
+	assert.Equal(t, "CONFLICT", *onLoan.Code)
+
+	scanned := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/kiosk/sync", strings.NewReader(
+		`{"checkouts":[{"client_id":"c-1","card":"2077","barcode":"A-1","scanned_at":"`+scanned+`"},`+
+			`{"client_id":"c-2","card":"2077","barcode":"A-9","scanned_at":"`+scanned+`"}]}`)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var synced api.KioskSyncResult
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&synced))
+	require.Len(t, synced.Results, 2)
+	assert.True(t, synced.Results[0].Ok, "c-1 was synced already")
+	assert.Equal(t, out.Loan.Id, synced.Results[0].Loan.Id)
+	assert.False(t, synced.Results[1].Ok)
+	assert.Equal(t, "c-2", *synced.Results[1].ClientId)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/kiosk/sync", strings.NewReader(`{"checkouts":[{"card":"2077","barcode":"A-2"}]}`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/kiosk/card", strings.NewReader(`{`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,133 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+func (h *HTTPHandler) PrintLabels(w http.ResponseWriter, r *http.Request) {
+	var in api.LabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	var sel model.LabelSelection
+	if in.BookIds != nil {
+		sel.BookIDs = *in.BookIds
+	}
+	if in.Barcodes != nil {
+		sel.Barcodes = *in.Barcodes
+	}
+	layout, skip, err := labelRequestLayout(in)
+	var labels []model.Label
+	if err == nil {
+		labels, err = h.Svc.Labels(r.Context(), sel)
+	}
+	if err == nil && in.Kind == api.Barcode {
+		for _, lb := range labels {
+			if lb.Barcode == "" {
+				err = &model.FieldError{Field: "book_ids", Reason: fmt.Sprintf("book %s tracks no items to print barcodes for", lb.BookID)}
+				break
+			}
+		}
+	}
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("print labels failed")
+		return
+	}
+	var buf bytes.Buffer
+	if err := renderLabels(&buf, string(in.Kind), layout, skip, labels); err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Warn("render labels failed")
+		return
+	}
+	h.logFor(r).Info("labels printed", "kind", in.Kind, "layout", layout.Name, "labels", len(labels))
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `inline; filename="labels.pdf"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = buf.WriteTo(w)
+}
+
+// labelRequestLayout is the layout the request names or gives, and the
+// label positions it skips.
+func labelRequestLayout(in api.LabelRequest) (labelLayout, int, error) {
+	if in.Kind != api.Spine && in.Kind != api.Barcode {
+		return labelLayout{}, 0, &model.FieldError{Field: "kind", Reason: "must be spine or barcode"}
+	}
+	var l labelLayout
+	switch {
+	case in.CustomLayout != nil:
+		l = toLabelLayout(*in.CustomLayout)
+		if err := l.validate(); err != nil {
+			return labelLayout{}, 0, err
+		}
+	case in.Layout != nil:
+		var ok bool
+		if l, ok = findLabelLayout(*in.Layout); !ok {
+			return labelLayout{}, 0, &model.FieldError{Field: "layout", Reason: fmt.Sprintf("unknown layout %q; see GET /api/v1/labels/layouts", *in.Layout)}
+		}
+	default:
+		l, _ = findLabelLayout(defaultLabelLayout)
+	}
+	skip := 0
+	if in.Skip != nil {
+		skip = *in.Skip
+	}
+	if skip < 0 || skip >= l.Columns*l.Rows {
+		return labelLayout{}, 0, &model.FieldError{Field: "skip", Reason: fmt.Sprintf("must be 0 to %d, the labels on a sheet less one", l.Columns*l.Rows-1)}
+	}
+	return l, skip, nil
+}
+
+func (h *HTTPHandler) ListLabelLayouts(w http.ResponseWriter, r *http.Request) {
+	out := api.LabelLayoutList{Data: make([]api.LabelLayout, 0, len(labelLayouts))}
+	for _, l := range labelLayouts {
+		out.Data = append(out.Data, fromLabelLayout(l))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func toLabelLayout(in api.LabelLayout) labelLayout {
+	l := labelLayout{
+		Name:        "custom",
+		PageWidth:   in.PageWidthMm,
+		PageHeight:  in.PageHeightMm,
+		Columns:     in.Columns,
+		Rows:        in.Rows,
+		LabelWidth:  in.LabelWidthMm,
+		LabelHeight: in.LabelHeightMm,
+		Top:         in.TopMm,
+		Left:        in.LeftMm,
+	}
+	if in.GapXMm != nil {
+		l.GapX = *in.GapXMm
+	}
+	if in.GapYMm != nil {
+		l.GapY = *in.GapYMm
+	}
+	return l
+}
+
+func fromLabelLayout(l labelLayout) api.LabelLayout {
+	return api.LabelLayout{
+		Name:          &l.Name,
+		Description:   &l.Description,
+		PageWidthMm:   l.PageWidth,
+		PageHeightMm:  l.PageHeight,
+		Columns:       l.Columns,
+		Rows:          l.Rows,
+		LabelWidthMm:  l.LabelWidth,
+		LabelHeightMm: l.LabelHeight,
+		TopMm:         l.Top,
+		LeftMm:        l.Left,
+		GapXMm:        &l.GapX,
+		GapYMm:        &l.GapY,
+	}
+}
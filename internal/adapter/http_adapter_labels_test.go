@@ -0,0 +1,122 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelsHTTP(t *testing.T) {
+	h, svc := newServer(t)
+	ctx := context.Background()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune (1965)"), Authors: []string{"Herbert, Frank"}, PublishedYear: util.GetPtr(1965)})
+	require.NoError(t, err)
+	for _, it := range []model.Item{{Barcode: "31234000001", Location: "SF"}, {Barcode: "A-2"}} {
+		_, err = svc.AddItem(ctx, b.ID, it)
+		require.NoError(t, err)
+	}
+	bare, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Emma")})
+	require.NoError(t, err)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/labels", `{"kind":"spine","book_ids":["`+b.ID+`","`+bare.ID+`"]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	pages := pdfPages(t, w.Body.Bytes())
+	require.Len(t, pages, 1)
+	assert.Contains(t, pages[0], "(SF) Tj")
+	assert.Contains(t, pages[0], "(HER) Tj")
+	assert.Contains(t, pages[0], "(1965) Tj")
+	assert.Contains(t, pages[0], "(EMM) Tj", "the title stands in for a missing author")
+
+	w = do(http.MethodPost, "/api/v1/labels", `{"kind":"barcode","barcodes":["31234000001","A-2"],"layout":"avery-5160","skip":29}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	pages = pdfPages(t, w.Body.Bytes())
+	require.Len(t, pages, 2, "the skipped positions fill the first sheet")
+	assert.Contains(t, pages[0], "(31234000001) Tj")
+	assert.Contains(t, pages[0], `(Dune \(1965\)) Tj`)
+	assert.Contains(t, pages[0], " re f\n")
+	assert.Contains(t, pages[1], "(A-2) Tj")
+
+	for name, body := range map[string]string{
+		"no items":     `{"kind":"barcode","book_ids":["` + bare.ID + `"]}`,
+		"kind":         `{"kind":"qr","book_ids":["` + b.ID + `"]}`,
+		"layout":       `{"kind":"spine","book_ids":["` + b.ID + `"],"layout":"avery-0000"}`,
+		"skip":         `{"kind":"spine","book_ids":["` + b.ID + `"],"skip":65}`,
+		"custom":       `{"kind":"spine","book_ids":["` + b.ID + `"],"custom_layout":{"page_width_mm":100,"page_height_mm":100,"columns":3,"rows":1,"label_width_mm":40,"label_height_mm":20,"top_mm":0,"left_mm":0}}`,
+		"nothing":      `{"kind":"spine"}`,
+		"invalid JSON": `{`,
+	} {
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/v1/labels", body).Code, name)
+	}
+	assert.Equal(t, http.StatusNotFound, do(http.MethodPost, "/api/v1/labels", `{"kind":"spine","barcodes":["Z-9"]}`).Code)
+
+	w = do(http.MethodPost, "/api/v1/labels", `{"kind":"spine","book_ids":["`+b.ID+`"],"custom_layout":{"page_width_mm":100,"page_height_mm":50,"columns":2,"rows":2,"label_width_mm":45,"label_height_mm":20,"top_mm":4,"left_mm":4,"gap_x_mm":2}}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Len(t, pdfPages(t, w.Body.Bytes()), 1)
+
+	w = do(http.MethodGet, "/api/v1/labels/layouts", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var layouts api.LabelLayoutList
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&layouts))
+	require.NotEmpty(t, layouts.Data)
+	for _, l := range layouts.Data {
+		assert.NoError(t, toLabelLayout(l).validate(), *l.Name)
+	}
+}
+
+// pdfPages checks that the xref table of doc points at its objects and
+// returns the uncompressed content of its pages.
+func pdfPages(t *testing.T, doc []byte) []string {
+	t.Helper()
+	require.True(t, bytes.HasPrefix(doc, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(doc, []byte("%%EOF\n")))
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(doc)
+	require.NotNil(t, m)
+	xref, _ := strconv.Atoi(string(m[1]))
+	require.True(t, bytes.HasPrefix(doc[xref:], []byte("xref\n0 ")))
+	lines := strings.Split(string(doc[xref:]), "\n")
+	n, _ := strconv.Atoi(strings.Fields(lines[1])[1])
+	for i := 1; i < n; i++ {
+		off, err := strconv.Atoi(lines[2+i][:10])
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(doc[off:], []byte(fmt.Sprintf("%d 0 obj\n", i))), "object %d", i)
+	}
+
+	var pages []string
+	for _, s := range regexp.MustCompile(`(?s)/Length (\d+) /Filter /FlateDecode >>\nstream\n`).FindAllSubmatchIndex(doc, -1) {
+		length, _ := strconv.Atoi(string(doc[s[2]:s[3]]))
+		zr, err := zlib.NewReader(bytes.NewReader(doc[s[1] : s[1]+length]))
+		require.NoError(t, err)
+		content, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(doc[s[1]+length:], []byte("\nendstream")))
+		pages = append(pages, string(content))
+	}
+	count := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(doc)
+	require.NotNil(t, count)
+	assert.Equal(t, strconv.Itoa(len(pages)), string(count[1]))
+	return pages
+}
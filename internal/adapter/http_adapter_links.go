@@ -0,0 +1,57 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"encoding/json"
+	"net/http"
+)
+
+// halMediaType asks for book responses with _links, whether or not the
+// handler adds them by default.
+const halMediaType = "application/hal+json"
+
+const booksPath = "/api/v1/books"
+
+func (h *HTTPHandler) wantsLinks(r *http.Request) bool {
+	return h.Links || accepts(r, halMediaType)
+}
+
+// bookLinks is the single place book navigation links are built, so URL
+// templates stay in the server and out of clients.
+func bookLinks(b api.Book) *api.BookLinks {
+	links := &api.BookLinks{
+		Self:       api.Link{Href: booksPath + "/" + b.Id},
+		Collection: api.Link{Href: booksPath},
+	}
+	if b.CoverUrl != nil {
+		links.Cover = &api.Link{Href: *b.CoverUrl}
+	}
+	if b.Isbn != nil && *b.Isbn != "" {
+		post := http.MethodPost
+		links.Enrich = &api.Link{Href: booksPath + "/" + b.Id + "/enrich", Method: &post}
+	}
+	var related []api.Link
+	for _, a := range b.Authors {
+		if a.Id == "" {
+			continue // not linked to the registry
+		}
+		title := a.Name
+		related = append(related, api.Link{Href: "/api/v1/authors/" + a.Id, Title: &title})
+	}
+	if related != nil {
+		links.Related = &related
+	}
+	return links
+}
+
+// writeJSONFor writes v as JSON, labelled hal+json when the client asked
+// for that.
+func writeJSONFor(w http.ResponseWriter, r *http.Request, status int, v any) {
+	if !accepts(r, halMediaType) {
+		writeJSON(w, status, v)
+		return
+	}
+	w.Header().Set("Content-Type", halMediaType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,125 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+const (
+	// defaultLiveInterval is the least time between two counter reads for
+	// one /ws connection when HTTPHandler.LiveInterval is not set.
+	defaultLiveInterval = time.Second
+	// liveHeartbeat is how often an idle /ws connection is pinged and its
+	// counters re-read, which also rolls "added today" over at midnight.
+	liveHeartbeat = 30 * time.Second
+)
+
+// LiveCounters pushes catalog counters over a WebSocket. Each connection
+// subscribes to catalog events and re-reads the counters when one arrives,
+// at most once per LiveInterval: events in between are coalesced, so a
+// large import costs every dashboard one read per interval, not one per
+// book. Unchanged counters are not sent again.
+func (h *HTTPHandler) LiveCounters(w http.ResponseWriter, r *http.Request) {
+	c, err := upgradeWebSocket(w, r)
+	if err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("websocket handshake rejected")
+		return
+	}
+	defer c.close(wsCloseNormal)
+	events, cancel := h.Svc.Subscribe(r.Context(), 16)
+	defer cancel()
+	ctx, stop := context.WithCancel(r.Context())
+	defer stop()
+	go func() {
+		_ = c.readLoop()
+		stop()
+	}()
+	log := h.logFor(r)
+	log.Info("live counters connected")
+	defer log.Info("live counters disconnected")
+
+	interval := h.LiveInterval
+	if interval <= 0 {
+		interval = defaultLiveInterval
+	}
+	var (
+		last     api.CatalogCounters
+		sent     bool
+		lastRead time.Time
+	)
+	push := func() error {
+		lastRead = time.Now()
+		cc, err := h.Svc.CatalogCounters(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.With("error", err).Warn("reading live counters failed")
+			return nil
+		}
+		out := fromDomainCounters(cc)
+		if sent && sameCounters(out, last) {
+			return nil
+		}
+		data, err := json.Marshal(out)
+		if err != nil {
+			return err
+		}
+		if err := c.writeText(data); err != nil {
+			return err
+		}
+		last, sent = out, true
+		return nil
+	}
+	if push() != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(liveHeartbeat)
+	defer heartbeat.Stop()
+	var throttle <-chan time.Time // set while a read waits for the interval
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+			if throttle != nil {
+				continue
+			}
+			if wait := interval - time.Since(lastRead); wait > 0 {
+				throttle = time.After(wait)
+				continue
+			}
+		case <-throttle:
+			throttle = nil
+		case <-heartbeat.C:
+			if c.ping() != nil {
+				return
+			}
+		}
+		if push() != nil {
+			return
+		}
+	}
+}
+
+func fromDomainCounters(c model.CatalogCounters) api.CatalogCounters {
+	return api.CatalogCounters{
+		TotalBooks:      c.TotalBooks,
+		BooksAddedToday: c.AddedToday,
+		ActiveImports:   c.ActiveImports,
+		At:              c.At.UTC(),
+	}
+}
+
+func sameCounters(a, b api.CatalogCounters) bool {
+	return a.TotalBooks == b.TotalBooks && a.BooksAddedToday == b.BooksAddedToday && a.ActiveImports == b.ActiveImports
+}
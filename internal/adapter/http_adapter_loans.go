@@ -0,0 +1,150 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+func (h *HTTPHandler) CheckoutBook(w http.ResponseWriter, r *http.Request, id string) {
+	var in api.LoanCreate
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	l, err := h.Svc.CheckoutBook(r.Context(), id, in.Borrower, fromAPIDate(in.DueDate))
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("checkout book failed")
+		return
+	}
+	h.logFor(r).Info("book checked out", "book", id, "loan", l.ID)
+	w.Header().Set("Location", "/api/v1/loans/"+l.ID)
+	writeJSON(w, http.StatusCreated, fromDomainLoan(l, time.Now()))
+}
+
+func (h *HTTPHandler) ReturnLoan(w http.ResponseWriter, r *http.Request, id string) {
+	l, err := h.Svc.ReturnLoan(r.Context(), id)
+	if err != nil && l.ID == "" {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("return loan failed")
+		return
+	}
+	if err != nil {
+		h.logFor(r).With("error", err).Warn("charging the fine or serving holds failed", "book", l.BookID)
+	}
+	h.logFor(r).Info("loan returned", "book", l.BookID, "loan", l.ID)
+	writeJSON(w, http.StatusOK, fromDomainLoan(l, time.Now()))
+}
+
+func (h *HTTPHandler) GetLoan(w http.ResponseWriter, r *http.Request, id string) {
+	l, err := h.Svc.GetLoan(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("get loan failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainLoan(l, time.Now()))
+}
+
+func (h *HTTPHandler) ListLoans(w http.ResponseWriter, r *http.Request, p api.ListLoansParams) {
+	now := time.Now()
+	q := model.LoanQuery{Now: now}
+	if p.Status != nil {
+		q.Status = model.LoanStatus(*p.Status)
+	}
+	if p.Borrower != nil {
+		q.Borrower = *p.Borrower
+	}
+	if p.BookId != nil {
+		q.BookID = *p.BookId
+	}
+	loans, err := h.Svc.ListLoans(r.Context(), q)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("list loans failed")
+		return
+	}
+	out := api.LoanList{Data: make([]api.Loan, 0, len(loans))}
+	for _, l := range loans {
+		out.Data = append(out.Data, fromDomainLoan(l, now))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) PlaceHold(w http.ResponseWriter, r *http.Request, id string) {
+	var in api.HoldCreate
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	hold, err := h.Svc.PlaceHold(r.Context(), id, in.Borrower)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("place hold failed")
+		return
+	}
+	h.logFor(r).Info("hold placed", "book", id, "hold", hold.ID, "position", hold.Position)
+	writeJSON(w, http.StatusCreated, fromDomainHold(hold))
+}
+
+func (h *HTTPHandler) ListBookHolds(w http.ResponseWriter, r *http.Request, id string) {
+	holds, err := h.Svc.ListHolds(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list holds failed")
+		return
+	}
+	out := api.HoldList{Data: make([]api.Hold, 0, len(holds))}
+	for _, hold := range holds {
+		out.Data = append(out.Data, fromDomainHold(hold))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) CancelHold(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.Svc.CancelHold(r.Context(), id); err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("cancel hold failed")
+		return
+	}
+	h.logFor(r).Info("hold cancelled", "hold", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func fromDomainHold(h model.Hold) api.Hold {
+	return api.Hold{
+		Id:       h.ID,
+		BookId:   h.BookID,
+		Borrower: h.Borrower,
+		PlacedAt: h.PlacedAt.UTC(),
+		ReadyAt:  utcPtr(h.ReadyAt),
+		LoanId:   strPtrOrNil(h.LoanID),
+		Position: h.Position,
+	}
+}
+
+func fromDomainLoan(l model.Loan, now time.Time) api.Loan {
+	return api.Loan{
+		Id:           l.ID,
+		BookId:       l.BookID,
+		Borrower:     l.Borrower,
+		CheckedOutAt: l.CheckedOutAt.UTC(),
+		DueAt:        l.DueAt.UTC(),
+		ReturnedAt:   utcPtr(l.ReturnedAt),
+		Overdue:      l.Overdue(now),
+		Barcode:      strPtrOrNil(l.Barcode),
+		LostAt:       utcPtr(l.LostAt),
+	}
+}
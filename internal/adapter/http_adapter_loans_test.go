@@ -0,0 +1,141 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoansHTTP(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Loans = NewLoanRepo()
+	b, err := svc.CreateBook(context.Background(), model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/books/"+b.ID+"/loans", `{"borrower":"card-1","due_date":"2999-01-31"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var loan api.Loan
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&loan))
+	assert.Equal(t, "/api/v1/loans/"+loan.Id, w.Header().Get("Location"))
+	assert.Equal(t, "2999-01-31T23:59:59Z", loan.DueAt.Format("2006-01-02T15:04:05Z07:00"))
+	assert.False(t, loan.Overdue)
+
+	w = do(http.MethodPost, "/api/v1/books/"+b.ID+"/loans", `{"borrower":"card-2"}`)
+	assert.Equal(t, http.StatusConflict, w.Code, "the only copy is out")
+
+	w = do(http.MethodGet, "/api/v1/books/"+b.ID, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var book api.Book
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&book))
+	require.NotNil(t, book.Lending)
+	assert.Equal(t, 1, book.Lending.CheckedOut)
+	assert.Equal(t, 0, book.Lending.Available)
+	assert.Equal(t, loan.DueAt, *book.Lending.NextDueAt)
+
+	w = do(http.MethodGet, "/api/v1/loans?status=active&book_id="+b.ID, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var list api.LoanList
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	assert.Len(t, list.Data, 1)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/api/v1/loans?status=missing", "").Code)
+
+	w = do(http.MethodPost, "/api/v1/loans/"+loan.Id+"/return", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&loan))
+	assert.NotNil(t, loan.ReturnedAt)
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/api/v1/loans/"+loan.Id+"/return", "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/v1/loans/missing", "").Code)
+}
+
+func TestHoldsHTTP(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Loans, svc.Holds = NewLoanRepo(), NewHoldRepo()
+	b, err := svc.CreateBook(context.Background(), model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	_, err = svc.CheckoutBook(context.Background(), b.ID, "card-1", nil)
+	require.NoError(t, err)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/books/"+b.ID+"/holds", `{"borrower":"card-2"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var hold api.Hold
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&hold))
+	assert.Equal(t, 1, hold.Position)
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/api/v1/books/"+b.ID+"/holds", `{"borrower":"card-2"}`).Code)
+
+	w = do(http.MethodGet, "/api/v1/books/"+b.ID, "")
+	var book api.Book
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&book))
+	assert.Equal(t, 1, book.Lending.Holds)
+
+	w = do(http.MethodGet, "/api/v1/books/"+b.ID+"/holds", "")
+	var list api.HoldList
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, "card-2", list.Data[0].Borrower)
+
+	assert.Equal(t, http.StatusNoContent, do(http.MethodDelete, "/api/v1/holds/"+hold.Id, "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, "/api/v1/holds/"+hold.Id, "").Code)
+}
+
+func TestLoansFeed(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Loans = NewLoanRepo()
+	ctx := context.Background()
+	dune, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune, Messiah; and more")})
+	require.NoError(t, err)
+	emma, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Emma " + strings.Repeat("é", 60))})
+	require.NoError(t, err)
+	due := time.Date(2999, 1, 31, 0, 0, 0, 0, time.UTC)
+	l, err := svc.CheckoutBook(ctx, dune.ID, "card-1", &due)
+	require.NoError(t, err)
+	returned, err := svc.CheckoutBook(ctx, emma.ID, "card-2", nil)
+	require.NoError(t, err)
+	_, err = svc.ReturnLoan(ctx, returned.ID)
+	require.NoError(t, err)
+	_, err = svc.CheckoutBook(ctx, emma.ID, "card-3", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/feeds/loans.ics", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	assert.True(t, strings.HasPrefix(body, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(body, "END:VCALENDAR\r\n"))
+	assert.Equal(t, 2, strings.Count(body, "BEGIN:VEVENT"), "returned loans are left out")
+	assert.Contains(t, body, "UID:"+l.ID+"@book-manager\r\n")
+	assert.Contains(t, body, "DTSTART;VALUE=DATE:29990131\r\nDTEND;VALUE=DATE:29990201\r\n")
+	assert.Contains(t, body, `SUMMARY:Due: Dune\, Messiah\; and more (card-1)`)
+	assert.NotContains(t, body, "card-2")
+	for _, line := range strings.Split(body, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, line)
+		assert.True(t, utf8.ValidString(line), line)
+	}
+	unfolded := strings.ReplaceAll(body, "\r\n ", "")
+	assert.Contains(t, unfolded, "SUMMARY:Due: Emma "+strings.Repeat("é", 60)+" (card-3)")
+}
@@ -0,0 +1,80 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/apispec"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+const openAPIPath = "/api/v1/openapi.json"
+
+// openAPIJSON is the API's own document as JSON, served by this server:
+// its servers are replaced by the one relative URL of the server itself,
+// so requests tried from Swagger UI or the console reach the instance
+// that served it.
+var openAPIJSON = sync.OnceValues(func() ([]byte, error) {
+	doc, err := apispec.Decode(api.Spec)
+	if err != nil {
+		return nil, err
+	}
+	doc["servers"] = []any{map[string]any{"url": "/", "description": "This server"}}
+	return json.Marshal(doc)
+})
+
+func (h *HTTPHandler) GetOpenAPIDocument(w http.ResponseWriter, r *http.Request) {
+	data, err := openAPIJSON()
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("read openapi document failed")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// swaggerUIVersion pins the Swagger UI that the page loads from unpkg.
+const swaggerUIVersion = "5.17.14"
+
+// swaggerUIPage runs Swagger UI on the document. The document's URL is
+// relative, so the page also works under a /tenants/{tenant} prefix.
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Book Manager API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "api/v1/openapi.json", dom_id: "#swagger-ui", deepLinking: true });
+  </script>
+</body>
+</html>
+`
+
+func (h *HTTPHandler) GetSwaggerUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(swaggerUIPage))
+}
+
+// apiConsole is the page served at /docs: a console that reads the
+// document and tries requests, in one file with no outside scripts or
+// styles, so it works where no CDN can be reached.
+//
+//go:embed apiconsole.html
+var apiConsole []byte
+
+// apiConsolePolicy keeps the console to its own inline code and this
+// server.
+const apiConsolePolicy = "default-src 'self'; script-src 'unsafe-inline'; style-src 'unsafe-inline'; img-src 'self' blob:"
+
+func (h *HTTPHandler) GetDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", apiConsolePolicy)
+	_, _ = w.Write(apiConsole)
+}
@@ -0,0 +1,66 @@
+//go:build unit
+
+package adapter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIDocumentHTTP(t *testing.T) {
+	h, _ := newServer(t)
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/api/v1/openapi.json")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var doc struct {
+		OpenAPI string                    `json:"openapi"`
+		Servers []map[string]string       `json:"servers"`
+		Paths   map[string]map[string]any `json:"paths"`
+		Comps   struct {
+			Schemas map[string]struct {
+				Properties map[string]any `json:"properties"`
+				Enum       []string       `json:"enum"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Equal(t, "/", doc.Servers[0]["url"], "requests go to this server")
+	assert.Contains(t, doc.Paths["/api/v1/books/{id}"], "patch")
+	assert.Contains(t, doc.Paths["/api/v1/branches/{branchId}"], "patch")
+
+	// the errors the server writes are what the document describes
+	w = get("/api/v1/books/nope")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	var body map[string]map[string]any
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	for field := range body["error"] {
+		assert.Contains(t, doc.Comps.Schemas["Error"].Properties, field)
+	}
+	assert.True(t, slices.Contains(doc.Comps.Schemas["ErrorCode"].Enum, body["error"]["code"].(string)))
+
+	w = get("/swagger")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `url: "api/v1/openapi.json"`)
+
+	w = get("/docs")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Security-Policy"), "default-src 'self'")
+	page := w.Body.String()
+	assert.Contains(t, page, `"api/v1/openapi.json"`)
+	assert.NotRegexp(t, `(src|href)="(https?:)?//`, page, "the console loads nothing from elsewhere")
+}
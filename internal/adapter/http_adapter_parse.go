@@ -0,0 +1,60 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// maxParseBody is generous for the 4096 characters the service accepts.
+const maxParseBody = 64 << 10
+
+func (h *HTTPHandler) ParseBook(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxParseBody)
+	var in api.ParseRequest
+	var err error
+	if ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); ct == "text/plain" {
+		var b []byte
+		b, err = io.ReadAll(r.Body)
+		in.Text = string(b)
+	} else {
+		err = json.NewDecoder(r.Body).Decode(&in)
+	}
+	if err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid request body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid parse request")
+		return
+	}
+	res, err := h.Svc.ParseBook(r.Context(), in.Text)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("parse book failed")
+		return
+	}
+	h.logFor(r).Info("parse request processed", "extractor", res.Extractor)
+	writeJSON(w, http.StatusOK, api.ParseResult{Book: toAPIBookCreate(res.Input), Extractor: res.Extractor})
+}
+
+// toAPIBookCreate is the inverse of toCreateInput for the fields a parse
+// can fill.
+func toAPIBookCreate(in model.CreateBookInput) api.BookCreate {
+	out := api.BookCreate{
+		Isbn:          in.ISBN,
+		Title:         deref(in.Title),
+		Subtitle:      in.Subtitle,
+		PublishedYear: in.PublishedYear,
+		PageCount:     in.PageCount,
+		CoverUrl:      in.CoverURL,
+	}
+	if len(in.Authors) > 0 {
+		out.Authors = &in.Authors
+	}
+	if len(in.Tags) > 0 {
+		out.Tags = &in.Tags
+	}
+	return out
+}
@@ -0,0 +1,34 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"math"
+	"net/http"
+)
+
+func (h *HTTPHandler) GetBookPrices(w http.ResponseWriter, r *http.Request, id string) {
+	pts, err := h.Svc.PriceHistory(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("get book prices failed")
+		return
+	}
+	out := api.PriceHistory{Data: make([]api.PricePoint, 0, len(pts))}
+	for _, p := range pts {
+		out.Data = append(out.Data, api.PricePoint{Price: fromDomainPrice(p.Price), Source: p.Source, RecordedAt: p.At.UTC()})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func fromDomainPrice(p model.Price) api.Price {
+	return api.Price{Amount: float64(p.Amount) / 100, Currency: p.Currency}
+}
+
+func toDomainPrice(p *api.Price) *model.Price {
+	if p == nil {
+		return nil
+	}
+	return &model.Price{Amount: int64(math.Round(p.Amount * 100)), Currency: p.Currency}
+}
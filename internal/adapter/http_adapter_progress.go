@@ -0,0 +1,77 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (h *HTTPHandler) UpdateBookProgress(w http.ResponseWriter, r *http.Request, id string) {
+	var in api.ProgressUpdate
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	p, err := h.Svc.UpdateProgress(r.Context(), id, in.Page, in.Percent)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("update progress failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainProgress(p))
+}
+
+func (h *HTTPHandler) GetBookProgress(w http.ResponseWriter, r *http.Request, id string) {
+	history, err := h.Svc.ProgressHistory(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("get progress failed")
+		return
+	}
+	out := api.ProgressHistory{History: make([]api.ReadingProgress, 0, len(history))}
+	for _, p := range history {
+		out.History = append(out.History, fromDomainProgress(p))
+	}
+	out.Current = out.History[len(out.History)-1]
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) GetReadingStats(w http.ResponseWriter, r *http.Request, p api.GetReadingStatsParams) {
+	weeks := 12
+	if p.Weeks != nil {
+		weeks = *p.Weeks
+	}
+	stats, err := h.Svc.ReadingStats(r.Context(), weeks, time.Now())
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("reading stats failed")
+		return
+	}
+	var out api.ReadingStats
+	for _, wp := range stats.PagesPerWeek {
+		out.PagesPerWeek = append(out.PagesPerWeek, struct {
+			Pages     int                `json:"pages"`
+			WeekStart openapi_types.Date `json:"week_start"`
+		}{Pages: wp.Pages, WeekStart: openapi_types.Date{Time: wp.Start}})
+	}
+	out.BooksFinishedPerYear = make([]struct {
+		Books int `json:"books"`
+		Year  int `json:"year"`
+	}, len(stats.FinishedPerYear))
+	for i, y := range stats.FinishedPerYear {
+		out.BooksFinishedPerYear[i].Books, out.BooksFinishedPerYear[i].Year = y.Books, y.Year
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func fromDomainProgress(p model.ReadingProgress) api.ReadingProgress {
+	return api.ReadingProgress{BookId: p.BookID, Page: p.Page, Percent: p.Percent, Finished: p.Finished, At: p.At.UTC()}
+}
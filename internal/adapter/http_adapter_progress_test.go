@@ -0,0 +1,57 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressHTTP(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Progress = NewProgressRepo()
+	b, err := svc.CreateBook(context.Background(), model.CreateBookInput{Title: util.GetPtr("Dune"), PageCount: util.GetPtr(200)})
+	require.NoError(t, err)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/v1/books/"+b.ID+"/progress", "").Code, "nothing recorded")
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, "/api/v1/books/"+b.ID+"/progress", `{"page":10,"percent":5}`).Code)
+
+	w := do(http.MethodPut, "/api/v1/books/"+b.ID+"/progress", `{"page":50}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var p api.ReadingProgress
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&p))
+	assert.Equal(t, 25, *p.Percent)
+	require.Equal(t, http.StatusOK, do(http.MethodPut, "/api/v1/books/"+b.ID+"/progress", `{"percent":100}`).Code)
+
+	w = do(http.MethodGet, "/api/v1/books/"+b.ID+"/progress", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var hist api.ProgressHistory
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&hist))
+	assert.Len(t, hist.History, 2)
+	assert.True(t, hist.Current.Finished)
+
+	w = do(http.MethodGet, "/api/v1/reading-stats?weeks=2", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var stats api.ReadingStats
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&stats))
+	require.Len(t, stats.PagesPerWeek, 2)
+	assert.Equal(t, 200, stats.PagesPerWeek[1].Pages)
+	require.Len(t, stats.BooksFinishedPerYear, 1)
+	assert.Equal(t, 1, stats.BooksFinishedPerYear[0].Books)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/api/v1/reading-stats?weeks=500", "").Code)
+}
@@ -0,0 +1,58 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+)
+
+func (h *HTTPHandler) ImportReadingList(w http.ResponseWriter, r *http.Request) {
+	var in api.ReadingListImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	ref := model.ReadingListRef{}
+	if in.Username != nil {
+		ref.Username = *in.Username
+	}
+	if in.Shelf != nil {
+		ref.Shelf = *in.Shelf
+	}
+	if in.ListUrl != nil {
+		ref.ListURL = *in.ListUrl
+	}
+	res, err := h.Svc.ImportReadingList(r.Context(), ref)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("reading list import failed")
+		return
+	}
+
+	out := api.ReadingListImportResult{Created: res.Created, Existing: res.Existing, Failed: res.Failed, Items: make([]api.ReadingListImportItem, 0, len(res.Items))}
+	for _, it := range res.Items {
+		item := api.ReadingListImportItem{
+			Title:      it.Item.Title,
+			Isbn:       strPtrOrNil(it.Item.ISBN),
+			WorkKey:    strPtrOrNil(it.Item.WorkKey),
+			EditionKey: strPtrOrNil(it.Item.EditionKey),
+			BookId:     strPtrOrNil(it.BookID),
+		}
+		switch {
+		case it.Err != nil:
+			_, code := mapSvcErr(it.Err)
+			item.Status = "failed"
+			item.Error = &api.BatchItemError{Code: code, Message: it.Err.Error()}
+		case it.Existing:
+			item.Status = "existing"
+		default:
+			item.Status = "created"
+		}
+		out.Items = append(out.Items, item)
+	}
+	h.logFor(r).Info("reading list imported", "created", out.Created, "existing", out.Existing, "failed", out.Failed)
+	writeJSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,38 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"net/http"
+)
+
+// defaultRecentViewsLimit is the number of books listed without a limit.
+const defaultRecentViewsLimit = 20
+
+func (h *HTTPHandler) ListRecentViews(w http.ResponseWriter, r *http.Request, p api.ListRecentViewsParams) {
+	limit := defaultRecentViewsLimit
+	if p.Limit != nil {
+		limit = *p.Limit
+	}
+	views, err := h.Svc.RecentlyViewed(r.Context(), limit)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("list recent views failed")
+		return
+	}
+	out := api.RecentViewList{Data: make([]api.RecentView, 0, len(views))}
+	for _, v := range views {
+		out.Data = append(out.Data, api.RecentView{Book: fromDomainBook(v.Book), ViewedAt: v.ViewedAt.UTC()})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) ClearRecentViews(w http.ResponseWriter, r *http.Request) {
+	if err := h.Svc.ClearRecentViews(r.Context()); err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("clear recent views failed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,53 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries a request's ID in both directions, and on to
+// upstream services.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen bounds a client's request ID; longer ones are replaced.
+const maxRequestIDLen = 128
+
+// RequestIDMiddleware gives every request an ID: the client's X-Request-ID
+// when it is sane, else a new UUID. The ID is echoed in the response
+// header, carried in the context (model.RequestIDFromContext), logged with
+// the request and put in error bodies. It runs first, so every later
+// middleware's errors carry it too.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(model.WithRequestID(r.Context(), id)))
+	})
+}
+
+// validRequestID accepts up to maxRequestIDLen printable ASCII characters
+// without spaces, so a client cannot forge log lines or headers with it.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// setRequestID forwards the ID of the request ctx serves on an upstream
+// request.
+func setRequestID(req *http.Request) {
+	if id := model.RequestIDFromContext(req.Context()); id != "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+}
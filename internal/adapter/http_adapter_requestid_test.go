@@ -0,0 +1,72 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core"
+	"book-manager/internal/core/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	h := NewHTTPHandler(core.NewService(NewBookRepo(), mockEnrich{}), slog.New(slog.NewTextHandler(&logs, nil)))
+	r := chi.NewRouter()
+	r.Use(RequestIDMiddleware)
+	api.HandlerFromMux(h, r)
+
+	get := func(id string) (*httptest.ResponseRecorder, string) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/books/missing", nil)
+		if id != "" {
+			req.Header.Set(RequestIDHeader, id)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code)
+		var body api.ErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+		require.NotNil(t, body.Error.RequestId)
+		assert.Equal(t, w.Header().Get(RequestIDHeader), *body.Error.RequestId)
+		return w, *body.Error.RequestId
+	}
+
+	_, id := get("support-4711")
+	assert.Equal(t, "support-4711", id)
+	assert.Contains(t, logs.String(), "request_id=support-4711")
+
+	for _, sent := range []string{"", "two words", "line\nbreak", strings.Repeat("x", maxRequestIDLen+1)} {
+		_, id = get(sent)
+		assert.NoError(t, uuid.Validate(id), "%q is replaced", sent)
+	}
+}
+
+func TestOpenLibrary_ForwardsRequestID(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(RequestIDHeader)
+		_, _ = w.Write([]byte(`{"title":"Dune"}`))
+	}))
+	defer srv.Close()
+
+	c := NewOpenLibraryClient(srv.URL, 0, srv.Client())
+	_, err := c.FetchByISBN(model.WithRequestID(context.Background(), "support-4711"), "9780441013593")
+	require.NoError(t, err)
+	assert.Equal(t, "support-4711", got)
+
+	_, err = c.FetchByISBN(context.Background(), "9780441013593")
+	require.NoError(t, err)
+	assert.Empty(t, got, "no request, no header")
+}
@@ -0,0 +1,84 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/auth"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+)
+
+func (h *HTTPHandler) ListBookReviews(w http.ResponseWriter, r *http.Request, id string, p api.ListBookReviewsParams) {
+	page, pageSize := 1, 20
+	if p.Page != nil {
+		page = *p.Page
+	}
+	if p.PageSize != nil {
+		pageSize = *p.PageSize
+	}
+	reviews, err := h.Svc.ListReviews(r.Context(), id, page, pageSize)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("list reviews failed")
+		return
+	}
+	out := api.PaginatedReviews{Data: make([]api.Review, 0, len(reviews.Data)), Page: reviews.Page, PageSize: reviews.PageSize, Total: reviews.Total}
+	for _, rv := range reviews.Data {
+		out.Data = append(out.Data, fromDomainReview(rv))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) CreateBookReview(w http.ResponseWriter, r *http.Request, id string) {
+	var in api.ReviewCreate
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	rv := model.Review{BookID: id, Rating: in.Rating}
+	if in.Text != nil {
+		rv.Text = *in.Text
+	}
+	rv, err := h.Svc.AddReview(r.Context(), rv)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("create review failed")
+		return
+	}
+	h.logFor(r).Info("review created", "book", id, "review", rv.ID, "rating", rv.Rating)
+	writeJSON(w, http.StatusCreated, fromDomainReview(rv))
+}
+
+// DeleteBookReview removes a review; readers may only remove their own.
+func (h *HTTPHandler) DeleteBookReview(w http.ResponseWriter, r *http.Request, id, reviewID string) {
+	if !auth.AllowsAnyReview(r.Context()) {
+		rv, err := h.Svc.GetReview(r.Context(), id, reviewID)
+		if err == nil && rv.Reviewer != model.ActorFromContext(r.Context()) {
+			writeErrFor(w, r, http.StatusForbidden, "FORBIDDEN", "not the reviewer", map[string]any{"review": reviewID})
+			h.logFor(r).Info("review forbidden", "review", reviewID)
+			return
+		}
+	}
+	if err := h.Svc.DeleteReview(r.Context(), id, reviewID); err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("delete review failed")
+		return
+	}
+	h.logFor(r).Info("review deleted", "book", id, "review", reviewID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func fromDomainReview(rv model.Review) api.Review {
+	return api.Review{
+		Id:        rv.ID,
+		BookId:    rv.BookID,
+		Reviewer:  rv.Reviewer,
+		Rating:    rv.Rating,
+		Text:      strPtrOrNil(rv.Text),
+		CreatedAt: rv.CreatedAt.UTC(),
+	}
+}
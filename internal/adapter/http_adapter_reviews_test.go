@@ -0,0 +1,74 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/auth"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewsHTTP(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Reviews = NewReviewRepo()
+	ctx := context.Background()
+	dune, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	emma, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Emma")})
+	require.NoError(t, err)
+
+	do := func(p auth.Principal, method, path, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		ctx := auth.NewContext(r.Context(), p)
+		r = r.WithContext(model.WithActor(ctx, p.Method+":"+p.ID))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+	alice := auth.Principal{ID: "alice", Method: "jwt", Role: auth.RoleReader}
+	bob := auth.Principal{ID: "bob", Method: "jwt", Role: auth.RoleReader}
+	editor := auth.Principal{ID: "ed", Method: "jwt", Role: auth.RoleEditor}
+
+	w := do(alice, http.MethodPost, "/api/v1/books/"+dune.ID+"/reviews", `{"rating":4,"text":"Spice"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var rv api.Review
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&rv))
+	assert.Equal(t, "jwt:alice", rv.Reviewer)
+	assert.Equal(t, http.StatusConflict, do(alice, http.MethodPost, "/api/v1/books/"+dune.ID+"/reviews", `{"rating":5}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(bob, http.MethodPost, "/api/v1/books/"+dune.ID+"/reviews", `{"rating":0}`).Code)
+	require.Equal(t, http.StatusCreated, do(bob, http.MethodPost, "/api/v1/books/"+emma.ID+"/reviews", `{"rating":5}`).Code)
+
+	w = do(alice, http.MethodGet, "/api/v1/books?sort=-rating", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var books api.PaginatedBooks
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&books))
+	require.Len(t, books.Data, 2)
+	assert.Equal(t, emma.ID, books.Data[0].Id)
+	require.NotNil(t, books.Data[1].Rating)
+	assert.Equal(t, api.Rating{Average: util.GetPtr(4.0), Count: 1}, *books.Data[1].Rating)
+
+	w = do(alice, http.MethodGet, "/api/v1/books/"+dune.ID+"/reviews?page_size=10", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var page api.PaginatedReviews
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	assert.Equal(t, 1, page.Total)
+	assert.Equal(t, "Spice", *page.Data[0].Text)
+
+	assert.Equal(t, http.StatusForbidden, do(bob, http.MethodDelete, "/api/v1/books/"+dune.ID+"/reviews/"+rv.Id, "").Code)
+	require.Equal(t, http.StatusNoContent, do(alice, http.MethodDelete, "/api/v1/books/"+dune.ID+"/reviews/"+rv.Id, "").Code)
+	assert.Equal(t, http.StatusNotFound, do(editor, http.MethodDelete, "/api/v1/books/"+dune.ID+"/reviews/"+rv.Id, "").Code)
+
+	w = do(alice, http.MethodGet, "/api/v1/books/"+dune.ID, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"rating":{"count":0}`)
+}
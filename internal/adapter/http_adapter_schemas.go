@@ -0,0 +1,56 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/apispec"
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+const schemasPath = "/api/v1/schemas/"
+
+// schemaMediaType labels JSON Schema documents.
+const schemaMediaType = "application/schema+json"
+
+// openAPI is the API's own document, read once; the schemas are derived
+// from it on every request so they cannot drift from what is served.
+var openAPI = sync.OnceValues(func() (*apispec.Document, error) {
+	return apispec.Parse(api.Spec)
+})
+
+func (h *HTTPHandler) ListSchemas(w http.ResponseWriter, r *http.Request) {
+	d, err := openAPI()
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("read openapi document failed")
+		return
+	}
+	out := api.SchemaList{Data: []api.SchemaRef{}}
+	for _, name := range d.SchemaNames() {
+		out.Data = append(out.Data, api.SchemaRef{Name: name, Href: schemasPath + name})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) GetSchema(w http.ResponseWriter, r *http.Request, name api.SchemaName) {
+	d, err := openAPI()
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("read openapi document failed")
+		return
+	}
+	if d.Components.Schemas[name] == nil {
+		writeErrFor(w, r, http.StatusNotFound, "NOT_FOUND", "schema not found", nil)
+		return
+	}
+	schema, err := d.JSONSchema(name, schemasPath)
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("convert schema failed")
+		return
+	}
+	w.Header().Set("Content-Type", schemaMediaType)
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(schema)
+}
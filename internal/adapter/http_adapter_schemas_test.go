@@ -0,0 +1,63 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemasHTTP(t *testing.T) {
+	h, _ := newServer(t)
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/api/v1/schemas")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var list api.SchemaList
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	assert.Contains(t, list.Data, api.SchemaRef{Name: "Book", Href: "/api/v1/schemas/Book"})
+
+	w = get("/api/v1/schemas/Book")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/schema+json", w.Header().Get("Content-Type"))
+	var book struct {
+		Schema     string                    `json:"$schema"`
+		ID         string                    `json:"$id"`
+		Required   []string                  `json:"required"`
+		Properties map[string]map[string]any `json:"properties"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&book))
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", book.Schema)
+	assert.Equal(t, "/api/v1/schemas/Book", book.ID)
+	assert.Contains(t, book.Required, "title")
+	assert.Equal(t, []any{"string", "null"}, book.Properties["isbn"]["type"], "nullable becomes a null type")
+	assert.NotContains(t, book.Properties["isbn"], "nullable")
+	assert.Equal(t, "/api/v1/schemas/AuthorSummary", book.Properties["authors"]["items"].(map[string]any)["$ref"])
+
+	// every reference leads to a published schema
+	hrefs := map[string]bool{}
+	for _, s := range list.Data {
+		hrefs[s.Href] = true
+	}
+	for _, s := range list.Data {
+		w := get(s.Href)
+		require.Equal(t, http.StatusOK, w.Code, s.Name)
+		for _, part := range strings.Split(w.Body.String(), `"$ref":"`)[1:] {
+			ref := part[:strings.IndexByte(part, '"')]
+			assert.True(t, hrefs[ref], "%s refers to %s", s.Name, ref)
+		}
+	}
+
+	assert.Equal(t, http.StatusNotFound, get("/api/v1/schemas/Nope").Code)
+}
@@ -0,0 +1,51 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"net/http"
+)
+
+func (h *HTTPHandler) SemanticSearchBooks(w http.ResponseWriter, r *http.Request, p api.SemanticSearchBooksParams) {
+	limit := 0
+	if p.Limit != nil {
+		limit = *p.Limit
+	}
+	hits, err := h.Svc.SemanticSearch(r.Context(), p.Q, limit)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("semantic search failed")
+		return
+	}
+	h.logFor(r).Info("semantic search processed", "hits", len(hits))
+	h.writeScoredBooks(w, r, hits)
+}
+
+func (h *HTTPHandler) GetSimilarBooks(w http.ResponseWriter, r *http.Request, id string, p api.GetSimilarBooksParams) {
+	limit := 0
+	if p.Limit != nil {
+		limit = *p.Limit
+	}
+	hits, err := h.Svc.SimilarBooks(r.Context(), id, limit)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("get similar books failed")
+		return
+	}
+	h.writeScoredBooks(w, r, hits)
+}
+
+func (h *HTTPHandler) writeScoredBooks(w http.ResponseWriter, r *http.Request, hits []model.ScoredBook) {
+	links := h.wantsLinks(r)
+	out := api.ScoredBooks{Data: make([]api.ScoredBook, 0, len(hits))}
+	for _, hit := range hits {
+		b := fromDomainBook(hit.Book)
+		if links {
+			b.Links = bookLinks(b)
+		}
+		out.Data = append(out.Data, api.ScoredBook{Score: hit.Score, Book: b})
+	}
+	writeJSONFor(w, r, http.StatusOK, out)
+}
@@ -0,0 +1,81 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"net/http"
+)
+
+// defaultShelfSyncLimit is the number of reports listed without a limit.
+const defaultShelfSyncLimit = 20
+
+func (h *HTTPHandler) ListShelfSyncs(w http.ResponseWriter, r *http.Request, p api.ListShelfSyncsParams) {
+	limit := defaultShelfSyncLimit
+	if p.Limit != nil {
+		limit = *p.Limit
+	}
+	reports, err := h.Svc.ShelfSyncReports(r.Context(), limit)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("list shelf syncs failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainShelfSyncs(reports))
+}
+
+func (h *HTTPHandler) SyncShelves(w http.ResponseWriter, r *http.Request) {
+	reports, err := h.Svc.SyncShelves(r.Context())
+	if err != nil && len(reports) == 0 {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("shelf sync failed")
+		return
+	}
+	if err != nil {
+		// the syncs ran; only their reports could not all be kept
+		h.logFor(r).With("error", err).Warn("shelf sync reports lost")
+	}
+	h.logFor(r).Info("shelves synced", "feeds", len(reports))
+	writeJSON(w, http.StatusOK, fromDomainShelfSyncs(reports))
+}
+
+func fromDomainShelfSyncs(reports []model.ShelfSyncReport) api.ShelfSyncReportList {
+	out := api.ShelfSyncReportList{Data: make([]api.ShelfSyncReport, 0, len(reports))}
+	for _, rep := range reports {
+		o := api.ShelfSyncReport{
+			Id:         rep.ID,
+			Shelf:      strPtrOrNil(rep.Shelf),
+			Source:     rep.Source,
+			StartedAt:  rep.StartedAt.UTC(),
+			FinishedAt: rep.FinishedAt.UTC(),
+			Created:    rep.Created,
+			Tagged:     rep.Tagged,
+			Existing:   rep.Existing,
+			Failed:     rep.Failed,
+			Items:      make([]api.ShelfSyncItem, 0, len(rep.Items)),
+		}
+		if rep.Err != nil {
+			_, code := mapSvcErr(rep.Err)
+			o.Error = &api.BatchItemError{Code: code, Message: rep.Err.Error()}
+		}
+		for _, it := range rep.Items {
+			item := api.ShelfSyncItem{
+				Title:  it.Entry.Title,
+				Isbn:   strPtrOrNil(it.Entry.ISBN),
+				Status: it.Status,
+				BookId: strPtrOrNil(it.BookID),
+			}
+			if len(it.Entry.Authors) > 0 {
+				item.Authors = &it.Entry.Authors
+			}
+			if it.Err != nil {
+				_, code := mapSvcErr(it.Err)
+				item.Error = &api.BatchItemError{Code: code, Message: it.Err.Error()}
+			}
+			o.Items = append(o.Items, item)
+		}
+		out.Data = append(out.Data, o)
+	}
+	return out
+}
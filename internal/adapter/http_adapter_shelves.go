@@ -0,0 +1,115 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+func (h *HTTPHandler) ListShelves(w http.ResponseWriter, r *http.Request) {
+	shelves, err := h.Svc.ListBookshelves(r.Context())
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list shelves failed")
+		return
+	}
+	out := api.ShelfList{Data: make([]api.Shelf, 0, len(shelves))}
+	for _, s := range shelves {
+		out.Data = append(out.Data, fromDomainShelf(s))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) CreateShelf(w http.ResponseWriter, r *http.Request) {
+	var in api.ShelfCreate
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	s, err := h.Svc.CreateBookshelf(r.Context(), in.Name)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("create shelf failed")
+		return
+	}
+	h.logFor(r).Info("shelf created", "shelf", s.ID)
+	w.Header().Set("Location", "/api/v1/shelves/"+url.PathEscape(s.ID))
+	writeJSON(w, http.StatusCreated, fromDomainShelf(s))
+}
+
+func (h *HTTPHandler) GetShelf(w http.ResponseWriter, r *http.Request, id string) {
+	s, err := h.Svc.GetBookshelf(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("get shelf failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainShelf(s))
+}
+
+func (h *HTTPHandler) DeleteShelf(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.Svc.DeleteBookshelf(r.Context(), id); err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("delete shelf failed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HTTPHandler) ListShelfBooks(w http.ResponseWriter, r *http.Request, id string, p api.ListShelfBooksParams) {
+	page, pageSize := 1, 20
+	if p.Page != nil {
+		page = *p.Page
+	}
+	if p.PageSize != nil {
+		pageSize = *p.PageSize
+	}
+	books, err := h.Svc.ShelfBooks(r.Context(), id, page, pageSize)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("list shelf books failed")
+		return
+	}
+	out := api.PaginatedShelfBooks{Data: make([]api.ShelfBook, 0, len(books.Data)), Page: books.Page, PageSize: books.PageSize, Total: books.Total}
+	for _, b := range books.Data {
+		out.Data = append(out.Data, api.ShelfBook{Book: fromDomainBook(b.Book), AddedAt: b.AddedAt.UTC()})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) ShelveBook(w http.ResponseWriter, r *http.Request, shelfID, id string) {
+	if err := h.Svc.ShelveBook(r.Context(), shelfID, id); err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("shelve book failed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HTTPHandler) UnshelveBook(w http.ResponseWriter, r *http.Request, shelfID, id string) {
+	if err := h.Svc.UnshelveBook(r.Context(), shelfID, id); err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("unshelve book failed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func fromDomainShelf(s model.Bookshelf) api.Shelf {
+	out := api.Shelf{Id: s.ID, Name: s.Name, Status: s.Status, Books: s.Books}
+	if !s.CreatedAt.IsZero() {
+		created := s.CreatedAt.UTC()
+		out.CreatedAt = &created
+	}
+	return out
+}
@@ -0,0 +1,64 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShelvesHTTP(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Bookshelves = NewBookshelfRepo()
+	b, err := svc.CreateBook(context.Background(), model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/shelves", `{"name":"Summer 2026"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, "/api/v1/shelves/summer-2026", w.Header().Get("Location"))
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/api/v1/shelves", `{"name":"read"}`).Code)
+
+	require.Equal(t, http.StatusNoContent, do(http.MethodPut, "/api/v1/shelves/summer-2026/books/"+b.ID, "").Code)
+	require.Equal(t, http.StatusNoContent, do(http.MethodPut, "/api/v1/shelves/reading/books/"+b.ID, "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodPut, "/api/v1/shelves/unknown/books/"+b.ID, "").Code)
+
+	w = do(http.MethodGet, "/api/v1/shelves", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var shelves api.ShelfList
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&shelves))
+	require.Len(t, shelves.Data, 4)
+	assert.Equal(t, "reading", shelves.Data[1].Id)
+	assert.Equal(t, 1, shelves.Data[1].Books)
+	assert.Nil(t, shelves.Data[1].CreatedAt)
+	assert.NotNil(t, shelves.Data[3].CreatedAt)
+
+	w = do(http.MethodGet, "/api/v1/shelves/summer-2026/books?page_size=10", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var books api.PaginatedShelfBooks
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&books))
+	assert.Equal(t, 1, books.Total)
+	require.Len(t, books.Data, 1)
+	assert.Equal(t, "Dune", books.Data[0].Book.Title)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/api/v1/shelves/summer-2026/books?page_size=500", "").Code)
+
+	require.Equal(t, http.StatusNoContent, do(http.MethodDelete, "/api/v1/shelves/summer-2026/books/"+b.ID, "").Code)
+	assert.Equal(t, http.StatusConflict, do(http.MethodDelete, "/api/v1/shelves/read", "").Code)
+	require.Equal(t, http.StatusNoContent, do(http.MethodDelete, "/api/v1/shelves/summer-2026", "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/v1/shelves/summer-2026", "").Code)
+}
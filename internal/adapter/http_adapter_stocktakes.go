@@ -0,0 +1,198 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/auth"
+	"book-manager/internal/core/model"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+func (h *HTTPHandler) ListStocktakes(w http.ResponseWriter, r *http.Request) {
+	sts, err := h.Svc.ListStocktakes(r.Context())
+	if err != nil {
+		writeErrFor(w, r, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list stocktakes failed")
+		return
+	}
+	out := api.StocktakeList{Data: make([]api.Stocktake, 0, len(sts))}
+	for _, st := range sts {
+		out.Data = append(out.Data, fromDomainStocktake(st))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) StartStocktake(w http.ResponseWriter, r *http.Request) {
+	var in api.StocktakeCreate
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	var branch string
+	if in.Branch != nil {
+		branch = *in.Branch
+	}
+	st, err := h.Svc.StartStocktake(r.Context(), branch)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("start stocktake failed")
+		return
+	}
+	h.logFor(r).Info("stocktake started", "stocktake", st.ID, "branch", st.Branch)
+	w.Header().Set("Location", "/api/v1/stocktakes/"+st.ID)
+	writeJSON(w, http.StatusCreated, fromDomainStocktake(st))
+}
+
+func (h *HTTPHandler) GetStocktake(w http.ResponseWriter, r *http.Request, id string) {
+	st, err := h.Svc.GetStocktake(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("get stocktake failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainStocktake(st))
+}
+
+func (h *HTTPHandler) ScanStocktake(w http.ResponseWriter, r *http.Request, id string) {
+	st, err := h.Svc.GetStocktake(r.Context(), id)
+	if err == nil && st.ClosedAt != nil {
+		err = fmt.Errorf("%w: stocktake %s is closed", model.ErrConflict, id)
+	}
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("scan stocktake failed")
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBody)
+	sc := bufio.NewScanner(r.Body)
+	out := api.StocktakeScanResult{Errors: []api.ImportRowError{}}
+	for line := 1; sc.Scan(); line++ {
+		code := strings.TrimSpace(sc.Text())
+		if code == "" {
+			continue
+		}
+		out.Lines++
+		added, err := h.Svc.ScanStocktake(r.Context(), id, code)
+		if err != nil {
+			_, c := mapSvcErr(err)
+			out.Failed++
+			out.Errors = append(out.Errors, api.ImportRowError{Line: line, Code: c, Message: err.Error()})
+			continue
+		}
+		if added {
+			out.Added++
+		}
+	}
+	if err := sc.Err(); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "scan aborted", map[string]any{
+			"cause": err.Error(), "lines": out.Lines, "added": out.Added,
+		})
+		h.logFor(r).With("error", err).Info("stocktake scan aborted", "stocktake", id, "lines", out.Lines)
+		return
+	}
+	h.logFor(r).Info("stocktake scans recorded", "stocktake", id, "lines", out.Lines, "added", out.Added, "failed", out.Failed)
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) GetStocktakeReport(w http.ResponseWriter, r *http.Request, id string) {
+	rep, err := h.Svc.StocktakeReport(r.Context(), id)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("stocktake report failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDomainStocktakeReport(rep))
+}
+
+func (h *HTTPHandler) CloseStocktake(w http.ResponseWriter, r *http.Request, id string) {
+	var in api.StocktakeClose
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	markMissing := in.MarkMissing != nil && *in.MarkMissing
+	if markMissing {
+		st, err := h.Svc.GetStocktake(r.Context(), id)
+		if err != nil {
+			status, code := mapSvcErr(err)
+			writeErrFor(w, r, status, code, err.Error(), nil)
+			h.logFor(r).With("error", err).Info("close stocktake failed")
+			return
+		}
+		if st.Branch == "" && !auth.AllowsBranch(r.Context(), "") {
+			writeErrFor(w, r, http.StatusForbidden, "FORBIDDEN", "marking copies missing at every branch needs access to every branch", nil)
+			h.logFor(r).Info("branch forbidden", "stocktake", id)
+			return
+		}
+		if st.Branch != "" && !h.allowBranches(w, r, st.Branch) {
+			return
+		}
+	}
+	st, err := h.Svc.CloseStocktake(r.Context(), id, markMissing)
+	if err != nil && st.ID == "" {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("close stocktake failed")
+		return
+	}
+	if err != nil {
+		h.logFor(r).With("error", err).Warn("stocktake closed with failed item updates", "stocktake", id)
+	}
+	h.logFor(r).Info("stocktake closed", "stocktake", id, "mark_missing", markMissing)
+	writeJSON(w, http.StatusOK, fromDomainStocktake(st))
+}
+
+func fromDomainStocktake(st model.Stocktake) api.Stocktake {
+	out := api.Stocktake{
+		Id:        st.ID,
+		Branch:    strPtrOrNil(st.Branch),
+		StartedBy: st.StartedBy,
+		StartedAt: st.StartedAt.UTC(),
+		Scanned:   len(st.Scanned),
+		ClosedAt:  st.ClosedAt,
+		ClosedBy:  strPtrOrNil(st.ClosedBy),
+	}
+	if st.Report != nil {
+		rep := fromDomainStocktakeReport(*st.Report)
+		out.Report = &rep
+	}
+	return out
+}
+
+func fromDomainStocktakeReport(rep model.StocktakeReport) api.StocktakeReport {
+	copies := func(in []model.StocktakeCopy) []api.StocktakeCopy {
+		out := make([]api.StocktakeCopy, 0, len(in))
+		for _, c := range in {
+			ac := api.StocktakeCopy{
+				Barcode:  c.Barcode,
+				BookId:   strPtrOrNil(c.BookID),
+				Title:    strPtrOrNil(c.Title),
+				Branch:   strPtrOrNil(c.Branch),
+				Location: strPtrOrNil(c.Location),
+			}
+			if c.Reason != "" {
+				reason := api.StocktakeCopyReason(c.Reason)
+				ac.Reason = &reason
+			}
+			out = append(out, ac)
+		}
+		return out
+	}
+	return api.StocktakeReport{
+		Expected:      rep.Expected,
+		Seen:          copies(rep.Seen),
+		Missing:       copies(rep.Missing),
+		Unexpected:    copies(rep.Unexpected),
+		MarkedMissing: rep.MarkedMissing,
+		Found:         rep.Found,
+	}
+}
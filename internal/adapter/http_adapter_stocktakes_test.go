@@ -0,0 +1,85 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/auth"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStocktakesHTTP(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Stocktakes = NewStocktakeRepo()
+	ctx := context.Background()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	for _, code := range []string{"A-1", "A-2"} {
+		_, err = svc.AddItem(ctx, b.ID, model.Item{Barcode: code})
+		require.NoError(t, err)
+	}
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/stocktakes", `{}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var st api.Stocktake
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&st))
+	assert.Equal(t, "/api/v1/stocktakes/"+st.Id, w.Header().Get("Location"))
+
+	w = do(http.MethodPost, "/api/v1/stocktakes/"+st.Id+"/scans", "A-1\n\nA-1\n-bad\nZ-9\n")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var scans api.StocktakeScanResult
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&scans))
+	assert.Equal(t, 4, scans.Lines)
+	assert.Equal(t, 2, scans.Added)
+	require.Len(t, scans.Errors, 1)
+	assert.Equal(t, 4, scans.Errors[0].Line)
+	assert.Equal(t, "VALIDATION", scans.Errors[0].Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodPost, "/api/v1/stocktakes/nope/scans", "A-1\n").Code)
+
+	w = do(http.MethodGet, "/api/v1/stocktakes/"+st.Id+"/report", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var rep api.StocktakeReport
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&rep))
+	assert.Equal(t, 2, rep.Expected)
+	require.Len(t, rep.Missing, 1)
+	assert.Equal(t, "A-2", rep.Missing[0].Barcode)
+	require.Len(t, rep.Unexpected, 1)
+	assert.Equal(t, api.Unknown, *rep.Unexpected[0].Reason)
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/stocktakes/"+st.Id+"/close", strings.NewReader(`{"mark_missing":true}`))
+	r = r.WithContext(auth.NewContext(r.Context(), auth.Principal{Role: auth.RoleEditor, Branches: []string{"east"}}))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code, "limited to a branch")
+
+	w = do(http.MethodPost, "/api/v1/stocktakes/"+st.Id+"/close", `{"mark_missing":true}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&st))
+	require.NotNil(t, st.ClosedAt)
+	require.NotNil(t, st.Report)
+	assert.Equal(t, 1, st.Report.MarkedMissing)
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/api/v1/stocktakes/"+st.Id+"/scans", "A-2\n").Code)
+	assert.Contains(t, do(http.MethodGet, "/api/v1/books/"+b.ID, "").Body.String(), `"condition":"missing"`)
+
+	w = do(http.MethodGet, "/api/v1/stocktakes", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var list api.StocktakeList
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	assert.Len(t, list.Data, 1)
+}
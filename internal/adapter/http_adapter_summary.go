@@ -0,0 +1,18 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"net/http"
+)
+
+func (h *HTTPHandler) GetBookSummary(w http.ResponseWriter, r *http.Request, id string, params api.GetBookSummaryParams) {
+	withDescription := params.IncludeDescription != nil && *params.IncludeDescription
+	text, err := h.Svc.BookSummary(r.Context(), id, withDescription)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("get book summary failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, api.BookSummary{Id: id, Text: text})
+}
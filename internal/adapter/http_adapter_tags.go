@@ -0,0 +1,102 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"encoding/json"
+	"net/http"
+)
+
+func (h *HTTPHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.Svc.ListTags(r.Context())
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list tags failed")
+		return
+	}
+	out := api.TagList{Data: make([]api.TagCount, 0, len(tags))}
+	for _, t := range tags {
+		out.Data = append(out.Data, api.TagCount{Tag: t.Tag, Books: t.Books})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *HTTPHandler) GetTagTree(w http.ResponseWriter, r *http.Request) {
+	tree, err := h.Svc.TagTree(r.Context())
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("tag tree failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, api.TagTree{Data: fromDomainTagNodes(tree)})
+}
+
+func fromDomainTagNodes(nodes []model.TagNode) []api.TagNode {
+	out := make([]api.TagNode, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, api.TagNode{
+			Name: n.Name, Tag: n.Tag, Books: n.Books, TotalBooks: n.TotalBooks,
+			Children: fromDomainTagNodes(n.Children),
+		})
+	}
+	return out
+}
+
+func (h *HTTPHandler) ReplaceBookTags(w http.ResponseWriter, r *http.Request, id string, p api.ReplaceBookTagsParams) {
+	var in api.BookTags
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	version, ok := h.preconditionOK(w, r, id, p.IfMatch)
+	if !ok {
+		return
+	}
+	if in.Version == nil {
+		in.Version = version
+	}
+	b, err := h.Svc.ReplaceBookTags(r.Context(), id, in.Tags, in.Version)
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("replace book tags failed")
+		return
+	}
+	h.writeBookETag(w, r, http.StatusOK, fromDomainBook(b))
+}
+
+func (h *HTTPHandler) RenameTag(w http.ResponseWriter, r *http.Request) {
+	var in api.TagRenameRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	rn, err := h.Svc.RenameTag(r.Context(), in.From, in.To)
+	h.writeTagRename(w, r, rn, err)
+}
+
+func (h *HTTPHandler) MergeTags(w http.ResponseWriter, r *http.Request) {
+	var in api.TagMergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "invalid JSON body", map[string]any{"cause": err.Error()})
+		h.logFor(r).With("error", err).Info("invalid JSON body")
+		return
+	}
+	rn, err := h.Svc.MergeTags(r.Context(), in.Sources, in.Target)
+	h.writeTagRename(w, r, rn, err)
+}
+
+func (h *HTTPHandler) writeTagRename(w http.ResponseWriter, r *http.Request, rn model.TagRename, err error) {
+	if err != nil {
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), errDetails(err))
+		h.logFor(r).With("error", err).Info("rename tags failed")
+		return
+	}
+	h.logFor(r).Info("tags renamed", "from", rn.From, "to", rn.To, "books", rn.BooksUpdated)
+	writeJSON(w, http.StatusOK, api.TagRename{From: rn.From, To: rn.To, BooksUpdated: rn.BooksUpdated, RenamedAt: rn.RenamedAt.UTC()})
+}
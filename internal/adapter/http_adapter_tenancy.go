@@ -0,0 +1,105 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// tenantPathPrefix starts the paths that name their tenant, as in
+// /tenants/east/api/v1/books.
+const tenantPathPrefix = "/tenants/"
+
+// Tenancy lets one server keep the books of several libraries apart. Its
+// middleware resolves the tenant of each request and puts it in the
+// request context (model.WithTenant), which limits the book repository to
+// that tenant's books.
+type Tenancy struct {
+	Tenants map[string]bool // known tenant IDs
+	Header  string          // header naming the tenant; "" ignores headers
+	Domain  string          // requests to <tenant>.<Domain> are for that tenant; "" ignores hosts
+}
+
+// Middleware takes the tenant from the path prefix /tenants/{tenant},
+// which it strips, the Header and the subdomain of Domain. Sources that
+// name different tenants, an unknown tenant, or a request for the API, the
+// live counters (/ws) or the loans feed without one are refused. Other
+// paths, such as /healthz and /metrics, and the document and schemas
+// describing the API need no tenant. It runs
+// before authentication, which then sees the stripped path.
+func (t *Tenancy) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var tenant, source string
+		name := func(id, from string) bool {
+			if tenant != "" && id != tenant {
+				writeErrFor(w, r, http.StatusBadRequest, "VALIDATION",
+					fmt.Sprintf("the %s names tenant %q but the %s names %q", source, tenant, from, id), nil)
+				return false
+			}
+			tenant, source = id, from
+			return true
+		}
+		if rest, ok := strings.CutPrefix(r.URL.Path, tenantPathPrefix); ok {
+			id, _, _ := strings.Cut(rest, "/")
+			r = stripTenantPath(r, id)
+			name(id, "path")
+		}
+		if h := r.Header.Get(t.Header); t.Header != "" && h != "" && !name(h, t.Header+" header") {
+			return
+		}
+		if id := t.subdomain(r.Host); id != "" && !name(id, "host") {
+			return
+		}
+		switch {
+		case tenant == "" && needsTenant(r.URL.Path):
+			writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", "the request names no tenant", nil)
+			return
+		case tenant == "":
+			next.ServeHTTP(w, r)
+			return
+		case !t.Tenants[tenant]:
+			writeErrFor(w, r, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("unknown tenant %q", tenant), nil)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(model.WithTenant(r.Context(), tenant)))
+	})
+}
+
+// needsTenant reports whether requests for path must name a tenant: those
+// of the API, except the document and schemas describing it, which are
+// the same for every tenant, and those of the live counters and feeds.
+func needsTenant(path string) bool {
+	if path == openAPIPath || path == strings.TrimSuffix(schemasPath, "/") || strings.HasPrefix(path, schemasPath) {
+		return false
+	}
+	return strings.HasPrefix(path, "/api/") || path == "/ws" || strings.HasPrefix(path, "/feeds/")
+}
+
+// subdomain is the tenant named by host: the label before Domain.
+func (t *Tenancy) subdomain(host string) string {
+	if t.Domain == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	id, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(t.Domain))
+	if !ok || strings.Contains(id, ".") {
+		return ""
+	}
+	return id
+}
+
+// stripTenantPath is r without the path prefix naming tenant id, in both
+// forms of the path routers read.
+func stripTenantPath(r *http.Request, id string) *http.Request {
+	r2 := r.Clone(r.Context())
+	r2.URL.Path = "/" + strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, tenantPathPrefix+id), "/")
+	if r.URL.RawPath != "" {
+		r2.URL.RawPath = "/" + strings.TrimPrefix(strings.TrimPrefix(r.URL.RawPath, tenantPathPrefix+url.PathEscape(id)), "/")
+	}
+	return r2
+}
@@ -0,0 +1,64 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenancyMiddleware(t *testing.T) {
+	svc := core.NewService(NewBookRepo(), mockEnrich{})
+	svc.TenantQuotas = map[string]int{"west": 1}
+	tenancy := &Tenancy{Tenants: map[string]bool{"east": true, "west": true}, Header: "X-Tenant-ID", Domain: "books.example.org"}
+	router := chi.NewRouter()
+	router.Use(tenancy.Middleware)
+	api.HandlerFromMux(NewHTTPHandler(svc, slog.New(slog.DiscardHandler)), router)
+
+	do := func(method, host, path, tenant, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r.Host = host
+		if tenant != "" {
+			r.Header.Set("X-Tenant-ID", tenant)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+	const host = "localhost:8080"
+
+	w := do(http.MethodPost, host, "/tenants/east/api/v1/books", "", `{"title":"Dune","isbn":"9780441013593"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var b api.Book
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&b))
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "east.books.example.org", "/api/v1/books", "", `{"isbn":"9780441013593","title":"Dune"}`).Code)
+	assert.Equal(t, http.StatusCreated, do(http.MethodPost, host, "/api/v1/books", "west", `{"title":"Dune","isbn":"9780441013593"}`).Code,
+		"ISBNs are unique per tenant")
+
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, host, "/api/v1/books/"+b.Id, "east", "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, host, "/api/v1/books/"+b.Id, "west", "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, "west.books.example.org:8080", "/api/v1/books/"+b.Id, "", "").Code)
+
+	w = do(http.MethodPost, host, "/api/v1/books", "west", `{"title":"Emma"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "QUOTA_EXCEEDED")
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, host, "/api/v1/books", "", "").Code, "no tenant")
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "west.books.example.org", "/tenants/east/api/v1/books", "", "").Code, "tenants disagree")
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, host, "/api/v1/books", "north", "").Code, "unknown tenant")
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, host, "/ws", "", "").Code, "live counters need a tenant")
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, host, "/feeds/loans.ics", "", "").Code, "feeds need a tenant")
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, host, "/healthz", "", "").Code, "health needs no tenant")
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, host, "/metrics", "", "").Code, "metrics need no tenant")
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, host, "/api/v1/schemas/Book", "", "").Code, "the schemas are the same for every tenant")
+}
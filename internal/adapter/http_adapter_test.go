@@ -6,14 +6,23 @@ import (
 	"book-manager/api"
 	"book-manager/internal/core"
 	"book-manager/internal/core/model"
+	"book-manager/pkg/buildinfo"
 	"book-manager/pkg/util"
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"image"
+	"image/jpeg"
+	"image/png"
 	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
@@ -81,8 +90,18 @@ func TestListBooks_Pagination(t *testing.T) {
 	require.NoError(t, json.NewDecoder(w.Body).Decode(&out))
 	assert.Equal(t, 1, out.Page)
 	assert.Equal(t, 2, out.PageSize)
-	assert.Equal(t, 3, out.Total)
+	assert.Equal(t, util.GetPtr(3), out.Total)
 	assert.Len(t, out.Data, 2)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/books?page=1&page_size=2&count=none", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"total"`)
+	assert.Contains(t, w.Body.String(), `"next_cursor"`)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/books?count=roughly", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestDeleteBook_204_then_404(t *testing.T) {
@@ -102,6 +121,91 @@ func TestDeleteBook_204_then_404(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w2.Code)
 }
 
+func TestETag_ConditionalRequests(t *testing.T) {
+	h, svc := newServer(t)
+	b, err := svc.CreateBook(context.Background(), model.CreateBookInput{Title: util.GetPtr("Seed")})
+	require.NoError(t, err)
+	do := func(method, path, body string, hdr ...string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		for i := 0; i+1 < len(hdr); i += 2 {
+			r.Header.Set(hdr[i], hdr[i+1])
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := do(http.MethodGet, "/api/v1/books/"+b.ID, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.NotEqual(t, etag, do(http.MethodGet, "/api/v1/books/"+b.ID, "", "Accept", jsonAPIMediaType).Header().Get("ETag"), "another representation")
+
+	w = do(http.MethodGet, "/api/v1/books/"+b.ID, "", "If-None-Match", `"other", W/`+etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	list := do(http.MethodGet, "/api/v1/books", "")
+	listTag := list.Header().Get("ETag")
+	require.NotEmpty(t, listTag)
+	assert.Equal(t, http.StatusNotModified, do(http.MethodGet, "/api/v1/books", "", "If-None-Match", listTag).Code)
+
+	w = do(http.MethodPatch, "/api/v1/books/"+b.ID, `{"page_count": 10}`, "If-Match", etag)
+	require.Equal(t, http.StatusOK, w.Code)
+	newTag := w.Header().Get("ETag")
+	assert.NotEqual(t, etag, newTag)
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/v1/books/"+b.ID, "", "If-None-Match", etag).Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/v1/books", "", "If-None-Match", listTag).Code)
+
+	// the stale tag is rejected and nothing is written
+	w = do(http.MethodPut, "/api/v1/books/"+b.ID, `{"title": "Lost"}`, "If-Match", etag)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Contains(t, w.Body.String(), "PRECONDITION_FAILED")
+	assert.Equal(t, http.StatusPreconditionFailed, do(http.MethodDelete, "/api/v1/books/"+b.ID, "", "If-Match", etag).Code)
+	got, err := svc.GetBook(context.Background(), b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Seed", got.Title)
+
+	// a review changes the book's rating, which a cached copy would miss,
+	// but not the book itself, which a write may still be based on
+	svc.Reviews = NewReviewRepo()
+	_, err = svc.AddReview(context.Background(), model.Review{BookID: b.ID, Rating: 4})
+	require.NoError(t, err)
+	w = do(http.MethodGet, "/api/v1/books/"+b.ID, "", "If-None-Match", newTag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"rating":{"average":4`)
+	assert.NotEqual(t, newTag, w.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNoContent, do(http.MethodDelete, "/api/v1/books/"+b.ID, "", "If-Match", newTag).Code)
+}
+
+func TestCreateBook_IdempotencyKey(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Idempotency = NewIdempotencyRepo(time.Hour)
+	post := func(key, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/books", strings.NewReader(body))
+		r.Header.Set("Idempotency-Key", key)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	first := post("k1", `{"title":"Dune","isbn":"9780441013593"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+	retry := post("k1", `{"title":"Dune","isbn":"9780441013593"}`)
+	require.Equal(t, http.StatusCreated, retry.Code, retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, first.Header().Get("Location"), retry.Header().Get("Location"))
+
+	w := post("k1", `{"title":"Dune Messiah"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, http.StatusConflict, post("k2", `{"title":"Dune","isbn":"9780441013593"}`).Code, "a new key is a new request")
+
+	page, err := svc.ListBooks(context.Background(), model.ListQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+}
+
 func TestCreateBook_Validation400(t *testing.T) {
 	h, _ := newServer(t)
 
@@ -113,11 +217,425 @@ func TestCreateBook_Validation400(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestCreateBook_TrashedISBNConflict(t *testing.T) {
+	h, svc := newServer(t)
+	b, err := svc.CreateBook(context.Background(), model.CreateBookInput{Title: util.GetPtr("Dune"), ISBN: util.GetPtr("9780441013593")})
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteBook(context.Background(), b.ID))
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/books", strings.NewReader(`{"title":"Dune","isbn":"9780441013593"}`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusConflict, w.Code)
+	var out errBody
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &out))
+	assert.Equal(t, "CONFLICT", out.Error.Code)
+	assert.Equal(t, b.ID, out.Error.Details["trashed_book_id"])
+}
+
+func TestAutoTagRules_CreateListDelete(t *testing.T) {
+	h, _ := newServer(t)
+
+	body := []byte(`{"field":"title","contains":"go","add_tag":"golang"}`)
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/admin/autotag-rules", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var rule api.AutoTagRule
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&rule))
+	assert.Equal(t, api.AutoTagFieldTitle, rule.Field)
+
+	r = httptest.NewRequest(http.MethodGet, "/api/v1/admin/autotag-rules", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list api.AutoTagRuleList
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	assert.Len(t, list.Data, 1)
+
+	r = httptest.NewRequest(http.MethodDelete, "/api/v1/admin/autotag-rules/"+rule.Id, nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	r = httptest.NewRequest(http.MethodPost, "/api/v1/admin/autotag-rules", bytes.NewReader([]byte(`{"field":"isbn","contains":"1","add_tag":"x"}`)))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCompareBooks_CSV(t *testing.T) {
+	h, svc := newServer(t)
+	_, err := svc.CreateBook(context.Background(), model.CreateBookInput{Title: util.GetPtr("Dune"), ISBN: util.GetPtr("9780441013593")})
+	require.NoError(t, err)
+
+	csv := "title,isbn\nDune,978-0-441-01359-3\nOther,9780000000002\n"
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/books/compare", bytes.NewReader([]byte(csv)))
+	r.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var out api.CompareResult
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&out))
+	require.Len(t, out.Present, 1)
+	assert.Equal(t, "Dune", out.Present[0].Title)
+	assert.Equal(t, []string{"9780000000002"}, out.Missing)
+
+	r = httptest.NewRequest(http.MethodPost, "/api/v1/books/compare", bytes.NewReader([]byte("a,\"b\n")))
+	r.Header.Set("Content-Type", "text/csv")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestBookCover_UploadAndServe(t *testing.T) {
+	h, svc := newServer(t)
+	var err error
+	svc.Covers, err = NewDiskBlobStore(t.TempDir())
+	require.NoError(t, err)
+	b, err := svc.CreateBook(context.Background(), model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	path := "/api/v1/books/" + b.ID + "/cover"
+
+	upload := func(field string, data []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, err := mw.CreateFormFile(field, "cover.png")
+		require.NoError(t, err)
+		_, _ = fw.Write(data)
+		require.NoError(t, mw.Close())
+		r := httptest.NewRequest(http.MethodPost, path, &body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+	img := image.NewRGBA(image.Rect(0, 0, 300, 450))
+	var pngData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, img))
+
+	assert.Equal(t, http.StatusBadRequest, upload("image", pngData.Bytes()).Code, "wrong field")
+	assert.Equal(t, http.StatusBadRequest, upload("file", []byte("GIF89a broken")).Code)
+	w := upload("file", pngData.Bytes())
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var out api.Book
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&out))
+	assert.Equal(t, path, *out.CoverUrl)
+
+	r := httptest.NewRequest(http.MethodGet, path+"?size=m", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+	cfg, err := jpeg.DecodeConfig(w.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 240, cfg.Width)
+
+	r = httptest.NewRequest(http.MethodGet, path+"?size=m", nil)
+	r.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+}
+
+func TestListBooks_InlineCovers(t *testing.T) {
+	h, svc := newServer(t)
+	var err error
+	svc.Covers, err = NewDiskBlobStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Emma"), CoverURL: util.GetPtr("https://example.com/e.png")})
+	require.NoError(t, err)
+	var pngData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, image.NewRGBA(image.Rect(0, 0, 300, 450))))
+	_, err = svc.UploadCover(ctx, b.ID, pngData.Bytes())
+	require.NoError(t, err)
+
+	list := func(query, etag string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/books?sort=title"+query, nil)
+		if etag != "" {
+			r.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+	w := list("&inline_covers=thumb", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var page api.PaginatedBooks
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	require.Len(t, page.Data, 2)
+	require.NotNil(t, page.Data[0].CoverThumb)
+	uri, ok := strings.CutPrefix(*page.Data[0].CoverThumb, "data:image/jpeg;base64,")
+	require.True(t, ok, *page.Data[0].CoverThumb)
+	data, err := base64.StdEncoding.DecodeString(uri)
+	require.NoError(t, err)
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 90, cfg.Width)
+	assert.Nil(t, page.Data[1].CoverThumb, "remote covers are only linked")
+
+	plain := list("", "")
+	assert.NotContains(t, plain.Body.String(), "cover_thumb")
+	assert.NotEqual(t, w.Header().Get("ETag"), plain.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNotModified, list("&inline_covers=thumb", w.Header().Get("ETag")).Code)
+	assert.Equal(t, http.StatusBadRequest, list("&inline_covers=full", "").Code)
+}
+
+func TestBookCover_RedirectsToRemoteCover(t *testing.T) {
+	h, svc := newServer(t)
+	b, err := svc.CreateBook(context.Background(), model.CreateBookInput{
+		Title: util.GetPtr("Dune"), CoverURL: util.GetPtr("https://covers.openlibrary.org/b/id/42-L.jpg"),
+	})
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/books/"+b.ID+"/cover?size=S", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://covers.openlibrary.org/b/id/42-S.jpg", w.Header().Get("Location"))
+}
+
+func TestGetVersion(t *testing.T) {
+	h, _ := newServer(t)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var out api.BuildInfo
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&out))
+	assert.Equal(t, buildinfo.Get().Version, out.Version)
+	assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, out.Platform)
+}
+
+func TestImportExportCSV(t *testing.T) {
+	h, svc := newServer(t)
+	_, err := svc.CreateBook(context.Background(), model.CreateBookInput{Title: util.GetPtr("Dune"), ISBN: util.GetPtr("9780441013593")})
+	require.NoError(t, err)
+
+	csv := "Title,isbn,authors,tags,published_year\n" +
+		"Clean Architecture,9780134494166,Robert C. Martin,software; design,2017\n" +
+		"Dune again,978-0-441-01359-3,,,\n" +
+		"Bad year,,,,soon\n" +
+		"\"Multi\nline\",,Ann;Bob,,\n"
+	rep := importCSV(t, h, csv)
+	assert.Equal(t, 4, rep.Rows)
+	assert.Equal(t, 4, rep.Processed)
+	assert.Equal(t, 2, rep.Created)
+	require.Equal(t, 2, rep.Failed)
+	assert.Equal(t, 3, rep.Errors[0].Line)
+	assert.Equal(t, "CONFLICT", rep.Errors[0].Code)
+	assert.Equal(t, 4, rep.Errors[1].Line)
+	assert.Equal(t, "VALIDATION", rep.Errors[1].Code)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/books/export?format=csv", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	require.Len(t, lines, 5) // header, 3 books, one of them spanning two lines
+	assert.True(t, strings.HasPrefix(lines[0], "id,isbn,title,"))
+	assert.Contains(t, lines[2], ",9780134494166,Clean Architecture,,2017,,,Robert C. Martin,software;design,")
+
+	// an export imports back as is; books with an ISBN are already there
+	rep = importCSV(t, h, w.Body.String())
+	assert.Equal(t, 1, rep.Created)
+	assert.Equal(t, 2, rep.Failed)
+
+	r = httptest.NewRequest(http.MethodGet, "/api/v1/books/export?format=xml", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	r = httptest.NewRequest(http.MethodPost, "/api/v1/books/import", strings.NewReader("title,price\nX,1\n"))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// importCSV starts an import of doc and waits for it to be done.
+func importCSV(t *testing.T, h http.Handler, doc string) api.ImportJob {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/books/import", strings.NewReader(doc))
+	r.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	loc := w.Header().Get("Location")
+	require.True(t, strings.HasPrefix(loc, "/api/v1/imports/"), loc)
+
+	var job api.ImportJob
+	require.Eventually(t, func() bool {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, loc, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&job))
+		return job.Status == api.Done
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NotNil(t, job.FinishedAt)
+	return job
+}
+
+func TestImportCSV_Goodreads(t *testing.T) {
+	h, svc := newServer(t)
+	svc.Bookshelves = NewBookshelfRepo()
+	svc.Progress = NewProgressRepo()
+	csv := "Book Id,Title,Author,Author l-f,Additional Authors,ISBN,ISBN13,My Rating,Number of Pages,Year Published,Date Read,Date Added,Bookshelves,Exclusive Shelf\n" +
+		`234225,Dune,Frank Herbert,"Herbert, Frank",,"=""0441013597""","=""9780441013593""",5,604,1990,2023/05/14,2020/01/02,,read` + "\n" +
+		`1,Good Omens,Terry Pratchett,"Pratchett, Terry",Neil Gaiman,"=""""","=""9780060853983""",0,,2006,,2021/03/04,,currently-reading` + "\n" +
+		`2,Emma,Jane Austen,"Austen, Jane",,"=""""","=""""",0,,,,2021/03/04,,did-not-finish` + "\n" +
+		`3,Bad,Nobody,,,"=""""","=""""",0,,,yesterday,,,read` + "\n"
+	rep := importCSV(t, h, csv)
+	assert.Equal(t, 3, rep.Created)
+	require.Equal(t, 1, rep.Failed)
+	assert.Equal(t, 5, rep.Errors[0].Line)
+	assert.Contains(t, rep.Errors[0].Message, "date_read")
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/books?sort=title", nil))
+	var page api.PaginatedBooks
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	require.Len(t, page.Data, 3)
+	dune, omens := page.Data[0], page.Data[2]
+	assert.Equal(t, "9780441013593", *dune.Isbn)
+	assert.Equal(t, 604, *dune.PageCount)
+	assert.Equal(t, "9780060853983", *omens.Isbn)
+	require.Len(t, omens.Authors, 2)
+	assert.Equal(t, "Neil Gaiman", omens.Authors[1].Name)
+
+	ctx := context.Background()
+
+	shelf := func(id string) []string {
+		page, err := svc.ShelfBooks(ctx, id, 1, 10)
+		require.NoError(t, err)
+		var titles []string
+		for _, b := range page.Data {
+			titles = append(titles, b.Book.Title)
+		}
+		return titles
+	}
+	assert.Equal(t, []string{"Dune"}, shelf(model.BookshelfRead))
+	assert.Equal(t, []string{"Good Omens"}, shelf(model.BookshelfReading))
+	assert.Equal(t, []string{"Emma"}, shelf("did-not-finish"))
+	stats, err := svc.ReadingStats(ctx, 1, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []model.YearBooks{{Year: 2023, Books: 1}}, stats.FinishedPerYear)
+}
+
+func TestListBooksText(t *testing.T) {
+	h, svc := newServer(t)
+	ctx := context.Background()
+	_, err := svc.CreateBook(ctx, model.CreateBookInput{
+		Title: util.GetPtr("Clean Architecture"), Subtitle: util.GetPtr("A Craftsman's Guide"),
+		ISBN: util.GetPtr("9780134494166"), PublishedYear: util.GetPtr(2017),
+		Authors: []string{"Robert C. Martin"}, Tags: []string{"software"},
+	})
+	require.NoError(t, err)
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune"), Tags: []string{"scifi"}})
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/books.txt?sort=title", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Clean Architecture: A Craftsman's Guide by Robert C. Martin (2017), ISBN 9780134494166\nDune\n", w.Body.String())
+
+	tmpl, err := NewTextTemplate("{{.N}}\t{{.Title}}\t{{join .Tags \",\"}}\n")
+	require.NoError(t, err)
+	custom := NewHTTPHandler(svc, slog.New(slog.NewTextHandler(httptest.NewRecorder(), nil)))
+	custom.TextTemplate = tmpl
+	w = httptest.NewRecorder()
+	custom.ListBooksText(w, httptest.NewRequest(http.MethodGet, "/api/v1/books.txt", nil), api.ListBooksTextParams{Tag: util.GetPtr("scifi")})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1\tDune\tscifi\n", w.Body.String())
+}
+
+func TestExportBooks_MarkdownAndOrg(t *testing.T) {
+	h, svc := newServer(t)
+	ctx := context.Background()
+	_, err := svc.CreateBook(ctx, model.CreateBookInput{
+		Title: util.GetPtr("Pipes | Filters"), ISBN: util.GetPtr("9780134494166"), PublishedYear: util.GetPtr(2017),
+		Authors: []string{"Ann"}, Tags: []string{"unix", "command-line"},
+	})
+	require.NoError(t, err)
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune"), Tags: []string{"scifi"}})
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/books/export?format=markdown&tag=unix", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/markdown; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "| Title | Authors | Year | ISBN | Tags |\n| --- | --- | --- | --- | --- |\n"+
+		"| Pipes \\| Filters | Ann | 2017 | 9780134494166 | unix, command-line |\n", w.Body.String())
+
+	r = httptest.NewRequest(http.MethodGet, "/api/v1/books/export?format=org&sort=title", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.True(t, strings.HasPrefix(body, "#+TITLE: Books\n\n* Dune :scifi:\n  :PROPERTIES:\n  :ID: "), body)
+	assert.Contains(t, body, "* Pipes | Filters :unix:command_line:\n")
+	assert.Contains(t, body, "  :AUTHORS: Ann\n  :YEAR: 2017\n  :ISBN: 9780134494166\n  :END:\n")
+}
+
+func TestExportBooks_TimeZone(t *testing.T) {
+	h, svc := newServer(t)
+	b, err := svc.CreateBook(context.Background(), model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		return w
+	}
+
+	w := get("/api/v1/books/" + b.ID)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"created_at":"`+b.CreatedAt.UTC().Format(time.RFC3339Nano)+`"`)
+
+	ist := b.CreatedAt.In(time.FixedZone("IST", 5*3600+1800)).Format(time.RFC3339)
+	w = get("/api/v1/books/export")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), b.CreatedAt.UTC().Format(time.RFC3339))
+	w = get("/api/v1/books/export?tz=Asia/Kolkata")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), ","+ist+",")
+	w = get("/api/v1/books/export?format=org&tz=Asia/Kolkata")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "  :CREATED: "+ist+"\n")
+
+	tmpl, err := NewTextTemplate(`{{.Created.Format "2006-01-02T15:04:05Z07:00"}}`)
+	require.NoError(t, err)
+	custom := NewHTTPHandler(svc, slog.New(slog.NewTextHandler(httptest.NewRecorder(), nil)))
+	custom.TextTemplate = tmpl
+	w = httptest.NewRecorder()
+	custom.ListBooksText(w, httptest.NewRequest(http.MethodGet, "/api/v1/books.txt", nil), api.ListBooksTextParams{Tz: util.GetPtr("Asia/Kolkata")})
+	assert.Equal(t, ist, w.Body.String())
+
+	for _, tz := range []string{"Mars/Olympus", "Local"} {
+		w = get("/api/v1/books/export?tz=" + tz)
+		assert.Equal(t, http.StatusBadRequest, w.Code, tz)
+		assert.Contains(t, w.Body.String(), "unknown time zone")
+		w = get("/api/v1/books.txt?tz=" + tz)
+		assert.Equal(t, http.StatusBadRequest, w.Code, tz)
+	}
+}
+
 // create test server
 func newServer(t *testing.T) (http.Handler, *core.Service) {
 	t.Helper()
 	repo := NewBookRepo()
 	svc := core.NewService(repo, mockEnrich{})
+	svc.Rules = NewAutoTagRuleRepo()
+	svc.Authors = NewAuthorRepo()
+	svc.Audit = NewAuditRepo()
+	svc.Exclusions = NewDuplicateExclusionRepo()
+	svc.Branches = NewBranchRepo()
+	svc.Imports = NewImportRepo()
+	t.Cleanup(svc.StopImports)
 	logger := slog.New(slog.NewTextHandler(httptest.NewRecorder(), nil))
 	h := NewHTTPHandler(svc, logger)
 
@@ -0,0 +1,105 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/internal/core/model"
+	"bytes"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// defaultTextTemplate renders one plain line per book, which reads well in a
+// screen reader and stays easy to grep, cut or sort.
+const defaultTextTemplate = `{{.Title}}{{with .Subtitle}}: {{.}}{{end}}` +
+	`{{with .Authors}} by {{join . ", "}}{{end}}{{with .Year}} ({{.}}){{end}}` +
+	`{{with .ISBN}}, ISBN {{.}}{{end}}` + "\n"
+
+// textBook is the data a text template sees for each book. Missing values
+// are zero, so templates can test them with "with" or "if".
+type textBook struct {
+	N        int // 1-based position in the listing
+	ID       string
+	ISBN     string
+	Title    string
+	Subtitle string
+	Authors  []string
+	Tags     []string
+	Year     int
+	Pages    int
+	Created  time.Time // in the requested time zone
+	Updated  time.Time
+}
+
+// NewTextTemplate parses a template for GET /api/v1/books.txt. It is
+// executed once per book with fields N, ID, ISBN, Title, Subtitle, Authors,
+// Tags, Year, Pages, Created and Updated; join is available for the lists.
+func NewTextTemplate(src string) (*template.Template, error) {
+	return template.New("book").Funcs(template.FuncMap{"join": strings.Join}).Parse(src)
+}
+
+var defaultText = template.Must(NewTextTemplate(defaultTextTemplate))
+
+func (h *HTTPHandler) ListBooksText(w http.ResponseWriter, r *http.Request, p api.ListBooksTextParams) {
+	tmpl := h.TextTemplate
+	if tmpl == nil {
+		tmpl = defaultText
+	}
+	loc, err := timeZone(p.Tz)
+	if err != nil {
+		writeErrFor(w, r, http.StatusBadRequest, "VALIDATION", err.Error(), map[string]any{"tz": *p.Tz})
+		return
+	}
+	q := toListQuery(api.ListBooksParams{Q: p.Q, Author: p.Author, Year: p.Year, YearFrom: p.YearFrom, YearTo: p.YearTo, UpdatedSince: p.UpdatedSince, Tag: p.Tag, IncludeChildren: p.IncludeChildren, Branch: p.Branch, Barcode: p.Barcode, Available: p.Available, Sort: p.Sort, IncludeDeleted: p.IncludeDeleted})
+
+	var buf bytes.Buffer
+	n, written := 0, false
+	err = h.Svc.WalkBooks(r.Context(), q, func(b model.Book) error {
+		n++
+		buf.Reset()
+		if err := tmpl.Execute(&buf, toTextBook(n, b, loc)); err != nil {
+			return err
+		}
+		if !written {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			written = true
+		}
+		_, err := w.Write(buf.Bytes())
+		return err
+	})
+	switch {
+	case err != nil && !written:
+		status, code := mapSvcErr(err)
+		writeErrFor(w, r, status, code, err.Error(), nil)
+		h.logFor(r).With("error", err).Info("list books text failed")
+	case err != nil:
+		// the status line is gone by now; the client sees a truncated body
+		h.logFor(r).With("error", err).Warn("list books text failed", "books", n)
+	case !written:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+}
+
+func toTextBook(n int, b model.Book, loc *time.Location) textBook {
+	return textBook{
+		N:        n,
+		ID:       b.ID,
+		ISBN:     deref(b.ISBN),
+		Title:    b.Title,
+		Subtitle: deref(b.Subtitle),
+		Authors:  b.Authors,
+		Tags:     b.Tags,
+		Year:     valueOrZero(b.PublishedYear),
+		Pages:    valueOrZero(b.PageCount),
+		Created:  b.CreatedAt.In(loc),
+		Updated:  b.UpdatedAt.In(loc),
+	}
+}
+
+func valueOrZero(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
@@ -0,0 +1,22 @@
+package adapter
+
+import (
+	"book-manager/api"
+	"book-manager/pkg/buildinfo"
+	"net/http"
+)
+
+func (h *HTTPHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	bi := buildinfo.Get()
+	out := api.BuildInfo{
+		Version:   bi.Version,
+		Commit:    strPtrOrNil(bi.Commit),
+		Date:      strPtrOrNil(bi.Date),
+		GoVersion: bi.GoVersion,
+		Platform:  bi.Platform,
+	}
+	if bi.Modified {
+		out.Modified = &bi.Modified
+	}
+	writeJSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,190 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with -update to rewrite the golden files after an intended wire change:
+//
+//	go test -tags=unit ./internal/adapter -run TestGolden -update
+var update = flag.Bool("update", false, "rewrite golden files")
+
+var (
+	uuidRe = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	timeRe = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+	// cursors embed ids and timestamps
+	cursorRe = regexp.MustCompile(`("next_cursor": "|cursor=)[A-Za-z0-9_-]+`)
+)
+
+type goldenCase struct {
+	name   string
+	method string
+	path   string // "{id}" is replaced with the id of the first seeded book
+	body   string
+	accept string
+}
+
+var goldenV1 = []goldenCase{
+	{name: "create_book", method: http.MethodPost, path: "/api/v1/books",
+		body: `{"title":"Dune","authors":["Frank Herbert"],"isbn":"9780441013593","tags":["sf"]}`},
+	{name: "create_book_validation", method: http.MethodPost, path: "/api/v1/books", body: `{}`},
+	{name: "create_book_conflict", method: http.MethodPost, path: "/api/v1/books",
+		body: `{"title":"Again","isbn":"978-0-12-345678-6"}`},
+	{name: "create_books_batch", method: http.MethodPost, path: "/api/v1/books:batch",
+		body: `{"items":[{"book":{"title":"Batch One","tags":["b"]}},{"book":{"title":"Dup","isbn":"9780123456786"}},{"book":{"title":""}}]}`},
+	{name: "compare_books", method: http.MethodPost, path: "/api/v1/books/compare",
+		body: `{"isbns":["9780123456786","9780441013593","978-0-12-345678-6"]}`},
+	{name: "get_book", method: http.MethodGet, path: "/api/v1/books/{id}"},
+	{name: "create_book_invalid_isbn", method: http.MethodPost, path: "/api/v1/books",
+		body: `{"title":"Typo","isbn":"978-0-13-449416-7"}`},
+	{name: "get_book_not_found", method: http.MethodGet, path: "/api/v1/books/missing"},
+	{name: "list_books", method: http.MethodGet, path: "/api/v1/books?page=1&page_size=1&sort=title"},
+	{name: "get_book_hal", method: http.MethodGet, path: "/api/v1/books/{id}", accept: "application/hal+json"},
+	{name: "get_book_jsonapi", method: http.MethodGet, path: "/api/v1/books/{id}", accept: "application/vnd.api+json"},
+	{name: "get_book_not_found_jsonapi", method: http.MethodGet, path: "/api/v1/books/missing", accept: "application/vnd.api+json"},
+	{name: "list_books_bad_cursor", method: http.MethodGet, path: "/api/v1/books?cursor=not-a-cursor"},
+	{name: "semantic_search_not_configured", method: http.MethodGet, path: "/api/v1/books/semantic-search?q=desert"},
+	{name: "similar_books_unknown_book", method: http.MethodGet, path: "/api/v1/books/e8f506eb-8f2b-4fa2-afd9-7d0087da2568/similar"},
+	{name: "parse_book", method: http.MethodPost, path: "/api/v1/books/parse",
+		body: `{"text":"Herbert, Frank. (1965). Dune. Chilton Books. ISBN 978-0-441-01359-3"}`},
+	{name: "parse_book_empty", method: http.MethodPost, path: "/api/v1/books/parse", body: `{"text":""}`},
+	{name: "book_summary", method: http.MethodGet, path: "/api/v1/books/{id}/summary"},
+	{name: "book_summary_not_found", method: http.MethodGet, path: "/api/v1/books/missing/summary"},
+	{name: "health", method: http.MethodGet, path: "/healthz"},
+	{name: "list_tags", method: http.MethodGet, path: "/api/v1/tags"},
+	{name: "replace_book_tags", method: http.MethodPut, path: "/api/v1/books/{id}/tags", body: `{"tags":["classics"," classics ","to-read"]}`},
+	{name: "replace_book_tags_empty_tag", method: http.MethodPut, path: "/api/v1/books/{id}/tags", body: `{"tags":[""]}`},
+	{name: "rename_tag", method: http.MethodPost, path: "/api/v1/tags/rename", body: `{"from":"seed","to":"seeded"}`},
+	{name: "rename_tag_not_found", method: http.MethodPost, path: "/api/v1/tags/rename", body: `{"from":"missing","to":"other"}`},
+	{name: "merge_tags_into_itself", method: http.MethodPost, path: "/api/v1/tags/merge", body: `{"sources":["seed"],"target":"seed"}`},
+	{name: "book_cover_none", method: http.MethodGet, path: "/api/v1/books/{id}/cover"},
+	{name: "book_cover_bad_size", method: http.MethodGet, path: "/api/v1/books/{id}/cover?size=XL"},
+	{name: "cover_prefetch_disabled", method: http.MethodPost, path: "/api/v1/admin/covers/prefetch"},
+	{name: "list_dead_letters_empty", method: http.MethodGet, path: "/api/v1/admin/deadletters"},
+	{name: "replay_dead_letter_not_found", method: http.MethodPost, path: "/api/v1/admin/deadletters/missing/replay"},
+	{name: "restore_book_not_deleted", method: http.MethodPost, path: "/api/v1/books/{id}/restore"},
+	{name: "purge_books_empty", method: http.MethodPost, path: "/api/v1/admin/books/purge"},
+	{name: "book_history", method: http.MethodGet, path: "/api/v1/books/{id}/history"},
+	{name: "book_history_not_found", method: http.MethodGet, path: "/api/v1/books/missing/history"},
+	{name: "audit_log_bad_limit", method: http.MethodGet, path: "/api/v1/admin/audit?limit=5000"},
+	{name: "duplicate_books", method: http.MethodGet, path: "/api/v1/books/duplicates?mode=title&page_size=5"},
+	{name: "duplicate_books_bad_mode", method: http.MethodGet, path: "/api/v1/books/duplicates?mode=isbn"},
+	{name: "create_duplicate_exclusion_one_book", method: http.MethodPost, path: "/api/v1/books/duplicates/exclusions", body: `{"book_ids":["only-one"]}`},
+	{name: "import_reading_list_bad_body", method: http.MethodPost, path: "/api/v1/books/import/openlibrary", body: `{"username":42}`},
+	{name: "list_shelf_syncs", method: http.MethodGet, path: "/api/v1/admin/shelf-syncs"},
+	{name: "sync_shelves_unconfigured", method: http.MethodPost, path: "/api/v1/admin/shelf-syncs"},
+	{name: "tag_tree", method: http.MethodGet, path: "/api/v1/tags/tree"},
+	{name: "list_books_tag_children", method: http.MethodGet, path: "/api/v1/books?tag=seed&include_children=true"},
+	{name: "replace_book_tags_empty_level", method: http.MethodPut, path: "/api/v1/books/{id}/tags", body: `{"tags":["programming//go"]}`},
+	{name: "list_circuit_breakers", method: http.MethodGet, path: "/api/v1/admin/circuit-breakers"},
+	{name: "list_recent_views_untracked", method: http.MethodGet, path: "/api/v1/books/recent-views"},
+	{name: "list_books_uncounted_jsonapi", method: http.MethodGet, path: "/api/v1/books?page=1&page_size=1&sort=title&count=none", accept: "application/vnd.api+json"},
+	{name: "create_branch", method: http.MethodPost, path: "/api/v1/branches", body: `{"id":"east","name":"East Branch","address":"1 Main St"}`},
+	{name: "create_branch_bad_id", method: http.MethodPost, path: "/api/v1/branches", body: `{"id":"East Branch","name":"East"}`},
+	{name: "set_book_copies_unknown_branch", method: http.MethodPut, path: "/api/v1/books/{id}/copies/east", body: `{"copies":2}`},
+	{name: "create_book_many_invalid", method: http.MethodPost, path: "/api/v1/books",
+		body: `{"isbn":"978-0-12-345678-0","published_year":1200,"page_count":0}`},
+	{name: "add_book_item_invalid", method: http.MethodPost, path: "/api/v1/books/{id}/items", body: `{"barcode":"-x","condition":"mint"}`},
+	{name: "list_books_jsonapi", method: http.MethodGet, path: "/api/v1/books?page=1&page_size=1&sort=title", accept: "application/vnd.api+json"},
+	{name: "update_book", method: http.MethodPut, path: "/api/v1/books/{id}",
+		body: `{"title":"Seed One, 2nd ed.","isbn":"9780123456786","authors":["Ann Author"]}`},
+	{name: "update_book_stale_version", method: http.MethodPut, path: "/api/v1/books/{id}", body: `{"title":"Late edit","version":7}`},
+	{name: "update_book_not_found", method: http.MethodPut, path: "/api/v1/books/missing", body: `{"title":"X"}`},
+	{name: "patch_book", method: http.MethodPatch, path: "/api/v1/books/{id}", body: `{"page_count":321}`},
+	{name: "patch_book_validation", method: http.MethodPatch, path: "/api/v1/books/{id}", body: `{"published_year":99}`},
+	{name: "patch_book_price_target", method: http.MethodPatch, path: "/api/v1/books/{id}", body: `{"price_target":{"amount":19.99,"currency":"EUR"}}`},
+	{name: "get_book_prices", method: http.MethodGet, path: "/api/v1/books/{id}/prices"},
+	{name: "delete_book_not_found", method: http.MethodDelete, path: "/api/v1/books/missing"},
+	{name: "list_autotag_rules", method: http.MethodGet, path: "/api/v1/admin/autotag-rules"},
+	{name: "list_authors", method: http.MethodGet, path: "/api/v1/authors"},
+	{name: "create_author_conflict", method: http.MethodPost, path: "/api/v1/authors", body: `{"name":"ann author"}`},
+	{name: "get_author_not_found", method: http.MethodGet, path: "/api/v1/authors/missing"},
+	{name: "get_author_not_found_jsonapi", method: http.MethodGet, path: "/api/v1/authors/missing", accept: "application/vnd.api+json"},
+	{name: "rename_tag_validation_jsonapi", method: http.MethodPost, path: "/api/v1/tags/rename", body: `{`, accept: "application/vnd.api+json"},
+	{name: "list_author_renames", method: http.MethodGet, path: "/api/v1/authors/renames"},
+}
+
+func TestGolden_V1(t *testing.T) {
+	runGolden(t, "v1", goldenV1)
+}
+
+func runGolden(t *testing.T, version string, cases []goldenCase) {
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, id := goldenServer(t)
+			path := strings.ReplaceAll(tc.path, "{id}", id)
+			r := httptest.NewRequest(tc.method, path, strings.NewReader(tc.body))
+			if tc.body != "" {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			if tc.accept != "" {
+				r.Header.Set("Accept", tc.accept)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			got := goldenText(t, w)
+			file := filepath.Join("testdata", "golden", version, tc.name+".golden")
+			if *update {
+				require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+				require.NoError(t, os.WriteFile(file, []byte(got), 0o644))
+				return
+			}
+			want, err := os.ReadFile(file)
+			require.NoError(t, err, "missing golden file; run with -update")
+			assert.Equal(t, string(want), got)
+		})
+	}
+}
+
+// goldenServer returns a server seeded with two books and the id of the first.
+func goldenServer(t *testing.T) (http.Handler, string) {
+	t.Helper()
+	h, svc := newServer(t)
+	ctx := context.Background()
+	first, err := svc.CreateBook(ctx, model.CreateBookInput{
+		Title:   util.GetPtr("Seed One"),
+		Authors: []string{"Ann Author"},
+		ISBN:    util.GetPtr("978-0-12-345678-6"),
+		Tags:    []string{"seed"},
+	})
+	require.NoError(t, err)
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Seed Two")})
+	require.NoError(t, err)
+	_, err = svc.CreateAutoTagRule(ctx, model.AutoTagRule{Field: "author", Contains: "ann", AddTag: "ann"})
+	require.NoError(t, err)
+	return h, first.ID
+}
+
+// goldenText renders the status line and the indented body with generated
+// ids and timestamps replaced by placeholders.
+func goldenText(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	body := w.Body.Bytes()
+	var buf bytes.Buffer
+	if len(bytes.TrimSpace(body)) > 0 {
+		require.NoError(t, json.Indent(&buf, body, "", "  "))
+	}
+	out := uuidRe.ReplaceAllString(buf.String(), "<uuid>")
+	out = timeRe.ReplaceAllString(out, "<timestamp>")
+	out = cursorRe.ReplaceAllString(out, "${1}<cursor>")
+	return fmt.Sprintf("HTTP %d\n%s\n", w.Code, strings.TrimSpace(out))
+}
@@ -0,0 +1,130 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// IdempotencyRepo keeps Idempotency-Keys for ttl after they were first
+// sent. Expired keys are swept when the repo is opened and then at most
+// once a minute, when a key is reserved.
+type IdempotencyRepo struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	records   map[string]model.IdempotencyRecord
+	store     recordStore[model.IdempotencyRecord]
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewIdempotencyRepo keeps the keys in memory; they are lost on restart.
+func NewIdempotencyRepo(ttl time.Duration) *IdempotencyRepo {
+	return &IdempotencyRepo{ttl: ttl, records: map[string]model.IdempotencyRecord{}, store: memoryRecords[model.IdempotencyRecord]{}, now: time.Now}
+}
+
+// OpenIdempotencyRepo loads the keys kept in path, which need not exist
+// yet.
+func OpenIdempotencyRepo(path string, ttl time.Duration) (*IdempotencyRepo, error) {
+	j, records, err := openRecordJournal[model.IdempotencyRecord](path)
+	if err != nil {
+		return nil, err
+	}
+	return openIdempotencyRepo(j, records, ttl)
+}
+
+// OpenBoltIdempotencyRepo loads the keys kept in db's idempotency_keys
+// bucket.
+func OpenBoltIdempotencyRepo(db *bolt.DB, ttl time.Duration) (*IdempotencyRepo, error) {
+	b, records, err := openBoltRecords[model.IdempotencyRecord](db, "idempotency_keys")
+	if err != nil {
+		return nil, err
+	}
+	return openIdempotencyRepo(b, records, ttl)
+}
+
+func openIdempotencyRepo(store recordStore[model.IdempotencyRecord], records map[string]model.IdempotencyRecord, ttl time.Duration) (*IdempotencyRepo, error) {
+	r := &IdempotencyRepo{ttl: ttl, records: records, store: store, now: time.Now}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.sweep(r.now()); err != nil {
+		_ = store.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *IdempotencyRepo) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.store.Close()
+}
+
+func (r *IdempotencyRepo) Reserve(_ context.Context, key, fingerprint string) (model.IdempotencyRecord, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.store.writable(); err != nil {
+		return model.IdempotencyRecord{}, false, err
+	}
+	now := r.now()
+	if now.Sub(r.lastSweep) >= time.Minute {
+		if err := r.sweep(now); err != nil {
+			return model.IdempotencyRecord{}, false, err
+		}
+	}
+	if rec, ok := r.records[key]; ok && !r.expired(rec, now) {
+		return rec, false, nil
+	}
+	rec := model.IdempotencyRecord{Key: key, Fingerprint: fingerprint, CreatedAt: now}
+	r.records[key] = rec
+	return rec, true, r.store.put(key, rec)
+}
+
+func (r *IdempotencyRepo) Complete(_ context.Context, key, bookID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.store.writable(); err != nil {
+		return err
+	}
+	rec, ok := r.records[key]
+	if !ok {
+		return nil
+	}
+	rec.BookID = bookID
+	r.records[key] = rec
+	return r.store.put(key, rec)
+}
+
+func (r *IdempotencyRepo) Release(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.store.writable(); err != nil {
+		return err
+	}
+	if _, ok := r.records[key]; !ok {
+		return nil
+	}
+	delete(r.records, key)
+	return r.store.delete(key)
+}
+
+// sweep forgets the keys expired at now; callers hold mu.
+func (r *IdempotencyRepo) sweep(now time.Time) error {
+	for k, rec := range r.records {
+		if r.expired(rec, now) {
+			delete(r.records, k)
+			if err := r.store.delete(k); err != nil {
+				return err
+			}
+		}
+	}
+	r.lastSweep = now
+	return nil
+}
+
+func (r *IdempotencyRepo) expired(rec model.IdempotencyRecord, now time.Time) bool {
+	return now.Sub(rec.CreatedAt) >= r.ttl
+}
@@ -0,0 +1,75 @@
+//go:build unit
+
+package adapter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyRepo(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	r := NewIdempotencyRepo(time.Hour)
+	r.now = func() time.Time { return now }
+
+	_, ok, err := r.Reserve(ctx, "k", "fp")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	rec, ok, err := r.Reserve(ctx, "k", "other")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, "fp", rec.Fingerprint)
+	assert.Empty(t, rec.BookID, "still in progress")
+
+	require.NoError(t, r.Complete(ctx, "k", "book-1"))
+	rec, _, _ = r.Reserve(ctx, "k", "fp")
+	assert.Equal(t, "book-1", rec.BookID)
+
+	now = now.Add(time.Hour)
+	rec, ok, _ = r.Reserve(ctx, "k", "fp2")
+	assert.True(t, ok, "an expired key is claimed afresh")
+	assert.Equal(t, "fp2", rec.Fingerprint)
+
+	require.NoError(t, r.Release(ctx, "k"))
+	_, ok, _ = r.Reserve(ctx, "k", "fp3")
+	assert.True(t, ok)
+
+	_, _, _ = r.Reserve(ctx, "old", "fp")
+	now = now.Add(2 * time.Hour)
+	_, _, _ = r.Reserve(ctx, "new", "fp")
+	assert.Len(t, r.records, 1, "expired keys are swept")
+}
+
+func TestIdempotencyRepo_Persists(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "idempotency.journal")
+	r, err := OpenIdempotencyRepo(path, time.Hour)
+	require.NoError(t, err)
+	r.now = func() time.Time { return time.Now().Add(-2 * time.Hour) }
+	_, _, err = r.Reserve(ctx, "old", "fp")
+	require.NoError(t, err)
+	r.now = time.Now
+	_, _, err = r.Reserve(ctx, "k", "fp")
+	require.NoError(t, err)
+	require.NoError(t, r.Complete(ctx, "k", "book-1"))
+	_, _, err = r.Reserve(ctx, "failed", "fp")
+	require.NoError(t, err)
+	require.NoError(t, r.Release(ctx, "failed"))
+	require.NoError(t, r.Close())
+
+	r, err = OpenIdempotencyRepo(path, time.Hour)
+	require.NoError(t, err)
+	defer r.Close()
+	rec, ok, err := r.Reserve(ctx, "k", "fp")
+	require.NoError(t, err)
+	assert.False(t, ok, "a retry after a restart is still recognized")
+	assert.Equal(t, "book-1", rec.BookID)
+	assert.NotContains(t, r.records, "old", "expired keys are swept on open")
+	assert.NotContains(t, r.records, "failed")
+}
@@ -0,0 +1,169 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// importRowChunk is how many rows of an import one record keeps.
+const importRowChunk = 500
+
+// ImportRepo keeps import jobs and their rows. Rows are kept in records of
+// importRowChunk rows, next to the job's own record, and dropped once the
+// job is done.
+type ImportRepo struct {
+	mu    sync.Mutex
+	jobs  map[string]model.ImportJob
+	rows  map[string][]model.ImportRow // of unfinished jobs
+	store recordStore[importRecord]
+}
+
+// importRecord is a job, under "job:<id>", or a chunk of its rows, under
+// "rows:<id>:<chunk>".
+type importRecord struct {
+	Job  *model.ImportJob  `json:"job,omitempty"`
+	Rows []model.ImportRow `json:"rows,omitempty"`
+}
+
+// NewImportRepo keeps the imports in memory; they are lost on restart.
+func NewImportRepo() *ImportRepo {
+	r, _ := openImportRepo(memoryRecords[importRecord]{}, nil)
+	return r
+}
+
+// OpenImportRepo loads the imports kept in path, which need not exist yet.
+func OpenImportRepo(path string) (*ImportRepo, error) {
+	j, records, err := openRecordJournal[importRecord](path)
+	if err != nil {
+		return nil, err
+	}
+	return openImportRepo(j, records)
+}
+
+// OpenBoltImportRepo loads the imports kept in db's imports bucket.
+func OpenBoltImportRepo(db *bolt.DB) (*ImportRepo, error) {
+	b, records, err := openBoltRecords[importRecord](db, "imports")
+	if err != nil {
+		return nil, err
+	}
+	return openImportRepo(b, records)
+}
+
+func openImportRepo(store recordStore[importRecord], records map[string]importRecord) (*ImportRepo, error) {
+	r := &ImportRepo{jobs: map[string]model.ImportJob{}, rows: map[string][]model.ImportRow{}, store: store}
+	chunks := map[string][]string{}
+	for key, rec := range records {
+		switch {
+		case rec.Job != nil:
+			r.jobs[rec.Job.ID] = *rec.Job
+		case strings.HasPrefix(key, "rows:"):
+			id, _, _ := strings.Cut(strings.TrimPrefix(key, "rows:"), ":")
+			chunks[id] = append(chunks[id], key)
+		}
+	}
+	for id, keys := range chunks {
+		slices.Sort(keys) // the chunk numbers are zero-padded
+		for _, key := range keys {
+			r.rows[id] = append(r.rows[id], records[key].Rows...)
+		}
+	}
+	return r, nil
+}
+
+func (r *ImportRepo) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.store.Close()
+}
+
+func importRowsKey(id string, chunk int) string {
+	return fmt.Sprintf("rows:%s:%08d", id, chunk)
+}
+
+func (r *ImportRepo) Create(_ context.Context, job model.ImportJob, rows []model.ImportRow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.store.writable(); err != nil {
+		return err
+	}
+	if _, ok := r.jobs[job.ID]; ok {
+		return model.ErrConflict
+	}
+	r.rows[job.ID] = slices.Clone(rows)
+	for i := 0; i < len(rows); i += importRowChunk {
+		chunk := rows[i:min(i+importRowChunk, len(rows))]
+		if err := r.store.put(importRowsKey(job.ID, i/importRowChunk), importRecord{Rows: chunk}); err != nil {
+			return err
+		}
+	}
+	r.jobs[job.ID] = job
+	return r.store.put("job:"+job.ID, importRecord{Job: &job})
+}
+
+func (r *ImportRepo) Get(_ context.Context, id string) (model.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return model.ImportJob{}, model.ErrNotFound
+	}
+	job.Errors = slices.Clone(job.Errors)
+	return job, nil
+}
+
+func (r *ImportRepo) Rows(_ context.Context, id string, from, n int) ([]model.ImportRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows := r.rows[id]
+	if from >= len(rows) {
+		return nil, nil
+	}
+	return slices.Clone(rows[from:min(from+n, len(rows))]), nil
+}
+
+// Save drops the rows of a job that is done.
+func (r *ImportRepo) Save(_ context.Context, job model.ImportJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.store.writable(); err != nil {
+		return err
+	}
+	if _, ok := r.jobs[job.ID]; !ok {
+		return model.ErrNotFound
+	}
+	job.Errors = slices.Clone(job.Errors)
+	r.jobs[job.ID] = job
+	if err := r.store.put("job:"+job.ID, importRecord{Job: &job}); err != nil {
+		return err
+	}
+	if job.Status != model.ImportDone {
+		return nil
+	}
+	n := len(r.rows[job.ID])
+	delete(r.rows, job.ID)
+	for i := 0; i < n; i += importRowChunk {
+		if err := r.store.delete(importRowsKey(job.ID, i/importRowChunk)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ImportRepo) Unfinished(_ context.Context) ([]model.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []model.ImportJob
+	for _, job := range r.jobs {
+		if job.Status != model.ImportDone {
+			out = append(out, job)
+		}
+	}
+	slices.SortFunc(out, func(a, b model.ImportJob) int { return a.StartedAt.Compare(b.StartedAt) })
+	return out, nil
+}
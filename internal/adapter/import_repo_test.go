@@ -0,0 +1,81 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportRepo_Persists(t *testing.T) {
+	// open returns the repository kept in dir and what closes it
+	for name, open := range map[string]func(t *testing.T, dir string) (*ImportRepo, func() error){
+		"file": func(t *testing.T, dir string) (*ImportRepo, func() error) {
+			r, err := OpenImportRepo(filepath.Join(dir, "imports.journal"))
+			require.NoError(t, err)
+			return r, r.Close
+		},
+		"bolt": func(t *testing.T, dir string) (*ImportRepo, func() error) {
+			db, err := OpenBoltDB(filepath.Join(dir, "books.db"))
+			require.NoError(t, err)
+			r, err := OpenBoltImportRepo(db)
+			require.NoError(t, err)
+			return r, db.Close
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			dir := t.TempDir()
+			now := time.Now().UTC().Truncate(time.Second)
+			rows := make([]model.ImportRow, 2*importRowChunk+3)
+			for i := range rows {
+				rows[i] = model.ImportRow{Line: i + 2, Input: model.CreateBookInput{Title: util.GetPtr(fmt.Sprintf("Book %d", i))}}
+			}
+			r, closeRepo := open(t, dir)
+			paused := model.ImportJob{ID: "i-1", Tenant: "west", Status: model.ImportRunning, Rows: len(rows), StartedAt: now, UpdatedAt: now}
+			require.NoError(t, r.Create(ctx, paused, rows))
+			assert.ErrorIs(t, r.Create(ctx, paused, nil), model.ErrConflict)
+			paused.Status, paused.Offset, paused.Created = model.ImportPaused, 600, 600
+			require.NoError(t, r.Save(ctx, paused))
+			done := model.ImportJob{ID: "i-2", Status: model.ImportRunning, Rows: 1, StartedAt: now.Add(time.Second)}
+			require.NoError(t, r.Create(ctx, done, rows[:1]))
+			done.Status, done.Offset, done.FinishedAt = model.ImportDone, 1, &now
+			require.NoError(t, r.Save(ctx, done))
+			assert.ErrorIs(t, r.Save(ctx, model.ImportJob{ID: "nope"}), model.ErrNotFound)
+			require.NoError(t, closeRepo())
+
+			r, closeRepo = open(t, dir)
+			defer closeRepo()
+			jobs, err := r.Unfinished(ctx)
+			require.NoError(t, err)
+			require.Len(t, jobs, 1)
+			assert.Equal(t, paused.ID, jobs[0].ID)
+			assert.Equal(t, model.ImportPaused, jobs[0].Status)
+			assert.Equal(t, 600, jobs[0].Offset)
+			assert.Equal(t, "west", jobs[0].Tenant)
+
+			got, err := r.Rows(ctx, paused.ID, 600, 100)
+			require.NoError(t, err)
+			require.Len(t, got, 100)
+			assert.Equal(t, "Book 600", *got[0].Input.Title, "rows are read back in order")
+			got, err = r.Rows(ctx, paused.ID, len(rows)-2, 100)
+			require.NoError(t, err)
+			assert.Len(t, got, 2)
+
+			job, err := r.Get(ctx, done.ID)
+			require.NoError(t, err)
+			assert.Equal(t, model.ImportDone, job.Status)
+			got, err = r.Rows(ctx, done.ID, 0, 100)
+			require.NoError(t, err)
+			assert.Empty(t, got, "the rows of a done import are dropped")
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errBudgetExhausted is returned without a request once every key has used
+// its daily budget; an EnrichmentChain then falls back to its next source.
+var errBudgetExhausted = errors.New("isbndb: daily budget exhausted")
+
+// IsbndbClient looks books up in the ISBNdb v2 API, which requires an API key.
+// ISBNdb plans allow a number of calls per day, so each key can be given a
+// DailyBudget; keys are used in order, moving to the next when one is spent.
+type IsbndbClient struct {
+	BaseURL     string
+	APIKeys     []string
+	DailyBudget int // requests per key per UTC day; 0 is unlimited
+	Client      *http.Client
+	Retry       int
+
+	mu   sync.Mutex
+	day  string         // UTC date the counts in used are for
+	used map[string]int // requests per key on day
+	now  func() time.Time
+}
+
+func NewIsbndbClient(baseURL string, apiKeys []string, retry int, httpClient *http.Client) *IsbndbClient {
+	if baseURL == "" {
+		baseURL = "https://api2.isbndb.com"
+	}
+	if retry < 0 {
+		retry = 0
+	}
+	return &IsbndbClient{
+		BaseURL: baseURL,
+		APIKeys: apiKeys,
+		Client:  httpClient,
+		Retry:   retry,
+		now:     time.Now,
+	}
+}
+
+func (c *IsbndbClient) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	if c.Remaining() == 0 {
+		return model.EnrichedBook{}, errBudgetExhausted
+	}
+	u := c.BaseURL + "/book/" + url.PathEscape(isbn)
+	return fetchWithRetry(ctx, c.Retry, func() (model.EnrichedBook, error) {
+		return c.fetchOnce(ctx, u)
+	})
+}
+
+// Remaining reports how many requests today's budget still allows across
+// all keys, or -1 when the budget is unlimited.
+func (c *IsbndbClient) Remaining() int {
+	if c.DailyBudget <= 0 {
+		return -1
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rollDay()
+	n := 0
+	for _, k := range c.APIKeys {
+		n += max(c.DailyBudget-c.used[k], 0)
+	}
+	return n
+}
+
+// takeKey picks the first key with budget left and counts a request on it.
+func (c *IsbndbClient) takeKey() (string, bool) {
+	if c.DailyBudget <= 0 {
+		if len(c.APIKeys) == 0 {
+			return "", false
+		}
+		return c.APIKeys[0], true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rollDay()
+	for _, k := range c.APIKeys {
+		if c.used[k] < c.DailyBudget {
+			c.used[k]++
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// rollDay resets the counts when the UTC day changes. Callers hold mu.
+func (c *IsbndbClient) rollDay() {
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+	if day := now().UTC().Format(time.DateOnly); day != c.day {
+		c.day, c.used = day, make(map[string]int, len(c.APIKeys))
+	}
+}
+
+func (c *IsbndbClient) fetchOnce(ctx context.Context, u string) (model.EnrichedBook, error) {
+	key, ok := c.takeKey()
+	if !ok {
+		return model.EnrichedBook{}, errBudgetExhausted
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return model.EnrichedBook{}, err
+	}
+	req.Header.Set("Authorization", key)
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return model.EnrichedBook{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return model.EnrichedBook{}, errNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return model.EnrichedBook{}, fmt.Errorf("isbndb: status %d: %s", resp.StatusCode, string(b))
+	}
+
+	var res struct {
+		Book isbndbBook `json:"book"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return model.EnrichedBook{}, err
+	}
+	return mapIsbndbBook(res.Book), nil
+}
+
+type isbndbBook struct {
+	Title         *string  `json:"title"`
+	Authors       []string `json:"authors"`
+	DatePublished *string  `json:"date_published"` // e.g. "2017-09-10" or "2017"
+	Pages         *int     `json:"pages"`
+	Image         *string  `json:"image"`
+	Synopsis      *string  `json:"synopsis"`
+	Subjects      []string `json:"subjects"`
+}
+
+func mapIsbndbBook(ib isbndbBook) model.EnrichedBook {
+	var year *int
+	if ib.DatePublished != nil {
+		if y, err := parseYear(*ib.DatePublished); err == nil {
+			year = &y
+		}
+	}
+	pages := ib.Pages
+	if pages != nil && *pages < 1 {
+		pages = nil
+	}
+	var cover *string
+	if ib.Image != nil && *ib.Image != "" {
+		cover = ib.Image
+	}
+	authors := make([]string, 0, len(ib.Authors))
+	for _, a := range ib.Authors {
+		if a != "" {
+			authors = append(authors, a)
+		}
+	}
+	return model.EnrichedBook{
+		Source:        "isbndb",
+		Title:         ib.Title,
+		PublishedYear: year,
+		PageCount:     pages,
+		CoverURL:      cover,
+		Authors:       authors,
+		ReleaseDate:   parseDate(ib.DatePublished),
+		Description:   strPtrOrNil(strings.TrimSpace(deref(ib.Synopsis))),
+		Subjects:      nonEmpty(ib.Subjects),
+	}
+}
@@ -0,0 +1,53 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsbndb_DailyBudgetPerKey(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"book":{"title":"Dune","authors":["Frank Herbert"],"date_published":"1965"}}`))
+	}))
+	defer srv.Close()
+	now := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	c := NewIsbndbClient(srv.URL, []string{"k1", "k2"}, 0, srv.Client())
+	c.DailyBudget = 2
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for range 4 {
+		eb, err := c.FetchByISBN(ctx, "9780441013593")
+		require.NoError(t, err)
+		assert.Equal(t, "Dune", *eb.Title)
+	}
+	assert.Equal(t, []string{"k1", "k1", "k2", "k2"}, keys)
+	assert.Equal(t, 0, c.Remaining())
+
+	_, err := c.FetchByISBN(ctx, "9780441013593")
+	assert.ErrorIs(t, err, errBudgetExhausted)
+	assert.Len(t, keys, 4, "no request once the budget is spent")
+
+	// the chain falls back to the next source
+	free := &fakeEnrich{book: model.EnrichedBook{Source: "openlibrary"}}
+	eb, err := NewEnrichmentChain(ChainSource{Name: "isbndb", Client: c}, ChainSource{Name: "openlibrary", Client: free}).FetchByISBN(ctx, "9780441013593")
+	require.NoError(t, err)
+	assert.Equal(t, "openlibrary", eb.Source)
+
+	now = now.Add(2 * time.Hour) // next UTC day
+	assert.Equal(t, 4, c.Remaining())
+	_, err = c.FetchByISBN(ctx, "9780441013593")
+	require.NoError(t, err)
+	assert.Equal(t, "k1", keys[len(keys)-1])
+}
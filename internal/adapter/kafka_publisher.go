@@ -0,0 +1,77 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+)
+
+// KafkaPublisher publishes domain events to a Kafka topic as JSON, keyed
+// by book ID so that the changes of a book stay in one partition and in
+// order, with the event type in the "type" header. Each publish waits for
+// every in-sync replica to have the event, so a returned nil means the
+// cluster keeps it.
+type KafkaPublisher struct {
+	Topic string
+	w     *kafka.Writer
+}
+
+// NewKafkaPublisher reads a broker URL such as kafka://kafka:9092, with
+// more brokers separated by commas and optional user:password for SASL
+// PLAIN, and publishes to topic, which is created if need be.
+func NewKafkaPublisher(brokerURL, topic string, t BusTLS) (*KafkaPublisher, error) {
+	u, err := url.Parse(brokerURL)
+	if err != nil || u.Scheme != "kafka" || u.Host == "" {
+		return nil, fmt.Errorf("kafka: %q is not a kafka://host:port URL", brokerURL)
+	}
+	if topic == "" {
+		return nil, fmt.Errorf("kafka: no topic")
+	}
+	conf, err := t.config()
+	if err != nil {
+		return nil, fmt.Errorf("kafka: tls: %w", err)
+	}
+	transport := &kafka.Transport{TLS: conf, DialTimeout: busTimeout, ClientID: "book-manager"}
+	if u.User != nil {
+		pass, _ := u.User.Password()
+		transport.SASL = plain.Mechanism{Username: u.User.Username(), Password: pass}
+	}
+	w := &kafka.Writer{
+		Addr:                   kafka.TCP(strings.Split(u.Host, ",")...),
+		Topic:                  topic,
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		MaxAttempts:            1, // the outbox relay retries
+		AllowAutoTopicCreation: true,
+		Transport:              transport,
+	}
+	return &KafkaPublisher{Topic: topic, w: w}, nil
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, ev model.Event) error {
+	payload, err := marshalBusEvent(ev)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, busTimeout)
+	defer cancel()
+	err = p.w.WriteMessages(ctx, kafka.Message{
+		Key:     []byte(ev.BookID),
+		Value:   payload,
+		Headers: []kafka.Header{{Key: "type", Value: []byte(ev.Type)}, {Key: "id", Value: []byte(ev.ID)}},
+	})
+	if err != nil {
+		return fmt.Errorf("kafka: publish to %s: %w", p.Topic, err)
+	}
+	return nil
+}
+
+// Close closes the connections to the brokers.
+func (p *KafkaPublisher) Close() error {
+	return p.w.Close()
+}
@@ -0,0 +1,33 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaPublisher(t *testing.T) {
+	for _, bad := range []string{"nats://localhost:4222", "kafka://", "::"} {
+		_, err := NewKafkaPublisher(bad, "catalog", BusTLS{})
+		assert.Error(t, err, bad)
+	}
+	_, err := NewKafkaPublisher("kafka://localhost:9092", "", BusTLS{})
+	assert.Error(t, err, "no topic")
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	down := ln.Addr().String()
+	ln.Close()
+	p, err := NewKafkaPublisher("kafka://user:pass@"+down, "catalog", BusTLS{})
+	require.NoError(t, err)
+	defer p.Close()
+	err = p.Publish(context.Background(), model.Event{ID: "e1", Type: model.EventBookCreated, BookID: "b1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kafka: publish to catalog")
+}
@@ -0,0 +1,32 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"sync"
+)
+
+// KioskRepo remembers the synced kiosk checkouts in memory; they are lost
+// on restart.
+type KioskRepo struct {
+	mu     sync.RWMutex
+	synced map[[3]string]string // tenant, kiosk, client ID -> loan ID
+}
+
+func NewKioskRepo() *KioskRepo {
+	return &KioskRepo{synced: map[[3]string]string{}}
+}
+
+func (r *KioskRepo) Synced(ctx context.Context, kiosk, clientID string) (string, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id, ok := r.synced[[3]string{model.TenantFromContext(ctx), kiosk, clientID}]
+	return id, ok, nil
+}
+
+func (r *KioskRepo) Record(ctx context.Context, kiosk, clientID, loanID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.synced[[3]string{model.TenantFromContext(ctx), kiosk, clientID}] = loanID
+	return nil
+}
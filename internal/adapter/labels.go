@@ -0,0 +1,210 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Label kinds: spine labels show the call number, barcode labels the
+// copy's barcode.
+const (
+	labelSpine   = "spine"
+	labelBarcode = "barcode"
+)
+
+// labelLayout is a sheet of equal labels in a grid; lengths are in
+// millimetres.
+type labelLayout struct {
+	Name        string
+	Description string
+	PageWidth   float64
+	PageHeight  float64
+	Columns     int
+	Rows        int
+	LabelWidth  float64
+	LabelHeight float64
+	Top         float64 // page edge to the first row
+	Left        float64 // page edge to the first column
+	GapX        float64 // between columns
+	GapY        float64 // between rows
+}
+
+// defaultLabelLayout is the layout used when a request names none.
+const defaultLabelLayout = "avery-l7651"
+
+// labelLayouts are the named layouts, sorted by name.
+var labelLayouts = []labelLayout{
+	{Name: "avery-5160", Description: "Avery 5160, US Letter, 30 labels of 66.7 x 25.4 mm",
+		PageWidth: 215.9, PageHeight: 279.4, Columns: 3, Rows: 10, LabelWidth: 66.675, LabelHeight: 25.4, Top: 12.7, Left: 4.7625, GapX: 3.175},
+	{Name: "avery-5167", Description: "Avery 5167, US Letter, 80 labels of 44.5 x 12.7 mm",
+		PageWidth: 215.9, PageHeight: 279.4, Columns: 4, Rows: 20, LabelWidth: 44.45, LabelHeight: 12.7, Top: 12.7, Left: 7.62, GapX: 7.9375},
+	{Name: "avery-l7160", Description: "Avery L7160, A4, 21 labels of 63.5 x 38.1 mm",
+		PageWidth: 210, PageHeight: 297, Columns: 3, Rows: 7, LabelWidth: 63.5, LabelHeight: 38.1, Top: 15.15, Left: 7.25, GapX: 2.54},
+	{Name: "avery-l7651", Description: "Avery L7651, A4, 65 labels of 38.1 x 21.2 mm",
+		PageWidth: 210, PageHeight: 297, Columns: 5, Rows: 13, LabelWidth: 38.1, LabelHeight: 21.2, Top: 10.7, Left: 4.75, GapX: 2.54},
+}
+
+func findLabelLayout(name string) (labelLayout, bool) {
+	for _, l := range labelLayouts {
+		if l.Name == name {
+			return l, true
+		}
+	}
+	return labelLayout{}, false
+}
+
+// validate checks that the labels are of a printable size and fit on the
+// page.
+func (l labelLayout) validate() error {
+	const eps = 0.01
+	switch {
+	case l.PageWidth <= 0 || l.PageHeight <= 0 || l.PageWidth > 1000 || l.PageHeight > 1000:
+		return &model.FieldError{Field: "custom_layout", Reason: "pages must be 0 to 1000 mm wide and high"}
+	case l.Columns < 1 || l.Rows < 1 || l.Columns > 100 || l.Rows > 100:
+		return &model.FieldError{Field: "custom_layout", Reason: "columns and rows must be 1 to 100"}
+	case l.LabelWidth < 10 || l.LabelHeight < 5:
+		return &model.FieldError{Field: "custom_layout", Reason: "labels must be at least 10 mm wide and 5 mm high"}
+	case l.Top < 0 || l.Left < 0 || l.GapX < 0 || l.GapY < 0:
+		return &model.FieldError{Field: "custom_layout", Reason: "margins and gaps must not be negative"}
+	case l.Left+float64(l.Columns)*l.LabelWidth+float64(l.Columns-1)*l.GapX > l.PageWidth+eps:
+		return &model.FieldError{Field: "custom_layout", Reason: "the columns do not fit the page width"}
+	case l.Top+float64(l.Rows)*l.LabelHeight+float64(l.Rows-1)*l.GapY > l.PageHeight+eps:
+		return &model.FieldError{Field: "custom_layout", Reason: "the rows do not fit the page height"}
+	}
+	return nil
+}
+
+// renderLabels writes a PDF of labels of kind on sheets of layout l, one
+// per label in order, leaving the first skip positions of the first sheet
+// empty. Barcode labels need every label to have a barcode.
+func renderLabels(w io.Writer, kind string, l labelLayout, skip int, labels []model.Label) error {
+	perPage := l.Columns * l.Rows
+	doc := newPDF(l.PageWidth*mmToPt, l.PageHeight*mmToPt)
+	for i, lb := range labels {
+		pos := skip + i
+		if pos%perPage == 0 || i == 0 {
+			doc.newPage()
+		}
+		row, col := pos%perPage/l.Columns, pos%l.Columns
+		x := (l.Left + float64(col)*(l.LabelWidth+l.GapX)) * mmToPt
+		top := (l.PageHeight - l.Top - float64(row)*(l.LabelHeight+l.GapY)) * mmToPt
+		box := labelBox{x: x, y: top - l.LabelHeight*mmToPt, w: l.LabelWidth * mmToPt, h: l.LabelHeight * mmToPt}
+		switch kind {
+		case labelSpine:
+			drawSpineLabel(doc, box, lb)
+		case labelBarcode:
+			if err := drawBarcodeLabel(doc, box, lb); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown label kind %q", kind)
+		}
+	}
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+// labelBox is the area of one label in points, from its bottom left.
+type labelBox struct{ x, y, w, h float64 }
+
+// labelPadding keeps the print off the label's edges, in points.
+const labelPadding = 1.5 * mmToPt
+
+// drawSpineLabel prints the call number one part per line, centered and
+// as large as fits: the shelf location split at spaces, the first three
+// letters of the author's surname (or of the title) and the year.
+func drawSpineLabel(doc *pdfDoc, box labelBox, lb model.Label) {
+	lines := strings.Fields(lb.Location)
+	if c := cutter(lb); c != "" {
+		lines = append(lines, c)
+	}
+	if lb.Year != nil {
+		lines = append(lines, strconv.Itoa(*lb.Year))
+	}
+	if len(lines) > 5 {
+		lines = lines[:5]
+	}
+	if len(lines) == 0 {
+		return
+	}
+	const lineHeight = 1.15
+	w, h := box.w-2*labelPadding, box.h-2*labelPadding
+	size := math.Min(14, h/(float64(len(lines))*lineHeight))
+	for _, s := range lines {
+		if tw := textWidth(s, size, true); tw > w {
+			size = math.Max(size*w/tw, 5)
+		}
+	}
+	y := box.y + box.h/2 + float64(len(lines))*size*lineHeight/2
+	for _, s := range lines {
+		y -= size * lineHeight
+		s = fitText(s, w, size, true)
+		doc.text(box.x+(box.w-textWidth(s, size, true))/2, y+size*0.2, size, true, s)
+	}
+}
+
+// cutter is the short author mark of a spine label: the first three
+// letters of the author's surname, or of the title without an author.
+func cutter(lb model.Label) string {
+	name := lb.Author
+	if surname, _, ok := strings.Cut(name, ","); ok {
+		name = surname
+	} else if f := strings.Fields(name); len(f) > 0 {
+		name = f[len(f)-1]
+	}
+	if name == "" {
+		name = lb.Title
+	}
+	var out []rune
+	for _, r := range name {
+		if unicode.IsLetter(r) {
+			out = append(out, unicode.ToUpper(r))
+			if len(out) == 3 {
+				break
+			}
+		}
+	}
+	return string(out)
+}
+
+// drawBarcodeLabel prints the title, the barcode in Code 128 and the
+// barcode in text below it. The title is left out on labels too low for
+// it.
+func drawBarcodeLabel(doc *pdfDoc, box labelBox, lb model.Label) error {
+	vals, err := code128(lb.Barcode)
+	if err != nil {
+		return err
+	}
+	widths := code128Widths(vals)
+	modules := 20 // quiet zones of ten modules each side
+	for _, n := range widths {
+		modules += n
+	}
+	w, h := box.w-2*labelPadding, box.h-2*labelPadding
+	module := math.Min(w/float64(modules), 0.5*mmToPt)
+	const titleSize, codeSize = 6.5, 7.0
+	top := box.y + box.h - labelPadding
+	if h >= 12*mmToPt && lb.Title != "" {
+		top -= titleSize
+		title := fitText(lb.Title, w, titleSize, false)
+		doc.text(box.x+(box.w-textWidth(title, titleSize, false))/2, top, titleSize, false, title)
+		top -= titleSize * 0.4
+	}
+	bottom := box.y + labelPadding + codeSize*1.1
+	code := fitText(lb.Barcode, w, codeSize, false)
+	doc.text(box.x+(box.w-textWidth(code, codeSize, false))/2, box.y+labelPadding+codeSize*0.2, codeSize, false, code)
+
+	x := box.x + (box.w-float64(modules-20)*module)/2
+	for i, n := range widths {
+		if i%2 == 0 {
+			doc.rect(x, bottom, float64(n)*module, top-bottom)
+		}
+		x += float64(n) * module
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// listCursor is the state behind an opaque list cursor: the query it
+// continues and the sort values of the last book returned. Keeping the
+// values rather than only the id lets the walk go on after that book is
+// deleted or changed.
+type listCursor struct {
+	Q      *string    `json:"q,omitempty"`
+	Author *string    `json:"a,omitempty"`
+	Year   *int       `json:"y,omitempty"`
+	From   *int       `json:"yf,omitempty"` // YearFrom
+	To     *int       `json:"yt,omitempty"` // YearTo
+	Since  *time.Time `json:"us,omitempty"` // UpdatedSince
+	Tag    *string    `json:"t,omitempty"`
+	Nested bool       `json:"n,omitempty"` // IncludeChildren
+	Branch *string    `json:"b,omitempty"`
+	Code   *string    `json:"bc,omitempty"` // Barcode
+	Avail  *bool      `json:"av,omitempty"` // Available
+	Sort   []string   `json:"s,omitempty"`  // "-field" for descending
+	Trash  bool       `json:"d,omitempty"`  // IncludeDeleted
+	Last   cursorBook `json:"l"`
+}
+
+type cursorBook struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"ti,omitempty"`
+	Year      *int      `json:"py,omitempty"`
+	Rating    *float64  `json:"r,omitempty"` // average, when rated
+	CreatedAt time.Time `json:"ca"`
+	UpdatedAt time.Time `json:"ua"`
+}
+
+var errBadCursor = fmt.Errorf("%w: invalid cursor", model.ErrValidation)
+
+func encodeCursor(q model.ListQuery, keys []model.SortKey, last model.Book) string {
+	sortSpec := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k.Desc {
+			sortSpec = append(sortSpec, "-"+k.Field)
+		} else {
+			sortSpec = append(sortSpec, k.Field)
+		}
+	}
+	c := listCursor{
+		Q: q.Q, Author: q.Author, Year: q.Year, From: q.YearFrom, To: q.YearTo, Since: q.UpdatedSince, Tag: q.Tag, Nested: q.IncludeChildren, Branch: q.Branch, Code: q.Barcode, Avail: q.Available, Sort: sortSpec, Trash: q.IncludeDeleted,
+		Last: cursorBook{ID: last.ID, Title: last.Title, Year: last.PublishedYear, CreatedAt: last.CreatedAt, UpdatedAt: last.UpdatedAt},
+	}
+	if last.Rating != nil {
+		c.Last.Rating = &last.Rating.Average
+	}
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// applyCursor replaces q's filters and sort with the cursor's and returns
+// the last book of the previous page.
+func applyCursor(q *model.ListQuery) (model.Book, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return model.Book{}, errBadCursor
+	}
+	var c listCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.Last.ID == "" {
+		return model.Book{}, errBadCursor
+	}
+	if c.Trash && !q.IncludeDeleted {
+		// only requests asking for the trash may read it; see auth.RequiredRole
+		return model.Book{}, &model.FieldError{Field: "cursor", Reason: "continues a listing with include_deleted=true, which must be sent again"}
+	}
+	q.Q, q.Author, q.Year, q.Tag, q.Branch, q.Sort = c.Q, c.Author, c.Year, c.Tag, c.Branch, nil
+	q.Barcode, q.Available = c.Code, c.Avail
+	q.YearFrom, q.YearTo, q.UpdatedSince = c.From, c.To, c.Since
+	q.IncludeDeleted, q.IncludeChildren = c.Trash, c.Nested
+	for _, f := range c.Sort {
+		field, desc := strings.CutPrefix(f, "-")
+		q.Sort = append(q.Sort, model.SortKey{Field: field, Desc: desc})
+	}
+	last := model.Book{
+		ID: c.Last.ID, Title: c.Last.Title, PublishedYear: c.Last.Year,
+		CreatedAt: c.Last.CreatedAt, UpdatedAt: c.Last.UpdatedAt,
+	}
+	if c.Last.Rating != nil {
+		last.Rating = &model.Rating{Average: *c.Last.Rating}
+	}
+	return last, nil
+}
@@ -0,0 +1,63 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// snapshotTTL bounds how long an idle snapshot is kept. Every page read
+// through a snapshot extends its lifetime by another TTL.
+const snapshotTTL = 5 * time.Minute
+
+// listSnapshots pins filtered and sorted list results so a client can walk
+// all pages of a mutating catalog without skipping or repeating books.
+type listSnapshots struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	items map[string]listSnapshot
+}
+
+type listSnapshot struct {
+	books   []model.Book
+	expires time.Time
+}
+
+func newListSnapshots(ttl time.Duration) *listSnapshots {
+	return &listSnapshots{ttl: ttl, items: make(map[string]listSnapshot)}
+}
+
+// save stores books (which must not be mutated afterwards) and returns the
+// snapshot id.
+func (s *listSnapshots) save(books []model.Book) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictLocked(time.Now())
+	id := uuid.NewString()
+	s.items[id] = listSnapshot{books: books, expires: time.Now().Add(s.ttl)}
+	return id
+}
+
+func (s *listSnapshots) get(id string) ([]model.Book, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.evictLocked(now)
+	snap, ok := s.items[id]
+	if !ok {
+		return nil, false
+	}
+	snap.expires = now.Add(s.ttl)
+	s.items[id] = snap
+	return snap.books, true
+}
+
+func (s *listSnapshots) evictLocked(now time.Time) {
+	for id, snap := range s.items {
+		if now.After(snap.expires) {
+			delete(s.items, id)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// LoanRepo keeps loans in memory and, when opened on a journal file or a
+// bbolt database, there too, so they survive a restart.
+type LoanRepo struct {
+	mu      sync.RWMutex
+	byID    map[string]model.Loan
+	records recordStore[model.Loan]
+}
+
+func NewLoanRepo() *LoanRepo {
+	return &LoanRepo{byID: map[string]model.Loan{}, records: memoryRecords[model.Loan]{}}
+}
+
+// OpenLoanRepo loads the loans kept in path, which need not exist yet.
+func OpenLoanRepo(path string) (*LoanRepo, error) {
+	j, loans, err := openRecordJournal[model.Loan](path)
+	if err != nil {
+		return nil, err
+	}
+	return &LoanRepo{byID: loans, records: j}, nil
+}
+
+// OpenBoltLoanRepo loads the loans kept in db's loans bucket.
+func OpenBoltLoanRepo(db *bolt.DB) (*LoanRepo, error) {
+	b, loans, err := openBoltRecords[model.Loan](db, "loans")
+	if err != nil {
+		return nil, err
+	}
+	return &LoanRepo{byID: loans, records: b}, nil
+}
+
+func (r *LoanRepo) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.records.Close()
+}
+
+func (r *LoanRepo) Checkout(_ context.Context, l model.Loan, copies int) (model.Loan, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.records.writable(); err != nil {
+		return model.Loan{}, err
+	}
+	active := 0
+	for _, other := range r.byID {
+		if other.BookID == l.BookID && other.ReturnedAt == nil {
+			active++
+			if l.Barcode != "" && other.Barcode == l.Barcode {
+				return model.Loan{}, fmt.Errorf("%w: item %s is on loan", model.ErrConflict, l.Barcode)
+			}
+		}
+	}
+	if active >= copies {
+		return model.Loan{}, fmt.Errorf("%w: all %d copies of book %s are checked out", model.ErrConflict, copies, l.BookID)
+	}
+	r.byID[l.ID] = l
+	return l, r.records.put(l.ID, l)
+}
+
+func (r *LoanRepo) Get(ctx context.Context, id string) (model.Loan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	l, ok := r.byID[id]
+	if !ok || !ofTenant(ctx, l.Tenant) {
+		return model.Loan{}, fmt.Errorf("%w: loan %s", model.ErrNotFound, id)
+	}
+	return l, nil
+}
+
+func (r *LoanRepo) Return(ctx context.Context, id string, at time.Time) (model.Loan, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, ok := r.byID[id]
+	if !ok || !ofTenant(ctx, l.Tenant) {
+		return model.Loan{}, fmt.Errorf("%w: loan %s", model.ErrNotFound, id)
+	}
+	if l.ReturnedAt != nil {
+		return model.Loan{}, fmt.Errorf("%w: loan %s was returned at %s", model.ErrConflict, id, l.ReturnedAt.Format(time.RFC3339))
+	}
+	if err := r.records.writable(); err != nil {
+		return model.Loan{}, err
+	}
+	l.ReturnedAt = &at
+	r.byID[id] = l
+	return l, r.records.put(id, l)
+}
+
+func (r *LoanRepo) MarkLost(ctx context.Context, id string, at time.Time) (model.Loan, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, ok := r.byID[id]
+	if !ok || !ofTenant(ctx, l.Tenant) {
+		return model.Loan{}, fmt.Errorf("%w: loan %s", model.ErrNotFound, id)
+	}
+	if l.ReturnedAt != nil {
+		return model.Loan{}, fmt.Errorf("%w: loan %s was returned at %s", model.ErrConflict, id, l.ReturnedAt.Format(time.RFC3339))
+	}
+	if l.LostAt == nil {
+		if err := r.records.writable(); err != nil {
+			return model.Loan{}, err
+		}
+		l.LostAt = &at
+		r.byID[id] = l
+		return l, r.records.put(id, l)
+	}
+	return l, nil
+}
+
+func (r *LoanRepo) List(ctx context.Context, q model.LoanQuery) ([]model.Loan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []model.Loan
+	for _, l := range r.byID {
+		if !ofTenant(ctx, l.Tenant) || (q.BookID != "" && l.BookID != q.BookID) || (q.Borrower != "" && l.Borrower != q.Borrower) {
+			continue
+		}
+		switch q.Status {
+		case model.LoanActive:
+			if l.ReturnedAt != nil {
+				continue
+			}
+		case model.LoanOverdue:
+			if !l.Overdue(q.Now) {
+				continue
+			}
+		case model.LoanLost:
+			if l.ReturnedAt != nil || l.LostAt == nil {
+				continue
+			}
+		case model.LoanReturned:
+			if l.ReturnedAt == nil {
+				continue
+			}
+		}
+		out = append(out, l)
+	}
+	slices.SortFunc(out, func(a, b model.Loan) int {
+		if c := a.DueAt.Compare(b.DueAt); c != 0 {
+			return c
+		}
+		return a.CheckedOutAt.Compare(b.CheckedOutAt)
+	})
+	return out, nil
+}
+
+func (r *LoanRepo) Reassign(ctx context.Context, from, to string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.records.writable(); err != nil {
+		return 0, err
+	}
+	n := 0
+	for id, l := range r.byID {
+		if l.Borrower == from && ofTenant(ctx, l.Tenant) {
+			l.Borrower = to
+			r.byID[id] = l
+			if err := r.records.put(id, l); err != nil {
+				return n, err
+			}
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,27 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"log/slog"
+)
+
+// LogNotifier writes catalog events to the log.
+type LogNotifier struct {
+	Log *slog.Logger
+}
+
+func (n LogNotifier) Notify(ctx context.Context, ev model.Event) error {
+	attrs := []any{"type", ev.Type, "book-id", ev.BookID, "title", ev.Book.Title}
+	if ev.Price != nil {
+		attrs = append(attrs, "price", fromDomainPrice(*ev.Price))
+	}
+	if ev.Hold != nil {
+		attrs = append(attrs, "borrower", ev.Hold.Borrower, "hold-id", ev.Hold.ID)
+	}
+	if ev.Loan != nil {
+		attrs = append(attrs, "borrower", ev.Loan.Borrower, "loan-id", ev.Loan.ID, "due-at", ev.Loan.DueAt)
+	}
+	n.Log.InfoContext(ctx, "catalog event", attrs...)
+	return nil
+}
@@ -0,0 +1,185 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// marcRecord is a MARC 21 bibliographic record in MARCXML
+// (http://www.loc.gov/MARC21/slim). Tags carry no namespace so the record
+// decodes whatever prefix the server uses.
+type marcRecord struct {
+	ControlFields []marcControlField `xml:"controlfield"`
+	DataFields    []marcDataField    `xml:"datafield"`
+}
+
+type marcControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type marcDataField struct {
+	Tag       string         `xml:"tag,attr"`
+	Ind1      string         `xml:"ind1,attr"`
+	Ind2      string         `xml:"ind2,attr"`
+	Subfields []marcSubfield `xml:"subfield"`
+}
+
+type marcSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (r marcRecord) control(tag string) string {
+	for _, f := range r.ControlFields {
+		if f.Tag == tag {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+func (r marcRecord) fields(tag string) []marcDataField {
+	var out []marcDataField
+	for _, f := range r.DataFields {
+		if f.Tag == tag {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// sub returns the first subfield with the code, stripped of ISBD
+// punctuation.
+func (f marcDataField) sub(code string) string {
+	for _, s := range f.Subfields {
+		if s.Code == code {
+			return trimISBD(s.Value)
+		}
+	}
+	return ""
+}
+
+// subRaw is sub without the punctuation trimming, for free text.
+func (f marcDataField) subRaw(code string) string {
+	for _, s := range f.Subfields {
+		if s.Code == code {
+			return s.Value
+		}
+	}
+	return ""
+}
+
+// trimISBD drops the punctuation that ISBD puts between elements, e.g. the
+// " /" ending a 245 $a before its statement of responsibility.
+func trimISBD(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimRight(s, " /:;,=")
+	if strings.HasSuffix(s, ".") && !strings.HasSuffix(s, "..") && !initialRe.MatchString(s) {
+		s = s[:len(s)-1]
+	}
+	return strings.TrimSpace(s)
+}
+
+// initialRe matches a name ending in an initial ("Robert C."), whose period
+// is not punctuation.
+var initialRe = regexp.MustCompile(`(^|[\s,])\p{Lu}\.$`)
+
+// pagesRe finds the page count in a 300 $a extent, e.g. "xxii, 431 pages"
+// or "431 p.".
+var pagesRe = regexp.MustCompile(`(\d+)\s*(?:pages|page|pp\.?|p\.|p\b|S\.|Seiten)`)
+
+// mapMARCRecord reads the fields enrichment uses: title (245), authors
+// (100, 700), year (264/260 $c, else 008), pages (300), summary (520) and
+// topical subjects (650).
+func mapMARCRecord(rec marcRecord, source string) model.EnrichedBook {
+	eb := model.EnrichedBook{Source: source}
+	if fs := rec.fields("245"); len(fs) > 0 {
+		if t := fs[0].sub("a"); t != "" {
+			eb.Title = &t
+		}
+		if st := fs[0].sub("b"); st != "" {
+			eb.Subtitle = &st
+		}
+	}
+
+	for _, tag := range []string{"100", "700"} {
+		for _, f := range rec.fields(tag) {
+			if tag == "700" && !marcAuthorRole(f) {
+				continue
+			}
+			if name := marcName(f); name != "" {
+				eb.Authors = append(eb.Authors, name)
+			}
+		}
+	}
+
+	var published string
+	for _, f := range rec.fields("264") {
+		if f.Ind2 == "1" {
+			published = f.sub("c")
+			break
+		}
+	}
+	if published == "" {
+		if fs := rec.fields("260"); len(fs) > 0 {
+			published = fs[0].sub("c")
+		}
+	}
+	if y, err := parseYear(published); err == nil {
+		eb.PublishedYear = &y
+	} else if f008 := rec.control("008"); len(f008) >= 11 {
+		// 008/07-10 is the first date; "uuuu" or blanks when unknown
+		if y, err := strconv.Atoi(f008[7:11]); err == nil && y > 0 {
+			eb.PublishedYear = &y
+		}
+	}
+
+	if fs := rec.fields("300"); len(fs) > 0 {
+		if m := pagesRe.FindAllStringSubmatch(fs[0].sub("a"), -1); len(m) > 0 {
+			if n, err := strconv.Atoi(m[len(m)-1][1]); err == nil && n > 0 {
+				eb.PageCount = &n
+			}
+		}
+	}
+
+	if fs := rec.fields("520"); len(fs) > 0 {
+		if d := strings.TrimSpace(fs[0].subRaw("a")); d != "" {
+			eb.Description = &d
+		}
+	}
+	for _, f := range rec.fields("650") {
+		if subj := f.sub("a"); subj != "" && !slices.Contains(eb.Subjects, subj) {
+			eb.Subjects = append(eb.Subjects, subj)
+		}
+	}
+	return eb
+}
+
+// marcAuthorRole tells whether an added entry (700) is an author rather
+// than, say, an editor or translator. Entries without a relator are counted.
+func marcAuthorRole(f marcDataField) bool {
+	role, code := f.sub("e"), f.sub("4")
+	if role == "" && code == "" {
+		return true
+	}
+	return code == "aut" || strings.HasPrefix(strings.ToLower(role), "author")
+}
+
+// marcName turns a personal name heading into display order: "Martin,
+// Robert C." becomes "Robert C. Martin". Only surname-first headings
+// (first indicator 1) are inverted.
+func marcName(f marcDataField) string {
+	name := f.sub("a")
+	if f.Ind1 != "1" {
+		return name
+	}
+	last, first, ok := strings.Cut(name, ",")
+	if !ok {
+		return name
+	}
+	return strings.TrimSpace(first) + " " + strings.TrimSpace(last)
+}
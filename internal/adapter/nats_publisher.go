@@ -0,0 +1,87 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSPublisher publishes domain events to NATS, as JSON on the subject
+// <Prefix>.<event type>, for example catalog.book.created, with the event
+// ID in the Nats-Msg-Id header. Each publish flushes the connection and
+// waits for the server's answer, so a returned nil means the server has
+// the event. The client reconnects by itself; while it is disconnected
+// publishes fail rather than being buffered.
+type NATSPublisher struct {
+	Prefix string
+	nc     *nats.Conn
+}
+
+// NewNATSPublisher connects to servers, a comma-separated list of URLs
+// such as nats://localhost:4222 or tls://nats:4222, with optional
+// user:password or token credentials. A server that is down is retried in
+// the background.
+func NewNATSPublisher(servers, prefix string, t BusTLS) (*NATSPublisher, error) {
+	opts := []nats.Option{
+		nats.Name("book-manager"),
+		nats.Timeout(busTimeout),
+		nats.MaxReconnects(-1),
+		nats.RetryOnFailedConnect(true),
+		nats.ReconnectBufSize(-1),
+		// Publish returns the errors of its events
+		nats.ErrorHandler(func(*nats.Conn, *nats.Subscription, error) {}),
+	}
+	conf, err := t.config()
+	if err != nil {
+		return nil, fmt.Errorf("nats: tls: %w", err)
+	}
+	if conf != nil {
+		opts = append(opts, nats.Secure(conf))
+	}
+	nc, err := nats.Connect(servers, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("nats: %w", err)
+	}
+	return &NATSPublisher{Prefix: strings.TrimSuffix(prefix, "."), nc: nc}, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, ev model.Event) error {
+	payload, err := marshalBusEvent(ev)
+	if err != nil {
+		return err
+	}
+	subject := ev.Type
+	if p.Prefix != "" {
+		subject = p.Prefix + "." + ev.Type
+	}
+	if !p.nc.IsConnected() {
+		return fmt.Errorf("nats: publish %s: %w", subject, nats.ErrConnectionReconnecting)
+	}
+	ctx, cancel := context.WithTimeout(ctx, busTimeout)
+	defer cancel()
+	msg := nats.NewMsg(subject)
+	msg.Data = payload
+	msg.Header.Set(nats.MsgIdHdr, ev.ID)
+	// the server reports a rejected publish, e.g. for permissions, before
+	// it answers the flush
+	last := p.nc.LastError()
+	if err = p.nc.PublishMsg(msg); err == nil {
+		err = p.nc.FlushWithContext(ctx)
+	}
+	if lerr := p.nc.LastError(); err == nil && lerr != nil && lerr != last {
+		err = lerr
+	}
+	if err != nil {
+		return fmt.Errorf("nats: publish %s: %w", subject, err)
+	}
+	return nil
+}
+
+// Close closes the connection.
+func (p *NATSPublisher) Close() error {
+	p.nc.Close()
+	return nil
+}
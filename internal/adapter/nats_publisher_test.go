@@ -0,0 +1,160 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type natsMsg struct {
+	subject string
+	header  string
+	payload []byte
+}
+
+// fakeNATS serves the core NATS protocol on a local port, over TLS with
+// cert; with failPub it rejects every publish.
+func fakeNATS(t *testing.T, cert *tls.Certificate, failPub bool) (addr string, msgs <-chan natsMsg, connects <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	out := make(chan natsMsg, 10)
+	conns := make(chan string, 10)
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				fmt.Fprintf(c, "INFO {\"server_id\":\"test\",\"max_payload\":1048576,\"headers\":true,\"proto\":1,\"tls_required\":%t}\r\n", cert != nil)
+				if cert != nil {
+					tc := tls.Server(c, &tls.Config{Certificates: []tls.Certificate{*cert}})
+					if tc.Handshake() != nil {
+						return
+					}
+					c = tc
+				}
+				rd := bufio.NewReader(c)
+				for {
+					line, err := rd.ReadString('\n')
+					if err != nil {
+						return
+					}
+					fields := strings.Fields(line)
+					switch {
+					case len(fields) == 0:
+					case fields[0] == "CONNECT":
+						conns <- strings.TrimSpace(strings.TrimPrefix(line, "CONNECT"))
+					case fields[0] == "PING":
+						fmt.Fprint(c, "PONG\r\n")
+					case fields[0] == "HPUB" || fields[0] == "PUB":
+						n, _ := strconv.Atoi(fields[len(fields)-1])
+						h := 0
+						if fields[0] == "HPUB" {
+							h, _ = strconv.Atoi(fields[len(fields)-2])
+						}
+						data := make([]byte, n+2)
+						if _, err := io.ReadFull(rd, data); err != nil {
+							return
+						}
+						if failPub {
+							fmt.Fprintf(c, "-ERR 'Permissions Violation for Publish to \"%s\"'\r\n", fields[1])
+							continue
+						}
+						out <- natsMsg{subject: fields[1], header: string(data[:h]), payload: data[h:n]}
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String(), out, conns
+}
+
+func TestNATSPublisher_Publish(t *testing.T) {
+	addr, msgs, connects := fakeNATS(t, nil, false)
+	p, err := NewNATSPublisher("nats://svc:s3cret@"+addr, "catalog", BusTLS{})
+	require.NoError(t, err)
+	defer p.Close()
+
+	at := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	ev := model.Event{ID: "e1", Type: model.EventBookUpdated, BookID: "b1", Book: model.Book{ID: "b1", Title: "Dune"}, At: at,
+		Actor: "api-key:ci", Changes: []model.FieldChange{{Field: "title", Before: "Dun", After: "Dune"}}}
+	require.NoError(t, p.Publish(context.Background(), ev))
+	require.NoError(t, p.Publish(context.Background(), model.Event{ID: "e2", Type: model.EventBookDeleted, BookID: "b1", At: at}))
+
+	var opts map[string]any
+	require.NoError(t, json.Unmarshal([]byte(<-connects), &opts))
+	assert.Equal(t, "svc", opts["user"])
+	assert.Equal(t, "s3cret", opts["pass"])
+	assert.Empty(t, connects, "the connection is reused")
+
+	m := <-msgs
+	assert.Equal(t, "catalog.book.updated", m.subject)
+	assert.Contains(t, m.header, "Nats-Msg-Id: e1")
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(m.payload, &got))
+	assert.Equal(t, "e1", got["id"])
+	assert.Equal(t, "api-key:ci", got["actor"])
+	assert.Equal(t, "Dune", got["book"].(map[string]any)["title"])
+	assert.Equal(t, []any{map[string]any{"field": "title", "before": "Dun", "after": "Dune"}}, got["changes"])
+	assert.Equal(t, "catalog.book.deleted", (<-msgs).subject)
+}
+
+func TestNATSPublisher_TLS(t *testing.T) {
+	// borrow the test server's certificate for 127.0.0.1
+	srv := httptest.NewTLSServer(nil)
+	cert := srv.TLS.Certificates[0]
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(ca, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0o600))
+	srv.Close()
+
+	addr, msgs, _ := fakeNATS(t, &cert, false)
+	p, err := NewNATSPublisher("tls://"+addr, "catalog", BusTLS{Enabled: true, CAFile: ca})
+	require.NoError(t, err)
+	defer p.Close()
+	require.NoError(t, p.Publish(context.Background(), model.Event{ID: "e1", Type: model.EventBookCreated}))
+	assert.Equal(t, "catalog.book.created", (<-msgs).subject)
+
+	_, err = NewNATSPublisher("tls://"+addr, "catalog", BusTLS{Enabled: true, CAFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.Error(t, err)
+}
+
+func TestNATSPublisher_Errors(t *testing.T) {
+	addr, _, _ := fakeNATS(t, nil, true)
+	p, err := NewNATSPublisher("nats://"+addr, "catalog", BusTLS{})
+	require.NoError(t, err)
+	defer p.Close()
+	err = p.Publish(context.Background(), model.Event{ID: "e1", Type: model.EventBookCreated})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Permissions Violation")
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	down := ln.Addr().String()
+	ln.Close()
+	p, err = NewNATSPublisher("nats://"+down, "catalog", BusTLS{})
+	require.NoError(t, err, "a bus that is down is retried")
+	defer p.Close()
+	assert.Error(t, p.Publish(context.Background(), model.Event{ID: "e1", Type: model.EventBookCreated}))
+}
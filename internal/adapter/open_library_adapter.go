@@ -4,60 +4,41 @@ import (
 	"book-manager/internal/core/model"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type OpenLibraryClient struct {
 	BaseURL string
 	Client  *http.Client
-	Retry   int
+	// Retry paces the attempts of every request; 429 and 503 answers are
+	// retried after their Retry-After.
+	Retry RetryPolicy
 }
 
+// NewOpenLibraryClient retries failed requests retry times with
+// DefaultRetryPolicy; set Retry to change the policy.
 func NewOpenLibraryClient(baseURL string, retry int, httpClient *http.Client) *OpenLibraryClient {
 	if baseURL == "" {
 		baseURL = "https://openlibrary.org"
 	}
-	if retry < 0 {
-		retry = 0
-	}
 	return &OpenLibraryClient{
 		BaseURL: baseURL,
 		Client:  httpClient,
-		Retry:   retry,
+		Retry:   DefaultRetryPolicy(retry),
 	}
 }
 
 func (c *OpenLibraryClient) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
 	url := fmt.Sprintf("%s/isbn/%s.json", c.BaseURL, isbn)
-
-	var lastErr error
-	attempts := c.Retry + 1
-	for i := 0; i < attempts; i++ {
-		eb, err := c.fetchOnce(ctx, url)
-		if err == nil {
-			return eb, nil
-		}
-		// 404 is final: not found
-		if errors.Is(err, errNotFound) {
-			return model.EnrichedBook{}, err
-		}
-		lastErr = err
-		// simple backoff
-		if i < attempts-1 {
-			select {
-			case <-time.After(time.Duration(150*(i+1)) * time.Millisecond):
-			case <-ctx.Done():
-				return model.EnrichedBook{}, ctx.Err()
-			}
-		}
-	}
-	return model.EnrichedBook{}, lastErr
+	return fetchWithPolicy(ctx, c.Retry, func() (model.EnrichedBook, error) {
+		return c.fetchOnce(ctx, url)
+	})
 }
 
 func (c *OpenLibraryClient) fetchOnce(ctx context.Context, url string) (model.EnrichedBook, error) {
@@ -65,6 +46,7 @@ func (c *OpenLibraryClient) fetchOnce(ctx context.Context, url string) (model.En
 	if err != nil {
 		return model.EnrichedBook{}, err
 	}
+	setRequestID(req)
 	resp, err := c.Client.Do(req)
 	if err != nil {
 		return model.EnrichedBook{}, err
@@ -76,7 +58,7 @@ func (c *OpenLibraryClient) fetchOnce(ctx context.Context, url string) (model.En
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
-		return model.EnrichedBook{}, fmt.Errorf("openlibrary: status %d: %s", resp.StatusCode, string(b))
+		return model.EnrichedBook{}, withRetryAfter(resp, fmt.Errorf("openlibrary: status %d: %s", resp.StatusCode, string(b)))
 	}
 
 	var ob openLibBook
@@ -91,9 +73,31 @@ type openLibBook struct {
 	Title         *string     `json:"title"`
 	Subtitle      *string     `json:"subtitle"`
 	NumberOfPages *int        `json:"number_of_pages"`
-	PublishDate   *string     `json:"publish_date"` // e.g. "2017"
+	PublishDate   *string     `json:"publish_date"` // e.g. "2017" or "Sep 10, 2017"
 	Covers        []int       `json:"covers"`
 	Authors       []olbAuthor `json:"authors"`
+	Subjects      []string    `json:"subjects"`
+	Description   olbText     `json:"description"`
+}
+
+// olbText is a text field that Open Library gives either as a plain string
+// or as {"type": "/type/text", "value": "..."}.
+type olbText string
+
+func (t *olbText) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = olbText(s)
+		return nil
+	}
+	var v struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*t = olbText(v.Value)
+	return nil
 }
 
 type olbAuthor struct {
@@ -114,7 +118,7 @@ func mapToEnriched(ob openLibBook) model.EnrichedBook {
 
 	var cover *string
 	if len(ob.Covers) > 0 {
-		u := fmt.Sprintf("https://covers.openlibrary.org/b/id/%d-L.jpg", ob.Covers[0])
+		u := olCoverURL(ob.Covers[0])
 		cover = &u
 	}
 
@@ -126,13 +130,28 @@ func mapToEnriched(ob openLibBook) model.EnrichedBook {
 	}
 
 	return model.EnrichedBook{
+		Source:        "openlibrary",
 		Title:         ob.Title,
 		Subtitle:      ob.Subtitle,
 		PublishedYear: year,
 		PageCount:     ob.NumberOfPages,
 		CoverURL:      cover,
 		Authors:       authors,
+		ReleaseDate:   parseDate(ob.PublishDate),
+		Description:   strPtrOrNil(strings.TrimSpace(string(ob.Description))),
+		Subjects:      nonEmpty(ob.Subjects),
+	}
+}
+
+// nonEmpty returns the trimmed, non-blank values of ss.
+func nonEmpty(ss []string) []string {
+	var out []string
+	for _, s := range ss {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
 	}
+	return out
 }
 
 var yearRe = regexp.MustCompile(`(\d{4})`)
@@ -146,3 +165,21 @@ func parseYear(s string) (int, error) {
 	}
 	return strconv.Atoi(match)
 }
+
+// dateLayouts are the full-date formats seen in provider publish dates.
+var dateLayouts = []string{"2006-01-02", "January 2, 2006", "Jan 2, 2006", "2 January 2006", "Jan 02, 2006"}
+
+// parseDate reads a publish date that names a day. Year-only or month-only
+// dates give nil, as they cannot tell whether a book is out yet.
+func parseDate(s *string) *time.Time {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
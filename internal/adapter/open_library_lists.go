@@ -0,0 +1,313 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// maxReadingListItems bounds the books of one reading list; longer lists
+// are refused before any edition is looked up.
+const maxReadingListItems = 1000
+
+var (
+	olUsernameRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	// e.g. https://openlibrary.org/people/alice/lists/OL123L/Summer_reading
+	olListPathRe = regexp.MustCompile(`^/people/([A-Za-z0-9_.-]+)/lists/(OL\d+L)(?:/[^/]*)?/?$`)
+)
+
+// olListEntry is a book on a list before its edition is resolved.
+type olListEntry struct {
+	title      string
+	authors    []string
+	workKey    string
+	editionKey string
+}
+
+type olReadingLog struct {
+	NumFound int `json:"numFound"`
+	Entries  []struct {
+		Work struct {
+			Title           string   `json:"title"`
+			Key             string   `json:"key"`
+			AuthorNames     []string `json:"author_names"`
+			CoverEditionKey string   `json:"cover_edition_key"`
+		} `json:"work"`
+		LoggedEdition string `json:"logged_edition"`
+	} `json:"reading_log_entries"`
+}
+
+type olSeeds struct {
+	Entries []struct {
+		URL   string `json:"url"`
+		Title string `json:"title"`
+	} `json:"entries"`
+}
+
+type olKey struct {
+	Key string `json:"key"`
+}
+
+type olEdition struct {
+	Key         string   `json:"key"`
+	Title       string   `json:"title"`
+	ISBN13      []string `json:"isbn_13"`
+	ISBN10      []string `json:"isbn_10"`
+	PublishDate string   `json:"publish_date"`
+	Authors     []olKey  `json:"authors"`
+	Works       []olKey  `json:"works"`
+}
+
+type olWork struct {
+	Title   string `json:"title"`
+	Authors []struct {
+		Author olKey `json:"author"`
+	} `json:"authors"`
+	Covers           []int  `json:"covers"`
+	FirstPublishDate string `json:"first_publish_date"`
+}
+
+// ReadingList reads a user's reading log, one shelf or all of them, or one
+// of their lists. Each book is resolved to its preferred edition: the one
+// the user logged or the work's cover edition when it has an ISBN,
+// otherwise the work's edition with an ISBN-13, or else an ISBN-10, that
+// was published last. Books without any edition carrying an ISBN are kept
+// with their work's title and authors; subjects and authors on a list are
+// skipped.
+func (c *OpenLibraryClient) ReadingList(ctx context.Context, ref model.ReadingListRef) ([]model.ReadingListItem, error) {
+	var entries []olListEntry
+	var err error
+	if ref.ListURL != "" {
+		entries, err = c.listEntries(ctx, ref.ListURL)
+	} else {
+		entries, err = c.readingLogEntries(ctx, ref.Username, ref.Shelf)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > maxReadingListItems {
+		return nil, &model.FieldError{Field: "list", Reason: fmt.Sprintf("has more than %d books", maxReadingListItems)}
+	}
+
+	authorNames := map[string]string{}
+	out := make([]model.ReadingListItem, 0, len(entries))
+	for _, e := range entries {
+		it, ok, err := c.resolveEntry(ctx, e, authorNames)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, it)
+		}
+	}
+	return out, nil
+}
+
+func (c *OpenLibraryClient) readingLogEntries(ctx context.Context, user, shelf string) ([]olListEntry, error) {
+	if !olUsernameRe.MatchString(user) {
+		return nil, &model.FieldError{Field: "username", Reason: "is not an Open Library username"}
+	}
+	shelves := []string{model.ShelfWantToRead, model.ShelfCurrentlyReading, model.ShelfAlreadyRead}
+	if shelf != "" {
+		shelves = []string{shelf}
+	}
+	var out []olListEntry
+	for _, shelf := range shelves {
+		for page := 1; ; page++ {
+			var log olReadingLog
+			err := c.getJSON(ctx, fmt.Sprintf("/people/%s/books/%s.json?page=%d", user, shelf, page), &log)
+			if errors.Is(err, errNotFound) {
+				return nil, fmt.Errorf("%w: open library user %s, or their reading log is private", model.ErrNotFound, user)
+			}
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range log.Entries {
+				entry := olListEntry{title: e.Work.Title, authors: e.Work.AuthorNames, workKey: e.Work.Key, editionKey: e.LoggedEdition}
+				if entry.editionKey == "" && e.Work.CoverEditionKey != "" {
+					entry.editionKey = "/books/" + e.Work.CoverEditionKey
+				}
+				out = append(out, entry)
+			}
+			if len(log.Entries) == 0 || len(out) > maxReadingListItems {
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (c *OpenLibraryClient) listEntries(ctx context.Context, listURL string) ([]olListEntry, error) {
+	u, err := url.Parse(listURL)
+	var m []string
+	if err == nil {
+		m = olListPathRe.FindStringSubmatch(u.Path)
+	}
+	if m == nil {
+		return nil, &model.FieldError{Field: "list_url", Reason: "must be an Open Library list such as https://openlibrary.org/people/alice/lists/OL1L"}
+	}
+	var seeds olSeeds
+	err = c.getJSON(ctx, fmt.Sprintf("/people/%s/lists/%s/seeds.json", m[1], m[2]), &seeds)
+	if errors.Is(err, errNotFound) {
+		return nil, fmt.Errorf("%w: open library list %s of %s", model.ErrNotFound, m[2], m[1])
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []olListEntry
+	for _, s := range seeds.Entries {
+		switch {
+		case strings.HasPrefix(s.URL, "/works/"):
+			out = append(out, olListEntry{title: s.Title, workKey: s.URL})
+		case strings.HasPrefix(s.URL, "/books/"):
+			out = append(out, olListEntry{title: s.Title, editionKey: s.URL})
+		}
+	}
+	return out, nil
+}
+
+// resolveEntry finds the preferred edition of e and fills in what the list
+// left out. It reports false for entries that vanished from Open Library.
+func (c *OpenLibraryClient) resolveEntry(ctx context.Context, e olListEntry, authorNames map[string]string) (model.ReadingListItem, bool, error) {
+	it := model.ReadingListItem{Title: e.title, Authors: e.authors, WorkKey: e.workKey}
+	var authorKeys []string
+	if e.editionKey != "" {
+		var ed olEdition
+		switch err := c.getJSON(ctx, e.editionKey+".json", &ed); {
+		case errors.Is(err, errNotFound):
+		case err != nil:
+			return it, false, err
+		default:
+			if isbn := editionISBN(ed); isbn != "" {
+				it.ISBN, it.EditionKey = isbn, e.editionKey
+			}
+			if it.Title == "" {
+				it.Title = ed.Title
+			}
+			if it.WorkKey == "" && len(ed.Works) > 0 {
+				it.WorkKey = ed.Works[0].Key
+			}
+			for _, a := range ed.Authors {
+				authorKeys = append(authorKeys, a.Key)
+			}
+		}
+	}
+	if it.WorkKey != "" && it.ISBN == "" {
+		var eds struct {
+			Entries []olEdition `json:"entries"`
+		}
+		switch err := c.getJSON(ctx, it.WorkKey+"/editions.json?limit=50", &eds); {
+		case errors.Is(err, errNotFound):
+		case err != nil:
+			return it, false, err
+		default:
+			if ed, ok := preferredEdition(eds.Entries); ok {
+				it.ISBN, it.EditionKey = editionISBN(ed), ed.Key
+			}
+		}
+	}
+	if it.WorkKey != "" && (it.Title == "" || len(it.Authors) == 0 && len(authorKeys) == 0) {
+		var w olWork
+		switch err := c.getJSON(ctx, it.WorkKey+".json", &w); {
+		case errors.Is(err, errNotFound):
+		case err != nil:
+			return it, false, err
+		default:
+			if it.Title == "" {
+				it.Title = w.Title
+			}
+			for _, a := range w.Authors {
+				authorKeys = append(authorKeys, a.Author.Key)
+			}
+		}
+	}
+	if len(it.Authors) == 0 {
+		for _, key := range authorKeys {
+			name, ok := authorNames[key]
+			if !ok {
+				var a struct {
+					Name string `json:"name"`
+				}
+				if err := c.getJSON(ctx, key+".json", &a); err != nil && !errors.Is(err, errNotFound) {
+					return it, false, err
+				}
+				name = strings.TrimSpace(a.Name)
+				authorNames[key] = name
+			}
+			if name != "" {
+				it.Authors = append(it.Authors, name)
+			}
+		}
+	}
+	return it, it.Title != "" || it.ISBN != "", nil
+}
+
+func editionISBN(ed olEdition) string {
+	if isbn := firstNonBlank(ed.ISBN13); isbn != "" {
+		return isbn
+	}
+	return firstNonBlank(ed.ISBN10)
+}
+
+func firstNonBlank(ss []string) string {
+	for _, s := range ss {
+		if s = strings.TrimSpace(s); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// preferredEdition picks the edition with an ISBN-13, or else an ISBN-10,
+// published last; the first listed wins a tie.
+func preferredEdition(eds []olEdition) (olEdition, bool) {
+	best, bestRank, found := olEdition{}, [2]int{}, false
+	for _, ed := range eds {
+		if editionISBN(ed) == "" {
+			continue
+		}
+		rank := [2]int{0, 0}
+		if firstNonBlank(ed.ISBN13) != "" {
+			rank[0] = 1
+		}
+		if y, err := parseYear(ed.PublishDate); err == nil {
+			rank[1] = y
+		}
+		if !found || rank[0] > bestRank[0] || rank[0] == bestRank[0] && rank[1] > bestRank[1] {
+			best, bestRank, found = ed, rank, true
+		}
+	}
+	return best, found
+}
+
+// getJSON decodes the Open Library document at path into v.
+func (c *OpenLibraryClient) getJSON(ctx context.Context, path string, v any) error {
+	_, err := fetchWithPolicy(ctx, c.Retry, func() (struct{}, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
+		if err != nil {
+			return struct{}{}, err
+		}
+		setRequestID(req)
+		resp, err := c.Client.Do(req)
+		if err != nil {
+			return struct{}{}, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden {
+			return struct{}{}, errNotFound
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			return struct{}{}, withRetryAfter(resp, fmt.Errorf("openlibrary: %s: status %d: %s", path, resp.StatusCode, string(b)))
+		}
+		return struct{}{}, json.NewDecoder(resp.Body).Decode(v)
+	})
+	return err
+}
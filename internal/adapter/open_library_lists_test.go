@@ -0,0 +1,86 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReadingListStub() *httptest.Server {
+	docs := map[string]string{
+		"/people/alice/books/want-to-read.json?page=1": `{"numFound":2,"reading_log_entries":[
+			{"work":{"title":"Dune","key":"/works/OL893415W","author_names":["Frank Herbert"],"cover_edition_key":"OL1M"}},
+			{"work":{"title":"Neuromancer","key":"/works/OL27258W","author_names":["William Gibson"]},"logged_edition":"/books/OL2M"}]}`,
+		"/people/alice/books/want-to-read.json?page=2": `{"numFound":2,"reading_log_entries":[]}`,
+		// the cover edition has no ISBN, so the work's editions are searched
+		"/books/OL1M.json": `{"key":"/books/OL1M","title":"Dune"}`,
+		"/works/OL893415W/editions.json?limit=50": `{"entries":[
+			{"key":"/books/OL3M","isbn_10":["0441013597"],"publish_date":"2005"},
+			{"key":"/books/OL4M","isbn_13":["9780441172719"],"publish_date":"1990"},
+			{"key":"/books/OL5M","isbn_13":["9780593099322"],"publish_date":"October 1, 2019"}]}`,
+		"/books/OL2M.json": `{"key":"/books/OL2M","title":"Neuromancer","isbn_13":["9780441569595"]}`,
+		"/people/alice/lists/OL7L/seeds.json": `{"entries":[
+			{"url":"/works/OL27448W","title":"The Lord of the Rings"},
+			{"url":"/subjects/fantasy","title":"Fantasy"},
+			{"url":"/books/OL9M","title":"Gone"}]}`,
+		"/works/OL27448W/editions.json?limit=50": `{"entries":[{"key":"/books/OL6M","isbn_13":["9780618640157"]}]}`,
+		"/works/OL27448W.json":                   `{"title":"The Lord of the Rings","authors":[{"author":{"key":"/authors/OL26320A"}}]}`,
+		"/authors/OL26320A.json":                 `{"name":"J.R.R. Tolkien"}`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		doc, ok := docs[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(doc))
+	}))
+}
+
+func TestOpenLibrary_ReadingLog(t *testing.T) {
+	srv := newReadingListStub()
+	defer srv.Close()
+	c := NewOpenLibraryClient(srv.URL, 0, srv.Client())
+
+	items, err := c.ReadingList(context.Background(), model.ReadingListRef{Username: "alice", Shelf: model.ShelfWantToRead})
+	require.NoError(t, err)
+	assert.Equal(t, []model.ReadingListItem{
+		{Title: "Dune", Authors: []string{"Frank Herbert"}, ISBN: "9780593099322", WorkKey: "/works/OL893415W", EditionKey: "/books/OL5M"},
+		{Title: "Neuromancer", Authors: []string{"William Gibson"}, ISBN: "9780441569595", WorkKey: "/works/OL27258W", EditionKey: "/books/OL2M"},
+	}, items)
+
+	_, err = c.ReadingList(context.Background(), model.ReadingListRef{Username: "bob", Shelf: model.ShelfAlreadyRead})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	_, err = c.ReadingList(context.Background(), model.ReadingListRef{Username: "../admin"})
+	assert.ErrorIs(t, err, model.ErrValidation)
+}
+
+func TestOpenLibrary_List(t *testing.T) {
+	srv := newReadingListStub()
+	defer srv.Close()
+	c := NewOpenLibraryClient(srv.URL, 0, srv.Client())
+
+	items, err := c.ReadingList(context.Background(), model.ReadingListRef{ListURL: "https://openlibrary.org/people/alice/lists/OL7L/Favourites"})
+	require.NoError(t, err)
+	assert.Equal(t, []model.ReadingListItem{
+		// subjects are skipped; the removed edition keeps its seed title
+		{Title: "The Lord of the Rings", Authors: []string{"J.R.R. Tolkien"}, ISBN: "9780618640157", WorkKey: "/works/OL27448W", EditionKey: "/books/OL6M"},
+		{Title: "Gone"},
+	}, items)
+
+	_, err = c.ReadingList(context.Background(), model.ReadingListRef{ListURL: "https://openlibrary.org/people/alice/lists/OL8L"})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	var fe *model.FieldError
+	_, err = c.ReadingList(context.Background(), model.ReadingListRef{ListURL: "https://openlibrary.org/works/OL27448W"})
+	require.ErrorAs(t, err, &fe)
+	assert.Equal(t, "list_url", fe.Field)
+}
@@ -0,0 +1,142 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// maxExternalISBNs bounds the ISBNs kept of one external book; Open
+// Library lists those of every edition of a work.
+const maxExternalISBNs = 10
+
+var olWorkKeyRe = regexp.MustCompile(`^/works/OL\d+W$`)
+
+type olSearch struct {
+	Docs []struct {
+		Key              string   `json:"key"`
+		Title            string   `json:"title"`
+		AuthorName       []string `json:"author_name"`
+		ISBN             []string `json:"isbn"`
+		CoverID          int      `json:"cover_i"`
+		FirstPublishYear int      `json:"first_publish_year"`
+	} `json:"docs"`
+}
+
+// SearchBooks searches the works of Open Library by title and author.
+func (c *OpenLibraryClient) SearchBooks(ctx context.Context, q string, limit int) ([]model.ExternalBook, error) {
+	v := url.Values{}
+	v.Set("q", q)
+	v.Set("fields", "key,title,author_name,isbn,cover_i,first_publish_year")
+	v.Set("limit", fmt.Sprint(limit))
+	var res olSearch
+	if err := c.getJSON(ctx, "/search.json?"+v.Encode(), &res); err != nil {
+		return nil, err
+	}
+	out := make([]model.ExternalBook, 0, len(res.Docs))
+	for _, d := range res.Docs {
+		if !olWorkKeyRe.MatchString(d.Key) {
+			continue
+		}
+		b := model.ExternalBook{
+			Key:              d.Key,
+			Title:            d.Title,
+			Authors:          d.AuthorName,
+			ISBNs:            sortISBNs(d.ISBN),
+			FirstPublishYear: d.FirstPublishYear,
+		}
+		if d.CoverID > 0 {
+			b.CoverURL = olCoverURL(d.CoverID)
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+// ExternalBook reads the Open Library work with key, e.g.
+// "/works/OL45883W". Its ISBNs are those of its preferred edition, as a
+// reading list import picks it, then those of its other editions.
+func (c *OpenLibraryClient) ExternalBook(ctx context.Context, key string) (model.ExternalBook, error) {
+	if !olWorkKeyRe.MatchString(key) {
+		return model.ExternalBook{}, &model.FieldError{Field: "key", Reason: "must be an Open Library work such as /works/OL45883W"}
+	}
+	var w olWork
+	err := c.getJSON(ctx, key+".json", &w)
+	if errors.Is(err, errNotFound) {
+		return model.ExternalBook{}, fmt.Errorf("%w: open library work %s", model.ErrNotFound, key)
+	}
+	if err != nil {
+		return model.ExternalBook{}, err
+	}
+	b := model.ExternalBook{Key: key, Title: strings.TrimSpace(w.Title)}
+	// -1 marks a removed cover
+	if i := slices.IndexFunc(w.Covers, func(id int) bool { return id > 0 }); i >= 0 {
+		b.CoverURL = olCoverURL(w.Covers[i])
+	}
+	if y, err := parseYear(w.FirstPublishDate); err == nil {
+		b.FirstPublishYear = y
+	}
+
+	var eds struct {
+		Entries []olEdition `json:"entries"`
+	}
+	if err := c.getJSON(ctx, key+"/editions.json?limit=50", &eds); err != nil && !errors.Is(err, errNotFound) {
+		return model.ExternalBook{}, err
+	}
+	if ed, ok := preferredEdition(eds.Entries); ok {
+		b.ISBNs = append(b.ISBNs, editionISBN(ed))
+	}
+	var rest []string
+	for _, ed := range eds.Entries {
+		rest = append(rest, ed.ISBN13...)
+		rest = append(rest, ed.ISBN10...)
+	}
+	for _, isbn := range sortISBNs(rest) {
+		if len(b.ISBNs) == maxExternalISBNs {
+			break
+		}
+		if !slices.Contains(b.ISBNs, isbn) {
+			b.ISBNs = append(b.ISBNs, isbn)
+		}
+	}
+
+	for _, a := range w.Authors {
+		var author struct {
+			Name string `json:"name"`
+		}
+		if err := c.getJSON(ctx, a.Author.Key+".json", &author); err != nil && !errors.Is(err, errNotFound) {
+			return model.ExternalBook{}, err
+		}
+		if name := strings.TrimSpace(author.Name); name != "" {
+			b.Authors = append(b.Authors, name)
+		}
+	}
+	return b, nil
+}
+
+// sortISBNs returns the distinct non-blank ISBNs of isbns, ISBN-13s
+// first, at most maxExternalISBNs of them.
+func sortISBNs(isbns []string) []string {
+	var out []string
+	for _, long := range []bool{true, false} {
+		for _, isbn := range isbns {
+			isbn = strings.TrimSpace(isbn)
+			if isbn == "" || (len(isbn) == 13) != long || slices.Contains(out, isbn) {
+				continue
+			}
+			if out = append(out, isbn); len(out) == maxExternalISBNs {
+				return out
+			}
+		}
+	}
+	return out
+}
+
+func olCoverURL(id int) string {
+	return fmt.Sprintf("https://covers.openlibrary.org/b/id/%d-L.jpg", id)
+}
@@ -0,0 +1,124 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSearchStub(t *testing.T) *httptest.Server {
+	t.Helper()
+	docs := map[string]string{
+		"/works/OL893415W.json": `{"title":"Dune","covers":[-1,11481354],"first_publish_date":"1965",
+			"authors":[{"author":{"key":"/authors/OL79034A"}}]}`,
+		"/works/OL893415W/editions.json?limit=50": `{"entries":[
+			{"key":"/books/OL3M","isbn_10":["0441013597"],"publish_date":"2005"},
+			{"key":"/books/OL4M","isbn_13":["9780441172719"],"publish_date":"1990"},
+			{"key":"/books/OL5M","isbn_13":["9780593099322"],"publish_date":"2019"}]}`,
+		"/authors/OL79034A.json": `{"name":"Frank Herbert"}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/search.json" {
+			assert.Equal(t, "dune herbert", r.URL.Query().Get("q"))
+			assert.Equal(t, "2", r.URL.Query().Get("limit"))
+			_, _ = w.Write([]byte(`{"numFound":2,"docs":[
+				{"key":"/works/OL893415W","title":"Dune","author_name":["Frank Herbert"],"cover_i":11481354,
+				 "first_publish_year":1965,"isbn":["0441013597","9780441172719"," ","9780441172719"]},
+				{"key":"/works/OL27258W","title":"Dune Messiah","author_name":["Frank Herbert"]}]}`))
+			return
+		}
+		doc, ok := docs[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(doc))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOpenLibrary_SearchBooks(t *testing.T) {
+	srv := newSearchStub(t)
+	c := NewOpenLibraryClient(srv.URL, 0, srv.Client())
+
+	books, err := c.SearchBooks(context.Background(), "dune herbert", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []model.ExternalBook{
+		{Key: "/works/OL893415W", Title: "Dune", Authors: []string{"Frank Herbert"}, ISBNs: []string{"9780441172719", "0441013597"},
+			CoverURL: "https://covers.openlibrary.org/b/id/11481354-L.jpg", FirstPublishYear: 1965},
+		{Key: "/works/OL27258W", Title: "Dune Messiah", Authors: []string{"Frank Herbert"}},
+	}, books)
+}
+
+func TestOpenLibrary_ExternalBook(t *testing.T) {
+	srv := newSearchStub(t)
+	c := NewOpenLibraryClient(srv.URL, 0, srv.Client())
+
+	b, err := c.ExternalBook(context.Background(), "/works/OL893415W")
+	require.NoError(t, err)
+	assert.Equal(t, model.ExternalBook{
+		Key: "/works/OL893415W", Title: "Dune", Authors: []string{"Frank Herbert"},
+		// the preferred edition first
+		ISBNs:    []string{"9780593099322", "9780441172719", "0441013597"},
+		CoverURL: "https://covers.openlibrary.org/b/id/11481354-L.jpg", FirstPublishYear: 1965,
+	}, b)
+
+	_, err = c.ExternalBook(context.Background(), "/works/OL1W")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	_, err = c.ExternalBook(context.Background(), "/authors/OL79034A")
+	assert.ErrorIs(t, err, model.ErrValidation)
+}
+
+func TestHTTP_ExternalSearchAndImport(t *testing.T) {
+	h, svc := newServer(t)
+	srv := newSearchStub(t)
+	svc.External = NewOpenLibraryClient(srv.URL, 0, srv.Client())
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := do(http.MethodGet, "/api/v1/search/external?q=dune+herbert&limit=2", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var list struct {
+		Data []map[string]any `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Data, 2)
+	assert.Equal(t, "/works/OL893415W", list.Data[0]["key"])
+	assert.Equal(t, []any{}, list.Data[1]["isbns"])
+	assert.NotContains(t, list.Data[1], "cover_url")
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/api/v1/search/external?q=+", "").Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/api/v1/search/external?q=dune&limit=51", "").Code)
+
+	w = do(http.MethodPost, "/api/v1/books/import-external", `{"key":"/works/OL893415W","isbn":"9780441172719","tags":["sf"]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var b struct {
+		ID    string   `json:"id"`
+		Title string   `json:"title"`
+		ISBN  string   `json:"isbn"`
+		Tags  []string `json:"tags"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &b))
+	assert.Equal(t, "Dune", b.Title)
+	assert.Equal(t, "9780441172719", b.ISBN)
+	assert.Equal(t, []string{"sf"}, b.Tags)
+	assert.Equal(t, "/api/v1/books/"+b.ID, w.Header().Get("Location"))
+
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/api/v1/books/import-external", `{"key":"/works/OL893415W","isbn":"978-0-441-17271-9"}`).Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodPost, "/api/v1/books/import-external", `{"key":"/works/OL1W"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/v1/books/import-external", `{"key":""}`).Code)
+}
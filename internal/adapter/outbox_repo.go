@@ -0,0 +1,41 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"slices"
+	"sync"
+)
+
+// OutboxRepo keeps unpublished domain events in memory, for books that are
+// kept in memory too. The durable repositories, FileBookRepo and
+// BoltBookRepo, are outboxes themselves and keep the events with the
+// books.
+type OutboxRepo struct {
+	mu      sync.Mutex
+	pending []model.Event // oldest first
+}
+
+func NewOutboxRepo() *OutboxRepo {
+	return &OutboxRepo{}
+}
+
+func (r *OutboxRepo) Add(_ context.Context, ev model.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending = append(r.pending, ev)
+	return nil
+}
+
+func (r *OutboxRepo) Pending(_ context.Context, limit int) ([]model.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.pending[:min(limit, len(r.pending))]), nil
+}
+
+func (r *OutboxRepo) Ack(_ context.Context, ids []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending = slices.DeleteFunc(r.pending, func(ev model.Event) bool { return slices.Contains(ids, ev.ID) })
+	return nil
+}
@@ -0,0 +1,85 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type durableOutbox interface {
+	Create(ctx context.Context, b model.Book) (model.Book, error)
+	Delete(ctx context.Context, id string) error
+	Add(ctx context.Context, ev model.Event) error
+	Pending(ctx context.Context, limit int) ([]model.Event, error)
+	Ack(ctx context.Context, ids []string) error
+}
+
+func TestOutbox_KeptWithTheChange(t *testing.T) {
+	for name, open := range map[string]func(t *testing.T, path string) (durableOutbox, func()){
+		"file": func(t *testing.T, path string) (durableOutbox, func()) {
+			r, err := OpenFileBookRepo(path)
+			require.NoError(t, err)
+			return r, func() { require.NoError(t, r.Close()) }
+		},
+		"bolt": func(t *testing.T, path string) (durableOutbox, func()) {
+			db, err := OpenBoltDB(path)
+			require.NoError(t, err)
+			r, err := OpenBoltBookRepo(db)
+			require.NoError(t, err)
+			return r, func() { require.NoError(t, db.Close()) }
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "books")
+			n := 0
+			staged := func() context.Context {
+				return model.WithEventStage(ctx, model.NewEventStage(func(before, after *model.Book) (model.Event, bool) {
+					n++
+					typ := model.EventBookCreated
+					if after == nil {
+						typ = model.EventBookPurged
+					}
+					return model.Event{ID: string(rune('0' + n)), Type: typ}, true
+				}))
+			}
+			ids := func(r durableOutbox) []string {
+				pending, err := r.Pending(ctx, 10)
+				require.NoError(t, err)
+				var out []string
+				for _, ev := range pending {
+					out = append(out, ev.ID+":"+ev.Type)
+				}
+				return out
+			}
+
+			r, closeRepo := open(t, path)
+			_, err := r.Create(staged(), model.Book{ID: "b1", Title: "Dune", ISBN: util.GetPtr("9780441172719")})
+			require.NoError(t, err)
+			_, err = r.Create(staged(), model.Book{ID: "b2", Title: "Dune again", ISBN: util.GetPtr("9780441172719")})
+			require.Error(t, err)
+			require.NoError(t, r.Delete(staged(), "b1"))
+			_, err = r.Create(ctx, model.Book{ID: "b3", Title: "Emma"})
+			require.NoError(t, err, "a write without a stage keeps no event")
+			require.NoError(t, r.Add(ctx, model.Event{ID: "x", Type: model.EventBookUpdated}))
+			closeRepo()
+
+			r, closeRepo = open(t, path)
+			assert.Equal(t, []string{"1:" + model.EventBookCreated, "2:" + model.EventBookPurged, "x:" + model.EventBookUpdated}, ids(r),
+				"the failed create keeps no event")
+			require.NoError(t, r.Ack(ctx, []string{"1", "2"}))
+			closeRepo()
+
+			r, closeRepo = open(t, path)
+			defer closeRepo()
+			assert.Equal(t, []string{"x:" + model.EventBookUpdated}, ids(r))
+		})
+	}
+}
@@ -0,0 +1,174 @@
+//go:build integration
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"database/sql"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openTestPostgres connects to the database of POSTGRES_DSN and drops the
+// tables of the books; the test is skipped without it.
+func openTestPostgres(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_DSN is not set")
+	}
+	db, err := OpenPostgresDB(dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec(`DROP TABLE IF EXISTS books, author_renames, outbox`)
+	require.NoError(t, err)
+	return db
+}
+
+func TestPostgresBookRepo_SurvivesReopen(t *testing.T) {
+	db := openTestPostgres(t)
+	ctx := context.Background()
+	r, err := OpenPostgresBookRepo(db)
+	require.NoError(t, err)
+
+	_, err = r.Create(ctx, model.Book{ID: "b2", Title: "Two", CreatedAt: time.Unix(2, 0)})
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Book{ID: "b1", Title: "One", ISBN: util.GetPtr("9780134494166"), Authors: []string{"Bob"}, CreatedAt: time.Unix(1, 0)})
+	require.NoError(t, err)
+	_, err = r.Update(ctx, model.Book{ID: "b2", Version: 1, Title: "Two v2", Tags: []string{"scifi"}, CreatedAt: time.Unix(2, 0)})
+	require.NoError(t, err)
+	_, err = r.Create(ctx, model.Book{ID: "b3", Title: "Three", CreatedAt: time.Unix(3, 0)})
+	require.NoError(t, err)
+	require.NoError(t, r.Delete(ctx, "b3"))
+	_, err = r.Create(ctx, model.Book{ID: "b2", Title: "Dup"})
+	assert.Error(t, err)
+	_, err = r.RenameAuthor(ctx, model.AuthorRename{From: "bob", To: "Robert", RenamedAt: time.Unix(4, 0)})
+	require.NoError(t, err)
+	_, err = r.RenameTags(ctx, model.TagRename{From: []string{"scifi"}, To: "sf", RenamedAt: time.Unix(5, 0)})
+	require.NoError(t, err)
+
+	r, err = OpenPostgresBookRepo(db)
+	require.NoError(t, err)
+	page, err := r.List(ctx, model.ListQuery{Page: 1, PageSize: 10, Sort: []model.SortKey{{Field: "created_at"}}})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 2)
+	assert.Equal(t, "b1", page.Data[0].ID)
+	assert.Equal(t, "Two v2", page.Data[1].Title)
+	assert.Equal(t, []string{"Robert"}, page.Data[0].Authors)
+	assert.Equal(t, 2, page.Data[0].Version, "renames count as a write")
+	assert.Equal(t, []string{"sf"}, page.Data[1].Tags)
+	assert.Equal(t, 3, page.Data[1].Version)
+
+	got, err := r.GetByISBN(ctx, "", "978-0-13-449416-6")
+	require.NoError(t, err)
+	assert.Equal(t, "b1", got.ID)
+	renames, err := r.ListAuthorRenames(ctx)
+	require.NoError(t, err)
+	require.Len(t, renames, 1)
+	assert.Equal(t, 1, renames[0].BooksUpdated)
+	assert.Empty(t, renames[0].Changes)
+}
+
+func TestPostgresBookRepo_TenantsAndTrash(t *testing.T) {
+	db := openTestPostgres(t)
+	east := model.WithTenant(context.Background(), "east")
+	r, err := OpenPostgresBookRepo(db)
+	require.NoError(t, err)
+
+	isbn := util.GetPtr("9780134494166")
+	_, err = r.Create(east, model.Book{ID: "b1", Title: "One", ISBN: isbn, Tenant: "east", CreatedAt: time.Unix(1, 0)})
+	require.NoError(t, err)
+	got, err := r.GetByISBN(east, "", "978-0134494166")
+	require.NoError(t, err)
+	assert.Equal(t, "b1", got.ID)
+	_, err = r.GetByISBN(model.WithTenant(context.Background(), "west"), "", *isbn)
+	assert.ErrorIs(t, err, errNotFound, "ISBNs are indexed per tenant")
+	_, err = r.GetByID(model.WithTenant(context.Background(), "west"), "b1")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	// the trashed book is found by its ISBN until another one takes it
+	deleted := time.Unix(2, 0)
+	stale := got
+	got.DeletedAt = &deleted
+	got, err = r.Update(east, got)
+	require.NoError(t, err)
+	_, err = r.Create(east, model.Book{ID: "b2", Title: "Two", ISBN: isbn, Tenant: "east", CreatedAt: time.Unix(3, 0)})
+	require.NoError(t, err)
+	again, err := r.GetByISBN(east, "", *isbn)
+	require.NoError(t, err)
+	assert.Equal(t, "b2", again.ID)
+	_, err = r.Update(east, stale)
+	assert.ErrorIs(t, err, model.ErrConflict, "stale version")
+	got.DeletedAt = nil
+	_, err = r.Update(east, got)
+	assert.ErrorIs(t, err, model.ErrConflict, "the ISBN is taken")
+
+	_, err = r.CreateCapped(east, model.Book{ID: "b3", Title: "Three", Tenant: "east"}, 2)
+	assert.ErrorIs(t, err, model.ErrQuota, "trashed books count")
+	_, err = r.CreateCapped(east, model.Book{ID: "b3", Title: "Three", Tenant: "east"}, 3)
+	require.NoError(t, err)
+	n, err := r.Count(east, model.ListQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	n, err = r.Count(model.WithTenant(context.Background(), "west"), model.ListQuery{IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	rep, err := r.CheckConsistency(context.Background(), false)
+	require.NoError(t, err)
+	assert.Empty(t, rep.Issues)
+	_, err = db.Exec(`UPDATE books SET isbn_key = NULL WHERE id = 'b2'`)
+	require.NoError(t, err)
+	rep, err = r.CheckConsistency(context.Background(), true)
+	require.NoError(t, err)
+	assert.Len(t, rep.Issues, 1)
+	rep, err = r.CheckConsistency(context.Background(), false)
+	require.NoError(t, err)
+	assert.Empty(t, rep.Issues, "repaired")
+}
+
+func TestPostgresBookRepo_CapHoldsUnderConcurrency(t *testing.T) {
+	db := openTestPostgres(t)
+	ctx := model.WithTenant(context.Background(), "east")
+	r, err := OpenPostgresBookRepo(db)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = r.CreateCapped(ctx, model.Book{ID: string(rune('a' + i)), Title: "Book", Tenant: "east"}, 3)
+		}()
+	}
+	wg.Wait()
+	n, err := r.Count(ctx, model.ListQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+}
+
+func TestPostgresBookRepo_Outbox(t *testing.T) {
+	db := openTestPostgres(t)
+	ctx := context.Background()
+	r, err := OpenPostgresBookRepo(db)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Add(ctx, model.Event{ID: "e1", Type: "book.created"}))
+	require.NoError(t, r.Add(ctx, model.Event{ID: "e2", Type: "book.updated"}))
+	events, err := r.Pending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "e1", events[0].ID, "oldest first")
+	require.NoError(t, r.Ack(ctx, []string{"e1"}))
+	events, err = r.Pending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "e2", events[0].ID)
+}
@@ -0,0 +1,48 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"sync"
+)
+
+// maxPricePoints bounds the history kept per book; the oldest points are
+// dropped first.
+const maxPricePoints = 1000
+
+// PriceRepo keeps price histories in memory; they are lost on restart.
+type PriceRepo struct {
+	mu     sync.RWMutex
+	byBook map[string][]model.PricePoint
+}
+
+func NewPriceRepo() *PriceRepo {
+	return &PriceRepo{byBook: make(map[string][]model.PricePoint)}
+}
+
+func (r *PriceRepo) Add(_ context.Context, p model.PricePoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pts := append(r.byBook[p.BookID], p)
+	if len(pts) > maxPricePoints {
+		pts = append([]model.PricePoint(nil), pts[len(pts)-maxPricePoints:]...)
+	}
+	r.byBook[p.BookID] = pts
+	return nil
+}
+
+func (r *PriceRepo) List(_ context.Context, bookID string) ([]model.PricePoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]model.PricePoint(nil), r.byBook[bookID]...), nil
+}
+
+func (r *PriceRepo) Last(_ context.Context, bookID string) (model.PricePoint, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	pts := r.byBook[bookID]
+	if len(pts) == 0 {
+		return model.PricePoint{}, false, nil
+	}
+	return pts[len(pts)-1], true, nil
+}
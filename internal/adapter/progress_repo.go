@@ -0,0 +1,68 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"slices"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// ProgressRepo keeps the users' reading progress updates in memory.
+type ProgressRepo struct {
+	mu      sync.RWMutex
+	byUser  map[string][]model.ReadingProgress // by tenantKey, oldest first
+	records recordStore[[]model.ReadingProgress]
+}
+
+// NewProgressRepo keeps nothing durably; the updates are lost on restart.
+func NewProgressRepo() *ProgressRepo {
+	return &ProgressRepo{byUser: map[string][]model.ReadingProgress{}, records: memoryRecords[[]model.ReadingProgress]{}}
+}
+
+// OpenBoltProgressRepo loads the updates kept in db's reading_progress
+// bucket, one record per user.
+func OpenBoltProgressRepo(db *bolt.DB) (*ProgressRepo, error) {
+	b, byUser, err := openBoltRecords[[]model.ReadingProgress](db, "reading_progress")
+	if err != nil {
+		return nil, err
+	}
+	return &ProgressRepo{byUser: byUser, records: b}, nil
+}
+
+func (r *ProgressRepo) Add(ctx context.Context, user string, p model.ReadingProgress) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.records.writable(); err != nil {
+		return err
+	}
+	user = tenantKey(ctx, user)
+	all := r.byUser[user]
+	i := len(all)
+	for i > 0 && all[i-1].At.After(p.At) {
+		i--
+	}
+	r.byUser[user] = slices.Insert(all, i, p)
+	return r.records.put(user, r.byUser[user])
+}
+
+func (r *ProgressRepo) History(ctx context.Context, user, bookID string) ([]model.ReadingProgress, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	user = tenantKey(ctx, user)
+	var out []model.ReadingProgress
+	for _, p := range r.byUser[user] {
+		if p.BookID == bookID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (r *ProgressRepo) All(ctx context.Context, user string) ([]model.ReadingProgress, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	user = tenantKey(ctx, user)
+	return slices.Clone(r.byUser[user]), nil
+}
@@ -0,0 +1,115 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"sync"
+	"time"
+)
+
+// maxRecentViewUsers bounds how many users' views RecentViewRepo keeps; the
+// user who viewed nothing for longest is forgotten first.
+const maxRecentViewUsers = 10000
+
+// RecentViewRepo keeps each user's last views in memory, in a ring of
+// fixed size per user; the oldest view is overwritten first and a book
+// viewed again is moved to the front. Views are lost on restart.
+type RecentViewRepo struct {
+	size int
+
+	mu    sync.Mutex
+	rings map[string]*viewRing // by tenantKey
+}
+
+type viewRing struct {
+	views []model.RecentView // len size once full
+	next  int                // slot the next view goes into
+	last  time.Time          // latest view
+}
+
+// NewRecentViewRepo keeps size views per user; size must be positive.
+func NewRecentViewRepo(size int) *RecentViewRepo {
+	return &RecentViewRepo{size: max(size, 1), rings: map[string]*viewRing{}}
+}
+
+func (r *RecentViewRepo) Record(ctx context.Context, user string, v model.RecentView) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user = tenantKey(ctx, user)
+	ring, ok := r.rings[user]
+	if !ok {
+		if len(r.rings) >= maxRecentViewUsers {
+			r.evictIdle()
+		}
+		ring = &viewRing{views: make([]model.RecentView, 0, r.size)}
+		r.rings[user] = ring
+	}
+	ring.add(v, r.size)
+	return nil
+}
+
+func (r *RecentViewRepo) List(ctx context.Context, user string, limit int) ([]model.RecentView, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user = tenantKey(ctx, user)
+	ring, ok := r.rings[user]
+	if !ok {
+		return nil, nil
+	}
+	return ring.latest(limit), nil
+}
+
+func (r *RecentViewRepo) Clear(ctx context.Context, user string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user = tenantKey(ctx, user)
+	delete(r.rings, user)
+	return nil
+}
+
+func (r *RecentViewRepo) evictIdle() {
+	var idle string
+	var at time.Time
+	for user, ring := range r.rings {
+		if idle == "" || ring.last.Before(at) {
+			idle, at = user, ring.last
+		}
+	}
+	delete(r.rings, idle)
+}
+
+// add puts v in front; an earlier view of the same book is dropped, and
+// the ring closes the gap it leaves.
+func (g *viewRing) add(v model.RecentView, size int) {
+	g.last = v.ViewedAt
+	views := g.latest(len(g.views))
+	kept := make([]model.RecentView, 0, size)
+	for i := len(views) - 1; i >= 0; i-- {
+		if views[i].BookID != v.BookID {
+			kept = append(kept, views[i])
+		}
+	}
+	if len(kept) == len(views) {
+		// no duplicate: overwrite the oldest slot in place
+		if len(g.views) < size {
+			g.views = append(g.views, v)
+			g.next = len(g.views) % size
+		} else {
+			g.views[g.next] = v
+			g.next = (g.next + 1) % size
+		}
+		return
+	}
+	g.views = append(kept, v)
+	g.next = len(g.views) % size
+}
+
+// latest returns up to limit views, newest first.
+func (g *viewRing) latest(limit int) []model.RecentView {
+	n := min(limit, len(g.views))
+	out := make([]model.RecentView, 0, n)
+	for i := 1; i <= n; i++ {
+		out = append(out, g.views[(g.next-i+len(g.views))%len(g.views)])
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecentViewRepo(t *testing.T) {
+	ctx := context.Background()
+	r := NewRecentViewRepo(3)
+	at := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	view := func(user, id string) {
+		at = at.Add(time.Minute)
+		require.NoError(t, r.Record(ctx, user, model.RecentView{BookID: id, ViewedAt: at}))
+	}
+	ids := func(user string, limit int) []string {
+		views, err := r.List(ctx, user, limit)
+		require.NoError(t, err)
+		var out []string
+		for _, v := range views {
+			out = append(out, v.BookID)
+		}
+		return out
+	}
+
+	for _, id := range []string{"a", "b", "c", "d"} {
+		view("ann", id)
+	}
+	assert.Equal(t, []string{"d", "c", "b"}, ids("ann", 10), "the oldest view is overwritten")
+	view("ann", "e")
+	assert.Equal(t, []string{"e", "d", "c"}, ids("ann", 10))
+	view("ann", "d")
+	assert.Equal(t, []string{"d", "e", "c"}, ids("ann", 10), "a book viewed again moves to the front")
+	view("ann", "f")
+	assert.Equal(t, []string{"f", "d", "e"}, ids("ann", 10))
+	assert.Equal(t, []string{"f"}, ids("ann", 1))
+
+	view("bob", "a")
+	assert.Equal(t, []string{"a"}, ids("bob", 10), "histories are per user")
+	require.NoError(t, r.Clear(ctx, "ann"))
+	assert.Empty(t, ids("ann", 10))
+	assert.Equal(t, []string{"a"}, ids("bob", 10))
+}
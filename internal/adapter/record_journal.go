@@ -0,0 +1,163 @@
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// recordStore keeps the records of an in-memory store by ID durably. The
+// store changes memory and then calls put or delete while holding its lock.
+//
+// If a write fails, the change is still visible in memory but is lost on
+// restart; writable then fails, and stores check it before any change.
+type recordStore[T any] interface {
+	writable() error
+	put(id string, v T) error
+	delete(id string) error
+	Close() error
+}
+
+// memoryRecords keeps nothing, for stores that are lost on restart.
+type memoryRecords[T any] struct{}
+
+func (memoryRecords[T]) writable() error     { return nil }
+func (memoryRecords[T]) put(string, T) error { return nil }
+func (memoryRecords[T]) delete(string) error { return nil }
+func (memoryRecords[T]) Close() error        { return nil }
+
+// recordJournal keeps records in a journal file of puts and deletes,
+// synced before each write returns. On open the journal is replayed and
+// compacted, which also drops a record torn by a crash.
+type recordJournal[T any] struct {
+	path   string
+	f      *os.File
+	broken error
+}
+
+type recordJournalEntry[T any] struct {
+	ID     string `json:"id"`
+	Record *T     `json:"record,omitempty"` // nil deletes the record
+}
+
+// openRecordJournal replays the journal in path, which need not exist yet,
+// and returns the records it keeps by ID.
+func openRecordJournal[T any](path string) (*recordJournal[T], map[string]T, error) {
+	records := map[string]T{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+	// anything after the last newline is a record torn by a crash
+	if i := bytes.LastIndexByte(data, '\n'); i < len(data)-1 {
+		data = data[:i+1]
+	}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		var e recordJournalEntry[T]
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, nil, fmt.Errorf("journal %s line %d: %w", path, n, err)
+		}
+		if e.Record != nil {
+			records[e.ID] = *e.Record
+		} else {
+			delete(records, e.ID)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, nil, err
+	}
+	j := &recordJournal[T]{path: path}
+	if err := j.compact(records); err != nil {
+		return nil, nil, err
+	}
+	if j.f, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644); err != nil {
+		return nil, nil, err
+	}
+	return j, records, nil
+}
+
+func (j *recordJournal[T]) Close() error {
+	return j.f.Close()
+}
+
+func (j *recordJournal[T]) writable() error {
+	return j.broken
+}
+
+func (j *recordJournal[T]) put(id string, v T) error {
+	return j.append(recordJournalEntry[T]{ID: id, Record: &v})
+}
+
+func (j *recordJournal[T]) delete(id string) error {
+	return j.append(recordJournalEntry[T]{ID: id})
+}
+
+// append writes one entry and syncs it; callers hold the store's lock.
+func (j *recordJournal[T]) append(e recordJournalEntry[T]) error {
+	if j.broken != nil {
+		return j.broken
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err = j.f.Write(append(line, '\n')); err == nil {
+		err = j.f.Sync()
+	}
+	if err != nil {
+		j.broken = fmt.Errorf("journal %s: %w", j.path, err)
+		return j.broken
+	}
+	return nil
+}
+
+// compact rewrites the journal as one put per record, by ID, replacing the
+// old file atomically.
+func (j *recordJournal[T]) compact(records map[string]T) error {
+	ids := make([]string, 0, len(records))
+	for id := range records {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	tmp := j.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, id := range ids {
+		v := records[id]
+		if err = enc.Encode(recordJournalEntry[T]{ID: id, Record: &v}); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, j.path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if dir, err := os.Open(filepath.Dir(j.path)); err == nil {
+		_ = dir.Sync()
+		_ = dir.Close()
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLendingRepos_Persist(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	loans, err := OpenLoanRepo(filepath.Join(dir, "loans.journal"))
+	require.NoError(t, err)
+	holds, err := OpenHoldRepo(filepath.Join(dir, "holds.journal"))
+	require.NoError(t, err)
+	borrowers, err := OpenBorrowerRepo(filepath.Join(dir, "borrowers.journal"))
+	require.NoError(t, err)
+	fees, err := OpenFeeRepo(filepath.Join(dir, "fees.journal"))
+	require.NoError(t, err)
+
+	_, err = borrowers.Create(ctx, model.Borrower{ID: "ada", Name: "Ada", Tenant: "east"})
+	require.NoError(t, err)
+	_, err = borrowers.Create(ctx, model.Borrower{ID: "bob", Name: "Bob"})
+	require.NoError(t, err)
+	require.NoError(t, borrowers.Delete(ctx, "bob"))
+	_, err = loans.Checkout(ctx, model.Loan{ID: "l-1", BookID: "b1", Borrower: "ada", CheckedOutAt: now, DueAt: now.Add(time.Hour)}, 1)
+	require.NoError(t, err)
+	_, err = loans.Return(ctx, "l-1", now.Add(2*time.Hour))
+	require.NoError(t, err)
+	_, err = holds.Place(ctx, model.Hold{ID: "h-1", BookID: "b1", Borrower: "ada", PlacedAt: now})
+	require.NoError(t, err)
+	_, err = holds.Place(ctx, model.Hold{ID: "h-2", BookID: "b2", Borrower: "ada", PlacedAt: now})
+	require.NoError(t, err)
+	require.NoError(t, holds.Delete(ctx, "h-2"))
+	_, err = fees.Add(ctx, model.FeeEntry{ID: "f-1", Borrower: "ada", Kind: model.FeeFine, Amount: model.Price{Amount: 50, Currency: "EUR"}, LoanID: "l-1", At: now})
+	require.NoError(t, err)
+	_, err = fees.Add(ctx, model.FeeEntry{ID: "f-2", Borrower: "ada", Kind: model.FeePayment, Amount: model.Price{Amount: 20, Currency: "EUR"}, At: now.Add(time.Minute)})
+	require.NoError(t, err)
+	_, err = fees.Reassign(ctx, "ada", "card-7")
+	require.NoError(t, err)
+	for _, c := range []interface{ Close() error }{loans, holds, borrowers, fees} {
+		require.NoError(t, c.Close())
+	}
+
+	// a record torn by a crash is dropped
+	f, err := os.OpenFile(filepath.Join(dir, "loans.journal"), os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"id":"l-2","record":{`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	loans, err = OpenLoanRepo(filepath.Join(dir, "loans.journal"))
+	require.NoError(t, err)
+	defer loans.Close()
+	l, err := loans.Get(ctx, "l-1")
+	require.NoError(t, err)
+	require.NotNil(t, l.ReturnedAt)
+	assert.True(t, now.Add(2*time.Hour).Equal(*l.ReturnedAt))
+	_, err = loans.Get(ctx, "l-2")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	holds, err = OpenHoldRepo(filepath.Join(dir, "holds.journal"))
+	require.NoError(t, err)
+	defer holds.Close()
+	_, err = holds.Get(ctx, "h-1")
+	assert.NoError(t, err)
+	_, err = holds.Get(ctx, "h-2")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	borrowers, err = OpenBorrowerRepo(filepath.Join(dir, "borrowers.journal"))
+	require.NoError(t, err)
+	defer borrowers.Close()
+	b, err := borrowers.Get(ctx, "ada")
+	require.NoError(t, err)
+	assert.Equal(t, "east", b.Tenant)
+	_, err = borrowers.Get(ctx, "bob")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	fees, err = OpenFeeRepo(filepath.Join(dir, "fees.journal"))
+	require.NoError(t, err)
+	defer fees.Close()
+	entries, err := fees.List(ctx, "card-7")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "f-1", entries[0].ID, "in ledger order")
+	entries, err = fees.List(ctx, "ada")
+	require.NoError(t, err)
+	assert.Empty(t, entries, "reassigned")
+
+	data, err := os.ReadFile(filepath.Join(dir, "holds.journal"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "h-2", "compacted to the current holds")
+}
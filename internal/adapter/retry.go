@@ -0,0 +1,121 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy says how often and how long to wait between attempts of a
+// call to an upstream service. Waits grow exponentially from Initial by
+// Multiplier up to Max, each randomized by ±Jitter (a fraction) so clients
+// that failed together do not retry together. A server's Retry-After
+// replaces the computed wait. MaxElapsed, when set, bounds the total time
+// spent: a wait that would end past it is not started.
+type RetryPolicy struct {
+	MaxAttempts int // including the first; below 1 is taken as 1
+	Initial     time.Duration
+	Max         time.Duration // 0 is no cap
+	Multiplier  float64       // below 1 is taken as 1
+	Jitter      float64       // 0..1
+	MaxElapsed  time.Duration // 0 is no bound
+}
+
+// DefaultRetryPolicy makes up to retry+1 attempts, waiting about 200ms,
+// 400ms, 800ms ... (at most 5s) in between, for at most 15s in all.
+func DefaultRetryPolicy(retry int) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: max(retry, 0) + 1,
+		Initial:     200 * time.Millisecond,
+		Max:         5 * time.Second,
+		Multiplier:  2,
+		Jitter:      0.5,
+		MaxElapsed:  15 * time.Second,
+	}
+}
+
+// backoff is the wait after the given failed attempt (0 is the first).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.Initial)
+	for range attempt {
+		d *= max(p.Multiplier, 1)
+		if p.Max > 0 && d >= float64(p.Max) {
+			break
+		}
+	}
+	if p.Max > 0 {
+		d = min(d, float64(p.Max))
+	}
+	if j := min(max(p.Jitter, 0), 1); j > 0 {
+		d *= 1 - j + 2*j*rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+// retryAfterError is an upstream error whose response asked the client to
+// wait before trying again.
+type retryAfterError struct {
+	err   error
+	after time.Duration
+}
+
+func (e *retryAfterError) Error() string { return e.err.Error() }
+func (e *retryAfterError) Unwrap() error { return e.err }
+
+// withRetryAfter wraps err with the wait a 429 or 503 response asks for in
+// its Retry-After header, given in seconds or as an HTTP date; other
+// responses leave err as is.
+func withRetryAfter(resp *http.Response, err error) error {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return err
+	}
+	v := resp.Header.Get("Retry-After")
+	if secs, perr := strconv.Atoi(v); perr == nil && secs >= 0 {
+		return &retryAfterError{err: err, after: time.Duration(secs) * time.Second}
+	}
+	if at, perr := http.ParseTime(v); perr == nil {
+		return &retryAfterError{err: err, after: max(time.Until(at), 0)}
+	}
+	return err
+}
+
+// fetchWithRetry runs fetch with DefaultRetryPolicy(retry).
+func fetchWithRetry[T any](ctx context.Context, retry int, fetch func() (T, error)) (T, error) {
+	return fetchWithPolicy(ctx, DefaultRetryPolicy(retry), fetch)
+}
+
+// fetchWithPolicy runs fetch until it succeeds or p gives up, returning the
+// last error. errNotFound and errBudgetExhausted are final and returned at
+// once.
+func fetchWithPolicy[T any](ctx context.Context, p RetryPolicy, fetch func() (T, error)) (T, error) {
+	var zero T
+	start := time.Now()
+	attempts := max(p.MaxAttempts, 1)
+	for i := 0; ; i++ {
+		v, err := fetch()
+		if err == nil {
+			return v, nil
+		}
+		// 404 is final: not found; a spent budget stays spent until tomorrow
+		if errors.Is(err, errNotFound) || errors.Is(err, errBudgetExhausted) || i == attempts-1 {
+			return zero, err
+		}
+		wait := p.backoff(i)
+		var ra *retryAfterError
+		if errors.As(err, &ra) {
+			wait = ra.after
+		}
+		if p.MaxElapsed > 0 && time.Since(start)+wait > p.MaxElapsed {
+			return zero, fmt.Errorf("%w (next retry in %s would pass the %s retry limit)", err, wait.Round(time.Millisecond), p.MaxElapsed)
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+}
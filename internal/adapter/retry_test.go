@@ -0,0 +1,101 @@
+//go:build unit
+
+package adapter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+	var got []time.Duration
+	for i := range 6 {
+		got = append(got, p.backoff(i))
+	}
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond,
+		800 * time.Millisecond, time.Second, time.Second}, got)
+
+	p.Jitter = 0.5
+	for i := range 100 {
+		d := p.backoff(i % 3)
+		base := []time.Duration{100, 200, 400}[i%3] * time.Millisecond
+		assert.GreaterOrEqual(t, d, base/2)
+		assert.LessOrEqual(t, d, base*3/2)
+	}
+}
+
+func TestFetchWithPolicy(t *testing.T) {
+	ctx := context.Background()
+	fail := errors.New("status 500")
+	p := RetryPolicy{MaxAttempts: 3, Initial: time.Millisecond, Multiplier: 2}
+	calls := 0
+	_, err := fetchWithPolicy(ctx, p, func() (int, error) { calls++; return 0, fail })
+	assert.ErrorIs(t, err, fail)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	_, err = fetchWithPolicy(ctx, p, func() (int, error) { calls++; return 0, errNotFound })
+	assert.ErrorIs(t, err, errNotFound)
+	assert.Equal(t, 1, calls, "not found is final")
+
+	// the next wait would end past MaxElapsed
+	p.Initial, p.MaxElapsed = time.Hour, time.Second
+	calls = 0
+	_, err = fetchWithPolicy(ctx, p, func() (int, error) { calls++; return 0, fail })
+	assert.ErrorIs(t, err, fail)
+	assert.ErrorContains(t, err, "would pass the 1s retry limit")
+	assert.Equal(t, 1, calls)
+}
+
+func TestOpenLibrary_RetryAfter(t *testing.T) {
+	calls := 0
+	retryAfter := "0"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"title":"Dune"}`))
+	}))
+	defer srv.Close()
+
+	c := NewOpenLibraryClient(srv.URL, 3, srv.Client())
+	c.Retry.Initial = time.Hour // only Retry-After lets it retry in time
+	eb, err := c.FetchByISBN(context.Background(), "9780441013593")
+	require.NoError(t, err)
+	assert.Equal(t, "Dune", *eb.Title)
+	assert.Equal(t, 2, calls)
+
+	calls, retryAfter = 0, "120"
+	c.Retry.MaxElapsed = 10 * time.Second
+	_, err = c.FetchByISBN(context.Background(), "9780441013593")
+	assert.ErrorContains(t, err, "status 429")
+	assert.ErrorContains(t, err, "next retry in 2m0s")
+	assert.Equal(t, 1, calls, "a Retry-After past MaxElapsed ends the retries")
+}
+
+func TestWithRetryAfter(t *testing.T) {
+	base := errors.New("status 503")
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+	resp.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	var ra *retryAfterError
+	require.ErrorAs(t, withRetryAfter(resp, base), &ra)
+	assert.InDelta(t, time.Minute, ra.after, float64(2*time.Second))
+	assert.ErrorIs(t, ra, base)
+
+	resp.Header.Set("Retry-After", "soon")
+	assert.Same(t, base, withRetryAfter(resp, base))
+	resp.StatusCode = http.StatusInternalServerError
+	resp.Header.Set("Retry-After", "5")
+	assert.Same(t, base, withRetryAfter(resp, base), "only 429 and 503 ask to wait")
+}
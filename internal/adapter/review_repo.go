@@ -0,0 +1,110 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// ReviewRepo keeps book reviews in memory.
+type ReviewRepo struct {
+	mu      sync.RWMutex
+	byID    map[string]model.Review
+	records recordStore[model.Review]
+}
+
+// NewReviewRepo keeps nothing durably; the reviews are lost on restart.
+func NewReviewRepo() *ReviewRepo {
+	return &ReviewRepo{byID: map[string]model.Review{}, records: memoryRecords[model.Review]{}}
+}
+
+// OpenBoltReviewRepo loads the reviews kept in db's reviews bucket.
+func OpenBoltReviewRepo(db *bolt.DB) (*ReviewRepo, error) {
+	b, reviews, err := openBoltRecords[model.Review](db, "reviews")
+	if err != nil {
+		return nil, err
+	}
+	return &ReviewRepo{byID: reviews, records: b}, nil
+}
+
+func (r *ReviewRepo) Add(_ context.Context, rv model.Review) (model.Review, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, other := range r.byID {
+		if other.BookID == rv.BookID && other.Reviewer == rv.Reviewer {
+			return model.Review{}, fmt.Errorf("%w: %s has reviewed book %s already", model.ErrConflict, rv.Reviewer, rv.BookID)
+		}
+	}
+	if err := r.records.writable(); err != nil {
+		return model.Review{}, err
+	}
+	r.byID[rv.ID] = rv
+	return rv, r.records.put(rv.ID, rv)
+}
+
+func (r *ReviewRepo) Get(ctx context.Context, id string) (model.Review, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rv, ok := r.byID[id]
+	if !ok || !ofTenant(ctx, rv.Tenant) {
+		return model.Review{}, fmt.Errorf("%w: review %s", model.ErrNotFound, id)
+	}
+	return rv, nil
+}
+
+func (r *ReviewRepo) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rv, ok := r.byID[id]; !ok || !ofTenant(ctx, rv.Tenant) {
+		return fmt.Errorf("%w: review %s", model.ErrNotFound, id)
+	}
+	if err := r.records.writable(); err != nil {
+		return err
+	}
+	delete(r.byID, id)
+	return r.records.delete(id)
+}
+
+func (r *ReviewRepo) List(ctx context.Context, bookID string) ([]model.Review, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []model.Review
+	for _, rv := range r.byID {
+		if rv.BookID == bookID && ofTenant(ctx, rv.Tenant) {
+			out = append(out, rv)
+		}
+	}
+	slices.SortFunc(out, func(a, b model.Review) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return out, nil
+}
+
+func (r *ReviewRepo) Ratings(ctx context.Context) (map[string]model.Rating, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sums := map[string]int{}
+	out := map[string]model.Rating{}
+	for _, rv := range r.byID {
+		if !ofTenant(ctx, rv.Tenant) {
+			continue
+		}
+		sums[rv.BookID] += rv.Rating
+		rt := out[rv.BookID]
+		rt.Count++
+		out[rv.BookID] = rt
+	}
+	for id, rt := range out {
+		rt.Average = float64(sums[id]) / float64(rt.Count)
+		out[id] = rt
+	}
+	return out, nil
+}
@@ -0,0 +1,41 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"sync"
+)
+
+// maxShelfSyncReports is how many sync reports ShelfSyncRepo keeps.
+const maxShelfSyncReports = 100
+
+// ShelfSyncRepo keeps the latest shelf sync reports in memory; older
+// reports are dropped, and all are lost on restart.
+type ShelfSyncRepo struct {
+	mu      sync.RWMutex
+	reports []model.ShelfSyncReport // oldest first
+}
+
+func NewShelfSyncRepo() *ShelfSyncRepo {
+	return &ShelfSyncRepo{}
+}
+
+func (r *ShelfSyncRepo) Add(_ context.Context, rep model.ShelfSyncReport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, rep)
+	if n := len(r.reports) - maxShelfSyncReports; n > 0 {
+		r.reports = append(r.reports[:0:0], r.reports[n:]...)
+	}
+	return nil
+}
+
+func (r *ShelfSyncRepo) List(_ context.Context, limit int) ([]model.ShelfSyncReport, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []model.ShelfSyncReport
+	for i := len(r.reports) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, r.reports[i])
+	}
+	return out, nil
+}
@@ -0,0 +1,163 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SRUClient checks a library catalog through SRU 1.2 searchRetrieve. A hit
+// means the library holds the book. Copy counts come from ISO 20775 holdings
+// records (recordSchema isohold), read from copiesSummary or from one
+// circulation entry per copy; catalogs that return only bibliographic
+// records leave them unknown.
+type SRUClient struct {
+	BaseURL      string
+	Library      string // display name, e.g. "City Library"
+	Index        string // CQL index for ISBNs, e.g. "bath.isbn"
+	RecordSchema string // empty leaves the server default
+	Client       *http.Client
+	Retry        int
+}
+
+func NewSRUClient(baseURL, library string, retry int, httpClient *http.Client) *SRUClient {
+	if retry < 0 {
+		retry = 0
+	}
+	return &SRUClient{
+		BaseURL:      baseURL,
+		Library:      library,
+		Index:        "bath.isbn",
+		RecordSchema: "isohold",
+		Client:       httpClient,
+		Retry:        retry,
+	}
+}
+
+func (c *SRUClient) CheckAvailability(ctx context.Context, isbn string) (model.Availability, error) {
+	q := url.Values{
+		"version":        {"1.2"},
+		"operation":      {"searchRetrieve"},
+		"query":          {c.Index + `="` + isbn + `"`},
+		"maximumRecords": {"10"},
+	}
+	if c.RecordSchema != "" {
+		q.Set("recordSchema", c.RecordSchema)
+	}
+	sep := "?"
+	if strings.Contains(c.BaseURL, "?") {
+		sep = "&"
+	}
+	u := c.BaseURL + sep + q.Encode()
+	return fetchWithRetry(ctx, c.Retry, func() (model.Availability, error) {
+		return c.fetchOnce(ctx, u)
+	})
+}
+
+func (c *SRUClient) fetchOnce(ctx context.Context, u string) (model.Availability, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return model.Availability{}, err
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return model.Availability{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return model.Availability{}, fmt.Errorf("sru: status %d: %s", resp.StatusCode, string(b))
+	}
+	res, err := parseSRUResponse(resp.Body)
+	if err != nil {
+		return model.Availability{}, err
+	}
+	a := model.Availability{Library: c.Library, Held: res.records > 0, CheckedAt: time.Now()}
+	switch {
+	case res.summaries > 0:
+		a.Copies, a.Available = &res.copiesCount, &res.availableCount
+	case res.circulations > 0:
+		a.Copies, a.Available = &res.circulations, &res.availableNow
+	}
+	return a, nil
+}
+
+type sruResult struct {
+	records        int
+	summaries      int // copiesSummary elements
+	copiesCount    int
+	availableCount int
+	circulations   int // circulation elements, one per copy
+	availableNow   int
+}
+
+// parseSRUResponse reads the counts it needs from a searchRetrieveResponse,
+// matching elements by local name so any namespace prefixes work.
+func parseSRUResponse(r io.Reader) (sruResult, error) {
+	var res sruResult
+	var diagnostic []string
+	dec := xml.NewDecoder(io.LimitReader(r, 4<<20))
+	var path []string
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return sruResult{}, fmt.Errorf("sru: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			path = append(path, t.Name.Local)
+			switch t.Name.Local {
+			case "copiesSummary":
+				res.summaries++
+			case "circulation":
+				res.circulations++
+			case "availabilityNow":
+				for _, at := range t.Attr {
+					if at.Name.Local == "value" && at.Value == "1" {
+						res.availableNow++
+					}
+				}
+			}
+		case xml.EndElement:
+			path = path[:len(path)-1]
+		case xml.CharData:
+			if len(path) == 0 {
+				continue
+			}
+			text := strings.TrimSpace(string(t))
+			if text == "" {
+				continue
+			}
+			n, _ := strconv.Atoi(text)
+			switch path[len(path)-1] {
+			case "numberOfRecords":
+				res.records = n
+			case "copiesCount":
+				res.copiesCount += n
+			case "availableCount":
+				res.availableCount += n
+			case "message", "details":
+				if slices.Contains(path, "diagnostic") {
+					diagnostic = append(diagnostic, text)
+				}
+			}
+		}
+	}
+	if len(diagnostic) > 0 {
+		return sruResult{}, fmt.Errorf("sru: %s", strings.Join(diagnostic, ": "))
+	}
+	return res, nil
+}
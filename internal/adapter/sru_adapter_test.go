@@ -0,0 +1,70 @@
+//go:build unit
+
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSRU_CheckAvailability(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "searchRetrieve", q.Get("operation"))
+		assert.Equal(t, "isohold", q.Get("recordSchema"))
+		switch q.Get("query") {
+		case `bath.isbn="9780134494166"`:
+			_, _ = w.Write([]byte(`<?xml version="1.0"?>
+<srw:searchRetrieveResponse xmlns:srw="http://www.loc.gov/zing/srw/">
+  <srw:version>1.2</srw:version>
+  <srw:numberOfRecords>1</srw:numberOfRecords>
+  <srw:records><srw:record><srw:recordData>
+    <holdings xmlns="http://www.loc.gov/standards/iso20775/">
+      <holding><holdingStructured><set><component>
+        <circulation><availabilityNow value="0"/></circulation>
+        <circulation><availabilityNow value="1"/></circulation>
+      </component></set></holdingStructured></holding>
+    </holdings>
+  </srw:recordData></srw:record></srw:records>
+</srw:searchRetrieveResponse>`))
+		case `bath.isbn="9780123456786"`:
+			_, _ = w.Write([]byte(`<searchRetrieveResponse><numberOfRecords>1</numberOfRecords><records><record><recordData>
+<holdings><holding><holdingSimple><copiesSummary><copiesCount>3</copiesCount><status><availableCount>0</availableCount></status></copiesSummary></holdingSimple></holding></holdings>
+</recordData></record></records></searchRetrieveResponse>`))
+		case `bath.isbn="9780441013593"`:
+			_, _ = w.Write([]byte(`<searchRetrieveResponse><numberOfRecords>0</numberOfRecords></searchRetrieveResponse>`))
+		default:
+			_, _ = w.Write([]byte(`<searchRetrieveResponse><diagnostics><diagnostic>
+<uri>info:srw/diagnostic/1/66</uri><message>Unknown schema for retrieval</message><details>isohold</details>
+</diagnostic></diagnostics></searchRetrieveResponse>`))
+		}
+	}))
+	defer srv.Close()
+	c := NewSRUClient(srv.URL, "City Library", 0, srv.Client())
+
+	a, err := c.CheckAvailability(context.Background(), "9780134494166")
+	require.NoError(t, err)
+	assert.Equal(t, "City Library", a.Library)
+	assert.True(t, a.Held)
+	assert.Equal(t, 2, *a.Copies)
+	assert.Equal(t, 1, *a.Available)
+	assert.True(t, a.Borrowable())
+
+	a, err = c.CheckAvailability(context.Background(), "9780123456786")
+	require.NoError(t, err)
+	assert.Equal(t, 3, *a.Copies)
+	assert.False(t, a.Borrowable(), "all copies lent out")
+
+	a, err = c.CheckAvailability(context.Background(), "9780441013593")
+	require.NoError(t, err)
+	assert.False(t, a.Held)
+	assert.Nil(t, a.Copies)
+
+	_, err = c.CheckAvailability(context.Background(), "9790000000001")
+	assert.ErrorContains(t, err, "Unknown schema for retrieval: isohold")
+}
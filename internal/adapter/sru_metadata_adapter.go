@@ -0,0 +1,114 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SRUMetadataClient looks books up in a library catalog over SRU 1.2,
+// asking for MARCXML records. Queries are CQL templates in which {isbn} is
+// replaced by the ISBN; they are tried in order until one finds a record,
+// as catalogs differ in which ISBN index they support.
+type SRUMetadataClient struct {
+	BaseURL      string
+	Queries      []string // e.g. bath.isbn="{isbn}"
+	RecordSchema string   // must name MARCXML on this server, e.g. "marcxml"
+	Client       *http.Client
+	Retry        int
+}
+
+// DefaultSRUQueries cover the Bath profile ISBN index and, for catalogs
+// without it, Dublin Core identifiers.
+var DefaultSRUQueries = []string{`bath.isbn="{isbn}"`, `dc.identifier="{isbn}"`}
+
+func NewSRUMetadataClient(baseURL string, retry int, httpClient *http.Client) *SRUMetadataClient {
+	if retry < 0 {
+		retry = 0
+	}
+	return &SRUMetadataClient{
+		BaseURL:      baseURL,
+		Queries:      DefaultSRUQueries,
+		RecordSchema: "marcxml",
+		Client:       httpClient,
+		Retry:        retry,
+	}
+}
+
+func (c *SRUMetadataClient) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	for _, tpl := range c.Queries {
+		q := url.Values{
+			"version":        {"1.2"},
+			"operation":      {"searchRetrieve"},
+			"query":          {strings.ReplaceAll(tpl, "{isbn}", isbn)},
+			"recordPacking":  {"xml"},
+			"maximumRecords": {"1"},
+		}
+		if c.RecordSchema != "" {
+			q.Set("recordSchema", c.RecordSchema)
+		}
+		sep := "?"
+		if strings.Contains(c.BaseURL, "?") {
+			sep = "&"
+		}
+		u := c.BaseURL + sep + q.Encode()
+		eb, err := fetchWithRetry(ctx, c.Retry, func() (model.EnrichedBook, error) {
+			return c.fetchOnce(ctx, u)
+		})
+		if !errors.Is(err, errNotFound) {
+			return eb, err
+		}
+	}
+	return model.EnrichedBook{}, errNotFound
+}
+
+func (c *SRUMetadataClient) fetchOnce(ctx context.Context, u string) (model.EnrichedBook, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return model.EnrichedBook{}, err
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return model.EnrichedBook{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return model.EnrichedBook{}, fmt.Errorf("sru: status %d: %s", resp.StatusCode, string(b))
+	}
+	var res sruMARCResponse
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 4<<20)).Decode(&res); err != nil {
+		return model.EnrichedBook{}, fmt.Errorf("sru: %w", err)
+	}
+	if len(res.Diagnostics) > 0 {
+		d := res.Diagnostics[0]
+		msg := d.Message
+		if d.Details != "" {
+			msg += ": " + d.Details
+		}
+		return model.EnrichedBook{}, fmt.Errorf("sru: %s", msg)
+	}
+	if len(res.Records) == 0 {
+		return model.EnrichedBook{}, errNotFound
+	}
+	return mapMARCRecord(res.Records[0].Data.Record, "sru"), nil
+}
+
+type sruMARCResponse struct {
+	Records []struct {
+		Data struct {
+			Record marcRecord `xml:"record"`
+		} `xml:"recordData"`
+	} `xml:"records>record"`
+	Diagnostics []struct {
+		Message string `xml:"message"`
+		Details string `xml:"details"`
+	} `xml:"diagnostics>diagnostic"`
+}
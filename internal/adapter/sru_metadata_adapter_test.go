@@ -0,0 +1,90 @@
+//go:build unit
+
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const marcResponse = `<?xml version="1.0"?>
+<zs:searchRetrieveResponse xmlns:zs="http://www.loc.gov/zing/srw/">
+  <zs:numberOfRecords>1</zs:numberOfRecords>
+  <zs:records><zs:record>
+    <zs:recordSchema>marcxml</zs:recordSchema>
+    <zs:recordData>
+      <marc:record xmlns:marc="http://www.loc.gov/MARC21/slim">
+        <marc:controlfield tag="008">080410s2009    njua     b    001 0 eng  </marc:controlfield>
+        <marc:datafield tag="100" ind1="1" ind2=" "><marc:subfield code="a">Martin, Robert C.,</marc:subfield><marc:subfield code="e">author.</marc:subfield></marc:datafield>
+        <marc:datafield tag="245" ind1="1" ind2="0"><marc:subfield code="a">Clean code :</marc:subfield><marc:subfield code="b">a handbook of agile software craftsmanship /</marc:subfield><marc:subfield code="c">Robert C. Martin.</marc:subfield></marc:datafield>
+        <marc:datafield tag="264" ind1=" " ind2="1"><marc:subfield code="c">[2009]</marc:subfield></marc:datafield>
+        <marc:datafield tag="300" ind1=" " ind2=" "><marc:subfield code="a">xxix, 431 pages :</marc:subfield></marc:datafield>
+        <marc:datafield tag="520" ind1=" " ind2=" "><marc:subfield code="a">Even bad code can function.</marc:subfield></marc:datafield>
+        <marc:datafield tag="650" ind1=" " ind2="0"><marc:subfield code="a">Agile software development.</marc:subfield></marc:datafield>
+        <marc:datafield tag="650" ind1=" " ind2="0"><marc:subfield code="a">Computer software</marc:subfield><marc:subfield code="x">Reliability.</marc:subfield></marc:datafield>
+        <marc:datafield tag="700" ind1="1" ind2=" "><marc:subfield code="a">Feathers, Michael C.,</marc:subfield><marc:subfield code="e">contributor.</marc:subfield></marc:datafield>
+        <marc:datafield tag="700" ind1="1" ind2=" "><marc:subfield code="a">Ottinger, Tim,</marc:subfield><marc:subfield code="4">aut</marc:subfield></marc:datafield>
+      </marc:record>
+    </zs:recordData>
+  </zs:record></zs:records>
+</zs:searchRetrieveResponse>`
+
+func TestSRUMetadata_FetchByISBN(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "marcxml", q.Get("recordSchema"))
+		queries = append(queries, q.Get("query"))
+		switch q.Get("query") {
+		case `dc.identifier="9780132350884"`:
+			_, _ = w.Write([]byte(marcResponse))
+		case `dc.identifier="9780000000002"`:
+			_, _ = w.Write([]byte(`<searchRetrieveResponse><diagnostics><diagnostic>
+<uri>info:srw/diagnostic/1/16</uri><message>Unsupported index</message><details>dc.identifier</details>
+</diagnostic></diagnostics></searchRetrieveResponse>`))
+		default:
+			_, _ = w.Write([]byte(`<searchRetrieveResponse><numberOfRecords>0</numberOfRecords></searchRetrieveResponse>`))
+		}
+	}))
+	defer srv.Close()
+	c := NewSRUMetadataClient(srv.URL, 0, srv.Client())
+
+	eb, err := c.FetchByISBN(context.Background(), "9780132350884")
+	require.NoError(t, err)
+	assert.Equal(t, []string{`bath.isbn="9780132350884"`, `dc.identifier="9780132350884"`}, queries)
+	assert.Equal(t, "sru", eb.Source)
+	assert.Equal(t, "Clean code", *eb.Title)
+	assert.Equal(t, "a handbook of agile software craftsmanship", *eb.Subtitle)
+	assert.Equal(t, []string{"Robert C. Martin", "Tim Ottinger"}, eb.Authors)
+	assert.Equal(t, 2009, *eb.PublishedYear)
+	assert.Equal(t, 431, *eb.PageCount)
+	assert.Equal(t, "Even bad code can function.", *eb.Description)
+	assert.Equal(t, []string{"Agile software development", "Computer software"}, eb.Subjects)
+
+	_, err = c.FetchByISBN(context.Background(), "9780441013593")
+	assert.ErrorIs(t, err, errNotFound)
+
+	_, err = c.FetchByISBN(context.Background(), "9780000000002")
+	assert.ErrorContains(t, err, "Unsupported index: dc.identifier")
+}
+
+func TestMapMARCRecord_YearFrom008(t *testing.T) {
+	rec := marcRecord{
+		ControlFields: []marcControlField{{Tag: "008", Value: "851104s1965    nyu           000 1 eng  "}},
+		DataFields: []marcDataField{
+			{Tag: "245", Ind1: "1", Ind2: "0", Subfields: []marcSubfield{{Code: "a", Value: "Dune."}}},
+			{Tag: "100", Ind1: "0", Subfields: []marcSubfield{{Code: "a", Value: "Frank Herbert."}}},
+		},
+	}
+	eb := mapMARCRecord(rec, "sru")
+	assert.Equal(t, "Dune", *eb.Title)
+	assert.Nil(t, eb.Subtitle)
+	assert.Equal(t, []string{"Frank Herbert"}, eb.Authors)
+	assert.Equal(t, 1965, *eb.PublishedYear)
+	assert.Nil(t, eb.PageCount)
+}
@@ -0,0 +1,262 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// StarlarkHook rewrites the input of created books with a Starlark script
+// that defines transform(book). book is a dict of isbn, title, subtitle,
+// published_year, page_count and cover_url, None when absent, and the
+// lists authors and tags; transform returns it, changed as it sees fit, or
+// calls fail(msg) to reject the book:
+//
+//	def transform(book):
+//	    if book["isbn"] and book["isbn"].startswith("978014"):
+//	        book["tags"].append("penguin")
+//	    book["title"] = book["title"].strip() if book["title"] else None
+//	    return book
+//
+// Scripts are sandboxed: they have only Starlark's built-ins, no load, no
+// while loops or recursion, and each run is cut off after a timeout and a
+// number of execution steps. Without a script books are left as given.
+// Load replaces the script at runtime.
+type StarlarkHook struct {
+	log    *slog.Logger
+	script atomic.Pointer[starlarkScript]
+}
+
+type starlarkScript struct {
+	name      string
+	transform starlark.Callable
+	timeout   time.Duration
+	maxSteps  uint64
+}
+
+// starlarkBookFields are the keys of the book dict, in its order.
+var starlarkBookFields = []string{"isbn", "title", "subtitle", "published_year", "page_count", "cover_url", "authors", "tags"}
+
+func NewStarlarkHook(log *slog.Logger) *StarlarkHook {
+	return &StarlarkHook{log: log}
+}
+
+// Load compiles src, named name in errors, and runs it with the limits of
+// a transform; an empty src removes the script. The old script stays in
+// place when src fails.
+func (h *StarlarkHook) Load(name string, src []byte, timeout time.Duration, maxSteps uint64) error {
+	if len(src) == 0 {
+		h.script.Store(nil)
+		return nil
+	}
+	s := &starlarkScript{name: name, timeout: timeout, maxSteps: maxSteps}
+	var globals starlark.StringDict
+	err := s.run(context.Background(), h.log, func(th *starlark.Thread) error {
+		var err error
+		globals, err = starlark.ExecFileOptions(&syntax.FileOptions{}, th, name, src, nil)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("create hook %s: %w", name, err)
+	}
+	fn, ok := globals["transform"].(starlark.Callable)
+	if !ok {
+		return fmt.Errorf("create hook %s: no transform function", name)
+	}
+	s.transform = fn
+	h.script.Store(s)
+	return nil
+}
+
+func (h *StarlarkHook) Transform(ctx context.Context, in model.CreateBookInput) (model.CreateBookInput, error) {
+	s := h.script.Load()
+	if s == nil {
+		return in, nil
+	}
+	var out starlark.Value
+	err := s.run(ctx, h.log, func(th *starlark.Thread) error {
+		var err error
+		out, err = starlark.Call(th, s.transform, starlark.Tuple{toStarlarkBook(in)}, nil)
+		return err
+	})
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		// fail(), or a script error on this book
+		return in, fmt.Errorf("%w: create hook: %s", model.ErrValidation, evalErr.Msg)
+	}
+	if err != nil {
+		return in, fmt.Errorf("create hook %s: %w", s.name, err)
+	}
+	in, err = fromStarlarkBook(out, in)
+	if err != nil {
+		return in, fmt.Errorf("create hook %s: %w", s.name, err)
+	}
+	return in, nil
+}
+
+// run calls fn with a thread of its own that is cancelled after the
+// script's timeout and steps, or when ctx ends; the error then says so
+// instead of being the script's.
+func (s *starlarkScript) run(ctx context.Context, log *slog.Logger, fn func(*starlark.Thread) error) error {
+	th := &starlark.Thread{
+		Name: s.name,
+		Print: func(_ *starlark.Thread, msg string) {
+			log.Debug("create hook print", "script", s.name, "msg", msg)
+		},
+		// Load left nil: load statements fail
+	}
+	th.SetMaxExecutionSteps(s.maxSteps) // 0: no limit
+	var cancel context.CancelFunc
+	if s.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+	stop := context.AfterFunc(ctx, func() { th.Cancel(ctx.Err().Error()) })
+	defer stop()
+	err := fn(th)
+	switch {
+	case err == nil:
+		return nil
+	case ctx.Err() != nil:
+		return fmt.Errorf("cut off: %w", ctx.Err())
+	case s.maxSteps > 0 && th.ExecutionSteps() >= s.maxSteps:
+		return fmt.Errorf("cut off after %d steps", s.maxSteps)
+	}
+	return err
+}
+
+func toStarlarkBook(in model.CreateBookInput) *starlark.Dict {
+	str := func(v *string) starlark.Value {
+		if v == nil {
+			return starlark.None
+		}
+		return starlark.String(*v)
+	}
+	num := func(v *int) starlark.Value {
+		if v == nil {
+			return starlark.None
+		}
+		return starlark.MakeInt(*v)
+	}
+	list := func(vs []string) starlark.Value {
+		l := make([]starlark.Value, 0, len(vs))
+		for _, v := range vs {
+			l = append(l, starlark.String(v))
+		}
+		return starlark.NewList(l)
+	}
+	d := starlark.NewDict(len(starlarkBookFields))
+	for _, kv := range []struct {
+		key string
+		v   starlark.Value
+	}{
+		{"isbn", str(in.ISBN)}, {"title", str(in.Title)}, {"subtitle", str(in.Subtitle)},
+		{"published_year", num(in.PublishedYear)}, {"page_count", num(in.PageCount)},
+		{"cover_url", str(in.CoverURL)}, {"authors", list(in.Authors)}, {"tags", list(in.Tags)},
+	} {
+		_ = d.SetKey(starlark.String(kv.key), kv.v) // a new dict of strings takes any value
+	}
+	return d
+}
+
+// fromStarlarkBook reads the book dict transform returned into in; keys it
+// dropped are absent.
+func fromStarlarkBook(v starlark.Value, in model.CreateBookInput) (model.CreateBookInput, error) {
+	d, ok := v.(*starlark.Dict)
+	if !ok {
+		return in, fmt.Errorf("transform returned %s, want dict", v.Type())
+	}
+	for _, k := range d.Keys() {
+		if s, ok := k.(starlark.String); !ok || !slices.Contains(starlarkBookFields, string(s)) {
+			return in, fmt.Errorf("book has unknown key %s", k)
+		}
+	}
+	field := func(key string) starlark.Value {
+		v, found, _ := d.Get(starlark.String(key))
+		if !found {
+			return starlark.None
+		}
+		return v
+	}
+	str := func(key string) (*string, error) {
+		switch v := field(key).(type) {
+		case starlark.NoneType:
+			return nil, nil
+		case starlark.String:
+			s := string(v)
+			return &s, nil
+		default:
+			return nil, fmt.Errorf("book[%q] is %s, want string or None", key, v.Type())
+		}
+	}
+	num := func(key string) (*int, error) {
+		switch v := field(key).(type) {
+		case starlark.NoneType:
+			return nil, nil
+		case starlark.Int:
+			n, ok := v.Int64()
+			if !ok || int64(int(n)) != n {
+				return nil, fmt.Errorf("book[%q] is out of range", key)
+			}
+			i := int(n)
+			return &i, nil
+		default:
+			return nil, fmt.Errorf("book[%q] is %s, want int or None", key, v.Type())
+		}
+	}
+	list := func(key string) ([]string, error) {
+		v := field(key)
+		if v == starlark.None {
+			return nil, nil
+		}
+		l, ok := v.(*starlark.List)
+		if !ok {
+			return nil, fmt.Errorf("book[%q] is %s, want list", key, v.Type())
+		}
+		out := make([]string, 0, l.Len())
+		for i := 0; i < l.Len(); i++ {
+			s, ok := l.Index(i).(starlark.String)
+			if !ok {
+				return nil, fmt.Errorf("book[%q][%d] is %s, want string", key, i, l.Index(i).Type())
+			}
+			out = append(out, string(s))
+		}
+		return out, nil
+	}
+	var err error
+	if in.ISBN, err = str("isbn"); err != nil {
+		return in, err
+	}
+	if in.Title, err = str("title"); err != nil {
+		return in, err
+	}
+	if in.Subtitle, err = str("subtitle"); err != nil {
+		return in, err
+	}
+	if in.CoverURL, err = str("cover_url"); err != nil {
+		return in, err
+	}
+	if in.PublishedYear, err = num("published_year"); err != nil {
+		return in, err
+	}
+	if in.PageCount, err = num("page_count"); err != nil {
+		return in, err
+	}
+	if in.Authors, err = list("authors"); err != nil {
+		return in, err
+	}
+	if in.Tags, err = list("tags"); err != nil {
+		return in, err
+	}
+	return in, nil
+}
@@ -0,0 +1,96 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStarlarkHook(t *testing.T) {
+	ctx := context.Background()
+	h := NewStarlarkHook(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	in := model.CreateBookInput{ISBN: util.GetPtr("9780141439518"), Title: util.GetPtr("  pride and prejudice "), Tags: []string{"novel"}, Enrich: true}
+	out, err := h.Transform(ctx, in)
+	require.NoError(t, err)
+	assert.Equal(t, in, out, "no script")
+
+	require.NoError(t, h.Load("rules.star", []byte(`
+PUBLISHERS = {"978014": "penguin", "978006": "harper"}
+
+def transform(book):
+    for prefix, tag in PUBLISHERS.items():
+        if book["isbn"] and book["isbn"].startswith(prefix):
+            book["tags"].append(tag)
+    if book["title"]:
+        book["title"] = book["title"].strip().title()
+    if book["page_count"] == None:
+        book.pop("page_count")
+    if "draft" in book["tags"]:
+        fail("drafts are not catalogued")
+    return book
+`), time.Second, 1_000_000))
+	out, err = h.Transform(ctx, in)
+	require.NoError(t, err)
+	assert.Equal(t, "Pride And Prejudice", *out.Title)
+	assert.Equal(t, []string{"novel", "penguin"}, out.Tags)
+	assert.Equal(t, in.ISBN, out.ISBN)
+	assert.Nil(t, out.PageCount)
+	assert.True(t, out.Enrich, "fields the script does not see are kept")
+
+	in.Tags = []string{"draft"}
+	_, err = h.Transform(ctx, in)
+	assert.ErrorIs(t, err, model.ErrValidation)
+	assert.Contains(t, err.Error(), "drafts are not catalogued")
+
+	// a script that fails to load leaves the running one in place
+	assert.Error(t, h.Load("bad.star", []byte(`load("os.star", "os")`), time.Second, 1_000_000))
+	assert.Error(t, h.Load("bad.star", []byte("def transform(book):\n    while True:\n        pass\n"), time.Second, 1_000_000))
+	assert.Error(t, h.Load("bad.star", []byte("x = 1\n"), time.Second, 1_000_000), "no transform")
+	in.Tags = nil
+	out, err = h.Transform(ctx, in)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"penguin"}, out.Tags)
+
+	require.NoError(t, h.Load("wrong.star", []byte("def transform(book):\n    book[\"page_count\"] = \"many\"\n    return book\n"), time.Second, 1_000_000))
+	_, err = h.Transform(ctx, in)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, model.ErrValidation, "a broken script is the server's fault")
+
+	require.NoError(t, h.Load("", nil, 0, 0))
+	out, err = h.Transform(ctx, in)
+	require.NoError(t, err)
+	assert.Equal(t, in, out, "script removed")
+}
+
+func TestStarlarkHook_Limits(t *testing.T) {
+	ctx := context.Background()
+	h := NewStarlarkHook(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	spin := []byte(`
+def transform(book):
+    for i in range(1000000000):
+        pass
+    return book
+`)
+	require.NoError(t, h.Load("spin.star", spin, time.Minute, 10_000))
+	_, err := h.Transform(ctx, model.CreateBookInput{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "10000 steps")
+
+	require.NoError(t, h.Load("spin.star", spin, 20*time.Millisecond, 0))
+	start := time.Now()
+	_, err = h.Transform(ctx, model.CreateBookInput{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	assert.Error(t, h.Load("spin.star", []byte("x = [i for i in range(1000000000)]\ndef transform(book):\n    return book\n"), time.Minute, 10_000),
+		"loading runs under the same limits")
+}
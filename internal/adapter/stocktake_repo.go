@@ -0,0 +1,95 @@
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// StocktakeRepo keeps stocktakes in memory; they are lost on restart.
+type StocktakeRepo struct {
+	mu   sync.RWMutex
+	byID map[string]*model.Stocktake
+	seen map[string]map[string]bool // stocktake id -> scanned barcodes
+}
+
+func NewStocktakeRepo() *StocktakeRepo {
+	return &StocktakeRepo{byID: map[string]*model.Stocktake{}, seen: map[string]map[string]bool{}}
+}
+
+func (r *StocktakeRepo) Create(_ context.Context, st model.Stocktake) (model.Stocktake, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byID[st.ID]; ok {
+		return model.Stocktake{}, fmt.Errorf("%w: stocktake %s exists", model.ErrConflict, st.ID)
+	}
+	st.Scanned = slices.Clone(st.Scanned)
+	r.byID[st.ID] = &st
+	r.seen[st.ID] = map[string]bool{}
+	for _, code := range st.Scanned {
+		r.seen[st.ID][code] = true
+	}
+	return cloneStocktake(st), nil
+}
+
+func (r *StocktakeRepo) Get(ctx context.Context, id string) (model.Stocktake, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	st, ok := r.byID[id]
+	if !ok || !ofTenant(ctx, st.Tenant) {
+		return model.Stocktake{}, fmt.Errorf("%w: stocktake %s", model.ErrNotFound, id)
+	}
+	return cloneStocktake(*st), nil
+}
+
+func (r *StocktakeRepo) List(ctx context.Context) ([]model.Stocktake, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]model.Stocktake, 0, len(r.byID))
+	for _, st := range r.byID {
+		if ofTenant(ctx, st.Tenant) {
+			out = append(out, cloneStocktake(*st))
+		}
+	}
+	slices.SortFunc(out, func(a, b model.Stocktake) int { return b.StartedAt.Compare(a.StartedAt) })
+	return out, nil
+}
+
+func (r *StocktakeRepo) AddScan(ctx context.Context, id, code string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st, ok := r.byID[id]
+	switch {
+	case !ok || !ofTenant(ctx, st.Tenant):
+		return false, fmt.Errorf("%w: stocktake %s", model.ErrNotFound, id)
+	case st.ClosedAt != nil:
+		return false, fmt.Errorf("%w: stocktake %s is closed", model.ErrConflict, id)
+	case r.seen[id][code]:
+		return false, nil
+	}
+	r.seen[id][code] = true
+	st.Scanned = append(st.Scanned, code)
+	return true, nil
+}
+
+func (r *StocktakeRepo) Close(ctx context.Context, st model.Stocktake) (model.Stocktake, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	old, ok := r.byID[st.ID]
+	switch {
+	case !ok || !ofTenant(ctx, old.Tenant):
+		return model.Stocktake{}, fmt.Errorf("%w: stocktake %s", model.ErrNotFound, st.ID)
+	case old.ClosedAt != nil:
+		return model.Stocktake{}, fmt.Errorf("%w: stocktake %s is closed", model.ErrConflict, st.ID)
+	}
+	old.ClosedAt, old.ClosedBy, old.Report = st.ClosedAt, st.ClosedBy, st.Report
+	return cloneStocktake(*old), nil
+}
+
+// cloneStocktake copies st's scans, which AddScan appends to.
+func cloneStocktake(st model.Stocktake) model.Stocktake {
+	st.Scanned = slices.Clone(st.Scanned)
+	return st
+}
@@ -0,0 +1,133 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// LibreTranslateClient translates through a LibreTranslate server
+// (POST /translate), detecting the source language.
+type LibreTranslateClient struct {
+	BaseURL string
+	APIKey  string // optional; required by libretranslate.com
+	Client  *http.Client
+	Retry   int
+}
+
+func NewLibreTranslateClient(baseURL, apiKey string, retry int, httpClient *http.Client) *LibreTranslateClient {
+	if baseURL == "" {
+		baseURL = "https://libretranslate.com"
+	}
+	if retry < 0 {
+		retry = 0
+	}
+	return &LibreTranslateClient{BaseURL: baseURL, APIKey: apiKey, Client: httpClient, Retry: retry}
+}
+
+func (c *LibreTranslateClient) Translate(ctx context.Context, texts []string, target string) ([]string, error) {
+	body, err := json.Marshal(map[string]any{
+		"q":       texts,
+		"source":  "auto",
+		"target":  target,
+		"format":  "text",
+		"api_key": c.APIKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fetchWithRetry(ctx, c.Retry, func() ([]string, error) {
+		var res struct {
+			TranslatedText []string `json:"translatedText"`
+		}
+		if err := postJSON(ctx, c.Client, c.BaseURL+"/translate", nil, body, "libretranslate", &res); err != nil {
+			return nil, err
+		}
+		return res.TranslatedText, nil
+	})
+}
+
+// DeepLClient translates through the DeepL API v2.
+type DeepLClient struct {
+	BaseURL string
+	AuthKey string
+	Client  *http.Client
+	Retry   int
+}
+
+// NewDeepLClient picks the free API endpoint for free-plan keys, which end
+// in ":fx", unless baseURL is given.
+func NewDeepLClient(baseURL, authKey string, retry int, httpClient *http.Client) *DeepLClient {
+	if baseURL == "" {
+		baseURL = "https://api.deepl.com"
+		if strings.HasSuffix(authKey, ":fx") {
+			baseURL = "https://api-free.deepl.com"
+		}
+	}
+	if retry < 0 {
+		retry = 0
+	}
+	return &DeepLClient{BaseURL: baseURL, AuthKey: authKey, Client: httpClient, Retry: retry}
+}
+
+func (c *DeepLClient) Translate(ctx context.Context, texts []string, target string) ([]string, error) {
+	body, err := json.Marshal(map[string]any{
+		"text":        texts,
+		"target_lang": strings.ToUpper(target),
+	})
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{"Authorization": {"DeepL-Auth-Key " + c.AuthKey}}
+	return fetchWithRetry(ctx, c.Retry, func() ([]string, error) {
+		var res struct {
+			Translations []struct {
+				Text string `json:"text"`
+			} `json:"translations"`
+		}
+		if err := postJSON(ctx, c.Client, c.BaseURL+"/v2/translate", header, body, "deepl", &res); err != nil {
+			return nil, err
+		}
+		out := make([]string, len(res.Translations))
+		for i, t := range res.Translations {
+			out[i] = t.Text
+		}
+		return out, nil
+	})
+}
+
+// postJSON posts body and decodes a 2xx JSON response into out.
+func postJSON(ctx context.Context, client *http.Client, u string, header http.Header, body []byte, name string, out any) error {
+	return doJSON(ctx, client, http.MethodPost, u, header, body, name, out)
+}
+
+// doJSON sends body and decodes the answer into out; a nil out discards it.
+func doJSON(ctx context.Context, client *http.Client, method, u string, header http.Header, body []byte, name string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("%s: status %d: %s", name, resp.StatusCode, string(b))
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,81 @@
+//go:build unit
+
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibreTranslate_Translate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/translate", r.URL.Path)
+		var req struct {
+			Q      []string `json:"q"`
+			Source string   `json:"source"`
+			Target string   `json:"target"`
+			APIKey string   `json:"api_key"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "auto", req.Source)
+		assert.Equal(t, "secret", req.APIKey)
+		if req.Target != "de" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"xx is not supported"}`))
+			return
+		}
+		out := make([]string, len(req.Q))
+		for i, q := range req.Q {
+			out[i] = strings.ToUpper(q)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"translatedText": out})
+	}))
+	defer srv.Close()
+	c := NewLibreTranslateClient(srv.URL, "secret", 0, srv.Client())
+
+	out, err := c.Translate(context.Background(), []string{"a desert planet", "fiction"}, "de")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A DESERT PLANET", "FICTION"}, out)
+
+	_, err = c.Translate(context.Background(), []string{"fiction"}, "xx")
+	assert.ErrorContains(t, err, "not supported")
+}
+
+func TestDeepL_Translate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/translate", r.URL.Path)
+		assert.Equal(t, "DeepL-Auth-Key k:fx", r.Header.Get("Authorization"))
+		var req struct {
+			Text       []string `json:"text"`
+			TargetLang string   `json:"target_lang"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "DE", req.TargetLang)
+		_, _ = w.Write([]byte(`{"translations":[{"detected_source_language":"EN","text":"Ein Wüstenplanet"}]}`))
+	}))
+	defer srv.Close()
+
+	assert.Equal(t, "https://api-free.deepl.com", NewDeepLClient("", "k:fx", 0, nil).BaseURL)
+	c := NewDeepLClient(srv.URL, "k:fx", 0, srv.Client())
+	out, err := c.Translate(context.Background(), []string{"A desert planet"}, "de")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Ein Wüstenplanet"}, out)
+}
+
+func TestOpenLibrary_DescriptionForms(t *testing.T) {
+	var ob openLibBook
+	require.NoError(t, json.Unmarshal([]byte(`{"description":{"type":"/type/text","value":" A desert planet. "},"subjects":["Fiction",""]}`), &ob))
+	eb := mapToEnriched(ob)
+	assert.Equal(t, "A desert planet.", *eb.Description)
+	assert.Equal(t, []string{"Fiction"}, eb.Subjects)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"description":"Plain."}`), &ob))
+	assert.Equal(t, "Plain.", *mapToEnriched(ob).Description)
+}
@@ -0,0 +1,134 @@
+//go:build unit
+
+package adapter
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWSAccept(t *testing.T) {
+	// the example from RFC 6455 section 1.3
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", wsAccept("dGhlIHNhbXBsZSBub25jZQ=="))
+}
+
+// wsTestClient is a minimal client side: it masks what it sends, as
+// clients must.
+type wsTestClient struct {
+	t    *testing.T
+	conn net.Conn
+	br   *bufio.Reader
+}
+
+func dialWS(t *testing.T, srv *httptest.Server, path string) *wsTestClient {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	_, err = io.WriteString(conn, "GET "+path+" HTTP/1.1\r\nHost: test\r\n"+
+		"Connection: keep-alive, Upgrade\r\nUpgrade: websocket\r\n"+
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n\r\n")
+	require.NoError(t, err)
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	require.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+	return &wsTestClient{t: t, conn: conn, br: br}
+}
+
+func (c *wsTestClient) send(op byte, payload []byte) {
+	mask := []byte{1, 2, 3, 4}
+	frame := []byte{0x80 | op, 0x80 | byte(len(payload))}
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	_, err := c.conn.Write(frame)
+	require.NoError(c.t, err)
+}
+
+func (c *wsTestClient) read() (byte, []byte) {
+	c.t.Helper()
+	var hdr [2]byte
+	_, err := io.ReadFull(c.br, hdr[:])
+	require.NoError(c.t, err)
+	n := int(hdr[1] & 0x7f)
+	if n == 126 {
+		var ext [2]byte
+		_, err = io.ReadFull(c.br, ext[:])
+		require.NoError(c.t, err)
+		n = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, n)
+	_, err = io.ReadFull(c.br, payload)
+	require.NoError(c.t, err)
+	return hdr[0] & 0x0f, payload
+}
+
+func (c *wsTestClient) counters() map[string]any {
+	c.t.Helper()
+	op, payload := c.read()
+	require.Equal(c.t, byte(wsText), op)
+	var m map[string]any
+	require.NoError(c.t, json.Unmarshal(payload, &m))
+	delete(m, "at")
+	return m
+}
+
+func TestLiveCounters(t *testing.T) {
+	h, svc := newServer(t)
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+	ctx := context.Background()
+
+	c := dialWS(t, srv, "/ws")
+	assert.Equal(t, map[string]any{"total_books": 0.0, "books_added_today": 0.0, "active_imports": 0.0}, c.counters())
+
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("A")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"total_books": 1.0, "books_added_today": 1.0, "active_imports": 0.0}, c.counters())
+
+	// a burst of changes is coalesced into one push
+	done := svc.StartImport(context.Background())
+	for range 5 {
+		_, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("B")})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, map[string]any{"total_books": 6.0, "books_added_today": 6.0, "active_imports": 1.0}, c.counters())
+	done()
+	require.NoError(t, svc.DeleteBook(ctx, b.ID))
+	assert.Equal(t, map[string]any{"total_books": 5.0, "books_added_today": 5.0, "active_imports": 0.0}, c.counters())
+
+	c.send(wsPing, []byte("hi"))
+	op, payload := c.read()
+	assert.Equal(t, byte(wsPong), op)
+	assert.Equal(t, "hi", string(payload))
+
+	c.send(wsClose, []byte{0x03, 0xe8})
+	op, _ = c.read()
+	assert.Equal(t, byte(wsClose), op)
+}
+
+func TestLiveCounters_RejectsPlainRequests(t *testing.T) {
+	h, _ := newServer(t)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/ws", nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Upgrade: websocket")
+}
@@ -0,0 +1,249 @@
+// Package apispec reads the OpenAPI document of the API (api.Spec) for the
+// code that publishes or generates from it: the JSON Schemas served at
+// /api/v1/schemas and the client SDKs of internal/sdkgen. It models the
+// subset of OpenAPI 3.0 the document uses.
+package apispec
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is an OpenAPI document.
+type Document struct {
+	Info       Info                             `yaml:"info"`
+	Paths      map[string]map[string]*Operation `yaml:"paths"` // path -> method -> operation
+	Components Components                       `yaml:"components"`
+
+	raw map[string]any // schemas as decoded, for JSONSchema
+}
+
+type Info struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+type Components struct {
+	Schemas       map[string]*Schema      `yaml:"schemas"`
+	Parameters    map[string]*Parameter   `yaml:"parameters"`
+	Responses     map[string]*Response    `yaml:"responses"`
+	RequestBodies map[string]*RequestBody `yaml:"requestBodies"`
+}
+
+// Operation is one method of a path.
+type Operation struct {
+	OperationID string               `yaml:"operationId"`
+	Summary     string               `yaml:"summary"`
+	Description string               `yaml:"description"`
+	Parameters  []*Parameter         `yaml:"parameters"`
+	RequestBody *RequestBody         `yaml:"requestBody"`
+	Responses   map[string]*Response `yaml:"responses"`
+}
+
+type Parameter struct {
+	Ref         string  `yaml:"$ref"`
+	Name        string  `yaml:"name"`
+	In          string  `yaml:"in"` // path | query | header
+	Description string  `yaml:"description"`
+	Required    bool    `yaml:"required"`
+	Schema      *Schema `yaml:"schema"`
+}
+
+type RequestBody struct {
+	Ref      string               `yaml:"$ref"`
+	Required bool                 `yaml:"required"`
+	Content  map[string]MediaType `yaml:"content"`
+}
+
+type Response struct {
+	Ref         string               `yaml:"$ref"`
+	Description string               `yaml:"description"`
+	Content     map[string]MediaType `yaml:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `yaml:"schema"`
+}
+
+// Schema is a schema object. AdditionalProperties is nil when the
+// document leaves it out or sets it to true or false; see
+// NoAdditionalProperties.
+type Schema struct {
+	Ref                    string             `yaml:"$ref"`
+	Type                   string             `yaml:"type"`
+	Format                 string             `yaml:"format"`
+	Description            string             `yaml:"description"`
+	Nullable               bool               `yaml:"nullable"`
+	Enum                   []string           `yaml:"enum"`
+	Items                  *Schema            `yaml:"items"`
+	Properties             map[string]*Schema `yaml:"properties"`
+	Required               []string           `yaml:"required"`
+	AdditionalProperties   *Schema            `yaml:"-"`
+	NoAdditionalProperties bool               `yaml:"-"` // additionalProperties: false
+}
+
+func (s *Schema) UnmarshalYAML(n *yaml.Node) error {
+	type plain Schema
+	if err := n.Decode((*plain)(s)); err != nil {
+		return err
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value != "additionalProperties" {
+			continue
+		}
+		v := n.Content[i+1]
+		if v.Kind == yaml.ScalarNode {
+			var b bool
+			if err := v.Decode(&b); err != nil {
+				return err
+			}
+			s.NoAdditionalProperties = !b
+			continue
+		}
+		s.AdditionalProperties = &Schema{}
+		if err := v.Decode(s.AdditionalProperties); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RefName is the name of the component s refers to, or "".
+func (s *Schema) RefName() string {
+	if s == nil {
+		return ""
+	}
+	return refName(s.Ref)
+}
+
+// IsRequired reports whether the object s requires the property.
+func (s *Schema) IsRequired(property string) bool {
+	return slices.Contains(s.Required, property)
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndexByte(ref, '/')+1:]
+}
+
+// Parse reads an OpenAPI document and resolves the references to
+// parameters, request bodies and responses, so that only schemas are
+// left to refer to components.
+func Parse(data []byte) (*Document, error) {
+	var d Document
+	if err := yaml.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+	var raw struct {
+		Components struct {
+			Schemas map[string]any `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+	d.raw = raw.Components.Schemas
+	for path, methods := range d.Paths {
+		for method, op := range methods {
+			if err := d.resolve(op); err != nil {
+				return nil, fmt.Errorf("openapi: %s %s: %w", strings.ToUpper(method), path, err)
+			}
+		}
+	}
+	for name, s := range d.Components.Schemas {
+		if err := d.checkRefs(s); err != nil {
+			return nil, fmt.Errorf("openapi: schema %s: %w", name, err)
+		}
+	}
+	return &d, nil
+}
+
+func (d *Document) resolve(op *Operation) error {
+	for i, p := range op.Parameters {
+		if p.Ref == "" {
+			continue
+		}
+		if op.Parameters[i] = d.Components.Parameters[refName(p.Ref)]; op.Parameters[i] == nil {
+			return fmt.Errorf("unknown parameter %s", p.Ref)
+		}
+	}
+	if b := op.RequestBody; b != nil && b.Ref != "" {
+		if op.RequestBody = d.Components.RequestBodies[refName(b.Ref)]; op.RequestBody == nil {
+			return fmt.Errorf("unknown request body %s", b.Ref)
+		}
+	}
+	for code, r := range op.Responses {
+		if r.Ref == "" {
+			continue
+		}
+		if op.Responses[code] = d.Components.Responses[refName(r.Ref)]; op.Responses[code] == nil {
+			return fmt.Errorf("unknown response %s", r.Ref)
+		}
+	}
+	return nil
+}
+
+// checkRefs makes sure every schema s refers to exists.
+func (d *Document) checkRefs(s *Schema) error {
+	if s == nil {
+		return nil
+	}
+	if s.Ref != "" && d.Components.Schemas[s.RefName()] == nil {
+		return fmt.Errorf("unknown schema %s", s.Ref)
+	}
+	for _, p := range s.Properties {
+		if err := d.checkRefs(p); err != nil {
+			return err
+		}
+	}
+	if err := d.checkRefs(s.Items); err != nil {
+		return err
+	}
+	return d.checkRefs(s.AdditionalProperties)
+}
+
+// Decode reads a whole OpenAPI document as plain maps, slices and
+// scalars, ready to be written as JSON.
+func Decode(data []byte) (map[string]any, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+	return doc, nil
+}
+
+// SchemaNames are the names of the component schemas, sorted.
+func (d *Document) SchemaNames() []string {
+	names := make([]string, 0, len(d.Components.Schemas))
+	for name := range d.Components.Schemas {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Route is an operation with its method and path.
+type Route struct {
+	Method string // upper case
+	Path   string
+	*Operation
+}
+
+// Routes are the operations sorted by path, then method.
+func (d *Document) Routes() []Route {
+	var out []Route
+	for path, methods := range d.Paths {
+		for method, op := range methods {
+			out = append(out, Route{Method: strings.ToUpper(method), Path: path, Operation: op})
+		}
+	}
+	slices.SortFunc(out, func(a, b Route) int {
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Method, b.Method)
+	})
+	return out
+}
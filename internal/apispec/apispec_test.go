@@ -0,0 +1,98 @@
+//go:build unit
+
+package apispec
+
+import (
+	"book-manager/api"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDoc = `
+openapi: 3.0.3
+info: { title: Test, version: 1.0.0 }
+paths:
+  /things/{id}:
+    get:
+      operationId: getThing
+      parameters:
+        - $ref: '#/components/parameters/Id'
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/Thing' }
+        '404': { $ref: '#/components/responses/NotFound' }
+components:
+  parameters:
+    Id: { name: id, in: path, required: true, schema: { type: string } }
+  responses:
+    NotFound: { description: Not found }
+  schemas:
+    Kind: { type: string, enum: [a, b] }
+    Thing:
+      type: object
+      required: [id]
+      properties:
+        id: { type: string, example: t1 }
+        kind: { $ref: '#/components/schemas/Kind', nullable: true }
+        size: { type: string, enum: [s, m], nullable: true }
+        labels: { type: object, additionalProperties: { type: integer } }
+        closed: { type: object, additionalProperties: false }
+`
+
+func TestParse(t *testing.T) {
+	d, err := Parse([]byte(testDoc))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Kind", "Thing"}, d.SchemaNames())
+
+	routes := d.Routes()
+	require.Len(t, routes, 1)
+	assert.Equal(t, "GET", routes[0].Method)
+	require.Len(t, routes[0].Parameters, 1)
+	assert.Equal(t, "id", routes[0].Parameters[0].Name, "parameter references are resolved")
+	assert.Equal(t, "Not found", routes[0].Responses["404"].Description, "response references are resolved")
+
+	thing := d.Components.Schemas["Thing"]
+	assert.True(t, thing.IsRequired("id"))
+	assert.Equal(t, "Kind", thing.Properties["kind"].RefName())
+	assert.Equal(t, "integer", thing.Properties["labels"].AdditionalProperties.Type)
+	assert.True(t, thing.Properties["closed"].NoAdditionalProperties)
+
+	_, err = Parse([]byte(testDoc + "    Broken: { $ref: '#/components/schemas/Missing' }\n"))
+	assert.ErrorContains(t, err, "unknown schema")
+}
+
+func TestJSONSchema(t *testing.T) {
+	d, err := Parse([]byte(testDoc))
+	require.NoError(t, err)
+	s, err := d.JSONSchema("Thing", "https://example.org/schemas/")
+	require.NoError(t, err)
+	assert.Equal(t, JSONSchemaDialect, s["$schema"])
+	assert.Equal(t, "https://example.org/schemas/Thing", s["$id"])
+	assert.Equal(t, "Thing", s["title"])
+
+	props := s["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string", "examples": []any{"t1"}}, props["id"])
+	assert.Equal(t, map[string]any{"anyOf": []any{
+		map[string]any{"$ref": "https://example.org/schemas/Kind"},
+		map[string]any{"type": "null"},
+	}}, props["kind"])
+	assert.Equal(t, map[string]any{"type": []any{"string", "null"}, "enum": []any{"s", "m", nil}}, props["size"])
+
+	_, err = d.JSONSchema("Nope", "")
+	assert.Error(t, err)
+}
+
+// TestSpec keeps the document of the API within what the package reads.
+func TestSpec(t *testing.T) {
+	d, err := Parse(api.Spec)
+	require.NoError(t, err)
+	for _, name := range d.SchemaNames() {
+		_, err := d.JSONSchema(name, "/")
+		assert.NoError(t, err, name)
+	}
+}
@@ -0,0 +1,80 @@
+package apispec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JSONSchemaDialect is the JSON Schema version JSONSchema writes.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is the component schema name as a JSON Schema document of its
+// own, with $id base+name. References to other components become
+// references to their documents, base+name, so base is where the schemas
+// are published. The OpenAPI-only keywords are translated: nullable adds
+// null to the types, example becomes examples.
+func (d *Document) JSONSchema(name, base string) (map[string]any, error) {
+	raw, ok := d.raw[name].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unknown schema %q", name)
+	}
+	out, ok := toJSONSchema(raw, base).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("schema %q is not an object", name)
+	}
+	out["$schema"] = JSONSchemaDialect
+	out["$id"] = base + name
+	out["title"] = name
+	return out, nil
+}
+
+// toJSONSchema translates a decoded OpenAPI schema, or any value inside
+// one, copying it.
+func toJSONSchema(v any, base string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			switch k {
+			case "$ref":
+				if ref, ok := val.(string); ok && strings.HasPrefix(ref, "#/components/schemas/") {
+					val = base + refName(ref)
+				}
+				out[k] = val
+			case "nullable", "example":
+			case "properties":
+				// property names are not keywords
+				props, _ := val.(map[string]any)
+				m := make(map[string]any, len(props))
+				for p, s := range props {
+					m[p] = toJSONSchema(s, base)
+				}
+				out[k] = m
+			default:
+				out[k] = toJSONSchema(val, base)
+			}
+		}
+		if ex, ok := v["example"]; ok {
+			out["examples"] = []any{ex}
+		}
+		if nullable, _ := v["nullable"].(bool); nullable {
+			if t, ok := v["type"].(string); ok {
+				out["type"] = []any{t, "null"}
+				if enum, ok := out["enum"].([]any); ok {
+					out["enum"] = append(enum, nil)
+				}
+			} else {
+				out = map[string]any{"anyOf": []any{out, map[string]any{"type": "null"}}}
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = toJSONSchema(val, base)
+		}
+		return out
+	default:
+		return v
+	}
+}
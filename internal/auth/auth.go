@@ -0,0 +1,451 @@
+// Package auth authenticates API requests, by API key or by a JWT from an
+// OpenID Connect provider, and authorizes them by role.
+package auth
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Role grants access to a class of endpoints; each role includes the ones
+// below it.
+type Role int
+
+const (
+	RoleNone Role = iota
+	// RoleReader reads books and authors.
+	RoleReader
+	// RoleEditor also creates, changes and deletes them.
+	RoleEditor
+	// RoleAdmin also manages auto-tag rules and renames authors and tags
+	// catalog-wide.
+	RoleAdmin
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleReader:
+		return "reader"
+	case RoleEditor:
+		return "editor"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return "none"
+	}
+}
+
+func ParseRole(s string) (Role, error) {
+	switch s {
+	case "reader":
+		return RoleReader, nil
+	case "editor":
+		return RoleEditor, nil
+	case "admin":
+		return RoleAdmin, nil
+	}
+	return RoleNone, errors.New("role must be reader, editor or admin")
+}
+
+// ParseRoleMap reads comma-separated value=role pairs, e.g.
+// "book-admins=admin,staff=editor".
+func ParseRoleMap(s string) (map[string]Role, error) {
+	out := make(map[string]Role)
+	for _, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		value, name, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("role map %q: want value=role", pair)
+		}
+		r, err := ParseRole(strings.TrimSpace(name))
+		if err != nil {
+			return nil, fmt.Errorf("role map %q: %w", pair, err)
+		}
+		out[strings.TrimSpace(value)] = r
+	}
+	return out, nil
+}
+
+// Principal is the authenticated caller.
+type Principal struct {
+	ID     string // API key id or token subject
+	Method string // "api-key", "kiosk" or "jwt"
+	Role   Role   // highest role granted
+	// Branches limits the branches whose copies the caller may change;
+	// nil is every branch.
+	Branches []string
+	// Kiosk marks a kiosk key, which may use the kiosk endpoints and
+	// nothing else.
+	Kiosk bool
+	// Tenants limits the tenants the caller may make requests for; nil is
+	// every tenant. See model.WithTenant.
+	Tenants []string
+}
+
+type principalCtxKey struct{}
+
+// NewContext returns ctx carrying p as the caller.
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, p)
+}
+
+// FromContext returns the caller of an authenticated request.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalCtxKey{}).(Principal)
+	return p, ok
+}
+
+// kioskPrefix is the path of the kiosk endpoints, the only ones kiosk keys
+// may use.
+const kioskPrefix = "/api/v1/kiosk/"
+
+// RequiredRole is the role a request needs: admin for /api/v1/admin,
+// catalog-wide author and tag renames, creating and deleting branches and
+// reads that include the trash (include_deleted=true), editor for loans,
+// the loans calendar, holds, fees, the borrower directory and the kiosk
+// endpoints, which name their borrowers, and other requests that change
+// data, reader for the rest. Comparing and parsing books and printing
+// labels are posts that change nothing, and readers may clear their own
+// recently viewed books, keep their own shelves and reading progress and
+// review books.
+func RequiredRole(r *http.Request) Role {
+	p := r.URL.Path
+	switch {
+	case strings.HasPrefix(p, "/api/v1/admin/") || p == "/api/v1/authors/rename" ||
+		p == "/api/v1/tags/rename" || p == "/api/v1/tags/merge":
+		return RoleAdmin
+	case r.URL.Query().Has("include_deleted") && r.URL.Query().Get("include_deleted") != "false":
+		return RoleAdmin
+	case strings.HasPrefix(p, "/api/v1/branches") && r.Method != http.MethodGet && r.Method != http.MethodHead:
+		return RoleAdmin
+	case strings.HasPrefix(p, "/api/v1/loans") || strings.HasPrefix(p, "/api/v1/holds") || strings.HasSuffix(p, "/holds") ||
+		strings.HasPrefix(p, "/api/v1/borrowers") || strings.HasPrefix(p, kioskPrefix) || p == "/feeds/loans.ics":
+		return RoleEditor
+	case r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions:
+		return RoleReader
+	case p == "/api/v1/books/compare" || p == "/api/v1/books/parse" || p == "/api/v1/books/recent-views" || p == "/api/v1/labels" ||
+		strings.HasPrefix(p, "/api/v1/shelves") || strings.HasPrefix(p, "/api/v1/books/") && (strings.Contains(p, "/reviews") || strings.HasSuffix(p, "/progress")):
+		return RoleReader
+	default:
+		return RoleEditor
+	}
+}
+
+// AllowsBranch reports whether the caller of ctx may change the copies a
+// branch holds: admins and callers without a branch limit may change any,
+// and so may every request when authentication is off.
+func AllowsBranch(ctx context.Context, branch string) bool {
+	p, ok := FromContext(ctx)
+	return !ok || p.Role >= RoleAdmin || p.Branches == nil || slices.Contains(p.Branches, branch)
+}
+
+// AllowsAnyReview reports whether the caller of ctx may remove reviews
+// others wrote: editors and admins may, and so may every request when
+// authentication is off.
+func AllowsAnyReview(ctx context.Context) bool {
+	p, ok := FromContext(ctx)
+	return !ok || p.Role >= RoleEditor
+}
+
+// serverPaths serve the whole server rather than a tenant: its metrics,
+// circuit breakers, dead letters, auto-tag rules, shelf syncs and cover
+// prefetch. Callers limited to tenants may not use them.
+var serverPaths = []string{
+	"/metrics",
+	"/api/v1/admin/autotag-rules",
+	"/api/v1/admin/circuit-breakers",
+	"/api/v1/admin/covers/prefetch",
+	"/api/v1/admin/deadletters",
+	"/api/v1/admin/shelf-syncs",
+}
+
+func serverPath(p string) bool {
+	return slices.ContainsFunc(serverPaths, func(prefix string) bool {
+		return p == prefix || strings.HasPrefix(p, prefix+"/")
+	})
+}
+
+// AllowsTenant reports whether p may make a request for tenant, "" for
+// requests outside a tenant: callers limited to tenants must name one of
+// theirs.
+func (p Principal) AllowsTenant(tenant string) bool {
+	return p.Tenants == nil || tenant != "" && slices.Contains(p.Tenants, tenant)
+}
+
+// publicPaths need no credentials: /healthz is polled by load balancers
+// and registries, which have none, and browsers open the API's document
+// and the pages showing it before a key can be entered; requests tried
+// from the pages carry the key as usual.
+var publicPaths = map[string]bool{
+	"/healthz":             true,
+	"/api/v1/openapi.json": true,
+	"/docs":                true,
+	"/swagger":             true,
+}
+
+// Authenticator checks the credentials of every request and the role they
+// grant. API keys, sent as X-API-Key or as a bearer token, grant every
+// role; kiosk keys, sent the same way, grant editor on the kiosk
+// endpoints only. With a Verifier, bearer tokens that are JWTs are
+// verified instead and their roles read from RoleClaim. Requests without credentials may
+// read when PublicReads is on. With neither keys nor a verifier, every
+// request passes. Keys can be replaced at runtime, e.g. on config reload.
+type Authenticator struct {
+	Verifier *Verifier // nil: no JWTs
+	// RoleClaim is the claim holding the caller's roles, a string or an
+	// array; dots descend into objects, e.g. "realm_access.roles".
+	RoleClaim string
+	// RoleMap maps claim values to roles; values that are not in it are
+	// taken as role names.
+	RoleMap map[string]Role
+	// BranchClaim, when set, is the claim listing the branches a caller
+	// may change copies at, like RoleClaim. Tokens without it are not
+	// limited to branches.
+	BranchClaim string
+	// TenantClaim, when set, is the claim listing the tenants a caller may
+	// make requests for, like BranchClaim. Tokens without it are not
+	// limited to tenants.
+	TenantClaim string
+	// WriteError answers a rejected request; nil writes a plain JSON error.
+	WriteError func(w http.ResponseWriter, r *http.Request, status int, code, msg string)
+
+	mu           sync.RWMutex
+	keys         map[string]string // id -> key
+	kioskKeys    map[string]string // id -> key
+	keyTenants   map[string][]string
+	kioskTenants map[string][]string
+	publicReads  bool
+	log          *slog.Logger
+}
+
+func NewAuthenticator(logger *slog.Logger) *Authenticator {
+	return &Authenticator{RoleClaim: "roles", publicReads: true, log: logger}
+}
+
+// SetKeys replaces the API keys, given by id, and whether reads are public.
+func (a *Authenticator) SetKeys(keys map[string]string, publicReads bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.keys = keys
+	a.publicReads = publicReads
+}
+
+// SetKioskKeys replaces the kiosk keys, given by id. They only take effect
+// while authentication is on, with API keys or a verifier.
+func (a *Authenticator) SetKioskKeys(keys map[string]string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.kioskKeys = keys
+}
+
+// SetKeyTenants limits API keys and kiosk keys, by id, to the tenants
+// listed; keys without an entry may make requests for every tenant.
+func (a *Authenticator) SetKeyTenants(keys, kioskKeys map[string][]string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.keyTenants = keys
+	a.kioskTenants = kioskKeys
+}
+
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.mu.RLock()
+		keys, kioskKeys, publicReads := a.keys, a.kioskKeys, a.publicReads
+		a.mu.RUnlock()
+		if len(keys) == 0 && a.Verifier == nil || publicPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		need := RequiredRole(r)
+		p, reason, err := a.authenticate(r, keys, kioskKeys)
+		switch {
+		case reason == "missing credentials" && publicReads && need == RoleReader:
+			next.ServeHTTP(w, r)
+			return
+		case reason != "":
+			a.reject(w, r, http.StatusUnauthorized, reason, err)
+			return
+		}
+		if p.Kiosk && !strings.HasPrefix(r.URL.Path, kioskPrefix) {
+			a.log.Info("request forbidden", "principal", p.ID, "kiosk", true, "method", r.Method, "path", r.URL.Path)
+			a.writeError(w, r, http.StatusForbidden, "FORBIDDEN", "kiosk keys may only use "+kioskPrefix)
+			return
+		}
+		if p.Role < need {
+			a.log.Info("request forbidden", "principal", p.ID, "role", p.Role.String(), "required", need.String(), "method", r.Method, "path", r.URL.Path)
+			a.writeError(w, r, http.StatusForbidden, "FORBIDDEN", "requires role "+need.String())
+			return
+		}
+		if tenant := model.TenantFromContext(r.Context()); !p.AllowsTenant(tenant) || p.Tenants != nil && serverPath(r.URL.Path) {
+			msg := fmt.Sprintf("credentials are not valid for tenant %q", tenant)
+			switch {
+			case serverPath(r.URL.Path):
+				msg = "credentials limited to tenants may not use " + r.URL.Path
+			case tenant == "":
+				msg = "credentials limited to tenants must name one"
+			}
+			a.log.Info("request forbidden", "principal", p.ID, "tenant", tenant, "tenants", p.Tenants, "method", r.Method, "path", r.URL.Path)
+			a.writeError(w, r, http.StatusForbidden, "FORBIDDEN", msg)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), p)))
+	})
+}
+
+// Identify returns the caller whose credentials r carries, without
+// answering the request, so that a middleware running before Middleware,
+// such as a rate limiter, can tell callers apart. It reports false for
+// requests without valid credentials.
+func (a *Authenticator) Identify(r *http.Request) (Principal, bool) {
+	a.mu.RLock()
+	keys, kioskKeys := a.keys, a.kioskKeys
+	a.mu.RUnlock()
+	if len(keys) == 0 && a.Verifier == nil {
+		return Principal{}, false
+	}
+	p, reason, _ := a.authenticate(r, keys, kioskKeys)
+	return p, reason == ""
+}
+
+// authenticate checks the credentials of r. When they are missing or
+// invalid it returns the reason, and the verification error if any.
+func (a *Authenticator) authenticate(r *http.Request, keys, kioskKeys map[string]string) (Principal, string, error) {
+	key, token := credentials(r)
+	switch {
+	case key == "" && token == "":
+		return Principal{}, "missing credentials", nil
+	case token != "" && a.Verifier != nil && isJWT(token):
+		claims, err := a.Verifier.Verify(r.Context(), token)
+		if err != nil {
+			return Principal{}, "invalid token", err
+		}
+		sub, _ := claims["sub"].(string)
+		return Principal{ID: sub, Method: "jwt", Role: a.role(claims), Branches: a.limits(claims, a.BranchClaim),
+			Tenants: a.limits(claims, a.TenantClaim)}, "", nil
+	}
+	if key == "" {
+		key = token
+	}
+	a.mu.RLock()
+	keyTenants, kioskTenants := a.keyTenants, a.kioskTenants
+	a.mu.RUnlock()
+	if id, ok := matchKey(keys, key); ok {
+		return Principal{ID: id, Method: "api-key", Role: RoleAdmin, Tenants: keyTenants[id]}, "", nil
+	}
+	if id, ok := matchKey(kioskKeys, key); ok {
+		return Principal{ID: id, Method: "kiosk", Role: RoleEditor, Kiosk: true, Tenants: kioskTenants[id]}, "", nil
+	}
+	return Principal{}, "invalid API key", nil
+}
+
+func (a *Authenticator) reject(w http.ResponseWriter, r *http.Request, status int, msg string, err error) {
+	log := a.log
+	if err != nil {
+		log = log.With("error", err)
+	}
+	log.Info("request rejected", "reason", msg, "method", r.Method, "path", r.URL.Path)
+	w.Header().Set("WWW-Authenticate", `Bearer realm="book-manager"`)
+	a.writeError(w, r, status, "UNAUTHORIZED", msg)
+}
+
+func (a *Authenticator) writeError(w http.ResponseWriter, r *http.Request, status int, code, msg string) {
+	if a.WriteError != nil {
+		a.WriteError(w, r, status, code, msg)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]string{"code": code, "message": msg}})
+}
+
+// role is the highest role the claims grant.
+func (a *Authenticator) role(c Claims) Role {
+	values, _ := claimValues(c, a.RoleClaim)
+	best := RoleNone
+	for _, s := range values {
+		r, ok := a.RoleMap[s]
+		if !ok {
+			r, _ = ParseRole(s)
+		}
+		best = max(best, r)
+	}
+	return best
+}
+
+// limits are the branches or tenants the claim at path limits the caller
+// to, nil when it does not. A claim naming none leaves none.
+func (a *Authenticator) limits(c Claims, path string) []string {
+	if path == "" {
+		return nil
+	}
+	values, ok := claimValues(c, path)
+	if !ok {
+		return nil
+	}
+	if values == nil {
+		values = []string{}
+	}
+	return values
+}
+
+// claimValues reads the strings of the claim at path, where dots descend
+// into objects; a string holds space-separated values, OAuth scope style.
+// ok is false when the claim is absent.
+func claimValues(c Claims, path string) (values []string, ok bool) {
+	var v any = map[string]any(c)
+	for _, name := range strings.Split(path, ".") {
+		m, isMap := v.(map[string]any)
+		if !isMap {
+			return nil, false
+		}
+		if v, ok = m[name]; !ok {
+			return nil, false
+		}
+	}
+	switch v := v.(type) {
+	case string:
+		values = strings.Fields(v)
+	case []any:
+		for _, x := range v {
+			if s, isStr := x.(string); isStr {
+				values = append(values, s)
+			}
+		}
+	}
+	return values, true
+}
+
+func credentials(r *http.Request) (key, token string) {
+	key = r.Header.Get("X-API-Key")
+	if scheme, t, ok := strings.Cut(r.Header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		token = strings.TrimSpace(t)
+	}
+	return key, token
+}
+
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// matchKey compares sent with every key in constant time, so timing tells
+// nothing about which key or how much of it matched.
+func matchKey(keys map[string]string, sent string) (string, bool) {
+	var found string
+	for id, k := range keys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(sent)) == 1 {
+			found = id
+		}
+	}
+	return found, found != "" && sent != ""
+}
@@ -0,0 +1,222 @@
+//go:build unit
+
+package auth
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticator(t *testing.T) {
+	ti := newTestIssuer(t)
+	a := NewAuthenticator(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	a.Verifier = NewVerifier(NewJWKS(ti.srv.URL, ti.srv.Client()), "", "")
+	a.RoleClaim = "realm_access.roles"
+	a.RoleMap = map[string]Role{"book-admins": RoleAdmin}
+	a.SetKeys(map[string]string{"ci": "s3cret"}, true)
+	a.SetKioskKeys(map[string]string{"lobby": "k1osk"})
+
+	var got Principal
+	h := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = FromContext(r.Context())
+	}))
+	token := func(roles ...string) string {
+		c := validClaims()
+		delete(c, "roles")
+		c["realm_access"] = map[string]any{"roles": roles}
+		return "Bearer " + ti.sign(t, "RS256", "rsa-1", c)
+	}
+	do := func(method, path, header, value string) int {
+		got = Principal{}
+		r := httptest.NewRequest(method, path, nil)
+		if header != "" {
+			r.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	tests := []struct {
+		name, method, path, header, value string
+		want                              int
+	}{
+		{"public read", http.MethodGet, "/api/v1/books", "", "", http.StatusOK},
+		{"anonymous write", http.MethodPost, "/api/v1/books", "", "", http.StatusUnauthorized},
+		{"anonymous compare", http.MethodPost, "/api/v1/books/compare", "", "", http.StatusOK},
+		{"anonymous labels", http.MethodPost, "/api/v1/labels", "", "", http.StatusOK},
+		{"health", http.MethodGet, "/healthz", "", "", http.StatusOK},
+		{"api key write", http.MethodPost, "/api/v1/books", "X-API-Key", "s3cret", http.StatusOK},
+		{"api key admin", http.MethodPost, "/api/v1/admin/autotag-rules", "Authorization", "Bearer s3cret", http.StatusOK},
+		{"wrong api key", http.MethodGet, "/api/v1/books", "X-API-Key", "nope", http.StatusUnauthorized},
+		{"reader write", http.MethodPut, "/api/v1/books/1", "Authorization", token("reader"), http.StatusForbidden},
+		{"editor write", http.MethodPut, "/api/v1/books/1", "Authorization", token("reader", "editor"), http.StatusOK},
+		{"editor admin", http.MethodPost, "/api/v1/authors/rename", "Authorization", token("editor"), http.StatusForbidden},
+		{"editor tag merge", http.MethodPost, "/api/v1/tags/merge", "Authorization", token("editor"), http.StatusForbidden},
+		{"reader trash", http.MethodGet, "/api/v1/books/export?include_deleted=true", "Authorization", token("editor"), http.StatusForbidden},
+		{"anonymous trash", http.MethodGet, "/api/v1/books?include_deleted=1", "", "", http.StatusUnauthorized},
+		{"reader no trash", http.MethodGet, "/api/v1/books?include_deleted=false", "Authorization", token("reader"), http.StatusOK},
+		{"admin trash", http.MethodGet, "/api/v1/books?include_deleted=true", "Authorization", token("book-admins"), http.StatusOK},
+		{"reader clears views", http.MethodDelete, "/api/v1/books/recent-views", "Authorization", token("reader"), http.StatusOK},
+		{"reader shelves a book", http.MethodPut, "/api/v1/shelves/read/books/1", "Authorization", token("reader"), http.StatusOK},
+		{"reader reviews a book", http.MethodPost, "/api/v1/books/1/reviews", "Authorization", token("reader"), http.StatusOK},
+		{"reader deletes a review", http.MethodDelete, "/api/v1/books/1/reviews/2", "Authorization", token("reader"), http.StatusOK},
+		{"reader records progress", http.MethodPut, "/api/v1/books/1/progress", "Authorization", token("reader"), http.StatusOK},
+		{"reader enrichment preview", http.MethodGet, "/api/v1/enrichment/isbn/9780441013593", "Authorization", token("reader"), http.StatusOK},
+		{"reader external search", http.MethodGet, "/api/v1/search/external?q=dune", "Authorization", token("reader"), http.StatusOK},
+		{"reader external import", http.MethodPost, "/api/v1/books/import-external", "Authorization", token("reader"), http.StatusForbidden},
+		{"editor branch create", http.MethodPost, "/api/v1/branches", "Authorization", token("editor"), http.StatusForbidden},
+		{"reader branches", http.MethodGet, "/api/v1/branches/east", "Authorization", token("reader"), http.StatusOK},
+		{"editor branch update", http.MethodPatch, "/api/v1/branches/east", "Authorization", token("editor"), http.StatusForbidden},
+		{"editor item update", http.MethodPatch, "/api/v1/books/1/items/31234000001", "Authorization", token("editor"), http.StatusOK},
+		{"editor copies", http.MethodPut, "/api/v1/books/1/copies/east", "Authorization", token("editor"), http.StatusOK},
+		{"reader loans", http.MethodGet, "/api/v1/loans", "Authorization", token("reader"), http.StatusForbidden},
+		{"editor loans", http.MethodGet, "/api/v1/loans?status=overdue", "Authorization", token("editor"), http.StatusOK},
+		{"reader loans feed", http.MethodGet, "/feeds/loans.ics", "Authorization", token("reader"), http.StatusForbidden},
+		{"editor loans feed", http.MethodGet, "/feeds/loans.ics", "Authorization", token("editor"), http.StatusOK},
+		{"reader holds", http.MethodGet, "/api/v1/books/1/holds", "Authorization", token("reader"), http.StatusForbidden},
+		{"reader fees", http.MethodGet, "/api/v1/borrowers/card-1/fees", "Authorization", token("reader"), http.StatusForbidden},
+		{"reader borrowers", http.MethodGet, "/api/v1/borrowers?q=ada", "Authorization", token("reader"), http.StatusForbidden},
+		{"reader kiosk", http.MethodPost, "/api/v1/kiosk/scan", "Authorization", token("reader"), http.StatusForbidden},
+		{"kiosk key scan", http.MethodPost, "/api/v1/kiosk/scan", "X-API-Key", "k1osk", http.StatusOK},
+		{"kiosk key checkout", http.MethodPost, "/api/v1/kiosk/checkout", "Authorization", "Bearer k1osk", http.StatusOK},
+		{"kiosk key books", http.MethodGet, "/api/v1/books", "X-API-Key", "k1osk", http.StatusForbidden},
+		{"kiosk key loans", http.MethodGet, "/api/v1/loans", "X-API-Key", "k1osk", http.StatusForbidden},
+		{"mapped admin", http.MethodDelete, "/api/v1/admin/autotag-rules/1", "Authorization", token("book-admins"), http.StatusOK},
+		{"no roles", http.MethodGet, "/api/v1/books", "Authorization", token(), http.StatusForbidden},
+		{"bad token", http.MethodGet, "/api/v1/books", "Authorization", "Bearer a.b.c", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, do(tt.method, tt.path, tt.header, tt.value), tt.name)
+	}
+
+	require.Equal(t, http.StatusOK, do(http.MethodPost, "/api/v1/books", "Authorization", token("editor")))
+	assert.Equal(t, Principal{ID: "user-1", Method: "jwt", Role: RoleEditor}, got)
+	require.Equal(t, http.StatusOK, do(http.MethodPost, "/api/v1/kiosk/card", "X-API-Key", "k1osk"))
+	assert.Equal(t, Principal{ID: "lobby", Method: "kiosk", Role: RoleEditor, Kiosk: true}, got)
+
+	a.SetKeys(map[string]string{"ci": "s3cret"}, false)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/v1/books", "", ""))
+	for _, path := range []string{"/healthz", "/docs", "/swagger", "/api/v1/openapi.json"} {
+		assert.Equal(t, http.StatusOK, do(http.MethodGet, path, "", ""), path)
+	}
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/v1/schemas", "", ""))
+}
+
+func TestAuthenticator_BranchClaim(t *testing.T) {
+	ti := newTestIssuer(t)
+	a := NewAuthenticator(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	a.Verifier = NewVerifier(NewJWKS(ti.srv.URL, ti.srv.Client()), "", "")
+	a.RoleClaim = "roles"
+	a.BranchClaim = "branches"
+
+	var got Principal
+	h := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = FromContext(r.Context())
+	}))
+	do := func(branches any) Principal {
+		got = Principal{}
+		c := validClaims()
+		c["roles"] = []any{"editor"}
+		if branches != nil {
+			c["branches"] = branches
+		}
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/books", nil)
+		r.Header.Set("Authorization", "Bearer "+ti.sign(t, "RS256", "rsa-1", c))
+		h.ServeHTTP(httptest.NewRecorder(), r)
+		return got
+	}
+
+	assert.Equal(t, []string{"east", "west"}, do([]any{"east", "west"}).Branches)
+	assert.Equal(t, []string{"east"}, do("east").Branches)
+	assert.Equal(t, []string{}, do([]any{}).Branches, "an empty claim allows no branch")
+	assert.Nil(t, do(nil).Branches, "no claim, no limit")
+}
+
+func TestAuthenticator_Tenants(t *testing.T) {
+	ti := newTestIssuer(t)
+	a := NewAuthenticator(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	a.Verifier = NewVerifier(NewJWKS(ti.srv.URL, ti.srv.Client()), "", "")
+	a.RoleClaim = "roles"
+	a.TenantClaim = "tenants"
+	a.SetKeys(map[string]string{"east-ci": "e4st", "ops": "0ps"}, false)
+	a.SetKioskKeys(map[string]string{"lobby": "k1osk"})
+	a.SetKeyTenants(map[string][]string{"east-ci": {"east"}}, map[string][]string{"lobby": {"west"}})
+
+	h := a.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	token := func(tenants any) string {
+		c := validClaims()
+		c["roles"] = []any{"admin"}
+		if tenants != nil {
+			c["tenants"] = tenants
+		}
+		return "Bearer " + ti.sign(t, "RS256", "rsa-1", c)
+	}
+	do := func(tenant, path, credentials string) int {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if tenant != "" {
+			r = r.WithContext(model.WithTenant(r.Context(), tenant))
+		}
+		r.Header.Set("Authorization", credentials)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	tests := []struct {
+		name, tenant, path, credentials string
+		want                            int
+	}{
+		{"key of the tenant", "east", "/api/v1/books", "Bearer e4st", http.StatusOK},
+		{"key of another tenant", "west", "/api/v1/books", "Bearer e4st", http.StatusForbidden},
+		{"key outside a tenant", "", "/api/v1/books", "Bearer e4st", http.StatusForbidden},
+		{"key on a server endpoint", "east", "/api/v1/admin/deadletters", "Bearer e4st", http.StatusForbidden},
+		{"key on metrics", "east", "/metrics", "Bearer e4st", http.StatusForbidden},
+		{"key of every tenant", "west", "/api/v1/books", "Bearer 0ps", http.StatusOK},
+		{"unlimited key on a server endpoint", "", "/metrics", "Bearer 0ps", http.StatusOK},
+		{"kiosk key of the tenant", "west", "/api/v1/kiosk/card", "Bearer k1osk", http.StatusOK},
+		{"kiosk key of another tenant", "east", "/api/v1/kiosk/card", "Bearer k1osk", http.StatusForbidden},
+		{"token of the tenant", "east", "/api/v1/books", token([]any{"east", "north"}), http.StatusOK},
+		{"token of another tenant", "west", "/api/v1/books", token("east"), http.StatusForbidden},
+		{"token with no tenants", "east", "/api/v1/books", token([]any{}), http.StatusForbidden},
+		{"token without the claim", "west", "/api/v1/books", token(nil), http.StatusOK},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, do(tt.tenant, tt.path, tt.credentials), tt.name)
+	}
+}
+
+func TestAllowsBranch(t *testing.T) {
+	ctx := context.Background()
+	assert.True(t, AllowsBranch(ctx, "east"), "authentication off")
+	assert.True(t, AllowsBranch(NewContext(ctx, Principal{Role: RoleEditor}), "east"))
+	limited := NewContext(ctx, Principal{Role: RoleEditor, Branches: []string{"east"}})
+	assert.True(t, AllowsBranch(limited, "east"))
+	assert.False(t, AllowsBranch(limited, "west"))
+	assert.False(t, AllowsBranch(NewContext(ctx, Principal{Role: RoleEditor, Branches: []string{}}), "east"))
+	assert.True(t, AllowsBranch(NewContext(ctx, Principal{Role: RoleAdmin, Branches: []string{}}), "west"))
+}
+
+func TestAuthenticator_OffWithoutKeysOrVerifier(t *testing.T) {
+	a := NewAuthenticator(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	h := a.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/books/1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestParseRoleMap(t *testing.T) {
+	m, err := ParseRoleMap("book-admins=admin, staff=editor")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Role{"book-admins": RoleAdmin, "staff": RoleEditor}, m)
+	_, err = ParseRoleMap("staff=owner")
+	assert.Error(t, err)
+}
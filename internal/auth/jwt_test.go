@@ -0,0 +1,144 @@
+//go:build unit
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIssuer signs tokens and serves its keys as a JWKS.
+type testIssuer struct {
+	rsa     *rsa.PrivateKey
+	ec      *ecdsa.PrivateKey
+	srv     *httptest.Server
+	fetches atomic.Int32
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	ti := &testIssuer{}
+	var err error
+	ti.rsa, err = rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ti.ec, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	b64 := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	ti.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ti.fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": b64(ti.rsa.N.Bytes()), "e": b64(big.NewInt(int64(ti.rsa.E)).Bytes())},
+			{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64(ti.ec.X.FillBytes(make([]byte, 32))), "y": b64(ti.ec.Y.FillBytes(make([]byte, 32)))},
+			{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"},
+		}})
+	}))
+	t.Cleanup(ti.srv.Close)
+	return ti
+}
+
+func (ti *testIssuer) sign(t *testing.T, alg, kid string, claims map[string]any) string {
+	t.Helper()
+	enc := func(v any) string {
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	signed := enc(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"}) + "." + enc(claims)
+	digest := sha256.Sum256([]byte(signed))
+	var sig []byte
+	switch alg {
+	case "RS256":
+		var err error
+		sig, err = rsa.SignPKCS1v15(rand.Reader, ti.rsa, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, ti.ec, digest[:])
+		require.NoError(t, err)
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub": "user-1", "iss": "https://idp.example", "aud": []string{"books", "other"},
+		"exp": time.Now().Add(time.Hour).Unix(), "roles": []string{"editor"},
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	ti := newTestIssuer(t)
+	v := NewVerifier(NewJWKS(ti.srv.URL, ti.srv.Client()), "https://idp.example", "books")
+	ctx := context.Background()
+
+	for _, alg := range []struct{ alg, kid string }{{"RS256", "rsa-1"}, {"ES256", "ec-1"}} {
+		claims, err := v.Verify(ctx, ti.sign(t, alg.alg, alg.kid, validClaims()))
+		require.NoError(t, err, alg.alg)
+		assert.Equal(t, "user-1", claims["sub"])
+	}
+	assert.EqualValues(t, 1, ti.fetches.Load(), "keys are cached")
+
+	expired := validClaims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	otherAud := validClaims()
+	otherAud["aud"] = "elsewhere"
+	otherIss := validClaims()
+	otherIss["iss"] = "https://evil.example"
+	noExp := validClaims()
+	delete(noExp, "exp")
+	tampered := ti.sign(t, "RS256", "rsa-1", validClaims())
+	parts := strings.Split(tampered, ".")
+	admin := validClaims()
+	admin["roles"] = []string{"admin"}
+	tampered = parts[0] + "." + strings.Split(ti.sign(t, "RS256", "rsa-1", admin), ".")[1] + "." + parts[2]
+
+	tests := map[string]string{
+		"expired":          ti.sign(t, "RS256", "rsa-1", expired),
+		"wrong audience":   ti.sign(t, "RS256", "rsa-1", otherAud),
+		"wrong issuer":     ti.sign(t, "RS256", "rsa-1", otherIss),
+		"no exp":           ti.sign(t, "RS256", "rsa-1", noExp),
+		"tampered payload": tampered,
+		"alg none":         ti.sign(t, "none", "rsa-1", validClaims()),
+		"key of other alg": ti.sign(t, "ES256", "rsa-1", validClaims()),
+		"hmac key":         ti.sign(t, "HS256", "hmac", validClaims()),
+		"not a jwt":        "abc",
+	}
+	for name, token := range tests {
+		_, err := v.Verify(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidToken, name)
+	}
+}
+
+func TestJWKS_RefetchesForUnknownKeyOnlyAfterMinRefresh(t *testing.T) {
+	ti := newTestIssuer(t)
+	j := NewJWKS(ti.srv.URL, ti.srv.Client())
+	ctx := context.Background()
+
+	_, err := j.Key(ctx, "rsa-1")
+	require.NoError(t, err)
+	_, err = j.Key(ctx, "rotated")
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, ti.fetches.Load())
+
+	j.MinRefresh = 0
+	_, err = j.Key(ctx, "rotated")
+	assert.Error(t, err)
+	assert.EqualValues(t, 2, ti.fetches.Load())
+}
@@ -0,0 +1,201 @@
+// Package chaos injects latency and failures into the request path so that
+// retries, timeouts and circuit breakers can be exercised end to end. It is
+// meant for resilience testing only and is disabled unless configured.
+package chaos
+
+import (
+	"book-manager/internal/core"
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInjected = errors.New("chaos: injected failure")
+
+// Targets that faults can be scoped to.
+const (
+	TargetHTTP   = "http"
+	TargetRepo   = "repo"
+	TargetEnrich = "enrich"
+)
+
+// Config describes the faults to inject. Zero values inject nothing.
+type Config struct {
+	Latency   time.Duration
+	ErrorRate float64  // probability in [0,1]
+	Targets   []string // empty means all targets
+}
+
+func (c Config) Enabled() bool {
+	return c.Latency > 0 || c.ErrorRate > 0
+}
+
+func (c Config) applies(target string) bool {
+	if len(c.Targets) == 0 {
+		return true
+	}
+	for _, t := range c.Targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+type ctxKey struct{}
+
+func withConfig(ctx context.Context, c Config) context.Context {
+	return context.WithValue(ctx, ctxKey{}, c)
+}
+
+func configFrom(ctx context.Context, def Config) Config {
+	if c, ok := ctx.Value(ctxKey{}).(Config); ok {
+		return c
+	}
+	return def
+}
+
+// inject sleeps for the configured latency and then fails with the configured
+// probability. It returns early with the context error if ctx is done.
+func inject(ctx context.Context, c Config, target string) error {
+	if !c.applies(target) {
+		return nil
+	}
+	if c.Latency > 0 {
+		select {
+		case <-time.After(c.Latency):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if c.ErrorRate > 0 && rand.Float64() < c.ErrorRate {
+		return ErrInjected
+	}
+	return nil
+}
+
+// Middleware injects faults into HTTP requests and hands the effective config
+// to the decorators further down via the request context. With allowHeaders,
+// X-Chaos-Latency (Go duration), X-Chaos-Error-Rate (0..1) and X-Chaos-Targets
+// (comma-separated http,repo,enrich) override def per request.
+func Middleware(def Config, allowHeaders bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := def
+			if allowHeaders {
+				var err error
+				if c, err = fromHeaders(r.Header, def); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+			ctx := withConfig(r.Context(), c)
+			if err := inject(ctx, c, TargetHTTP); err != nil {
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func fromHeaders(h http.Header, def Config) (Config, error) {
+	c := def
+	if v := h.Get("X-Chaos-Latency"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return c, errors.New("chaos: invalid X-Chaos-Latency")
+		}
+		c.Latency = d
+	}
+	if v := h.Get("X-Chaos-Error-Rate"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 || f > 1 {
+			return c, errors.New("chaos: invalid X-Chaos-Error-Rate")
+		}
+		c.ErrorRate = f
+	}
+	if v := h.Get("X-Chaos-Targets"); v != "" {
+		c.Targets = nil
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				c.Targets = append(c.Targets, t)
+			}
+		}
+	}
+	return c, nil
+}
+
+// Repo decorates a BookRepository with fault injection on its main
+// operations; all other methods pass straight through.
+type Repo struct {
+	core.BookRepository
+	Config Config
+}
+
+func (r Repo) Create(ctx context.Context, b model.Book) (model.Book, error) {
+	if err := inject(ctx, configFrom(ctx, r.Config), TargetRepo); err != nil {
+		return model.Book{}, err
+	}
+	return r.BookRepository.Create(ctx, b)
+}
+
+func (r Repo) CreateCapped(ctx context.Context, b model.Book, limit int) (model.Book, error) {
+	if err := inject(ctx, configFrom(ctx, r.Config), TargetRepo); err != nil {
+		return model.Book{}, err
+	}
+	return r.BookRepository.CreateCapped(ctx, b, limit)
+}
+
+func (r Repo) Update(ctx context.Context, b model.Book) (model.Book, error) {
+	if err := inject(ctx, configFrom(ctx, r.Config), TargetRepo); err != nil {
+		return model.Book{}, err
+	}
+	return r.BookRepository.Update(ctx, b)
+}
+
+func (r Repo) GetByID(ctx context.Context, id string) (model.Book, error) {
+	if err := inject(ctx, configFrom(ctx, r.Config), TargetRepo); err != nil {
+		return model.Book{}, err
+	}
+	return r.BookRepository.GetByID(ctx, id)
+}
+
+func (r Repo) List(ctx context.Context, q model.ListQuery) (model.Page[model.Book], error) {
+	if err := inject(ctx, configFrom(ctx, r.Config), TargetRepo); err != nil {
+		return model.Page[model.Book]{}, err
+	}
+	return r.BookRepository.List(ctx, q)
+}
+
+func (r Repo) Count(ctx context.Context, q model.ListQuery) (int, error) {
+	if err := inject(ctx, configFrom(ctx, r.Config), TargetRepo); err != nil {
+		return 0, err
+	}
+	return r.BookRepository.Count(ctx, q)
+}
+
+func (r Repo) Delete(ctx context.Context, id string) error {
+	if err := inject(ctx, configFrom(ctx, r.Config), TargetRepo); err != nil {
+		return err
+	}
+	return r.BookRepository.Delete(ctx, id)
+}
+
+// Enrichment decorates an EnrichmentClient with fault injection.
+type Enrichment struct {
+	core.EnrichmentClient
+	Config Config
+}
+
+func (e Enrichment) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	if err := inject(ctx, configFrom(ctx, e.Config), TargetEnrich); err != nil {
+		return model.EnrichedBook{}, err
+	}
+	return e.EnrichmentClient.FetchByISBN(ctx, isbn)
+}
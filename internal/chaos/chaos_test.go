@@ -0,0 +1,71 @@
+//go:build unit
+
+package chaos
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware_InjectsFailure(t *testing.T) {
+	h := Middleware(Config{ErrorRate: 1}, false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler must not be reached")
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestMiddleware_HeadersScopeFaultsToRepo(t *testing.T) {
+	repo := Repo{BookRepository: adapter.NewBookRepo()}
+	var repoErr error
+	h := Middleware(Config{}, true)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, repoErr = repo.GetByID(r.Context(), "missing")
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Chaos-Error-Rate", "1")
+	req.Header.Set("X-Chaos-Targets", "repo")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.ErrorIs(t, repoErr, ErrInjected)
+}
+
+func TestMiddleware_RejectsInvalidHeaders(t *testing.T) {
+	h := Middleware(Config{}, true)(http.NotFoundHandler())
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Chaos-Error-Rate", "2")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRepo_LatencyHonoursContext(t *testing.T) {
+	repo := Repo{BookRepository: adapter.NewBookRepo(), Config: Config{Latency: time.Minute}}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := repo.List(ctx, model.ListQuery{Page: 1, PageSize: 10})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestRepo_PassesThroughWhenDisabled(t *testing.T) {
+	repo := Repo{BookRepository: adapter.NewBookRepo()}
+	ctx := context.Background()
+	_, err := repo.Create(ctx, model.Book{ID: "b1", Title: "T"})
+	require.NoError(t, err)
+	got, err := repo.GetByID(ctx, "b1")
+	require.NoError(t, err)
+	assert.Equal(t, "T", got.Title)
+}
@@ -0,0 +1,279 @@
+// Package config loads the optional YAML config file: startup settings,
+// which stand in for flags, and the settings that can be changed without
+// a restart.
+package config
+
+import (
+	"book-manager/internal/core/model"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the content of the config file. Omitted settings keep their
+// defaults.
+type Config struct {
+	// Startup sets flags by name, e.g. listen or enrichment-source, unless
+	// given on the command line or in the environment; see ApplyFlags. It
+	// is read once, at start.
+	Startup map[string]any `yaml:"startup"`
+
+	LogLevel     string        `yaml:"log_level"`
+	Enrichment   Enrichment    `yaml:"enrichment"`
+	AutoTagRules []AutoTagRule `yaml:"auto_tag_rules"`
+	Auth         Auth          `yaml:"auth"`
+	RateLimit    RateLimit     `yaml:"rate_limit"`
+	CreateHook   CreateHook    `yaml:"create_hook"`
+}
+
+// CreateHook runs a Starlark script on every book being created, before it
+// is validated; see adapter.StarlarkHook for the script's contract. The
+// script is read again on every reload.
+type CreateHook struct {
+	// Script is the path of the script; empty turns the hook off.
+	Script string `yaml:"script"`
+	// Timeout and MaxSteps cut off a run of the script; they default to
+	// DefaultHookTimeout and DefaultHookMaxSteps.
+	Timeout  time.Duration `yaml:"timeout"`
+	MaxSteps uint64        `yaml:"max_steps"`
+}
+
+// Limits of a create hook run unless configured.
+const (
+	DefaultHookTimeout  = 100 * time.Millisecond
+	DefaultHookMaxSteps = 1_000_000
+)
+
+// Limits returns Timeout and MaxSteps with the defaults filled in.
+func (h CreateHook) Limits() (time.Duration, uint64) {
+	timeout, steps := h.Timeout, h.MaxSteps
+	if timeout == 0 {
+		timeout = DefaultHookTimeout
+	}
+	if steps == 0 {
+		steps = DefaultHookMaxSteps
+	}
+	return timeout, steps
+}
+
+type Enrichment struct {
+	Enabled *bool `yaml:"enabled"`
+	// Tenants gives tenants enrichment providers of their own, by tenant
+	// id; the others use the server's.
+	Tenants map[string]TenantEnrichment `yaml:"tenants"`
+}
+
+// TenantEnrichment configures the enrichment providers of one tenant.
+// Empty settings keep the server's.
+type TenantEnrichment struct {
+	Enabled *bool `yaml:"enabled"`
+	// Sources lists providers like -enrichment-source.
+	Sources        string `yaml:"sources"`
+	GoogleBooksKey string `yaml:"google_books_key"`
+	IsbndbKey      string `yaml:"isbndb_key"` // comma-separated like -isbndb-key
+}
+
+// IsEnabled reports whether the tenant's enrichment is on; it is unless
+// explicitly disabled.
+func (e TenantEnrichment) IsEnabled() bool {
+	return e.Enabled == nil || *e.Enabled
+}
+
+// IsEnabled reports whether enrichment is on; it is unless explicitly disabled.
+func (e Enrichment) IsEnabled() bool {
+	return e.Enabled == nil || *e.Enabled
+}
+
+// RateLimit overrides -rate-limit and -rate-burst when set; a rate of 0
+// turns rate limiting off.
+type RateLimit struct {
+	Rate  *float64 `yaml:"rate"`
+	Burst *int     `yaml:"burst"`
+}
+
+// Auth holds API keys in addition to those given by flag or environment.
+// With no keys at all, authentication is off.
+type Auth struct {
+	Keys []APIKey `yaml:"keys"`
+	// PublicReads lets requests without a key read (GET, HEAD); it
+	// overrides the -public-reads flag when set.
+	PublicReads *bool `yaml:"public_reads"`
+}
+
+// APIKey is a secret and the id that names its holder in logs. Tenants,
+// when set, are the only tenants the key may make requests for.
+type APIKey struct {
+	ID      string   `yaml:"id"`
+	Key     string   `yaml:"key"`
+	Tenants []string `yaml:"tenants"`
+}
+
+// ParseAPIKeys reads keys written as comma-separated id:key pairs, the
+// format of the API_KEYS environment variable. An id may be followed by
+// @ and the +-separated tenants the key is limited to, as in
+// east-ci@east+north:secret.
+func ParseAPIKeys(s string) ([]APIKey, error) {
+	var out []APIKey
+	for i, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		id, key, ok := strings.Cut(pair, ":")
+		if !ok {
+			// don't echo the entry, it may be a bare secret
+			return nil, fmt.Errorf("api key %d: want id:key", i+1)
+		}
+		k := APIKey{Key: strings.TrimSpace(key)}
+		id, tenants, limited := strings.Cut(id, "@")
+		k.ID = strings.TrimSpace(id)
+		if limited {
+			k.Tenants = strings.Split(tenants, "+")
+		}
+		out = append(out, k)
+	}
+	if err := validateKeys(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func validateKeys(keys []APIKey) error {
+	ids := make(map[string]bool, len(keys))
+	secrets := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		switch {
+		case k.ID == "" || k.Key == "":
+			return errors.New("api key: id and key must not be empty")
+		case ids[k.ID]:
+			return fmt.Errorf("api key %q: duplicate id", k.ID)
+		case secrets[k.Key]:
+			return fmt.Errorf("api key %q: key is used by another id", k.ID)
+		case k.Tenants != nil && len(k.Tenants) == 0:
+			return fmt.Errorf("api key %q: tenants must not be empty", k.ID)
+		}
+		for _, t := range k.Tenants {
+			if !validTenantID(t) {
+				return fmt.Errorf("api key %q: tenant %q is not a tenant id", k.ID, t)
+			}
+		}
+		ids[k.ID], secrets[k.Key] = true, true
+	}
+	return nil
+}
+
+// Tenant is a library a server serves along with others, and how many
+// books it may keep; a Quota of 0 keeps any number.
+type Tenant struct {
+	ID    string
+	Quota int
+}
+
+// ParseTenants reads tenants written as comma-separated ids, each
+// optionally followed by :quota. Ids are DNS labels, lowercase, so that
+// they also work as subdomains.
+func ParseTenants(s string) ([]Tenant, error) {
+	var out []Tenant
+	seen := map[string]bool{}
+	for _, entry := range strings.Split(s, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		id, quota, hasQuota := strings.Cut(entry, ":")
+		t := Tenant{ID: strings.TrimSpace(id)}
+		if !validTenantID(t.ID) {
+			return nil, fmt.Errorf("tenant %q: want 1 to 63 lowercase letters, digits or hyphens, not starting or ending with a hyphen", t.ID)
+		}
+		if seen[t.ID] {
+			return nil, fmt.Errorf("tenant %q: duplicate id", t.ID)
+		}
+		seen[t.ID] = true
+		if hasQuota {
+			n, err := strconv.Atoi(strings.TrimSpace(quota))
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("tenant %q: quota must be a positive number", t.ID)
+			}
+			t.Quota = n
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func validTenantID(id string) bool {
+	if id == "" || len(id) > 63 || id[0] == '-' || id[len(id)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if c := id[i]; (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+type AutoTagRule struct {
+	Field    string `yaml:"field"`
+	Contains string `yaml:"contains"`
+	AddTag   string `yaml:"add_tag"`
+}
+
+// Load reads and validates the config file. Unknown keys are rejected so
+// that typos don't silently fall back to defaults.
+func Load(path string) (Config, error) {
+	var c Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+	if _, err := c.Level(slog.LevelInfo); err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+	if err := validateKeys(c.Auth.Keys); err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+	if rl := c.RateLimit; (rl.Rate != nil && *rl.Rate < 0) || (rl.Burst != nil && *rl.Burst < 0) {
+		return Config{}, fmt.Errorf("config %s: rate_limit: rate and burst must not be negative", path)
+	}
+	if c.CreateHook.Timeout < 0 {
+		return Config{}, fmt.Errorf("config %s: create_hook: timeout must not be negative", path)
+	}
+	for id := range c.Enrichment.Tenants {
+		if !validTenantID(id) {
+			return Config{}, fmt.Errorf("config %s: enrichment: tenant %q is not a tenant id", path, id)
+		}
+	}
+	return c, nil
+}
+
+// Level parses LogLevel, returning def when it is not set.
+func (c Config) Level(def slog.Level) (slog.Level, error) {
+	if c.LogLevel == "" {
+		return def, nil
+	}
+	var lvl slog.Level
+	err := lvl.UnmarshalText([]byte(c.LogLevel))
+	return lvl, err
+}
+
+// Rules converts the configured auto-tag rules; they are validated when the
+// service installs them.
+func (c Config) Rules() []model.AutoTagRule {
+	out := make([]model.AutoTagRule, 0, len(c.AutoTagRules))
+	for _, r := range c.AutoTagRules {
+		out = append(out, model.AutoTagRule{Field: r.Field, Contains: r.Contains, AddTag: r.AddTag})
+	}
+	return out
+}
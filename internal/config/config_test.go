@@ -0,0 +1,190 @@
+//go:build unit
+
+package config
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	path := writeFile(t, `
+log_level: debug
+enrichment:
+  enabled: false
+auto_tag_rules:
+  - field: author
+    contains: tolkien
+    add_tag: fantasy
+create_hook:
+  script: rules.star
+  timeout: 250ms
+`)
+	c, err := Load(path)
+	require.NoError(t, err)
+	lvl, err := c.Level(slog.LevelInfo)
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelDebug, lvl)
+	assert.False(t, c.Enrichment.IsEnabled())
+	require.Len(t, c.Rules(), 1)
+	assert.Equal(t, "fantasy", c.Rules()[0].AddTag)
+	assert.Equal(t, "rules.star", c.CreateHook.Script)
+	timeout, steps := c.CreateHook.Limits()
+	assert.Equal(t, 250*time.Millisecond, timeout)
+	assert.Equal(t, uint64(DefaultHookMaxSteps), steps)
+}
+
+func TestLoad_EmptyFileUsesDefaults(t *testing.T) {
+	c, err := Load(writeFile(t, ""))
+	require.NoError(t, err)
+	lvl, err := c.Level(slog.LevelWarn)
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelWarn, lvl)
+	assert.True(t, c.Enrichment.IsEnabled())
+}
+
+func TestLoad_Rejects(t *testing.T) {
+	_, err := Load(writeFile(t, "log_levl: debug\n"))
+	assert.Error(t, err, "unknown key")
+	_, err = Load(writeFile(t, "log_level: loud\n"))
+	assert.Error(t, err, "invalid level")
+	_, err = Load(writeFile(t, "create_hook:\n  timeout: -1s\n"))
+	assert.Error(t, err, "negative timeout")
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestLoad_Auth(t *testing.T) {
+	c, err := Load(writeFile(t, `
+auth:
+  public_reads: false
+  keys:
+    - id: ci
+      key: s3cret
+    - id: east-ci
+      key: e4st
+      tenants: [east]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []APIKey{{ID: "ci", Key: "s3cret"}, {ID: "east-ci", Key: "e4st", Tenants: []string{"east"}}}, c.Auth.Keys)
+	require.NotNil(t, c.Auth.PublicReads)
+	assert.False(t, *c.Auth.PublicReads)
+
+	_, err = Load(writeFile(t, "auth:\n  keys:\n    - id: ci\n      key: a\n    - id: ci\n      key: b\n"))
+	assert.ErrorContains(t, err, "duplicate id")
+}
+
+func TestLoad_RateLimit(t *testing.T) {
+	c, err := Load(writeFile(t, "rate_limit:\n  rate: 2.5\n"))
+	require.NoError(t, err)
+	require.NotNil(t, c.RateLimit.Rate)
+	assert.Equal(t, 2.5, *c.RateLimit.Rate)
+	assert.Nil(t, c.RateLimit.Burst)
+
+	_, err = Load(writeFile(t, "rate_limit:\n  burst: -1\n"))
+	assert.ErrorContains(t, err, "must not be negative")
+}
+
+func TestLoad_TenantEnrichment(t *testing.T) {
+	c, err := Load(writeFile(t, `
+enrichment:
+  tenants:
+    acme:
+      sources: googlebooks,openlibrary:2s
+      google_books_key: acme-key
+    globex:
+      enabled: false
+`))
+	require.NoError(t, err)
+	require.Len(t, c.Enrichment.Tenants, 2)
+	acme := c.Enrichment.Tenants["acme"]
+	assert.Equal(t, "googlebooks,openlibrary:2s", acme.Sources)
+	assert.Equal(t, "acme-key", acme.GoogleBooksKey)
+	assert.True(t, acme.IsEnabled())
+	assert.False(t, c.Enrichment.Tenants["globex"].IsEnabled())
+
+	_, err = Load(writeFile(t, "enrichment:\n  tenants:\n    Acme:\n      enabled: false\n"))
+	assert.ErrorContains(t, err, "not a tenant id")
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := ParseAPIKeys(" ci:abc, admin:def ,")
+	require.NoError(t, err)
+	assert.Equal(t, []APIKey{{ID: "ci", Key: "abc"}, {ID: "admin", Key: "def"}}, keys)
+
+	keys, err = ParseAPIKeys("")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = ParseAPIKeys("ci:abc,bare-secret")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "bare-secret")
+	_, err = ParseAPIKeys("ci:")
+	assert.Error(t, err)
+	_, err = ParseAPIKeys("a:same,b:same")
+	assert.Error(t, err)
+
+	keys, err = ParseAPIKeys("east-ci@east+north:abc")
+	require.NoError(t, err)
+	assert.Equal(t, []APIKey{{ID: "east-ci", Key: "abc", Tenants: []string{"east", "north"}}}, keys)
+	for _, bad := range []string{"ci@:abc", "ci@East:abc", "ci@east+:abc"} {
+		_, err = ParseAPIKeys(bad)
+		assert.ErrorContains(t, err, "tenant", bad)
+	}
+}
+
+func TestParseTenants(t *testing.T) {
+	tenants, err := ParseTenants(" east:500, west ,")
+	require.NoError(t, err)
+	assert.Equal(t, []Tenant{{ID: "east", Quota: 500}, {ID: "west"}}, tenants)
+
+	for _, bad := range []string{"East", "-east", "a/b", "east,east", "east:0", "east:many"} {
+		_, err = ParseTenants(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestWatch_ReloadsOnChange(t *testing.T) {
+	path := writeFile(t, "log_level: info\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got := make(chan Config, 1)
+	go Watch(ctx, path, 5*time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil)), func(c Config) error {
+		select {
+		case got <- c:
+		default:
+		}
+		return nil
+	})
+
+	require.NoError(t, os.WriteFile(path, []byte("log_level: error\n"), 0o644))
+	timeout := time.After(2 * time.Second)
+	for i := 1; ; i++ {
+		// keep moving the mtime so the change is seen however the watcher
+		// goroutine is scheduled
+		require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Duration(i)*time.Second)))
+		select {
+		case c := <-got:
+			assert.Equal(t, "error", c.LogLevel)
+			return
+		case <-time.After(20 * time.Millisecond):
+		case <-timeout:
+			t.Fatal("config was not reloaded")
+		}
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
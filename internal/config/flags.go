@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// EnvPrefix starts the environment variables that override flags.
+const EnvPrefix = "BOOK_MANAGER_"
+
+// EnvName is the environment variable setting a flag: BOOK_MANAGER_LISTEN
+// for -listen, BOOK_MANAGER_ENRICHMENT_SOURCE for -enrichment-source.
+func EnvName(flagName string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(flagName))
+}
+
+// ApplyFlags gives each flag of fs that was not set on the command line its
+// value from the environment (see EnvName) or else from startup, the
+// config file's startup settings keyed by flag name. Lists in startup are
+// joined with commas. The flags are set as if given, so a flag set here is
+// not overridden by a later call. Settings naming no flag are rejected, as
+// is setting -config from the file.
+func ApplyFlags(fs *flag.FlagSet, startup map[string]any, lookupEnv func(string) (string, bool)) error {
+	var errs []error
+	for name := range startup {
+		if fs.Lookup(name) == nil || name == "config" {
+			errs = append(errs, fmt.Errorf("startup: unknown setting %q", name))
+		}
+	}
+	given := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	fs.VisitAll(func(f *flag.Flag) {
+		if given[f.Name] {
+			return
+		}
+		if v, ok := lookupEnv(EnvName(f.Name)); ok {
+			if err := fs.Set(f.Name, v); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", EnvName(f.Name), err))
+			}
+			return
+		}
+		raw, ok := startup[f.Name]
+		if !ok || f.Name == "config" {
+			return
+		}
+		v, err := settingValue(raw)
+		if err == nil {
+			err = fs.Set(f.Name, v)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("startup: %s: %w", f.Name, err))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// settingValue is a startup setting as flag text.
+func settingValue(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string, bool, int, int64, uint64, float64:
+		return fmt.Sprint(v), nil
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, x := range v {
+			s, err := settingValue(x)
+			if err != nil {
+				return "", err
+			}
+			if _, nested := x.([]any); nested {
+				return "", errors.New("lists must not be nested")
+			}
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, ","), nil
+	default:
+		return "", fmt.Errorf("want a value or a list of values, not %T", v)
+	}
+}
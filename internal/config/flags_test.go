@@ -0,0 +1,72 @@
+//go:build unit
+
+package config
+
+import (
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFlags(t *testing.T) {
+	fs := flag.NewFlagSet("api", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	listen := fs.String("listen", ":8080", "")
+	storage := fs.String("storage", "memory", "")
+	sources := fs.String("enrichment-source", "openlibrary", "")
+	rate := fs.Float64("rate-limit", 0, "")
+	timeout := fs.Duration("request-timeout", 10*time.Second, "")
+	public := fs.Bool("public-reads", true, "")
+	configPath := fs.String("config", "", "")
+	require.NoError(t, fs.Parse([]string{"-listen", ":9000"}))
+
+	c, err := Load(writeFile(t, `
+startup:
+  listen: ":7000"
+  storage: file
+  enrichment-source: [openlibrary:2s, googlebooks]
+  rate-limit: 12.5
+  request-timeout: 30s
+  public-reads: false
+log_level: debug
+`))
+	require.NoError(t, err)
+	env := map[string]string{"BOOK_MANAGER_STORAGE": "memory", "BOOK_MANAGER_CONFIG": "other.yaml"}
+	lookup := func(k string) (string, bool) { v, ok := env[k]; return v, ok }
+	require.NoError(t, ApplyFlags(fs, c.Startup, lookup))
+
+	assert.Equal(t, ":9000", *listen, "the command line wins")
+	assert.Equal(t, "memory", *storage, "the environment wins over the file")
+	assert.Equal(t, "openlibrary:2s,googlebooks", *sources, "lists are joined")
+	assert.Equal(t, 12.5, *rate)
+	assert.Equal(t, 30*time.Second, *timeout)
+	assert.False(t, *public)
+	assert.Equal(t, "other.yaml", *configPath)
+}
+
+func TestApplyFlags_Rejects(t *testing.T) {
+	newFS := func() *flag.FlagSet {
+		fs := flag.NewFlagSet("api", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.Int("rate-burst", 20, "")
+		fs.String("config", "", "")
+		fs.Duration("request-timeout", 0, "")
+		return fs
+	}
+	noEnv := func(string) (string, bool) { return "", false }
+	for name, startup := range map[string]map[string]any{
+		"unknown flag":     {"rate-brust": 5},
+		"config from file": {"config": "x.yaml"},
+		"bad int":          {"rate-burst": "lots"},
+		"bad duration":     {"request-timeout": 10},
+		"map value":        {"rate-burst": map[string]any{"n": 1}},
+	} {
+		assert.Error(t, ApplyFlags(newFS(), startup, noEnv), name)
+	}
+	err := ApplyFlags(newFS(), nil, func(k string) (string, bool) { return "x", k == "BOOK_MANAGER_RATE_BURST" })
+	assert.ErrorContains(t, err, "BOOK_MANAGER_RATE_BURST")
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Watch reloads the file at path on SIGHUP and whenever its modification time
+// changes, checking every interval, and passes each config that loads cleanly
+// to apply. Invalid files and failed applies are logged and otherwise
+// ignored, so the running settings stay in place. Watch returns when ctx is
+// done.
+func Watch(ctx context.Context, path string, interval time.Duration, log *slog.Logger, apply func(Config) error) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := modTime(path)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			log.Info("reloading config", "path", path, "trigger", "SIGHUP")
+		case <-ticker.C:
+			mt := modTime(path)
+			if mt.Equal(last) {
+				continue
+			}
+			last = mt
+			log.Info("reloading config", "path", path, "trigger", "file changed")
+		}
+		c, err := Load(path)
+		if err != nil {
+			log.With("error", err).Warn("config reload rejected")
+			continue
+		}
+		if err := apply(c); err != nil {
+			log.With("error", err).Warn("config reload rejected")
+			continue
+		}
+		log.Info("config reloaded", "path", path)
+	}
+}
+
+func modTime(path string) time.Time {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return fi.ModTime()
+}
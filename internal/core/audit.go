@@ -0,0 +1,271 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditRepository keeps the audit log of book changes.
+type AuditRepository interface {
+	Add(ctx context.Context, e model.AuditEntry) error
+	// List returns the entries matching q, newest first.
+	List(ctx context.Context, q model.AuditQuery) ([]model.AuditEntry, error)
+}
+
+// maxAuditLimit bounds the entries one audit query returns.
+const maxAuditLimit = 1000
+
+// auditedFields are the fields of a book the audit log tracks, named and
+// valued as in the API; unset and zero values are nil.
+var auditedFields = []struct {
+	name  string
+	value func(b model.Book) any
+}{
+	{"isbn", func(b model.Book) any { return ptrValue(b.ISBN) }},
+	{"title", func(b model.Book) any { return b.Title }},
+	{"subtitle", func(b model.Book) any { return ptrValue(b.Subtitle) }},
+	{"published_year", func(b model.Book) any { return ptrValue(b.PublishedYear) }},
+	{"page_count", func(b model.Book) any { return ptrValue(b.PageCount) }},
+	{"cover_url", func(b model.Book) any { return ptrValue(b.CoverURL) }},
+	{"authors", func(b model.Book) any { return sliceValue(b.Authors) }},
+	{"tags", func(b model.Book) any { return sliceValue(b.Tags) }},
+	{"description", func(b model.Book) any { return ptrValue(b.Description) }},
+	{"subjects", func(b model.Book) any { return sliceValue(b.Subjects) }},
+	{"forthcoming", func(b model.Book) any {
+		if !b.Forthcoming {
+			return nil
+		}
+		return true
+	}},
+	{"release_date", func(b model.Book) any {
+		if b.ReleaseDate == nil {
+			return nil
+		}
+		return b.ReleaseDate.Format(time.DateOnly)
+	}},
+	{"price_target", func(b model.Book) any {
+		if b.PriceTarget == nil {
+			return nil
+		}
+		return map[string]any{"amount": float64(b.PriceTarget.Amount) / 100, "currency": b.PriceTarget.Currency}
+	}},
+	{"enrichment_status", func(b model.Book) any {
+		if b.Enrichment.Status == "" {
+			return nil
+		}
+		return string(b.Enrichment.Status)
+	}},
+	{"copies", func(b model.Book) any {
+		if len(b.Copies) == 0 {
+			return nil
+		}
+		return maps.Clone(b.Copies)
+	}},
+	{"items", func(b model.Book) any {
+		if len(b.Items) == 0 {
+			return nil
+		}
+		barcodes := make([]string, len(b.Items))
+		for i, it := range b.Items {
+			barcodes[i] = it.Barcode
+		}
+		return barcodes
+	}},
+	{"lost_items", func(b model.Book) any {
+		var barcodes []string
+		for _, it := range b.Items {
+			if it.Condition == model.ItemLost {
+				barcodes = append(barcodes, it.Barcode)
+			}
+		}
+		if len(barcodes) == 0 {
+			return nil
+		}
+		return barcodes
+	}},
+	{"deleted_at", func(b model.Book) any {
+		if b.DeletedAt == nil {
+			return nil
+		}
+		return b.DeletedAt.UTC().Format(time.RFC3339Nano)
+	}},
+}
+
+func ptrValue[T any](p *T) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+func sliceValue(s []string) any {
+	if len(s) == 0 {
+		return nil
+	}
+	return slices.Clone(s)
+}
+
+// diffBooks lists the audited fields that differ between before and after;
+// a nil book has every field unset.
+func diffBooks(before, after *model.Book) []model.FieldChange {
+	var out []model.FieldChange
+	for _, f := range auditedFields {
+		var b, a any
+		if before != nil {
+			b = f.value(*before)
+		}
+		if after != nil {
+			a = f.value(*after)
+		}
+		if !reflect.DeepEqual(b, a) {
+			out = append(out, model.FieldChange{Field: f.name, Before: b, After: a})
+		}
+	}
+	return out
+}
+
+// audit records a change to a book, by the actor in ctx, in the audit log
+// and, unless the repository stored it with the change, as a domain event
+// in the outbox. Updates that change no audited field are not recorded.
+// The change itself is already stored, so a failure here only loses the
+// record: it is logged, and the change still succeeds.
+func (s *Service) audit(ctx context.Context, action string, before, after *model.Book) {
+	if s.Audit == nil && s.Outbox == nil {
+		return
+	}
+	ev, ok := s.event(ctx, action, before, after)
+	if !ok {
+		return
+	}
+	if s.Audit != nil {
+		e := model.AuditEntry{
+			ID:      ev.ID,
+			BookID:  ev.BookID,
+			Action:  action,
+			Actor:   ev.Actor,
+			At:      ev.At,
+			Changes: ev.Changes,
+			Tenant:  ev.Book.Tenant,
+		}
+		if err := s.Audit.Add(ctx, e); err != nil {
+			s.logger().Error("audit entry lost", "book_id", ev.BookID, "action", action, "err", err)
+		}
+	}
+	if st := model.EventStageFromContext(ctx); s.Outbox != nil && (st == nil || !st.Staged()) {
+		if err := s.Outbox.Add(ctx, ev); err != nil {
+			s.logger().Error("event lost", "book_id", ev.BookID, "action", action, "err", err)
+		}
+	}
+}
+
+// event returns the domain event of a change to a book by the actor in
+// ctx, or false for an update that changes no audited field.
+func (s *Service) event(ctx context.Context, action string, before, after *model.Book) (model.Event, bool) {
+	changes := diffBooks(before, after)
+	if len(changes) == 0 && (action == model.AuditUpdate || action == model.AuditEnrich) {
+		return model.Event{}, false
+	}
+	var b model.Book
+	if after != nil {
+		b = *after
+	} else if before != nil {
+		b = *before
+	}
+	b.Embedding = nil // large and of no use to a consumer
+	return model.Event{
+		ID:      uuid.NewString(),
+		Type:    eventTypes[action],
+		BookID:  b.ID,
+		Actor:   model.ActorFromContext(ctx),
+		At:      time.Now(),
+		Book:    b,
+		Changes: changes,
+	}, true
+}
+
+// stage returns ctx for a repository write recording action. When the
+// book repository is also the outbox, ctx carries the stage the repository
+// stores the events of the write by, in the same transaction.
+func (s *Service) stage(ctx context.Context, action string) context.Context {
+	if o, ok := unwrapRepo(s.Repo).(Outbox); !ok || s.Outbox == nil || o != s.Outbox {
+		return ctx
+	}
+	return model.WithEventStage(ctx, model.NewEventStage(func(before, after *model.Book) (model.Event, bool) {
+		return s.event(ctx, action, before, after)
+	}))
+}
+
+func (s *Service) logger() *slog.Logger {
+	if s.Log != nil {
+		return s.Log
+	}
+	return slog.Default()
+}
+
+// updateBook stores b, which was before, and records the change. It stamps
+// b itself, so no caller can move CreatedAt or set UpdatedAt.
+func (s *Service) updateBook(ctx context.Context, action string, before, b model.Book) (model.Book, error) {
+	touch(before, &b)
+	ctx = s.stage(ctx, action)
+	out, err := s.Repo.Update(ctx, b)
+	if err != nil {
+		return out, err
+	}
+	s.audit(ctx, action, &before, &out)
+	return out, nil
+}
+
+// auditChanges records the changes a repository made to many books at once,
+// each like an update of that book.
+func (s *Service) auditChanges(ctx context.Context, changes []model.BookChange) {
+	for _, c := range changes {
+		s.audit(ctx, model.AuditUpdate, &c.Before, &c.After)
+	}
+}
+
+// touch stamps b, a change of before, and returns the time of the change:
+// b keeps before's CreatedAt and gets UpdatedAt now, in UTC and never
+// earlier than before's, so a clock stepping back does not reorder changes.
+func touch(before model.Book, b *model.Book) time.Time {
+	now := time.Now().UTC()
+	if now.Before(before.UpdatedAt) {
+		now = before.UpdatedAt.UTC()
+	}
+	b.CreatedAt, b.UpdatedAt = before.CreatedAt, now
+	return now
+}
+
+// BookHistory returns the audit entries of a book the caller can read,
+// newest first. The history of trashed and purged books stays in the log,
+// where AuditLog finds it by book.
+func (s *Service) BookHistory(ctx context.Context, id string) ([]model.AuditEntry, error) {
+	if s.Audit == nil {
+		return nil, fmt.Errorf("%w: no audit log configured", model.ErrNotFound)
+	}
+	if _, err := s.getBook(ctx, id); err != nil {
+		return nil, fmt.Errorf("%w: book %s", model.ErrNotFound, id)
+	}
+	return s.Audit.List(ctx, model.AuditQuery{BookID: id})
+}
+
+// AuditLog queries the audit log of the whole catalog, newest first.
+func (s *Service) AuditLog(ctx context.Context, q model.AuditQuery) ([]model.AuditEntry, error) {
+	if s.Audit == nil {
+		return nil, fmt.Errorf("%w: no audit log configured", model.ErrNotFound)
+	}
+	if q.Limit < 1 || q.Limit > maxAuditLimit {
+		return nil, &model.FieldError{Field: "limit", Reason: fmt.Sprintf("must be between 1 and %d", maxAuditLimit)}
+	}
+	if !q.Since.IsZero() && !q.Until.IsZero() && !q.Since.Before(q.Until) {
+		return nil, &model.FieldError{Field: "since", Reason: "must be before until"}
+	}
+	return s.Audit.List(ctx, q)
+}
@@ -0,0 +1,112 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit(t *testing.T) {
+	repo := adapter.NewBookRepo()
+	svc := NewService(repo, mockEnrich{hit: true})
+	ctx := model.WithActor(context.Background(), "api-key:ci")
+	_, err := svc.BookHistory(ctx, "missing")
+	assert.ErrorIs(t, err, model.ErrNotFound, "no audit log")
+	svc.Audit = adapter.NewAuditRepo()
+
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{ISBN: util.GetPtr("9780134494166"), Title: util.GetPtr("Draft")})
+	require.NoError(t, err)
+	_, err = svc.PatchBook(model.WithActor(ctx, "jwt:alice"), b.ID, model.BookPatch{Title: util.GetPtr("Final")})
+	require.NoError(t, err)
+	_, err = svc.PatchBook(ctx, b.ID, model.BookPatch{Title: util.GetPtr("Final")})
+	require.NoError(t, err, "no-op edit")
+	_, err = svc.EnrichBook(context.Background(), b.ID, false)
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteBook(ctx, b.ID))
+	_, err = svc.RestoreBook(ctx, b.ID)
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteBook(ctx, b.ID))
+	_, err = svc.PurgeBooks(ctx, time.Time{})
+	require.NoError(t, err)
+
+	_, err = svc.BookHistory(ctx, b.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound, "purged books are gone")
+	history, err := svc.AuditLog(ctx, model.AuditQuery{BookID: b.ID, Limit: 100})
+	require.NoError(t, err, "purged books keep their history in the log")
+	var actions, actors []string
+	for _, e := range history {
+		actions = append(actions, e.Action)
+		actors = append(actors, e.Actor)
+		assert.Equal(t, b.ID, e.BookID)
+	}
+	assert.Equal(t, []string{"purge", "delete", "restore", "delete", "enrich", "update", "create"}, actions)
+	assert.Equal(t, []string{"api-key:ci", "api-key:ci", "api-key:ci", "api-key:ci", "system", "jwt:alice", "api-key:ci"}, actors)
+	assert.Equal(t, []model.FieldChange{{Field: "title", Before: "Draft", After: "Final"}}, history[5].Changes)
+	assert.Contains(t, history[4].Changes, model.FieldChange{Field: "authors", After: []string{"Robert C. Martin"}})
+	assert.Contains(t, history[6].Changes, model.FieldChange{Field: "isbn", After: "9780134494166"})
+	assert.Equal(t, "deleted_at", history[3].Changes[0].Field)
+	assert.Nil(t, history[3].Changes[0].Before)
+
+	since := history[2].At
+	got, err := svc.AuditLog(ctx, model.AuditQuery{Actor: "api-key:ci", Since: since, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, history[0].ID, got[0].ID)
+	_, err = svc.AuditLog(ctx, model.AuditQuery{Limit: 0})
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.AuditLog(ctx, model.AuditQuery{Since: since, Until: since, Limit: 10})
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.BookHistory(ctx, "missing")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestBookHistory_Visibility(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	svc.Audit = adapter.NewAuditRepo()
+	svc.PerUser = true
+	alice := model.WithUser(context.Background(), model.User{ID: "jwt:alice"})
+	bob := model.WithUser(context.Background(), model.User{ID: "jwt:bob"})
+	b, err := svc.CreateBook(alice, model.CreateBookInput{Title: util.GetPtr("Diary")})
+	require.NoError(t, err)
+
+	history, err := svc.BookHistory(alice, b.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+	_, err = svc.BookHistory(bob, b.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound, "another user's book has no history for bob")
+
+	require.NoError(t, svc.DeleteBook(alice, b.ID))
+	_, err = svc.BookHistory(alice, b.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound, "trashed")
+}
+
+// brokenAudit fails every write, as a full disk would.
+type brokenAudit struct{ AuditRepository }
+
+func (brokenAudit) Add(context.Context, model.AuditEntry) error { return errors.New("disk full") }
+
+func TestAudit_LostEntryKeepsTheChange(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Audit = brokenAudit{}
+	svc.Log = slog.New(slog.DiscardHandler)
+
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Draft")})
+	require.NoError(t, err, "the book is stored; only its entry is lost")
+	_, err = svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	b, err = svc.UpdateBook(ctx, b.ID, model.UpdateBookInput{Title: util.GetPtr("Final")})
+	require.NoError(t, err)
+	assert.Equal(t, "Final", b.Title)
+	assert.NoError(t, svc.DeleteBook(ctx, b.ID))
+}
@@ -0,0 +1,251 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuthorRepository interface {
+	Create(ctx context.Context, a model.Author) (model.Author, error)
+	Update(ctx context.Context, a model.Author) (model.Author, error)
+	GetByID(ctx context.Context, id string) (model.Author, error)
+	// GetByName matches case-insensitively.
+	GetByName(ctx context.Context, name string) (model.Author, error)
+	List(ctx context.Context, q model.AuthorQuery) (model.Page[model.Author], error)
+	Delete(ctx context.Context, id string) error
+}
+
+var errAuthorInUse = errors.New("author is still listed on books")
+
+// RenameAuthor renames an author across the whole catalog. When a book
+// already lists the new name the two entries are merged, and so are the
+// registry entries: books end up linked to the existing author for the new
+// name, or to the renamed author if there was none. Every renamed book gets
+// an audit entry and an event, as an update of it would.
+func (s *Service) RenameAuthor(ctx context.Context, from, to string) (model.AuthorRename, error) {
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == "" || to == "" || from == to {
+		return model.AuthorRename{}, model.ErrValidation
+	}
+	rn := model.AuthorRename{From: from, To: to, RenamedAt: time.Now().UTC()}
+
+	// resolve the registry entry the renamed books will point to
+	var fromAuthor, toAuthor *model.Author
+	if s.Authors != nil {
+		if a, err := s.Authors.GetByName(ctx, from); err == nil {
+			fromAuthor = &a
+		}
+		if a, err := s.Authors.GetByName(ctx, to); err == nil && (fromAuthor == nil || a.ID != fromAuthor.ID) {
+			toAuthor = &a
+		}
+		switch {
+		case toAuthor != nil:
+			rn.ToID = toAuthor.ID
+		case fromAuthor != nil:
+			rn.ToID = fromAuthor.ID
+		default:
+			rn.ToID = uuid.NewString()
+		}
+	}
+
+	wctx := s.stage(ctx, model.AuditUpdate)
+	rn, err := s.Repo.RenameAuthor(wctx, rn)
+	if err != nil {
+		return model.AuthorRename{}, err
+	}
+	if rn.BooksUpdated == 0 {
+		return model.AuthorRename{}, model.ErrNotFound
+	}
+	s.auditChanges(wctx, rn.Changes)
+	rn.Changes = nil
+	if s.Authors == nil {
+		return rn, nil
+	}
+	switch {
+	case toAuthor != nil && fromAuthor != nil:
+		err = s.Authors.Delete(ctx, fromAuthor.ID)
+	case toAuthor != nil:
+	case fromAuthor != nil:
+		fromAuthor.Name, fromAuthor.UpdatedAt = to, rn.RenamedAt
+		_, err = s.Authors.Update(ctx, *fromAuthor)
+	default:
+		_, err = s.Authors.Create(ctx, model.Author{ID: rn.ToID, Name: to, Tenant: model.TenantFromContext(ctx), CreatedAt: rn.RenamedAt, UpdatedAt: rn.RenamedAt})
+	}
+	return rn, err
+}
+
+func (s *Service) ListAuthorRenames(ctx context.Context) ([]model.AuthorRename, error) {
+	return s.Repo.ListAuthorRenames(ctx)
+}
+
+func (s *Service) CreateAuthor(ctx context.Context, name string) (model.Author, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return model.Author{}, model.ErrValidation
+	}
+	if _, err := s.Authors.GetByName(ctx, name); err == nil {
+		return model.Author{}, model.ErrConflict
+	}
+	now := time.Now()
+	a, err := s.Authors.Create(ctx, model.Author{ID: uuid.NewString(), Name: name, Tenant: model.TenantFromContext(ctx), CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		return model.Author{}, model.ErrConflict
+	}
+	return a, nil
+}
+
+func (s *Service) GetAuthor(ctx context.Context, id string) (model.Author, error) {
+	a, err := s.Authors.GetByID(ctx, id)
+	if err != nil {
+		return model.Author{}, model.ErrNotFound
+	}
+	return a, nil
+}
+
+func (s *Service) ListAuthors(ctx context.Context, q model.AuthorQuery) (model.Page[model.Author], error) {
+	return s.Authors.List(ctx, q)
+}
+
+// UpdateAuthor renames a registry entry and every book listing it. Renaming
+// to another author's name is a conflict; use RenameAuthor to merge.
+func (s *Service) UpdateAuthor(ctx context.Context, id, name string) (model.Author, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return model.Author{}, model.ErrValidation
+	}
+	a, err := s.Authors.GetByID(ctx, id)
+	if err != nil {
+		return model.Author{}, model.ErrNotFound
+	}
+	if a.Name == name {
+		return a, nil
+	}
+	if other, err := s.Authors.GetByName(ctx, name); err == nil && other.ID != id {
+		return model.Author{}, model.ErrConflict
+	}
+	old := a.Name
+	a.Name, a.UpdatedAt = name, time.Now().UTC()
+	if a, err = s.Authors.Update(ctx, a); err != nil {
+		return model.Author{}, model.ErrConflict
+	}
+	wctx := s.stage(ctx, model.AuditUpdate)
+	rn, err := s.Repo.RenameAuthor(wctx, model.AuthorRename{From: old, To: name, ToID: id, RenamedAt: a.UpdatedAt})
+	if err != nil {
+		return a, err
+	}
+	s.auditChanges(wctx, rn.Changes)
+	return a, nil
+}
+
+// DeleteAuthor removes a registry entry that no book lists anymore.
+func (s *Service) DeleteAuthor(ctx context.Context, id string) error {
+	a, err := s.Authors.GetByID(ctx, id)
+	if err != nil {
+		return model.ErrNotFound
+	}
+	err = s.eachBook(ctx, func(b model.Book) error {
+		if slices.Contains(b.AuthorIDs, id) || hasAuthor(b.Authors, a.Name) {
+			return errAuthorInUse
+		}
+		return nil
+	})
+	if errors.Is(err, errAuthorInUse) {
+		return model.ErrConflict
+	}
+	if err != nil {
+		return err
+	}
+	return s.Authors.Delete(ctx, id)
+}
+
+// SyncAuthors links every stored book to the author registry, creating
+// missing entries. Ids already stored on books are registered first, so a
+// registry rebuilt on startup keeps the ids clients have seen. It returns
+// how many books changed.
+func SyncAuthors(ctx context.Context, repo BookRepository, authors AuthorRepository) (int, error) {
+	s := &Service{Repo: repo, Authors: authors}
+	err := s.eachBook(ctx, func(b model.Book) error {
+		if len(b.AuthorIDs) != len(b.Authors) {
+			return nil
+		}
+		for i, id := range b.AuthorIDs {
+			if id == "" {
+				continue
+			}
+			if _, err := authors.GetByName(ctx, b.Authors[i]); err == nil {
+				continue
+			}
+			if _, err := authors.GetByID(ctx, id); err == nil {
+				continue
+			}
+			now := time.Now()
+			if _, err := authors.Create(ctx, model.Author{ID: id, Name: b.Authors[i], CreatedAt: now, UpdatedAt: now}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	err = s.eachBook(ctx, func(b model.Book) error {
+		before := b
+		before.AuthorIDs = slices.Clone(b.AuthorIDs)
+		if err := s.linkAuthors(ctx, &b); err != nil {
+			return err
+		}
+		if slices.Equal(before.AuthorIDs, b.AuthorIDs) {
+			return nil
+		}
+		if _, err := s.updateBook(ctx, model.AuditUpdate, before, b); err != nil {
+			return err
+		}
+		updated++
+		return nil
+	})
+	return updated, err
+}
+
+// linkAuthors sets b.AuthorIDs from b.Authors, registering names that are not
+// known yet. A service without an author registry leaves the book unlinked.
+func (s *Service) linkAuthors(ctx context.Context, b *model.Book) error {
+	if s.Authors == nil {
+		return nil
+	}
+	// the book's tenant has the authors, also for work outside a tenant
+	ctx = model.WithTenant(ctx, b.Tenant)
+	ids := make([]string, 0, len(b.Authors))
+	for _, name := range b.Authors {
+		a, err := s.Authors.GetByName(ctx, name)
+		if err != nil {
+			now := time.Now()
+			a, err = s.Authors.Create(ctx, model.Author{ID: uuid.NewString(), Name: name, Tenant: model.TenantFromContext(ctx), CreatedAt: now, UpdatedAt: now})
+		}
+		if err != nil {
+			// a concurrent request may have registered the name meanwhile
+			if a, err = s.Authors.GetByName(ctx, name); err != nil {
+				return err
+			}
+		}
+		ids = append(ids, a.ID)
+	}
+	b.AuthorIDs = ids
+	return nil
+}
+
+func hasAuthor(authors []string, name string) bool {
+	for _, a := range authors {
+		if strings.EqualFold(a, name) {
+			return true
+		}
+	}
+	return false
+}
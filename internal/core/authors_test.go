@@ -0,0 +1,198 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameAuthor_MergesAndRecordsHistory(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	ctx := context.Background()
+	a, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("A"), Authors: []string{"Bob Martin", "Jane Doe"}})
+	require.NoError(t, err)
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("B"), Authors: []string{"Robert C. Martin", "bob martin"}})
+	require.NoError(t, err)
+	c, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("C"), Authors: []string{"Eric Evans"}})
+	require.NoError(t, err)
+
+	rn, err := svc.RenameAuthor(ctx, "Bob Martin", "Robert C. Martin")
+	require.NoError(t, err)
+	assert.Equal(t, 2, rn.BooksUpdated)
+
+	got, _ := svc.GetBook(ctx, a.ID)
+	assert.Equal(t, []string{"Robert C. Martin", "Jane Doe"}, got.Authors)
+	assert.Equal(t, rn.RenamedAt, got.UpdatedAt)
+	got, _ = svc.GetBook(ctx, b.ID)
+	assert.Equal(t, []string{"Robert C. Martin"}, got.Authors, "collision is merged")
+	got, _ = svc.GetBook(ctx, c.ID)
+	assert.Equal(t, []string{"Eric Evans"}, got.Authors)
+
+	history, err := svc.ListAuthorRenames(ctx)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "Bob Martin", history[0].From)
+}
+
+func TestRenameAuthor_AuditsEveryBook(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Audit = adapter.NewAuditRepo()
+	svc.Outbox = adapter.NewOutboxRepo()
+	svc.Authors = adapter.NewAuthorRepo()
+	ctx := model.WithActor(context.Background(), "api-key:ci")
+	a, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("A"), Authors: []string{"Bob Martin"}})
+	require.NoError(t, err)
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("B"), Authors: []string{"bob martin", "Jane Doe"}})
+	require.NoError(t, err)
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("C"), Authors: []string{"Eric Evans"}})
+	require.NoError(t, err)
+
+	rn, err := svc.RenameAuthor(ctx, "Bob Martin", "Robert C. Martin")
+	require.NoError(t, err)
+	assert.Empty(t, rn.Changes)
+	for _, id := range []string{a.ID, b.ID} {
+		history, err := svc.BookHistory(ctx, id)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, model.AuditUpdate, history[0].Action)
+		assert.Equal(t, "api-key:ci", history[0].Actor)
+		require.Len(t, history[0].Changes, 1)
+		assert.Equal(t, "authors", history[0].Changes[0].Field)
+	}
+	events, err := svc.Outbox.Pending(ctx, 100)
+	require.NoError(t, err)
+	var updated []string
+	for _, ev := range events {
+		if ev.Type == model.EventBookUpdated {
+			updated = append(updated, ev.BookID)
+		}
+	}
+	assert.ElementsMatch(t, []string{a.ID, b.ID}, updated)
+
+	// renaming through the registry is recorded the same way
+	author, err := svc.Authors.GetByName(ctx, "Robert C. Martin")
+	require.NoError(t, err)
+	_, err = svc.UpdateAuthor(ctx, author.ID, "Uncle Bob")
+	require.NoError(t, err)
+	history, err := svc.BookHistory(ctx, a.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 3)
+}
+
+func TestRenameAuthor_Errors(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	ctx := context.Background()
+	_, err := svc.RenameAuthor(ctx, "", "X")
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.RenameAuthor(ctx, "Nobody", "Somebody")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	history, err := svc.ListAuthorRenames(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func newAuthorService() *Service {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Authors = adapter.NewAuthorRepo()
+	return svc
+}
+
+func TestAuthors_LinkedAndDeduplicated(t *testing.T) {
+	svc := newAuthorService()
+	ctx := context.Background()
+	a, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("A"), Authors: []string{"Jane Doe", "Eric Evans"}})
+	require.NoError(t, err)
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("B"), Authors: []string{"jane doe"}})
+	require.NoError(t, err)
+
+	require.Len(t, a.AuthorIDs, 2)
+	assert.Equal(t, a.AuthorIDs[0], b.AuthorIDs[0])
+	page, err := svc.ListAuthors(ctx, model.AuthorQuery{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+	assert.Equal(t, "Eric Evans", page.Data[0].Name)
+}
+
+func TestRenameAuthor_MergesRegistry(t *testing.T) {
+	svc := newAuthorService()
+	ctx := context.Background()
+	a, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("A"), Authors: []string{"Bob Martin"}})
+	require.NoError(t, err)
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("B"), Authors: []string{"Robert C. Martin"}})
+	require.NoError(t, err)
+
+	_, err = svc.RenameAuthor(ctx, "Bob Martin", "Robert C. Martin")
+	require.NoError(t, err)
+	got, err := svc.GetBook(ctx, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, b.AuthorIDs, got.AuthorIDs)
+	_, err = svc.GetAuthor(ctx, a.AuthorIDs[0])
+	assert.ErrorIs(t, err, model.ErrNotFound, "merged author is removed")
+
+	// renaming to an unknown name keeps the author's id
+	_, err = svc.RenameAuthor(ctx, "Robert C. Martin", "Uncle Bob")
+	require.NoError(t, err)
+	author, err := svc.GetAuthor(ctx, b.AuthorIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, "Uncle Bob", author.Name)
+}
+
+func TestUpdateAndDeleteAuthor(t *testing.T) {
+	svc := newAuthorService()
+	ctx := context.Background()
+	book, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("A"), Authors: []string{"Jon Doe"}})
+	require.NoError(t, err)
+	other, err := svc.CreateAuthor(ctx, "Jane Roe")
+	require.NoError(t, err)
+	_, err = svc.CreateAuthor(ctx, "jane roe")
+	assert.ErrorIs(t, err, model.ErrConflict)
+
+	id := book.AuthorIDs[0]
+	a, err := svc.UpdateAuthor(ctx, id, "John Doe")
+	require.NoError(t, err)
+	assert.Equal(t, "John Doe", a.Name)
+	got, err := svc.GetBook(ctx, book.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"John Doe"}, got.Authors)
+	assert.Equal(t, []string{id}, got.AuthorIDs)
+
+	_, err = svc.UpdateAuthor(ctx, id, "Jane Roe")
+	assert.ErrorIs(t, err, model.ErrConflict)
+	_, err = svc.UpdateAuthor(ctx, "missing", "X")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	assert.ErrorIs(t, svc.DeleteAuthor(ctx, id), model.ErrConflict, "still listed on a book")
+	require.NoError(t, svc.DeleteAuthor(ctx, other.ID))
+	require.NoError(t, svc.DeleteBook(ctx, book.ID))
+	require.NoError(t, svc.DeleteAuthor(ctx, id))
+}
+
+func TestSyncAuthors_ReusesStoredIDs(t *testing.T) {
+	repo := adapter.NewBookRepo()
+	ctx := context.Background()
+	_, err := repo.Create(ctx, model.Book{ID: "b1", Title: "A", Authors: []string{"Jane Doe"}, AuthorIDs: []string{"author-1"}})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.Book{ID: "b2", Title: "B", Authors: []string{"jane doe", "Eric Evans"}})
+	require.NoError(t, err)
+
+	authors := adapter.NewAuthorRepo()
+	n, err := SyncAuthors(ctx, repo, authors)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	jane, err := authors.GetByName(ctx, "Jane Doe")
+	require.NoError(t, err)
+	assert.Equal(t, "author-1", jane.ID)
+	b2, err := repo.GetByID(ctx, "b2")
+	require.NoError(t, err)
+	assert.Equal(t, "author-1", b2.AuthorIDs[0])
+	assert.NotEmpty(t, b2.AuthorIDs[1])
+}
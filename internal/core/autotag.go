@@ -0,0 +1,167 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AutoTagRuleRepository interface {
+	Create(ctx context.Context, r model.AutoTagRule) (model.AutoTagRule, error)
+	List(ctx context.Context) ([]model.AutoTagRule, error)
+	Delete(ctx context.Context, id string) error
+	// ReplaceConfigured atomically swaps the rules that come from the config
+	// file; rules created through the API are kept.
+	ReplaceConfigured(ctx context.Context, rules []model.AutoTagRule) error
+}
+
+func (s *Service) CreateAutoTagRule(ctx context.Context, in model.AutoTagRule) (model.AutoTagRule, error) {
+	in, err := normalizeRule(in)
+	if err != nil {
+		return model.AutoTagRule{}, err
+	}
+	in.ID = uuid.NewString()
+	in.CreatedAt = time.Now()
+	return s.Rules.Create(ctx, in)
+}
+
+// ReplaceConfiguredAutoTagRules installs the rules from the config file. The
+// set is validated as a whole, so an invalid rule leaves the current ones in
+// place.
+func (s *Service) ReplaceConfiguredAutoTagRules(ctx context.Context, rules []model.AutoTagRule) error {
+	now := time.Now()
+	out := make([]model.AutoTagRule, 0, len(rules))
+	for i, r := range rules {
+		r, err := normalizeRule(r)
+		if err != nil {
+			return fmt.Errorf("auto-tag rule %d: %w", i+1, err)
+		}
+		r.ID = fmt.Sprintf("config-%d", i+1)
+		r.CreatedAt = now
+		out = append(out, r)
+	}
+	return s.Rules.ReplaceConfigured(ctx, out)
+}
+
+func normalizeRule(r model.AutoTagRule) (model.AutoTagRule, error) {
+	switch r.Field {
+	case "author", "title", "tag":
+	default:
+		return model.AutoTagRule{}, model.ErrValidation
+	}
+	r.Contains = strings.TrimSpace(r.Contains)
+	r.AddTag = strings.TrimSpace(r.AddTag)
+	if r.Contains == "" || r.AddTag == "" {
+		return model.AutoTagRule{}, model.ErrValidation
+	}
+	tag, err := tagPath("add_tag", r.AddTag)
+	if err != nil {
+		return model.AutoTagRule{}, err
+	}
+	r.AddTag = tag
+	return r, nil
+}
+
+func (s *Service) ListAutoTagRules(ctx context.Context) ([]model.AutoTagRule, error) {
+	return s.Rules.List(ctx)
+}
+
+func (s *Service) DeleteAutoTagRule(ctx context.Context, id string) error {
+	if err := s.Rules.Delete(ctx, id); err != nil {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+// BackfillAutoTags applies the current rules to every stored book and
+// returns how many books gained at least one tag.
+func (s *Service) BackfillAutoTags(ctx context.Context) (int, error) {
+	rules, err := s.Rules.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	updated := 0
+	err = s.eachBook(ctx, func(b model.Book) error {
+		before := b
+		if !applyAutoTags(&b, rules) {
+			return nil
+		}
+		if _, err := s.updateBook(ctx, model.AuditUpdate, before, b); err != nil {
+			return err
+		}
+		updated++
+		return nil
+	})
+	return updated, err
+}
+
+// autoTag applies the configured rules to b; a service without a rule
+// repository leaves the book untouched.
+func (s *Service) autoTag(ctx context.Context, b *model.Book) error {
+	if s.Rules == nil {
+		return nil
+	}
+	rules, err := s.Rules.List(ctx)
+	if err != nil {
+		return err
+	}
+	applyAutoTags(b, rules)
+	return nil
+}
+
+// eachBook walks the whole catalog page by page in creation order.
+func (s *Service) eachBook(ctx context.Context, fn func(b model.Book) error) error {
+	return s.ExportBooks(ctx, model.ListQuery{}, fn)
+}
+
+// applyAutoTags appends the tag of every matching rule that the book does not
+// carry yet and reports whether anything changed.
+func applyAutoTags(b *model.Book, rules []model.AutoTagRule) bool {
+	changed := false
+	for _, r := range rules {
+		if !ruleMatches(*b, r) || hasTag(b.Tags, r.AddTag) {
+			continue
+		}
+		b.Tags = append(b.Tags, r.AddTag)
+		changed = true
+	}
+	return changed
+}
+
+func ruleMatches(b model.Book, r model.AutoTagRule) bool {
+	needle := strings.ToLower(r.Contains)
+	switch r.Field {
+	case "author":
+		return anyContains(b.Authors, needle)
+	case "title":
+		if strings.Contains(strings.ToLower(b.Title), needle) {
+			return true
+		}
+		return b.Subtitle != nil && strings.Contains(strings.ToLower(*b.Subtitle), needle)
+	case "tag":
+		return anyContains(b.Tags, needle)
+	}
+	return false
+}
+
+func anyContains(values []string, needle string) bool {
+	for _, v := range values {
+		if strings.Contains(strings.ToLower(v), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
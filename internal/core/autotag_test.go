@@ -0,0 +1,86 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoTag_AppliedOnCreate(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Rules = adapter.NewAutoTagRuleRepo()
+	ctx := context.Background()
+	_, err := svc.CreateAutoTagRule(ctx, model.AutoTagRule{Field: "author", Contains: "martin", AddTag: "clean-code"})
+	require.NoError(t, err)
+
+	out, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Clean Code"), Authors: []string{"Robert C. Martin"}, Tags: []string{"go"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "clean-code"}, out.Tags)
+
+	other, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Refactoring"), Authors: []string{"Martin Fowler"}, Tags: []string{"clean-code"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"clean-code"}, other.Tags, "tag is not duplicated")
+}
+
+func TestAutoTag_RuleValidation(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Rules = adapter.NewAutoTagRuleRepo()
+	ctx := context.Background()
+
+	_, err := svc.CreateAutoTagRule(ctx, model.AutoTagRule{Field: "publisher", Contains: "x", AddTag: "y"})
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.CreateAutoTagRule(ctx, model.AutoTagRule{Field: "title", Contains: " ", AddTag: "y"})
+	assert.ErrorIs(t, err, model.ErrValidation)
+	assert.ErrorIs(t, svc.DeleteAutoTagRule(ctx, "missing"), model.ErrNotFound)
+}
+
+func TestAutoTag_Backfill(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Rules = adapter.NewAutoTagRuleRepo()
+	ctx := context.Background()
+	arch, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Clean Architecture")})
+	require.NoError(t, err)
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Go in Action")})
+	require.NoError(t, err)
+
+	_, err = svc.CreateAutoTagRule(ctx, model.AutoTagRule{Field: "title", Contains: "architecture", AddTag: "arch"})
+	require.NoError(t, err)
+	n, err := svc.BackfillAutoTags(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	got, err := svc.GetBook(ctx, arch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"arch"}, got.Tags)
+
+	n, err = svc.BackfillAutoTags(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n, "second run is a no-op")
+}
+
+func TestAutoTag_ReplaceConfiguredRules(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Rules = adapter.NewAutoTagRuleRepo()
+	ctx := context.Background()
+	_, err := svc.CreateAutoTagRule(ctx, model.AutoTagRule{Field: "title", Contains: "go", AddTag: "golang"})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.ReplaceConfiguredAutoTagRules(ctx, []model.AutoTagRule{{Field: "author", Contains: "pike", AddTag: "classic"}}))
+	rules, err := svc.ListAutoTagRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "config-1", rules[0].ID)
+
+	err = svc.ReplaceConfiguredAutoTagRules(ctx, []model.AutoTagRule{{Field: "author", Contains: "kernighan", AddTag: "classic"}, {Field: "isbn", Contains: "1", AddTag: "x"}})
+	assert.ErrorIs(t, err, model.ErrValidation)
+	rules, err = svc.ListAutoTagRules(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "pike", rules[0].Contains, "invalid set leaves the current rules in place")
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"time"
+)
+
+// AvailabilityProvider checks a library catalog for a book.
+type AvailabilityProvider interface {
+	CheckAvailability(ctx context.Context, isbn string) (model.Availability, error)
+}
+
+// BookAvailability asks the configured library whether it can lend the book.
+func (s *Service) BookAvailability(ctx context.Context, id string) (model.Availability, error) {
+	b, err := s.getBook(ctx, id)
+	if err != nil {
+		return model.Availability{}, model.ErrNotFound
+	}
+	if s.Library == nil {
+		return model.Availability{}, fmt.Errorf("%w: no library configured", model.ErrNotFound)
+	}
+	if b.ISBN == nil {
+		return model.Availability{}, &model.FieldError{Field: "isbn", Reason: "book has no ISBN to look up"}
+	}
+	a, err := s.Library.CheckAvailability(ctx, *b.ISBN)
+	if err != nil {
+		return model.Availability{}, fmt.Errorf("%w: %v", model.ErrUpstream, err)
+	}
+	if a.CheckedAt.IsZero() {
+		a.CheckedAt = time.Now()
+	}
+	return a, nil
+}
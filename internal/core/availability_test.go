@@ -0,0 +1,52 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubLibrary struct{ err error }
+
+func (l stubLibrary) CheckAvailability(_ context.Context, isbn string) (model.Availability, error) {
+	if l.err != nil {
+		return model.Availability{}, l.err
+	}
+	return model.Availability{Library: "City", Held: isbn == "9780134494166"}, nil
+}
+
+func TestBookAvailability(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Clean"), ISBN: util.GetPtr("9780134494166")})
+	require.NoError(t, err)
+	noISBN, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Notes")})
+	require.NoError(t, err)
+
+	_, err = svc.BookAvailability(ctx, b.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound, "no library configured")
+
+	svc.Library = stubLibrary{}
+	a, err := svc.BookAvailability(ctx, b.ID)
+	require.NoError(t, err)
+	assert.True(t, a.Held)
+	assert.True(t, a.Borrowable())
+	assert.False(t, a.CheckedAt.IsZero())
+
+	_, err = svc.BookAvailability(ctx, noISBN.ID)
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.BookAvailability(ctx, "missing")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	svc.Library = stubLibrary{err: errors.New("timeout")}
+	_, err = svc.BookAvailability(ctx, b.ID)
+	assert.ErrorIs(t, err, model.ErrUpstream)
+}
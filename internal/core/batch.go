@@ -0,0 +1,55 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"sync"
+)
+
+const (
+	maxBatchItems = 1000
+	batchWorkers  = 4 // bounds concurrent enrichment lookups
+)
+
+// CreateBooks creates each input independently, so some items may fail
+// while others succeed; results are in input order. Items repeating the ISBN
+// of an earlier item in the same batch fail with ErrConflict.
+func (s *Service) CreateBooks(ctx context.Context, inputs []model.CreateBookInput) ([]model.BatchResult, error) {
+	if len(inputs) == 0 || len(inputs) > maxBatchItems {
+		return nil, model.ErrValidation
+	}
+	results := make([]model.BatchResult, len(inputs))
+
+	// settle in-batch duplicates up front; the workers would race on them
+	seen := make(map[string]bool)
+	todo := make(chan int, len(inputs))
+	for i, in := range inputs {
+		if in.ISBN != nil && isbnKey(*in.ISBN) != "" {
+			key := isbnKey(*in.ISBN)
+			if seen[key] {
+				results[i].Err = model.ErrConflict
+				continue
+			}
+			seen[key] = true
+		}
+		todo <- i
+	}
+	close(todo)
+
+	var wg sync.WaitGroup
+	for range min(batchWorkers, len(inputs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range todo {
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+				results[i].Book, results[i].Err = s.CreateBook(ctx, inputs[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return results, nil
+}
@@ -0,0 +1,57 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/factory"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBooks_PartialSuccess(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: true})
+	svc.Authors = adapter.NewAuthorRepo()
+	ctx := context.Background()
+	_, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Existing"), ISBN: util.GetPtr("9780000000002")})
+	require.NoError(t, err)
+
+	res, err := svc.CreateBooks(ctx, []model.CreateBookInput{
+		{Title: util.GetPtr("One"), Authors: []string{"Jane Doe"}},
+		{Title: util.GetPtr("Dup of existing"), ISBN: util.GetPtr("978-0-00-000000-2")},
+		{},
+		{ISBN: util.GetPtr("9780134494166"), Enrich: true, Authors: []string{"jane doe"}},
+		{Title: util.GetPtr("Dup in batch"), ISBN: util.GetPtr("9780134494166")},
+	})
+	require.NoError(t, err)
+	require.Len(t, res, 5)
+	assert.NoError(t, res[0].Err)
+	assert.ErrorIs(t, res[1].Err, model.ErrConflict)
+	assert.ErrorIs(t, res[2].Err, model.ErrValidation)
+	require.NoError(t, res[3].Err)
+	assert.Equal(t, "Clean Architecture", res[3].Book.Title)
+	assert.Equal(t, res[0].Book.AuthorIDs[0], res[3].Book.AuthorIDs[0], "authors registered concurrently are shared")
+	assert.ErrorIs(t, res[4].Err, model.ErrConflict)
+}
+
+func TestCreateBooks_Limits(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	ctx := context.Background()
+	_, err := svc.CreateBooks(ctx, nil)
+	assert.ErrorIs(t, err, model.ErrValidation)
+
+	many := factory.New(1).CreateBookInputs(maxBatchItems + 1)
+	_, err = svc.CreateBooks(ctx, many)
+	assert.ErrorIs(t, err, model.ErrValidation)
+
+	res, err := svc.CreateBooks(ctx, many[:maxBatchItems])
+	require.NoError(t, err)
+	for _, r := range res {
+		require.NoError(t, r.Err)
+	}
+}
@@ -0,0 +1,247 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// BookshelfRepository keeps the shelves users create and the books on
+// every shelf, the reading status shelves included, which are never
+// created. Create fails with model.ErrConflict for an id the user has
+// already; Delete fails with model.ErrNotFound for an unknown shelf and
+// Remove for a book not on the shelf.
+type BookshelfRepository interface {
+	Create(ctx context.Context, s model.Bookshelf) (model.Bookshelf, error)
+	// List returns the shelves user created, by name.
+	List(ctx context.Context, user string) ([]model.Bookshelf, error)
+	Delete(ctx context.Context, user, id string) error
+	// Put adds a book to a shelf, keeping the time it was first added, and
+	// takes it off the shelves in off in the same change.
+	Put(ctx context.Context, user, shelfID string, it model.ShelfItem, off []string) error
+	Remove(ctx context.Context, user, shelfID, bookID string) error
+	// Items returns the books on a shelf, latest added first.
+	Items(ctx context.Context, user, shelfID string) ([]model.ShelfItem, error)
+}
+
+const (
+	maxShelfNameLen  = 100
+	maxShelfPageSize = 100
+)
+
+// statusShelves are the reading status shelves every user has.
+var statusShelves = []model.Bookshelf{
+	{ID: model.BookshelfWantToRead, Name: "Want to read", Status: true},
+	{ID: model.BookshelfReading, Name: "Reading", Status: true},
+	{ID: model.BookshelfRead, Name: "Read", Status: true},
+}
+
+// ListBookshelves returns the caller's shelves: the reading status shelves
+// first, then those they created by name.
+func (s *Service) ListBookshelves(ctx context.Context) ([]model.Bookshelf, error) {
+	if s.Bookshelves == nil {
+		return nil, fmt.Errorf("%w: shelves are not configured", model.ErrNotFound)
+	}
+	user := model.ActorFromContext(ctx)
+	created, err := s.Bookshelves.List(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	out := append(slices.Clone(statusShelves), created...)
+	for i := range out {
+		out[i].User = user
+		items, err := s.Bookshelves.Items(ctx, user, out[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		out[i].Books = len(items)
+	}
+	return out, nil
+}
+
+func (s *Service) GetBookshelf(ctx context.Context, id string) (model.Bookshelf, error) {
+	shelves, err := s.ListBookshelves(ctx)
+	if err != nil {
+		return model.Bookshelf{}, err
+	}
+	i := slices.IndexFunc(shelves, func(sh model.Bookshelf) bool { return sh.ID == id })
+	if i < 0 {
+		return model.Bookshelf{}, fmt.Errorf("%w: shelf %s", model.ErrNotFound, id)
+	}
+	return shelves[i], nil
+}
+
+// CreateBookshelf adds a shelf for the caller, with an id made from its
+// name.
+func (s *Service) CreateBookshelf(ctx context.Context, name string) (model.Bookshelf, error) {
+	if s.Bookshelves == nil {
+		return model.Bookshelf{}, fmt.Errorf("%w: shelves are not configured", model.ErrNotFound)
+	}
+	name = strings.TrimSpace(name)
+	id := shelfSlug(name)
+	var v validator
+	v.check(id != "", "name", "must contain a letter or digit")
+	v.check(len(name) <= maxShelfNameLen, "name", fmt.Sprintf("must be at most %d characters", maxShelfNameLen))
+	if err := v.err(); err != nil {
+		return model.Bookshelf{}, err
+	}
+	if isStatusShelf(id) {
+		return model.Bookshelf{}, fmt.Errorf("%w: %s is a reading status shelf", model.ErrConflict, id)
+	}
+	return s.Bookshelves.Create(ctx, model.Bookshelf{
+		ID:        id,
+		User:      model.ActorFromContext(ctx),
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+	})
+}
+
+// DeleteBookshelf removes one of the caller's shelves; the reading status
+// shelves stay.
+func (s *Service) DeleteBookshelf(ctx context.Context, id string) error {
+	if s.Bookshelves == nil {
+		return fmt.Errorf("%w: shelves are not configured", model.ErrNotFound)
+	}
+	if isStatusShelf(id) {
+		return fmt.Errorf("%w: %s is a reading status shelf", model.ErrConflict, id)
+	}
+	return s.Bookshelves.Delete(ctx, model.ActorFromContext(ctx), id)
+}
+
+// ShelveBook puts a book on one of the caller's shelves. On a reading
+// status shelf it replaces the book's reading status.
+func (s *Service) ShelveBook(ctx context.Context, shelfID, bookID string) error {
+	if _, err := s.GetBookshelf(ctx, shelfID); err != nil {
+		return err
+	}
+	b, err := s.getBook(ctx, bookID)
+	if err != nil {
+		return model.ErrNotFound
+	}
+	return s.shelve(ctx, shelfID, b.ID, time.Now().UTC())
+}
+
+// shelve puts a book on shelfID as of at, taking it off the other reading
+// status shelves when shelfID is one.
+func (s *Service) shelve(ctx context.Context, shelfID, bookID string, at time.Time) error {
+	var off []string
+	if isStatusShelf(shelfID) {
+		for _, sh := range statusShelves {
+			if sh.ID != shelfID {
+				off = append(off, sh.ID)
+			}
+		}
+	}
+	return s.Bookshelves.Put(ctx, model.ActorFromContext(ctx), shelfID, model.ShelfItem{BookID: bookID, AddedAt: at}, off)
+}
+
+// ImportReadingState records a reading state brought over from another
+// service for the caller. The book goes on st.Shelf, a reading status shelf
+// or one of the caller's own, made when they have none of that name, as of
+// its finish date if it has one. A finish date is also recorded as a
+// finished progress update at that time, so it counts in ReadingStats.
+func (s *Service) ImportReadingState(ctx context.Context, bookID string, st model.ReadingState) error {
+	if s.Bookshelves == nil {
+		return fmt.Errorf("%w: shelves are not configured", model.ErrNotFound)
+	}
+	if st.FinishedAt != nil && s.Progress == nil {
+		return fmt.Errorf("%w: reading progress is not tracked", model.ErrNotFound)
+	}
+	b, err := s.getBook(ctx, bookID)
+	if err != nil {
+		return model.ErrNotFound
+	}
+	at := time.Now().UTC()
+	if st.FinishedAt != nil {
+		at = st.FinishedAt.UTC()
+	}
+	if shelf := strings.TrimSpace(st.Shelf); shelf != "" {
+		id := shelf
+		if !isStatusShelf(id) {
+			id = shelfSlug(shelf)
+			_, err := s.GetBookshelf(ctx, id)
+			if errors.Is(err, model.ErrNotFound) {
+				_, err = s.CreateBookshelf(ctx, shelf)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err := s.shelve(ctx, id, b.ID, at); err != nil {
+			return err
+		}
+	}
+	if st.FinishedAt == nil {
+		return nil
+	}
+	pct := 100
+	return s.Progress.Add(ctx, model.ActorFromContext(ctx), model.ReadingProgress{
+		BookID: b.ID, Page: b.PageCount, Percent: &pct, Finished: true, At: at,
+	})
+}
+
+func (s *Service) UnshelveBook(ctx context.Context, shelfID, bookID string) error {
+	if _, err := s.GetBookshelf(ctx, shelfID); err != nil {
+		return err
+	}
+	return s.Bookshelves.Remove(ctx, model.ActorFromContext(ctx), shelfID, bookID)
+}
+
+// ShelfBooks returns a page of the books on one of the caller's shelves,
+// latest added first. Books in the trash are left out.
+func (s *Service) ShelfBooks(ctx context.Context, shelfID string, page, pageSize int) (model.Page[model.ShelvedBook], error) {
+	var v validator
+	v.check(page >= 1, "page", "must be at least 1")
+	v.check(pageSize >= 1 && pageSize <= maxShelfPageSize, "page_size", fmt.Sprintf("must be between 1 and %d", maxShelfPageSize))
+	if err := v.err(); err != nil {
+		return model.Page[model.ShelvedBook]{}, err
+	}
+	if _, err := s.GetBookshelf(ctx, shelfID); err != nil {
+		return model.Page[model.ShelvedBook]{}, err
+	}
+	items, err := s.Bookshelves.Items(ctx, model.ActorFromContext(ctx), shelfID)
+	if err != nil {
+		return model.Page[model.ShelvedBook]{}, err
+	}
+	books := make([]model.ShelvedBook, 0, len(items))
+	for _, it := range items {
+		b, err := s.GetBook(ctx, it.BookID)
+		if err != nil {
+			continue
+		}
+		books = append(books, model.ShelvedBook{Book: b, AddedAt: it.AddedAt})
+	}
+	out := model.Page[model.ShelvedBook]{Page: page, PageSize: pageSize, Total: len(books)}
+	if start := (page - 1) * pageSize; start < len(books) {
+		out.Data = books[start:min(start+pageSize, len(books))]
+	}
+	return out, nil
+}
+
+func isStatusShelf(id string) bool {
+	return slices.ContainsFunc(statusShelves, func(sh model.Bookshelf) bool { return sh.ID == id })
+}
+
+// shelfSlug makes a shelf id from a name: lower-case letters and digits,
+// with a dash for every run of anything else.
+func shelfSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,127 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShelfSlug(t *testing.T) {
+	assert.Equal(t, "summer-2026", shelfSlug("  Summer 2026! "))
+	assert.Equal(t, "sci-fi", shelfSlug("Sci--Fi"))
+	assert.Equal(t, "bücher", shelfSlug("Bücher"))
+	assert.Empty(t, shelfSlug("!!!"))
+}
+
+func TestBookshelves(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Bookshelves = adapter.NewBookshelfRepo()
+	alice := model.WithActor(context.Background(), "jwt:alice")
+	bob := model.WithActor(context.Background(), "jwt:bob")
+	dune, err := svc.CreateBook(alice, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	emma, err := svc.CreateBook(alice, model.CreateBookInput{Title: util.GetPtr("Emma")})
+	require.NoError(t, err)
+
+	shelves, err := svc.ListBookshelves(alice)
+	require.NoError(t, err)
+	require.Len(t, shelves, 3)
+	assert.Equal(t, model.BookshelfWantToRead, shelves[0].ID)
+	assert.True(t, shelves[0].Status)
+
+	_, err = svc.CreateBookshelf(alice, "!!")
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.CreateBookshelf(alice, "Read")
+	assert.ErrorIs(t, err, model.ErrConflict, "a status shelf")
+	summer, err := svc.CreateBookshelf(alice, " Summer 2026 ")
+	require.NoError(t, err)
+	assert.Equal(t, "summer-2026", summer.ID)
+	assert.Equal(t, "Summer 2026", summer.Name)
+	_, err = svc.CreateBookshelf(alice, "summer 2026")
+	assert.ErrorIs(t, err, model.ErrConflict)
+
+	require.NoError(t, svc.ShelveBook(alice, model.BookshelfWantToRead, dune.ID))
+	require.NoError(t, svc.ShelveBook(alice, model.BookshelfReading, dune.ID))
+	require.NoError(t, svc.ShelveBook(alice, summer.ID, dune.ID))
+	require.NoError(t, svc.ShelveBook(alice, summer.ID, emma.ID))
+	require.NoError(t, svc.ShelveBook(alice, summer.ID, dune.ID), "shelving again keeps the first add")
+	assert.ErrorIs(t, svc.ShelveBook(alice, summer.ID, "missing"), model.ErrNotFound)
+	assert.ErrorIs(t, svc.ShelveBook(bob, summer.ID, dune.ID), model.ErrNotFound, "shelves are personal")
+
+	shelves, err = svc.ListBookshelves(alice)
+	require.NoError(t, err)
+	require.Len(t, shelves, 4)
+	assert.Equal(t, []int{0, 1, 0, 2}, []int{shelves[0].Books, shelves[1].Books, shelves[2].Books, shelves[3].Books}, "a book has one reading status")
+
+	page, err := svc.ShelfBooks(alice, summer.ID, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "Emma", page.Data[0].Book.Title, "latest added first")
+	page, err = svc.ShelfBooks(alice, summer.ID, 3, 1)
+	require.NoError(t, err)
+	assert.Empty(t, page.Data)
+	_, err = svc.ShelfBooks(alice, summer.ID, 0, 500)
+	var ve model.ValidationErrors
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, ve, 2)
+
+	require.NoError(t, svc.DeleteBook(alice, emma.ID))
+	page, err = svc.ShelfBooks(alice, summer.ID, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total, "books in the trash are left out")
+
+	require.NoError(t, svc.UnshelveBook(alice, summer.ID, dune.ID))
+	assert.ErrorIs(t, svc.UnshelveBook(alice, summer.ID, dune.ID), model.ErrNotFound)
+	assert.ErrorIs(t, svc.DeleteBookshelf(alice, model.BookshelfRead), model.ErrConflict)
+	require.NoError(t, svc.DeleteBookshelf(alice, summer.ID))
+	_, err = svc.GetBookshelf(alice, summer.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestImportReadingState(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Bookshelves = adapter.NewBookshelfRepo()
+	svc.Progress = adapter.NewProgressRepo()
+	alice := model.WithActor(context.Background(), "jwt:alice")
+	dune, err := svc.CreateBook(alice, model.CreateBookInput{Title: util.GetPtr("Dune"), PageCount: util.GetPtr(412)})
+	require.NoError(t, err)
+	emma, err := svc.CreateBook(alice, model.CreateBookInput{Title: util.GetPtr("Emma")})
+	require.NoError(t, err)
+	_, err = svc.UpdateProgress(alice, dune.ID, util.GetPtr(100), nil)
+	require.NoError(t, err)
+
+	finished := time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, svc.ImportReadingState(alice, dune.ID, model.ReadingState{Shelf: model.BookshelfRead, FinishedAt: &finished}))
+	require.NoError(t, svc.ImportReadingState(alice, emma.ID, model.ReadingState{Shelf: "Did not finish"}))
+	assert.ErrorIs(t, svc.ImportReadingState(alice, "missing", model.ReadingState{Shelf: model.BookshelfRead}), model.ErrNotFound)
+
+	read, err := svc.ShelfBooks(alice, model.BookshelfRead, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, read.Data, 1)
+	assert.Equal(t, finished, read.Data[0].AddedAt)
+	dnf, err := svc.GetBookshelf(alice, "did-not-finish")
+	require.NoError(t, err, "a shelf made for an unknown status")
+	assert.Equal(t, 1, dnf.Books)
+
+	h, err := svc.ProgressHistory(alice, dune.ID)
+	require.NoError(t, err)
+	require.Len(t, h, 2)
+	assert.True(t, h[0].Finished, "the imported finish comes first")
+	assert.Equal(t, finished, h[0].At)
+	assert.Equal(t, 412, *h[0].Page)
+	stats, err := svc.ReadingStats(alice, 1, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []model.YearBooks{{Year: 2023, Books: 1}}, stats.FinishedPerYear)
+
+	svc.Progress = nil
+	assert.ErrorIs(t, svc.ImportReadingState(alice, emma.ID, model.ReadingState{FinishedAt: &finished}), model.ErrNotFound)
+}
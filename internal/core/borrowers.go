@@ -0,0 +1,185 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BorrowerRepository keeps the borrower directory. Create and Update fail
+// with model.ErrConflict for an email or external ID another borrower has;
+// Get, GetByExternalID, Update and Delete fail with model.ErrNotFound for
+// an unknown one.
+type BorrowerRepository interface {
+	Create(ctx context.Context, b model.Borrower) (model.Borrower, error)
+	Get(ctx context.Context, id string) (model.Borrower, error)
+	GetByExternalID(ctx context.Context, externalID string) (model.Borrower, error)
+	Update(ctx context.Context, b model.Borrower) (model.Borrower, error)
+	Delete(ctx context.Context, id string) error
+	// List returns a page of the matching borrowers by name.
+	List(ctx context.Context, q model.BorrowerQuery) (model.Page[model.Borrower], error)
+}
+
+const (
+	maxExternalIDLen    = 100
+	maxBorrowerPageSize = 100
+)
+
+func (s *Service) CreateBorrower(ctx context.Context, b model.Borrower) (model.Borrower, error) {
+	if s.Borrowers == nil {
+		return model.Borrower{}, fmt.Errorf("%w: no borrower directory configured", model.ErrNotFound)
+	}
+	b, err := normalizeBorrower(b)
+	if err != nil {
+		return model.Borrower{}, err
+	}
+	b.ID = uuid.NewString()
+	b.Tenant = model.TenantFromContext(ctx)
+	b.CreatedAt = time.Now().UTC()
+	b.UpdatedAt = b.CreatedAt
+	return s.Borrowers.Create(ctx, b)
+}
+
+func (s *Service) GetBorrower(ctx context.Context, id string) (model.Borrower, error) {
+	if s.Borrowers == nil {
+		return model.Borrower{}, fmt.Errorf("%w: no borrower directory configured", model.ErrNotFound)
+	}
+	return s.Borrowers.Get(ctx, id)
+}
+
+// UpdateBorrower replaces the details of a borrower.
+func (s *Service) UpdateBorrower(ctx context.Context, b model.Borrower) (model.Borrower, error) {
+	old, err := s.GetBorrower(ctx, b.ID)
+	if err != nil {
+		return model.Borrower{}, err
+	}
+	if b, err = normalizeBorrower(b); err != nil {
+		return model.Borrower{}, err
+	}
+	b.Tenant, b.CreatedAt = old.Tenant, old.CreatedAt
+	b.UpdatedAt = time.Now().UTC()
+	return s.Borrowers.Update(ctx, b)
+}
+
+func (s *Service) ListBorrowers(ctx context.Context, q model.BorrowerQuery) (model.Page[model.Borrower], error) {
+	if s.Borrowers == nil {
+		return model.Page[model.Borrower]{}, fmt.Errorf("%w: no borrower directory configured", model.ErrNotFound)
+	}
+	var v validator
+	v.check(q.Page >= 1, "page", "must be at least 1")
+	v.check(q.PageSize >= 1 && q.PageSize <= maxBorrowerPageSize, "page_size", fmt.Sprintf("must be between 1 and %d", maxBorrowerPageSize))
+	if err := v.err(); err != nil {
+		return model.Page[model.Borrower]{}, err
+	}
+	q.Q = strings.TrimSpace(q.Q)
+	return s.Borrowers.List(ctx, q)
+}
+
+// DeleteBorrower removes a borrower who has no active loans or holds and
+// owes no fees.
+func (s *Service) DeleteBorrower(ctx context.Context, id string) error {
+	if _, err := s.GetBorrower(ctx, id); err != nil {
+		return err
+	}
+	if s.Loans != nil {
+		active, err := s.Loans.List(ctx, model.LoanQuery{Borrower: id, Status: model.LoanActive, Now: time.Now().UTC()})
+		if err != nil {
+			return err
+		}
+		if len(active) > 0 {
+			return fmt.Errorf("%w: borrower %s has %d active loans", model.ErrConflict, id, len(active))
+		}
+	}
+	if s.Holds != nil {
+		holds, err := s.Holds.ListByBorrower(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(holds) > 0 {
+			return fmt.Errorf("%w: borrower %s has %d holds", model.ErrConflict, id, len(holds))
+		}
+	}
+	if s.finesOn() == nil {
+		a, err := s.FeeAccount(ctx, id)
+		if err != nil {
+			return err
+		}
+		if a.Balance.Amount != 0 {
+			return fmt.Errorf("%w: borrower %s owes fees", model.ErrConflict, id)
+		}
+	}
+	return s.Borrowers.Delete(ctx, id)
+}
+
+// MergeBorrowers moves the loans, holds and fees of the duplicate borrower
+// from to into, fills in what into lacks from it and deletes it.
+func (s *Service) MergeBorrowers(ctx context.Context, into, from string) (model.Borrower, error) {
+	b, err := s.GetBorrower(ctx, into)
+	if err != nil {
+		return model.Borrower{}, err
+	}
+	if from == into {
+		return model.Borrower{}, &model.FieldError{Field: "from", Reason: "must be another borrower"}
+	}
+	dup, err := s.Borrowers.Get(ctx, from)
+	if err != nil {
+		return model.Borrower{}, &model.FieldError{Field: "from", Reason: "must be a registered borrower"}
+	}
+	if s.Loans != nil {
+		if _, err := s.Loans.Reassign(ctx, from, into); err != nil {
+			return model.Borrower{}, err
+		}
+	}
+	if s.Holds != nil {
+		if _, err := s.Holds.Reassign(ctx, from, into); err != nil {
+			return model.Borrower{}, err
+		}
+	}
+	if s.Fees != nil {
+		if _, err := s.Fees.Reassign(ctx, from, into); err != nil {
+			return model.Borrower{}, err
+		}
+	}
+	if err := s.Borrowers.Delete(ctx, from); err != nil && !errors.Is(err, model.ErrNotFound) {
+		return model.Borrower{}, err
+	}
+	if (b.Email != "" || dup.Email == "") && (b.ExternalID != "" || dup.ExternalID == "") {
+		return b, nil
+	}
+	b.Email = cmp.Or(b.Email, dup.Email)
+	b.ExternalID = cmp.Or(b.ExternalID, dup.ExternalID)
+	b.UpdatedAt = time.Now().UTC()
+	return s.Borrowers.Update(ctx, b)
+}
+
+// checkBorrower reports an unknown borrower when the directory is kept;
+// without one, borrowers are free text.
+func (s *Service) checkBorrower(ctx context.Context, id string) error {
+	if s.Borrowers == nil {
+		return nil
+	}
+	_, err := s.Borrowers.Get(ctx, id)
+	return err
+}
+
+func normalizeBorrower(b model.Borrower) (model.Borrower, error) {
+	b.Name = strings.TrimSpace(b.Name)
+	b.Email = strings.TrimSpace(b.Email)
+	b.ExternalID = strings.TrimSpace(b.ExternalID)
+	var v validator
+	v.check(b.Name != "", "name", "must not be empty")
+	v.check(len(b.Name) <= maxBorrowerLen, "name", fmt.Sprintf("must be at most %d characters", maxBorrowerLen))
+	if b.Email != "" {
+		a, err := mail.ParseAddress(b.Email)
+		v.check(err == nil && a.Address == b.Email, "email", "must be an email address")
+	}
+	v.check(len(b.ExternalID) <= maxExternalIDLen, "external_id", fmt.Sprintf("must be at most %d characters", maxExternalIDLen))
+	return b, v.err()
+}
@@ -0,0 +1,88 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBorrowers(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Loans = adapter.NewLoanRepo()
+	svc.Holds = adapter.NewHoldRepo()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	_, err = svc.CreateBorrower(ctx, model.Borrower{Name: "Ada"})
+	assert.ErrorIs(t, err, model.ErrNotFound, "no directory")
+	_, err = svc.CheckoutBook(ctx, b.ID, "card-1", nil)
+	require.NoError(t, err, "borrowers are free text without a directory")
+
+	svc = NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Loans = adapter.NewLoanRepo()
+	svc.Holds = adapter.NewHoldRepo()
+	svc.Borrowers = adapter.NewBorrowerRepo()
+	b, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+
+	_, err = svc.CreateBorrower(ctx, model.Borrower{Name: " ", Email: "ada", ExternalID: string(make([]byte, 101))})
+	var ve model.ValidationErrors
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, ve, 3)
+	ada, err := svc.CreateBorrower(ctx, model.Borrower{Name: " Ada Lovelace ", Email: "ada@example.org"})
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", ada.Name)
+	_, err = svc.CreateBorrower(ctx, model.Borrower{Name: "Ada", Email: "ADA@example.org"})
+	assert.ErrorIs(t, err, model.ErrConflict, "emails are unique case-insensitively")
+	dup, err := svc.CreateBorrower(ctx, model.Borrower{Name: "A. Lovelace", ExternalID: "2077"})
+	require.NoError(t, err)
+
+	_, err = svc.CheckoutBook(ctx, b.ID, "card-1", nil)
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "borrower", ve[0].Field)
+	l, err := svc.CheckoutBook(ctx, b.ID, dup.ID, nil)
+	require.NoError(t, err)
+	_, err = svc.PlaceHold(ctx, b.ID, "card-1")
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.PlaceHold(ctx, b.ID, ada.ID)
+	require.NoError(t, err)
+
+	page, err := svc.ListBorrowers(ctx, model.BorrowerQuery{Q: "lovelace", Page: 1, PageSize: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, dup.ID, page.Data[0].ID, "by name")
+	_, err = svc.ListBorrowers(ctx, model.BorrowerQuery{Page: 0, PageSize: 500})
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, ve, 2)
+
+	assert.ErrorIs(t, svc.DeleteBorrower(ctx, dup.ID), model.ErrConflict, "active loan")
+	_, err = svc.MergeBorrowers(ctx, ada.ID, ada.ID)
+	assert.ErrorIs(t, err, model.ErrValidation)
+	merged, err := svc.MergeBorrowers(ctx, ada.ID, dup.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.org", merged.Email)
+	assert.Equal(t, "2077", merged.ExternalID, "filled in from the duplicate")
+	_, err = svc.GetBorrower(ctx, dup.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	loans, err := svc.Loans.List(ctx, model.LoanQuery{Borrower: ada.ID, Now: time.Now().UTC()})
+	require.NoError(t, err)
+	require.Len(t, loans, 1)
+	assert.Equal(t, l.ID, loans[0].ID)
+
+	merged.Name = "Augusta Ada King"
+	merged, err = svc.UpdateBorrower(ctx, merged)
+	require.NoError(t, err)
+	assert.Equal(t, ada.CreatedAt, merged.CreatedAt)
+	_, err = svc.ReturnLoan(ctx, l.ID)
+	require.NoError(t, err)
+	assert.ErrorIs(t, svc.DeleteBorrower(ctx, ada.ID), model.ErrConflict, "holds the returned copy")
+}
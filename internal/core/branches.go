@@ -0,0 +1,166 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// BranchRepository keeps the library's branches. Create fails with
+// model.ErrConflict for a taken id, Get, Update and Delete with
+// model.ErrNotFound for an unknown one.
+type BranchRepository interface {
+	Create(ctx context.Context, b model.Branch) (model.Branch, error)
+	Get(ctx context.Context, id string) (model.Branch, error)
+	Update(ctx context.Context, b model.Branch) (model.Branch, error)
+	// List returns the branches by id.
+	List(ctx context.Context) ([]model.Branch, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// branchID is the form of a branch id: short enough for tokens and query
+// strings, and free of characters that need escaping in either.
+var branchID = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+func (s *Service) CreateBranch(ctx context.Context, b model.Branch) (model.Branch, error) {
+	if s.Branches == nil {
+		return model.Branch{}, fmt.Errorf("%w: no branches configured", model.ErrNotFound)
+	}
+	if !branchID.MatchString(b.ID) {
+		return model.Branch{}, &model.FieldError{Field: "id", Reason: "must be 1 to 32 lowercase letters, digits or dashes, not starting with a dash"}
+	}
+	b.Name, b.Address = strings.TrimSpace(b.Name), strings.TrimSpace(b.Address)
+	if b.Name == "" {
+		return model.Branch{}, &model.FieldError{Field: "name", Reason: "must not be empty"}
+	}
+	b.Tenant = model.TenantFromContext(ctx)
+	b.CreatedAt = time.Now().UTC()
+	return s.Branches.Create(ctx, b)
+}
+
+func (s *Service) GetBranch(ctx context.Context, id string) (model.Branch, error) {
+	if s.Branches == nil {
+		return model.Branch{}, fmt.Errorf("%w: branch %s", model.ErrNotFound, id)
+	}
+	return s.Branches.Get(ctx, id)
+}
+
+func (s *Service) ListBranches(ctx context.Context) ([]model.Branch, error) {
+	if s.Branches == nil {
+		return nil, nil
+	}
+	return s.Branches.List(ctx)
+}
+
+// UpdateBranch changes the name or address of a branch; its id, which
+// books and tokens refer to, stays.
+func (s *Service) UpdateBranch(ctx context.Context, id string, p model.BranchPatch) (model.Branch, error) {
+	b, err := s.GetBranch(ctx, id)
+	if err != nil {
+		return model.Branch{}, err
+	}
+	if p.Name != nil {
+		if b.Name = strings.TrimSpace(*p.Name); b.Name == "" {
+			return model.Branch{}, &model.FieldError{Field: "name", Reason: "must not be empty"}
+		}
+	}
+	if p.Address != nil {
+		b.Address = strings.TrimSpace(*p.Address)
+	}
+	return s.Branches.Update(ctx, b)
+}
+
+// DeleteBranch removes a branch that holds no copies, counting those of
+// books in the trash, which may be restored.
+func (s *Service) DeleteBranch(ctx context.Context, id string) error {
+	if _, err := s.GetBranch(ctx, id); err != nil {
+		return err
+	}
+	n, err := s.Repo.Count(ctx, model.ListQuery{Branch: &id, IncludeDeleted: true})
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("%w: branch %s still holds copies of %d books", model.ErrConflict, id, n)
+	}
+	return s.Branches.Delete(ctx, id)
+}
+
+// SetCopies sets how many copies of a book a branch holds; 0 takes the
+// book off the branch.
+func (s *Service) SetCopies(ctx context.Context, bookID, branch string, copies int) (model.Book, error) {
+	if copies < 0 {
+		return model.Book{}, &model.FieldError{Field: "copies", Reason: "must not be negative"}
+	}
+	if _, err := s.GetBranch(ctx, branch); err != nil {
+		return model.Book{}, err
+	}
+	b, err := s.getBook(ctx, bookID)
+	if err != nil {
+		return model.Book{}, model.ErrNotFound
+	}
+	if len(b.Items) > 0 {
+		return model.Book{}, errTracksItems(b.ID)
+	}
+	before := b
+	b.Copies = withCopies(b.Copies, branch, copies)
+	return s.updateBook(ctx, model.AuditUpdate, before, b)
+}
+
+// TransferCopies moves copies of a book between two branches in one
+// change of the book, so both inventories move together or not at all; a
+// concurrent change of the book makes it fail with model.ErrConflict.
+func (s *Service) TransferCopies(ctx context.Context, t model.Transfer) (model.Book, error) {
+	if t.Copies < 1 {
+		return model.Book{}, &model.FieldError{Field: "copies", Reason: "must be at least 1"}
+	}
+	if t.From == t.To {
+		return model.Book{}, &model.FieldError{Field: "to", Reason: "must differ from from"}
+	}
+	for _, id := range []string{t.From, t.To} {
+		if _, err := s.GetBranch(ctx, id); err != nil {
+			return model.Book{}, err
+		}
+	}
+	b, err := s.getBook(ctx, t.BookID)
+	if err != nil {
+		return model.Book{}, model.ErrNotFound
+	}
+	if len(b.Items) > 0 {
+		return model.Book{}, errTracksItems(b.ID)
+	}
+	if have := b.Copies[t.From]; have < t.Copies {
+		return model.Book{}, fmt.Errorf("%w: branch %s holds %d copies", model.ErrConflict, t.From, have)
+	}
+	before := b
+	b.Copies = withCopies(b.Copies, t.From, b.Copies[t.From]-t.Copies)
+	b.Copies = withCopies(b.Copies, t.To, b.Copies[t.To]+t.Copies)
+	return s.updateBook(ctx, model.AuditUpdate, before, b)
+}
+
+// errTracksItems refuses to count the copies of a book that tracks items,
+// whose branches count them.
+func errTracksItems(id string) error {
+	return fmt.Errorf("%w: book %s tracks items; add or remove them instead", model.ErrConflict, id)
+}
+
+// withCopies returns a copy of copies with branch set to n, dropping it at
+// 0; the result is nil when no branch is left.
+func withCopies(copies map[string]int, branch string, n int) map[string]int {
+	out := make(map[string]int, len(copies)+1)
+	for k, v := range copies {
+		out[k] = v
+	}
+	if n > 0 {
+		out[branch] = n
+	} else {
+		delete(out, branch)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
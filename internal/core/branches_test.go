@@ -0,0 +1,82 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranches(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	_, err := svc.CreateBranch(ctx, model.Branch{ID: "east", Name: "East"})
+	assert.ErrorIs(t, err, model.ErrNotFound, "no branch repository")
+
+	svc.Branches = adapter.NewBranchRepo()
+	for _, b := range []model.Branch{{ID: "East", Name: "East"}, {ID: "-east", Name: "East"}, {ID: "east", Name: " "}} {
+		_, err = svc.CreateBranch(ctx, b)
+		var fe *model.FieldError
+		assert.ErrorAs(t, err, &fe, "%+v", b)
+	}
+	east, err := svc.CreateBranch(ctx, model.Branch{ID: "east", Name: " East ", Address: "1 Main St"})
+	require.NoError(t, err)
+	assert.Equal(t, "East", east.Name)
+	assert.False(t, east.CreatedAt.IsZero())
+	_, err = svc.CreateBranch(ctx, model.Branch{ID: "east", Name: "Again"})
+	assert.ErrorIs(t, err, model.ErrConflict)
+	_, err = svc.CreateBranch(ctx, model.Branch{ID: "west", Name: "West"})
+	require.NoError(t, err)
+	branches, err := svc.ListBranches(ctx)
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+	assert.Equal(t, "east", branches[0].ID)
+
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Other")})
+	require.NoError(t, err)
+
+	_, err = svc.SetCopies(ctx, b.ID, "north", 1)
+	assert.ErrorIs(t, err, model.ErrNotFound, "unknown branch")
+	_, err = svc.SetCopies(ctx, b.ID, "east", -1)
+	assert.Error(t, err)
+	b, err = svc.SetCopies(ctx, b.ID, "east", 3)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"east": 3}, b.Copies)
+
+	page, err := svc.ListBooks(ctx, model.ListQuery{Branch: util.GetPtr("east")})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, b.ID, page.Data[0].ID)
+	assert.Equal(t, 1, page.Total)
+
+	_, err = svc.TransferCopies(ctx, model.Transfer{BookID: b.ID, From: "east", To: "west", Copies: 4})
+	assert.ErrorIs(t, err, model.ErrConflict, "east holds only 3")
+	_, err = svc.TransferCopies(ctx, model.Transfer{BookID: b.ID, From: "east", To: "east", Copies: 1})
+	assert.Error(t, err)
+	b, err = svc.TransferCopies(ctx, model.Transfer{BookID: b.ID, From: "east", To: "west", Copies: 3})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"west": 3}, b.Copies, "an emptied branch is dropped")
+
+	east, err = svc.UpdateBranch(ctx, "east", model.BranchPatch{Name: util.GetPtr(" East Side ")})
+	require.NoError(t, err)
+	assert.Equal(t, "East Side", east.Name)
+	assert.Equal(t, "1 Main St", east.Address, "left out, kept")
+	_, err = svc.UpdateBranch(ctx, "east", model.BranchPatch{Name: util.GetPtr("")})
+	var fe *model.FieldError
+	assert.ErrorAs(t, err, &fe)
+	_, err = svc.UpdateBranch(ctx, "north", model.BranchPatch{Address: util.GetPtr("2 Side St")})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	assert.NoError(t, svc.DeleteBranch(ctx, "east"))
+	assert.ErrorIs(t, svc.DeleteBranch(ctx, "east"), model.ErrNotFound)
+	require.NoError(t, svc.DeleteBook(ctx, b.ID))
+	assert.ErrorIs(t, svc.DeleteBranch(ctx, "west"), model.ErrConflict, "trashed books keep their copies")
+}
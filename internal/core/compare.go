@@ -0,0 +1,54 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"strings"
+)
+
+const maxCompareISBNs = 10000
+
+// CompareISBNs checks which of the given ISBNs are in the catalog. ISBNs are
+// compared without dashes and spaces; blank entries are skipped.
+func (s *Service) CompareISBNs(ctx context.Context, isbns []string) (model.CatalogComparison, error) {
+	if len(isbns) == 0 || len(isbns) > maxCompareISBNs {
+		return model.CatalogComparison{}, model.ErrValidation
+	}
+	out := model.CatalogComparison{Present: []model.ISBNMatch{}, Missing: []string{}, Duplicated: []string{}}
+	seen := make(map[string]int) // normalized ISBN -> times submitted
+	for _, raw := range isbns {
+		isbn := strings.TrimSpace(raw)
+		key := isbnKey(isbn)
+		if key == "" {
+			continue
+		}
+		seen[key]++
+		switch seen[key] {
+		case 1:
+		case 2:
+			out.Duplicated = append(out.Duplicated, isbn)
+			continue
+		default:
+			continue
+		}
+		b, err := s.Repo.GetByISBN(ctx, s.owner(ctx), key)
+		if err != nil || b.DeletedAt != nil {
+			out.Missing = append(out.Missing, isbn)
+			continue
+		}
+		out.Present = append(out.Present, model.ISBNMatch{ISBN: isbn, Book: b})
+	}
+	if len(seen) == 0 {
+		return model.CatalogComparison{}, model.ErrValidation
+	}
+	return out, nil
+}
+
+// isbnKey is the form an ISBN is stored and deduplicated in: the canonical
+// ISBN-13 when it is valid, otherwise the ISBN without dashes and spaces.
+func isbnKey(isbn string) string {
+	if canon, err := NormalizeISBN(isbn); err == nil {
+		return canon
+	}
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(isbn))
+}
@@ -0,0 +1,34 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareISBNs(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	ctx := context.Background()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Clean Architecture"), ISBN: util.GetPtr("9780134494166")})
+	require.NoError(t, err)
+
+	res, err := svc.CompareISBNs(ctx, []string{"978-0-13-449416-6", "9780441013593", " ", "9780134494166", "978 0441013593", "9780441013593"})
+	require.NoError(t, err)
+	require.Len(t, res.Present, 1)
+	assert.Equal(t, "978-0-13-449416-6", res.Present[0].ISBN)
+	assert.Equal(t, b.ID, res.Present[0].Book.ID)
+	assert.Equal(t, []string{"9780441013593"}, res.Missing)
+	assert.Equal(t, []string{"9780134494166", "978 0441013593"}, res.Duplicated)
+
+	_, err = svc.CompareISBNs(ctx, nil)
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.CompareISBNs(ctx, []string{"", " "})
+	assert.ErrorIs(t, err, model.ErrValidation)
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+)
+
+// ConsistencyChecker is implemented by repositories that can validate their
+// internal indexes, and repair them when asked to.
+type ConsistencyChecker interface {
+	CheckConsistency(ctx context.Context, repair bool) (model.ConsistencyReport, error)
+}
+
+// VerifyRepository runs the repository's consistency check, if it has one.
+// Without repair any issue fails the check; with repair only issues that
+// could not be repaired do.
+func VerifyRepository(ctx context.Context, repo BookRepository, repair bool) (model.ConsistencyReport, error) {
+	checker, ok := repo.(ConsistencyChecker)
+	if !ok {
+		return model.ConsistencyReport{}, nil
+	}
+	report, err := checker.CheckConsistency(ctx, repair)
+	if err != nil {
+		return report, err
+	}
+	if n := report.Unrepaired(); n > 0 {
+		return report, fmt.Errorf("%w: %d unresolved repository issue(s)", model.ErrInconsistent, n)
+	}
+	return report, nil
+}
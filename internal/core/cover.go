@@ -0,0 +1,243 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // decoders for uploaded covers
+	"image/jpeg"
+	_ "image/png"
+	"strings"
+)
+
+// BlobStore keeps binary objects such as cover images under slash
+// separated keys.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get returns the object and its content type; it fails with an error
+	// matching model.ErrNotFound when key is absent.
+	Get(ctx context.Context, key string) ([]byte, string, error)
+	// Delete removes key; deleting an absent key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// CoverFetcher downloads a remote cover image.
+type CoverFetcher interface {
+	FetchCover(ctx context.Context, url string) ([]byte, error)
+}
+
+// coverWidths bounds the width of each stored rendition; smaller images
+// are not scaled up.
+var coverWidths = map[model.CoverSize]int{
+	model.CoverSmall:  90,
+	model.CoverMedium: 240,
+	model.CoverLarge:  600,
+}
+
+// maxCoverPixels rejects uploads whose decoded image would take hundreds
+// of megabytes, however small the file.
+const maxCoverPixels = 40_000_000
+
+// CoverPath is where the API serves the cover of book id; uploading a
+// cover points the book's cover URL at it.
+func CoverPath(id string) string {
+	return "/api/v1/books/" + id + "/cover"
+}
+
+// UploadCover stores a JPEG, PNG or GIF image as the book's cover, scaled
+// to every CoverSize, and points the book's cover URL at CoverPath.
+func (s *Service) UploadCover(ctx context.Context, id string, data []byte) (model.Book, error) {
+	if s.Covers == nil {
+		return model.Book{}, fmt.Errorf("%w: no cover store configured", model.ErrNotFound)
+	}
+	b, err := s.getBook(ctx, id)
+	if err != nil {
+		return model.Book{}, model.ErrNotFound
+	}
+	if err := s.storeCover(ctx, id, data); err != nil {
+		return model.Book{}, err
+	}
+	path := CoverPath(id)
+	if b.CoverURL != nil && *b.CoverURL == path {
+		return b, nil
+	}
+	return s.PatchBook(ctx, id, model.BookPatch{CoverURL: &path})
+}
+
+// storeCover scales an image to every CoverSize and stores the renditions.
+func (s *Service) storeCover(ctx context.Context, id string, data []byte) error {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return &model.FieldError{Field: "file", Reason: "must be a JPEG, PNG or GIF image"}
+	}
+	if cfg.Width*cfg.Height > maxCoverPixels {
+		return &model.FieldError{Field: "file", Reason: fmt.Sprintf("must not exceed %d pixels", maxCoverPixels)}
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return &model.FieldError{Field: "file", Reason: "is not a readable image"}
+	}
+	for size, width := range coverWidths {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, thumbnail(img, width), &jpeg.Options{Quality: 85}); err != nil {
+			return err
+		}
+		if err := s.Covers.Put(ctx, coverKey(id, size), buf.Bytes(), "image/jpeg"); err != nil {
+			return fmt.Errorf("store cover: %w", err)
+		}
+	}
+	return nil
+}
+
+// cacheCover downloads the cover enrichment found for b and stores it like
+// an upload, pointing b's cover URL at CoverPath so clients stop
+// hotlinking it. It is best effort: on failure b keeps the remote URL,
+// which BookCover redirects to.
+func (s *Service) cacheCover(ctx context.Context, b *model.Book, res model.EnrichedBook) {
+	if s.CoverCache == nil || s.Covers == nil || res.CoverURL == nil || b.CoverURL == nil || *b.CoverURL != *res.CoverURL {
+		return
+	}
+	data, err := s.CoverCache.FetchCover(ctx, *res.CoverURL)
+	if err != nil {
+		return
+	}
+	if err := s.storeCover(ctx, b.ID, data); err != nil {
+		return
+	}
+	path := CoverPath(b.ID)
+	b.CoverURL = &path
+}
+
+// BookCover returns the stored cover of the book in the given size. A
+// book without a stored cover but with a remote cover URL gets that URL,
+// in the requested size for Open Library covers.
+func (s *Service) BookCover(ctx context.Context, id string, size model.CoverSize) (model.Cover, error) {
+	b, err := s.getBook(ctx, id)
+	if err != nil {
+		return model.Cover{}, model.ErrNotFound
+	}
+	if s.Covers != nil {
+		data, ct, err := s.Covers.Get(ctx, coverKey(id, size))
+		if err == nil {
+			return model.Cover{Data: data, ContentType: ct}, nil
+		}
+		if !errors.Is(err, model.ErrNotFound) {
+			return model.Cover{}, fmt.Errorf("load cover: %w", err)
+		}
+	}
+	if remoteCover(b) {
+		return model.Cover{URL: openLibraryCoverSize(*b.CoverURL, size)}, nil
+	}
+	return model.Cover{}, fmt.Errorf("%w: book has no cover", model.ErrNotFound)
+}
+
+// Inline thumbnails are capped so listings stay small: a stored small cover
+// over maxInlineCoverBytes is never inlined, and a page stops inlining once
+// its thumbnails reach maxInlineCoversBytes.
+const (
+	maxInlineCoverBytes  = 16 << 10
+	maxInlineCoversBytes = 256 << 10
+)
+
+// CoverThumbnails returns the small stored covers of books, by book id, for
+// inlining into a listing of them. Only covers kept in the cover store are
+// returned, within the inline size caps; nothing is downloaded, and books
+// without a stored cover are left out.
+func (s *Service) CoverThumbnails(ctx context.Context, books []model.Book) (map[string]model.Cover, error) {
+	thumbs := map[string]model.Cover{}
+	if s.Covers == nil {
+		return thumbs, nil
+	}
+	total := 0
+	for _, b := range books {
+		if b.CoverURL == nil || *b.CoverURL != CoverPath(b.ID) {
+			continue
+		}
+		data, ct, err := s.Covers.Get(ctx, coverKey(b.ID, model.CoverSmall))
+		if errors.Is(err, model.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("load cover: %w", err)
+		}
+		if len(data) > maxInlineCoverBytes {
+			continue
+		}
+		if total+len(data) > maxInlineCoversBytes {
+			break
+		}
+		total += len(data)
+		thumbs[b.ID] = model.Cover{Data: data, ContentType: ct}
+	}
+	return thumbs, nil
+}
+
+// deleteCovers drops the stored covers of a deleted book. It is best
+// effort: a leftover cover is unreachable once the book is gone.
+func (s *Service) deleteCovers(ctx context.Context, id string) {
+	if s.Covers == nil {
+		return
+	}
+	for size := range coverWidths {
+		_ = s.Covers.Delete(ctx, coverKey(id, size))
+	}
+}
+
+func coverKey(id string, size model.CoverSize) string {
+	return "covers/" + id + "/" + string(size) + ".jpg"
+}
+
+// openLibraryCoverSize rewrites the size suffix of a covers.openlibrary.org
+// URL, e.g. .../b/id/42-L.jpg; other URLs are returned unchanged.
+func openLibraryCoverSize(u string, size model.CoverSize) string {
+	if !strings.HasPrefix(u, "https://covers.openlibrary.org/") && !strings.HasPrefix(u, "http://covers.openlibrary.org/") {
+		return u
+	}
+	for _, from := range []string{"-S.jpg", "-M.jpg", "-L.jpg"} {
+		if base, ok := strings.CutSuffix(u, from); ok {
+			return base + "-" + string(size) + ".jpg"
+		}
+	}
+	return u
+}
+
+// thumbnail scales img down to at most width pixels wide, averaging the
+// source pixels under each target pixel, onto a white background so
+// transparent images encode well as JPEG.
+func thumbnail(img image.Image, width int) *image.RGBA {
+	sb := img.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	if sw <= width {
+		dst := image.NewRGBA(image.Rect(0, 0, sw, sh))
+		draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+		draw.Draw(dst, dst.Bounds(), img, sb.Min, draw.Over)
+		return dst
+	}
+	dw := width
+	dh := max(1, sh*dw/sw)
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := sb.Min.Y+y*sh/dh, sb.Min.Y+max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := sb.Min.X+x*sw/dw, sb.Min.X+max((x+1)*sw/dw, x*sw/dw+1)
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					// composite onto white
+					r += uint64(cr + 0xffff - ca)
+					g += uint64(cg + 0xffff - ca)
+					b += uint64(cb + 0xffff - ca)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n >> 8), G: uint8(g / n >> 8), B: uint8(b / n >> 8), A: 0xff})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,204 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CoverPrefetcher downloads the remote covers of stored books into the
+// cover store in the background, so cover views after a bulk import do not
+// each wait for the source. Downloads run on a fixed number of workers and
+// are spaced per host, which keeps a large catalog from hammering a single
+// cover service. One run goes at a time. Attach it to the service with
+// Service.Prefetch; it needs Covers and CoverCache.
+type CoverPrefetcher struct {
+	svc          *Service
+	log          *slog.Logger
+	workers      int
+	hostInterval time.Duration
+
+	mu     sync.Mutex
+	status model.CoverPrefetch
+	next   map[string]time.Time // earliest next download per host
+
+	ctx    context.Context // cancelled by Stop
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewCoverPrefetcher returns a prefetcher downloading on workers goroutines
+// with at least hostInterval between two downloads from the same host.
+func NewCoverPrefetcher(svc *Service, workers int, hostInterval time.Duration, log *slog.Logger) *CoverPrefetcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &CoverPrefetcher{
+		svc:          svc,
+		log:          log,
+		workers:      max(1, workers),
+		hostInterval: hostInterval,
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// Start begins a run unless one is going and returns its status.
+func (p *CoverPrefetcher) Start() model.CoverPrefetch {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.status.Running || p.ctx.Err() != nil {
+		return p.status
+	}
+	p.status = model.CoverPrefetch{Running: true, StartedAt: time.Now()}
+	p.wg.Add(1)
+	go p.run()
+	return p.status
+}
+
+// Status returns the progress of the current or latest run.
+func (p *CoverPrefetcher) Status() model.CoverPrefetch {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.status
+}
+
+// Stop cancels a run in progress and waits for its workers; books not
+// reached yet keep their remote covers for the next run.
+func (p *CoverPrefetcher) Stop() {
+	p.cancel()
+	p.wg.Wait()
+}
+
+func (p *CoverPrefetcher) run() {
+	defer p.wg.Done()
+	var books []model.Book
+	err := p.svc.eachBook(p.ctx, func(b model.Book) error {
+		if remoteCover(b) {
+			books = append(books, b)
+		}
+		return nil
+	})
+	p.mu.Lock()
+	p.status.Books = len(books)
+	p.mu.Unlock()
+	if err != nil {
+		p.log.With("error", err).Warn("cover prefetch could not list books")
+	}
+
+	jobs := make(chan model.Book)
+	var wg sync.WaitGroup
+	for range p.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range jobs {
+				p.prefetch(b)
+			}
+		}()
+	}
+	for _, b := range books {
+		if p.ctx.Err() != nil {
+			break
+		}
+		jobs <- b
+	}
+	close(jobs)
+	wg.Wait()
+
+	p.mu.Lock()
+	p.status.Running = false
+	p.status.FinishedAt = time.Now()
+	st := p.status
+	p.mu.Unlock()
+	p.log.Info("cover prefetch finished", "books", st.Books, "cached", st.Cached, "failed", st.Failed)
+}
+
+func (p *CoverPrefetcher) prefetch(b model.Book) {
+	if !p.wait(*b.CoverURL) {
+		return
+	}
+	err := p.svc.prefetchCover(p.ctx, b)
+	if p.ctx.Err() != nil {
+		return // stopped; neither cached nor failed
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		p.status.Failed++
+		p.log.With("error", err).Info("cover prefetch failed", "book-id", b.ID)
+		return
+	}
+	p.status.Cached++
+}
+
+// wait reserves the next download slot for the host of u and sleeps until
+// it; false means the run was stopped meanwhile.
+func (p *CoverPrefetcher) wait(u string) bool {
+	host := u
+	if pu, err := url.Parse(u); err == nil {
+		host = pu.Host
+	}
+	p.mu.Lock()
+	if p.next == nil {
+		p.next = make(map[string]time.Time)
+	}
+	at := time.Now()
+	if next := p.next[host]; next.After(at) {
+		at = next
+	}
+	p.next[host] = at.Add(p.hostInterval)
+	p.mu.Unlock()
+
+	t := time.NewTimer(time.Until(at))
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-p.ctx.Done():
+		return false
+	}
+}
+
+// prefetchCover downloads the remote cover of b, stores it and points the
+// book at CoverPath, unless the book changed meanwhile.
+func (s *Service) prefetchCover(ctx context.Context, b model.Book) error {
+	data, err := s.CoverCache.FetchCover(ctx, *b.CoverURL)
+	if err != nil {
+		return err
+	}
+	cur, err := s.getBook(ctx, b.ID)
+	if err != nil || cur.CoverURL == nil || *cur.CoverURL != *b.CoverURL {
+		return nil // deleted or given another cover while downloading
+	}
+	if err := s.storeCover(ctx, b.ID, data); err != nil {
+		return err
+	}
+	path := CoverPath(b.ID)
+	_, err = s.PatchBook(ctx, b.ID, model.BookPatch{CoverURL: &path, Version: &cur.Version})
+	return err
+}
+
+func remoteCover(b model.Book) bool {
+	return b.CoverURL != nil && (strings.HasPrefix(*b.CoverURL, "https://") || strings.HasPrefix(*b.CoverURL, "http://"))
+}
+
+// PrefetchCovers starts caching every remote cover, or reports on the run
+// already going.
+func (s *Service) PrefetchCovers(ctx context.Context) (model.CoverPrefetch, error) {
+	if s.Prefetch == nil {
+		return model.CoverPrefetch{}, fmt.Errorf("%w: no cover cache configured", model.ErrNotFound)
+	}
+	return s.Prefetch.Start(), nil
+}
+
+// CoverPrefetchStatus reports on the current or latest prefetch run.
+func (s *Service) CoverPrefetchStatus(ctx context.Context) (model.CoverPrefetch, error) {
+	if s.Prefetch == nil {
+		return model.CoverPrefetch{}, fmt.Errorf("%w: no cover cache configured", model.ErrNotFound)
+	}
+	return s.Prefetch.Status(), nil
+}
@@ -0,0 +1,121 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/factory"
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitPrefetch(t *testing.T, svc *Service) model.CoverPrefetch {
+	t.Helper()
+	var st model.CoverPrefetch
+	require.Eventually(t, func() bool {
+		st, _ = svc.CoverPrefetchStatus(context.Background())
+		return !st.Running
+	}, 5*time.Second, 5*time.Millisecond)
+	return st
+}
+
+func TestPrefetchCovers(t *testing.T) {
+	ctx := context.Background()
+	repo := adapter.NewBookRepo()
+	svc := NewService(repo, nil)
+	_, err := svc.PrefetchCovers(ctx)
+	assert.ErrorIs(t, err, model.ErrNotFound, "no prefetcher")
+
+	svc.Covers, err = adapter.NewDiskBlobStore(t.TempDir())
+	require.NoError(t, err)
+	const hostInterval = 30 * time.Millisecond
+	var (
+		mu             sync.Mutex
+		inFlight, most int
+		starts         = map[string][]time.Time{}
+		png            = testPNG(t, 40, 60)
+	)
+	svc.CoverCache = coverFetcherFunc(func(_ context.Context, u string) ([]byte, error) {
+		pu, _ := url.Parse(u)
+		mu.Lock()
+		inFlight++
+		most = max(most, inFlight)
+		starts[pu.Host] = append(starts[pu.Host], time.Now())
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		if strings.HasSuffix(u, "/gone.jpg") {
+			return nil, errors.New("404")
+		}
+		return png, nil
+	})
+	svc.Prefetch = NewCoverPrefetcher(svc, 2, hostInterval, slog.New(slog.DiscardHandler))
+
+	f := factory.New(1)
+	var remote []model.Book
+	for i := range 8 {
+		host := "a.example"
+		if i%2 == 1 {
+			host = "b.example"
+		}
+		b, err := repo.Create(ctx, f.Book(factory.WithCover("https://"+host+"/"+f.ISBN()+".jpg")))
+		require.NoError(t, err)
+		remote = append(remote, b)
+	}
+	gone, err := repo.Create(ctx, f.Book(factory.WithCover("https://c.example/gone.jpg")))
+	require.NoError(t, err)
+	uploaded, err := repo.Create(ctx, f.Book(factory.WithCover(CoverPath("x"))))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, f.Book())
+	require.NoError(t, err)
+
+	st, err := svc.PrefetchCovers(ctx)
+	require.NoError(t, err)
+	assert.True(t, st.Running)
+	st = waitPrefetch(t, svc)
+	assert.Equal(t, 9, st.Books)
+	assert.Equal(t, 8, st.Cached)
+	assert.Equal(t, 1, st.Failed)
+	assert.False(t, st.FinishedAt.Before(st.StartedAt))
+
+	assert.LessOrEqual(t, most, 2, "bounded concurrency")
+	for host, ts := range starts {
+		for i := 1; i < len(ts); i++ {
+			assert.GreaterOrEqual(t, ts[i].Sub(ts[i-1]), hostInterval-5*time.Millisecond, host)
+		}
+	}
+	for _, b := range remote {
+		got, err := svc.GetBook(ctx, b.ID)
+		require.NoError(t, err)
+		assert.Equal(t, CoverPath(b.ID), *got.CoverURL)
+		c, err := svc.BookCover(ctx, b.ID, model.CoverSmall)
+		require.NoError(t, err)
+		assert.NotEmpty(t, c.Data)
+	}
+	got, err := svc.GetBook(ctx, gone.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://c.example/gone.jpg", *got.CoverURL, "failed downloads keep the remote cover")
+	got, err = svc.GetBook(ctx, uploaded.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uploaded.Version, got.Version)
+
+	// finishing an import starts another run, which only retries the failure
+	svc.StartImport(ctx)()
+	st = waitPrefetch(t, svc)
+	assert.Equal(t, model.CoverPrefetch{StartedAt: st.StartedAt, FinishedAt: st.FinishedAt, Books: 1, Failed: 1}, st)
+
+	svc.Prefetch.Stop()
+	assert.False(t, svc.Prefetch.Start().Running, "no runs after Stop")
+}
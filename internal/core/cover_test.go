@@ -0,0 +1,220 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/factory"
+	"book-manager/pkg/util"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{R: 200, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestUploadCover(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), nil)
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("A")})
+	require.NoError(t, err)
+
+	_, err = svc.UploadCover(ctx, b.ID, testPNG(t, 10, 10))
+	assert.ErrorIs(t, err, model.ErrNotFound, "no cover store")
+
+	svc.Covers, err = adapter.NewDiskBlobStore(t.TempDir())
+	require.NoError(t, err)
+	_, err = svc.UploadCover(ctx, b.ID, []byte("not an image"))
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.UploadCover(ctx, "missing", testPNG(t, 10, 10))
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	up, err := svc.UploadCover(ctx, b.ID, testPNG(t, 800, 1200))
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/books/"+b.ID+"/cover", *up.CoverURL)
+	assert.Equal(t, 2, up.Version)
+
+	for size, want := range map[model.CoverSize]image.Point{
+		model.CoverSmall:  {90, 135},
+		model.CoverMedium: {240, 360},
+		model.CoverLarge:  {600, 900},
+	} {
+		c, err := svc.BookCover(ctx, b.ID, size)
+		require.NoError(t, err)
+		assert.Equal(t, "image/jpeg", c.ContentType)
+		img, err := jpeg.Decode(bytes.NewReader(c.Data))
+		require.NoError(t, err)
+		assert.Equal(t, want, img.Bounds().Size(), size)
+		r, g, _, _ := img.At(10, 10).RGBA()
+		assert.InDelta(t, 200, r>>8, 4)
+		assert.InDelta(t, 0, g>>8, 4)
+	}
+
+	// small images are not scaled up; uploading again keeps the version
+	up, err = svc.UploadCover(ctx, b.ID, testPNG(t, 50, 70))
+	require.NoError(t, err)
+	assert.Equal(t, 2, up.Version)
+	c, err := svc.BookCover(ctx, b.ID, model.CoverLarge)
+	require.NoError(t, err)
+	img, err := jpeg.Decode(bytes.NewReader(c.Data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Pt(50, 70), img.Bounds().Size())
+
+	require.NoError(t, svc.DeleteBook(ctx, b.ID))
+	_, _, err = svc.Covers.Get(ctx, coverKey(b.ID, model.CoverLarge))
+	assert.NoError(t, err, "the trash keeps covers")
+	_, err = svc.PurgeBooks(ctx, time.Time{})
+	require.NoError(t, err)
+	_, _, err = svc.Covers.Get(ctx, coverKey(b.ID, model.CoverLarge))
+	assert.ErrorIs(t, err, model.ErrNotFound, "covers go with the purged book")
+}
+
+func TestBookCover_RemoteURL(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), nil)
+	ol, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("A"), CoverURL: util.GetPtr("https://covers.openlibrary.org/b/id/42-L.jpg")})
+	require.NoError(t, err)
+	other, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("B"), CoverURL: util.GetPtr("https://example.com/b.png")})
+	require.NoError(t, err)
+	none, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("C")})
+	require.NoError(t, err)
+
+	c, err := svc.BookCover(ctx, ol.ID, model.CoverSmall)
+	require.NoError(t, err)
+	assert.Equal(t, "https://covers.openlibrary.org/b/id/42-S.jpg", c.URL)
+	c, err = svc.BookCover(ctx, other.ID, model.CoverSmall)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/b.png", c.URL)
+	_, err = svc.BookCover(ctx, none.ID, model.CoverLarge)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestCoverThumbnails(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), nil)
+	var err error
+	svc.Covers, err = adapter.NewDiskBlobStore(t.TempDir())
+	require.NoError(t, err)
+	up, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("A")})
+	require.NoError(t, err)
+	up, err = svc.UploadCover(ctx, up.ID, testPNG(t, 300, 450))
+	require.NoError(t, err)
+	remote, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("B"), CoverURL: util.GetPtr("https://example.com/b.png")})
+	require.NoError(t, err)
+
+	// stored thumbnails of hand-made books: one over the per-cover cap,
+	// then enough to pass the per-page cap
+	stored := func(id string, n int) model.Book {
+		require.NoError(t, svc.Covers.Put(ctx, coverKey(id, model.CoverSmall), bytes.Repeat([]byte{1}, n), "image/jpeg"))
+		return model.Book{ID: id, CoverURL: util.GetPtr(CoverPath(id))}
+	}
+	books := []model.Book{up, remote, stored("big", maxInlineCoverBytes+1)}
+	for i := range 20 {
+		books = append(books, stored(fmt.Sprint("b", i), maxInlineCoverBytes))
+	}
+
+	thumbs, err := svc.CoverThumbnails(ctx, books)
+	require.NoError(t, err)
+	small, err := svc.BookCover(ctx, up.ID, model.CoverSmall)
+	require.NoError(t, err)
+	assert.Equal(t, small.Data, thumbs[up.ID].Data)
+	assert.Equal(t, "image/jpeg", thumbs[up.ID].ContentType)
+	assert.NotContains(t, thumbs, remote.ID, "remote covers are not downloaded")
+	assert.NotContains(t, thumbs, "big")
+	total := 0
+	for _, c := range thumbs {
+		total += len(c.Data)
+	}
+	assert.LessOrEqual(t, total, maxInlineCoversBytes)
+	assert.Len(t, thumbs, 1+(maxInlineCoversBytes-len(small.Data))/maxInlineCoverBytes)
+
+	svc.Covers = nil
+	thumbs, err = svc.CoverThumbnails(ctx, books)
+	require.NoError(t, err)
+	assert.Empty(t, thumbs)
+}
+
+type coverEnrich struct{ res model.EnrichedBook }
+
+func (e coverEnrich) FetchByISBN(context.Context, string) (model.EnrichedBook, error) {
+	return e.res, nil
+}
+
+type coverFetcherFunc func(ctx context.Context, url string) ([]byte, error)
+
+func (f coverFetcherFunc) FetchCover(ctx context.Context, url string) ([]byte, error) {
+	return f(ctx, url)
+}
+
+func TestCacheCovers(t *testing.T) {
+	ctx := context.Background()
+	const remote = "https://covers.openlibrary.org/b/id/42-L.jpg"
+	f := factory.New(1)
+	svc := NewService(adapter.NewBookRepo(), coverEnrich{f.EnrichedBook(factory.WithCover(remote))})
+	var err error
+	svc.Covers, err = adapter.NewDiskBlobStore(t.TempDir())
+	require.NoError(t, err)
+	var fetched []string
+	svc.CoverCache = coverFetcherFunc(func(_ context.Context, url string) ([]byte, error) {
+		fetched = append(fetched, url)
+		if url != remote {
+			return nil, errors.New("unreachable")
+		}
+		return testPNG(t, 300, 450), nil
+	})
+
+	in := f.CreateBookInput()
+	in.Enrich = true
+	b, err := svc.CreateBook(ctx, in)
+	require.NoError(t, err)
+	assert.Equal(t, CoverPath(b.ID), *b.CoverURL)
+	c, err := svc.BookCover(ctx, b.ID, model.CoverMedium)
+	require.NoError(t, err)
+	assert.NotEmpty(t, c.Data)
+	assert.Empty(t, c.URL)
+
+	// the user's cover wins over enrichment and is not downloaded
+	in = f.CreateBookInput(factory.WithCover("https://example.com/own.jpg"))
+	in.Enrich = true
+	b, err = svc.CreateBook(ctx, in)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/own.jpg", *b.CoverURL)
+	assert.Equal(t, []string{remote}, fetched)
+
+	// a failed download keeps linking to the source
+	svc.Enrich = coverEnrich{f.EnrichedBook(factory.WithCover("https://example.com/gone.jpg"))}
+	b, err = svc.EnrichBook(ctx, b.ID, true)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/gone.jpg", *b.CoverURL)
+	c, err = svc.BookCover(ctx, b.ID, model.CoverLarge)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/gone.jpg", c.URL)
+
+	// re-enrichment downloads the new cover
+	svc.Enrich = coverEnrich{f.EnrichedBook(factory.WithCover(remote))}
+	b, err = svc.EnrichBook(ctx, b.ID, true)
+	require.NoError(t, err)
+	assert.Equal(t, CoverPath(b.ID), *b.CoverURL)
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetterRepository keeps events whose delivery failed for good.
+type DeadLetterRepository interface {
+	Add(ctx context.Context, d model.DeadLetter) error
+	// List returns the dead letters, oldest first.
+	List(ctx context.Context) ([]model.DeadLetter, error)
+	// Get, Update and Delete fail with an error matching
+	// model.ErrNotFound when id is unknown.
+	Get(ctx context.Context, id string) (model.DeadLetter, error)
+	Update(ctx context.Context, d model.DeadLetter) error
+	Delete(ctx context.Context, id string) error
+}
+
+// notifyAttempts is how often a delivery is tried before the event is
+// dead-lettered.
+const notifyAttempts = 3
+
+// notifyBackoff is the wait before the second attempt, doubling after
+// that; tests shorten it.
+var notifyBackoff = 500 * time.Millisecond
+
+// notify delivers ev, retrying failures. An event that still fails is kept
+// as a dead letter when a repository is configured, and the error is
+// returned either way so the caller can log it.
+func (s *Service) notify(ctx context.Context, ev model.Event) error {
+	if s.Notifier == nil {
+		return nil
+	}
+	var err error
+	wait := notifyBackoff
+	for i := range notifyAttempts {
+		if i > 0 {
+			select {
+			case <-time.After(wait):
+				wait *= 2
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err = s.Notifier.Notify(ctx, ev); err == nil {
+			return nil
+		}
+	}
+	if s.DeadLetters == nil {
+		return err
+	}
+	ev.Book.Embedding = nil // large and of no use to a receiver
+	d := model.DeadLetter{ID: uuid.NewString(), Event: ev, Error: err.Error(), Attempts: notifyAttempts, FailedAt: time.Now()}
+	if aerr := s.DeadLetters.Add(ctx, d); aerr != nil {
+		return errors.Join(err, fmt.Errorf("keep dead letter: %w", aerr))
+	}
+	return fmt.Errorf("%w (kept as dead letter %s)", err, d.ID)
+}
+
+func (s *Service) ListDeadLetters(ctx context.Context) ([]model.DeadLetter, error) {
+	if s.DeadLetters == nil {
+		return nil, nil
+	}
+	return s.DeadLetters.List(ctx)
+}
+
+// ReplayDeadLetter delivers a dead letter once more and drops it when that
+// succeeds. On failure it stays, with the attempt counted, and the error
+// matches model.ErrUpstream.
+func (s *Service) ReplayDeadLetter(ctx context.Context, id string) (model.DeadLetter, error) {
+	if s.DeadLetters == nil {
+		return model.DeadLetter{}, fmt.Errorf("%w: dead letter %s", model.ErrNotFound, id)
+	}
+	d, err := s.DeadLetters.Get(ctx, id)
+	if err != nil {
+		return model.DeadLetter{}, err
+	}
+	if s.Notifier == nil {
+		return d, fmt.Errorf("%w: no notifier configured", model.ErrUpstream)
+	}
+	if err := s.Notifier.Notify(ctx, d.Event); err != nil {
+		d.Attempts++
+		d.Error, d.FailedAt = err.Error(), time.Now()
+		if uerr := s.DeadLetters.Update(ctx, d); uerr != nil {
+			return d, uerr
+		}
+		return d, fmt.Errorf("%w: %v", model.ErrUpstream, err)
+	}
+	return d, s.DeadLetters.Delete(ctx, id)
+}
+
+// ReplayDeadLetters replays every dead letter and returns how many were
+// delivered and how many failed again.
+func (s *Service) ReplayDeadLetters(ctx context.Context) (delivered, failed int, err error) {
+	all, err := s.ListDeadLetters(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, d := range all {
+		_, err := s.ReplayDeadLetter(ctx, d.ID)
+		switch {
+		case err == nil:
+			delivered++
+		case errors.Is(err, model.ErrUpstream):
+			failed++
+		case errors.Is(err, model.ErrNotFound):
+			// replayed or discarded meanwhile
+		default:
+			return delivered, failed, err
+		}
+	}
+	return delivered, failed, nil
+}
+
+// DeleteDeadLetter discards a dead letter without delivering it.
+func (s *Service) DeleteDeadLetter(ctx context.Context, id string) error {
+	if s.DeadLetters == nil {
+		return fmt.Errorf("%w: dead letter %s", model.ErrNotFound, id)
+	}
+	return s.DeadLetters.Delete(ctx, id)
+}
@@ -0,0 +1,81 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyNotifier fails the first failures deliveries.
+type flakyNotifier struct {
+	failures  int
+	calls     int
+	delivered []model.Event
+}
+
+func (n *flakyNotifier) Notify(ctx context.Context, ev model.Event) error {
+	n.calls++
+	if n.calls <= n.failures {
+		return errors.New("receiver down")
+	}
+	n.delivered = append(n.delivered, ev)
+	return nil
+}
+
+func TestNotify_DeadLetters(t *testing.T) {
+	defer func(d time.Duration) { notifyBackoff = d }(notifyBackoff)
+	notifyBackoff = time.Millisecond
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), nil)
+	n := &flakyNotifier{failures: 2}
+	svc.Notifier = n
+	svc.DeadLetters = adapter.NewDeadLetterRepo()
+
+	ev := model.Event{Type: model.EventBookReleased, BookID: "b1", Book: model.Book{ID: "b1", Embedding: &model.Embedding{Vector: []float32{1}}}, At: time.Now()}
+	require.NoError(t, svc.notify(ctx, ev), "retries cover short outages")
+	assert.Equal(t, 3, n.calls)
+
+	n.failures, n.calls = 100, 0
+	err := svc.notify(ctx, ev)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dead letter")
+	assert.Equal(t, notifyAttempts, n.calls)
+	letters, err := svc.ListDeadLetters(ctx)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	d := letters[0]
+	assert.Equal(t, "b1", d.Event.BookID)
+	assert.Nil(t, d.Event.Book.Embedding)
+	assert.Equal(t, "receiver down", d.Error)
+	assert.Equal(t, notifyAttempts, d.Attempts)
+
+	_, err = svc.ReplayDeadLetter(ctx, d.ID)
+	assert.ErrorIs(t, err, model.ErrUpstream)
+	got, err := svc.DeadLetters.Get(ctx, d.ID)
+	require.NoError(t, err)
+	assert.Equal(t, notifyAttempts+1, got.Attempts)
+
+	n.failures = 0
+	require.NoError(t, svc.notify(ctx, ev))
+	n.failures, n.calls = 100, 0
+	require.Error(t, svc.notify(ctx, ev))
+	n.failures = 0
+	delivered, failed, err := svc.ReplayDeadLetters(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+	assert.Zero(t, failed)
+	letters, err = svc.ListDeadLetters(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+	_, err = svc.ReplayDeadLetter(ctx, d.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	assert.ErrorIs(t, svc.DeleteDeadLetter(ctx, d.ID), model.ErrNotFound)
+}
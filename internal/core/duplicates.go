@@ -0,0 +1,224 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// DuplicateExclusionRepository keeps the sets of books confirmed not to be
+// duplicates.
+type DuplicateExclusionRepository interface {
+	Add(ctx context.Context, e model.DuplicateExclusion) error
+	// List returns the exclusions, oldest first.
+	List(ctx context.Context) ([]model.DuplicateExclusion, error)
+	// Delete fails with an error matching model.ErrNotFound when id is
+	// unknown.
+	Delete(ctx context.Context, id string) error
+}
+
+// DuplicateBooks reports groups of books that look like duplicates the
+// ISBN check cannot catch: their normalized titles are within a small edit
+// distance (see titlesMatch) and they share an author. Books in the trash
+// and pairs of books excluded together are not matched. Groups are sorted
+// by the title of their oldest book.
+func (s *Service) DuplicateBooks(ctx context.Context, mode string, page, size int) (model.Page[model.DuplicateGroup], error) {
+	if mode != model.DuplicateModeTitle {
+		return model.Page[model.DuplicateGroup]{}, &model.FieldError{Field: "mode", Reason: "must be title"}
+	}
+	excluded, err := s.excludedPairs(ctx)
+	if err != nil {
+		return model.Page[model.DuplicateGroup]{}, err
+	}
+
+	var books []model.Book
+	var titles []string
+	byAuthor := map[string][]int{} // lowercased author -> indexes into books
+	err = s.WalkBooks(ctx, model.ListQuery{Sort: []model.SortKey{{Field: "created_at"}}}, func(b model.Book) error {
+		i := len(books)
+		books = append(books, b)
+		titles = append(titles, normalizeTitle(b.Title))
+		seen := map[string]bool{}
+		for _, a := range b.Authors {
+			k := strings.ToLower(strings.TrimSpace(a))
+			if k != "" && !seen[k] {
+				seen[k] = true
+				byAuthor[k] = append(byAuthor[k], i)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return model.Page[model.DuplicateGroup]{}, err
+	}
+
+	parent := make([]int, len(books))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for _, idx := range byAuthor {
+		for x := 0; x < len(idx); x++ {
+			for y := x + 1; y < len(idx); y++ {
+				i, j := idx[x], idx[y]
+				if excluded[bookPair(books[i].ID, books[j].ID)] || !titlesMatch(titles[i], titles[j]) {
+					continue
+				}
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	members := map[int][]model.Book{}
+	for i := range books {
+		r := find(i)
+		members[r] = append(members[r], books[i]) // books are oldest first
+	}
+	var groups []model.DuplicateGroup
+	for _, m := range members {
+		if len(m) > 1 {
+			groups = append(groups, model.DuplicateGroup{Books: m})
+		}
+	}
+	slices.SortFunc(groups, func(a, b model.DuplicateGroup) int {
+		if c := strings.Compare(normalizeTitle(a.Books[0].Title), normalizeTitle(b.Books[0].Title)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Books[0].ID, b.Books[0].ID)
+	})
+
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 20
+	}
+	total := len(groups)
+	start := min((page-1)*size, total)
+	end := min(start+size, total)
+	return model.Page[model.DuplicateGroup]{Data: groups[start:end], Page: page, PageSize: size, Total: total}, nil
+}
+
+// normalizeTitle lowercases a title, keeps only letters and digits
+// separated by single spaces and drops a leading English article, so
+// "The  Hobbit!" and "hobbit" compare equal.
+func normalizeTitle(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > 1 && (words[0] == "the" || words[0] == "a" || words[0] == "an") {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// titlesMatch tells whether two normalized titles are close enough to be
+// the same book: equal below five runes, within edit distance 1 below
+// twelve, within 2 above. Titles with different numbers, such as volumes
+// of a series, never match.
+func titlesMatch(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	if !slices.Equal(titleNumbers(a), titleNumbers(b)) {
+		return false
+	}
+	n := min(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	maxDist := 2
+	switch {
+	case n < 5:
+		return false
+	case n < 12:
+		maxDist = 1
+	}
+	return util.Levenshtein(a, b) <= maxDist
+}
+
+func titleNumbers(title string) []string {
+	return strings.FieldsFunc(title, func(r rune) bool { return !unicode.IsDigit(r) })
+}
+
+func bookPair(a, b string) [2]string {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
+
+func (s *Service) excludedPairs(ctx context.Context) (map[[2]string]bool, error) {
+	out := map[[2]string]bool{}
+	if s.Exclusions == nil {
+		return out, nil
+	}
+	all, err := s.Exclusions.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range all {
+		for i, a := range e.BookIDs {
+			for _, b := range e.BookIDs[i+1:] {
+				out[bookPair(a, b)] = true
+			}
+		}
+	}
+	return out, nil
+}
+
+func (s *Service) ListDuplicateExclusions(ctx context.Context) ([]model.DuplicateExclusion, error) {
+	if s.Exclusions == nil {
+		return nil, nil
+	}
+	return s.Exclusions.List(ctx)
+}
+
+// ExcludeDuplicates confirms that the given books, at least two, are not
+// duplicates of each other.
+func (s *Service) ExcludeDuplicates(ctx context.Context, bookIDs []string) (model.DuplicateExclusion, error) {
+	if s.Exclusions == nil {
+		return model.DuplicateExclusion{}, fmt.Errorf("%w: no exclusion list configured", model.ErrNotFound)
+	}
+	var ids []string
+	for _, id := range bookIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < 2 {
+		return model.DuplicateExclusion{}, &model.FieldError{Field: "book_ids", Reason: "must name at least two books"}
+	}
+	for _, id := range ids {
+		if _, err := s.getBook(ctx, id); err != nil {
+			return model.DuplicateExclusion{}, fmt.Errorf("%w: book %s", model.ErrNotFound, id)
+		}
+	}
+	e := model.DuplicateExclusion{ID: uuid.NewString(), BookIDs: ids, Tenant: model.TenantFromContext(ctx), CreatedAt: time.Now()}
+	if err := s.Exclusions.Add(ctx, e); err != nil {
+		return model.DuplicateExclusion{}, err
+	}
+	return e, nil
+}
+
+// DeleteDuplicateExclusion lets the duplicate report pair the books again.
+func (s *Service) DeleteDuplicateExclusion(ctx context.Context, id string) error {
+	if s.Exclusions == nil {
+		return fmt.Errorf("%w: duplicate exclusion %s", model.ErrNotFound, id)
+	}
+	return s.Exclusions.Delete(ctx, id)
+}
@@ -0,0 +1,103 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTitlesMatch(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want bool
+	}{
+		{"The Hobbit!", "hobbit", true},
+		{"Clean Code", "Clean Cod", true},
+		{"Clean Architecture", "Clean Architectre", true},
+		{"Clean Architecture", "Clean Archtectre", true},
+		{"Clean Code", "Clean Coder", true},
+		{"Clean Code", "Lean Coders", false},
+		{"Dune", "Dune", true},
+		{"Dune", "Dune!", true},
+		{"Dune", "Dunes", false},
+		{"Foundation 1", "Foundation 2", false},
+		{"Volume 1", "Volume 1", true},
+		{"", "", false},
+	} {
+		assert.Equal(t, tc.want, titlesMatch(normalizeTitle(tc.a), normalizeTitle(tc.b)), "%q vs %q", tc.a, tc.b)
+	}
+}
+
+func TestDuplicateBooks(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	create := func(title string, authors ...string) model.Book {
+		t.Helper()
+		b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr(title), Authors: authors})
+		require.NoError(t, err)
+		return b
+	}
+	clean := create("Clean Architecture", "Robert C. Martin")
+	typo := create("Clean Architectre", "robert c. martin", "Someone Else")
+	other := create("Clean Architecture", "Another Author")
+	third := create("clean architecture.", "Someone Else")
+	dune := create("Dune", "Frank Herbert")
+	dune2 := create("The Dune", "Frank Herbert")
+	create("Dune Messiah", "Frank Herbert")
+	trashed := create("Dune", "Frank Herbert")
+	require.NoError(t, svc.DeleteBook(ctx, trashed.ID))
+
+	ids := func(p model.Page[model.DuplicateGroup]) [][]string {
+		var out [][]string
+		for _, g := range p.Data {
+			var group []string
+			for _, b := range g.Books {
+				group = append(group, b.ID)
+			}
+			out = append(out, group)
+		}
+		return out
+	}
+	res, err := svc.DuplicateBooks(ctx, model.DuplicateModeTitle, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Total)
+	// third matches typo by author, so the chain joins clean; other shares no author
+	assert.Equal(t, [][]string{{clean.ID, typo.ID, third.ID}, {dune.ID, dune2.ID}}, ids(res))
+	assert.NotContains(t, ids(res)[0], other.ID)
+
+	res, err = svc.DuplicateBooks(ctx, model.DuplicateModeTitle, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{dune.ID, dune2.ID}}, ids(res))
+
+	_, err = svc.DuplicateBooks(ctx, "isbn", 1, 20)
+	assert.ErrorIs(t, err, model.ErrValidation)
+
+	_, err = svc.ExcludeDuplicates(ctx, []string{dune.ID, dune2.ID})
+	assert.ErrorIs(t, err, model.ErrNotFound, "no exclusion list")
+	svc.Exclusions = adapter.NewDuplicateExclusionRepo()
+	_, err = svc.ExcludeDuplicates(ctx, []string{dune.ID, dune.ID})
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.ExcludeDuplicates(ctx, []string{dune.ID, "missing"})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	ex, err := svc.ExcludeDuplicates(ctx, []string{dune.ID, dune2.ID})
+	require.NoError(t, err)
+	res, err = svc.DuplicateBooks(ctx, model.DuplicateModeTitle, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{clean.ID, typo.ID, third.ID}}, ids(res))
+
+	all, err := svc.ListDuplicateExclusions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.DuplicateExclusion{ex}, all)
+	require.NoError(t, svc.DeleteDuplicateExclusion(ctx, ex.ID))
+	assert.ErrorIs(t, svc.DeleteDuplicateExclusion(ctx, ex.ID), model.ErrNotFound)
+	res, err = svc.DuplicateBooks(ctx, model.DuplicateModeTitle, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Total)
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// EnrichBook looks a stored book's ISBN up again. By default only empty
+// fields are filled; overwrite lets the external data replace user values.
+// A failed lookup leaves the book unchanged and returns ErrUpstream.
+func (s *Service) EnrichBook(ctx context.Context, id string, overwrite bool) (_ model.Book, err error) {
+	ctx, span := tracer.Start(ctx, "Service.EnrichBook", bookID(id))
+	defer func() { endSpan(span, err) }()
+	b, err := s.getBook(ctx, id)
+	if err != nil {
+		return model.Book{}, model.ErrNotFound
+	}
+	if b.ISBN == nil || *b.ISBN == "" {
+		return model.Book{}, model.ErrValidation
+	}
+	res, err := s.Enrich.FetchByISBN(ctx, *b.ISBN)
+	if err != nil {
+		return model.Book{}, model.ErrUpstream
+	}
+
+	// re-read so edits made during the lookup are kept
+	if b, err = s.getBook(ctx, id); err != nil {
+		return model.Book{}, model.ErrNotFound
+	}
+	before := b
+	b.Enrichment.Attempted = true
+	b.Enrichment.LookedUpISBN = *b.ISBN
+	if err := s.applyEnrichment(ctx, &b, res, overwrite); err != nil {
+		return model.Book{}, err
+	}
+	return s.updateBook(ctx, model.AuditEnrich, before, b)
+}
+
+// PreviewEnrichment looks isbn up as creating a book with Enrich does,
+// through the same sources and cache, without creating anything: the
+// fields returned are those a create would fill in. An ISBN no source
+// knows is model.ErrNotFound; a failed lookup is model.ErrUpstream.
+func (s *Service) PreviewEnrichment(ctx context.Context, isbn string) (model.EnrichmentPreview, error) {
+	canon, err := NormalizeISBN(isbn)
+	if err != nil {
+		return model.EnrichmentPreview{}, err
+	}
+	res, err := s.Enrich.FetchByISBN(ctx, canon)
+	switch {
+	case err == nil:
+	case ctx.Err() != nil:
+		return model.EnrichmentPreview{}, ctx.Err()
+	case errors.Is(err, model.ErrNotFound):
+		return model.EnrichmentPreview{}, fmt.Errorf("%w: no enrichment source knows isbn %s", model.ErrNotFound, canon)
+	default:
+		return model.EnrichmentPreview{}, fmt.Errorf("%w: %v", model.ErrUpstream, err)
+	}
+	res.ReleaseDate = releaseDay(res.ReleaseDate)
+	return model.EnrichmentPreview{ISBN: canon, EnrichedBook: res}, nil
+}
+
+// applyEnrichment merges a lookup result into b and re-applies auto-tag
+// rules and author links, which may match the new data.
+func (s *Service) applyEnrichment(ctx context.Context, b *model.Book, res model.EnrichedBook, overwrite bool) error {
+	if overwrite {
+		overwriteEnriched(b, res)
+	} else {
+		merge(b, res) // fill only missing fields; user wins
+	}
+	s.translate(ctx, b)
+	s.embed(ctx, b)
+	s.cacheCover(ctx, b, res)
+	b.Enrichment.Source = res.Source
+	b.Enrichment.Status = model.EnrichmentOK
+	if err := s.autoTag(ctx, b); err != nil {
+		return err
+	}
+	return s.linkAuthors(ctx, b)
+}
+
+// overwriteEnriched replaces every field the lookup returned.
+func overwriteEnriched(dst *model.Book, e model.EnrichedBook) {
+	if e.Title != nil && *e.Title != "" {
+		dst.Title = *e.Title
+	}
+	if e.Subtitle != nil {
+		dst.Subtitle = e.Subtitle
+	}
+	if e.PublishedYear != nil {
+		dst.PublishedYear = e.PublishedYear
+	}
+	if e.PageCount != nil {
+		dst.PageCount = e.PageCount
+	}
+	if e.CoverURL != nil {
+		dst.CoverURL = e.CoverURL
+	}
+	if len(e.Authors) > 0 {
+		dst.Authors = append([]string(nil), e.Authors...)
+	}
+	if e.ReleaseDate != nil {
+		dst.ReleaseDate = releaseDay(e.ReleaseDate)
+	}
+	if e.Description != nil {
+		dst.Description = e.Description
+	}
+	if len(e.Subjects) > 0 {
+		dst.Subjects = append([]string(nil), e.Subjects...)
+	}
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// EnrichmentQueue enriches stored books in the background, so creating a
+// book with enrich=true and no require_enrichment returns at once with
+// status pending. Workers update the book when the lookup finishes.
+type EnrichmentQueue struct {
+	svc *Service
+	log *slog.Logger
+
+	mu     sync.Mutex
+	jobs   chan enrichJob
+	closed bool
+
+	ctx    context.Context // cancelled when a drain gives up
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// enrichJob is a queued book and the tenant it was created in, whose
+// enrichment providers look it up.
+type enrichJob struct {
+	tenant, id string
+}
+
+// NewEnrichmentQueue starts workers goroutines serving a queue of up to size
+// books. Attach it to the service with Service.Queue.
+func NewEnrichmentQueue(svc *Service, workers, size int, log *slog.Logger) *EnrichmentQueue {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &EnrichmentQueue{
+		svc:    svc,
+		log:    log,
+		jobs:   make(chan enrichJob, size),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	for range workers {
+		q.wg.Add(1)
+		go q.work()
+	}
+	return q
+}
+
+// Enqueue schedules enrichment of book id, in the tenant of ctx. It
+// returns false when the queue is full or draining; the caller then
+// enriches inline.
+func (q *EnrichmentQueue) Enqueue(ctx context.Context, id string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return false
+	}
+	select {
+	case q.jobs <- enrichJob{tenant: model.TenantFromContext(ctx), id: id}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Drain stops accepting books and waits for the queued ones. If ctx ends
+// first, lookups in flight are cancelled and the remaining books stay
+// pending; the number left is returned.
+func (q *EnrichmentQueue) Drain(ctx context.Context) int {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return 0
+	case <-ctx.Done():
+		left := len(q.jobs)
+		q.cancel()
+		<-done
+		return left
+	}
+}
+
+func (q *EnrichmentQueue) work() {
+	defer q.wg.Done()
+	for job := range q.jobs {
+		if q.ctx.Err() != nil {
+			continue // drain gave up; leave the book pending
+		}
+		ctx := q.ctx
+		if job.tenant != "" {
+			ctx = model.WithTenant(ctx, job.tenant)
+		}
+		if err := q.svc.enrichStored(ctx, job.id); err != nil {
+			q.log.With("error", err).Warn("background enrichment failed", "book-id", job.id, "tenant", job.tenant)
+		}
+	}
+}
+
+// enrichStored looks up the stored book's ISBN and fills its missing fields,
+// finishing a pending enrichment.
+func (s *Service) enrichStored(ctx context.Context, id string) error {
+	b, err := s.getBook(ctx, id)
+	if err != nil {
+		return nil // deleted meanwhile
+	}
+	res, fetchErr := s.Enrich.FetchByISBN(ctx, b.Enrichment.LookedUpISBN)
+	if fetchErr != nil && ctx.Err() != nil {
+		return ctx.Err() // shutting down; leave it pending
+	}
+
+	// re-read so edits made during the lookup are kept; user values still win
+	b, err = s.getBook(ctx, id)
+	if err != nil {
+		return nil
+	}
+	before := b
+	if fetchErr != nil {
+		b.Enrichment.Status = model.EnrichmentPartial
+	} else if err := s.applyEnrichment(ctx, &b, res, false); err != nil {
+		return err
+	}
+	_, err = s.updateBook(ctx, model.AuditEnrich, before, b)
+	return err
+}
@@ -0,0 +1,108 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingEnrich answers once release is closed, or fails when ctx ends.
+type blockingEnrich struct{ release chan struct{} }
+
+func (f blockingEnrich) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	select {
+	case <-f.release:
+		return mockEnrich{hit: true}.FetchByISBN(ctx, isbn)
+	case <-ctx.Done():
+		return model.EnrichedBook{}, ctx.Err()
+	}
+}
+
+func TestEnrichmentQueue(t *testing.T) {
+	ctx := context.Background()
+	enrich := blockingEnrich{release: make(chan struct{})}
+	svc := NewService(adapter.NewBookRepo(), enrich)
+	svc.Authors = adapter.NewAuthorRepo()
+	svc.Queue = NewEnrichmentQueue(svc, 2, 10, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{ISBN: util.GetPtr("9780134494166"), Subtitle: util.GetPtr("Mine"), Enrich: true})
+	require.NoError(t, err)
+	assert.Equal(t, model.EnrichmentPending, b.Enrichment.Status)
+	assert.Empty(t, b.Title)
+
+	close(enrich.release)
+	assert.Equal(t, 0, svc.Queue.Drain(ctx))
+	got, err := svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.EnrichmentOK, got.Enrichment.Status)
+	assert.Equal(t, "Clean Architecture", got.Title)
+	assert.Equal(t, "Mine", *got.Subtitle)
+	assert.Equal(t, []string{"Robert C. Martin"}, got.Authors)
+	assert.Len(t, got.AuthorIDs, 1)
+
+	// after a drain, creates enrich inline
+	b, err = svc.CreateBook(ctx, model.CreateBookInput{ISBN: util.GetPtr("9780441013593"), Enrich: true})
+	require.NoError(t, err)
+	assert.Equal(t, model.EnrichmentOK, b.Enrichment.Status)
+	assert.Equal(t, "Clean Architecture", b.Title)
+}
+
+// tenantEnrich records the tenant of every lookup.
+type tenantEnrich struct{ tenants chan string }
+
+func (f tenantEnrich) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	f.tenants <- model.TenantFromContext(ctx)
+	return mockEnrich{hit: true}.FetchByISBN(ctx, isbn)
+}
+
+func TestEnrichmentQueue_KeepsTenant(t *testing.T) {
+	enrich := tenantEnrich{tenants: make(chan string, 1)}
+	svc := NewService(adapter.NewBookRepo(), enrich)
+	svc.Queue = NewEnrichmentQueue(svc, 1, 10, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	acme := model.WithTenant(context.Background(), "acme")
+
+	b, err := svc.CreateBook(acme, model.CreateBookInput{ISBN: util.GetPtr("9780134494166"), Enrich: true})
+	require.NoError(t, err)
+	assert.Equal(t, model.EnrichmentPending, b.Enrichment.Status)
+	assert.Equal(t, "acme", <-enrich.tenants, "looked up with the tenant's providers")
+	assert.Equal(t, 0, svc.Queue.Drain(context.Background()))
+	got, err := svc.GetBook(acme, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.EnrichmentOK, got.Enrichment.Status)
+}
+
+func TestEnrichmentQueue_DrainTimeout(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), blockingEnrich{release: make(chan struct{})})
+	svc.Queue = NewEnrichmentQueue(svc, 1, 10, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	var ids []string
+	for _, isbn := range []string{"9780134494166", "9780441013593"} {
+		b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("T"), ISBN: util.GetPtr(isbn), Enrich: true})
+		require.NoError(t, err)
+		ids = append(ids, b.ID)
+	}
+	// required enrichment never goes through the queue
+	rctx, rcancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer rcancel()
+	_, err := svc.CreateBook(rctx, model.CreateBookInput{Title: util.GetPtr("T"), Enrich: true, RequireEnrichment: true, ISBN: util.GetPtr("9780000000002")})
+	assert.ErrorIs(t, err, model.ErrUpstream)
+
+	dctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	assert.Positive(t, svc.Queue.Drain(dctx), "queued books are left pending")
+	for _, id := range ids {
+		got, err := svc.GetBook(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, model.EnrichmentPending, got.Enrichment.Status)
+	}
+}
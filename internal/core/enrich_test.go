@@ -0,0 +1,89 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrichBook(t *testing.T) {
+	ctx := context.Background()
+	repo := adapter.NewBookRepo()
+	svc := NewService(repo, mockEnrich{hit: true})
+	svc.Authors = adapter.NewAuthorRepo()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Clean Arch"), ISBN: util.GetPtr("9780134494166"), PageCount: util.GetPtr(10)})
+	require.NoError(t, err)
+	assert.Equal(t, model.EnrichmentNotRequested, b.Enrichment.Status)
+
+	got, err := svc.EnrichBook(ctx, b.ID, false)
+	require.NoError(t, err)
+	assert.Equal(t, "Clean Arch", got.Title, "user values win")
+	assert.Equal(t, 10, *got.PageCount)
+	assert.Equal(t, 2017, *got.PublishedYear)
+	assert.Equal(t, []string{"Robert C. Martin"}, got.Authors)
+	assert.Len(t, got.AuthorIDs, 1)
+	assert.Equal(t, model.EnrichmentOK, got.Enrichment.Status)
+	assert.True(t, got.Enrichment.Attempted)
+	assert.Equal(t, "9780134494166", got.Enrichment.LookedUpISBN)
+	assert.True(t, got.UpdatedAt.After(b.UpdatedAt))
+
+	got, err = svc.EnrichBook(ctx, b.ID, true)
+	require.NoError(t, err)
+	assert.Equal(t, "Clean Architecture", got.Title)
+	assert.Equal(t, 432, *got.PageCount)
+
+	_, err = svc.EnrichBook(ctx, "missing", false)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	noISBN, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Notes")})
+	require.NoError(t, err)
+	_, err = svc.EnrichBook(ctx, noISBN.ID, false)
+	assert.ErrorIs(t, err, model.ErrValidation)
+
+	svc.Enrich = mockEnrich{hit: false}
+	_, err = svc.EnrichBook(ctx, b.ID, true)
+	assert.ErrorIs(t, err, model.ErrUpstream)
+	after, err := svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, got.UpdatedAt, after.UpdatedAt, "failed lookup leaves the book unchanged")
+}
+
+type unknownEnrich struct{}
+
+func (unknownEnrich) FetchByISBN(context.Context, string) (model.EnrichedBook, error) {
+	return model.EnrichedBook{}, fmt.Errorf("%w: no enrichment source knows it", model.ErrNotFound)
+}
+
+func TestPreviewEnrichment(t *testing.T) {
+	ctx := context.Background()
+	repo := adapter.NewBookRepo()
+	svc := NewService(repo, mockEnrich{hit: true})
+
+	got, err := svc.PreviewEnrichment(ctx, "978-0-13-449416-6")
+	require.NoError(t, err)
+	assert.Equal(t, "9780134494166", got.ISBN)
+	assert.Equal(t, "Clean Architecture", *got.Title)
+	assert.Equal(t, []string{"Robert C. Martin"}, got.Authors)
+	page, err := svc.ListBooks(ctx, model.ListQuery{})
+	require.NoError(t, err)
+	assert.Zero(t, page.Total, "nothing is created")
+
+	_, err = svc.PreviewEnrichment(ctx, "978-0-13-449416-7")
+	assert.ErrorIs(t, err, model.ErrValidation)
+
+	svc.Enrich = unknownEnrich{}
+	_, err = svc.PreviewEnrichment(ctx, "9780134494166")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	svc.Enrich = mockEnrich{hit: false}
+	_, err = svc.PreviewEnrichment(ctx, "9780134494166")
+	assert.ErrorIs(t, err, model.ErrUpstream)
+}
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EscalationRepository keeps the overdue escalation policies and the
+// audit trail of what they did. ListPolicies returns the policies by
+// AfterDays, in creation order among equals. Record stores a run unless
+// its policy already acted on the loan, and reports whether it stored it;
+// runs outlive their policy.
+type EscalationRepository interface {
+	CreatePolicy(ctx context.Context, p model.EscalationPolicy) (model.EscalationPolicy, error)
+	ListPolicies(ctx context.Context) ([]model.EscalationPolicy, error)
+	DeletePolicy(ctx context.Context, id string) error
+	Record(ctx context.Context, run model.EscalationRun) (bool, error)
+	// Runs returns the runs of a policy, oldest first.
+	Runs(ctx context.Context, policyID string) ([]model.EscalationRun, error)
+}
+
+const (
+	maxPolicyNameLen  = 100
+	maxEscalationDays = 3650
+)
+
+var escalationActions = []model.EscalationAction{model.EscalationRemind, model.EscalationEscalate, model.EscalationMarkLost}
+
+func (s *Service) CreateEscalationPolicy(ctx context.Context, in model.EscalationPolicy) (model.EscalationPolicy, error) {
+	if s.Escalations == nil || s.Loans == nil {
+		return model.EscalationPolicy{}, fmt.Errorf("%w: lending is not configured", model.ErrNotFound)
+	}
+	var v validator
+	in.Name = strings.TrimSpace(in.Name)
+	v.check(len(in.Name) <= maxPolicyNameLen, "name", fmt.Sprintf("must be at most %d characters", maxPolicyNameLen))
+	v.check(slices.Contains(escalationActions, in.Action), "action", "must be remind, escalate or mark_lost")
+	v.check(in.AfterDays >= 0 && in.AfterDays <= maxEscalationDays, "after_days", fmt.Sprintf("must be between 0 and %d", maxEscalationDays))
+	if err := v.err(); err != nil {
+		return model.EscalationPolicy{}, err
+	}
+	in.ID = uuid.NewString()
+	in.Tenant = model.TenantFromContext(ctx)
+	in.CreatedAt = time.Now().UTC()
+	return s.Escalations.CreatePolicy(ctx, in)
+}
+
+func (s *Service) ListEscalationPolicies(ctx context.Context) ([]model.EscalationPolicy, error) {
+	if s.Escalations == nil {
+		return nil, nil
+	}
+	return s.Escalations.ListPolicies(ctx)
+}
+
+func (s *Service) DeleteEscalationPolicy(ctx context.Context, id string) error {
+	if s.Escalations == nil {
+		return fmt.Errorf("%w: escalation policy %s", model.ErrNotFound, id)
+	}
+	return s.Escalations.DeletePolicy(ctx, id)
+}
+
+// EscalationRuns returns the audit trail of a policy, also of a deleted
+// one.
+func (s *Service) EscalationRuns(ctx context.Context, policyID string) ([]model.EscalationRun, error) {
+	if s.Escalations == nil {
+		return nil, nil
+	}
+	return s.Escalations.Runs(ctx, policyID)
+}
+
+// RunEscalations applies every policy whose day has come to the active
+// loans it has not acted on yet and returns how many times a policy acted.
+// A policy acts at most once on a loan: the run is recorded before the
+// action, so a failed notification is left to the dead letters rather than
+// repeated. Loans marked lost get no further reminders.
+func (s *Service) RunEscalations(ctx context.Context, now time.Time) (int, error) {
+	if s.Escalations == nil || s.Loans == nil {
+		return 0, nil
+	}
+	policies, err := s.Escalations.ListPolicies(ctx)
+	if err != nil || len(policies) == 0 {
+		return 0, err
+	}
+	now = now.UTC()
+	loans, err := s.Loans.List(ctx, model.LoanQuery{Status: model.LoanActive, Now: now})
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	var errs []error
+	for _, l := range loans {
+		for _, p := range policies {
+			if p.Tenant != l.Tenant || now.Before(escalationDay(l, p)) || (l.LostAt != nil && p.Action != model.EscalationMarkLost) {
+				continue
+			}
+			ok, err := s.Escalations.Record(ctx, model.EscalationRun{
+				PolicyID: p.ID, LoanID: l.ID, BookID: l.BookID, Borrower: l.Borrower, Action: p.Action, At: now, Tenant: l.Tenant,
+			})
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			n++
+			if l, err = s.escalate(ctx, p.Action, l, now); err != nil {
+				errs = append(errs, fmt.Errorf("policy %s on loan %s: %w", p.ID, l.ID, err))
+			}
+		}
+	}
+	return n, errors.Join(errs...)
+}
+
+// escalationDay is when p starts acting on l: the start of the day, in UTC,
+// AfterDays after the loan's due date.
+func escalationDay(l model.Loan, p model.EscalationPolicy) time.Time {
+	return l.DueAt.UTC().Truncate(24*time.Hour).AddDate(0, 0, p.AfterDays)
+}
+
+// escalate carries out action on l and notifies about it; marking a loan
+// lost also marks its item lost.
+func (s *Service) escalate(ctx context.Context, action model.EscalationAction, l model.Loan, now time.Time) (model.Loan, error) {
+	ev := model.Event{Type: model.EventLoanReminder, BookID: l.BookID, At: now}
+	switch action {
+	case model.EscalationEscalate:
+		ev.Type = model.EventLoanEscalated
+	case model.EscalationMarkLost:
+		ev.Type = model.EventLoanLost
+		lost, err := s.Loans.MarkLost(ctx, l.ID, now)
+		if err != nil {
+			return l, err
+		}
+		l = lost
+		if err := s.loseItem(ctx, l); err != nil {
+			return l, err
+		}
+	}
+	if b, err := s.getBook(ctx, l.BookID); err == nil {
+		ev.Book = b
+	}
+	ev.Loan = &l
+	return l, s.notify(ctx, ev)
+}
+
+// loseItem sets the condition of the item lent by l to lost. Books in the
+// trash and items removed meanwhile are left alone.
+func (s *Service) loseItem(ctx context.Context, l model.Loan) error {
+	if l.Barcode == "" {
+		return nil
+	}
+	_, err := s.setItemCondition(ctx, l.BookID, l.Barcode, model.ItemLost)
+	return err
+}
+
+// WatchEscalations applies the escalation policies every interval until ctx
+// is done.
+func (s *Service) WatchEscalations(ctx context.Context, interval time.Duration, log *slog.Logger) {
+	every(ctx, interval, func(now time.Time) {
+		n, err := s.RunEscalations(ctx, now)
+		if err != nil && ctx.Err() == nil {
+			log.With("error", err).Warn("loan escalation failed", "applied", n)
+			return
+		}
+		if n > 0 {
+			log.Info("loan escalation policies applied", "applied", n)
+		}
+	})
+}
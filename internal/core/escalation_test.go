@@ -0,0 +1,93 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunEscalations(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	_, err := svc.CreateEscalationPolicy(ctx, model.EscalationPolicy{Action: model.EscalationRemind})
+	assert.ErrorIs(t, err, model.ErrNotFound, "lending is off")
+
+	svc.Loans = adapter.NewLoanRepo()
+	svc.Escalations = adapter.NewEscalationRepo()
+	notes := &recordNotifier{}
+	svc.Notifier = notes
+	_, err = svc.CreateEscalationPolicy(ctx, model.EscalationPolicy{Action: "fine", AfterDays: -1})
+	var ve model.ValidationErrors
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, ve, 2, "action and after_days")
+
+	lost, err := svc.CreateEscalationPolicy(ctx, model.EscalationPolicy{Name: " lost ", Action: model.EscalationMarkLost, AfterDays: 30})
+	require.NoError(t, err)
+	assert.Equal(t, "lost", lost.Name)
+	remind, err := svc.CreateEscalationPolicy(ctx, model.EscalationPolicy{Action: model.EscalationRemind})
+	require.NoError(t, err)
+	escalate, err := svc.CreateEscalationPolicy(ctx, model.EscalationPolicy{Action: model.EscalationEscalate, AfterDays: 3})
+	require.NoError(t, err)
+	policies, err := svc.ListEscalationPolicies(ctx)
+	require.NoError(t, err)
+	require.Len(t, policies, 3)
+	assert.Equal(t, []string{remind.ID, escalate.ID, lost.ID}, []string{policies[0].ID, policies[1].ID, policies[2].ID})
+
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	_, err = svc.AddItem(ctx, b.ID, model.Item{Barcode: "B-1"})
+	require.NoError(t, err)
+	l, err := svc.CheckoutBook(ctx, b.ID, "card-1", nil)
+	require.NoError(t, err)
+	dueDay := l.DueAt.UTC().Truncate(24 * time.Hour)
+
+	n, err := svc.RunEscalations(ctx, dueDay.Add(-time.Second))
+	require.NoError(t, err)
+	assert.Zero(t, n, "not due yet")
+	n, err = svc.RunEscalations(ctx, dueDay)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.Len(t, notes.events, 1)
+	assert.Equal(t, model.EventLoanReminder, notes.events[0].Type)
+	assert.Equal(t, l.ID, notes.events[0].Loan.ID)
+	assert.Equal(t, "Dune", notes.events[0].Book.Title)
+	n, err = svc.RunEscalations(ctx, dueDay.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, n, "a policy acts once per loan")
+
+	at := dueDay.AddDate(0, 0, 31)
+	n, err = svc.RunEscalations(ctx, at)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n, "escalated and marked lost")
+	require.Len(t, notes.events, 3)
+	assert.Equal(t, model.EventLoanEscalated, notes.events[1].Type)
+	assert.Equal(t, model.EventLoanLost, notes.events[2].Type)
+
+	got, err := svc.GetLoan(ctx, l.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.LostAt)
+	assert.Equal(t, at, *got.LostAt)
+	assert.Nil(t, got.ReturnedAt, "a lost loan stays active")
+	book, err := svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ItemLost, book.Items[0].Condition)
+	assert.Equal(t, 1, book.Lending.CheckedOut)
+	lostLoans, err := svc.ListLoans(ctx, model.LoanQuery{Status: model.LoanLost})
+	require.NoError(t, err)
+	assert.Len(t, lostLoans, 1)
+
+	require.NoError(t, svc.DeleteEscalationPolicy(ctx, lost.ID))
+	assert.ErrorIs(t, svc.DeleteEscalationPolicy(ctx, lost.ID), model.ErrNotFound)
+	runs, err := svc.EscalationRuns(ctx, lost.ID)
+	require.NoError(t, err)
+	require.Len(t, runs, 1, "runs outlive their policy")
+	assert.Equal(t, model.EscalationRun{PolicyID: lost.ID, LoanID: l.ID, BookID: b.ID, Borrower: "card-1", Action: model.EscalationMarkLost, At: at}, runs[0])
+}
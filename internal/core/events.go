@@ -0,0 +1,154 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"sync"
+	"time"
+)
+
+// eventBus fans catalog events out to in-process subscribers such as live
+// dashboard connections. Publishing never blocks: a subscriber whose buffer
+// is full misses the event, so subscribers treat events as a cue to re-read
+// state rather than as a log. A subscriber of a tenant only gets the events
+// of that tenant. The zero value is ready to use.
+type eventBus struct {
+	mu   sync.Mutex
+	next int
+	subs map[int]subscriber
+}
+
+type subscriber struct {
+	tenant string // "" for every tenant
+	ch     chan model.Event
+}
+
+func (b *eventBus) subscribe(tenant string, buffer int) (<-chan model.Event, func()) {
+	ch := make(chan model.Event, buffer)
+	b.mu.Lock()
+	if b.subs == nil {
+		b.subs = make(map[int]subscriber)
+	}
+	id := b.next
+	b.next++
+	b.subs[id] = subscriber{tenant: tenant, ch: ch}
+	b.mu.Unlock()
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, id)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// publish delivers ev, which happened in tenant, to its subscribers.
+func (b *eventBus) publish(tenant string, ev model.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, sub := range b.subs {
+		if sub.tenant != "" && sub.tenant != tenant {
+			continue
+		}
+		select {
+		case sub.ch <- ev:
+		default:
+		}
+	}
+}
+
+// importCounter counts the running bulk imports per tenant. The zero value
+// is ready to use.
+type importCounter struct {
+	mu       sync.Mutex
+	byTenant map[string]int
+}
+
+func (c *importCounter) add(tenant string, n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.byTenant == nil {
+		c.byTenant = make(map[string]int)
+	}
+	if c.byTenant[tenant] += n; c.byTenant[tenant] == 0 {
+		delete(c.byTenant, tenant)
+	}
+}
+
+// running returns the imports running in tenant, or in every tenant for "".
+func (c *importCounter) running(tenant string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if tenant != "" {
+		return c.byTenant[tenant]
+	}
+	n := 0
+	for _, m := range c.byTenant {
+		n += m
+	}
+	return n
+}
+
+// Subscribe delivers the catalog events of ctx's tenant published from now
+// on, such as created and deleted books and import progress, until cancel
+// is called; cancel closes the channel. Outside a tenant it delivers the
+// events of every tenant. Events that do not fit in buffer are dropped for
+// this subscriber.
+func (s *Service) Subscribe(ctx context.Context, buffer int) (events <-chan model.Event, cancel func()) {
+	return s.events.subscribe(model.TenantFromContext(ctx), buffer)
+}
+
+// StartImport counts a bulk import into ctx's tenant as active until done
+// is called, for the live counters; done may be called more than once.
+// Finishing an import starts a cover prefetch when one is configured.
+func (s *Service) StartImport(ctx context.Context) (done func()) {
+	tenant := model.TenantFromContext(ctx)
+	s.imports.add(tenant, 1)
+	s.events.publish(tenant, model.Event{Type: model.EventImportStarted, At: time.Now()})
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.imports.add(tenant, -1)
+			s.events.publish(tenant, model.Event{Type: model.EventImportFinished, At: time.Now()})
+			if s.Prefetch != nil {
+				s.Prefetch.Start()
+			}
+		})
+	}
+}
+
+// CatalogCounters returns the number of books of ctx's tenant, how many of
+// them were created today and how many imports into it are running.
+func (s *Service) CatalogCounters(ctx context.Context) (model.CatalogCounters, error) {
+	return s.counters(ctx, time.Now())
+}
+
+func (s *Service) counters(ctx context.Context, now time.Time) (model.CatalogCounters, error) {
+	c := model.CatalogCounters{ActiveImports: s.imports.running(model.TenantFromContext(ctx)), At: now}
+	total, err := s.Repo.Count(ctx, model.ListQuery{})
+	if err != nil {
+		return model.CatalogCounters{}, err
+	}
+	c.TotalBooks = total
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	// newest first, so the walk stops at the first book from before today
+	q := model.ListQuery{Sort: []model.SortKey{{Field: "created_at", Desc: true}}, Page: 1, PageSize: exportPageSize}
+	for {
+		page, err := s.Repo.List(ctx, q)
+		if err != nil {
+			return model.CatalogCounters{}, err
+		}
+		for _, b := range page.Data {
+			if b.CreatedAt.Before(midnight) {
+				return c, nil
+			}
+			c.AddedToday++
+		}
+		if page.NextCursor == "" {
+			return c, nil
+		}
+		q.Cursor = page.NextCursor
+	}
+}
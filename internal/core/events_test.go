@@ -0,0 +1,78 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/factory"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogCounters(t *testing.T) {
+	ctx := context.Background()
+	repo := adapter.NewBookRepo()
+	svc := NewService(repo, nil)
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	f := factory.New(1)
+	for _, at := range []time.Time{
+		now.Add(-48 * time.Hour),
+		now.Add(-15*time.Hour - time.Second), // 23:59:59 the day before
+		now.Add(-15 * time.Hour),             // midnight
+		now.Add(-time.Minute),
+	} {
+		_, err := repo.Create(ctx, f.Book(factory.WithCreatedAt(at)))
+		require.NoError(t, err)
+	}
+
+	c, err := svc.counters(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, model.CatalogCounters{TotalBooks: 4, AddedToday: 2, At: now}, c)
+
+	done := svc.StartImport(ctx)
+	c, err = svc.counters(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, c.ActiveImports)
+	done()
+	done()
+	c, err = svc.counters(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, c.ActiveImports)
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), nil)
+	events, cancel := svc.Subscribe(ctx, 8)
+	full, cancelFull := svc.Subscribe(ctx, 0)
+	defer cancelFull()
+
+	b, err := svc.CreateBook(ctx, factory.New(1).CreateBookInput())
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteBook(ctx, b.ID))
+	svc.StartImport(ctx)()
+
+	var got []string
+	for range 4 {
+		ev := <-events
+		got = append(got, ev.Type+" "+ev.BookID)
+	}
+	assert.Equal(t, []string{
+		model.EventBookCreated + " " + b.ID,
+		model.EventBookDeleted + " " + b.ID,
+		model.EventImportStarted + " ",
+		model.EventImportFinished + " ",
+	}, got)
+	assert.Empty(t, full, "a subscriber that cannot keep up misses events")
+
+	cancel()
+	cancel()
+	_, ok := <-events
+	assert.False(t, ok, "cancel closes the channel")
+	svc.StartImport(ctx)() // publishing after cancel is fine
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+)
+
+const exportPageSize = 200
+
+// ExportBooks calls fn for every book matching q's filters, oldest first
+// unless q sets a sort order, like WalkBooks.
+func (s *Service) ExportBooks(ctx context.Context, q model.ListQuery, fn func(model.Book) error) error {
+	if len(q.Sort) == 0 {
+		q.Sort = []model.SortKey{{Field: "created_at"}}
+	}
+	return s.WalkBooks(ctx, q, fn)
+}
+
+// WalkBooks calls fn for every book matching q's filters, in q's sort order,
+// reading the catalog a page at a time so callers can stream it. q's paging
+// fields are ignored. Pages are chained with cursors, so books created or
+// deleted during the walk do not shift the others.
+func (s *Service) WalkBooks(ctx context.Context, q model.ListQuery, fn func(model.Book) error) error {
+	q.Page, q.PageSize = 1, exportPageSize
+	q.Snapshot, q.SnapshotID, q.Cursor = false, "", ""
+	q.Owner = s.ownerFilter(ctx)
+	if q.Available != nil {
+		lent, err := s.lentOut(ctx)
+		if err != nil {
+			return err
+		}
+		q.LentOut = lent
+	}
+	if sortsByRating(q.Sort) {
+		ratings, err := s.ratings(ctx)
+		if err != nil {
+			return err
+		}
+		q.Ratings = ratings
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		page, err := s.Repo.List(ctx, q)
+		if err != nil {
+			return err
+		}
+		for _, b := range page.Data {
+			if err := fn(b); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		q.Cursor = page.NextCursor
+	}
+}
@@ -0,0 +1,46 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/factory"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportBooks(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	ctx := context.Background()
+	n := exportPageSize*2 + 1
+	for _, in := range factory.New(1).CreateBookInputs(n) {
+		_, err := svc.CreateBook(ctx, in)
+		require.NoError(t, err)
+	}
+
+	seen := make(map[string]bool)
+	var last model.Book
+	err := svc.ExportBooks(ctx, model.ListQuery{}, func(b model.Book) error {
+		assert.False(t, seen[b.ID], "book %s exported twice", b.ID)
+		assert.False(t, b.CreatedAt.Before(last.CreatedAt), "not oldest first")
+		seen[b.ID] = true
+		last = b
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, seen, n)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = svc.ExportBooks(ctx, model.ListQuery{}, func(model.Book) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
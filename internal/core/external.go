@@ -0,0 +1,100 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultExternalResults = 10
+	maxExternalResults     = 50
+	maxExternalQuery       = 200
+)
+
+// ExternalCatalog searches a catalog outside this one, such as Open
+// Library, for books to import.
+type ExternalCatalog interface {
+	// SearchBooks returns up to limit books matching q, a title, an author
+	// or both, best match first.
+	SearchBooks(ctx context.Context, q string, limit int) ([]model.ExternalBook, error)
+	// ExternalBook returns the book with key as SearchBooks returned it,
+	// with the ISBN of its preferred edition first. An unknown key fails
+	// with an error matching model.ErrNotFound, a malformed one with a
+	// *model.FieldError.
+	ExternalBook(ctx context.Context, key string) (model.ExternalBook, error)
+}
+
+// SearchExternal searches the external catalog for candidates to import;
+// limit 0 returns 10 of them.
+func (s *Service) SearchExternal(ctx context.Context, q string, limit int) ([]model.ExternalBook, error) {
+	if s.External == nil {
+		return nil, fmt.Errorf("%w: no external catalog configured", model.ErrNotFound)
+	}
+	q = strings.TrimSpace(q)
+	switch {
+	case q == "":
+		return nil, &model.FieldError{Field: "q", Reason: "is required"}
+	case utf8.RuneCountInString(q) > maxExternalQuery:
+		return nil, &model.FieldError{Field: "q", Reason: fmt.Sprintf("must be at most %d characters", maxExternalQuery)}
+	case limit < 0 || limit > maxExternalResults:
+		return nil, &model.FieldError{Field: "limit", Reason: fmt.Sprintf("must be between 1 and %d", maxExternalResults)}
+	case limit == 0:
+		limit = defaultExternalResults
+	}
+	books, err := s.External.SearchBooks(ctx, q, limit)
+	if err != nil {
+		return nil, externalErr(ctx, err)
+	}
+	if len(books) > limit {
+		books = books[:limit]
+	}
+	return books, nil
+}
+
+// ImportExternal creates the external book in.Key, as the edition with
+// in.ISBN or else its preferred edition, enriched by ISBN like any book
+// created with Enrich. An ISBN already in the catalog fails with
+// model.ErrConflict.
+func (s *Service) ImportExternal(ctx context.Context, in model.ExternalImport) (model.Book, error) {
+	if s.External == nil {
+		return model.Book{}, fmt.Errorf("%w: no external catalog configured", model.ErrNotFound)
+	}
+	in.Key = strings.TrimSpace(in.Key)
+	in.ISBN = strings.TrimSpace(in.ISBN)
+	if in.Key == "" {
+		return model.Book{}, &model.FieldError{Field: "key", Reason: "is required"}
+	}
+	b, err := s.External.ExternalBook(ctx, in.Key)
+	if err != nil {
+		return model.Book{}, externalErr(ctx, err)
+	}
+	isbn := in.ISBN
+	if isbn == "" && len(b.ISBNs) > 0 {
+		isbn = b.ISBNs[0]
+	}
+	cb := model.CreateBookInput{Authors: b.Authors, Tags: in.Tags}
+	if b.Title != "" {
+		cb.Title = &b.Title
+	}
+	if b.CoverURL != "" {
+		cb.CoverURL = &b.CoverURL
+	}
+	if isbn != "" {
+		cb.ISBN = &isbn
+		cb.Enrich = true
+	}
+	return s.CreateBook(ctx, cb)
+}
+
+// externalErr passes on what the caller got wrong and reports any other
+// failure of the external catalog as upstream.
+func externalErr(ctx context.Context, err error) error {
+	if errors.Is(err, model.ErrNotFound) || errors.Is(err, model.ErrValidation) || ctx.Err() != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %v", model.ErrUpstream, err)
+}
@@ -0,0 +1,100 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeExternal struct {
+	books map[string]model.ExternalBook
+	err   error
+	limit int
+}
+
+func (f *fakeExternal) SearchBooks(_ context.Context, _ string, limit int) ([]model.ExternalBook, error) {
+	f.limit = limit
+	var out []model.ExternalBook
+	for _, b := range f.books {
+		out = append(out, b)
+	}
+	return out, f.err
+}
+
+func (f *fakeExternal) ExternalBook(_ context.Context, key string) (model.ExternalBook, error) {
+	if f.err != nil {
+		return model.ExternalBook{}, f.err
+	}
+	b, ok := f.books[key]
+	if !ok {
+		return model.ExternalBook{}, model.ErrNotFound
+	}
+	return b, nil
+}
+
+func TestSearchExternal(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	_, err := svc.SearchExternal(ctx, "dune", 0)
+	assert.ErrorIs(t, err, model.ErrNotFound, "no catalog configured")
+
+	src := &fakeExternal{books: map[string]model.ExternalBook{"/works/OL1W": {Key: "/works/OL1W", Title: "Dune"}}}
+	svc.External = src
+	books, err := svc.SearchExternal(ctx, " dune ", 0)
+	require.NoError(t, err)
+	assert.Len(t, books, 1)
+	assert.Equal(t, 10, src.limit)
+
+	for _, tc := range []struct {
+		q     string
+		limit int
+	}{{"", 0}, {strings.Repeat("x", 201), 0}, {"dune", 51}, {"dune", -1}} {
+		_, err := svc.SearchExternal(ctx, tc.q, tc.limit)
+		assert.ErrorIs(t, err, model.ErrValidation, "%+v", tc)
+	}
+
+	src.err = errors.New("connection reset")
+	_, err = svc.SearchExternal(ctx, "dune", 5)
+	assert.ErrorIs(t, err, model.ErrUpstream)
+}
+
+func TestImportExternal(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	svc.External = &fakeExternal{books: map[string]model.ExternalBook{
+		"/works/OL1W": {Key: "/works/OL1W", Title: "Dune", Authors: []string{"Frank Herbert"},
+			ISBNs: []string{"9780441172719", "9780593099322"}, CoverURL: "https://covers.example/1.jpg"},
+		"/works/OL2W": {Key: "/works/OL2W", Title: "Zine"},
+	}}
+
+	b, err := svc.ImportExternal(ctx, model.ExternalImport{Key: "/works/OL1W", Tags: []string{"sf"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Dune", b.Title)
+	assert.Equal(t, []string{"Frank Herbert"}, b.Authors)
+	require.NotNil(t, b.ISBN)
+	assert.Equal(t, "9780441172719", *b.ISBN, "the preferred edition")
+	assert.Equal(t, []string{"sf"}, b.Tags)
+
+	b, err = svc.ImportExternal(ctx, model.ExternalImport{Key: "/works/OL1W", ISBN: "978-0-593-09932-2"})
+	require.NoError(t, err)
+	assert.Equal(t, "9780593099322", *b.ISBN)
+	_, err = svc.ImportExternal(ctx, model.ExternalImport{Key: "/works/OL1W"})
+	assert.ErrorIs(t, err, model.ErrConflict)
+
+	b, err = svc.ImportExternal(ctx, model.ExternalImport{Key: "/works/OL2W"})
+	require.NoError(t, err)
+	assert.Nil(t, b.ISBN)
+
+	_, err = svc.ImportExternal(ctx, model.ExternalImport{Key: "/works/OL9W"})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	_, err = svc.ImportExternal(ctx, model.ExternalImport{Key: " "})
+	assert.ErrorIs(t, err, model.ErrValidation)
+}
@@ -0,0 +1,166 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeeRepository keeps the borrowers' fees ledgers. Add fails with
+// model.ErrConflict for a second fine of a loan and for a payment or
+// waiver above the borrower's balance, so that two credits cannot
+// together pay more than is owed.
+type FeeRepository interface {
+	Add(ctx context.Context, e model.FeeEntry) (model.FeeEntry, error)
+	// List returns the entries of a borrower, oldest first.
+	List(ctx context.Context, borrower string) ([]model.FeeEntry, error)
+	// Reassign moves the ledger of borrower from to borrower to and
+	// returns how many entries it moved.
+	Reassign(ctx context.Context, from, to string) (int, error)
+}
+
+const maxFeeNoteLen = 500
+
+// NewFinePolicy builds a fine policy from amounts in the currency's major
+// unit, e.g. 0.25 EUR a day capped at 10.
+func NewFinePolicy(perDay, limit float64, graceDays int, currency string) (*model.FinePolicy, error) {
+	p := &model.FinePolicy{
+		PerDay:    model.Price{Amount: int64(math.Round(perDay * 100)), Currency: strings.ToUpper(strings.TrimSpace(currency))},
+		Cap:       int64(math.Round(limit * 100)),
+		GraceDays: graceDays,
+	}
+	var v validator
+	v.check(p.PerDay.Amount > 0, "per_day", "must be positive")
+	v.check(len(p.PerDay.Currency) == 3 && strings.Trim(p.PerDay.Currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") == "", "currency", "must be a 3-letter ISO 4217 code")
+	v.check(p.Cap >= 0, "cap", "must not be negative")
+	v.check(p.GraceDays >= 0, "grace_days", "must not be negative")
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// LoanFine returns how many days a loan is late and what it owes: so far
+// for an active loan, as charged for a returned one.
+func (s *Service) LoanFine(ctx context.Context, id string) (int, model.Price, error) {
+	if err := s.finesOn(); err != nil {
+		return 0, model.Price{}, err
+	}
+	l, err := s.Loans.Get(ctx, id)
+	if err != nil {
+		return 0, model.Price{}, err
+	}
+	fine := model.Price{Currency: s.Fines.PerDay.Currency}
+	if l.ReturnedAt == nil {
+		days := lateDays(l, time.Now().UTC())
+		fine.Amount = s.fineFor(days)
+		return days, fine, nil
+	}
+	entries, err := s.Fees.List(ctx, l.Borrower)
+	if err != nil {
+		return 0, model.Price{}, err
+	}
+	if i := slices.IndexFunc(entries, func(e model.FeeEntry) bool { return e.Kind == model.FeeFine && e.LoanID == l.ID }); i >= 0 {
+		fine = entries[i].Amount
+	}
+	return lateDays(l, *l.ReturnedAt), fine, nil
+}
+
+// FeeAccount returns the fees ledger of a borrower; one never fined has an
+// empty one.
+func (s *Service) FeeAccount(ctx context.Context, borrower string) (model.FeeAccount, error) {
+	if err := s.finesOn(); err != nil {
+		return model.FeeAccount{}, err
+	}
+	if err := s.checkBorrower(ctx, borrower); err != nil {
+		return model.FeeAccount{}, err
+	}
+	entries, err := s.Fees.List(ctx, borrower)
+	if err != nil {
+		return model.FeeAccount{}, err
+	}
+	a := model.FeeAccount{Borrower: borrower, Balance: model.Price{Currency: s.Fines.PerDay.Currency}, Entries: entries}
+	for _, e := range entries {
+		a.Balance.Amount += e.Signed()
+	}
+	return a, nil
+}
+
+// RecordFee credits a payment or waiver to a borrower, in the currency of
+// the fine policy. A waiver may name the loan it forgives.
+func (s *Service) RecordFee(ctx context.Context, e model.FeeEntry) (model.FeeEntry, error) {
+	if err := s.finesOn(); err != nil {
+		return model.FeeEntry{}, err
+	}
+	if err := s.checkBorrower(ctx, e.Borrower); err != nil {
+		return model.FeeEntry{}, err
+	}
+	var v validator
+	e.Note = strings.TrimSpace(e.Note)
+	v.check(e.Kind == model.FeePayment || e.Kind == model.FeeWaiver, "kind", "must be payment or waiver")
+	v.check(e.Amount.Amount > 0, "amount", "must be positive")
+	v.check(len(e.Note) <= maxFeeNoteLen, "note", fmt.Sprintf("must be at most %d characters", maxFeeNoteLen))
+	if e.LoanID != "" {
+		l, err := s.Loans.Get(ctx, e.LoanID)
+		v.check(err == nil && l.Borrower == e.Borrower, "loan_id", "must be a loan of the borrower")
+	}
+	if err := v.err(); err != nil {
+		return model.FeeEntry{}, err
+	}
+	e.ID = uuid.NewString()
+	e.Tenant = model.TenantFromContext(ctx)
+	e.Amount.Currency = s.Fines.PerDay.Currency
+	e.At = time.Now().UTC()
+	return s.Fees.Add(ctx, e)
+}
+
+// chargeFine adds the fine of a returned loan to its borrower's ledger.
+func (s *Service) chargeFine(ctx context.Context, l model.Loan) error {
+	if s.finesOn() != nil || l.ReturnedAt == nil {
+		return nil
+	}
+	amount := s.fineFor(lateDays(l, *l.ReturnedAt))
+	if amount == 0 {
+		return nil
+	}
+	_, err := s.Fees.Add(ctx, model.FeeEntry{
+		ID:       uuid.NewString(),
+		Borrower: l.Borrower,
+		Kind:     model.FeeFine,
+		Amount:   model.Price{Amount: amount, Currency: s.Fines.PerDay.Currency},
+		LoanID:   l.ID,
+		At:       *l.ReturnedAt,
+		Tenant:   l.Tenant,
+	})
+	return err
+}
+
+func (s *Service) finesOn() error {
+	if s.Fines == nil || s.Fees == nil || s.Loans == nil {
+		return fmt.Errorf("%w: fines are not configured", model.ErrNotFound)
+	}
+	return nil
+}
+
+// lateDays counts the days, started or not, l runs past its due time at.
+func lateDays(l model.Loan, at time.Time) int {
+	if !at.After(l.DueAt) {
+		return 0
+	}
+	return int((at.Sub(l.DueAt) + 24*time.Hour - 1) / (24 * time.Hour))
+}
+
+// fineFor is the fine for a loan days late under the fine policy.
+func (s *Service) fineFor(days int) int64 {
+	fine := int64(max(days-s.Fines.GraceDays, 0)) * s.Fines.PerDay.Amount
+	if s.Fines.Cap > 0 {
+		fine = min(fine, s.Fines.Cap)
+	}
+	return fine
+}
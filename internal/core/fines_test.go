@@ -0,0 +1,96 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFinePolicy(t *testing.T) {
+	p, err := NewFinePolicy(0.25, 5, 2, " eur")
+	require.NoError(t, err)
+	assert.Equal(t, model.FinePolicy{PerDay: model.Price{Amount: 25, Currency: "EUR"}, Cap: 500, GraceDays: 2}, *p)
+	_, err = NewFinePolicy(0, -1, -1, "euro")
+	var ve model.ValidationErrors
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, ve, 4)
+}
+
+func TestLateDays(t *testing.T) {
+	due := time.Date(2026, 3, 1, 23, 59, 59, 0, time.UTC)
+	l := model.Loan{DueAt: due}
+	assert.Zero(t, lateDays(l, due))
+	assert.Equal(t, 1, lateDays(l, due.Add(time.Second)))
+	assert.Equal(t, 1, lateDays(l, due.Add(24*time.Hour)))
+	assert.Equal(t, 2, lateDays(l, due.Add(24*time.Hour+time.Second)))
+}
+
+func TestFines(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Loans = adapter.NewLoanRepo()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	_, err = svc.FeeAccount(ctx, "card-1")
+	assert.ErrorIs(t, err, model.ErrNotFound, "fines are off")
+
+	svc.Fines = &model.FinePolicy{PerDay: model.Price{Amount: 25, Currency: "EUR"}, Cap: 100, GraceDays: 1}
+	svc.Fees = adapter.NewFeeRepo()
+	assert.Equal(t, int64(0), svc.fineFor(1), "grace day")
+	assert.Equal(t, int64(50), svc.fineFor(3))
+	assert.Equal(t, int64(100), svc.fineFor(30), "capped")
+
+	// A loan checked out 10 days ago that was due 3 days ago.
+	now := time.Now().UTC()
+	l, err := svc.Loans.Checkout(ctx, model.Loan{ID: "l-1", BookID: b.ID, Borrower: "card-1", CheckedOutAt: now.AddDate(0, 0, -10), DueAt: now.Add(-72*time.Hour + time.Hour)}, 1)
+	require.NoError(t, err)
+	days, fine, err := svc.LoanFine(ctx, l.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, days)
+	assert.Equal(t, model.Price{Amount: 50, Currency: "EUR"}, fine, "so far")
+
+	_, err = svc.ReturnLoan(ctx, l.ID)
+	require.NoError(t, err)
+	days, fine, err = svc.LoanFine(ctx, l.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, days)
+	assert.Equal(t, int64(50), fine.Amount, "as charged")
+	a, err := svc.FeeAccount(ctx, "card-1")
+	require.NoError(t, err)
+	require.Len(t, a.Entries, 1)
+	assert.Equal(t, model.FeeFine, a.Entries[0].Kind)
+	assert.Equal(t, model.Price{Amount: 50, Currency: "EUR"}, a.Balance)
+
+	_, err = svc.RecordFee(ctx, model.FeeEntry{Borrower: "card-1", Kind: model.FeeFine, LoanID: "l-2"})
+	var ve model.ValidationErrors
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, ve, 3, "kind, amount and loan")
+	_, err = svc.RecordFee(ctx, model.FeeEntry{Borrower: "card-1", Kind: model.FeePayment, Amount: model.Price{Amount: 60}})
+	assert.ErrorIs(t, err, model.ErrConflict, "more than owed")
+	_, err = svc.RecordFee(ctx, model.FeeEntry{Borrower: "card-1", Kind: model.FeePayment, Amount: model.Price{Amount: 30}, Note: " cash "})
+	require.NoError(t, err)
+	w, err := svc.RecordFee(ctx, model.FeeEntry{Borrower: "card-1", Kind: model.FeeWaiver, Amount: model.Price{Amount: 20}, LoanID: l.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", w.Amount.Currency)
+	a, err = svc.FeeAccount(ctx, "card-1")
+	require.NoError(t, err)
+	assert.Len(t, a.Entries, 3)
+	assert.Equal(t, "cash", a.Entries[1].Note)
+	assert.Zero(t, a.Balance.Amount)
+
+	on, err := svc.CheckoutBook(ctx, b.ID, "card-2", nil)
+	require.NoError(t, err)
+	_, err = svc.ReturnLoan(ctx, on.ID)
+	require.NoError(t, err)
+	a, err = svc.FeeAccount(ctx, "card-2")
+	require.NoError(t, err)
+	assert.Empty(t, a.Entries, "returned on time")
+}
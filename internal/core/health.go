@@ -0,0 +1,27 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+)
+
+// CircuitBreaker reports the state of the breaker around a provider.
+type CircuitBreaker interface {
+	Status() model.CircuitBreakerStatus
+}
+
+// Health reports whether the book storage can be read.
+func (s *Service) Health(ctx context.Context) error {
+	_, err := s.Repo.List(ctx, model.ListQuery{Page: 1, PageSize: 1})
+	return err
+}
+
+// CircuitBreakers returns the state of every circuit breaker, as
+// configured.
+func (s *Service) CircuitBreakers(_ context.Context) ([]model.CircuitBreakerStatus, error) {
+	out := make([]model.CircuitBreakerStatus, 0, len(s.Breakers))
+	for _, b := range s.Breakers {
+		out = append(out, b.Status())
+	}
+	return out, nil
+}
@@ -0,0 +1,184 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HoldRepository keeps the hold queues of books. Place fails with
+// model.ErrConflict when the borrower already holds the book; Get, Ready
+// and Delete fail with model.ErrNotFound for an unknown hold, and Ready
+// with model.ErrConflict for one that is ready already, so that two
+// returns cannot serve the same hold.
+type HoldRepository interface {
+	Place(ctx context.Context, h model.Hold) (model.Hold, error)
+	Get(ctx context.Context, id string) (model.Hold, error)
+	// List returns the holds of a book in queue order, oldest first.
+	List(ctx context.Context, bookID string) ([]model.Hold, error)
+	Ready(ctx context.Context, id string, at time.Time) (model.Hold, error)
+	Delete(ctx context.Context, id string) error
+	// ListByBorrower returns the holds of a borrower, oldest first.
+	ListByBorrower(ctx context.Context, borrower string) ([]model.Hold, error)
+	// Reassign moves the holds of borrower from to borrower to, keeping
+	// the earlier of two holds of the same book, and returns how many it
+	// moved.
+	Reassign(ctx context.Context, from, to string) (int, error)
+}
+
+// PlaceHold queues borrower for a book whose copies are all lent out or
+// kept for earlier holders; while a copy is free it is to be checked out
+// instead.
+func (s *Service) PlaceHold(ctx context.Context, bookID, borrower string) (model.Hold, error) {
+	if s.Loans == nil || s.Holds == nil {
+		return model.Hold{}, fmt.Errorf("%w: holds are not configured", model.ErrNotFound)
+	}
+	borrower = strings.TrimSpace(borrower)
+	var v validator
+	v.check(borrower != "", "borrower", "must not be empty")
+	v.check(len(borrower) <= maxBorrowerLen, "borrower", fmt.Sprintf("must be at most %d characters", maxBorrowerLen))
+	v.check(borrower == "" || s.checkBorrower(ctx, borrower) == nil, "borrower", "must be a registered borrower")
+	if err := v.err(); err != nil {
+		return model.Hold{}, err
+	}
+	b, err := s.getBook(ctx, bookID)
+	if err != nil {
+		return model.Hold{}, model.ErrNotFound
+	}
+	active, holds, err := s.loanState(ctx, b.ID)
+	if err != nil {
+		return model.Hold{}, err
+	}
+	for _, l := range active {
+		if l.Borrower == borrower {
+			return model.Hold{}, fmt.Errorf("%w: %s has book %s on loan", model.ErrConflict, borrower, b.ID)
+		}
+	}
+	if free := lendableCopies(b) - len(active) - readyHolds(holds, ""); free > 0 {
+		return model.Hold{}, fmt.Errorf("%w: %d copies of book %s are available; check one out instead", model.ErrConflict, free, b.ID)
+	}
+	h, err := s.Holds.Place(ctx, model.Hold{ID: uuid.NewString(), BookID: b.ID, Borrower: borrower, PlacedAt: time.Now().UTC(), Tenant: b.Tenant})
+	if err != nil {
+		return model.Hold{}, err
+	}
+	h.Position = len(holds) + 1
+	return h, nil
+}
+
+// ListHolds returns the queue of a book with each hold's position.
+func (s *Service) ListHolds(ctx context.Context, bookID string) ([]model.Hold, error) {
+	if _, err := s.getBook(ctx, bookID); err != nil {
+		return nil, model.ErrNotFound
+	}
+	if s.Holds == nil {
+		return nil, nil
+	}
+	holds, err := s.Holds.List(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range holds {
+		holds[i].Position = i + 1
+	}
+	return holds, nil
+}
+
+// CancelHold takes a borrower off a queue; a copy kept for them goes to
+// the next holder.
+func (s *Service) CancelHold(ctx context.Context, id string) error {
+	if s.Holds == nil {
+		return fmt.Errorf("%w: hold %s", model.ErrNotFound, id)
+	}
+	h, err := s.Holds.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.Holds.Delete(ctx, id); err != nil {
+		return err
+	}
+	if h.ReadyAt == nil {
+		return nil
+	}
+	return s.serveHolds(ctx, h.BookID)
+}
+
+// serveHolds gives the copies of a book that are neither lent nor kept
+// to the oldest waiting holders: with HoldAutoCheckout the copy is lent to
+// the holder and the hold ends, otherwise it is kept for them. Either way
+// the holder is notified with a model.EventHoldReady; a notification that
+// fails is kept as a dead letter when configured and does not undo the
+// hold's change.
+func (s *Service) serveHolds(ctx context.Context, bookID string) error {
+	if s.Holds == nil {
+		return nil
+	}
+	b, err := s.getBook(ctx, bookID)
+	if err != nil {
+		return nil // trashed books keep their queue until restored
+	}
+	active, holds, err := s.loanState(ctx, bookID)
+	if err != nil {
+		return err
+	}
+	ready := readyHolds(holds, "")
+	free := lendableCopies(b) - len(active) - ready
+	now := time.Now().UTC()
+	var errs []error
+	for _, h := range holds {
+		if free <= 0 {
+			break
+		}
+		if h.ReadyAt != nil {
+			continue
+		}
+		if s.HoldAutoCheckout {
+			l, err := s.Loans.Checkout(ctx, model.Loan{
+				ID: uuid.NewString(), BookID: b.ID, Borrower: h.Borrower, CheckedOutAt: now, DueAt: now.Add(s.loanPeriod()),
+				Barcode: freeItem(b, active), Tenant: b.Tenant,
+			}, lendableCopies(b)-ready)
+			if err != nil {
+				return errors.Join(append(errs, err)...)
+			}
+			active = append(active, l)
+			if err := s.Holds.Delete(ctx, h.ID); err != nil {
+				return errors.Join(append(errs, err)...)
+			}
+			h.LoanID = l.ID
+		} else if h, err = s.Holds.Ready(ctx, h.ID, now); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		free--
+		h.Position = 1
+		if err := s.notify(ctx, model.Event{Type: model.EventHoldReady, BookID: b.ID, Book: b, Hold: &h, At: now}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// loanState returns the active loans and the holds of a book; holds is
+// empty when they are not configured.
+func (s *Service) loanState(ctx context.Context, bookID string) (active []model.Loan, holds []model.Hold, err error) {
+	active, err = s.Loans.List(ctx, model.LoanQuery{BookID: bookID, Status: model.LoanActive, Now: time.Now().UTC()})
+	if err != nil || s.Holds == nil {
+		return active, nil, err
+	}
+	holds, err = s.Holds.List(ctx, bookID)
+	return active, holds, err
+}
+
+// readyHolds counts the holds whose copy is kept, except one of borrower.
+func readyHolds(holds []model.Hold, borrower string) int {
+	n := 0
+	for _, h := range holds {
+		if h.ReadyAt != nil && h.Borrower != borrower {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,84 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHolds(t *testing.T) {
+	ctx := context.Background()
+	notes := &recordNotifier{}
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Loans, svc.Holds, svc.Notifier = adapter.NewLoanRepo(), adapter.NewHoldRepo(), notes
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+
+	_, err = svc.PlaceHold(ctx, b.ID, "card-2")
+	assert.ErrorIs(t, err, model.ErrConflict, "the copy is on the shelf")
+	loan, err := svc.CheckoutBook(ctx, b.ID, "card-1", nil)
+	require.NoError(t, err)
+	_, err = svc.PlaceHold(ctx, b.ID, "card-1")
+	assert.ErrorIs(t, err, model.ErrConflict, "the borrower has it")
+
+	second, err := svc.PlaceHold(ctx, b.ID, "card-2")
+	require.NoError(t, err)
+	assert.Equal(t, 1, second.Position)
+	third, err := svc.PlaceHold(ctx, b.ID, "card-3")
+	require.NoError(t, err)
+	assert.Equal(t, 2, third.Position)
+	_, err = svc.PlaceHold(ctx, b.ID, "card-3")
+	assert.ErrorIs(t, err, model.ErrConflict, "already queued")
+
+	_, err = svc.ReturnLoan(ctx, loan.ID)
+	require.NoError(t, err)
+	require.Len(t, notes.events, 1)
+	assert.Equal(t, model.EventHoldReady, notes.events[0].Type)
+	assert.Equal(t, "card-2", notes.events[0].Hold.Borrower)
+	got, err := svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.Lending{Copies: 1, Holds: 2, Ready: 1}, *got.Lending)
+
+	_, err = svc.CheckoutBook(ctx, b.ID, "card-9", nil)
+	assert.ErrorIs(t, err, model.ErrConflict, "the copy is kept for card-2")
+	require.NoError(t, svc.CancelHold(ctx, second.ID))
+	require.Len(t, notes.events, 2, "the kept copy passes on")
+	assert.Equal(t, "card-3", notes.events[1].Hold.Borrower)
+
+	_, err = svc.CheckoutBook(ctx, b.ID, "card-3", nil)
+	require.NoError(t, err)
+	holds, err := svc.ListHolds(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Empty(t, holds, "the loan ends the hold")
+	assert.ErrorIs(t, svc.CancelHold(ctx, third.ID), model.ErrNotFound)
+}
+
+func TestHolds_AutoCheckout(t *testing.T) {
+	ctx := context.Background()
+	notes := &recordNotifier{}
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Loans, svc.Holds, svc.Notifier, svc.HoldAutoCheckout = adapter.NewLoanRepo(), adapter.NewHoldRepo(), notes, true
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	loan, err := svc.CheckoutBook(ctx, b.ID, "card-1", nil)
+	require.NoError(t, err)
+	_, err = svc.PlaceHold(ctx, b.ID, "card-2")
+	require.NoError(t, err)
+
+	_, err = svc.ReturnLoan(ctx, loan.ID)
+	require.NoError(t, err)
+	require.Len(t, notes.events, 1)
+	next, err := svc.GetLoan(ctx, notes.events[0].Hold.LoanID)
+	require.NoError(t, err)
+	assert.Equal(t, "card-2", next.Borrower)
+	holds, err := svc.ListHolds(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Empty(t, holds)
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// IdempotencyRepository remembers the Idempotency-Keys of create requests
+// until they expire.
+type IdempotencyRepository interface {
+	// Reserve claims key for a request with the given fingerprint and
+	// reports true. When the key is already claimed it returns the record
+	// of the claim and false instead.
+	Reserve(ctx context.Context, key, fingerprint string) (model.IdempotencyRecord, bool, error)
+	// Complete records the book the request that reserved key created.
+	Complete(ctx context.Context, key, bookID string) error
+	// Release forgets a reserved key whose request failed, so that it can
+	// be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// maxIdempotencyKeyLen bounds the length of an Idempotency-Key.
+const maxIdempotencyKeyLen = 255
+
+// CreateBookOnce creates a book like CreateBook unless key was sent before
+// with the same input: then it returns the book that request created and
+// true, so a retried request does not create a duplicate. Reusing a key for
+// a different input, or while its first request is still in progress,
+// fails with model.ErrConflict. Every user of every tenant has keys of
+// their own, and a replay only returns a book the caller can still read.
+// An empty key, or no Idempotency repository, makes it a plain CreateBook.
+func (s *Service) CreateBookOnce(ctx context.Context, key string, in model.CreateBookInput) (model.Book, bool, error) {
+	if key == "" || s.Idempotency == nil {
+		b, err := s.CreateBook(ctx, in)
+		return b, false, err
+	}
+	if len(key) > maxIdempotencyKeyLen {
+		return model.Book{}, false, &model.FieldError{Field: "Idempotency-Key", Reason: fmt.Sprintf("must be at most %d characters", maxIdempotencyKeyLen)}
+	}
+	fp, err := inputFingerprint(in)
+	if err != nil {
+		return model.Book{}, false, err
+	}
+	stored := idempotencyScope(ctx) + key
+	rec, reserved, err := s.Idempotency.Reserve(ctx, stored, fp)
+	if err != nil {
+		return model.Book{}, false, err
+	}
+	if !reserved {
+		switch {
+		case rec.Fingerprint != fp:
+			return model.Book{}, false, fmt.Errorf("%w: Idempotency-Key %q was sent with a different book", model.ErrConflict, key)
+		case rec.BookID == "":
+			return model.Book{}, false, fmt.Errorf("%w: a request with Idempotency-Key %q is still in progress", model.ErrConflict, key)
+		}
+		b, err := s.getBook(ctx, rec.BookID)
+		if err != nil {
+			return model.Book{}, false, fmt.Errorf("%w: book %s created with Idempotency-Key %q no longer exists", model.ErrConflict, rec.BookID, key)
+		}
+		return b, true, nil
+	}
+
+	b, err := s.CreateBook(ctx, in)
+	if err != nil {
+		if rerr := s.Idempotency.Release(ctx, stored); rerr != nil {
+			err = errors.Join(err, rerr)
+		}
+		return model.Book{}, false, err
+	}
+	if err := s.Idempotency.Complete(ctx, stored, b.ID); err != nil {
+		return b, false, fmt.Errorf("book %s was created but its Idempotency-Key was lost: %w", b.ID, err)
+	}
+	return b, false, nil
+}
+
+// idempotencyScope prefixes the keys of ctx's tenant and user; escaping
+// keeps a "/" in an ID from reaching into another scope.
+func idempotencyScope(ctx context.Context) string {
+	u, _ := model.UserFromContext(ctx)
+	return url.PathEscape(model.TenantFromContext(ctx)) + "/" + url.PathEscape(u.ID) + "/"
+}
+
+func inputFingerprint(in model.CreateBookInput) (string, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
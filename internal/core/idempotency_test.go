@@ -0,0 +1,77 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBookOnce(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	in := model.CreateBookInput{Title: util.GetPtr("Dune"), ISBN: util.GetPtr("9780441013593")}
+
+	// without a repository the key is ignored
+	_, replayed, err := svc.CreateBookOnce(ctx, "k1", in)
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	_, _, err = svc.CreateBookOnce(ctx, "k1", in)
+	assert.ErrorIs(t, err, model.ErrConflict)
+
+	svc.Idempotency = adapter.NewIdempotencyRepo(time.Hour)
+	in.ISBN = util.GetPtr("9780441172719")
+	b, replayed, err := svc.CreateBookOnce(ctx, "k2", in)
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	again, replayed, err := svc.CreateBookOnce(ctx, "k2", in)
+	require.NoError(t, err)
+	assert.True(t, replayed)
+	assert.Equal(t, b.ID, again.ID)
+
+	other := in
+	other.Title = util.GetPtr("Dune Messiah")
+	_, _, err = svc.CreateBookOnce(ctx, "k2", other)
+	assert.ErrorIs(t, err, model.ErrConflict)
+
+	// a failed request releases its key
+	bad := model.CreateBookInput{}
+	_, _, err = svc.CreateBookOnce(ctx, "k3", bad)
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, replayed, err = svc.CreateBookOnce(ctx, "k3", model.CreateBookInput{Title: util.GetPtr("Children of Dune")})
+	require.NoError(t, err)
+	assert.False(t, replayed)
+
+	_, _, err = svc.CreateBookOnce(ctx, string(make([]byte, 256)), bad)
+	assert.ErrorIs(t, err, model.ErrValidation)
+}
+
+func TestCreateBookOnce_Scope(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	svc.Idempotency = adapter.NewIdempotencyRepo(time.Hour)
+	svc.PerUser = true
+	alice := model.WithUser(context.Background(), model.User{ID: "alice"})
+	bob := model.WithUser(context.Background(), model.User{ID: "bob"})
+	in := model.CreateBookInput{Title: util.GetPtr("Dune")}
+
+	a, _, err := svc.CreateBookOnce(alice, "k", in)
+	require.NoError(t, err)
+	// another user's key of the same name is a key of its own
+	b, replayed, err := svc.CreateBookOnce(bob, "k", in)
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	assert.NotEqual(t, a.ID, b.ID)
+	assert.Equal(t, "bob", b.Owner)
+
+	// a trashed book is not replayed
+	require.NoError(t, svc.DeleteBook(alice, a.ID))
+	_, _, err = svc.CreateBookOnce(alice, "k", in)
+	assert.ErrorIs(t, err, model.ErrConflict)
+}
@@ -0,0 +1,232 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportRepository keeps bulk import jobs with their rows, so that an
+// import stopped by shutdown resumes on the next start.
+type ImportRepository interface {
+	Create(ctx context.Context, job model.ImportJob, rows []model.ImportRow) error
+	Get(ctx context.Context, id string) (model.ImportJob, error)
+	// Rows returns up to n rows of job id from row from on.
+	Rows(ctx context.Context, id string, from, n int) ([]model.ImportRow, error)
+	// Save checkpoints job; once it is done its rows may be dropped.
+	Save(ctx context.Context, job model.ImportJob) error
+	// Unfinished returns the jobs not done, oldest first.
+	Unfinished(ctx context.Context) ([]model.ImportJob, error)
+}
+
+// importChunk is how many rows an import reads from the repository at once.
+const importChunk = 100
+
+// importRuns tracks the imports running in the background. The zero value
+// is ready to use.
+type importRuns struct {
+	mu      sync.Mutex
+	cancel  map[string]context.CancelFunc
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+// start runs fn in the background with a context cancelled by stop, unless
+// the runs are stopped.
+func (r *importRuns) start(id string, fn func(ctx context.Context)) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return false
+	}
+	if r.cancel == nil {
+		r.cancel = map[string]context.CancelFunc{}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel[id] = cancel
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer func() {
+			r.mu.Lock()
+			delete(r.cancel, id)
+			r.mu.Unlock()
+			cancel()
+		}()
+		fn(ctx)
+	}()
+	return true
+}
+
+func (r *importRuns) stop() {
+	r.mu.Lock()
+	r.stopped = true
+	for _, cancel := range r.cancel {
+		cancel()
+	}
+	r.mu.Unlock()
+	r.wg.Wait()
+}
+
+// StartBookImport creates the books of rows in the background, as CreateBook
+// and ImportReadingState do for the caller, and returns the job reporting
+// on it. After StopImports the job is kept paused, for the next start.
+func (s *Service) StartBookImport(ctx context.Context, rows []model.ImportRow) (model.ImportJob, error) {
+	if s.Imports == nil {
+		return model.ImportJob{}, fmt.Errorf("%w: imports are not configured", model.ErrNotFound)
+	}
+	now := time.Now().UTC()
+	job := model.ImportJob{
+		ID:        uuid.NewString(),
+		Tenant:    model.TenantFromContext(ctx),
+		Actor:     model.ActorFromContext(ctx),
+		Status:    model.ImportRunning,
+		Rows:      len(rows),
+		Errors:    []model.ImportRowError{},
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	job.User, _ = model.UserFromContext(ctx)
+	if err := s.Imports.Create(ctx, job, rows); err != nil {
+		return model.ImportJob{}, err
+	}
+	return s.launchImport(job)
+}
+
+// ImportJob returns an import of ctx's tenant.
+func (s *Service) ImportJob(ctx context.Context, id string) (model.ImportJob, error) {
+	if s.Imports == nil {
+		return model.ImportJob{}, model.ErrNotFound
+	}
+	job, err := s.Imports.Get(ctx, id)
+	if err != nil {
+		return model.ImportJob{}, err
+	}
+	if t := model.TenantFromContext(ctx); t != "" && job.Tenant != t {
+		return model.ImportJob{}, model.ErrNotFound
+	}
+	return job, nil
+}
+
+// ResumeImports continues the imports that were paused by shutdown, or cut
+// short by a crash, at their last checkpoint, and returns how many.
+func (s *Service) ResumeImports(ctx context.Context) (int, error) {
+	if s.Imports == nil {
+		return 0, nil
+	}
+	jobs, err := s.Imports.Unfinished(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, job := range jobs {
+		if _, err := s.launchImport(job); err != nil {
+			return 0, err
+		}
+	}
+	return len(jobs), nil
+}
+
+// StopImports stops the running imports after the row each is processing,
+// checkpoints them as paused and waits for them; later imports are paused
+// right away.
+func (s *Service) StopImports() {
+	s.importRuns.stop()
+}
+
+// launchImport marks job running and runs it, or pauses it when imports
+// are stopped.
+func (s *Service) launchImport(job model.ImportJob) (model.ImportJob, error) {
+	job.Status = model.ImportRunning
+	if !s.importRuns.start(job.ID, func(stop context.Context) { s.runImport(stop, job) }) {
+		job.Status = model.ImportPaused
+		if err := s.Imports.Save(context.Background(), job); err != nil {
+			return model.ImportJob{}, err
+		}
+	}
+	return job, nil
+}
+
+// runImport processes the rows of job from its offset until they are done
+// or stop is cancelled, checkpointing after every row. Rows run in the
+// tenant, and as the actor and user, that started the import; a row is
+// not cut short by stop.
+func (s *Service) runImport(stop context.Context, job model.ImportJob) {
+	ctx := model.WithActor(model.WithTenant(context.Background(), job.Tenant), job.Actor)
+	if job.User.ID != "" {
+		ctx = model.WithUser(ctx, job.User)
+	}
+	log := s.logger().With("import_id", job.ID)
+	done := s.StartImport(ctx)
+	defer done()
+	if job.Offset > 0 {
+		log.Info("import resumed", "offset", job.Offset, "rows", job.Rows)
+	}
+	for job.Offset < job.Rows {
+		rows, err := s.Imports.Rows(ctx, job.ID, job.Offset, importChunk)
+		if err == nil && len(rows) == 0 {
+			err = fmt.Errorf("%w: import %s has no row %d", model.ErrInconsistent, job.ID, job.Offset)
+		}
+		if err != nil {
+			// left running, so the next start retries it
+			log.With("error", err).Error("import rows unreadable", "offset", job.Offset)
+			return
+		}
+		for _, row := range rows {
+			if stop.Err() != nil {
+				job.Status = model.ImportPaused
+				if err := s.Imports.Save(ctx, job); err != nil {
+					log.With("error", err).Error("import checkpoint lost", "offset", job.Offset)
+				}
+				log.Info("import paused", "offset", job.Offset, "rows", job.Rows)
+				return
+			}
+			s.importRow(ctx, &job, row)
+			job.Offset++
+			now := time.Now().UTC()
+			job.UpdatedAt = now
+			if job.Offset == job.Rows {
+				job.Status, job.FinishedAt = model.ImportDone, &now
+			}
+			if err := s.Imports.Save(ctx, job); err != nil {
+				// resuming redoes the rows since the last checkpoint
+				log.With("error", err).Error("import checkpoint lost", "offset", job.Offset)
+			}
+		}
+	}
+	if job.Status != model.ImportDone { // no rows
+		now := time.Now().UTC()
+		job.Status, job.UpdatedAt, job.FinishedAt = model.ImportDone, now, &now
+		if err := s.Imports.Save(ctx, job); err != nil {
+			log.With("error", err).Error("import checkpoint lost", "offset", job.Offset)
+		}
+	}
+	log.Info("import finished", "rows", job.Rows, "created", job.Created, "failed", job.Failed)
+}
+
+func (s *Service) importRow(ctx context.Context, job *model.ImportJob, row model.ImportRow) {
+	fail := func(kind, msg string) {
+		job.Errors = append(job.Errors, model.ImportRowError{Line: row.Line, ISBN: row.Input.ISBN, Kind: kind, Message: msg})
+	}
+	if row.Error != "" {
+		job.Failed++
+		fail(model.ErrorKind(model.ErrValidation), row.Error)
+		return
+	}
+	b, err := s.CreateBook(ctx, row.Input)
+	if err != nil {
+		job.Failed++
+		fail(model.ErrorKind(err), err.Error())
+		return
+	}
+	job.Created++
+	if row.State != nil {
+		if err := s.ImportReadingState(ctx, b.ID, *row.State); err != nil {
+			// the book stays; the row is reported without counting as failed
+			fail(model.ErrorKind(err), "reading status not recorded: "+err.Error())
+		}
+	}
+}
@@ -0,0 +1,98 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// calledEnrich reports each call on called before it blocks as
+// blockingEnrich does.
+type calledEnrich struct {
+	blockingEnrich
+	called chan struct{}
+}
+
+func (f calledEnrich) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	f.called <- struct{}{}
+	return f.blockingEnrich.FetchByISBN(ctx, isbn)
+}
+
+func TestBookImport_PausesAndResumes(t *testing.T) {
+	ctx := model.WithTenant(context.Background(), "west")
+	repo, imports := adapter.NewBookRepo(), adapter.NewImportRepo()
+	enrich := calledEnrich{blockingEnrich{release: make(chan struct{})}, make(chan struct{}, 1)}
+	svc := NewService(repo, enrich)
+	svc.Imports = imports
+	rows := []model.ImportRow{
+		{Line: 2, Input: model.CreateBookInput{ISBN: util.GetPtr("9780134494166"), Enrich: true}},
+		{Line: 3, Input: model.CreateBookInput{Title: util.GetPtr("Dune")}},
+		{Line: 4, Error: "published_year: not a number"},
+		{Line: 5, Input: model.CreateBookInput{Title: util.GetPtr("Again"), ISBN: util.GetPtr("978-0-13-449416-6")}},
+	}
+	job, err := svc.StartBookImport(ctx, rows)
+	require.NoError(t, err)
+	assert.Equal(t, model.ImportRunning, job.Status)
+
+	// stop while the first row waits for its enrichment; it is finished
+	// before the import pauses
+	<-enrich.called
+	stopped := make(chan struct{})
+	go func() {
+		svc.StopImports()
+		close(stopped)
+	}()
+	require.Eventually(t, func() bool {
+		svc.importRuns.mu.Lock()
+		defer svc.importRuns.mu.Unlock()
+		return svc.importRuns.stopped
+	}, time.Second, time.Millisecond)
+	close(enrich.release)
+	<-stopped
+
+	job, err = svc.ImportJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ImportPaused, job.Status)
+	assert.Equal(t, 1, job.Offset)
+	assert.Equal(t, 1, job.Created)
+	_, err = svc.ImportJob(model.WithTenant(context.Background(), "east"), job.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	later, err := svc.StartBookImport(ctx, rows[1:2])
+	require.NoError(t, err)
+	assert.Equal(t, model.ImportPaused, later.Status, "imports started after the stop wait for the next start")
+
+	// the next start
+	svc = NewService(repo, mockEnrich{})
+	svc.Imports = imports
+	n, err := svc.ResumeImports(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	require.Eventually(t, func() bool {
+		job, err = svc.ImportJob(ctx, job.ID)
+		require.NoError(t, err)
+		later, err = svc.ImportJob(ctx, later.ID)
+		require.NoError(t, err)
+		return job.Status == model.ImportDone && later.Status == model.ImportDone
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 4, job.Offset)
+	assert.Equal(t, 2, job.Created)
+	assert.Equal(t, 2, job.Failed)
+	require.Len(t, job.Errors, 2)
+	assert.Equal(t, model.ImportRowError{Line: 4, Kind: model.ErrorKind(model.ErrValidation), Message: "published_year: not a number"}, job.Errors[0])
+	assert.Equal(t, 5, job.Errors[1].Line)
+	assert.Equal(t, model.ErrorKind(model.ErrConflict), job.Errors[1].Kind)
+	assert.NotNil(t, job.FinishedAt)
+
+	total, err := repo.Count(ctx, model.ListQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total, "the resumed import ran in its tenant, as did the one started after the stop")
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"strings"
+)
+
+// NormalizeISBN validates an ISBN-10 or ISBN-13 and returns it as a plain
+// ISBN-13 ("9780134494166"), the form books are stored and deduplicated in.
+// Dashes and spaces are ignored. Failures are *model.FieldError.
+func NormalizeISBN(isbn string) (string, error) {
+	s := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(isbn)))
+	invalid := func(reason string) (string, error) {
+		return "", &model.FieldError{Field: "isbn", Reason: reason}
+	}
+	switch len(s) {
+	case 10:
+		for i, c := range s {
+			if (c < '0' || c > '9') && !(c == 'X' && i == 9) {
+				return invalid("ISBN-10 must be 9 digits followed by a digit or X")
+			}
+		}
+		sum := 0
+		for i, c := range s {
+			d := int(c - '0')
+			if c == 'X' {
+				d = 10
+			}
+			sum += (10 - i) * d
+		}
+		if sum%11 != 0 {
+			return invalid("ISBN-10 check digit does not match")
+		}
+		body := "978" + s[:9]
+		return body + string(rune('0'+isbn13Check(body))), nil
+	case 13:
+		for _, c := range s {
+			if c < '0' || c > '9' {
+				return invalid("ISBN-13 must be 13 digits")
+			}
+		}
+		if !strings.HasPrefix(s, "978") && !strings.HasPrefix(s, "979") {
+			return invalid("ISBN-13 must start with 978 or 979")
+		}
+		if int(s[12]-'0') != isbn13Check(s[:12]) {
+			return invalid("ISBN-13 check digit does not match")
+		}
+		return s, nil
+	default:
+		return invalid("ISBN must have 10 or 13 digits")
+	}
+}
+
+// isbn13Check computes the check digit for the first 12 digits of an ISBN-13.
+func isbn13Check(body string) int {
+	sum := 0
+	for i, c := range body {
+		d := int(c - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
+}
+
+// normalizeISBNPtr canonicalizes an optional ISBN in place; empty means none.
+func normalizeISBNPtr(isbn **string) error {
+	if *isbn == nil {
+		return nil
+	}
+	if strings.TrimSpace(**isbn) == "" {
+		*isbn = nil
+		return nil
+	}
+	canon, err := NormalizeISBN(**isbn)
+	if err != nil {
+		return err
+	}
+	*isbn = &canon
+	return nil
+}
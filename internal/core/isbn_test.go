@@ -0,0 +1,42 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	for in, want := range map[string]string{
+		"9780134494166":     "9780134494166",
+		"978-0-13-449416-6": "9780134494166",
+		" 0134494164 ":      "9780134494166",
+		"0-8044-2957-X":     "9780804429573",
+		"080442957x":        "9780804429573",
+		"979-10-90636-07-1": "9791090636071",
+	} {
+		got, err := NormalizeISBN(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for in, reason := range map[string]string{
+		"9780134494167": "ISBN-13 check digit does not match",
+		"0134494165":    "ISBN-10 check digit does not match",
+		"977013449416":  "ISBN must have 10 or 13 digits",
+		"9770134494166": "ISBN-13 must start with 978 or 979",
+		"97801344941X6": "ISBN-13 must be 13 digits",
+		"01344X4164":    "ISBN-10 must be 9 digits followed by a digit or X",
+	} {
+		_, err := NormalizeISBN(in)
+		assert.ErrorIs(t, err, model.ErrValidation, in)
+		var fe *model.FieldError
+		require.ErrorAs(t, err, &fe, in)
+		assert.Equal(t, "isbn", fe.Field)
+		assert.Equal(t, reason, fe.Reason, in)
+	}
+}
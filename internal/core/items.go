@@ -0,0 +1,196 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// barcode is the form of an item barcode: the digits and letters of the
+// common symbologies (Codabar, Code 39, EAN), optionally dashed.
+var barcode = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]{0,63}$`)
+
+const maxItemLocationLen = 100
+
+var itemConditions = []model.ItemCondition{model.ItemNew, model.ItemGood, model.ItemFair, model.ItemPoor, model.ItemDamaged}
+
+// AddItem adds a physical copy to a book. Barcodes are unique across the
+// catalog, books in the trash included. A book that counts copies per
+// branch without items has to drop those counts first, since its items
+// then make up the counts.
+func (s *Service) AddItem(ctx context.Context, bookID string, it model.Item) (model.Book, error) {
+	var v validator
+	it.Barcode = strings.TrimSpace(it.Barcode)
+	it.Location = strings.TrimSpace(it.Location)
+	v.check(barcode.MatchString(it.Barcode), "barcode", "must be 1 to 64 letters, digits or dashes, not starting with a dash")
+	v.check(len(it.Location) <= maxItemLocationLen, "location", fmt.Sprintf("must be at most %d characters", maxItemLocationLen))
+	if it.Condition == "" {
+		it.Condition = model.ItemGood
+	}
+	v.check(slices.Contains(itemConditions, it.Condition), "condition", "must be new, good, fair, poor or damaged")
+	if err := v.err(); err != nil {
+		return model.Book{}, err
+	}
+	if it.Branch != "" {
+		if _, err := s.GetBranch(ctx, it.Branch); err != nil {
+			return model.Book{}, err
+		}
+	}
+	b, err := s.getBook(ctx, bookID)
+	if err != nil {
+		return model.Book{}, model.ErrNotFound
+	}
+	if len(b.Items) == 0 && len(b.Copies) > 0 {
+		return model.Book{}, fmt.Errorf("%w: book %s counts copies per branch; set them to 0 before adding items", model.ErrConflict, b.ID)
+	}
+	n, err := s.Repo.Count(ctx, model.ListQuery{Barcode: &it.Barcode, IncludeDeleted: true})
+	if err != nil {
+		return model.Book{}, err
+	}
+	if n > 0 {
+		return model.Book{}, fmt.Errorf("%w: barcode %s is taken", model.ErrConflict, it.Barcode)
+	}
+	it.AddedAt, it.OnLoan = time.Now().UTC(), false
+	before := b
+	b.Items = append(slices.Clone(b.Items), it)
+	b.Copies = itemCopies(b.Items)
+	return s.updateBook(ctx, model.AuditUpdate, before, b)
+}
+
+// UpdateItem moves a physical copy to another branch or shelf, or records
+// its condition. A lost or missing item found again is given back a
+// condition of its own, such as good.
+func (s *Service) UpdateItem(ctx context.Context, bookID, code string, p model.ItemPatch) (model.Book, error) {
+	var v validator
+	if p.Location != nil {
+		loc := strings.TrimSpace(*p.Location)
+		p.Location = &loc
+		v.check(len(loc) <= maxItemLocationLen, "location", fmt.Sprintf("must be at most %d characters", maxItemLocationLen))
+	}
+	if p.Condition != nil {
+		v.check(slices.Contains(itemConditions, *p.Condition), "condition", "must be new, good, fair, poor or damaged")
+	}
+	if err := v.err(); err != nil {
+		return model.Book{}, err
+	}
+	if p.Branch != nil && *p.Branch != "" {
+		if _, err := s.GetBranch(ctx, *p.Branch); err != nil {
+			return model.Book{}, err
+		}
+	}
+	b, err := s.getBook(ctx, bookID)
+	if err != nil {
+		return model.Book{}, model.ErrNotFound
+	}
+	i := slices.IndexFunc(b.Items, func(it model.Item) bool { return it.Barcode == code })
+	if i < 0 {
+		return model.Book{}, fmt.Errorf("%w: book %s has no item %s", model.ErrNotFound, b.ID, code)
+	}
+	before := b
+	b.Items = slices.Clone(b.Items)
+	it := &b.Items[i]
+	if p.Branch != nil {
+		it.Branch = *p.Branch
+	}
+	if p.Location != nil {
+		it.Location = *p.Location
+	}
+	if p.Condition != nil {
+		it.Condition = *p.Condition
+	}
+	b.Copies = itemCopies(b.Items)
+	return s.updateBook(ctx, model.AuditUpdate, before, b)
+}
+
+// RemoveItem takes a physical copy off a book, unless it is on loan.
+func (s *Service) RemoveItem(ctx context.Context, bookID, code string) (model.Book, error) {
+	b, err := s.getBook(ctx, bookID)
+	if err != nil {
+		return model.Book{}, model.ErrNotFound
+	}
+	i := slices.IndexFunc(b.Items, func(it model.Item) bool { return it.Barcode == code })
+	if i < 0 {
+		return model.Book{}, fmt.Errorf("%w: book %s has no item %s", model.ErrNotFound, b.ID, code)
+	}
+	if s.Loans != nil {
+		active, err := s.Loans.List(ctx, model.LoanQuery{BookID: b.ID, Status: model.LoanActive, Now: time.Now().UTC()})
+		if err != nil {
+			return model.Book{}, err
+		}
+		if slices.ContainsFunc(active, func(l model.Loan) bool { return l.Barcode == code }) {
+			return model.Book{}, fmt.Errorf("%w: item %s is on loan", model.ErrConflict, code)
+		}
+	}
+	before := b
+	b.Items = slices.Delete(slices.Clone(b.Items), i, i+1)
+	if len(b.Items) == 0 {
+		b.Items = nil
+	}
+	b.Copies = itemCopies(b.Items)
+	return s.updateBook(ctx, model.AuditUpdate, before, b)
+}
+
+// itemCopies counts items per branch, as Book.Copies.
+func itemCopies(items []model.Item) map[string]int {
+	var out map[string]int
+	for _, it := range items {
+		if it.Branch == "" {
+			continue
+		}
+		if out == nil {
+			out = map[string]int{}
+		}
+		out[it.Branch]++
+	}
+	return out
+}
+
+// freeItem returns the barcode of a lendable item of b that none of the
+// active loans has, or "" when b tracks no items or none is free.
+func freeItem(b model.Book, active []model.Loan) string {
+	for _, it := range b.Items {
+		if it.Lendable() && !slices.ContainsFunc(active, func(l model.Loan) bool { return l.Barcode == it.Barcode }) {
+			return it.Barcode
+		}
+	}
+	return ""
+}
+
+// lentOut returns the books no copy of which can be lent now: those whose
+// active loans and copies kept for holders take every copy.
+func (s *Service) lentOut(ctx context.Context) (map[string]bool, error) {
+	out := map[string]bool{}
+	if s.Loans == nil {
+		return out, nil
+	}
+	active, err := s.Loans.List(ctx, model.LoanQuery{Status: model.LoanActive, Now: time.Now().UTC()})
+	if err != nil {
+		return nil, err
+	}
+	lent := map[string]int{}
+	for _, l := range active {
+		lent[l.BookID]++
+	}
+	for id, n := range lent {
+		b, err := s.Repo.GetByID(ctx, id)
+		if err != nil {
+			continue // purged since
+		}
+		ready := 0
+		if s.Holds != nil {
+			holds, err := s.Holds.List(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			ready = readyHolds(holds, "")
+		}
+		if n+ready >= lendableCopies(b) {
+			out[id] = true
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,154 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItems(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Branches, svc.Loans = adapter.NewBranchRepo(), adapter.NewLoanRepo()
+	_, err := svc.CreateBranch(ctx, model.Branch{ID: "east", Name: "East"})
+	require.NoError(t, err)
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	other, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Other")})
+	require.NoError(t, err)
+
+	_, err = svc.AddItem(ctx, b.ID, model.Item{Barcode: "-1", Condition: "mint"})
+	var ve model.ValidationErrors
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, ve, 2)
+	_, err = svc.AddItem(ctx, b.ID, model.Item{Barcode: "31234000001", Branch: "north"})
+	assert.ErrorIs(t, err, model.ErrNotFound, "unknown branch")
+
+	b, err = svc.AddItem(ctx, b.ID, model.Item{Barcode: "31234000001", Branch: "east", Location: " PS3561 "})
+	require.NoError(t, err)
+	require.Len(t, b.Items, 1)
+	assert.Equal(t, model.ItemGood, b.Items[0].Condition)
+	assert.Equal(t, "PS3561", b.Items[0].Location)
+	b, err = svc.AddItem(ctx, b.ID, model.Item{Barcode: "31234000002", Condition: model.ItemPoor})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"east": 1}, b.Copies, "items make up the branch counts")
+	_, err = svc.AddItem(ctx, other.ID, model.Item{Barcode: "31234000001"})
+	assert.ErrorIs(t, err, model.ErrConflict, "barcodes are unique")
+	_, err = svc.SetCopies(ctx, b.ID, "east", 5)
+	assert.ErrorIs(t, err, model.ErrConflict, "the items count")
+
+	_, err = svc.SetCopies(ctx, other.ID, "east", 2)
+	require.NoError(t, err)
+	_, err = svc.AddItem(ctx, other.ID, model.Item{Barcode: "31234000003"})
+	assert.ErrorIs(t, err, model.ErrConflict, "counted copies first")
+
+	first, err := svc.CheckoutBook(ctx, b.ID, "card-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "31234000001", first.Barcode)
+	got, err := svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Items[0].OnLoan)
+	assert.False(t, got.Items[1].OnLoan)
+	_, err = svc.RemoveItem(ctx, b.ID, "31234000001")
+	assert.ErrorIs(t, err, model.ErrConflict, "on loan")
+
+	page, err := svc.ListBooks(ctx, model.ListQuery{Barcode: util.GetPtr("31234000002")})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, b.ID, page.Data[0].ID)
+
+	second, err := svc.CheckoutBook(ctx, b.ID, "card-2", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "31234000002", second.Barcode)
+	page, err = svc.ListBooks(ctx, model.ListQuery{Available: util.GetPtr(false)})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, b.ID, page.Data[0].ID)
+	assert.Equal(t, 1, page.Total)
+	page, err = svc.ListBooks(ctx, model.ListQuery{Available: util.GetPtr(true)})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, other.ID, page.Data[0].ID)
+
+	_, err = svc.ReturnLoan(ctx, second.ID)
+	require.NoError(t, err)
+	b, err = svc.RemoveItem(ctx, b.ID, "31234000002")
+	require.NoError(t, err)
+	assert.Len(t, b.Items, 1)
+	_, err = svc.RemoveItem(ctx, b.ID, "31234000002")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestUpdateItem(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Branches = adapter.NewBranchRepo()
+	for _, id := range []string{"east", "west"} {
+		_, err := svc.CreateBranch(ctx, model.Branch{ID: id, Name: id})
+		require.NoError(t, err)
+	}
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	b, err = svc.AddItem(ctx, b.ID, model.Item{Barcode: "31234000001", Branch: "east", Location: "PS3561"})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateItem(ctx, b.ID, "31234000001", model.ItemPatch{Condition: util.GetPtr(model.ItemLost)})
+	var ve model.ValidationErrors
+	assert.ErrorAs(t, err, &ve, "lost is set by escalation only")
+	_, err = svc.UpdateItem(ctx, b.ID, "31234000001", model.ItemPatch{Branch: util.GetPtr("north")})
+	assert.ErrorIs(t, err, model.ErrNotFound, "unknown branch")
+	_, err = svc.UpdateItem(ctx, b.ID, "99", model.ItemPatch{})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	b, err = svc.UpdateItem(ctx, b.ID, "31234000001", model.ItemPatch{Branch: util.GetPtr("west"), Condition: util.GetPtr(model.ItemFair)})
+	require.NoError(t, err)
+	assert.Equal(t, "west", b.Items[0].Branch)
+	assert.Equal(t, "PS3561", b.Items[0].Location, "left out, kept")
+	assert.Equal(t, model.ItemFair, b.Items[0].Condition)
+	assert.Equal(t, map[string]int{"west": 1}, b.Copies, "the copies follow the item")
+
+	b, err = svc.UpdateItem(ctx, b.ID, "31234000001", model.ItemPatch{Branch: util.GetPtr(""), Location: util.GetPtr(" PS3562 ")})
+	require.NoError(t, err)
+	assert.Equal(t, "PS3562", b.Items[0].Location)
+	assert.Nil(t, b.Copies, "off every branch")
+}
+
+func TestItems_MissingAndDamagedAreNotLent(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Loans = adapter.NewLoanRepo()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	for _, code := range []string{"31234000001", "31234000002"} {
+		_, err = svc.AddItem(ctx, b.ID, model.Item{Barcode: code})
+		require.NoError(t, err)
+	}
+	// a stocktake did not find the first copy; the second is damaged
+	ok, err := svc.setItemCondition(ctx, b.ID, "31234000001", model.ItemMissing)
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, err = svc.UpdateItem(ctx, b.ID, "31234000002", model.ItemPatch{Condition: util.GetPtr(model.ItemDamaged)})
+	require.NoError(t, err)
+
+	_, err = svc.CheckoutBook(ctx, b.ID, "card-1", nil)
+	assert.ErrorIs(t, err, model.ErrConflict)
+	_, err = svc.checkout(ctx, b.ID, "card-1", "31234000001", nil, time.Now().UTC())
+	assert.ErrorIs(t, err, model.ErrConflict, "a missing copy by its barcode")
+	page, err := svc.ListBooks(ctx, model.ListQuery{Available: util.GetPtr(true)})
+	require.NoError(t, err)
+	assert.Empty(t, page.Data)
+
+	_, err = svc.UpdateItem(ctx, b.ID, "31234000002", model.ItemPatch{Condition: util.GetPtr(model.ItemFair)})
+	require.NoError(t, err)
+	l, err := svc.CheckoutBook(ctx, b.ID, "card-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "31234000002", l.Barcode, "the copy that was found")
+}
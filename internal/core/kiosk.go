@@ -0,0 +1,138 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// KioskRepository remembers the loans of the queued checkouts kiosks
+// synced, by kiosk and the checkout's client ID, so that a sync repeated
+// after a lost reply lends nothing twice.
+type KioskRepository interface {
+	// Synced returns the loan ID recorded for a kiosk's checkout, if any.
+	Synced(ctx context.Context, kiosk, clientID string) (loanID string, ok bool, err error)
+	Record(ctx context.Context, kiosk, clientID, loanID string) error
+}
+
+const (
+	maxKioskClientIDLen = 100
+	maxKioskSync        = 200
+)
+
+// KioskBorrower returns the borrower whose card was scanned. With a
+// borrower directory the card is a borrower's external ID; without one it
+// names the borrower itself.
+func (s *Service) KioskBorrower(ctx context.Context, card string) (model.Borrower, error) {
+	card = strings.TrimSpace(card)
+	if card == "" {
+		return model.Borrower{}, &model.FieldError{Field: "card", Reason: "must not be empty"}
+	}
+	if s.Borrowers == nil {
+		return model.Borrower{ID: card, Name: card}, nil
+	}
+	return s.Borrowers.GetByExternalID(ctx, card)
+}
+
+// KioskItem returns the book whose copy was scanned, failing with
+// model.ErrConflict while that copy is on loan.
+func (s *Service) KioskItem(ctx context.Context, code string) (model.Book, error) {
+	if s.Loans == nil {
+		return model.Book{}, fmt.Errorf("%w: lending is not configured", model.ErrNotFound)
+	}
+	code = strings.TrimSpace(code)
+	if !barcode.MatchString(code) {
+		return model.Book{}, &model.FieldError{Field: "barcode", Reason: "must be 1 to 64 letters, digits or dashes, not starting with a dash"}
+	}
+	page, err := s.Repo.List(ctx, model.ListQuery{Barcode: &code, Page: 1, PageSize: 1})
+	if err != nil {
+		return model.Book{}, err
+	}
+	if len(page.Data) == 0 {
+		return model.Book{}, fmt.Errorf("%w: no copy has barcode %s", model.ErrNotFound, code)
+	}
+	b := page.Data[0]
+	active, err := s.Loans.List(ctx, model.LoanQuery{BookID: b.ID, Status: model.LoanActive, Now: time.Now().UTC()})
+	if err != nil {
+		return model.Book{}, err
+	}
+	if slices.ContainsFunc(active, func(l model.Loan) bool { return l.Barcode == code }) {
+		return model.Book{}, fmt.Errorf("%w: copy %s is on loan", model.ErrConflict, code)
+	}
+	return b, nil
+}
+
+// KioskCheckout lends the scanned copy to the borrower whose card was
+// scanned, for the loan period. A checkout queued while the kiosk was
+// offline is lent as of when it was scanned, though no earlier than one
+// loan period ago; one the kiosk already synced returns its loan again.
+func (s *Service) KioskCheckout(ctx context.Context, c model.KioskCheckout) (model.Loan, error) {
+	kiosk := model.ActorFromContext(ctx)
+	c.ClientID = strings.TrimSpace(c.ClientID)
+	if len(c.ClientID) > maxKioskClientIDLen {
+		return model.Loan{}, &model.FieldError{Field: "client_id", Reason: fmt.Sprintf("must be at most %d characters", maxKioskClientIDLen)}
+	}
+	if c.ClientID != "" && s.Kiosks != nil {
+		id, ok, err := s.Kiosks.Synced(ctx, kiosk, c.ClientID)
+		if err != nil {
+			return model.Loan{}, err
+		}
+		if ok {
+			return s.GetLoan(ctx, id)
+		}
+	}
+	br, err := s.KioskBorrower(ctx, c.Card)
+	if err != nil {
+		return model.Loan{}, err
+	}
+	b, err := s.KioskItem(ctx, c.Barcode)
+	if err != nil {
+		return model.Loan{}, err
+	}
+	now := time.Now().UTC()
+	at := now
+	if c.ScannedAt != nil && c.ScannedAt.Before(now) {
+		at = c.ScannedAt.UTC()
+		if earliest := now.Add(-s.loanPeriod()); at.Before(earliest) {
+			at = earliest
+		}
+	}
+	l, err := s.checkout(ctx, b.ID, br.ID, strings.TrimSpace(c.Barcode), nil, at)
+	if err != nil {
+		return model.Loan{}, err
+	}
+	if c.ClientID != "" && s.Kiosks != nil {
+		if err := s.Kiosks.Record(ctx, kiosk, c.ClientID, l.ID); err != nil {
+			return l, err
+		}
+	}
+	return l, nil
+}
+
+// SyncKiosk lends the checkouts a kiosk queued while offline, in the order
+// they were scanned. Each needs a client ID and its scan time; one that
+// fails does not stop the others.
+func (s *Service) SyncKiosk(ctx context.Context, checkouts []model.KioskCheckout) ([]model.KioskSyncResult, error) {
+	var v validator
+	v.check(len(checkouts) <= maxKioskSync, "checkouts", fmt.Sprintf("must be at most %d", maxKioskSync))
+	for i, c := range checkouts {
+		v.check(strings.TrimSpace(c.ClientID) != "", fmt.Sprintf("checkouts[%d].client_id", i), "must not be empty")
+		v.check(c.ScannedAt != nil, fmt.Sprintf("checkouts[%d].scanned_at", i), "must be set")
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	checkouts = slices.Clone(checkouts)
+	slices.SortStableFunc(checkouts, func(a, b model.KioskCheckout) int {
+		return a.ScannedAt.Compare(*b.ScannedAt)
+	})
+	out := make([]model.KioskSyncResult, 0, len(checkouts))
+	for _, c := range checkouts {
+		l, err := s.KioskCheckout(ctx, c)
+		out = append(out, model.KioskSyncResult{ClientID: c.ClientID, Loan: l, Err: err})
+	}
+	return out, nil
+}
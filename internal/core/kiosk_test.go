@@ -0,0 +1,84 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKiosk(t *testing.T) {
+	ctx := model.WithActor(context.Background(), "kiosk:lobby")
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Loans = adapter.NewLoanRepo()
+	svc.Borrowers = adapter.NewBorrowerRepo()
+	svc.Kiosks = adapter.NewKioskRepo()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	for _, code := range []string{"A-1", "A-2"} {
+		_, err = svc.AddItem(ctx, b.ID, model.Item{Barcode: code})
+		require.NoError(t, err)
+	}
+	ada, err := svc.CreateBorrower(ctx, model.Borrower{Name: "Ada", ExternalID: "2077"})
+	require.NoError(t, err)
+
+	got, err := svc.KioskBorrower(ctx, " 2077 ")
+	require.NoError(t, err)
+	assert.Equal(t, ada.ID, got.ID)
+	_, err = svc.KioskBorrower(ctx, "9999")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	_, err = svc.KioskBorrower(ctx, "")
+	assert.ErrorIs(t, err, model.ErrValidation)
+
+	book, err := svc.KioskItem(ctx, "A-2")
+	require.NoError(t, err)
+	assert.Equal(t, b.ID, book.ID)
+	_, err = svc.KioskItem(ctx, "B-1")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	l, err := svc.KioskCheckout(ctx, model.KioskCheckout{Card: "2077", Barcode: "A-2"})
+	require.NoError(t, err)
+	assert.Equal(t, ada.ID, l.Borrower)
+	assert.Equal(t, "A-2", l.Barcode, "the scanned copy is lent")
+	_, err = svc.KioskItem(ctx, "A-2")
+	assert.ErrorIs(t, err, model.ErrConflict)
+	_, err = svc.KioskCheckout(ctx, model.KioskCheckout{Card: "2077", Barcode: "A-2"})
+	assert.ErrorIs(t, err, model.ErrConflict)
+
+	t.Run("offline sync", func(t *testing.T) {
+		now := time.Now().UTC()
+		late, early := now.Add(-time.Hour), now.Add(-60*24*time.Hour)
+		queue := []model.KioskCheckout{
+			{ClientID: "q-2", Card: "2077", Barcode: "A-2", ScannedAt: &late},
+			{ClientID: "q-1", Card: "2077", Barcode: "A-1", ScannedAt: &early},
+		}
+		res, err := svc.SyncKiosk(ctx, queue)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, "q-1", res[0].ClientID, "in scan order")
+		require.NoError(t, res[0].Err)
+		assert.WithinDuration(t, now.Add(-svc.loanPeriod()), res[0].Loan.CheckedOutAt, time.Minute,
+			"no earlier than one loan period ago")
+		assert.ErrorIs(t, res[1].Err, model.ErrConflict, "A-2 is on loan")
+
+		again, err := svc.SyncKiosk(ctx, queue[1:])
+		require.NoError(t, err)
+		require.NoError(t, again[0].Err, "a synced checkout is not lent twice")
+		assert.Equal(t, res[0].Loan.ID, again[0].Loan.ID)
+		loans, err := svc.ListLoans(ctx, model.LoanQuery{BookID: b.ID})
+		require.NoError(t, err)
+		assert.Len(t, loans, 2)
+
+		_, err = svc.SyncKiosk(ctx, []model.KioskCheckout{{Card: "2077", Barcode: "A-1"}})
+		var ve model.ValidationErrors
+		require.ErrorAs(t, err, &ve)
+		assert.Len(t, ve, 2, "client_id and scanned_at are needed")
+	})
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// maxLabels bounds the labels one request prints.
+const maxLabels = 1000
+
+// Labels returns the labels of the copies sel names, in its order: every
+// item of each book, or the book itself when it tracks no items, then the
+// items with each barcode. A copy named twice gets two labels. Unknown
+// books and barcodes fail with model.ErrNotFound.
+func (s *Service) Labels(ctx context.Context, sel model.LabelSelection) ([]model.Label, error) {
+	if len(sel.BookIDs)+len(sel.Barcodes) == 0 {
+		return nil, &model.FieldError{Field: "book_ids", Reason: "or barcodes must name at least one copy"}
+	}
+	var out []model.Label
+	for _, id := range sel.BookIDs {
+		b, err := s.getBook(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: book %s", model.ErrNotFound, id)
+		}
+		if len(b.Items) == 0 {
+			out = append(out, label(b, model.Item{}))
+		}
+		for _, it := range b.Items {
+			out = append(out, label(b, it))
+		}
+	}
+	for _, code := range sel.Barcodes {
+		code = strings.TrimSpace(code)
+		if !barcode.MatchString(code) {
+			return nil, &model.FieldError{Field: "barcodes", Reason: fmt.Sprintf("%q must be 1 to 64 letters, digits or dashes, not starting with a dash", code)}
+		}
+		page, err := s.Repo.List(ctx, model.ListQuery{Barcode: &code, Owner: s.ownerFilter(ctx), Page: 1, PageSize: 1})
+		if err != nil {
+			return nil, err
+		}
+		if len(page.Data) == 0 {
+			return nil, fmt.Errorf("%w: no copy has barcode %s", model.ErrNotFound, code)
+		}
+		for _, it := range page.Data[0].Items {
+			if it.Barcode == code {
+				out = append(out, label(page.Data[0], it))
+			}
+		}
+	}
+	if len(out) > maxLabels {
+		return nil, &model.FieldError{Field: "book_ids", Reason: fmt.Sprintf("select at most %d copies, not %d", maxLabels, len(out))}
+	}
+	return out, nil
+}
+
+func label(b model.Book, it model.Item) model.Label {
+	l := model.Label{
+		Barcode:  it.Barcode,
+		BookID:   b.ID,
+		Title:    b.Title,
+		Year:     b.PublishedYear,
+		Branch:   it.Branch,
+		Location: it.Location,
+	}
+	if len(b.Authors) > 0 {
+		l.Author = b.Authors[0]
+	}
+	return l
+}
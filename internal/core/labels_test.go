@@ -0,0 +1,44 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabels(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	dune, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune"), Authors: []string{"Frank Herbert"}, PublishedYear: util.GetPtr(1965)})
+	require.NoError(t, err)
+	for _, it := range []model.Item{{Barcode: "A-1", Location: "SF HER"}, {Barcode: "A-2"}} {
+		_, err = svc.AddItem(ctx, dune.ID, it)
+		require.NoError(t, err)
+	}
+	emma, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Emma")})
+	require.NoError(t, err)
+
+	_, err = svc.Labels(ctx, model.LabelSelection{})
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.Labels(ctx, model.LabelSelection{BookIDs: []string{"nope"}})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	_, err = svc.Labels(ctx, model.LabelSelection{Barcodes: []string{"Z-9"}})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	_, err = svc.Labels(ctx, model.LabelSelection{Barcodes: []string{"-bad"}})
+	assert.ErrorIs(t, err, model.ErrValidation)
+
+	labels, err := svc.Labels(ctx, model.LabelSelection{BookIDs: []string{dune.ID, emma.ID}, Barcodes: []string{"A-2"}})
+	require.NoError(t, err)
+	require.Len(t, labels, 4)
+	assert.Equal(t, model.Label{Barcode: "A-1", BookID: dune.ID, Title: "Dune", Author: "Frank Herbert", Year: util.GetPtr(1965), Location: "SF HER"}, labels[0])
+	assert.Equal(t, "A-2", labels[1].Barcode)
+	assert.Equal(t, model.Label{BookID: emma.ID, Title: "Emma"}, labels[2], "a book without items gets one label")
+	assert.Equal(t, "A-2", labels[3].Barcode, "copies named twice get two labels")
+}
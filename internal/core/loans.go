@@ -0,0 +1,200 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoanRepository keeps loans. Checkout stores a loan unless its book
+// already has copies active loans, or its item is on an active loan,
+// failing with model.ErrConflict, so that two checkouts of the last copy
+// cannot both succeed. Get, Return and MarkLost fail with
+// model.ErrNotFound for an unknown loan; Return and MarkLost fail with
+// model.ErrConflict for a returned one.
+type LoanRepository interface {
+	Checkout(ctx context.Context, l model.Loan, copies int) (model.Loan, error)
+	Get(ctx context.Context, id string) (model.Loan, error)
+	Return(ctx context.Context, id string, at time.Time) (model.Loan, error)
+	// MarkLost sets the loan's LostAt unless it is set already.
+	MarkLost(ctx context.Context, id string, at time.Time) (model.Loan, error)
+	// List returns the matching loans by due time, soonest first.
+	List(ctx context.Context, q model.LoanQuery) ([]model.Loan, error)
+	// Reassign moves the loans of borrower from to borrower to and
+	// returns how many it moved.
+	Reassign(ctx context.Context, from, to string) (int, error)
+}
+
+const (
+	defaultLoanPeriod = 21 * 24 * time.Hour
+	maxBorrowerLen    = 200
+)
+
+// CheckoutBook lends a copy of a book to borrower until due, or for the
+// loan period when due is nil. A due date is the last day of the loan, so
+// the loan runs to its end. Copies kept for other holders cannot be lent;
+// the borrower's own ready hold ends with the loan.
+func (s *Service) CheckoutBook(ctx context.Context, bookID, borrower string, due *time.Time) (model.Loan, error) {
+	return s.checkout(ctx, bookID, borrower, "", due, time.Now().UTC())
+}
+
+// checkout lends the copy with barcode code, or any free copy when code is
+// empty, as of now.
+func (s *Service) checkout(ctx context.Context, bookID, borrower, code string, due *time.Time, now time.Time) (model.Loan, error) {
+	if s.Loans == nil {
+		return model.Loan{}, fmt.Errorf("%w: lending is not configured", model.ErrNotFound)
+	}
+	var v validator
+	borrower = strings.TrimSpace(borrower)
+	v.check(borrower != "", "borrower", "must not be empty")
+	v.check(len(borrower) <= maxBorrowerLen, "borrower", fmt.Sprintf("must be at most %d characters", maxBorrowerLen))
+	v.check(borrower == "" || s.checkBorrower(ctx, borrower) == nil, "borrower", "must be a registered borrower")
+	dueAt := now.Add(s.loanPeriod())
+	if due != nil {
+		dueAt = due.UTC().Truncate(24 * time.Hour).Add(24*time.Hour - time.Second)
+		v.check(dueAt.After(now), "due_date", "must not be in the past")
+	}
+	if err := v.err(); err != nil {
+		return model.Loan{}, err
+	}
+	b, err := s.getBook(ctx, bookID)
+	if err != nil {
+		return model.Loan{}, model.ErrNotFound
+	}
+	active, holds, err := s.loanState(ctx, b.ID)
+	if err != nil {
+		return model.Loan{}, err
+	}
+	if code == "" {
+		code = freeItem(b, active)
+		if code == "" && len(b.Items) > 0 && lendableCopies(b) == 0 {
+			return model.Loan{}, fmt.Errorf("%w: no copy of book %s can be lent", model.ErrConflict, b.ID)
+		}
+	} else if i := slices.IndexFunc(b.Items, func(it model.Item) bool { return it.Barcode == code }); i < 0 {
+		return model.Loan{}, fmt.Errorf("%w: book %s has no copy %s", model.ErrNotFound, b.ID, code)
+	} else if !b.Items[i].Lendable() {
+		return model.Loan{}, fmt.Errorf("%w: copy %s is %s", model.ErrConflict, code, b.Items[i].Condition)
+	} else if slices.ContainsFunc(active, func(l model.Loan) bool { return l.Barcode == code }) {
+		return model.Loan{}, fmt.Errorf("%w: copy %s is on loan", model.ErrConflict, code)
+	}
+	l, err := s.Loans.Checkout(ctx, model.Loan{
+		ID:           uuid.NewString(),
+		BookID:       b.ID,
+		Borrower:     borrower,
+		CheckedOutAt: now,
+		DueAt:        dueAt,
+		Barcode:      code,
+		Tenant:       b.Tenant,
+	}, lendableCopies(b)-readyHolds(holds, borrower))
+	if err != nil {
+		return model.Loan{}, err
+	}
+	for _, h := range holds {
+		if h.Borrower == borrower {
+			if err := s.Holds.Delete(ctx, h.ID); err != nil && !errors.Is(err, model.ErrNotFound) {
+				return l, err
+			}
+		}
+	}
+	return l, nil
+}
+
+// ReturnLoan ends a loan, charges its fine when it is late and serves the
+// next holder of the book. A failure to charge the fine or serve the holds
+// is returned along with the returned loan.
+func (s *Service) ReturnLoan(ctx context.Context, id string) (model.Loan, error) {
+	if s.Loans == nil {
+		return model.Loan{}, fmt.Errorf("%w: loan %s", model.ErrNotFound, id)
+	}
+	l, err := s.Loans.Return(ctx, id, time.Now().UTC())
+	if err != nil {
+		return model.Loan{}, err
+	}
+	return l, errors.Join(s.chargeFine(ctx, l), s.serveHolds(ctx, l.BookID))
+}
+
+func (s *Service) GetLoan(ctx context.Context, id string) (model.Loan, error) {
+	if s.Loans == nil {
+		return model.Loan{}, fmt.Errorf("%w: loan %s", model.ErrNotFound, id)
+	}
+	return s.Loans.Get(ctx, id)
+}
+
+func (s *Service) ListLoans(ctx context.Context, q model.LoanQuery) ([]model.Loan, error) {
+	if s.Loans == nil {
+		return nil, nil
+	}
+	switch q.Status {
+	case "", model.LoanActive, model.LoanOverdue, model.LoanLost, model.LoanReturned:
+	default:
+		return nil, &model.FieldError{Field: "status", Reason: "must be active, overdue, lost or returned"}
+	}
+	if q.Now.IsZero() {
+		q.Now = time.Now().UTC()
+	}
+	return s.Loans.List(ctx, q)
+}
+
+// withLending fills in the loan state of books when lending is configured.
+func (s *Service) withLending(ctx context.Context, books []model.Book) error {
+	if s.Loans == nil {
+		return nil
+	}
+	now := time.Now().UTC()
+	for i := range books {
+		active, holds, err := s.loanState(ctx, books[i].ID)
+		if err != nil {
+			return err
+		}
+		l := &model.Lending{Copies: lendableCopies(books[i]), CheckedOut: len(active), Holds: len(holds), Ready: readyHolds(holds, "")}
+		for _, loan := range active {
+			if loan.Overdue(now) {
+				l.Overdue++
+			}
+		}
+		if len(active) > 0 {
+			l.NextDueAt = &active[0].DueAt
+		}
+		books[i].Lending = l
+		if len(books[i].Items) > 0 {
+			items := slices.Clone(books[i].Items)
+			for j := range items {
+				items[j].OnLoan = slices.ContainsFunc(active, func(l model.Loan) bool { return l.Barcode == items[j].Barcode })
+			}
+			books[i].Items = items
+		}
+	}
+	return nil
+}
+
+func (s *Service) loanPeriod() time.Duration {
+	if s.LoanPeriod > 0 {
+		return s.LoanPeriod
+	}
+	return defaultLoanPeriod
+}
+
+// lendableCopies is the number of lendable items of b, or of copies the
+// branches hold when it tracks none, or 1 for a book nobody keeps count of.
+func lendableCopies(b model.Book) int {
+	if len(b.Items) > 0 {
+		n := 0
+		for _, it := range b.Items {
+			if it.Lendable() {
+				n++
+			}
+		}
+		return n
+	}
+	n := 0
+	for _, c := range b.Copies {
+		n += c
+	}
+	return max(n, 1)
+}
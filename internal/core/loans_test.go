@@ -0,0 +1,80 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoans(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	_, err = svc.CheckoutBook(ctx, b.ID, "card-1", nil)
+	assert.ErrorIs(t, err, model.ErrNotFound, "lending is off")
+	got, err := svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.Lending)
+
+	svc.Loans = adapter.NewLoanRepo()
+	svc.LoanPeriod = 7 * 24 * time.Hour
+	_, err = svc.CheckoutBook(ctx, b.ID, " ", util.GetPtr(time.Now().AddDate(0, 0, -1)))
+	var ve model.ValidationErrors
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, ve, 2, "borrower and due date")
+	_, err = svc.CheckoutBook(ctx, "missing", "card-1", nil)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	first, err := svc.CheckoutBook(ctx, b.ID, " card-1 ", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "card-1", first.Borrower)
+	assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), first.DueAt, time.Minute)
+	_, err = svc.CheckoutBook(ctx, b.ID, "card-2", nil)
+	assert.ErrorIs(t, err, model.ErrConflict, "the only copy is out")
+
+	svc.Branches = adapter.NewBranchRepo()
+	_, err = svc.CreateBranch(ctx, model.Branch{ID: "east", Name: "East"})
+	require.NoError(t, err)
+	_, err = svc.SetCopies(ctx, b.ID, "east", 2)
+	require.NoError(t, err)
+	due := time.Now().UTC().AddDate(0, 0, 3)
+	second, err := svc.CheckoutBook(ctx, b.ID, "card-2", &due)
+	require.NoError(t, err)
+	assert.Equal(t, due.Format(time.DateOnly), second.DueAt.Format(time.DateOnly))
+	assert.Equal(t, 23, second.DueAt.Hour(), "due at the end of the day")
+
+	got, err = svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.Lending)
+	assert.Equal(t, model.Lending{Copies: 2, CheckedOut: 2, NextDueAt: &second.DueAt}, *got.Lending)
+
+	returned, err := svc.ReturnLoan(ctx, second.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, returned.ReturnedAt)
+	_, err = svc.ReturnLoan(ctx, second.ID)
+	assert.ErrorIs(t, err, model.ErrConflict)
+	_, err = svc.ReturnLoan(ctx, "missing")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	active, err := svc.ListLoans(ctx, model.LoanQuery{Status: model.LoanActive})
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, first.ID, active[0].ID)
+	overdue, err := svc.ListLoans(ctx, model.LoanQuery{Status: model.LoanOverdue, Now: first.DueAt.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Len(t, overdue, 1)
+	mine, err := svc.ListLoans(ctx, model.LoanQuery{Borrower: "card-2"})
+	require.NoError(t, err)
+	assert.Len(t, mine, 1)
+	_, err = svc.ListLoans(ctx, model.LoanQuery{Status: "missing"})
+	assert.ErrorIs(t, err, model.ErrValidation)
+}
@@ -1,7 +1,9 @@
 package model
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"time"
 )
 
@@ -13,15 +15,64 @@ const (
 	EnrichmentNotRequested EnrichmentStatus = "not_requested"
 	EnrichmentOK           EnrichmentStatus = "ok"
 	EnrichmentPartial      EnrichmentStatus = "partial"
+	EnrichmentPending      EnrichmentStatus = "pending" // queued for background enrichment
 )
 
 var (
-	ErrValidation = errors.New("validation")
-	ErrConflict   = errors.New("conflict")
-	ErrNotFound   = errors.New("not_found")
-	ErrUpstream   = errors.New("upstream")
+	ErrValidation   = errors.New("validation")
+	ErrConflict     = errors.New("conflict")
+	ErrNotFound     = errors.New("not_found")
+	ErrUpstream     = errors.New("upstream")
+	ErrInconsistent = errors.New("inconsistent")
+	ErrQuota        = errors.New("quota")
 )
 
+// FieldError is a validation failure of one input field. It matches
+// ErrValidation with errors.Is.
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e *FieldError) Error() string { return "validation: " + e.Field + ": " + e.Reason }
+
+func (e *FieldError) Unwrap() error { return ErrValidation }
+
+// TrashedDuplicateError reports that a book in the trash holds the ISBN a
+// book was given. It matches ErrConflict with errors.Is.
+type TrashedDuplicateError struct {
+	ISBN   string
+	BookID string // the book in the trash
+}
+
+func (e *TrashedDuplicateError) Error() string {
+	return "conflict: isbn " + e.ISBN + " belongs to book " + e.BookID + " in the trash"
+}
+
+func (e *TrashedDuplicateError) Unwrap() error { return ErrConflict }
+
+// ValidationErrors are the failures of several input fields of one
+// request, reported together so a client can flag every field at once. It
+// matches ErrValidation with errors.Is and its first FieldError with
+// errors.As.
+type ValidationErrors []*FieldError
+
+func (e ValidationErrors) Error() string {
+	parts := make([]string, len(e))
+	for i, fe := range e {
+		parts[i] = fe.Field + ": " + fe.Reason
+	}
+	return "validation: " + strings.Join(parts, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	out := make([]error, len(e))
+	for i, fe := range e {
+		out[i] = fe
+	}
+	return out
+}
+
 type EnrichmentMeta struct {
 	Attempted    bool
 	Source       string // e.g., "openlibrary"
@@ -31,6 +82,9 @@ type EnrichmentMeta struct {
 
 type Book struct {
 	ID            string
+	Version       int    // starts at 1, incremented by the repository on every write
+	Owner         string // user who created it when books are kept per user; see User
+	Tenant        string // library it belongs to when one instance serves several; see WithTenant
 	ISBN          *string
 	Title         string
 	Subtitle      *string
@@ -38,21 +92,37 @@ type Book struct {
 	PageCount     *int
 	CoverURL      *string
 	Tags          []string
-	Authors       []string // names only; no author entity
+	Authors       []string     // names, in display order
+	AuthorIDs     []string     // parallel to Authors; empty when authors are not linked
+	Description   *string      // from enrichment
+	Subjects      []string     // from enrichment
+	Translation   *Translation // description and subjects in the configured language
+	Embedding     *Embedding   // vector of the book's text for semantic search; not exposed
 	Enrichment    EnrichmentMeta
+	Forthcoming   bool       // not released yet; polled until a source lists it as released
+	ReleaseDate   *time.Time // day precision, UTC
+	PriceTarget   *Price     // set on watched books; alert when the price drops to it
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	DeletedAt     *time.Time     // set while the book is in the trash
+	Copies        map[string]int // branch id -> copies held there; no zero entries
+	Items         []Item         // physical copies, when tracked; then Copies counts them per branch
+	Lending       *Lending       // filled on reads when lending is configured; not persisted
+	Rating        *Rating        // filled on reads when reviews are kept; not persisted
+	Suggestions   []Suggestion   // create response only; not persisted
 }
 
 type Page[T any] struct {
-	Data     []T
-	Page     int
-	PageSize int
-	Total    int
+	Data       []T
+	Page       int
+	PageSize   int
+	Total      int    // -1 when not counted
+	SnapshotID string // set when the page was served from a pinned snapshot
+	NextCursor string // continues after the last item; empty on the last page
 }
 
 type SortKey struct {
-	Field string // title | published_year | created_at | updated_at
+	Field string // title | published_year | created_at | updated_at | rating
 	Desc  bool
 }
 
@@ -60,19 +130,82 @@ type ListQuery struct {
 	Q        *string // search in title/subtitle
 	Author   *string // contains, case-insensitive
 	Year     *int
-	Tag      *string // exact
-	Sort     []SortKey
-	Page     int
-	PageSize int
+	YearFrom *int    // published in this year or later
+	YearTo   *int    // published in this year or earlier
+	Tag      *string // exact, or with IncludeChildren also nested tags
+	Branch   *string // holds copies of the book
+	Barcode  *string // one of the book's items has this barcode
+	Owner    *string // set by the service when books are kept per user; not kept in cursors
+
+	UpdatedSince *time.Time // changed at or after this time
+	Sort         []SortKey
+	Page         int
+	PageSize     int
+
+	Snapshot   bool   // pin the result for a stable paginated walk
+	SnapshotID string // continue a walk over a pinned result
+
+	// Cursor continues after the last book of an earlier page, with that
+	// request's filters and sort; Page is ignored. Unlike offsets, a cursor
+	// neither skips nor repeats books when others are created or deleted.
+	Cursor string
+
+	IncludeDeleted  bool // also list books in the trash
+	IncludeChildren bool // Tag also matches the tags nested under it
+	SkipCount       bool // leave Page.Total at -1 instead of counting
+
+	// Available keeps the books of which a copy can be lent now (true) or
+	// none can (false); LentOut, the books whose loans and ready holds take
+	// every copy, is filled in by the service for it. Books tracking items
+	// none of which is Lendable cannot be lent either.
+	Available *bool
+	LentOut   map[string]bool
+
+	// Ratings, the ratings of the reviewed books, is filled in by the
+	// service for sorting by rating; unreviewed books sort as unrated.
+	Ratings map[string]Rating
 }
 
 type EnrichedBook struct {
+	Source        string // provider that answered, e.g. "openlibrary"
 	Title         *string
 	Subtitle      *string
 	PublishedYear *int
 	PageCount     *int
 	CoverURL      *string
 	Authors       []string
+	ReleaseDate   *time.Time // only set when the source gives a full date
+	Description   *string
+	Subjects      []string
+}
+
+// EnrichmentPreview is what enrichment found for an ISBN, with no book
+// created.
+type EnrichmentPreview struct {
+	ISBN string // as looked up, a plain ISBN-13
+	EnrichedBook
+}
+
+// Embedding is a unit-length vector of a book's title, subtitle,
+// description and subjects. TextHash tells whether that text changed since.
+type Embedding struct {
+	Model    string // embedder that produced it; vectors of different models do not compare
+	Vector   []float32
+	TextHash uint64
+}
+
+// ScoredBook is a search hit with its cosine similarity to the query.
+type ScoredBook struct {
+	Book  Book
+	Score float64
+}
+
+// Translation is a book's description and subjects in another language,
+// kept next to the originals.
+type Translation struct {
+	Language    string // target language code, e.g. "de"
+	Description *string
+	Subjects    []string // parallel to Book.Subjects
 }
 
 type CreateBookInput struct {
@@ -84,6 +217,1094 @@ type CreateBookInput struct {
 	CoverURL          *string
 	Tags              []string
 	Authors           []string
+	Forthcoming       bool
+	ReleaseDate       *time.Time
+	PriceTarget       *Price
 	Enrich            bool
 	RequireEnrichment bool
+	AutoCorrect       bool // replace near-miss tags/authors with existing ones
+}
+
+// ParsedBook is book data extracted from free text, for the client to
+// review and submit.
+type ParsedBook struct {
+	Input     CreateBookInput
+	Extractor string // extractor that produced it, e.g. "rules"
+}
+
+// Author is an entry in the author registry. Names are unique
+// case-insensitively, which is how authors listed on books are deduplicated.
+type Author struct {
+	ID        string
+	Name      string
+	Tenant    string // names are unique per tenant; see WithTenant
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type AuthorQuery struct {
+	Q        *string // contains, case-insensitive
+	Page     int
+	PageSize int
+}
+
+// BatchResult is the outcome of one item of a batch create; exactly one of
+// Book and Err is set.
+type BatchResult struct {
+	Book Book
+	Err  error
+}
+
+// ISBNMatch is a submitted ISBN found in the catalog.
+type ISBNMatch struct {
+	ISBN string // as submitted
+	Book Book
+}
+
+// CatalogComparison is the result of checking a list of ISBNs against the
+// catalog. Each list keeps the order of first submission.
+type CatalogComparison struct {
+	Present    []ISBNMatch
+	Missing    []string
+	Duplicated []string // submitted more than once
+}
+
+// UpdateBookInput replaces all user-editable fields of a book.
+type UpdateBookInput struct {
+	ISBN          *string
+	Title         *string
+	Subtitle      *string
+	PublishedYear *int
+	PageCount     *int
+	CoverURL      *string
+	Tags          []string
+	Authors       []string
+	Forthcoming   bool
+	ReleaseDate   *time.Time
+	PriceTarget   *Price
+	Version       *int // when set, the update fails with ErrConflict unless the book is at this version
+}
+
+// BookPatch changes only the fields that are set.
+type BookPatch struct {
+	ISBN          *string
+	Title         *string
+	Subtitle      *string
+	PublishedYear *int
+	PageCount     *int
+	CoverURL      *string
+	Tags          *[]string
+	Authors       *[]string
+	Forthcoming   *bool
+	ReleaseDate   *time.Time
+	PriceTarget   *Price
+	Version       *int // as in UpdateBookInput
+}
+
+const (
+	// EventBookReleased is emitted when a forthcoming book is found to be
+	// released.
+	EventBookReleased = "book.released"
+	// EventPriceBelowTarget is emitted when a watched book's price drops to
+	// its target.
+	EventPriceBelowTarget = "book.price_below_target"
+	// EventBookCreated and EventBookDeleted follow every stored create and
+	// every move to the trash.
+	EventBookCreated = "book.created"
+	EventBookDeleted = "book.deleted"
+	// EventBookRestored follows a book's restore from the trash.
+	EventBookRestored = "book.restored"
+	// EventBookUpdated, EventBookEnriched and EventBookPurged follow the
+	// other recorded changes; they are only published to the event bus.
+	EventBookUpdated  = "book.updated"
+	EventBookEnriched = "book.enriched"
+	EventBookPurged   = "book.purged"
+	// EventHoldReady is emitted when a returned copy is kept for, or lent
+	// to, the next holder of a book.
+	EventHoldReady = "book.hold_ready"
+	// EventLoanReminder, EventLoanEscalated and EventLoanLost are emitted
+	// by the overdue escalation policies.
+	EventLoanReminder  = "loan.reminder"
+	EventLoanEscalated = "loan.escalated"
+	EventLoanLost      = "loan.lost"
+	// EventImportStarted and EventImportFinished bracket a bulk import.
+	EventImportStarted  = "import.started"
+	EventImportFinished = "import.finished"
+)
+
+// Event is a notification about a change in the catalog.
+type Event struct {
+	Type   string
+	BookID string
+	Book   Book
+	Price  *Price // price events only
+	Hold   *Hold  // hold events only
+	Loan   *Loan  // loan events only
+	At     time.Time
+
+	// Set on events for the event bus: ID lets consumers drop the
+	// duplicates at-least-once delivery brings.
+	ID      string
+	Actor   string
+	Changes []FieldChange
+}
+
+// DeadLetter is an event whose delivery kept failing, kept for inspection
+// and replay.
+type DeadLetter struct {
+	ID       string
+	Event    Event
+	Error    string // of the last attempt
+	Attempts int
+	FailedAt time.Time // of the last attempt
+}
+
+// CatalogCounters are the live figures shown on dashboards.
+type CatalogCounters struct {
+	TotalBooks    int
+	AddedToday    int // created since midnight, server time
+	ActiveImports int
+	At            time.Time
+}
+
+// CoverPrefetch is the progress of the latest cover prefetch run.
+type CoverPrefetch struct {
+	Running    bool
+	StartedAt  time.Time // zero before the first run
+	FinishedAt time.Time // zero while running
+	Books      int       // books with a remote cover when the run started
+	Cached     int
+	Failed     int
+}
+
+// Price is an amount in minor units (cents) of an ISO 4217 currency.
+type Price struct {
+	Amount   int64
+	Currency string
+}
+
+// PricePoint is a price observed for a book.
+type PricePoint struct {
+	BookID string
+	Price  Price
+	Source string // e.g. "googlebooks"
+	At     time.Time
+}
+
+// Availability tells whether a library holds a book and can lend it now.
+type Availability struct {
+	Library   string
+	Held      bool
+	Copies    *int // nil when the catalog does not report holdings
+	Available *int // copies on the shelf; nil when unknown
+	CheckedAt time.Time
+}
+
+// Borrowable reports whether a copy can be lent now. Without copy counts,
+// a held book is assumed to be.
+func (a Availability) Borrowable() bool {
+	if a.Available != nil {
+		return *a.Available > 0
+	}
+	return a.Held
+}
+
+// Suggestion flags a submitted tag or author that is within a small edit
+// distance of one already in the catalog.
+type Suggestion struct {
+	Field      string // tags | authors
+	Value      string
+	Suggestion string
+	Applied    bool
+}
+
+// AutoTagRule adds AddTag to every book whose Field contains Contains
+// (case-insensitive).
+type AutoTagRule struct {
+	ID        string
+	Field     string // author | title | tag
+	Contains  string
+	AddTag    string
+	CreatedAt time.Time
+}
+
+// AuthorRename records a catalog-wide author rename.
+type AuthorRename struct {
+	From         string
+	To           string
+	ToID         string // author id linked to To; empty leaves author ids untouched
+	Tenant       string // tenant whose books were renamed; set by the repository
+	BooksUpdated int
+	RenamedAt    time.Time
+	// Changes are the books the rename changed, set by the repository; the
+	// history does not keep them.
+	Changes []BookChange
+}
+
+// BookChange is a book before and after a change that a repository made to
+// many books at once, such as a rename.
+type BookChange struct {
+	Before, After Book
+}
+
+// TagCount is a tag and how many books carry it.
+type TagCount struct {
+	Tag   string
+	Books int
+}
+
+// TagSeparator splits a tag into levels: programming/go is nested under
+// programming.
+const TagSeparator = "/"
+
+// TagNode is one level of the tag tree. Books counts the books carrying
+// exactly Tag, TotalBooks those carrying Tag or a tag nested under it.
+type TagNode struct {
+	Name       string // last level, e.g. "go"
+	Tag        string // full path, e.g. "programming/go"
+	Books      int
+	TotalBooks int
+	Children   []TagNode
+}
+
+// TagRename replaces every tag in From with To on all books; with several
+// From tags it merges them.
+type TagRename struct {
+	From         []string
+	To           string
+	Tenant       string // tenant whose books were renamed; set by the repository
+	BooksUpdated int
+	RenamedAt    time.Time
+	// Changes are the books the rename changed, set by the repository.
+	Changes []BookChange
+}
+
+// CoverSize names one of the renditions an uploaded cover is stored in.
+type CoverSize string
+
+const (
+	CoverSmall  CoverSize = "S"
+	CoverMedium CoverSize = "M"
+	CoverLarge  CoverSize = "L"
+)
+
+// ParseCoverSize reads S, M or L in either case; empty is CoverLarge.
+func ParseCoverSize(s string) (CoverSize, error) {
+	switch size := CoverSize(strings.ToUpper(s)); size {
+	case "":
+		return CoverLarge, nil
+	case CoverSmall, CoverMedium, CoverLarge:
+		return size, nil
+	}
+	return "", &FieldError{Field: "size", Reason: "must be S, M or L"}
+}
+
+// Open Library reading log shelves.
+const (
+	ShelfWantToRead       = "want-to-read"
+	ShelfCurrentlyReading = "currently-reading"
+	ShelfAlreadyRead      = "already-read"
+)
+
+// ReadingListRef names a reading list to import: the reading log of a
+// user, one shelf or all of them, or one of their lists by URL.
+type ReadingListRef struct {
+	Username string
+	Shelf    string // with Username; empty reads every shelf
+	ListURL  string
+}
+
+// ReadingListItem is a book on a reading list, resolved to one edition.
+type ReadingListItem struct {
+	Title      string
+	Authors    []string
+	ISBN       string // of the preferred edition; empty when none has one
+	WorkKey    string // e.g. "/works/OL45883W"
+	EditionKey string // e.g. "/books/OL7353617M"; empty without an edition
+}
+
+// ReadingListImport is the outcome of importing a reading list.
+type ReadingListImport struct {
+	Items    []ReadingListImportItem // in list order
+	Created  int
+	Existing int // already in the catalog by ISBN
+	Failed   int
+}
+
+// ReadingListImportItem is one item of an import; BookID is the created
+// book or, for an existing ISBN, the catalog's book. Err is set on failure.
+type ReadingListImportItem struct {
+	Item   ReadingListItem
+	BookID string
+	// Existing is set when the book's ISBN was already in the catalog.
+	Existing bool
+	Err      error
+}
+
+// ImportJob is a bulk CSV import running in the background. Its rows are
+// kept with it, so an import stopped by shutdown is paused at Offset and
+// resumes there on the next start.
+type ImportJob struct {
+	ID     string
+	Tenant string
+	Actor  string // who started it, the actor of the books it creates
+	User   User   // whose reading state the rows record
+	Status string // see ImportRunning
+	Rows   int    // data rows, excluding the header
+	Offset int    // rows processed
+	// Created and Failed count the processed rows; Errors reports the
+	// failed ones and reading states that could not be recorded.
+	Created    int
+	Failed     int
+	Errors     []ImportRowError
+	StartedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt *time.Time
+}
+
+// Import job statuses.
+const (
+	ImportRunning = "running"
+	ImportPaused  = "paused" // stopped by shutdown; resumed on the next start
+	ImportDone    = "done"
+)
+
+// ImportRow is one data row of a CSV import, as read: the book it creates
+// and the importing user's reading state of it, or the Error that makes it
+// fail.
+type ImportRow struct {
+	Line  int // in the CSV document; the header is line 1
+	Input CreateBookInput
+	State *ReadingState
+	Error string
+}
+
+// ImportRowError reports a row of an import. Kind is ErrorKind of the
+// failure.
+type ImportRowError struct {
+	Line    int
+	ISBN    *string
+	Kind    string
+	Message string
+}
+
+// errorKinds are the errors ErrorKind names.
+var errorKinds = []error{ErrValidation, ErrConflict, ErrNotFound, ErrUpstream, ErrInconsistent, ErrQuota}
+
+// ErrorKind returns the text of the error of this package err matches,
+// such as "conflict", for keeping the kind of an error as a string; it is
+// "" for any other error.
+func ErrorKind(err error) string {
+	for _, k := range errorKinds {
+		if errors.Is(err, k) {
+			return k.Error()
+		}
+	}
+	return ""
+}
+
+// KindError returns the error ErrorKind named kind, or nil.
+func KindError(kind string) error {
+	for _, k := range errorKinds {
+		if k.Error() == kind {
+			return k
+		}
+	}
+	return nil
+}
+
+// ExternalBook is a book found in a catalog outside this one, such as
+// Open Library, that can be imported.
+type ExternalBook struct {
+	Key              string // the source's id, e.g. "/works/OL45883W"
+	Title            string
+	Authors          []string
+	ISBNs            []string // ISBN-13s first
+	CoverURL         string
+	FirstPublishYear int // 0 when unknown
+}
+
+// ExternalImport selects an external book to create: Key as a search
+// returned it, and optionally the ISBN of the edition to create, which
+// otherwise is the source's preferred edition.
+type ExternalImport struct {
+	Key  string
+	ISBN string
+	Tags []string
+}
+
+// DuplicateModeTitle groups books by similar titles and a shared author.
+const DuplicateModeTitle = "title"
+
+// DuplicateGroup is a set of books that look like the same book entered
+// more than once.
+type DuplicateGroup struct {
+	Books []Book // oldest first
+}
+
+// Shelf is a shelf of a book-tracking site, as read from its feed.
+type Shelf struct {
+	Name    string // e.g. "to-read"; books synced from it are tagged so
+	Entries []ShelfEntry
+}
+
+// ShelfEntry is one book on a shelf.
+type ShelfEntry struct {
+	Title         string
+	Authors       []string
+	ISBN          string // may be empty
+	PublishedYear *int
+}
+
+// Outcomes of a shelf entry in a sync.
+const (
+	ShelfEntryCreated  = "created"  // a new book was created
+	ShelfEntryTagged   = "tagged"   // a matching book was tagged with the shelf
+	ShelfEntryExisting = "existing" // a matching book already had the tag, or is in the trash
+	ShelfEntryFailed   = "failed"
+)
+
+// ShelfSyncItem is the outcome of one shelf entry.
+type ShelfSyncItem struct {
+	Entry  ShelfEntry
+	Status string
+	BookID string
+	Err    error
+}
+
+// ShelfSyncReport is the outcome of syncing one shelf feed. Err is set
+// when the feed could not be read, and then there are no items.
+type ShelfSyncReport struct {
+	ID         string
+	Shelf      string
+	Source     string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Created    int
+	Tagged     int
+	Existing   int
+	Failed     int
+	Items      []ShelfSyncItem
+	Err        error
+}
+
+// IdempotencyRecord remembers the create request an Idempotency-Key was
+// first sent with. BookID is empty while that request is in progress.
+type IdempotencyRecord struct {
+	Key         string
+	Fingerprint string // hash of the request's input
+	BookID      string
+	CreatedAt   time.Time
+}
+
+// DuplicateExclusion marks books confirmed not to be duplicates of each
+// other, so the duplicate report stops pairing them.
+type DuplicateExclusion struct {
+	ID        string
+	BookIDs   []string
+	Tenant    string // of the books
+	CreatedAt time.Time
+}
+
+// Audit actions.
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditEnrich  = "enrich"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+)
+
+// AuditEntry records one change to a book: who made it, when, and the
+// fields it changed.
+type AuditEntry struct {
+	ID      string
+	BookID  string
+	Action  string // one of the Audit* actions
+	Actor   string // see ActorFromContext
+	At      time.Time
+	Changes []FieldChange
+	Tenant  string // tenant of the book
+}
+
+// FieldChange is one field of a book before and after a change, valued as
+// in the API's JSON (nil when unset), so entries keep their meaning when
+// stored as JSON.
+type FieldChange struct {
+	Field  string
+	Before any
+	After  any
+}
+
+// AuditQuery filters the audit log; zero fields match everything.
+type AuditQuery struct {
+	BookID string
+	Actor  string
+	Action string
+	Since  time.Time // inclusive
+	Until  time.Time // exclusive
+	Limit  int       // newest first; 0 means all
+}
+
+// ActorSystem is the actor of changes made outside a request, e.g. by the
+// release checker.
+const ActorSystem = "system"
+
+// ActorAnonymous is the actor of requests without credentials.
+const ActorAnonymous = "anonymous"
+
+type actorCtxKey struct{}
+
+// WithActor returns ctx carrying who makes the changes, for the audit log.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorCtxKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, or ActorSystem.
+func ActorFromContext(ctx context.Context) string {
+	if a, ok := ctx.Value(actorCtxKey{}).(string); ok && a != "" {
+		return a
+	}
+	return ActorSystem
+}
+
+// User is the caller a request acts for, as authentication identified
+// it: the same name as its actor, e.g. "api-key:<id>" or "anonymous".
+type User struct {
+	ID    string
+	Admin bool // sees every user's books
+}
+
+type userCtxKey struct{}
+
+// WithUser returns ctx carrying the user a request acts for.
+func WithUser(ctx context.Context, u User) context.Context {
+	return context.WithValue(ctx, userCtxKey{}, u)
+}
+
+// UserFromContext returns the user set by WithUser; work outside a request
+// has none.
+func UserFromContext(ctx context.Context) (User, bool) {
+	u, ok := ctx.Value(userCtxKey{}).(User)
+	return u, ok && u.ID != ""
+}
+
+type tenantCtxKey struct{}
+
+// WithTenant returns ctx carrying the tenant, the library a request works
+// for when one instance serves several. Book repositories only read and
+// write the books of the tenant of their context.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantCtxKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant set by WithTenant, or "" for work
+// outside a tenant's requests, which sees the books of every tenant.
+func TenantFromContext(ctx context.Context) string {
+	t, _ := ctx.Value(tenantCtxKey{}).(string)
+	return t
+}
+
+type requestIDCtxKey struct{}
+
+// WithRequestID returns ctx carrying the ID of the request it serves, for
+// correlating logs and upstream calls.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, id)
+}
+
+// RequestIDFromContext returns the ID set by WithRequestID, or "" outside
+// a request.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey{}).(string)
+	return id
+}
+
+// EventStage turns the book changes a repository write stores into the
+// domain events its outbox keeps. The service sets one in the context of
+// each write when the book repository is also its outbox; the repository
+// calls Event for every book the write changes and stores what it returns
+// in the same transaction as the change.
+type EventStage struct {
+	build  func(before, after *Book) (Event, bool)
+	staged bool
+}
+
+// NewEventStage returns a stage building events with build, which reports
+// false for a change that publishes none.
+func NewEventStage(build func(before, after *Book) (Event, bool)) *EventStage {
+	return &EventStage{build: build}
+}
+
+// Event returns the event of the change of before to after; a nil book has
+// every field unset.
+func (s *EventStage) Event(before, after *Book) (Event, bool) {
+	s.staged = true
+	return s.build(before, after)
+}
+
+// Staged reports whether a repository stored the events of the write, so
+// the service must not add them to the outbox again.
+func (s *EventStage) Staged() bool {
+	return s.staged
+}
+
+type eventStageCtxKey struct{}
+
+// WithEventStage returns ctx carrying the event stage of a write.
+func WithEventStage(ctx context.Context, s *EventStage) context.Context {
+	return context.WithValue(ctx, eventStageCtxKey{}, s)
+}
+
+// EventStageFromContext returns the stage set by WithEventStage, or nil.
+func EventStageFromContext(ctx context.Context) *EventStage {
+	s, _ := ctx.Value(eventStageCtxKey{}).(*EventStage)
+	return s
+}
+
+// Cover is a book's cover image, or for a book whose cover is only linked,
+// the URL it lives at.
+type Cover struct {
+	Data        []byte
+	ContentType string
+	URL         string // set instead of Data when no image is stored
+}
+
+// ConsistencyIssue describes one broken repository invariant found on startup.
+type ConsistencyIssue struct {
+	Kind     string // e.g. dangling_isbn_index, unindexed_isbn
+	Detail   string
+	Repaired bool
+}
+
+type ConsistencyReport struct {
+	Issues []ConsistencyIssue
+}
+
+// Unrepaired counts the issues that are still present.
+func (r ConsistencyReport) Unrepaired() int {
+	n := 0
+	for _, i := range r.Issues {
+		if !i.Repaired {
+			n++
+		}
+	}
+	return n
+}
+
+// Circuit breaker states.
+const (
+	BreakerClosed   = "closed"    // calls go through
+	BreakerOpen     = "open"      // calls fail at once until the cooldown ends
+	BreakerHalfOpen = "half_open" // the next call probes the provider
+)
+
+// CircuitBreakerStatus is the state of the breaker around one provider and
+// its counters since start.
+type CircuitBreakerStatus struct {
+	Name                string
+	State               string
+	Threshold           int           // consecutive failures that open it
+	Cooldown            time.Duration // open time before a probe
+	ConsecutiveFailures int
+	Failures            int64 // failed calls
+	Opens               int64 // times it opened
+	Rejected            int64 // calls failed while open
+	OpenedAt            *time.Time
+	LastError           string
+}
+
+// RecentView is a caller's latest look at a book.
+type RecentView struct {
+	BookID   string
+	ViewedAt time.Time
+}
+
+// ViewedBook is a recently viewed book as it is now.
+type ViewedBook struct {
+	Book     Book
+	ViewedAt time.Time
+}
+
+// Branch is a physical location of the library; books count their copies
+// per branch.
+type Branch struct {
+	ID        string // short code, e.g. "main", unique per tenant
+	Name      string
+	Address   string
+	Tenant    string // see WithTenant
+	CreatedAt time.Time
+}
+
+// BranchPatch changes only the fields of a branch that are set.
+type BranchPatch struct {
+	Name    *string
+	Address *string
+}
+
+// Transfer moves copies of a book from one branch to another.
+type Transfer struct {
+	BookID string
+	From   string
+	To     string
+	Copies int
+}
+
+// Loan lends a copy of a book to a borrower.
+type Loan struct {
+	ID           string
+	BookID       string
+	Borrower     string
+	CheckedOutAt time.Time
+	DueAt        time.Time
+	ReturnedAt   *time.Time // nil while the loan is active
+	Barcode      string     // the item lent, for books that track items
+	LostAt       *time.Time // set when an escalation policy marks it lost; it stays active
+	Tenant       string     // tenant of the book
+}
+
+// Overdue reports whether the loan is still active past its due time.
+func (l Loan) Overdue(now time.Time) bool {
+	return l.ReturnedAt == nil && now.After(l.DueAt)
+}
+
+type LoanStatus string
+
+const (
+	LoanActive   LoanStatus = "active"
+	LoanOverdue  LoanStatus = "overdue" // active and past due
+	LoanLost     LoanStatus = "lost"    // active and marked lost
+	LoanReturned LoanStatus = "returned"
+)
+
+// LoanQuery selects loans; empty fields match every loan. Now decides
+// which loans are overdue.
+type LoanQuery struct {
+	BookID   string
+	Borrower string
+	Status   LoanStatus
+	Now      time.Time
+}
+
+// Lending is the loan state of a book.
+type Lending struct {
+	Copies     int // copies that can be lent
+	CheckedOut int
+	Overdue    int
+	NextDueAt  *time.Time // due time of the first active loan
+	Holds      int        // borrowers queued, including those whose copy is ready
+	Ready      int        // copies kept for holders
+}
+
+// Hold queues a borrower for a book whose copies are all lent out.
+type Hold struct {
+	ID       string
+	BookID   string
+	Borrower string
+	PlacedAt time.Time
+	ReadyAt  *time.Time // set once a returned copy waits for the holder
+	LoanID   string     // set when the copy was lent to the holder at once
+	Position int        // 1-based place in the book's queue; filled on reads
+	Tenant   string     // tenant of the book
+}
+
+// Item is one physical copy of a book, told apart by its barcode.
+type Item struct {
+	Barcode   string
+	Branch    string // optional; the item counts toward the book's Copies there
+	Location  string // shelf or call number, free text
+	Condition ItemCondition
+	AddedAt   time.Time
+	OnLoan    bool // filled on reads when lending is configured; not persisted
+}
+
+// Lendable reports whether the item can be lent: missing and damaged
+// copies cannot, until their condition is changed.
+func (it Item) Lendable() bool {
+	return it.Condition != ItemMissing && it.Condition != ItemDamaged
+}
+
+// ItemPatch changes only the fields of an item that are set.
+type ItemPatch struct {
+	Branch    *string // "" takes the item off its branch
+	Location  *string
+	Condition *ItemCondition
+}
+
+type ItemCondition string
+
+const (
+	ItemNew     ItemCondition = "new"
+	ItemGood    ItemCondition = "good"
+	ItemFair    ItemCondition = "fair"
+	ItemPoor    ItemCondition = "poor"
+	ItemDamaged ItemCondition = "damaged"
+	ItemLost    ItemCondition = "lost"    // set by escalation when its loan is marked lost
+	ItemMissing ItemCondition = "missing" // set by a stocktake that did not find the item
+)
+
+// EscalationPolicy acts on every active loan AfterDays past its due date,
+// once per loan.
+type EscalationPolicy struct {
+	ID        string
+	Name      string
+	Action    EscalationAction
+	AfterDays int    // 0 acts on the due date
+	Tenant    string // whose loans it acts on; see WithTenant
+	CreatedAt time.Time
+}
+
+type EscalationAction string
+
+const (
+	EscalationRemind   EscalationAction = "remind"
+	EscalationEscalate EscalationAction = "escalate"
+	EscalationMarkLost EscalationAction = "mark_lost" // also marks the loan and its item lost
+)
+
+// EscalationRun records that a policy acted on a loan.
+type EscalationRun struct {
+	PolicyID string
+	LoanID   string
+	BookID   string
+	Borrower string
+	Action   EscalationAction
+	At       time.Time
+	Tenant   string // of the loan
+}
+
+// FinePolicy charges borrowers for late returns: PerDay for every day,
+// started or not, a loan runs past its due date beyond GraceDays, up to
+// Cap a loan when Cap is positive. Cap is in PerDay's currency.
+type FinePolicy struct {
+	PerDay    Price
+	Cap       int64
+	GraceDays int
+}
+
+type FeeKind string
+
+const (
+	FeeFine    FeeKind = "fine"    // charged when a late loan is returned
+	FeePayment FeeKind = "payment" // paid by the borrower
+	FeeWaiver  FeeKind = "waiver"  // forgiven by staff
+)
+
+// FeeEntry is a line in a borrower's fees ledger. Amount is positive;
+// Kind tells a charge from a credit.
+type FeeEntry struct {
+	ID       string
+	Borrower string
+	Kind     FeeKind
+	Amount   Price
+	LoanID   string
+	Note     string
+	At       time.Time
+	Tenant   string // tenant of the borrower's ledger
+}
+
+// Signed is the entry's amount as it counts toward the balance: positive
+// for a fine, negative for a payment or waiver.
+func (e FeeEntry) Signed() int64 {
+	if e.Kind == FeeFine {
+		return e.Amount.Amount
+	}
+	return -e.Amount.Amount
+}
+
+// FeeAccount is a borrower's ledger with what they owe.
+type FeeAccount struct {
+	Borrower string
+	Balance  Price // fines less payments and waivers
+	Entries  []FeeEntry
+}
+
+// Bookshelf is a shelf of a user's personal library. Every user has the
+// reading status shelves; a book is on at most one of them.
+type Bookshelf struct {
+	ID        string
+	User      string
+	Name      string
+	Status    bool // a reading status shelf
+	Books     int  // filled on reads
+	CreatedAt time.Time
+}
+
+// The reading status shelves, in reading order.
+const (
+	BookshelfWantToRead = "want-to-read"
+	BookshelfReading    = "reading"
+	BookshelfRead       = "read"
+)
+
+// ShelfItem is a book put on a shelf.
+type ShelfItem struct {
+	BookID  string
+	AddedAt time.Time
+}
+
+// ShelvedBook is a book on a shelf, as listed.
+type ShelvedBook struct {
+	Book    Book
+	AddedAt time.Time
+}
+
+// Borrower is a registered library user. With a borrower directory, loans,
+// holds and fees name borrowers by ID.
+type Borrower struct {
+	ID         string
+	Name       string
+	Email      string // optional; unique, case-insensitively
+	ExternalID string // optional, e.g. a card number; unique
+	Tenant     string // library the borrower is registered with; see WithTenant
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// BorrowerQuery selects borrowers by Q, matched against name, email and
+// external ID, case-insensitively; empty matches all.
+type BorrowerQuery struct {
+	Q        string
+	Page     int
+	PageSize int
+}
+
+// Review is a star rating of a book, with optional text, by one reviewer;
+// a reviewer has at most one review of a book.
+type Review struct {
+	ID        string
+	BookID    string
+	Reviewer  string // actor who wrote it, e.g. jwt:alice
+	Rating    int    // 1 to 5 stars
+	Text      string
+	CreatedAt time.Time
+	Tenant    string // tenant of the book
+}
+
+// Rating sums up the reviews of a book.
+type Rating struct {
+	Average float64
+	Count   int
+}
+
+// ReadingProgress is an update of how far a reader is in a book. Page and
+// Percent are both set when the book's page count is known; otherwise
+// only the one given is.
+type ReadingProgress struct {
+	BookID   string
+	Page     *int
+	Percent  *int // 0 to 100
+	Finished bool // at 100 percent
+	At       time.Time
+}
+
+// ReadingState is a reading status brought over from another service, such
+// as a Goodreads export: the shelf the book was on and when it was read.
+type ReadingState struct {
+	Shelf      string     // a reading status shelf id or the name of a shelf of the user's
+	FinishedAt *time.Time // nil when the book was not read
+}
+
+// ReadingStats sums up a reader's progress updates.
+type ReadingStats struct {
+	PagesPerWeek    []WeekPages // oldest first, weeks without reading included
+	FinishedPerYear []YearBooks // by year
+}
+
+// WeekPages are the pages read in the week from Start, a Monday in UTC.
+type WeekPages struct {
+	Start time.Time
+	Pages int
+}
+
+// YearBooks are the books finished in a year.
+type YearBooks struct {
+	Year  int
+	Books int
+}
+
+// KioskCheckout is a checkout at a self-service kiosk: the borrower's card
+// and the barcode of the copy scanned. Checkouts queued while the kiosk was
+// offline carry the ClientID the kiosk gave them, which makes their sync
+// idempotent, and when they were scanned.
+type KioskCheckout struct {
+	ClientID  string
+	Card      string
+	Barcode   string
+	ScannedAt *time.Time
+}
+
+// KioskSyncResult is the outcome of one synced kiosk checkout: its loan,
+// or why it was not lent.
+type KioskSyncResult struct {
+	ClientID string
+	Loan     Loan
+	Err      error
+}
+
+// Stocktake is an inventory check: the barcodes of the copies found on the
+// shelves are scanned and compared with the copies the catalog expects
+// there, those of Branch, or of every branch when empty, that are not on
+// loan. Closing it keeps its final Report.
+type Stocktake struct {
+	ID        string
+	Branch    string
+	StartedBy string // actor, e.g. jwt:alice
+	StartedAt time.Time
+	Scanned   []string // barcodes, in scan order, each once
+	ClosedAt  *time.Time
+	ClosedBy  string
+	Report    *StocktakeReport // set on close
+	Tenant    string           // see WithTenant
+}
+
+// StocktakeReport compares the scans of a stocktake with the catalog.
+type StocktakeReport struct {
+	Expected   int             // copies expected on the shelves
+	Seen       []StocktakeCopy // expected and scanned
+	Missing    []StocktakeCopy // expected but not scanned
+	Unexpected []StocktakeCopy // scanned but not expected, with a Reason
+	// MarkedMissing and Found count the copies closing the stocktake set
+	// to missing and back to good, when asked to.
+	MarkedMissing int
+	Found         int
+}
+
+// StocktakeCopy is a copy in a stocktake report; an unknown barcode has
+// only Barcode and Reason.
+type StocktakeCopy struct {
+	Barcode  string
+	BookID   string
+	Title    string
+	Branch   string
+	Location string
+	Reason   StocktakeReason // for unexpected copies
+}
+
+type StocktakeReason string
+
+const (
+	StocktakeUnknown     StocktakeReason = "unknown"      // no copy has the barcode
+	StocktakeOtherBranch StocktakeReason = "other_branch" // the copy is kept at another branch
+	StocktakeOnLoan      StocktakeReason = "on_loan"      // the copy is lent out
+)
+
+// LabelSelection names the copies to print labels for: those of the books
+// BookIDs names, then those Barcodes names.
+type LabelSelection struct {
+	BookIDs  []string
+	Barcodes []string
+}
+
+// Label is what the labels of one copy show; a book that tracks no items
+// has one label without a Barcode.
+type Label struct {
+	Barcode  string
+	BookID   string
+	Title    string
+	Author   string // the first author
+	Year     *int
+	Branch   string
+	Location string // shelf or call number
 }
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// EventPublisher sends domain events to a message bus, such as NATS, for
+// downstream systems like search indexers.
+type EventPublisher interface {
+	Publish(ctx context.Context, ev model.Event) error
+}
+
+// Outbox keeps the domain events of stored changes until they are
+// published, so that none is lost while the bus is down or the process
+// restarts. A BookRepository that is also the Outbox stores the events of
+// its writes in the same transaction as the books, from the EventStage in
+// their context; any other outbox gets them added after the write.
+type Outbox interface {
+	Add(ctx context.Context, ev model.Event) error
+	// Pending returns up to limit unpublished events, oldest first.
+	Pending(ctx context.Context, limit int) ([]model.Event, error)
+	// Ack drops published events.
+	Ack(ctx context.Context, ids []string) error
+}
+
+// outboxBatch is how many events one relay pass publishes at most.
+const outboxBatch = 100
+
+// eventTypes maps audit actions to the domain events they publish.
+var eventTypes = map[string]string{
+	model.AuditCreate:  model.EventBookCreated,
+	model.AuditUpdate:  model.EventBookUpdated,
+	model.AuditEnrich:  model.EventBookEnriched,
+	model.AuditDelete:  model.EventBookDeleted,
+	model.AuditRestore: model.EventBookRestored,
+	model.AuditPurge:   model.EventBookPurged,
+}
+
+// PublishEvents publishes the events waiting in the outbox, oldest first,
+// until it is empty or one fails; events after a failed one wait, so
+// consumers see a book's changes in order. It returns how many were
+// published.
+func (s *Service) PublishEvents(ctx context.Context) (int, error) {
+	if s.Outbox == nil || s.Publisher == nil {
+		return 0, nil
+	}
+	published := 0
+	for {
+		pending, err := s.Outbox.Pending(ctx, outboxBatch)
+		if err != nil || len(pending) == 0 {
+			return published, err
+		}
+		var ids []string
+		var pubErr error
+		for _, ev := range pending {
+			if pubErr = s.Publisher.Publish(ctx, ev); pubErr != nil {
+				pubErr = fmt.Errorf("publish event %s: %w", ev.ID, pubErr)
+				break
+			}
+			ids = append(ids, ev.ID)
+		}
+		if len(ids) > 0 {
+			if err := s.Outbox.Ack(ctx, ids); err != nil {
+				return published, err
+			}
+			published += len(ids)
+		}
+		if pubErr != nil {
+			return published, pubErr
+		}
+	}
+}
+
+// RelayEvents runs PublishEvents every interval until ctx ends.
+func (s *Service) RelayEvents(ctx context.Context, interval time.Duration, log *slog.Logger) {
+	every(ctx, interval, func(time.Time) {
+		n, err := s.PublishEvents(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.With("error", err).Warn("event relay failed", "published", n)
+		}
+	})
+}
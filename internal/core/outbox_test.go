@@ -0,0 +1,100 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePublisher struct {
+	down bool
+	got  []model.Event
+}
+
+func (f *fakePublisher) Publish(_ context.Context, ev model.Event) error {
+	if f.down {
+		return errors.New("connection refused")
+	}
+	f.got = append(f.got, ev)
+	return nil
+}
+
+func TestPublishEvents(t *testing.T) {
+	ctx := model.WithActor(context.Background(), "api-key:ci")
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	svc.Outbox = adapter.NewOutboxRepo()
+	pub := &fakePublisher{down: true}
+	svc.Publisher = pub
+
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	_, err = svc.PatchBook(ctx, b.ID, model.BookPatch{PageCount: util.GetPtr(412)})
+	require.NoError(t, err)
+	_, err = svc.PatchBook(ctx, b.ID, model.BookPatch{PageCount: util.GetPtr(412)}) // no change, no event
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteBook(ctx, b.ID))
+	_, err = svc.PurgeBooks(ctx, time.Time{})
+	require.NoError(t, err)
+
+	n, err := svc.PublishEvents(ctx)
+	assert.Error(t, err, "the bus is down")
+	assert.Zero(t, n)
+
+	pub.down = false
+	n, err = svc.PublishEvents(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	var types []string
+	for _, ev := range pub.got {
+		types = append(types, ev.Type)
+		assert.Equal(t, b.ID, ev.BookID)
+		assert.Equal(t, "api-key:ci", ev.Actor)
+		assert.NotEmpty(t, ev.ID)
+	}
+	assert.Equal(t, []string{model.EventBookCreated, model.EventBookUpdated, model.EventBookDeleted, model.EventBookPurged}, types)
+	assert.Equal(t, []model.FieldChange{{Field: "page_count", After: 412}}, pub.got[1].Changes)
+	assert.Equal(t, "Dune", pub.got[3].Book.Title, "a purged book is sent as it was")
+
+	n, err = svc.PublishEvents(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n, "published events are acked")
+}
+
+func TestPublishEvents_RepositoryKeepsTheOutbox(t *testing.T) {
+	ctx := context.Background()
+	db, err := adapter.OpenBoltDB(filepath.Join(t.TempDir(), "books.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	repo, err := adapter.OpenBoltBookRepo(db)
+	require.NoError(t, err)
+	svc := NewService(repo, mockEnrich{})
+	svc.Outbox = repo
+	pub := &fakePublisher{}
+	svc.Publisher = pub
+
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune"), Authors: []string{"Frank Herbert"}})
+	require.NoError(t, err)
+	_, err = svc.RenameAuthor(ctx, "Frank Herbert", "F. Herbert")
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteBook(ctx, b.ID))
+
+	n, err := svc.PublishEvents(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n, "each event once, stored by the repository")
+	var types []string
+	for _, ev := range pub.got {
+		types = append(types, ev.Type)
+	}
+	assert.Equal(t, []string{model.EventBookCreated, model.EventBookUpdated, model.EventBookDeleted}, types)
+	assert.Equal(t, "F. Herbert", pub.got[1].Book.Authors[0])
+}
@@ -0,0 +1,206 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MetadataExtractor turns free text, e.g. a pasted citation, into book
+// fields.
+type MetadataExtractor interface {
+	Name() string
+	Extract(ctx context.Context, text string) (model.CreateBookInput, error)
+}
+
+const maxParseText = 4096
+
+// ParseBook extracts book fields from free text for the client to review
+// before creating the book. The configured extractor is tried first; when
+// there is none or it fails, the built-in rules are used. Values that would
+// not validate, such as an ISBN with a wrong check digit, are dropped.
+func (s *Service) ParseBook(ctx context.Context, text string) (model.ParsedBook, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return model.ParsedBook{}, &model.FieldError{Field: "text", Reason: "must not be empty"}
+	}
+	if utf8.RuneCountInString(text) > maxParseText {
+		return model.ParsedBook{}, &model.FieldError{Field: "text", Reason: "must be at most 4096 characters"}
+	}
+	var ex MetadataExtractor = RuleExtractor{}
+	in, err := RuleExtractor{}.Extract(ctx, text)
+	if s.Extractor != nil {
+		if got, xerr := s.Extractor.Extract(ctx, text); xerr == nil {
+			ex, in, err = s.Extractor, got, nil
+		}
+	}
+	if err != nil {
+		return model.ParsedBook{}, err
+	}
+	if normalizeISBNPtr(&in.ISBN) != nil {
+		in.ISBN = nil
+	}
+	if validateNumbers(in.PageCount, nil) != nil {
+		in.PageCount = nil
+	}
+	if validateNumbers(nil, in.PublishedYear) != nil {
+		in.PublishedYear = nil
+	}
+	return model.ParsedBook{Input: in, Extractor: ex.Name()}, nil
+}
+
+// RuleExtractor reads citations with regular expressions: an ISBN, a year,
+// a page count ("432pp", "432 pages"), and authors and title in the usual
+// orders, "Surname, I. Title", "Author - Title" and "Title by Author".
+type RuleExtractor struct{}
+
+func (RuleExtractor) Name() string { return "rules" }
+
+var (
+	isbnCandidateRe = regexp.MustCompile(`(?i)(?:\bISBN(?:-1[03])?:?\s*)?\b[0-9][0-9\- ]{8,15}[0-9X]\b`)
+	pagesTextRe     = regexp.MustCompile(`(?i)\b(\d{1,5})\s*(?:pp|pages|p)\b\.?`)
+	yearTextRe      = regexp.MustCompile(`\(?\b(1[5-9]\d\d|20\d\d)\b\)?`)
+	emptyParensRe   = regexp.MustCompile(`\(\s*[,;]?\s*\)`)
+	spaceBeforeRe   = regexp.MustCompile(`\s+([.,;:])`)
+	repeatedPunctRe = regexp.MustCompile(`([.,;])[.,;]+`)
+	surnameRe       = regexp.MustCompile(`^(\p{Lu}[\p{L}'’]+(?:[ -]\p{Lu}[\p{L}'’]+)*),\s+`)
+	initialsRe      = regexp.MustCompile(`^((?:\p{Lu}\.\s?-?)+)`)
+	givenNameRe     = regexp.MustCompile(`^(\p{Lu}[\p{L}'’-]+(?:\s\p{Lu}\.)?)`)
+	authorSepRe     = regexp.MustCompile(`^[.,;]?\s*(?:(?:&|and)\s+)?`)
+	byRe            = regexp.MustCompile(`^(.+?)\s+by\s+(.+)$`)
+	dashRe          = regexp.MustCompile(`^(.+?)\s+[-–—]\s+(.+)$`)
+	andRe           = regexp.MustCompile(`\s*(?:,|;|&|\band\b)\s*`)
+)
+
+func (RuleExtractor) Extract(_ context.Context, text string) (model.CreateBookInput, error) {
+	var in model.CreateBookInput
+	s := strings.Join(strings.Fields(text), " ")
+
+	for _, loc := range isbnCandidateRe.FindAllStringIndex(s, -1) {
+		raw := s[loc[0]:loc[1]]
+		digits := strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' || r == 'X' || r == 'x' {
+				return r
+			}
+			return -1
+		}, raw[strings.IndexAny(raw, "0123456789"):])
+		if isbn, err := NormalizeISBN(digits); err == nil {
+			in.ISBN = &isbn
+			s = s[:loc[0]] + s[loc[1]:]
+			break
+		}
+	}
+	if m := pagesTextRe.FindStringSubmatchIndex(s); m != nil {
+		n, _ := strconv.Atoi(s[m[2]:m[3]])
+		in.PageCount = &n
+		s = s[:m[0]] + s[m[1]:]
+	}
+	// a year in parentheses is the publication year in most styles;
+	// otherwise the last one is, after title and publisher
+	years := yearTextRe.FindAllStringSubmatchIndex(s, -1)
+	if len(years) > 0 {
+		m := years[len(years)-1]
+		for _, y := range years {
+			if s[y[0]] == '(' {
+				m = y
+				break
+			}
+		}
+		n, _ := strconv.Atoi(s[m[2]:m[3]])
+		in.PublishedYear = &n
+		s = s[:m[0]] + s[m[1]:]
+	}
+	s = tidyCitation(s)
+
+	var authors []string
+	var rest string
+	if m := byRe.FindStringSubmatch(s); m != nil {
+		rest = m[1]
+		authors = splitAuthors(cutAny(m[2], "(", ", "))
+	} else if m := dashRe.FindStringSubmatch(s); m != nil && !strings.ContainsAny(m[1], ":.") {
+		authors = splitAuthors(m[1])
+		rest = m[2]
+	} else {
+		authors, rest = leadingAuthors(s)
+	}
+	in.Authors = authors
+
+	title := cutAny(strings.TrimLeft(rest, " .,;:"), ". ", ", ", " (")
+	title = strings.Trim(title, " .,;:")
+	if t, sub, ok := strings.Cut(title, ": "); ok {
+		sub = strings.TrimSpace(sub)
+		in.Subtitle = &sub
+		title = t
+	}
+	if title = strings.TrimSpace(title); title != "" {
+		in.Title = &title
+	}
+	return in, nil
+}
+
+// tidyCitation cleans up after values were cut out of a citation.
+func tidyCitation(s string) string {
+	s = emptyParensRe.ReplaceAllString(s, "")
+	s = spaceBeforeRe.ReplaceAllString(s, "$1")
+	s = repeatedPunctRe.ReplaceAllString(s, "$1")
+	return strings.Trim(strings.Join(strings.Fields(s), " "), " ,;")
+}
+
+// leadingAuthors reads "Surname, Given" names at the start of s, as in APA
+// and MLA citations, and returns them in display order with the rest of s.
+func leadingAuthors(s string) ([]string, string) {
+	var authors []string
+	for {
+		m := surnameRe.FindStringSubmatch(s)
+		if m == nil {
+			return authors, s
+		}
+		after := s[len(m[0]):]
+		given := initialsRe.FindString(after)
+		if given == "" {
+			given = givenNameRe.FindString(after)
+			// a given name ends the name; "Clean Architecture, Prentice
+			// Hall" is a title and a publisher
+			if given == "" || !strings.HasSuffix(given, ".") && !endsName(after[len(given):]) {
+				return authors, s
+			}
+		}
+		authors = append(authors, strings.TrimSpace(given)+" "+m[1])
+		s = after[len(given):]
+		s = s[len(authorSepRe.FindString(s)):]
+	}
+}
+
+func endsName(s string) bool {
+	return s == "" || strings.ContainsAny(s[:1], ".,(")
+}
+
+// splitAuthors splits "A, B and C"; "Surname, Given" is kept together when
+// it is the only name.
+func splitAuthors(s string) []string {
+	s = strings.TrimSpace(s)
+	if names, rest := leadingAuthors(s + "."); len(names) > 0 && strings.Trim(rest, " .") == "" {
+		return names
+	}
+	var out []string
+	for _, a := range andRe.Split(s, -1) {
+		if a = strings.Trim(a, " ."); a != "" {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// cutAny returns s up to the first of seps.
+func cutAny(s string, seps ...string) string {
+	end := len(s)
+	for _, sep := range seps {
+		if i := strings.Index(s, sep); i >= 0 && i < end {
+			end = i
+		}
+	}
+	return s[:end]
+}
@@ -0,0 +1,106 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleExtractor(t *testing.T) {
+	tests := []struct {
+		text     string
+		title    string
+		subtitle string
+		authors  []string
+		isbn     string
+		year     int
+		pages    int
+	}{
+		{text: "Martin, R. Clean Architecture, Prentice Hall 2017, 432pp",
+			title: "Clean Architecture", authors: []string{"R. Martin"}, year: 2017, pages: 432},
+		{text: "Herbert, Frank. (1965). Dune. Chilton Books. ISBN 978-0-441-01359-3",
+			title: "Dune", authors: []string{"Frank Herbert"}, isbn: "9780441013593", year: 1965},
+		{text: "Clean Code: A Handbook of Agile Software Craftsmanship by Robert C. Martin (2008)",
+			title: "Clean Code", subtitle: "A Handbook of Agile Software Craftsmanship", authors: []string{"Robert C. Martin"}, year: 2008},
+		{text: "Kernighan and Ritchie - The C Programming Language, 272 pages",
+			title: "The C Programming Language", authors: []string{"Kernighan", "Ritchie"}, pages: 272},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			in, err := RuleExtractor{}.Extract(context.Background(), tt.text)
+			require.NoError(t, err)
+			require.NotNil(t, in.Title)
+			assert.Equal(t, tt.title, *in.Title)
+			if tt.subtitle != "" {
+				require.NotNil(t, in.Subtitle)
+				assert.Equal(t, tt.subtitle, *in.Subtitle)
+			}
+			assert.Equal(t, tt.authors, in.Authors)
+			if tt.isbn != "" {
+				require.NotNil(t, in.ISBN)
+				assert.Equal(t, tt.isbn, *in.ISBN)
+			}
+			if tt.year != 0 {
+				require.NotNil(t, in.PublishedYear)
+				assert.Equal(t, tt.year, *in.PublishedYear)
+			}
+			if tt.pages != 0 {
+				require.NotNil(t, in.PageCount)
+				assert.Equal(t, tt.pages, *in.PageCount)
+			}
+		})
+	}
+}
+
+// fakeExtractor answers with a fixed result or fails.
+type fakeExtractor struct {
+	in  model.CreateBookInput
+	err error
+}
+
+func (fakeExtractor) Name() string { return "fake" }
+
+func (f fakeExtractor) Extract(ctx context.Context, text string) (model.CreateBookInput, error) {
+	return f.in, f.err
+}
+
+func TestParseBook(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), nil)
+	svc.Extractor = fakeExtractor{in: model.CreateBookInput{
+		Title:         util.GetPtr("Dune"),
+		ISBN:          util.GetPtr("9780441013590"), // wrong check digit
+		PublishedYear: util.GetPtr(1965),
+		PageCount:     util.GetPtr(-1),
+	}}
+
+	res, err := svc.ParseBook(ctx, "Dune, Frank Herbert")
+	require.NoError(t, err)
+	assert.Equal(t, "fake", res.Extractor)
+	assert.Equal(t, "Dune", *res.Input.Title)
+	assert.Nil(t, res.Input.ISBN)
+	assert.Nil(t, res.Input.PageCount)
+	assert.Equal(t, 1965, *res.Input.PublishedYear)
+
+	// a failing extractor falls back to the rules
+	svc.Extractor = fakeExtractor{err: errors.New("rate limited")}
+	res, err = svc.ParseBook(ctx, "Dune by Frank Herbert")
+	require.NoError(t, err)
+	assert.Equal(t, "rules", res.Extractor)
+	assert.Equal(t, "Dune", *res.Input.Title)
+	assert.Equal(t, []string{"Frank Herbert"}, res.Input.Authors)
+
+	_, err = svc.ParseBook(ctx, "  ")
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.ParseBook(ctx, strings.Repeat("x", maxParseText+1))
+	assert.ErrorIs(t, err, model.ErrValidation)
+}
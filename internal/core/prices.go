@@ -0,0 +1,117 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// PriceProvider quotes the current price of a book.
+type PriceProvider interface {
+	FetchPrice(ctx context.Context, isbn string) (model.PricePoint, error)
+}
+
+// PriceRepository keeps the price history of books.
+type PriceRepository interface {
+	Add(ctx context.Context, p model.PricePoint) error
+	// List returns the points of one book, oldest first.
+	List(ctx context.Context, bookID string) ([]model.PricePoint, error)
+	// Last returns the newest point of a book, or false if it has none.
+	Last(ctx context.Context, bookID string) (model.PricePoint, bool, error)
+}
+
+// RecordPrices quotes every book with a price target and an ISBN, i.e. the
+// watched books, and records the prices. When a price is at or below the
+// target in the same currency, and the previous point was not, a
+// book.price_below_target event is sent. Failed quotes are skipped; the
+// number of recorded points is returned.
+func (s *Service) RecordPrices(ctx context.Context, now time.Time) (int, error) {
+	if s.Pricing == nil || s.Prices == nil {
+		return 0, nil
+	}
+	var watched []model.Book
+	err := s.WalkBooks(ctx, model.ListQuery{}, func(b model.Book) error {
+		if b.PriceTarget != nil && b.ISBN != nil {
+			watched = append(watched, b)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	recorded := 0
+	var errs []error
+	for _, b := range watched {
+		p, err := s.Pricing.FetchPrice(ctx, *b.ISBN)
+		if ctx.Err() != nil {
+			return recorded, ctx.Err()
+		}
+		if err != nil {
+			continue
+		}
+		prev, hadPrev, err := s.Prices.Last(ctx, b.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		p.BookID, p.At = b.ID, now
+		if err := s.Prices.Add(ctx, p); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		recorded++
+		if !atOrBelow(p.Price, *b.PriceTarget) || (hadPrev && atOrBelow(prev.Price, *b.PriceTarget)) {
+			continue
+		}
+		price := p.Price
+		ev := model.Event{Type: model.EventPriceBelowTarget, BookID: b.ID, Book: b, Price: &price, At: now}
+		if err := s.notify(ctx, ev); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return recorded, errors.Join(errs...)
+}
+
+// WatchPrices runs RecordPrices every interval until ctx ends.
+func (s *Service) WatchPrices(ctx context.Context, interval time.Duration, log *slog.Logger) {
+	every(ctx, interval, func(now time.Time) {
+		n, err := s.RecordPrices(ctx, now)
+		if err != nil && ctx.Err() == nil {
+			log.With("error", err).Warn("price check failed", "recorded", n)
+		}
+	})
+}
+
+// PriceHistory returns the recorded prices of a book, oldest first.
+func (s *Service) PriceHistory(ctx context.Context, id string) ([]model.PricePoint, error) {
+	if _, err := s.getBook(ctx, id); err != nil {
+		return nil, model.ErrNotFound
+	}
+	if s.Prices == nil {
+		return nil, nil
+	}
+	return s.Prices.List(ctx, id)
+}
+
+func atOrBelow(p, target model.Price) bool {
+	return p.Currency == target.Currency && p.Amount <= target.Amount
+}
+
+// normalizePrice validates a price target and upper-cases its currency.
+func normalizePrice(p *model.Price) error {
+	if p == nil {
+		return nil
+	}
+	if p.Amount <= 0 {
+		return &model.FieldError{Field: "price_target", Reason: "amount must be positive"}
+	}
+	p.Currency = strings.ToUpper(strings.TrimSpace(p.Currency))
+	if len(p.Currency) != 3 || strings.Trim(p.Currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return &model.FieldError{Field: "price_target", Reason: "currency must be a 3-letter ISO 4217 code"}
+	}
+	return nil
+}
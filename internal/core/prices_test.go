@@ -0,0 +1,82 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedPrices quotes prices by ISBN; unknown ISBNs are not for sale.
+type fixedPrices map[string]model.Price
+
+func (f fixedPrices) FetchPrice(_ context.Context, isbn string) (model.PricePoint, error) {
+	p, ok := f[isbn]
+	if !ok {
+		return model.PricePoint{}, errors.New("not for sale")
+	}
+	return model.PricePoint{Price: p, Source: "test"}, nil
+}
+
+func TestRecordPrices(t *testing.T) {
+	ctx := context.Background()
+	prices := fixedPrices{"9780134494166": {Amount: 2499, Currency: "EUR"}, "9780123456786": {Amount: 500, Currency: "EUR"}}
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	svc.Prices = adapter.NewPriceRepo()
+	svc.Pricing = prices
+	notes := &recordNotifier{}
+	svc.Notifier = notes
+
+	watched, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Wanted"), ISBN: util.GetPtr("9780134494166"),
+		PriceTarget: &model.Price{Amount: 2000, Currency: "eur"}})
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", watched.PriceTarget.Currency)
+	owned, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Owned"), ISBN: util.GetPtr("9780123456786")})
+	require.NoError(t, err)
+
+	day := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	n, err := svc.RecordPrices(ctx, day)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n, "only books with a target are watched")
+	assert.Empty(t, notes.events)
+
+	prices["9780134494166"] = model.Price{Amount: 1999, Currency: "EUR"}
+	for i := 1; i <= 2; i++ {
+		_, err = svc.RecordPrices(ctx, day.AddDate(0, 0, i))
+		require.NoError(t, err)
+	}
+	require.Len(t, notes.events, 1, "alert once when the price crosses the target")
+	assert.Equal(t, model.EventPriceBelowTarget, notes.events[0].Type)
+	assert.Equal(t, int64(1999), notes.events[0].Price.Amount)
+
+	hist, err := svc.PriceHistory(ctx, watched.ID)
+	require.NoError(t, err)
+	require.Len(t, hist, 3)
+	assert.Equal(t, int64(2499), hist[0].Price.Amount)
+	assert.Equal(t, day, hist[0].At)
+	assert.Equal(t, "test", hist[2].Source)
+
+	hist, err = svc.PriceHistory(ctx, owned.ID)
+	require.NoError(t, err)
+	assert.Empty(t, hist)
+	_, err = svc.PriceHistory(ctx, "missing")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestPriceTargetValidation(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	for _, p := range []model.Price{{Amount: 0, Currency: "EUR"}, {Amount: 100, Currency: "EURO"}, {Amount: 100, Currency: "E1R"}} {
+		_, err := svc.CreateBook(context.Background(), model.CreateBookInput{Title: util.GetPtr("X"), PriceTarget: &p})
+		var fe *model.FieldError
+		require.ErrorAs(t, err, &fe, "%+v", p)
+		assert.Equal(t, "price_target", fe.Field)
+	}
+}
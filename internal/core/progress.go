@@ -0,0 +1,140 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ProgressRepository keeps every update of each user's reading progress.
+type ProgressRepository interface {
+	// Add records an update in time order; an imported one may be older
+	// than those recorded already.
+	Add(ctx context.Context, user string, p model.ReadingProgress) error
+	// History returns user's updates of a book, oldest first.
+	History(ctx context.Context, user, bookID string) ([]model.ReadingProgress, error)
+	// All returns user's updates of every book, oldest first.
+	All(ctx context.Context, user string) ([]model.ReadingProgress, error)
+}
+
+const (
+	defaultStatsWeeks = 12
+	maxStatsWeeks     = 104
+)
+
+// UpdateProgress records how far the caller is in a book, by page or by
+// percentage; exactly one is given. With the book's page count known the
+// other is worked out from it.
+func (s *Service) UpdateProgress(ctx context.Context, bookID string, page, percent *int) (model.ReadingProgress, error) {
+	if s.Progress == nil {
+		return model.ReadingProgress{}, fmt.Errorf("%w: reading progress is not tracked", model.ErrNotFound)
+	}
+	b, err := s.getBook(ctx, bookID)
+	if err != nil {
+		return model.ReadingProgress{}, model.ErrNotFound
+	}
+	var v validator
+	v.check((page == nil) != (percent == nil), "page", "give either page or percent")
+	if page != nil {
+		v.check(*page >= 0, "page", "must not be negative")
+		if b.PageCount != nil && *b.PageCount > 0 {
+			v.check(*page <= *b.PageCount, "page", fmt.Sprintf("must be at most the book's %d pages", *b.PageCount))
+		}
+	}
+	if percent != nil {
+		v.check(*percent >= 0 && *percent <= 100, "percent", "must be between 0 and 100")
+	}
+	if err := v.err(); err != nil {
+		return model.ReadingProgress{}, err
+	}
+	p := model.ReadingProgress{BookID: b.ID, Page: page, Percent: percent, At: time.Now().UTC()}
+	if n := b.PageCount; n != nil && *n > 0 {
+		if page != nil {
+			pct := *page * 100 / *n
+			p.Percent = &pct
+		} else {
+			pg := *percent * *n / 100
+			p.Page = &pg
+		}
+	}
+	p.Finished = p.Percent != nil && *p.Percent == 100
+	if err := s.Progress.Add(ctx, model.ActorFromContext(ctx), p); err != nil {
+		return model.ReadingProgress{}, err
+	}
+	return p, nil
+}
+
+// ProgressHistory returns the caller's progress updates of a book, oldest
+// first; the last is where they are now.
+func (s *Service) ProgressHistory(ctx context.Context, bookID string) ([]model.ReadingProgress, error) {
+	if s.Progress == nil {
+		return nil, fmt.Errorf("%w: reading progress is not tracked", model.ErrNotFound)
+	}
+	if _, err := s.getBook(ctx, bookID); err != nil {
+		return nil, model.ErrNotFound
+	}
+	h, err := s.Progress.History(ctx, model.ActorFromContext(ctx), bookID)
+	if err != nil {
+		return nil, err
+	}
+	if len(h) == 0 {
+		return nil, fmt.Errorf("%w: no progress recorded for book %s", model.ErrNotFound, bookID)
+	}
+	return h, nil
+}
+
+// ReadingStats sums up the caller's progress: the pages read in each of the
+// last weeks (1 to 104, up to now) and the books finished per year. Pages
+// read are the forward steps between updates of a book; a book counts as
+// finished each time it reaches 100 percent.
+func (s *Service) ReadingStats(ctx context.Context, weeks int, now time.Time) (model.ReadingStats, error) {
+	if s.Progress == nil {
+		return model.ReadingStats{}, fmt.Errorf("%w: reading progress is not tracked", model.ErrNotFound)
+	}
+	if weeks < 1 || weeks > maxStatsWeeks {
+		return model.ReadingStats{}, &model.FieldError{Field: "weeks", Reason: fmt.Sprintf("must be between 1 and %d", maxStatsWeeks)}
+	}
+	all, err := s.Progress.All(ctx, model.ActorFromContext(ctx))
+	if err != nil {
+		return model.ReadingStats{}, err
+	}
+	first := weekStart(now).AddDate(0, 0, -7*(weeks-1))
+	out := model.ReadingStats{PagesPerWeek: make([]model.WeekPages, weeks), FinishedPerYear: []model.YearBooks{}}
+	for i := range out.PagesPerWeek {
+		out.PagesPerWeek[i].Start = first.AddDate(0, 0, 7*i)
+	}
+	lastPage := map[string]int{}
+	finished := map[string]bool{}
+	for _, p := range all {
+		if p.Page != nil {
+			if read := *p.Page - lastPage[p.BookID]; read > 0 && !p.At.Before(first) {
+				i := int(weekStart(p.At).Sub(first) / (7 * 24 * time.Hour))
+				if i < weeks {
+					out.PagesPerWeek[i].Pages += read
+				}
+			}
+			lastPage[p.BookID] = *p.Page
+		}
+		if p.Finished && !finished[p.BookID] {
+			year := p.At.UTC().Year()
+			i := slices.IndexFunc(out.FinishedPerYear, func(y model.YearBooks) bool { return y.Year == year })
+			if i < 0 {
+				out.FinishedPerYear = append(out.FinishedPerYear, model.YearBooks{Year: year})
+				i = len(out.FinishedPerYear) - 1
+			}
+			out.FinishedPerYear[i].Books++
+		}
+		finished[p.BookID] = p.Finished
+	}
+	slices.SortFunc(out.FinishedPerYear, func(a, b model.YearBooks) int { return a.Year - b.Year })
+	return out, nil
+}
+
+// weekStart is the Monday, in UTC, of the week of t.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
@@ -0,0 +1,86 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, monday, weekStart(monday))
+	assert.Equal(t, monday, weekStart(time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC)), "sunday")
+	assert.Equal(t, monday, weekStart(time.Date(2026, 10, 14, 1, 0, 0, 0, time.FixedZone("CEST", 2*3600))))
+}
+
+func TestUpdateProgress(t *testing.T) {
+	alice := model.WithActor(context.Background(), "jwt:alice")
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	dune, err := svc.CreateBook(alice, model.CreateBookInput{Title: util.GetPtr("Dune"), PageCount: util.GetPtr(400)})
+	require.NoError(t, err)
+	emma, err := svc.CreateBook(alice, model.CreateBookInput{Title: util.GetPtr("Emma")})
+	require.NoError(t, err)
+	_, err = svc.UpdateProgress(alice, dune.ID, util.GetPtr(10), nil)
+	assert.ErrorIs(t, err, model.ErrNotFound, "not tracked")
+
+	svc.Progress = adapter.NewProgressRepo()
+	_, err = svc.UpdateProgress(alice, dune.ID, nil, nil)
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.UpdateProgress(alice, dune.ID, util.GetPtr(401), nil)
+	assert.ErrorIs(t, err, model.ErrValidation, "past the last page")
+	_, err = svc.UpdateProgress(alice, emma.ID, nil, util.GetPtr(101))
+	assert.ErrorIs(t, err, model.ErrValidation)
+
+	p, err := svc.UpdateProgress(alice, dune.ID, util.GetPtr(100), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 25, *p.Percent)
+	p, err = svc.UpdateProgress(alice, dune.ID, nil, util.GetPtr(100))
+	require.NoError(t, err)
+	assert.Equal(t, 400, *p.Page)
+	assert.True(t, p.Finished)
+	p, err = svc.UpdateProgress(alice, emma.ID, util.GetPtr(50), nil)
+	require.NoError(t, err)
+	assert.Nil(t, p.Percent, "no page count")
+
+	h, err := svc.ProgressHistory(alice, dune.ID)
+	require.NoError(t, err)
+	assert.Len(t, h, 2)
+	_, err = svc.ProgressHistory(model.WithActor(context.Background(), "jwt:bob"), dune.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound, "progress is personal")
+}
+
+func TestReadingStats(t *testing.T) {
+	ctx := model.WithActor(context.Background(), "jwt:alice")
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Progress = adapter.NewProgressRepo()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) // a Friday
+	add := func(book string, page int, at time.Time) {
+		p := model.ReadingProgress{BookID: book, Page: &page, At: at}
+		p.Finished = page == 300
+		require.NoError(t, svc.Progress.Add(ctx, "jwt:alice", p))
+	}
+	add("a", 50, now.AddDate(0, 0, -30))  // before the weeks reported
+	add("a", 120, now.AddDate(0, 0, -10)) // week of Oct 5
+	add("a", 100, now.AddDate(0, 0, -9))  // went back
+	add("a", 300, now.AddDate(0, 0, -1))  // week of Oct 12, finished
+	add("b", 300, now.AddDate(-1, 0, 0))  // finished last year
+	add("b", 10, now.AddDate(0, 0, -2))   // re-reading
+	add("b", 300, now.Add(-time.Hour))    // finished again
+
+	_, err := svc.ReadingStats(ctx, 0, now)
+	assert.ErrorIs(t, err, model.ErrValidation)
+	stats, err := svc.ReadingStats(ctx, 3, now)
+	require.NoError(t, err)
+	require.Len(t, stats.PagesPerWeek, 3)
+	assert.Equal(t, time.Date(2026, 9, 28, 0, 0, 0, 0, time.UTC), stats.PagesPerWeek[0].Start)
+	assert.Equal(t, []int{0, 70, 200 + 290}, []int{stats.PagesPerWeek[0].Pages, stats.PagesPerWeek[1].Pages, stats.PagesPerWeek[2].Pages})
+	assert.Equal(t, []model.YearBooks{{Year: 2025, Books: 1}, {Year: 2026, Books: 2}}, stats.FinishedPerYear)
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ReadingListSource reads reading lists kept on a book-tracking site.
+type ReadingListSource interface {
+	// ReadingList returns the books on a list in list order, each resolved
+	// to its preferred edition. An unknown user or list fails with an error
+	// matching model.ErrNotFound, a malformed reference with a
+	// *model.FieldError.
+	ReadingList(ctx context.Context, ref model.ReadingListRef) ([]model.ReadingListItem, error)
+}
+
+// ImportReadingList creates the books on a reading list, enriching those
+// with an ISBN. Books whose ISBN is already in the catalog are reported as
+// existing, not failed. The list is read in full before anything is
+// created, so a list that cannot be read creates nothing.
+func (s *Service) ImportReadingList(ctx context.Context, ref model.ReadingListRef) (model.ReadingListImport, error) {
+	if s.ReadingLists == nil {
+		return model.ReadingListImport{}, fmt.Errorf("%w: no reading list source configured", model.ErrNotFound)
+	}
+	ref.Username = strings.TrimSpace(ref.Username)
+	ref.Shelf = strings.TrimSpace(ref.Shelf)
+	ref.ListURL = strings.TrimSpace(ref.ListURL)
+	switch {
+	case (ref.Username == "") == (ref.ListURL == ""):
+		return model.ReadingListImport{}, &model.FieldError{Field: "username", Reason: "give either username or list_url"}
+	case ref.Shelf != "" && ref.Username == "":
+		return model.ReadingListImport{}, &model.FieldError{Field: "shelf", Reason: "only applies to username"}
+	case ref.Shelf != "" && ref.Shelf != model.ShelfWantToRead && ref.Shelf != model.ShelfCurrentlyReading && ref.Shelf != model.ShelfAlreadyRead:
+		return model.ReadingListImport{}, &model.FieldError{Field: "shelf", Reason: "must be want-to-read, currently-reading or already-read"}
+	}
+
+	items, err := s.ReadingLists.ReadingList(ctx, ref)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) || errors.Is(err, model.ErrValidation) || ctx.Err() != nil {
+			return model.ReadingListImport{}, err
+		}
+		return model.ReadingListImport{}, fmt.Errorf("%w: %v", model.ErrUpstream, err)
+	}
+	out := model.ReadingListImport{Items: make([]model.ReadingListImportItem, len(items))}
+	if len(items) == 0 {
+		return out, nil
+	}
+	if len(items) > maxBatchItems {
+		return model.ReadingListImport{}, &model.FieldError{Field: "list", Reason: fmt.Sprintf("has more than %d books", maxBatchItems)}
+	}
+
+	done := s.StartImport(ctx)
+	defer done()
+	inputs := make([]model.CreateBookInput, len(items))
+	for i, it := range items {
+		in := model.CreateBookInput{Authors: it.Authors}
+		if it.Title != "" {
+			in.Title = &it.Title
+		}
+		if it.ISBN != "" {
+			in.ISBN = &it.ISBN
+			in.Enrich = true
+		}
+		inputs[i] = in
+	}
+	results, err := s.CreateBooks(ctx, inputs)
+	if err != nil {
+		return model.ReadingListImport{}, err
+	}
+	for i, r := range results {
+		item := model.ReadingListImportItem{Item: items[i], BookID: r.Book.ID, Err: r.Err}
+		if r.Err != nil && errors.Is(r.Err, model.ErrConflict) && items[i].ISBN != "" {
+			if b, err := s.Repo.GetByISBN(ctx, s.owner(ctx), isbnKey(items[i].ISBN)); err == nil {
+				if b.DeletedAt == nil {
+					item.BookID, item.Existing, item.Err = b.ID, true, nil
+					out.Existing++
+					out.Items[i] = item
+					continue
+				}
+				item.Err = fmt.Errorf("%w: book %s with this ISBN is in the trash", model.ErrConflict, b.ID)
+			}
+		}
+		if item.Err != nil {
+			out.Failed++
+		} else {
+			out.Created++
+		}
+		out.Items[i] = item
+	}
+	return out, nil
+}
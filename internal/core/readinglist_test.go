@@ -0,0 +1,93 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReadingLists struct {
+	items []model.ReadingListItem
+	err   error
+	got   model.ReadingListRef
+}
+
+func (f *fakeReadingLists) ReadingList(_ context.Context, ref model.ReadingListRef) ([]model.ReadingListItem, error) {
+	f.got = ref
+	return f.items, f.err
+}
+
+func TestImportReadingList(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	src := &fakeReadingLists{items: []model.ReadingListItem{
+		{Title: "Dune", Authors: []string{"Frank Herbert"}, ISBN: "9780441172719", WorkKey: "/works/OL893415W"},
+		{Title: "Neuromancer", Authors: []string{"William Gibson"}, ISBN: "978-0-441-56959-5"},
+		{Title: "Zine without ISBN"},
+		{Title: "Broken", ISBN: "123"},
+		{Title: "Gone", ISBN: "9780618640157"},
+	}}
+	svc.ReadingLists = src
+	neuromancer, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Neuromancer"), ISBN: util.GetPtr("9780441569595")})
+	require.NoError(t, err)
+	gone, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Gone"), ISBN: util.GetPtr("9780618640157")})
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteBook(ctx, gone.ID))
+
+	res, err := svc.ImportReadingList(ctx, model.ReadingListRef{Username: " alice ", Shelf: model.ShelfWantToRead})
+	require.NoError(t, err)
+	assert.Equal(t, model.ReadingListRef{Username: "alice", Shelf: model.ShelfWantToRead}, src.got)
+	assert.Equal(t, 2, res.Created)
+	assert.Equal(t, 1, res.Existing)
+	assert.Equal(t, 2, res.Failed)
+	require.Len(t, res.Items, 5)
+
+	dune, err := svc.GetBook(ctx, res.Items[0].BookID)
+	require.NoError(t, err)
+	assert.Equal(t, "Dune", dune.Title)
+	assert.Equal(t, []string{"Frank Herbert"}, dune.Authors)
+	assert.True(t, res.Items[1].Existing)
+	assert.Equal(t, neuromancer.ID, res.Items[1].BookID)
+	assert.NoError(t, res.Items[2].Err)
+	assert.ErrorIs(t, res.Items[3].Err, model.ErrValidation)
+	assert.ErrorIs(t, res.Items[4].Err, model.ErrConflict, "a trashed book is not silently reused")
+}
+
+func TestImportReadingList_Errors(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	_, err := svc.ImportReadingList(ctx, model.ReadingListRef{Username: "alice"})
+	assert.ErrorIs(t, err, model.ErrNotFound, "no source configured")
+
+	src := &fakeReadingLists{}
+	svc.ReadingLists = src
+	for _, ref := range []model.ReadingListRef{
+		{},
+		{Username: "alice", ListURL: "https://openlibrary.org/people/alice/lists/OL1L"},
+		{ListURL: "https://openlibrary.org/people/alice/lists/OL1L", Shelf: model.ShelfAlreadyRead},
+		{Username: "alice", Shelf: "favourites"},
+	} {
+		_, err := svc.ImportReadingList(ctx, ref)
+		assert.ErrorIs(t, err, model.ErrValidation, "%+v", ref)
+	}
+
+	src.err = errors.New("connection reset")
+	_, err = svc.ImportReadingList(ctx, model.ReadingListRef{Username: "alice"})
+	assert.ErrorIs(t, err, model.ErrUpstream)
+
+	src.err = nil
+	src.items = make([]model.ReadingListItem, maxBatchItems+1)
+	_, err = svc.ImportReadingList(ctx, model.ReadingListRef{Username: "alice"})
+	assert.ErrorIs(t, err, model.ErrValidation)
+	page, err := svc.ListBooks(ctx, model.ListQuery{})
+	require.NoError(t, err)
+	assert.Zero(t, page.Total, "nothing is created from a refused list")
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxRecentViewsLimit is the most recently viewed books listed at once.
+const maxRecentViewsLimit = 100
+
+// RecentViewRepository keeps, per user, the books they looked at last.
+type RecentViewRepository interface {
+	// Record notes that user viewed the book, moving it to the front.
+	Record(ctx context.Context, user string, v model.RecentView) error
+	// List returns up to limit of user's views, latest first.
+	List(ctx context.Context, user string, limit int) ([]model.RecentView, error)
+	// Clear forgets user's views.
+	Clear(ctx context.Context, user string) error
+}
+
+// ViewBook returns a book, as GetBook does, and records the view for the
+// caller's recently viewed books. Only authenticated callers are tracked:
+// anonymous readers would share one history. The book is returned even
+// when the view cannot be recorded.
+func (s *Service) ViewBook(ctx context.Context, id string) (model.Book, error) {
+	b, err := s.GetBook(ctx, id)
+	if err != nil {
+		return model.Book{}, err
+	}
+	if user, ok := viewer(ctx); ok && s.RecentViews != nil {
+		_ = s.RecentViews.Record(ctx, user, model.RecentView{BookID: b.ID, ViewedAt: time.Now()})
+	}
+	return b, nil
+}
+
+// RecentlyViewed returns up to limit (1..100) of the books the caller
+// viewed last, latest first. Books deleted since are left out.
+func (s *Service) RecentlyViewed(ctx context.Context, limit int) ([]model.ViewedBook, error) {
+	if limit < 1 || limit > maxRecentViewsLimit {
+		return nil, &model.FieldError{Field: "limit", Reason: fmt.Sprintf("must be between 1 and %d", maxRecentViewsLimit)}
+	}
+	if s.RecentViews == nil {
+		return nil, fmt.Errorf("%w: recently viewed books are not tracked", model.ErrNotFound)
+	}
+	user, ok := viewer(ctx)
+	if !ok {
+		return []model.ViewedBook{}, nil
+	}
+	views, err := s.RecentViews.List(ctx, user, maxRecentViewsLimit)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.ViewedBook, 0, min(limit, len(views)))
+	for _, v := range views {
+		if len(out) == limit {
+			break
+		}
+		b, err := s.GetBook(ctx, v.BookID)
+		if err != nil {
+			continue
+		}
+		out = append(out, model.ViewedBook{Book: b, ViewedAt: v.ViewedAt})
+	}
+	return out, nil
+}
+
+// ClearRecentViews forgets the books the caller viewed.
+func (s *Service) ClearRecentViews(ctx context.Context) error {
+	if s.RecentViews == nil {
+		return fmt.Errorf("%w: recently viewed books are not tracked", model.ErrNotFound)
+	}
+	user, ok := viewer(ctx)
+	if !ok {
+		return nil
+	}
+	return s.RecentViews.Clear(ctx, user)
+}
+
+// viewer is the caller whose views are tracked, if any.
+func viewer(ctx context.Context) (string, bool) {
+	switch user := model.ActorFromContext(ctx); user {
+	case model.ActorSystem, model.ActorAnonymous:
+		return "", false
+	default:
+		return user, true
+	}
+}
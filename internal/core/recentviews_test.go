@@ -0,0 +1,57 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecentlyViewed(t *testing.T) {
+	ann := model.WithActor(context.Background(), "api-key:ann")
+	anon := model.WithActor(context.Background(), model.ActorAnonymous)
+	svc := NewService(adapter.NewBookRepo(), nil)
+	_, err := svc.RecentlyViewed(ann, 10)
+	assert.ErrorIs(t, err, model.ErrNotFound, "not tracked")
+	svc.RecentViews = adapter.NewRecentViewRepo(10)
+
+	dune, err := svc.CreateBook(ann, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	emma, err := svc.CreateBook(ann, model.CreateBookInput{Title: util.GetPtr("Emma")})
+	require.NoError(t, err)
+	for _, id := range []string{dune.ID, emma.ID, dune.ID} {
+		_, err = svc.ViewBook(ann, id)
+		require.NoError(t, err)
+	}
+	_, err = svc.ViewBook(anon, emma.ID)
+	require.NoError(t, err)
+	_, err = svc.GetBook(ann, emma.ID) // internal reads are not views
+	require.NoError(t, err)
+
+	views, err := svc.RecentlyViewed(ann, 10)
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+	assert.Equal(t, []string{"Dune", "Emma"}, []string{views[0].Book.Title, views[1].Book.Title})
+	views, err = svc.RecentlyViewed(anon, 10)
+	require.NoError(t, err)
+	assert.Empty(t, views, "anonymous callers are not tracked")
+
+	require.NoError(t, svc.DeleteBook(ann, dune.ID))
+	views, err = svc.RecentlyViewed(ann, 10)
+	require.NoError(t, err)
+	require.Len(t, views, 1, "deleted books are left out")
+	assert.Equal(t, emma.ID, views[0].Book.ID)
+
+	_, err = svc.RecentlyViewed(ann, 0)
+	assert.ErrorIs(t, err, model.ErrValidation)
+	require.NoError(t, svc.ClearRecentViews(ann))
+	views, err = svc.RecentlyViewed(ann, 10)
+	require.NoError(t, err)
+	assert.Empty(t, views)
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// CheckForthcoming looks up every forthcoming book with an ISBN again. A
+// book counts as released when a source lists it and its release date, from
+// the lookup or stored, is not after now; a listing without any date counts
+// too. Released books get their missing fields filled, lose the forthcoming
+// flag and are announced with a book.released event. Books still
+// forthcoming only pick up a release date the source reports.
+//
+// Failed lookups are expected for unpublished books and are skipped. The
+// number of released books is returned.
+func (s *Service) CheckForthcoming(ctx context.Context, now time.Time) (int, error) {
+	var ids []string
+	err := s.WalkBooks(ctx, model.ListQuery{}, func(b model.Book) error {
+		if b.Forthcoming && b.ISBN != nil {
+			ids = append(ids, b.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	var errs []error
+	for _, id := range ids {
+		ok, err := s.checkRelease(ctx, id, now)
+		if ctx.Err() != nil {
+			return released, ctx.Err()
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if ok {
+			released++
+		}
+	}
+	return released, errors.Join(errs...)
+}
+
+func (s *Service) checkRelease(ctx context.Context, id string, now time.Time) (bool, error) {
+	b, err := s.getBook(ctx, id)
+	if err != nil || !b.Forthcoming || b.ISBN == nil {
+		return false, nil // deleted or edited meanwhile
+	}
+	res, err := s.Enrich.FetchByISBN(ctx, *b.ISBN)
+	if err != nil {
+		return false, nil
+	}
+	if b, err = s.getBook(ctx, id); err != nil || !b.Forthcoming {
+		return false, nil
+	}
+	before := b
+
+	date := releaseDay(res.ReleaseDate)
+	if date == nil {
+		date = b.ReleaseDate
+	}
+	if date != nil && date.After(now) {
+		if res.ReleaseDate == nil || (b.ReleaseDate != nil && b.ReleaseDate.Equal(*date)) {
+			return false, nil
+		}
+		b.ReleaseDate = date
+		_, err := s.updateBook(ctx, model.AuditUpdate, before, b)
+		return false, err
+	}
+
+	b.Enrichment.Attempted = true
+	b.Enrichment.LookedUpISBN = *b.ISBN
+	if err := s.applyEnrichment(ctx, &b, res, false); err != nil {
+		return false, err
+	}
+	b.Forthcoming = false
+	if b.ReleaseDate == nil {
+		b.ReleaseDate = date
+	}
+	if b, err = s.updateBook(ctx, model.AuditEnrich, before, b); err != nil {
+		return false, err
+	}
+	ev := model.Event{Type: model.EventBookReleased, BookID: b.ID, Book: b, At: time.Now()}
+	if err := s.notify(ctx, ev); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// WatchReleases runs CheckForthcoming every interval until ctx ends.
+func (s *Service) WatchReleases(ctx context.Context, interval time.Duration, log *slog.Logger) {
+	every(ctx, interval, func(now time.Time) {
+		n, err := s.CheckForthcoming(ctx, now)
+		if err != nil && ctx.Err() == nil {
+			log.With("error", err).Warn("release check failed", "released", n)
+			return
+		}
+		if n > 0 {
+			log.Info("forthcoming books released", "books", n)
+		}
+	})
+}
+
+// every calls fn on each tick of interval until ctx ends.
+func every(ctx context.Context, interval time.Duration, fn func(now time.Time)) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			fn(now)
+		}
+	}
+}
+
+// releaseDay drops the time of day; release dates are calendar days in UTC.
+func releaseDay(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	y, m, d := t.UTC().Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return &day
+}
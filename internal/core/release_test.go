@@ -0,0 +1,83 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dateEnrich answers every lookup with a release date.
+type dateEnrich struct{ date time.Time }
+
+func (f dateEnrich) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	return model.EnrichedBook{Source: "openlibrary", PageCount: util.GetPtr(300), ReleaseDate: &f.date}, nil
+}
+
+type recordNotifier struct{ events []model.Event }
+
+func (n *recordNotifier) Notify(ctx context.Context, ev model.Event) error {
+	n.events = append(n.events, ev)
+	return nil
+}
+
+func TestCheckForthcoming(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	svc := NewService(adapter.NewBookRepo(), dateEnrich{date: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)})
+	notes := &recordNotifier{}
+	svc.Notifier = notes
+
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Soon"), ISBN: util.GetPtr("9780134494166"), Forthcoming: true})
+	require.NoError(t, err)
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Out"), ISBN: util.GetPtr("9780123456786")})
+	require.NoError(t, err)
+
+	n, err := svc.CheckForthcoming(ctx, now)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	got, err := svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Forthcoming)
+	assert.Equal(t, "2026-04-01", got.ReleaseDate.Format(time.DateOnly), "future date is stored")
+	assert.Nil(t, got.PageCount, "not merged before release")
+	assert.Empty(t, notes.events)
+
+	n, err = svc.CheckForthcoming(ctx, time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	got, err = svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	assert.False(t, got.Forthcoming)
+	assert.Equal(t, 300, *got.PageCount)
+	assert.Equal(t, model.EnrichmentOK, got.Enrichment.Status)
+	require.Len(t, notes.events, 1)
+	assert.Equal(t, model.EventBookReleased, notes.events[0].Type)
+	assert.Equal(t, b.ID, notes.events[0].BookID)
+
+	n, err = svc.CheckForthcoming(ctx, now.AddDate(1, 0, 0))
+	require.NoError(t, err)
+	assert.Zero(t, n, "released books are not checked again")
+}
+
+func TestCheckForthcomingLookupFails(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Unlisted"), ISBN: util.GetPtr("9780134494166"), Forthcoming: true})
+	require.NoError(t, err)
+
+	n, err := svc.CheckForthcoming(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	got, err := svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Forthcoming)
+	assert.Equal(t, b.UpdatedAt, got.UpdatedAt)
+}
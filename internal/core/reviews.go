@@ -0,0 +1,132 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewRepository keeps the reviews of books. Add fails with
+// model.ErrConflict when the reviewer has reviewed the book already; Get
+// and Delete fail with model.ErrNotFound for an unknown review.
+type ReviewRepository interface {
+	Add(ctx context.Context, r model.Review) (model.Review, error)
+	Get(ctx context.Context, id string) (model.Review, error)
+	Delete(ctx context.Context, id string) error
+	// List returns the reviews of a book, latest first.
+	List(ctx context.Context, bookID string) ([]model.Review, error)
+	// Ratings returns the ratings of the books with reviews, by book id.
+	Ratings(ctx context.Context) (map[string]model.Rating, error)
+}
+
+const (
+	maxReviewTextLen  = 5000
+	maxReviewPageSize = 100
+)
+
+// AddReview rates a book for the caller, who may review a book once.
+func (s *Service) AddReview(ctx context.Context, r model.Review) (model.Review, error) {
+	if s.Reviews == nil {
+		return model.Review{}, fmt.Errorf("%w: reviews are not configured", model.ErrNotFound)
+	}
+	r.Text = strings.TrimSpace(r.Text)
+	var v validator
+	v.check(r.Rating >= 1 && r.Rating <= 5, "rating", "must be between 1 and 5")
+	v.check(len(r.Text) <= maxReviewTextLen, "text", fmt.Sprintf("must be at most %d characters", maxReviewTextLen))
+	if err := v.err(); err != nil {
+		return model.Review{}, err
+	}
+	b, err := s.getBook(ctx, r.BookID)
+	if err != nil {
+		return model.Review{}, model.ErrNotFound
+	}
+	r.ID = uuid.NewString()
+	r.Tenant = b.Tenant
+	r.Reviewer = model.ActorFromContext(ctx)
+	r.CreatedAt = time.Now().UTC()
+	return s.Reviews.Add(ctx, r)
+}
+
+// GetReview returns a review of a book.
+func (s *Service) GetReview(ctx context.Context, bookID, id string) (model.Review, error) {
+	if s.Reviews == nil {
+		return model.Review{}, fmt.Errorf("%w: reviews are not configured", model.ErrNotFound)
+	}
+	r, err := s.Reviews.Get(ctx, id)
+	if err != nil {
+		return model.Review{}, err
+	}
+	if r.BookID != bookID {
+		return model.Review{}, fmt.Errorf("%w: review %s of book %s", model.ErrNotFound, id, bookID)
+	}
+	return r, nil
+}
+
+// DeleteReview removes a review of a book. Whether the caller may remove
+// another's review is up to the caller; see auth.AllowsAnyReview.
+func (s *Service) DeleteReview(ctx context.Context, bookID, id string) error {
+	if _, err := s.GetReview(ctx, bookID, id); err != nil {
+		return err
+	}
+	return s.Reviews.Delete(ctx, id)
+}
+
+// ListReviews returns a page of the reviews of a book, latest first.
+func (s *Service) ListReviews(ctx context.Context, bookID string, page, pageSize int) (model.Page[model.Review], error) {
+	if s.Reviews == nil {
+		return model.Page[model.Review]{}, fmt.Errorf("%w: reviews are not configured", model.ErrNotFound)
+	}
+	var v validator
+	v.check(page >= 1, "page", "must be at least 1")
+	v.check(pageSize >= 1 && pageSize <= maxReviewPageSize, "page_size", fmt.Sprintf("must be between 1 and %d", maxReviewPageSize))
+	if err := v.err(); err != nil {
+		return model.Page[model.Review]{}, err
+	}
+	if _, err := s.getBook(ctx, bookID); err != nil {
+		return model.Page[model.Review]{}, model.ErrNotFound
+	}
+	reviews, err := s.Reviews.List(ctx, bookID)
+	if err != nil {
+		return model.Page[model.Review]{}, err
+	}
+	out := model.Page[model.Review]{Page: page, PageSize: pageSize, Total: len(reviews)}
+	if start := (page - 1) * pageSize; start < len(reviews) {
+		out.Data = reviews[start:min(start+pageSize, len(reviews))]
+	}
+	return out, nil
+}
+
+// withRatings fills in the ratings of books when reviews are kept; books
+// without reviews get a zero rating.
+func (s *Service) withRatings(ctx context.Context, books []model.Book) error {
+	if s.Reviews == nil || len(books) == 0 {
+		return nil
+	}
+	ratings, err := s.Reviews.Ratings(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range books {
+		r := ratings[books[i].ID]
+		books[i].Rating = &r
+	}
+	return nil
+}
+
+// ratings returns the ratings of the reviewed books, or nil when reviews
+// are not kept.
+func (s *Service) ratings(ctx context.Context) (map[string]model.Rating, error) {
+	if s.Reviews == nil {
+		return nil, nil
+	}
+	return s.Reviews.Ratings(ctx)
+}
+
+func sortsByRating(keys []model.SortKey) bool {
+	return slices.ContainsFunc(keys, func(k model.SortKey) bool { return k.Field == "rating" })
+}
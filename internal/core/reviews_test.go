@@ -0,0 +1,76 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviews(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	alice := model.WithActor(context.Background(), "jwt:alice")
+	bob := model.WithActor(context.Background(), "jwt:bob")
+	dune, err := svc.CreateBook(alice, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	emma, err := svc.CreateBook(alice, model.CreateBookInput{Title: util.GetPtr("Emma")})
+	require.NoError(t, err)
+	_, err = svc.AddReview(alice, model.Review{BookID: dune.ID, Rating: 5})
+	assert.ErrorIs(t, err, model.ErrNotFound, "reviews are off")
+
+	svc.Reviews = adapter.NewReviewRepo()
+	_, err = svc.AddReview(alice, model.Review{BookID: dune.ID, Rating: 6, Text: string(make([]byte, 5001))})
+	var ve model.ValidationErrors
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, ve, 2)
+	_, err = svc.AddReview(alice, model.Review{BookID: "missing", Rating: 3})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	r1, err := svc.AddReview(alice, model.Review{BookID: dune.ID, Rating: 5, Text: " Spice! "})
+	require.NoError(t, err)
+	assert.Equal(t, "jwt:alice", r1.Reviewer)
+	assert.Equal(t, "Spice!", r1.Text)
+	_, err = svc.AddReview(alice, model.Review{BookID: dune.ID, Rating: 1})
+	assert.ErrorIs(t, err, model.ErrConflict, "one review per reviewer")
+	_, err = svc.AddReview(bob, model.Review{BookID: dune.ID, Rating: 4})
+	require.NoError(t, err)
+	_, err = svc.AddReview(bob, model.Review{BookID: emma.ID, Rating: 2})
+	require.NoError(t, err)
+
+	got, err := svc.GetBook(alice, dune.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &model.Rating{Average: 4.5, Count: 2}, got.Rating)
+
+	page, err := svc.ListReviews(alice, dune.ID, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+	require.Len(t, page.Data, 1)
+	_, err = svc.ListReviews(alice, dune.ID, 0, 500)
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, ve, 2)
+
+	third, err := svc.CreateBook(alice, model.CreateBookInput{Title: util.GetPtr("Anathem")})
+	require.NoError(t, err)
+	books, err := svc.ListBooks(alice, model.ListQuery{Sort: []model.SortKey{{Field: "rating", Desc: true}}, Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, books.Data, 2)
+	assert.Equal(t, []string{dune.ID, emma.ID}, []string{books.Data[0].ID, books.Data[1].ID})
+	assert.Equal(t, 2.0, books.Data[1].Rating.Average)
+	books, err = svc.ListBooks(alice, model.ListQuery{Cursor: books.NextCursor, PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, books.Data, 1, "the cursor keeps the rating order")
+	assert.Equal(t, third.ID, books.Data[0].ID)
+	assert.Equal(t, &model.Rating{}, books.Data[0].Rating, "unrated")
+
+	assert.ErrorIs(t, svc.DeleteReview(alice, emma.ID, r1.ID), model.ErrNotFound, "a review of another book")
+	require.NoError(t, svc.DeleteReview(alice, dune.ID, r1.ID))
+	got, err = svc.GetBook(alice, dune.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &model.Rating{Average: 4, Count: 1}, got.Rating)
+}
@@ -0,0 +1,234 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Embedder turns texts into vectors, e.g. a local vectorizer or an
+// embeddings API. It returns one vector per text, in order.
+type Embedder interface {
+	// Model names the embedding model; vectors are only compared within one.
+	Model() string
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+const (
+	defaultSemanticLimit = 10
+	maxSemanticLimit     = 100
+	embedBatchSize       = 32
+)
+
+// embeddingText is the text a book is embedded by.
+func embeddingText(b model.Book) string {
+	parts := []string{b.Title}
+	if b.Subtitle != nil {
+		parts = append(parts, *b.Subtitle)
+	}
+	if b.Description != nil {
+		parts = append(parts, *b.Description)
+	}
+	parts = append(parts, b.Subjects...)
+	return strings.Join(parts, "\n")
+}
+
+func textHash(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// needsEmbedding tells whether b has no current vector from s.Embedder.
+func (s *Service) needsEmbedding(b model.Book) bool {
+	e := b.Embedding
+	return e == nil || e.Model != s.Embedder.Model() || e.TextHash != textHash(embeddingText(b))
+}
+
+// embed refreshes b's vector when its text changed. It is best effort: on
+// failure the stale vector is dropped and EmbedBooks fills it in later.
+func (s *Service) embed(ctx context.Context, b *model.Book) {
+	if s.Embedder == nil || !s.needsEmbedding(*b) {
+		return
+	}
+	text := embeddingText(*b)
+	vs, err := s.Embedder.Embed(ctx, []string{text})
+	if err != nil || len(vs) != 1 {
+		b.Embedding = nil
+		s.vectors.remove(b.ID)
+		return
+	}
+	b.Embedding = newEmbedding(s.Embedder.Model(), vs[0], text)
+	s.vectors.put(b.Embedding.Model, b.Tenant, b.ID, b.Embedding.Vector)
+}
+
+func newEmbedding(name string, v []float32, text string) *model.Embedding {
+	return &model.Embedding{Model: name, Vector: normalize(v), TextHash: textHash(text)}
+}
+
+// EmbedBooks computes the vectors books are missing, e.g. after the
+// embedder changed or a lookup failed, and returns how many it stored.
+// Books edited meanwhile are skipped; their edit embedded them already.
+// It also loads the stored vectors into the search index, so it runs on
+// startup.
+func (s *Service) EmbedBooks(ctx context.Context) (int, error) {
+	if s.Embedder == nil {
+		return 0, nil
+	}
+	var stale []model.Book
+	err := s.WalkBooks(ctx, model.ListQuery{}, func(b model.Book) error {
+		if s.needsEmbedding(b) {
+			stale = append(stale, b)
+		} else {
+			s.vectors.put(b.Embedding.Model, b.Tenant, b.ID, b.Embedding.Vector)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for len(stale) > 0 {
+		batch := stale[:min(embedBatchSize, len(stale))]
+		stale = stale[len(batch):]
+		texts := make([]string, len(batch))
+		for i, b := range batch {
+			texts[i] = embeddingText(b)
+		}
+		vs, err := s.Embedder.Embed(ctx, texts)
+		if err != nil {
+			return n, fmt.Errorf("%w: %v", model.ErrUpstream, err)
+		}
+		if len(vs) != len(batch) {
+			return n, fmt.Errorf("%w: embedder returned %d vectors for %d texts", model.ErrUpstream, len(vs), len(batch))
+		}
+		for i, b := range batch {
+			b.Embedding = newEmbedding(s.Embedder.Model(), vs[i], texts[i])
+			if _, err := s.Repo.Update(ctx, b); err != nil {
+				if errors.Is(err, model.ErrConflict) || errors.Is(err, model.ErrNotFound) {
+					continue
+				}
+				return n, err
+			}
+			s.vectors.put(b.Embedding.Model, b.Tenant, b.ID, b.Embedding.Vector)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// SemanticSearch ranks books by the cosine similarity of their text to q,
+// best first. Books without a vector in the index are left out until
+// EmbedBooks has run.
+func (s *Service) SemanticSearch(ctx context.Context, q string, limit int) ([]model.ScoredBook, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, &model.FieldError{Field: "q", Reason: "must not be empty"}
+	}
+	if limit == 0 {
+		limit = defaultSemanticLimit
+	}
+	if limit < 1 || limit > maxSemanticLimit {
+		return nil, &model.FieldError{Field: "limit", Reason: fmt.Sprintf("must be between 1 and %d", maxSemanticLimit)}
+	}
+	if s.Embedder == nil {
+		return nil, fmt.Errorf("%w: semantic search is not configured", model.ErrNotFound)
+	}
+	vs, err := s.Embedder.Embed(ctx, []string{q})
+	if err == nil && len(vs) != 1 {
+		err = fmt.Errorf("got %d vectors for 1 text", len(vs))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: embed query: %v", model.ErrUpstream, err)
+	}
+	return s.nearestBooks(ctx, normalize(vs[0]), limit, "")
+}
+
+// SimilarBooks returns the books whose text is closest to the book's,
+// best first. A book without a current vector is embedded first.
+func (s *Service) SimilarBooks(ctx context.Context, id string, limit int) ([]model.ScoredBook, error) {
+	if limit == 0 {
+		limit = defaultSemanticLimit
+	}
+	if limit < 1 || limit > maxSemanticLimit {
+		return nil, &model.FieldError{Field: "limit", Reason: fmt.Sprintf("must be between 1 and %d", maxSemanticLimit)}
+	}
+	b, err := s.getBook(ctx, id)
+	if err != nil {
+		return nil, model.ErrNotFound
+	}
+	if s.Embedder == nil {
+		return nil, fmt.Errorf("%w: semantic search is not configured", model.ErrNotFound)
+	}
+	s.embed(ctx, &b)
+	if b.Embedding == nil {
+		return nil, fmt.Errorf("%w: book could not be embedded", model.ErrUpstream)
+	}
+	return s.nearestBooks(ctx, b.Embedding.Vector, limit, b.ID)
+}
+
+// nearestBooks looks v's neighbours up in the index and re-reads them, so
+// books the caller cannot read are dropped and edited ones scored by their
+// current vector. The index holds the books of every owner of the tenant, so
+// it is asked for more neighbours until limit books are found or it has
+// none left.
+func (s *Service) nearestBooks(ctx context.Context, v []float32, limit int, exclude string) ([]model.ScoredBook, error) {
+	name := s.Embedder.Model()
+	hits := make([]model.ScoredBook, 0, limit)
+	seen := 0
+	for k := 2 * limit; len(hits) < limit; k *= 2 {
+		ids := s.vectors.nearest(name, model.TenantFromContext(ctx), v, k, exclude)
+		for _, id := range ids[min(seen, len(ids)):] {
+			b, err := s.getBook(ctx, id)
+			if errors.Is(err, model.ErrNotFound) {
+				continue // deleted since it was indexed, or not the caller's
+			}
+			if err != nil {
+				return nil, err
+			}
+			if e := b.Embedding; e != nil && e.Model == name && len(e.Vector) == len(v) {
+				hits = append(hits, model.ScoredBook{Book: b, Score: dot(v, e.Vector)})
+			}
+		}
+		if len(ids) < k {
+			break
+		}
+		seen = len(ids)
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// normalize scales v to unit length, so that cosine similarity is a dot
+// product.
+func normalize(v []float32) []float32 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	out := make([]float32, len(v))
+	if sum == 0 {
+		return out
+	}
+	n := math.Sqrt(sum)
+	for i, x := range v {
+		out[i] = float32(float64(x) / n)
+	}
+	return out
+}
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
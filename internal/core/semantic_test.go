@@ -0,0 +1,134 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingEmbedder fails every call, like an embeddings API that is down.
+type failingEmbedder struct{}
+
+func (failingEmbedder) Model() string { return "down" }
+
+func (failingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestSemanticSearch(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	svc.Embedder = adapter.NewHashingEmbedder(0)
+
+	for _, title := range []string{"Dune", "The Desert Garden", "Clean Architecture"} {
+		_, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr(title)})
+		require.NoError(t, err)
+	}
+	hits, err := svc.SemanticSearch(ctx, "software architecture", 2)
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+	assert.Equal(t, "Clean Architecture", hits[0].Book.Title)
+	assert.Greater(t, hits[0].Score, hits[1].Score)
+
+	_, err = svc.SemanticSearch(ctx, " ", 0)
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.SemanticSearch(ctx, "desert", 101)
+	assert.ErrorIs(t, err, model.ErrValidation)
+
+	svc.Embedder = nil
+	_, err = svc.SemanticSearch(ctx, "desert", 0)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestEmbedBooks_Backfill(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	svc.Embedder = failingEmbedder{}
+
+	// a failed embedding does not fail the write
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	assert.Nil(t, b.Embedding)
+	_, err = svc.EmbedBooks(ctx)
+	assert.ErrorIs(t, err, model.ErrUpstream)
+
+	svc.Embedder = adapter.NewHashingEmbedder(64)
+	n, err := svc.EmbedBooks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	b, err = svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	require.NotNil(t, b.Embedding)
+	assert.Equal(t, "hashing-64", b.Embedding.Model)
+
+	n, err = svc.EmbedBooks(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n, "unchanged books keep their vectors")
+
+	hits, err := svc.SemanticSearch(ctx, "dune", 0)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.InDelta(t, 1.0, hits[0].Score, 1e-6)
+}
+
+func TestSimilarBooks(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	svc.Embedder = adapter.NewHashingEmbedder(0)
+
+	var ids []string
+	for _, title := range []string{"Clean Architecture", "Clean Code", "Dune", "Dune Messiah"} {
+		b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr(title)})
+		require.NoError(t, err)
+		ids = append(ids, b.ID)
+	}
+	hits, err := svc.SimilarBooks(ctx, ids[2], 1)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "Dune Messiah", hits[0].Book.Title)
+
+	// deleted books drop out
+	require.NoError(t, svc.DeleteBook(ctx, ids[3]))
+	hits, err = svc.SimilarBooks(ctx, ids[2], 0)
+	require.NoError(t, err)
+	for _, h := range hits {
+		assert.NotEqual(t, ids[3], h.Book.ID)
+		assert.NotEqual(t, ids[2], h.Book.ID, "not similar to itself")
+	}
+	assert.Len(t, hits, 2)
+
+	_, err = svc.SimilarBooks(ctx, "missing", 0)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestSemanticSearch_FillsLimitWithVisibleBooks(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	svc.Embedder = adapter.NewHashingEmbedder(0)
+	svc.PerUser = true
+	alice := model.WithUser(context.Background(), model.User{ID: "alice"})
+	bob := model.WithUser(context.Background(), model.User{ID: "bob"})
+
+	// bob's books match the query better than any of alice's
+	for i := 0; i < 10; i++ {
+		_, err := svc.CreateBook(bob, model.CreateBookInput{Title: util.GetPtr("Dune desert planet")})
+		require.NoError(t, err)
+	}
+	for _, title := range []string{"Desert Solitaire", "The Desert Garden", "Clean Code"} {
+		_, err := svc.CreateBook(alice, model.CreateBookInput{Title: util.GetPtr(title)})
+		require.NoError(t, err)
+	}
+	hits, err := svc.SemanticSearch(alice, "dune desert planet", 3)
+	require.NoError(t, err)
+	require.Len(t, hits, 3)
+	for _, h := range hits {
+		assert.Equal(t, "alice", h.Book.Owner)
+	}
+}
@@ -3,49 +3,238 @@ package core
 import (
 	"book-manager/internal/core/model"
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// BookRepository stores the books. Every method but Create works on the
+// books of the tenant of its context only, see model.WithTenant; Create
+// stores the book for its own Tenant.
 type BookRepository interface {
+	// Create stores a new book at version 1.
 	Create(ctx context.Context, b model.Book) (model.Book, error)
+	// CreateCapped stores a new book like Create unless the tenant of b
+	// already keeps limit books, those in the trash included; then it
+	// fails with model.ErrQuota. The count and the insert are atomic.
+	CreateCapped(ctx context.Context, b model.Book, limit int) (model.Book, error)
+	// Update stores b and increments its version, atomically checking that
+	// the stored book is still at b.Version; otherwise it fails with an error
+	// matching model.ErrConflict and nothing is written.
+	Update(ctx context.Context, b model.Book) (model.Book, error)
+	// GetByID fails with an error matching model.ErrNotFound when there is
+	// no book with id.
 	GetByID(ctx context.Context, id string) (model.Book, error)
-	GetByISBN(ctx context.Context, isbn string) (model.Book, error)
+	// GetByISBN finds the book of owner with isbn; ISBNs are unique per
+	// owner within a tenant among the books outside the trash, and books
+	// not kept per user have the owner "". When no such book has isbn it
+	// returns the book trashed last that has it.
+	GetByISBN(ctx context.Context, owner, isbn string) (model.Book, error)
+	// List returns a page of the books matching q. It does not count them:
+	// the page's Total is left to Count.
 	List(ctx context.Context, q model.ListQuery) (model.Page[model.Book], error)
+	// Count returns how many books match q's filters, or those of q.Cursor,
+	// or how many q.SnapshotID pinned; paging and sort are ignored, so a
+	// backend can answer from an index without reading the books.
+	Count(ctx context.Context, q model.ListQuery) (int, error)
 	Delete(ctx context.Context, id string) error
+	// RenameAuthor rewrites rn.From to rn.To in every book atomically and
+	// records the rename; rn.BooksUpdated and rn.Changes are filled in.
+	RenameAuthor(ctx context.Context, rn model.AuthorRename) (model.AuthorRename, error)
+	ListAuthorRenames(ctx context.Context) ([]model.AuthorRename, error)
+	// RenameTags replaces the tags in rn.From with rn.To in every book
+	// atomically; rn.BooksUpdated and rn.Changes are filled in.
+	RenameTags(ctx context.Context, rn model.TagRename) (model.TagRename, error)
 }
 
 type EnrichmentClient interface {
 	FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error)
 }
 
+// CreateHook rewrites the input of a book being created, for rules of the
+// organization such as tags by publisher series or title conventions. An
+// error fails the create.
+type CreateHook interface {
+	Transform(ctx context.Context, in model.CreateBookInput) (model.CreateBookInput, error)
+}
+
+// Notifier delivers catalog events, e.g. a forthcoming book's release.
+type Notifier interface {
+	Notify(ctx context.Context, ev model.Event) error
+}
+
 type Service struct {
-	Repo   BookRepository
-	Enrich EnrichmentClient
+	Repo     BookRepository
+	Enrich   EnrichmentClient
+	Rules    AutoTagRuleRepository // optional; nil disables auto-tagging
+	Authors  AuthorRepository      // optional; nil leaves books unlinked
+	Queue    *EnrichmentQueue      // optional; nil enriches while creating
+	Notifier Notifier              // optional; nil drops events
+	Prices   PriceRepository       // optional; nil keeps no price history
+	Pricing  PriceProvider         // optional; nil disables price watching
+	Library  AvailabilityProvider  // optional; nil disables availability lookups
+	Audit    AuditRepository       // optional; nil keeps no audit log
+	Hook     CreateHook            // optional; nil creates books as given
+	Log      *slog.Logger          // optional; nil logs to slog.Default
+
+	// Outbox, when set, keeps a domain event for every recorded change of
+	// a book, which PublishEvents sends to Publisher.
+	Outbox    Outbox
+	Publisher EventPublisher
+
+	// Idempotency, when set, remembers the Idempotency-Keys CreateBookOnce
+	// replays retried requests by.
+	Idempotency IdempotencyRepository
+
+	// Shelves, when set with ShelfURLs, reads the shelf feeds SyncShelves
+	// imports; ShelfSyncs, when set, keeps the sync reports.
+	Shelves    ShelfFeed
+	ShelfURLs  []string
+	ShelfSyncs ShelfSyncRepository
+
+	// ReadingLists, when set, reads the reading lists ImportReadingList
+	// creates books from.
+	ReadingLists ReadingListSource
+
+	// External, when set, is the catalog SearchExternal searches and
+	// ImportExternal creates books from.
+	External ExternalCatalog
+
+	// RecentViews, when set, keeps the books each caller viewed last, for
+	// RecentlyViewed.
+	RecentViews RecentViewRepository
+	// Bookshelves, when set, keeps each caller's personal shelves.
+	Bookshelves BookshelfRepository
+	// Reviews, when set, keeps the star ratings and reviews of books, which
+	// then show their average rating and can be sorted by it.
+	Reviews ReviewRepository
+	// Progress, when set, keeps each caller's reading progress updates.
+	Progress ProgressRepository
+
+	// Breakers are the circuit breakers around enrichment providers, whose
+	// state CircuitBreakers reports.
+	Breakers []CircuitBreaker
+
+	// Branches, when set, registers the branches books keep copies at;
+	// nil disables the inventory.
+	Branches BranchRepository
+
+	// Loans, when set, records the books lent out; nil disables lending.
+	// LoanPeriod is how long a loan runs unless a due date is given,
+	// defaultLoanPeriod when zero.
+	Loans      LoanRepository
+	LoanPeriod time.Duration
+	// Holds, when set with Loans, queues borrowers for lent-out books;
+	// with HoldAutoCheckout a returned copy is lent to the next holder
+	// instead of kept for them.
+	Holds            HoldRepository
+	HoldAutoCheckout bool
+	// Escalations, when set with Loans, keeps the policies
+	// RunEscalations applies to overdue loans.
+	Escalations EscalationRepository
+	// Fines, when set with Loans and Fees, charges late returns to the
+	// borrowers' ledgers kept by Fees.
+	Fines *model.FinePolicy
+	Fees  FeeRepository
+	// Borrowers, when set, is the borrower directory: loans, holds and
+	// fees then name registered borrowers by ID instead of free text.
+	Borrowers BorrowerRepository
+	// Stocktakes, when set, keeps the inventory checks of the copies on
+	// the shelves.
+	Stocktakes StocktakeRepository
+	// Kiosks, when set, remembers the queued checkouts kiosks synced, so
+	// that syncing them again lends nothing twice.
+	Kiosks KioskRepository
+
+	// PerUser keeps books per user: callers find, list and change only the
+	// books they created, and ISBNs are unique per user; admins see every
+	// book. See model.User.
+	PerUser bool
+
+	// TrashedISBNs is what giving a book the ISBN of a book in the trash
+	// does; the zero value is TrashedISBNConflict.
+	TrashedISBNs TrashedISBNPolicy
+
+	// TenantQuotas caps how many books each tenant keeps, those in the
+	// trash included; tenants without an entry are not capped. See
+	// model.WithTenant.
+	TenantQuotas map[string]int
+
+	// Exclusions, when set, keeps the books confirmed not to be
+	// duplicates, which DuplicateBooks then stops pairing.
+	Exclusions DuplicateExclusionRepository
+
+	// DeadLetters, when set, keeps the events whose delivery to Notifier
+	// kept failing, for replay.
+	DeadLetters DeadLetterRepository
+
+	// Translator, when set with a TranslateTo language, translates the
+	// description and subjects of enriched books.
+	Translator  Translator
+	TranslateTo string
+
+	Embedder  Embedder          // optional; nil disables semantic search
+	Extractor MetadataExtractor // optional; nil parses free text with RuleExtractor only
+	Covers    BlobStore         // optional; nil disables cover uploads
+
+	// CoverCache, when set with Covers, downloads the covers enrichment
+	// finds and serves them locally instead of linking to the source.
+	CoverCache CoverFetcher
+	// Prefetch, when set, caches the remote covers of stored books on
+	// request and after every import.
+	Prefetch *CoverPrefetcher
+
+	// Imports, when set, keeps the CSV imports StartBookImport runs in
+	// the background.
+	Imports ImportRepository
+
+	vectors    vectorIndex
+	events     eventBus
+	imports    importCounter // running bulk imports
+	importRuns importRuns    // of StartBookImport
+	shelfSync  sync.Mutex    // serializes SyncShelves
 }
 
 func NewService(repo BookRepository, enrich EnrichmentClient) *Service {
 	return &Service{Repo: repo, Enrich: enrich}
 }
 
-func (s *Service) CreateBook(ctx context.Context, in model.CreateBookInput) (model.Book, error) {
-	// basic validation
-	if !in.Enrich || in.ISBN == nil {
-		if in.Title == nil || *in.Title == "" {
-			return model.Book{}, model.ErrValidation
+func (s *Service) CreateBook(ctx context.Context, in model.CreateBookInput) (_ model.Book, err error) {
+	ctx, span := tracer.Start(ctx, "Service.CreateBook")
+	defer func() { endSpan(span, err) }()
+	if s.Hook != nil {
+		// before validation, which the hook's output passes like any input
+		if in, err = s.Hook.Transform(ctx, in); err != nil {
+			return model.Book{}, err
 		}
 	}
-	if in.PageCount != nil && *in.PageCount < 1 {
-		return model.Book{}, model.ErrValidation
+	err = validateBook(bookFields{
+		Title:         in.Title,
+		TitleRequired: !in.Enrich || in.ISBN == nil,
+		ISBN:          &in.ISBN,
+		PageCount:     in.PageCount,
+		PublishedYear: in.PublishedYear,
+		PriceTarget:   in.PriceTarget,
+		Tags:          &in.Tags,
+	})
+	if err != nil {
+		return model.Book{}, err
 	}
-	if in.PublishedYear != nil {
-		y := *in.PublishedYear
-		if y < 1450 || y > 3000 {
-			return model.Book{}, model.ErrValidation
-		}
+	limit, capped, err := s.checkQuota(ctx)
+	if err != nil {
+		return model.Book{}, err
 	}
 
+	suggestions, err := s.suggestCorrections(ctx, &in)
+	if err != nil {
+		return model.Book{}, err
+	}
+
+	now := time.Now().UTC()
 	b := model.Book{
 		ID:            uuid.NewString(),
 		ISBN:          in.ISBN,
@@ -56,15 +245,25 @@ func (s *Service) CreateBook(ctx context.Context, in model.CreateBookInput) (mod
 		CoverURL:      in.CoverURL,
 		Tags:          in.Tags,
 		Authors:       in.Authors,
+		Forthcoming:   in.Forthcoming,
+		ReleaseDate:   releaseDay(in.ReleaseDate),
+		PriceTarget:   in.PriceTarget,
 		Enrichment:    model.EnrichmentMeta{Attempted: false, Status: model.EnrichmentNotRequested},
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		Owner:         s.owner(ctx),
+		Tenant:        model.TenantFromContext(ctx),
+		CreatedAt:     now,
+		UpdatedAt:     now,
 	}
 
-	// optional enrichment
-	if in.Enrich && in.ISBN != nil && *in.ISBN != "" {
+	// optional enrichment; deferred to the queue unless it is required
+	var enriched model.EnrichedBook
+	async := s.Queue != nil && !in.RequireEnrichment
+	if in.Enrich && in.ISBN != nil && *in.ISBN != "" && async {
+		b.Enrichment.Attempted = true
+		b.Enrichment.LookedUpISBN = *in.ISBN
+		b.Enrichment.Status = model.EnrichmentPending
+	} else if in.Enrich && in.ISBN != nil && *in.ISBN != "" {
 		b.Enrichment.Attempted = true
-		b.Enrichment.Source = "openlibrary"
 		b.Enrichment.LookedUpISBN = *in.ISBN
 		res, err := s.Enrich.FetchByISBN(ctx, *in.ISBN)
 		if err != nil {
@@ -74,42 +273,260 @@ func (s *Service) CreateBook(ctx context.Context, in model.CreateBookInput) (mod
 			b.Enrichment.Status = model.EnrichmentPartial
 		} else {
 			merge(&b, res) // fill only missing fields; user wins
+			s.translate(ctx, &b)
+			b.Enrichment.Source = res.Source
 			b.Enrichment.Status = model.EnrichmentOK
+			enriched = res
 		}
 	}
 
+	if err := s.autoTag(ctx, &b); err != nil {
+		return model.Book{}, err
+	}
+
 	// duplicate ISBN protection via repo (GetByISBN) before create
 	if b.ISBN != nil && *b.ISBN != "" {
-		if _, err := s.Repo.GetByISBN(ctx, *b.ISBN); err == nil {
-			return model.Book{}, model.ErrConflict
+		trashed, err := s.checkISBN(ctx, b.Owner, *b.ISBN, "")
+		if err != nil {
+			return model.Book{}, err
+		}
+		if trashed != nil && s.TrashedISBNs == TrashedISBNRestore {
+			return s.restoreWith(ctx, trashed.ID, b)
 		}
 	}
 
-	created, err := s.Repo.Create(ctx, b)
+	if err := s.linkAuthors(ctx, &b); err != nil {
+		return model.Book{}, err
+	}
+	s.embed(ctx, &b)
+	s.cacheCover(ctx, &b, enriched)
+	var created model.Book
+	wctx := s.stage(ctx, model.AuditCreate)
+	if capped {
+		created, err = s.Repo.CreateCapped(wctx, b, limit)
+	} else {
+		created, err = s.Repo.Create(wctx, b)
+	}
 	if err != nil {
+		s.deleteCovers(ctx, b.ID)
 		// map repo errors if needed
 		return model.Book{}, err
 	}
+	s.audit(wctx, model.AuditCreate, nil, &created)
+	if created.Enrichment.Status == model.EnrichmentPending && !s.Queue.Enqueue(ctx, created.ID) {
+		// queue full or shutting down
+		if err := s.enrichStored(ctx, created.ID); err != nil {
+			return model.Book{}, err
+		}
+		if created, err = s.getBook(ctx, created.ID); err != nil {
+			return model.Book{}, err
+		}
+	}
+	s.events.publish(created.Tenant, model.Event{Type: model.EventBookCreated, BookID: created.ID, Book: created, At: time.Now()})
+	created.Suggestions = suggestions
 	return created, nil
 }
 
-func (s *Service) ListBooks(ctx context.Context, q model.ListQuery) (model.Page[model.Book], error) {
-	return s.Repo.List(ctx, q)
+// UpdateBook replaces the editable fields of an existing book.
+func (s *Service) UpdateBook(ctx context.Context, id string, in model.UpdateBookInput) (_ model.Book, err error) {
+	ctx, span := tracer.Start(ctx, "Service.UpdateBook", bookID(id))
+	defer func() { endSpan(span, err) }()
+	cur, err := s.getBook(ctx, id)
+	if err != nil {
+		return model.Book{}, model.ErrNotFound
+	}
+	return s.replaceBook(ctx, cur, in)
 }
 
-func (s *Service) GetBook(ctx context.Context, id string) (model.Book, error) {
-	b, err := s.Repo.GetByID(ctx, id)
+// PatchBook changes only the fields set in p.
+func (s *Service) PatchBook(ctx context.Context, id string, p model.BookPatch) (_ model.Book, err error) {
+	ctx, span := tracer.Start(ctx, "Service.PatchBook", bookID(id))
+	defer func() { endSpan(span, err) }()
+	cur, err := s.getBook(ctx, id)
 	if err != nil {
 		return model.Book{}, model.ErrNotFound
 	}
-	return b, nil
+	in := model.UpdateBookInput{
+		ISBN:          cur.ISBN,
+		Title:         &cur.Title,
+		Subtitle:      cur.Subtitle,
+		PublishedYear: cur.PublishedYear,
+		PageCount:     cur.PageCount,
+		CoverURL:      cur.CoverURL,
+		Tags:          cur.Tags,
+		Authors:       cur.Authors,
+		Forthcoming:   cur.Forthcoming,
+		ReleaseDate:   cur.ReleaseDate,
+		PriceTarget:   cur.PriceTarget,
+		Version:       p.Version,
+	}
+	if p.ISBN != nil {
+		in.ISBN = p.ISBN
+	}
+	if p.Title != nil {
+		in.Title = p.Title
+	}
+	if p.Subtitle != nil {
+		in.Subtitle = p.Subtitle
+	}
+	if p.PublishedYear != nil {
+		in.PublishedYear = p.PublishedYear
+	}
+	if p.PageCount != nil {
+		in.PageCount = p.PageCount
+	}
+	if p.CoverURL != nil {
+		in.CoverURL = p.CoverURL
+	}
+	if p.Tags != nil {
+		in.Tags = *p.Tags
+	}
+	if p.Authors != nil {
+		in.Authors = *p.Authors
+	}
+	if p.Forthcoming != nil {
+		in.Forthcoming = *p.Forthcoming
+	}
+	if p.ReleaseDate != nil {
+		in.ReleaseDate = p.ReleaseDate
+	}
+	if p.PriceTarget != nil {
+		in.PriceTarget = p.PriceTarget
+	}
+	return s.replaceBook(ctx, cur, in)
 }
 
-func (s *Service) DeleteBook(ctx context.Context, id string) error {
-	if err := s.Repo.Delete(ctx, id); err != nil {
-		return model.ErrNotFound
+// replaceBook validates in, applies it to cur and stores the result. Server
+// managed fields (id, enrichment, created_at) are kept.
+func (s *Service) replaceBook(ctx context.Context, cur model.Book, in model.UpdateBookInput) (model.Book, error) {
+	err := validateBook(bookFields{
+		Title:         in.Title,
+		TitleRequired: true,
+		ISBN:          &in.ISBN,
+		PageCount:     in.PageCount,
+		PublishedYear: in.PublishedYear,
+		PriceTarget:   in.PriceTarget,
+		Tags:          &in.Tags,
+		KeepTags:      cur.Tags,
+	})
+	if err != nil {
+		return model.Book{}, err
+	}
+	if in.Version != nil && *in.Version != cur.Version {
+		return model.Book{}, fmt.Errorf("%w: book is at version %d, not %d", model.ErrConflict, cur.Version, *in.Version)
+	}
+
+	// ISBN uniqueness is only re-checked when it changes
+	if in.ISBN != nil && (cur.ISBN == nil || *cur.ISBN != *in.ISBN) {
+		if _, err := s.checkISBN(ctx, cur.Owner, *in.ISBN, cur.ID); err != nil {
+			return model.Book{}, err
+		}
 	}
-	return nil
+
+	b := cur
+	b.ISBN = in.ISBN
+	b.Title = *in.Title
+	b.Subtitle = in.Subtitle
+	b.PublishedYear = in.PublishedYear
+	b.PageCount = in.PageCount
+	b.CoverURL = in.CoverURL
+	b.Tags = in.Tags
+	b.Authors = append([]string(nil), in.Authors...)
+	b.Forthcoming = in.Forthcoming
+	b.ReleaseDate = releaseDay(in.ReleaseDate)
+	b.PriceTarget = in.PriceTarget
+	if err := s.autoTag(ctx, &b); err != nil {
+		return model.Book{}, err
+	}
+	if err := s.linkAuthors(ctx, &b); err != nil {
+		return model.Book{}, err
+	}
+	s.embed(ctx, &b)
+	return s.updateBook(ctx, model.AuditUpdate, cur, b)
+}
+
+// ListBooks returns a page of the books matching q with their total,
+// unless q.SkipCount. The total is counted after the page is read, so
+// books written in between may make it disagree with the page.
+func (s *Service) ListBooks(ctx context.Context, q model.ListQuery) (_ model.Page[model.Book], err error) {
+	ctx, span := tracer.Start(ctx, "Service.ListBooks")
+	defer func() { endSpan(span, err) }()
+	q.Owner = s.ownerFilter(ctx)
+	if q.Available != nil || q.Cursor != "" {
+		// a cursor may carry the filter
+		lent, err := s.lentOut(ctx)
+		if err != nil {
+			return model.Page[model.Book]{}, err
+		}
+		q.LentOut = lent
+	}
+	if q.Cursor != "" || sortsByRating(q.Sort) {
+		ratings, err := s.ratings(ctx)
+		if err != nil {
+			return model.Page[model.Book]{}, err
+		}
+		q.Ratings = ratings
+	}
+	page, err := s.Repo.List(ctx, q)
+	if err == nil {
+		page.Total = -1
+		if !q.SkipCount {
+			cq := q
+			if page.SnapshotID != "" {
+				// count what the first page pinned
+				cq.Snapshot, cq.SnapshotID = false, page.SnapshotID
+			}
+			page.Total, err = s.Repo.Count(ctx, cq)
+		}
+	}
+	if err != nil && q.SnapshotID != "" && !errors.Is(err, model.ErrValidation) {
+		// unknown or expired snapshot
+		return model.Page[model.Book]{}, model.ErrNotFound
+	}
+	if err != nil {
+		return model.Page[model.Book]{}, err
+	}
+	if err := s.withLending(ctx, page.Data); err != nil {
+		return model.Page[model.Book]{}, err
+	}
+	if err := s.withRatings(ctx, page.Data); err != nil {
+		return model.Page[model.Book]{}, err
+	}
+	return page, nil
+}
+
+func (s *Service) GetBook(ctx context.Context, id string) (_ model.Book, err error) {
+	ctx, span := tracer.Start(ctx, "Service.GetBook", bookID(id))
+	defer func() { endSpan(span, err) }()
+	b, err := s.getBook(ctx, id)
+	if err != nil {
+		return model.Book{}, model.ErrNotFound
+	}
+	books := []model.Book{b}
+	if err := s.withLending(ctx, books); err != nil {
+		return model.Book{}, err
+	}
+	if err := s.withRatings(ctx, books); err != nil {
+		return model.Book{}, err
+	}
+	return books[0], nil
+}
+
+// getBook reads a book that is not in the trash; trashed books are not
+// found, as for every caller but restore and purge. Neither are other
+// users' books; see PerUser.
+func (s *Service) getBook(ctx context.Context, id string) (model.Book, error) {
+	b, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		return model.Book{}, err
+	}
+	if !s.visible(ctx, b) {
+		return model.Book{}, fmt.Errorf("%w: book %s", model.ErrNotFound, id)
+	}
+	if b.DeletedAt != nil {
+		return model.Book{}, fmt.Errorf("%w: book %s is deleted", model.ErrNotFound, id)
+	}
+	return b, nil
 }
 
 func valueOr(p *string, def string) string {
@@ -138,4 +555,13 @@ func merge(dst *model.Book, e model.EnrichedBook) {
 	if len(dst.Authors) == 0 && len(e.Authors) > 0 {
 		dst.Authors = append([]string(nil), e.Authors...)
 	}
+	if dst.ReleaseDate == nil && e.ReleaseDate != nil {
+		dst.ReleaseDate = releaseDay(e.ReleaseDate)
+	}
+	if dst.Description == nil && e.Description != nil {
+		dst.Description = e.Description
+	}
+	if len(dst.Subjects) == 0 && len(e.Subjects) > 0 {
+		dst.Subjects = append([]string(nil), e.Subjects...)
+	}
 }
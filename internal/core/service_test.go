@@ -9,9 +9,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -58,10 +60,35 @@ func TestCreate_EnrichMiss_RequireTrue_Fails(t *testing.T) {
 	assert.Equal(t, model.ErrUpstream, err)
 }
 
+// hookFunc adapts a function to CreateHook.
+type hookFunc func(model.CreateBookInput) (model.CreateBookInput, error)
+
+func (f hookFunc) Transform(_ context.Context, in model.CreateBookInput) (model.CreateBookInput, error) {
+	return f(in)
+}
+
+func TestCreate_Hook(t *testing.T) {
+	repo := adapter.NewBookRepo()
+	svc := NewService(repo, mockEnrich{hit: false})
+	svc.Hook = hookFunc(func(in model.CreateBookInput) (model.CreateBookInput, error) {
+		if in.Title != nil && *in.Title == "secret" {
+			return in, fmt.Errorf("%w: not for the catalog", model.ErrValidation)
+		}
+		in.Tags = append(in.Tags, "catalogued")
+		return in, nil
+	})
+	ctx := context.Background()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"catalogued"}, b.Tags)
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("secret")})
+	assert.ErrorIs(t, err, model.ErrValidation)
+}
+
 func TestDuplicateISBN_Fails(t *testing.T) {
 	repo := adapter.NewBookRepo()
 	svc := NewService(repo, mockEnrich{hit: false})
-	isbn := "9780000000000"
+	isbn := "9780000000002"
 	ctx := context.Background()
 	_, err := svc.CreateBook(ctx, model.CreateBookInput{ISBN: util.GetPtr(isbn), Title: util.GetPtr("T")})
 	require.NoError(t, err)
@@ -136,3 +163,110 @@ func (f mockEnrich) FetchByISBN(ctx context.Context, isbn string) (model.Enriche
 	return model.EnrichedBook{
 		Title: util.GetPtr("Clean Architecture"), PublishedYear: util.GetPtr(2017), PageCount: util.GetPtr(432), Authors: []string{"Robert C. Martin"}}, nil
 }
+
+func TestVerifyRepository(t *testing.T) {
+	repo := adapter.NewBookRepo()
+	report, err := VerifyRepository(context.Background(), repo, false)
+	require.NoError(t, err)
+	assert.Empty(t, report.Issues)
+}
+
+func TestUpdateBook(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Rules = adapter.NewAutoTagRuleRepo()
+	ctx := context.Background()
+	orig, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Old"), ISBN: util.GetPtr("9780134494166"), PageCount: util.GetPtr(10)})
+	require.NoError(t, err)
+	other, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Other"), ISBN: util.GetPtr("9780441013593")})
+	require.NoError(t, err)
+	_, err = svc.CreateAutoTagRule(ctx, model.AutoTagRule{Field: "title", Contains: "new", AddTag: "fresh"})
+	require.NoError(t, err)
+
+	out, err := svc.UpdateBook(ctx, orig.ID, model.UpdateBookInput{Title: util.GetPtr("New"), ISBN: util.GetPtr("978-0-13-449416-6")})
+	require.NoError(t, err)
+	assert.Equal(t, "New", out.Title)
+	assert.Nil(t, out.PageCount, "PUT clears omitted fields")
+	assert.Equal(t, []string{"fresh"}, out.Tags)
+	assert.Equal(t, orig.CreatedAt, out.CreatedAt)
+	assert.True(t, out.UpdatedAt.After(orig.UpdatedAt))
+
+	_, err = svc.UpdateBook(ctx, orig.ID, model.UpdateBookInput{Title: util.GetPtr("New"), ISBN: other.ISBN})
+	assert.ErrorIs(t, err, model.ErrConflict)
+	_, err = svc.UpdateBook(ctx, orig.ID, model.UpdateBookInput{Title: util.GetPtr("")})
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.UpdateBook(ctx, orig.ID, model.UpdateBookInput{Title: util.GetPtr("New"), PublishedYear: util.GetPtr(1000)})
+	assert.ErrorIs(t, err, model.ErrValidation)
+	_, err = svc.UpdateBook(ctx, "missing", model.UpdateBookInput{Title: util.GetPtr("New")})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestTimestamps(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	ctx := context.Background()
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, b.CreatedAt.Location())
+	assert.Equal(t, b.CreatedAt, b.UpdatedAt)
+
+	// a change cannot move CreatedAt, nor UpdatedAt back when the clock steps back
+	later := time.Now().Add(time.Hour).UTC()
+	before := b
+	before.UpdatedAt = later
+	b.CreatedAt = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := touch(before, &b)
+	assert.Equal(t, before.CreatedAt, b.CreatedAt)
+	assert.Equal(t, later, b.UpdatedAt)
+	assert.Equal(t, later, at)
+
+	require.NoError(t, svc.DeleteBook(ctx, b.ID))
+	got, err := svc.Repo.GetByID(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, before.CreatedAt, got.CreatedAt)
+	assert.Equal(t, got.UpdatedAt, *got.DeletedAt)
+	assert.Equal(t, time.UTC, got.DeletedAt.Location())
+}
+
+func TestUpdateBook_Version(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	ctx := context.Background()
+	orig, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Draft")})
+	require.NoError(t, err)
+	assert.Equal(t, 1, orig.Version)
+
+	// two editors start from version 1; the second one loses
+	out, err := svc.PatchBook(ctx, orig.ID, model.BookPatch{Title: util.GetPtr("Mine"), Version: util.GetPtr(1)})
+	require.NoError(t, err)
+	assert.Equal(t, 2, out.Version)
+	_, err = svc.UpdateBook(ctx, orig.ID, model.UpdateBookInput{Title: util.GetPtr("Theirs"), Version: util.GetPtr(1)})
+	assert.ErrorIs(t, err, model.ErrConflict)
+
+	got, err := svc.GetBook(ctx, orig.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Mine", got.Title)
+
+	out, err = svc.UpdateBook(ctx, orig.ID, model.UpdateBookInput{Title: util.GetPtr("Unchecked")})
+	require.NoError(t, err, "no version, no check")
+	assert.Equal(t, 3, out.Version)
+}
+
+func TestPatchBook(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	ctx := context.Background()
+	orig, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Title"), PageCount: util.GetPtr(10), Tags: []string{"a"}})
+	require.NoError(t, err)
+
+	out, err := svc.PatchBook(ctx, orig.ID, model.BookPatch{PageCount: util.GetPtr(20), Tags: &[]string{"b"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Title", out.Title)
+	assert.Equal(t, 20, *out.PageCount)
+	assert.Equal(t, []string{"b"}, out.Tags)
+
+	_, err = svc.PatchBook(ctx, orig.ID, model.BookPatch{PageCount: util.GetPtr(0)})
+	assert.ErrorIs(t, err, model.ErrValidation)
+	got, err := svc.GetBook(ctx, orig.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 20, *got.PageCount, "rejected patch is not applied")
+
+	_, err = svc.PatchBook(ctx, "missing", model.BookPatch{})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+}
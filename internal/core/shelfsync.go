@@ -0,0 +1,228 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShelfFeed reads a shelf of a book-tracking site, such as a Goodreads
+// shelf's RSS feed.
+type ShelfFeed interface {
+	Shelf(ctx context.Context, feedURL string) (model.Shelf, error)
+}
+
+// ShelfSyncRepository keeps the reports of past shelf syncs.
+type ShelfSyncRepository interface {
+	Add(ctx context.Context, r model.ShelfSyncReport) error
+	// List returns up to limit reports, newest first.
+	List(ctx context.Context, limit int) ([]model.ShelfSyncReport, error)
+}
+
+// maxShelfSyncLimit bounds the reports one query returns.
+const maxShelfSyncLimit = 100
+
+// SyncShelves syncs every feed of ShelfURLs in turn and returns, and
+// records, a report per feed. Each entry of a shelf is matched with the
+// catalog by ISBN, or else by title and a shared author (see
+// normalizeTitle); a matching book is tagged with the shelf's name, and an
+// entry without a match is created with that tag, enriched when it has an
+// ISBN. Books in the trash match but are left alone. A feed that cannot be
+// read is reported and does not stop the others. Syncs never overlap.
+func (s *Service) SyncShelves(ctx context.Context) ([]model.ShelfSyncReport, error) {
+	if s.Shelves == nil || len(s.ShelfURLs) == 0 {
+		return nil, fmt.Errorf("%w: no shelf feeds configured", model.ErrNotFound)
+	}
+	s.shelfSync.Lock()
+	defer s.shelfSync.Unlock()
+
+	var reports []model.ShelfSyncReport
+	var errs []error
+	for _, feed := range s.ShelfURLs {
+		r, err := s.syncShelf(ctx, feed)
+		if err != nil {
+			return reports, err
+		}
+		if s.ShelfSyncs != nil {
+			if err := s.ShelfSyncs.Add(ctx, r); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		reports = append(reports, r)
+	}
+	return reports, errors.Join(errs...)
+}
+
+// WatchShelves runs SyncShelves every interval until ctx ends.
+func (s *Service) WatchShelves(ctx context.Context, interval time.Duration, log *slog.Logger) {
+	every(ctx, interval, func(time.Time) {
+		reports, err := s.SyncShelves(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.With("error", err).Warn("shelf sync failed")
+		}
+		for _, r := range reports {
+			if r.Err != nil {
+				log.With("error", r.Err).Warn("shelf feed unreadable", "source", r.Source)
+				continue
+			}
+			log.Info("shelf synced", "shelf", r.Shelf, "created", r.Created, "tagged", r.Tagged, "existing", r.Existing, "failed", r.Failed)
+		}
+	})
+}
+
+// ShelfSyncReports returns up to limit reports of past syncs, newest first.
+func (s *Service) ShelfSyncReports(ctx context.Context, limit int) ([]model.ShelfSyncReport, error) {
+	if limit < 1 || limit > maxShelfSyncLimit {
+		return nil, &model.FieldError{Field: "limit", Reason: fmt.Sprintf("must be between 1 and %d", maxShelfSyncLimit)}
+	}
+	if s.ShelfSyncs == nil {
+		return nil, nil
+	}
+	return s.ShelfSyncs.List(ctx, limit)
+}
+
+// syncShelf syncs one feed. Only a cancelled ctx is returned as an error;
+// everything else goes into the report.
+func (s *Service) syncShelf(ctx context.Context, feed string) (model.ShelfSyncReport, error) {
+	r := model.ShelfSyncReport{ID: uuid.NewString(), Source: feedSource(feed), StartedAt: time.Now()}
+	shelf, err := s.Shelves.Shelf(ctx, feed)
+	if ctx.Err() != nil {
+		return r, ctx.Err()
+	}
+	if err != nil {
+		r.Err, r.FinishedAt = fmt.Errorf("%w: %v", model.ErrUpstream, err), time.Now()
+		return r, nil
+	}
+	r.Shelf = shelf.Name
+
+	done := s.StartImport(ctx)
+	defer done()
+	byTitle := map[string]model.Book{}
+	err = s.WalkBooks(ctx, model.ListQuery{IncludeDeleted: true}, func(b model.Book) error {
+		for _, k := range shelfKeys(b.Title, b.Authors) {
+			byTitle[k] = b
+		}
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return r, ctx.Err()
+		}
+		r.Err, r.FinishedAt = err, time.Now()
+		return r, nil
+	}
+
+	r.Items = make([]model.ShelfSyncItem, 0, len(shelf.Entries))
+	for _, e := range shelf.Entries {
+		item := s.syncShelfEntry(ctx, shelf.Name, e, byTitle)
+		if ctx.Err() != nil {
+			return r, ctx.Err()
+		}
+		switch item.Status {
+		case model.ShelfEntryCreated:
+			r.Created++
+		case model.ShelfEntryTagged:
+			r.Tagged++
+		case model.ShelfEntryExisting:
+			r.Existing++
+		default:
+			r.Failed++
+		}
+		r.Items = append(r.Items, item)
+	}
+	r.FinishedAt = time.Now()
+	return r, nil
+}
+
+// syncShelfEntry tags or creates the book of e; byTitle, the catalog by
+// shelfKeys, is kept up to date.
+func (s *Service) syncShelfEntry(ctx context.Context, shelf string, e model.ShelfEntry, byTitle map[string]model.Book) model.ShelfSyncItem {
+	item := model.ShelfSyncItem{Entry: e}
+	var match *model.Book
+	if e.ISBN != "" {
+		if b, err := s.Repo.GetByISBN(ctx, s.owner(ctx), isbnKey(e.ISBN)); err == nil {
+			match = &b
+		}
+	}
+	if match == nil {
+		for _, k := range shelfKeys(e.Title, e.Authors) {
+			if b, ok := byTitle[k]; ok {
+				match = &b
+				break
+			}
+		}
+	}
+
+	if match != nil {
+		item.BookID = match.ID
+		if match.DeletedAt != nil || slices.Contains(match.Tags, shelf) {
+			item.Status = model.ShelfEntryExisting
+			return item
+		}
+		tags := append(slices.Clone(match.Tags), shelf)
+		b, err := s.PatchBook(ctx, match.ID, model.BookPatch{Tags: &tags})
+		if err != nil {
+			item.Status, item.Err = model.ShelfEntryFailed, err
+			return item
+		}
+		item.Status = model.ShelfEntryTagged
+		for _, k := range shelfKeys(b.Title, b.Authors) {
+			byTitle[k] = b
+		}
+		return item
+	}
+
+	in := model.CreateBookInput{Authors: e.Authors, PublishedYear: e.PublishedYear, Tags: []string{shelf}}
+	if e.Title != "" {
+		in.Title = &e.Title
+	}
+	if e.ISBN != "" {
+		in.ISBN = &e.ISBN
+		in.Enrich = true
+	}
+	b, err := s.CreateBook(ctx, in)
+	if err != nil {
+		item.Status, item.Err = model.ShelfEntryFailed, err
+		return item
+	}
+	item.Status, item.BookID = model.ShelfEntryCreated, b.ID
+	for _, k := range shelfKeys(b.Title, b.Authors) {
+		byTitle[k] = b
+	}
+	return item
+}
+
+// shelfKeys are the keys a book is matched by when its ISBN does not
+// match: its normalized title with each of its authors.
+func shelfKeys(title string, authors []string) []string {
+	t := normalizeTitle(title)
+	if t == "" {
+		return nil
+	}
+	keys := make([]string, 0, len(authors))
+	for _, a := range authors {
+		if a = strings.ToLower(strings.TrimSpace(a)); a != "" {
+			keys = append(keys, t+"\x00"+a)
+		}
+	}
+	return keys
+}
+
+// feedSource is a feed URL without its query, which may carry a key, and
+// credentials.
+func feedSource(feed string) string {
+	u, err := url.Parse(feed)
+	if err != nil {
+		return "(invalid URL)"
+	}
+	u.User, u.RawQuery, u.Fragment = nil, "", ""
+	return u.String()
+}
@@ -0,0 +1,90 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeShelves map[string]model.Shelf
+
+func (f fakeShelves) Shelf(_ context.Context, feedURL string) (model.Shelf, error) {
+	shelf, ok := f[feedURL]
+	if !ok {
+		return model.Shelf{}, errors.New("status 503")
+	}
+	return shelf, nil
+}
+
+func TestSyncShelves(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{})
+	_, err := svc.SyncShelves(ctx)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	byISBN, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune"), ISBN: util.GetPtr("9780441013593")})
+	require.NoError(t, err)
+	byTitle, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("The Way of Kings"), Authors: []string{"Brandon Sanderson"}, Tags: []string{"fantasy"}})
+	require.NoError(t, err)
+	trashed, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Piranesi"), Authors: []string{"Susanna Clarke"}})
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteBook(ctx, trashed.ID))
+
+	svc.Shelves = fakeShelves{"https://gr.example/rss/1?key=secret&shelf=to-read": {Name: "to-read", Entries: []model.ShelfEntry{
+		{Title: "Dune", Authors: []string{"Frank Herbert"}, ISBN: "0441013597"},
+		{Title: "Way of Kings", Authors: []string{"brandon sanderson"}},
+		{Title: "Piranesi", Authors: []string{"Susanna Clarke"}},
+		{Title: "Babel", Authors: []string{"R.F. Kuang"}, PublishedYear: util.GetPtr(2022)},
+		{Title: "Babel", Authors: []string{"R. F. Kuang", "R.F. Kuang"}},
+		{Title: "Bad ISBN", ISBN: "123"},
+	}}}
+	svc.ShelfURLs = []string{"https://gr.example/rss/1?key=secret&shelf=to-read", "https://gr.example/rss/2"}
+	svc.ShelfSyncs = adapter.NewShelfSyncRepo()
+
+	reports, err := svc.SyncShelves(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	r := reports[0]
+	assert.Equal(t, "to-read", r.Shelf)
+	assert.Equal(t, "https://gr.example/rss/1", r.Source, "the key is not reported")
+	assert.Equal(t, []int{1, 2, 2, 1}, []int{r.Created, r.Tagged, r.Existing, r.Failed})
+	statuses := make([]string, 0, len(r.Items))
+	for _, it := range r.Items {
+		statuses = append(statuses, it.Status)
+	}
+	assert.Equal(t, []string{"tagged", "tagged", "existing", "created", "existing", "failed"}, statuses)
+	assert.Equal(t, byISBN.ID, r.Items[0].BookID)
+	assert.Equal(t, trashed.ID, r.Items[2].BookID)
+	assert.Equal(t, r.Items[3].BookID, r.Items[4].BookID, "an entry seen twice is created once")
+
+	got, err := svc.GetBook(ctx, byTitle.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fantasy", "to-read"}, got.Tags)
+	babel, err := svc.GetBook(ctx, r.Items[3].BookID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"to-read"}, babel.Tags)
+	assert.Equal(t, 2022, *babel.PublishedYear)
+
+	assert.ErrorIs(t, reports[1].Err, model.ErrUpstream)
+	assert.Empty(t, reports[1].Items)
+
+	// the next sync finds everything in place
+	reports, err = svc.SyncShelves(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, reports[0].Existing)
+
+	saved, err := svc.ShelfSyncReports(ctx, 3)
+	require.NoError(t, err)
+	require.Len(t, saved, 3)
+	assert.Equal(t, reports[1].ID, saved[0].ID, "newest first")
+	_, err = svc.ShelfSyncReports(ctx, 0)
+	assert.ErrorIs(t, err, model.ErrValidation)
+}
@@ -0,0 +1,215 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StocktakeRepository keeps stocktakes. Get, AddScan and Close fail with
+// model.ErrNotFound for an unknown stocktake; AddScan and Close fail with
+// model.ErrConflict for a closed one.
+type StocktakeRepository interface {
+	Create(ctx context.Context, st model.Stocktake) (model.Stocktake, error)
+	Get(ctx context.Context, id string) (model.Stocktake, error)
+	// List returns every stocktake, latest started first.
+	List(ctx context.Context) ([]model.Stocktake, error)
+	// AddScan appends code to the scans unless it was scanned already,
+	// reporting whether it was added.
+	AddScan(ctx context.Context, id, code string) (bool, error)
+	// Close sets the closing time, closer and report of st.
+	Close(ctx context.Context, st model.Stocktake) (model.Stocktake, error)
+}
+
+// StartStocktake opens a stocktake of the copies kept at branch, or of
+// every copy when branch is empty.
+func (s *Service) StartStocktake(ctx context.Context, branch string) (model.Stocktake, error) {
+	if s.Stocktakes == nil {
+		return model.Stocktake{}, fmt.Errorf("%w: stocktakes are not configured", model.ErrNotFound)
+	}
+	branch = strings.TrimSpace(branch)
+	if branch != "" {
+		if _, err := s.GetBranch(ctx, branch); err != nil {
+			return model.Stocktake{}, err
+		}
+	}
+	return s.Stocktakes.Create(ctx, model.Stocktake{
+		ID:        uuid.NewString(),
+		Branch:    branch,
+		StartedBy: model.ActorFromContext(ctx),
+		StartedAt: time.Now().UTC(),
+		Tenant:    model.TenantFromContext(ctx),
+	})
+}
+
+func (s *Service) GetStocktake(ctx context.Context, id string) (model.Stocktake, error) {
+	if s.Stocktakes == nil {
+		return model.Stocktake{}, fmt.Errorf("%w: stocktake %s", model.ErrNotFound, id)
+	}
+	return s.Stocktakes.Get(ctx, id)
+}
+
+func (s *Service) ListStocktakes(ctx context.Context) ([]model.Stocktake, error) {
+	if s.Stocktakes == nil {
+		return nil, nil
+	}
+	return s.Stocktakes.List(ctx)
+}
+
+// ScanStocktake records a barcode scanned during an open stocktake,
+// reporting whether it is new to it; scanning a copy again changes nothing.
+func (s *Service) ScanStocktake(ctx context.Context, id, code string) (bool, error) {
+	if s.Stocktakes == nil {
+		return false, fmt.Errorf("%w: stocktake %s", model.ErrNotFound, id)
+	}
+	code = strings.TrimSpace(code)
+	if !barcode.MatchString(code) {
+		return false, &model.FieldError{Field: "barcode", Reason: "must be 1 to 64 letters, digits or dashes, not starting with a dash"}
+	}
+	return s.Stocktakes.AddScan(ctx, id, code)
+}
+
+// StocktakeReport compares the scans of a stocktake with the catalog as it
+// is now; a closed stocktake has its final report.
+func (s *Service) StocktakeReport(ctx context.Context, id string) (model.StocktakeReport, error) {
+	st, err := s.GetStocktake(ctx, id)
+	if err != nil {
+		return model.StocktakeReport{}, err
+	}
+	if st.Report != nil {
+		return *st.Report, nil
+	}
+	rep, _, err := s.stocktakeReport(ctx, st)
+	return rep, err
+}
+
+// CloseStocktake ends a stocktake and keeps its report. With markMissing
+// the copies it did not find are set to missing, and missing copies it
+// found back to good; those changes are in the audit log. A failure to
+// update a book is returned along with the closed stocktake.
+func (s *Service) CloseStocktake(ctx context.Context, id string, markMissing bool) (model.Stocktake, error) {
+	st, err := s.GetStocktake(ctx, id)
+	if err != nil {
+		return model.Stocktake{}, err
+	}
+	if st.ClosedAt != nil {
+		return model.Stocktake{}, fmt.Errorf("%w: stocktake %s is closed", model.ErrConflict, id)
+	}
+	rep, found, err := s.stocktakeReport(ctx, st)
+	if err != nil {
+		return model.Stocktake{}, err
+	}
+	var errs []error
+	if markMissing {
+		for _, c := range rep.Missing {
+			ok, err := s.setItemCondition(ctx, c.BookID, c.Barcode, model.ItemMissing)
+			if ok {
+				rep.MarkedMissing++
+			}
+			errs = append(errs, err)
+		}
+		for _, c := range found {
+			ok, err := s.setItemCondition(ctx, c.BookID, c.Barcode, model.ItemGood)
+			if ok {
+				rep.Found++
+			}
+			errs = append(errs, err)
+		}
+	}
+	now := time.Now().UTC()
+	st.ClosedAt, st.ClosedBy, st.Report = &now, model.ActorFromContext(ctx), &rep
+	if st, err = s.Stocktakes.Close(ctx, st); err != nil {
+		return model.Stocktake{}, err
+	}
+	return st, errors.Join(errs...)
+}
+
+// stocktakeReport compares st's scans with the copies in its scope, and
+// returns the missing copies it saw, which closing it may find again.
+func (s *Service) stocktakeReport(ctx context.Context, st model.Stocktake) (rep model.StocktakeReport, found []model.StocktakeCopy, err error) {
+	var active []model.Loan
+	if s.Loans != nil {
+		if active, err = s.Loans.List(ctx, model.LoanQuery{Status: model.LoanActive, Now: time.Now().UTC()}); err != nil {
+			return model.StocktakeReport{}, nil, err
+		}
+	}
+	lent := make(map[string]bool, len(active))
+	for _, l := range active {
+		if l.Barcode != "" {
+			lent[l.Barcode] = true
+		}
+	}
+	scanned := make(map[string]bool, len(st.Scanned))
+	for _, code := range st.Scanned {
+		scanned[code] = true
+	}
+	known := map[string]bool{}
+	err = s.WalkBooks(ctx, model.ListQuery{}, func(b model.Book) error {
+		for _, it := range b.Items {
+			c := model.StocktakeCopy{Barcode: it.Barcode, BookID: b.ID, Title: b.Title, Branch: it.Branch, Location: it.Location}
+			known[it.Barcode] = true
+			switch {
+			case st.Branch != "" && it.Branch != st.Branch:
+				if scanned[it.Barcode] {
+					c.Reason = model.StocktakeOtherBranch
+					rep.Unexpected = append(rep.Unexpected, c)
+				}
+			case lent[it.Barcode]:
+				if scanned[it.Barcode] {
+					c.Reason = model.StocktakeOnLoan
+					rep.Unexpected = append(rep.Unexpected, c)
+				}
+			case scanned[it.Barcode]:
+				rep.Expected++
+				rep.Seen = append(rep.Seen, c)
+				if it.Condition == model.ItemMissing {
+					found = append(found, c)
+				}
+			case it.Condition != model.ItemLost:
+				rep.Expected++
+				rep.Missing = append(rep.Missing, c)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return model.StocktakeReport{}, nil, err
+	}
+	for _, code := range st.Scanned {
+		if !known[code] {
+			rep.Unexpected = append(rep.Unexpected, model.StocktakeCopy{Barcode: code, Reason: model.StocktakeUnknown})
+		}
+	}
+	for _, list := range [][]model.StocktakeCopy{rep.Seen, rep.Missing, rep.Unexpected} {
+		slices.SortFunc(list, func(a, b model.StocktakeCopy) int { return cmp.Compare(a.Barcode, b.Barcode) })
+	}
+	return rep, found, nil
+}
+
+// setItemCondition sets the condition of a book's item, reporting whether
+// it changed. Books in the trash and items removed meanwhile are left
+// alone.
+func (s *Service) setItemCondition(ctx context.Context, bookID, code string, c model.ItemCondition) (bool, error) {
+	b, err := s.getBook(ctx, bookID)
+	if err != nil {
+		return false, nil
+	}
+	i := slices.IndexFunc(b.Items, func(it model.Item) bool { return it.Barcode == code })
+	if i < 0 || b.Items[i].Condition == c {
+		return false, nil
+	}
+	before := b
+	b.Items = slices.Clone(b.Items)
+	b.Items[i].Condition = c
+	if _, err := s.updateBook(ctx, model.AuditUpdate, before, b); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,98 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStocktake(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Branches = adapter.NewBranchRepo()
+	svc.Loans = adapter.NewLoanRepo()
+	svc.Stocktakes = adapter.NewStocktakeRepo()
+	for _, id := range []string{"east", "west"} {
+		_, err := svc.CreateBranch(ctx, model.Branch{ID: id, Name: id})
+		require.NoError(t, err)
+	}
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	for _, it := range []model.Item{
+		{Barcode: "E-1", Branch: "east"}, {Barcode: "E-2", Branch: "east"}, {Barcode: "E-3", Branch: "east"},
+		{Barcode: "E-4", Branch: "east"}, {Barcode: "W-1", Branch: "west"},
+	} {
+		_, err = svc.AddItem(ctx, b.ID, it)
+		require.NoError(t, err)
+	}
+	_, err = svc.CheckoutBook(ctx, b.ID, "ada", nil)
+	require.NoError(t, err, "lends E-1")
+
+	_, err = svc.StartStocktake(ctx, "north")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	st, err := svc.StartStocktake(ctx, "east")
+	require.NoError(t, err)
+	for _, code := range []string{"E-2", "E-1", "W-1", "X-9", "E-2"} {
+		_, err = svc.ScanStocktake(ctx, st.ID, code)
+		require.NoError(t, err)
+	}
+	added, err := svc.ScanStocktake(ctx, st.ID, "E-2")
+	require.NoError(t, err)
+	assert.False(t, added, "scanned already")
+	_, err = svc.ScanStocktake(ctx, st.ID, "-bad")
+	assert.ErrorIs(t, err, model.ErrValidation)
+
+	rep, err := svc.StocktakeReport(ctx, st.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, rep.Expected, "E-1 is on loan")
+	assert.Equal(t, []string{"E-2"}, barcodes(rep.Seen))
+	assert.Equal(t, []string{"E-3", "E-4"}, barcodes(rep.Missing))
+	require.Equal(t, []string{"E-1", "W-1", "X-9"}, barcodes(rep.Unexpected))
+	assert.Equal(t, model.StocktakeOnLoan, rep.Unexpected[0].Reason)
+	assert.Equal(t, model.StocktakeOtherBranch, rep.Unexpected[1].Reason)
+	assert.Equal(t, model.StocktakeUnknown, rep.Unexpected[2].Reason)
+
+	st, err = svc.CloseStocktake(ctx, st.ID, true)
+	require.NoError(t, err)
+	require.NotNil(t, st.Report)
+	assert.Equal(t, 2, st.Report.MarkedMissing)
+	got, err := svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ItemMissing, got.Items[2].Condition)
+	_, err = svc.ScanStocktake(ctx, st.ID, "E-3")
+	assert.ErrorIs(t, err, model.ErrConflict)
+	_, err = svc.CloseStocktake(ctx, st.ID, false)
+	assert.ErrorIs(t, err, model.ErrConflict)
+
+	again, err := svc.StartStocktake(ctx, "")
+	require.NoError(t, err)
+	_, err = svc.ScanStocktake(ctx, again.ID, "E-3")
+	require.NoError(t, err)
+	again, err = svc.CloseStocktake(ctx, again.ID, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, again.Report.Found, "E-3 is back")
+	assert.Equal(t, 2, again.Report.MarkedMissing, "E-2 and W-1 were not scanned")
+	got, err = svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ItemGood, got.Items[2].Condition)
+
+	all, err := svc.ListStocktakes(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, again.ID, all[0].ID, "latest first")
+}
+
+func barcodes(copies []model.StocktakeCopy) []string {
+	var out []string
+	for _, c := range copies {
+		out = append(out, c.Barcode)
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// suggestCorrections compares the submitted tags and authors with the ones
+// already in the catalog and returns a suggestion for every near miss: edit
+// distance 1, or 2 for values of five runes or more. With in.AutoCorrect the
+// submitted values are replaced by their suggestions.
+func (s *Service) suggestCorrections(ctx context.Context, in *model.CreateBookInput) ([]model.Suggestion, error) {
+	if len(in.Tags) == 0 && len(in.Authors) == 0 {
+		return nil, nil
+	}
+	tags := map[string]string{} // lowercased -> as stored
+	authors := map[string]string{}
+	err := s.eachBook(ctx, func(b model.Book) error {
+		for _, t := range b.Tags {
+			addKnown(tags, t)
+		}
+		for _, a := range b.Authors {
+			addKnown(authors, a)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out []model.Suggestion
+	in.Tags, out = correct("tags", in.Tags, tags, in.AutoCorrect, out)
+	in.Authors, out = correct("authors", in.Authors, authors, in.AutoCorrect, out)
+	return out, nil
+}
+
+func addKnown(known map[string]string, v string) {
+	k := strings.ToLower(v)
+	if _, ok := known[k]; !ok {
+		known[k] = v
+	}
+}
+
+func correct(field string, values []string, known map[string]string, apply bool, out []model.Suggestion) ([]string, []model.Suggestion) {
+	if len(values) == 0 || len(known) == 0 {
+		return values, out
+	}
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		if best, ok := closest(v, known); ok {
+			out = append(out, model.Suggestion{Field: field, Value: v, Suggestion: best, Applied: apply})
+			if apply {
+				v = best
+			}
+		}
+		if apply && hasTag(res, v) {
+			continue
+		}
+		res = append(res, v)
+	}
+	return res, out
+}
+
+// closest returns the known value nearest to v, or false when v is already
+// known (case-insensitively) or nothing is close enough.
+func closest(v string, known map[string]string) (string, bool) {
+	lv := strings.ToLower(v)
+	if _, ok := known[lv]; ok {
+		return "", false
+	}
+	maxDist := 1
+	if utf8.RuneCountInString(lv) >= 5 {
+		maxDist = 2
+	}
+	best, bestDist := "", maxDist+1
+	for k, orig := range known {
+		d := util.Levenshtein(lv, k)
+		if d < bestDist || (d == bestDist && orig < best) {
+			best, bestDist = orig, d
+		}
+	}
+	return best, bestDist <= maxDist
+}
@@ -0,0 +1,63 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreate_SuggestsNearMissTagsAndAuthors(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	ctx := context.Background()
+	_, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Seed"), Tags: []string{"architecture", "go"}, Authors: []string{"Robert C. Martin"}})
+	require.NoError(t, err)
+
+	out, err := svc.CreateBook(ctx, model.CreateBookInput{
+		Title:   util.GetPtr("Second"),
+		Tags:    []string{"architecure", "Go", "ddd"},
+		Authors: []string{"Robert C Martin"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []model.Suggestion{
+		{Field: "tags", Value: "architecure", Suggestion: "architecture"},
+		{Field: "authors", Value: "Robert C Martin", Suggestion: "Robert C. Martin"},
+	}, out.Suggestions)
+	assert.Equal(t, []string{"architecure", "Go", "ddd"}, out.Tags, "values are kept without auto_correct")
+}
+
+func TestCreate_AutoCorrect(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	ctx := context.Background()
+	_, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Seed"), Tags: []string{"architecture"}})
+	require.NoError(t, err)
+
+	out, err := svc.CreateBook(ctx, model.CreateBookInput{
+		Title:       util.GetPtr("Second"),
+		Tags:        []string{"architecure", "architecture", "go"},
+		AutoCorrect: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"architecture", "go"}, out.Tags)
+	require.Len(t, out.Suggestions, 1)
+	assert.True(t, out.Suggestions[0].Applied)
+
+	got, err := svc.GetBook(ctx, out.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.Suggestions, "suggestions are not persisted")
+}
+
+func TestClosest_ShortValuesAllowOneEdit(t *testing.T) {
+	known := map[string]string{"go": "go", "java": "java"}
+	_, ok := closest("rust", known)
+	assert.False(t, ok)
+	best, ok := closest("jav", known)
+	assert.True(t, ok)
+	assert.Equal(t, "java", best)
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxSummaryDescription bounds the part of the description read out; a
+// voice answer should take seconds, not minutes.
+const maxSummaryDescription = 300
+
+// BookSummary describes the book in a few plain sentences that read well
+// aloud, e.g. for voice assistants: "Clean Architecture, by Robert C.
+// Martin, published in 2017. It has 432 pages." With withDescription the
+// opening sentences of the description follow.
+func (s *Service) BookSummary(ctx context.Context, id string, withDescription bool) (string, error) {
+	b, err := s.getBook(ctx, id)
+	if err != nil {
+		return "", model.ErrNotFound
+	}
+	return summarize(b, withDescription, time.Now()), nil
+}
+
+func summarize(b model.Book, withDescription bool, now time.Time) string {
+	var sb strings.Builder
+	sb.WriteString(b.Title)
+	if b.Subtitle != nil && *b.Subtitle != "" {
+		sb.WriteString(": " + *b.Subtitle)
+	}
+	if len(b.Authors) > 0 {
+		sb.WriteString(", by " + spokenList(b.Authors, 3))
+	}
+	switch {
+	case b.Forthcoming && b.ReleaseDate != nil && b.ReleaseDate.After(now):
+		sb.WriteString(", due out on " + b.ReleaseDate.Format("2 January 2006"))
+	case b.Forthcoming:
+		sb.WriteString(", not yet released")
+	case b.PublishedYear != nil:
+		sb.WriteString(", published in " + strconv.Itoa(*b.PublishedYear))
+	}
+	sb.WriteString(".")
+	if b.PageCount != nil && *b.PageCount > 0 {
+		if *b.PageCount == 1 {
+			sb.WriteString(" It has 1 page.")
+		} else {
+			fmt.Fprintf(&sb, " It has %d pages.", *b.PageCount)
+		}
+	}
+	if withDescription && b.Description != nil {
+		if d := leadSentences(plainText(*b.Description), maxSummaryDescription); d != "" {
+			sb.WriteString(" " + d)
+		}
+	}
+	return sb.String()
+}
+
+// spokenList joins names as spoken: "A", "A and B", "A, B and C"; past max
+// names the rest become "and others".
+func spokenList(names []string, max int) string {
+	if len(names) > max {
+		return strings.Join(names[:max], ", ") + " and others"
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+var (
+	htmlTagRe   = regexp.MustCompile(`<[^>]*>`)
+	sentenceEnd = regexp.MustCompile(`[.!?]["”’)]?(\s|$)`)
+)
+
+// plainText drops the HTML some sources put in descriptions.
+func plainText(s string) string {
+	s = html.UnescapeString(htmlTagRe.ReplaceAllString(s, " "))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// leadSentences returns the whole sentences at the start of s that fit in
+// max characters, or, if the first one is longer, its first max characters
+// cut at a word.
+func leadSentences(s string, max int) string {
+	end := 0
+	for _, m := range sentenceEnd.FindAllStringIndex(s, -1) {
+		if utf8.RuneCountInString(s[:m[1]]) > max {
+			break
+		}
+		end = m[1]
+	}
+	if end > 0 {
+		return strings.TrimSpace(s[:end])
+	}
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	cut := string([]rune(s)[:max])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:") + "…"
+}
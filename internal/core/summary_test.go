@@ -0,0 +1,52 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarize(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	release := time.Date(2027, 3, 12, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		book model.Book
+		desc bool
+		want string
+	}{
+		{name: "title only", book: model.Book{Title: "Dune"}, want: "Dune."},
+		{name: "full", book: model.Book{
+			Title: "Clean Architecture", Subtitle: util.GetPtr("A Craftsman's Guide"),
+			Authors: []string{"Robert C. Martin"}, PublishedYear: util.GetPtr(2017), PageCount: util.GetPtr(432),
+		}, want: "Clean Architecture: A Craftsman's Guide, by Robert C. Martin, published in 2017. It has 432 pages."},
+		{name: "many authors", book: model.Book{
+			Title: "Design Patterns", Authors: []string{"Erich Gamma", "Richard Helm", "Ralph Johnson", "John Vlissides"},
+		}, want: "Design Patterns, by Erich Gamma, Richard Helm, Ralph Johnson and others."},
+		{name: "two authors", book: model.Book{Title: "SICP", Authors: []string{"Harold Abelson", "Gerald Sussman"}},
+			want: "SICP, by Harold Abelson and Gerald Sussman."},
+		{name: "forthcoming", book: model.Book{Title: "Next", Forthcoming: true, ReleaseDate: &release},
+			want: "Next, due out on 12 March 2027."},
+		{name: "description", desc: true, book: model.Book{
+			Title: "Dune", Description: util.GetPtr("<p>A desert planet.</p> <b>Spice</b> &amp; sand!"),
+		}, want: "Dune. A desert planet. Spice & sand!"},
+		{name: "description left out", book: model.Book{Title: "Dune", Description: util.GetPtr("A desert planet.")},
+			want: "Dune."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, summarize(tt.book, tt.desc, now))
+		})
+	}
+}
+
+func TestLeadSentences(t *testing.T) {
+	assert.Equal(t, "One. Two.", leadSentences("One. Two. Three is long.", 12))
+	assert.Equal(t, "A very long…", leadSentences("A very long first sentence", 14))
+	assert.Equal(t, "No end mark", leadSentences("No end mark", 20))
+}
@@ -0,0 +1,184 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ListTags returns every tag in the catalog with the number of books
+// carrying it, by tag.
+func (s *Service) ListTags(ctx context.Context) ([]model.TagCount, error) {
+	counts := map[string]int{}
+	err := s.eachBook(ctx, func(b model.Book) error {
+		for _, t := range b.Tags {
+			counts[t]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.TagCount, 0, len(counts))
+	for t, n := range counts {
+		out = append(out, model.TagCount{Tag: t, Books: n})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Tag < out[j].Tag })
+	return out, nil
+}
+
+// TagTree returns the catalog's tags nested by level, each level by name.
+// Parents no book carries themselves are included with Books 0.
+func (s *Service) TagTree(ctx context.Context) ([]model.TagNode, error) {
+	root := &tagTreeNode{children: map[string]*tagTreeNode{}}
+	err := s.eachBook(ctx, func(b model.Book) error {
+		seen := map[*tagTreeNode]bool{}
+		for _, t := range b.Tags {
+			n := root
+			for _, level := range strings.Split(t, model.TagSeparator) {
+				n = n.child(level)
+				if !seen[n] {
+					seen[n] = true
+					n.total++
+				}
+			}
+			n.books++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return root.nodes(""), nil
+}
+
+type tagTreeNode struct {
+	books, total int
+	children     map[string]*tagTreeNode
+}
+
+func (n *tagTreeNode) child(name string) *tagTreeNode {
+	c, ok := n.children[name]
+	if !ok {
+		c = &tagTreeNode{children: map[string]*tagTreeNode{}}
+		n.children[name] = c
+	}
+	return c
+}
+
+func (n *tagTreeNode) nodes(prefix string) []model.TagNode {
+	out := make([]model.TagNode, 0, len(n.children))
+	for name, c := range n.children {
+		tag := prefix + name
+		out = append(out, model.TagNode{
+			Name: name, Tag: tag, Books: c.books, TotalBooks: c.total,
+			Children: c.nodes(tag + model.TagSeparator),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// ReplaceBookTags sets the tags of a book; duplicates are dropped. version,
+// when set, must match the stored book.
+func (s *Service) ReplaceBookTags(ctx context.Context, id string, tags []string, version *int) (model.Book, error) {
+	clean, err := cleanTags("tags", tags)
+	if err != nil {
+		return model.Book{}, err
+	}
+	return s.PatchBook(ctx, id, model.BookPatch{Tags: &clean, Version: version})
+}
+
+// RenameTag renames a tag on every book.
+func (s *Service) RenameTag(ctx context.Context, from, to string) (model.TagRename, error) {
+	from = strings.TrimSpace(from)
+	if from == "" {
+		return model.TagRename{}, &model.FieldError{Field: "from", Reason: "must not be empty"}
+	}
+	return s.MergeTags(ctx, []string{from}, to)
+}
+
+// MergeTags replaces each of the sources with target on every book, all
+// books at once; a book that carried several of them keeps target once.
+// Sources may include target. Every changed book gets an audit entry and an
+// event, as an update of it would.
+func (s *Service) MergeTags(ctx context.Context, sources []string, target string) (model.TagRename, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return model.TagRename{}, &model.FieldError{Field: "to", Reason: "must not be empty"}
+	}
+	target, err := tagPath("to", target)
+	if err != nil {
+		return model.TagRename{}, err
+	}
+	from, err := cleanTags("from", sources)
+	if err != nil {
+		return model.TagRename{}, err
+	}
+	from = slices.DeleteFunc(from, func(t string) bool { return t == target })
+	if len(from) == 0 {
+		return model.TagRename{}, &model.FieldError{Field: "from", Reason: "must name a tag other than the target"}
+	}
+	wctx := s.stage(ctx, model.AuditUpdate)
+	rn, err := s.Repo.RenameTags(wctx, model.TagRename{From: from, To: target, RenamedAt: time.Now().UTC()})
+	if err != nil {
+		return model.TagRename{}, err
+	}
+	if rn.BooksUpdated == 0 {
+		return model.TagRename{}, fmt.Errorf("%w: no book is tagged %s", model.ErrNotFound, strings.Join(from, ", "))
+	}
+	s.auditChanges(wctx, rn.Changes)
+	rn.Changes = nil
+	return rn, nil
+}
+
+// cleanTags trims tags and drops duplicates; empty tags are invalid.
+func cleanTags(field string, tags []string) ([]string, error) {
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			return nil, &model.FieldError{Field: field, Reason: "must not contain empty tags"}
+		}
+		if !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// tagPaths checks the tags of a book that are not among keep, the tags it
+// already had, so books stored before nesting was validated can still be
+// edited; see tagPath.
+func tagPaths(tags, keep []string) ([]string, error) {
+	var out []string
+	for _, t := range tags {
+		if !slices.Contains(keep, t) {
+			var err error
+			if t, err = tagPath("tags", t); err != nil {
+				return nil, err
+			}
+		}
+		if !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// tagPath trims each level of a nested tag; a tag with an empty level, as
+// in "/go", "go/" or "programming//go", is invalid.
+func tagPath(field, tag string) (string, error) {
+	levels := strings.Split(tag, model.TagSeparator)
+	for i, l := range levels {
+		if levels[i] = strings.TrimSpace(l); levels[i] == "" {
+			return "", &model.FieldError{Field: field, Reason: fmt.Sprintf(
+				"tag %q has an empty level; levels are separated by a single %s with none at either end", tag, model.TagSeparator)}
+		}
+	}
+	return strings.Join(levels, model.TagSeparator), nil
+}
@@ -0,0 +1,124 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTags(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), nil)
+	a, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("A"), Tags: []string{"sci-fi", "classic"}})
+	require.NoError(t, err)
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("B"), Tags: []string{"scifi"}})
+	require.NoError(t, err)
+
+	tags, err := svc.ListTags(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.TagCount{{Tag: "classic", Books: 1}, {Tag: "sci-fi", Books: 1}, {Tag: "scifi", Books: 1}}, tags)
+
+	rn, err := svc.MergeTags(ctx, []string{"sci-fi", " scifi", "sf"}, "sf")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sci-fi", "scifi"}, rn.From)
+	assert.Equal(t, 2, rn.BooksUpdated)
+	tags, err = svc.ListTags(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.TagCount{{Tag: "classic", Books: 1}, {Tag: "sf", Books: 2}}, tags)
+
+	_, err = svc.RenameTag(ctx, "sci-fi", "sf")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	_, err = svc.RenameTag(ctx, "sf", " ")
+	assert.ErrorIs(t, err, model.ErrValidation)
+
+	b, err := svc.ReplaceBookTags(ctx, a.ID, []string{"b", "a", "b"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, b.Tags)
+	_, err = svc.ReplaceBookTags(ctx, a.ID, []string{"c"}, util.GetPtr(1))
+	assert.ErrorIs(t, err, model.ErrConflict, "stale version")
+}
+
+func TestMergeTags_AuditsEveryBook(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), nil)
+	svc.Audit = adapter.NewAuditRepo()
+	svc.Outbox = adapter.NewOutboxRepo()
+	a, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("A"), Tags: []string{"sci-fi", "classic"}})
+	require.NoError(t, err)
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("B"), Tags: []string{"scifi"}})
+	require.NoError(t, err)
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("C"), Tags: []string{"classic"}})
+	require.NoError(t, err)
+
+	rn, err := svc.MergeTags(ctx, []string{"sci-fi", "scifi"}, "sf")
+	require.NoError(t, err)
+	assert.Empty(t, rn.Changes)
+	history, err := svc.BookHistory(ctx, a.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, []model.FieldChange{{Field: "tags", Before: []string{"sci-fi", "classic"}, After: []string{"sf", "classic"}}}, history[0].Changes)
+
+	events, err := svc.Outbox.Pending(ctx, 100)
+	require.NoError(t, err)
+	var updated []string
+	for _, ev := range events {
+		if ev.Type == model.EventBookUpdated {
+			updated = append(updated, ev.BookID)
+		}
+	}
+	assert.ElementsMatch(t, []string{a.ID, b.ID}, updated)
+}
+
+func TestNestedTags(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), nil)
+	create := func(title string, tags ...string) (model.Book, error) {
+		return svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr(title), Tags: tags})
+	}
+	_, err := create("The Go Programming Language", "programming / go", "programming/go/")
+	assert.ErrorIs(t, err, model.ErrValidation, "trailing separator")
+	gopl, err := create("The Go Programming Language", "programming / go", "programming/go", "programming")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"programming/go", "programming"}, gopl.Tags, "levels trimmed, duplicates dropped")
+	_, err = create("Learn Go with Tests", "programming/go/testing")
+	require.NoError(t, err)
+	_, err = create("Dune", "fiction/sf")
+	require.NoError(t, err)
+	for _, tag := range []string{"/go", "programming//go", "go/ "} {
+		_, err = create("Bad", tag)
+		assert.ErrorIs(t, err, model.ErrValidation, tag)
+	}
+
+	page, err := svc.ListBooks(ctx, model.ListQuery{Tag: util.GetPtr("programming/go"), IncludeChildren: true, Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+	page, err = svc.ListBooks(ctx, model.ListQuery{Tag: util.GetPtr("programming/go"), Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+	page, err = svc.ListBooks(ctx, model.ListQuery{Tag: util.GetPtr("prog"), IncludeChildren: true, Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Zero(t, page.Total, "a prefix is not a parent")
+
+	tree, err := svc.TagTree(ctx)
+	require.NoError(t, err)
+	require.Len(t, tree, 2)
+	assert.Equal(t, model.TagNode{Name: "fiction", Tag: "fiction", TotalBooks: 1,
+		Children: []model.TagNode{{Name: "sf", Tag: "fiction/sf", Books: 1, TotalBooks: 1, Children: []model.TagNode{}}}}, tree[0])
+	prog := tree[1]
+	assert.Equal(t, 1, prog.Books)
+	assert.Equal(t, 2, prog.TotalBooks, "a book tagged at several levels counts once")
+	require.Len(t, prog.Children, 1)
+	assert.Equal(t, "programming/go", prog.Children[0].Tag)
+	assert.Equal(t, []int{1, 2}, []int{prog.Children[0].Books, prog.Children[0].TotalBooks})
+	assert.Equal(t, "programming/go/testing", prog.Children[0].Children[0].Tag)
+
+	_, err = svc.MergeTags(ctx, []string{"fiction/sf"}, "fiction//sf")
+	assert.ErrorIs(t, err, model.ErrValidation)
+}
@@ -0,0 +1,28 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+)
+
+// checkQuota returns the limit TenantQuotas sets ctx's tenant, if any, and
+// fails with model.ErrQuota when the tenant already keeps that many books.
+// It only saves a create that cannot succeed the enrichment: books created
+// at the same moment may all pass it, so the book is then stored with
+// BookRepository.CreateCapped, which enforces the limit.
+func (s *Service) checkQuota(ctx context.Context) (limit int, capped bool, err error) {
+	tenant := model.TenantFromContext(ctx)
+	limit, ok := s.TenantQuotas[tenant]
+	if tenant == "" || !ok {
+		return 0, false, nil
+	}
+	n, err := s.Repo.Count(ctx, model.ListQuery{IncludeDeleted: true})
+	if err != nil {
+		return 0, false, err
+	}
+	if n >= limit {
+		return 0, false, fmt.Errorf("%w: tenant %s keeps its limit of %d books", model.ErrQuota, tenant, limit)
+	}
+	return limit, true, nil
+}
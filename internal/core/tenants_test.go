@@ -0,0 +1,297 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenants(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.TenantQuotas = map[string]int{"east": 2}
+	east := model.WithTenant(context.Background(), "east")
+	west := model.WithTenant(context.Background(), "west")
+
+	b, err := svc.CreateBook(east, model.CreateBookInput{Title: util.GetPtr("Dune"), ISBN: util.GetPtr("9780441013593"), Tags: []string{"sf"}})
+	require.NoError(t, err)
+	assert.Equal(t, "east", b.Tenant)
+	_, err = svc.CreateBook(east, model.CreateBookInput{Title: util.GetPtr("Dune"), ISBN: util.GetPtr("9780441013593")})
+	assert.ErrorIs(t, err, model.ErrConflict)
+	theirs, err := svc.CreateBook(west, model.CreateBookInput{Title: util.GetPtr("Dune"), ISBN: util.GetPtr("9780441013593"), Tags: []string{"sf"}})
+	require.NoError(t, err, "ISBNs are unique per tenant")
+
+	_, err = svc.GetBook(west, b.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	_, err = svc.PatchBook(west, b.ID, model.BookPatch{Title: util.GetPtr("Mine")})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	assert.ErrorIs(t, svc.DeleteBook(west, b.ID), model.ErrNotFound)
+	_, err = svc.GetBook(context.Background(), b.ID)
+	assert.NoError(t, err, "work outside requests sees every tenant")
+
+	page, err := svc.ListBooks(west, model.ListQuery{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, page.Total)
+	assert.Equal(t, theirs.ID, page.Data[0].ID)
+
+	rn, err := svc.RenameTag(west, "sf", "science-fiction")
+	require.NoError(t, err)
+	assert.Equal(t, 1, rn.BooksUpdated)
+	got, err := svc.GetBook(east, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sf"}, got.Tags, "renames stay in their tenant")
+
+	_, err = svc.CreateBook(east, model.CreateBookInput{Title: util.GetPtr("Emma")})
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteBook(east, b.ID))
+	_, err = svc.CreateBook(east, model.CreateBookInput{Title: util.GetPtr("Persuasion")})
+	assert.ErrorIs(t, err, model.ErrQuota, "books in the trash count")
+	_, err = svc.CreateBook(west, model.CreateBookInput{Title: util.GetPtr("Persuasion")})
+	assert.NoError(t, err, "tenants without a quota are not capped")
+}
+
+func TestTenants_Lending(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Loans = adapter.NewLoanRepo()
+	svc.Holds = adapter.NewHoldRepo()
+	svc.Borrowers = adapter.NewBorrowerRepo()
+	svc.Fees = adapter.NewFeeRepo()
+	svc.Fines = &model.FinePolicy{PerDay: model.Price{Amount: 25, Currency: "EUR"}}
+	svc.Audit = adapter.NewAuditRepo()
+	east := model.WithTenant(context.Background(), "east")
+	west := model.WithTenant(context.Background(), "west")
+
+	b, err := svc.CreateBook(east, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	ada, err := svc.CreateBorrower(east, model.Borrower{Name: "Ada", Email: "ada@example.org"})
+	require.NoError(t, err)
+	assert.Equal(t, "east", ada.Tenant)
+	_, err = svc.CreateBorrower(west, model.Borrower{Name: "Ada", Email: "ada@example.org"})
+	assert.NoError(t, err, "emails are unique per tenant")
+	_, err = svc.GetBorrower(west, ada.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	page, err := svc.ListBorrowers(west, model.BorrowerQuery{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, page.Total)
+	assert.NotEqual(t, ada.ID, page.Data[0].ID)
+
+	l, err := svc.CheckoutBook(east, b.ID, ada.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "east", l.Tenant)
+	_, err = svc.GetLoan(west, l.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	loans, err := svc.ListLoans(west, model.LoanQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, loans)
+	bob, err := svc.CreateBorrower(east, model.Borrower{Name: "Bob"})
+	require.NoError(t, err)
+	_, err = svc.PlaceHold(east, b.ID, bob.ID)
+	require.NoError(t, err)
+	_, err = svc.ListHolds(west, b.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	_, err = svc.ReturnLoan(west, l.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	_, err = svc.ReturnLoan(east, l.ID)
+	require.NoError(t, err)
+
+	// A loan of east that was due 3 days ago leaves a fine on Ada's ledger.
+	now := time.Now().UTC()
+	late, err := svc.Loans.Checkout(east, model.Loan{ID: "l-late", BookID: b.ID, Borrower: ada.ID, Tenant: "east", CheckedOutAt: now.AddDate(0, 0, -10), DueAt: now.Add(-72*time.Hour + time.Hour)}, 2)
+	require.NoError(t, err)
+	_, err = svc.ReturnLoan(east, late.ID)
+	require.NoError(t, err)
+	_, err = svc.RecordFee(west, model.FeeEntry{Borrower: ada.ID, Kind: model.FeePayment, Amount: model.Price{Amount: 25}})
+	assert.ErrorIs(t, err, model.ErrNotFound, "not a borrower of the tenant")
+	_, err = svc.RecordFee(east, model.FeeEntry{Borrower: ada.ID, Kind: model.FeePayment, Amount: model.Price{Amount: 25}})
+	require.NoError(t, err)
+	_, err = svc.FeeAccount(west, ada.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	acct, err := svc.FeeAccount(east, ada.ID)
+	require.NoError(t, err)
+	assert.Len(t, acct.Entries, 2)
+	assert.Equal(t, int64(50), acct.Balance.Amount)
+
+	entries, err := svc.AuditLog(west, model.AuditQuery{Limit: 100})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	entries, err = svc.AuditLog(east, model.AuditQuery{Limit: 100})
+	require.NoError(t, err)
+	assert.NotEmpty(t, entries)
+}
+
+func TestTenants_Catalog(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Authors = adapter.NewAuthorRepo()
+	svc.Branches = adapter.NewBranchRepo()
+	svc.Stocktakes = adapter.NewStocktakeRepo()
+	svc.Loans = adapter.NewLoanRepo()
+	svc.Escalations = adapter.NewEscalationRepo()
+	svc.Exclusions = adapter.NewDuplicateExclusionRepo()
+	east := model.WithTenant(context.Background(), "east")
+	west := model.WithTenant(context.Background(), "west")
+
+	a, err := svc.CreateAuthor(east, "Frank Herbert")
+	require.NoError(t, err)
+	_, err = svc.CreateAuthor(west, "Frank Herbert")
+	assert.NoError(t, err, "author names are unique per tenant")
+	_, err = svc.GetAuthor(west, a.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	authors, err := svc.ListAuthors(west, model.AuthorQuery{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, authors.Total)
+
+	_, err = svc.CreateBranch(east, model.Branch{ID: "main", Name: "East main"})
+	require.NoError(t, err)
+	_, err = svc.CreateBranch(west, model.Branch{ID: "main", Name: "West main"})
+	require.NoError(t, err, "branch IDs are unique per tenant")
+	br, err := svc.GetBranch(west, "main")
+	require.NoError(t, err)
+	assert.Equal(t, "West main", br.Name)
+	branches, err := svc.ListBranches(west)
+	require.NoError(t, err)
+	assert.Len(t, branches, 1)
+
+	st, err := svc.StartStocktake(east, "main")
+	require.NoError(t, err)
+	_, err = svc.GetStocktake(west, st.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	stocktakes, err := svc.ListStocktakes(west)
+	require.NoError(t, err)
+	assert.Empty(t, stocktakes)
+
+	_, err = svc.CreateEscalationPolicy(east, model.EscalationPolicy{Name: "remind", Action: model.EscalationRemind, AfterDays: 3})
+	require.NoError(t, err)
+	policies, err := svc.ListEscalationPolicies(west)
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+
+	b1, err := svc.CreateBook(east, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	b2, err := svc.CreateBook(east, model.CreateBookInput{Title: util.GetPtr("Dune.")})
+	require.NoError(t, err)
+	_, err = svc.ExcludeDuplicates(west, []string{b1.ID, b2.ID})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	_, err = svc.ExcludeDuplicates(east, []string{b1.ID, b2.ID})
+	require.NoError(t, err)
+	exclusions, err := svc.ListDuplicateExclusions(west)
+	require.NoError(t, err)
+	assert.Empty(t, exclusions)
+}
+
+func TestTenants_Readers(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Reviews = adapter.NewReviewRepo()
+	svc.Bookshelves = adapter.NewBookshelfRepo()
+	svc.Progress = adapter.NewProgressRepo()
+	svc.RecentViews = adapter.NewRecentViewRepo(10)
+	alice := model.WithActor(context.Background(), "jwt:alice")
+	east := model.WithTenant(alice, "east")
+	west := model.WithTenant(alice, "west")
+
+	b, err := svc.CreateBook(east, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	_, err = svc.AddReview(west, model.Review{BookID: b.ID, Rating: 5})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	r, err := svc.AddReview(east, model.Review{BookID: b.ID, Rating: 5})
+	require.NoError(t, err)
+	assert.Equal(t, "east", r.Tenant)
+	_, err = svc.GetReview(west, b.ID, r.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	_, err = svc.CreateBookshelf(east, "Favourites")
+	require.NoError(t, err)
+	shelves, err := svc.ListBookshelves(west)
+	require.NoError(t, err)
+	for _, sh := range shelves {
+		assert.NotEqual(t, "Favourites", sh.Name, "the same user has shelves per tenant")
+	}
+
+	_, err = svc.UpdateProgress(east, b.ID, nil, util.GetPtr(40))
+	require.NoError(t, err)
+	_, err = svc.ProgressHistory(west, b.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	_, err = svc.ViewBook(east, b.ID)
+	require.NoError(t, err)
+	viewed, err := svc.RecentlyViewed(west, 10)
+	require.NoError(t, err)
+	assert.Empty(t, viewed)
+	viewed, err = svc.RecentlyViewed(east, 10)
+	require.NoError(t, err)
+	assert.Len(t, viewed, 1)
+}
+
+func TestTenants_SearchAndEvents(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.Embedder = adapter.NewHashingEmbedder(0)
+	east := model.WithTenant(context.Background(), "east")
+	west := model.WithTenant(context.Background(), "west")
+	westEvents, cancel := svc.Subscribe(west, 8)
+	defer cancel()
+	allEvents, cancelAll := svc.Subscribe(context.Background(), 8)
+	defer cancelAll()
+
+	b, err := svc.CreateBook(east, model.CreateBookInput{Title: util.GetPtr("The Desert Garden")})
+	require.NoError(t, err)
+	hits, err := svc.SemanticSearch(west, "desert garden", 5)
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+	hits, err = svc.SemanticSearch(east, "desert garden", 5)
+	require.NoError(t, err)
+	require.NotEmpty(t, hits)
+	assert.Equal(t, b.ID, hits[0].Book.ID)
+
+	ev := <-allEvents
+	assert.Equal(t, b.ID, ev.BookID, "subscribers outside a tenant get every tenant's events")
+	select {
+	case ev := <-westEvents:
+		t.Fatalf("west got east's event %s", ev.Type)
+	default:
+	}
+
+	done := svc.StartImport(east)
+	defer done()
+	c, err := svc.CatalogCounters(west)
+	require.NoError(t, err)
+	assert.Zero(t, c.ActiveImports)
+	assert.Zero(t, c.TotalBooks)
+	c, err = svc.CatalogCounters(east)
+	require.NoError(t, err)
+	assert.Equal(t, 1, c.ActiveImports)
+}
+
+func TestTenants_QuotaUnderConcurrentCreates(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	svc.TenantQuotas = map[string]int{"east": 5}
+	east := model.WithTenant(context.Background(), "east")
+
+	var wg sync.WaitGroup
+	var created atomic.Int32
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.CreateBook(east, model.CreateBookInput{Title: util.GetPtr(fmt.Sprintf("Book %d", i))})
+			if err == nil {
+				created.Add(1)
+			} else {
+				assert.ErrorIs(t, err, model.ErrQuota)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 5, created.Load())
+	n, err := svc.Repo.Count(east, model.ListQuery{IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+}
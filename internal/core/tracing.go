@@ -0,0 +1,41 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer starts the service's spans under the globally installed provider,
+// a no-op until tracing is set up.
+var tracer = otel.Tracer("book-manager/internal/core")
+
+func bookID(id string) trace.SpanStartOption {
+	return trace.WithAttributes(attribute.String("book.id", id))
+}
+
+// endSpan records err on span, unless the book was just not there, and
+// ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, model.ErrNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// unwrapRepo returns the repository that decorators such as tracing wrap
+// around repo, so what it implements besides BookRepository is found.
+func unwrapRepo(repo BookRepository) BookRepository {
+	for {
+		u, ok := repo.(interface{ Unwrap() BookRepository })
+		if !ok {
+			return repo
+		}
+		repo = u.Unwrap()
+	}
+}
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+)
+
+// Translator translates texts into a target language, e.g. through DeepL or
+// LibreTranslate. It returns one translation per text, in order.
+type Translator interface {
+	Translate(ctx context.Context, texts []string, target string) ([]string, error)
+}
+
+// translate stores b's description and subjects in s.TranslateTo. It is
+// best effort: a failed translation keeps the previous one, and the next
+// enrichment of the book tries again.
+func (s *Service) translate(ctx context.Context, b *model.Book) {
+	if s.Translator == nil || s.TranslateTo == "" {
+		return
+	}
+	var texts []string
+	if b.Description != nil && *b.Description != "" {
+		texts = append(texts, *b.Description)
+	}
+	texts = append(texts, b.Subjects...)
+	if len(texts) == 0 {
+		return
+	}
+	out, err := s.Translator.Translate(ctx, texts, s.TranslateTo)
+	if err != nil || len(out) != len(texts) {
+		return
+	}
+	t := &model.Translation{Language: s.TranslateTo}
+	if b.Description != nil && *b.Description != "" {
+		t.Description = &out[0]
+		out = out[1:]
+	}
+	if len(out) > 0 {
+		t.Subjects = out
+	}
+	b.Translation = t
+}
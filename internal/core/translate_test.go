@@ -0,0 +1,80 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// describedEnrich answers every lookup with a description and subjects.
+type describedEnrich struct{}
+
+func (describedEnrich) FetchByISBN(ctx context.Context, isbn string) (model.EnrichedBook, error) {
+	return model.EnrichedBook{
+		Source:      "googlebooks",
+		Title:       util.GetPtr("Dune"),
+		Description: util.GetPtr("A desert planet."),
+		Subjects:    []string{"Fiction", "Science fiction"},
+	}, nil
+}
+
+// upperTranslator "translates" by upper-casing, or fails.
+type upperTranslator struct{ fail bool }
+
+func (f upperTranslator) Translate(ctx context.Context, texts []string, target string) ([]string, error) {
+	if f.fail {
+		return nil, errors.New("quota exceeded")
+	}
+	out := make([]string, len(texts))
+	for i, t := range texts {
+		out[i] = target + ":" + strings.ToUpper(t)
+	}
+	return out, nil
+}
+
+func TestTranslateEnrichedMetadata(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), describedEnrich{})
+	svc.Translator = upperTranslator{}
+	svc.TranslateTo = "de"
+
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{ISBN: util.GetPtr("9780441013593"), Enrich: true})
+	require.NoError(t, err)
+	assert.Equal(t, "A desert planet.", *b.Description)
+	assert.Equal(t, []string{"Fiction", "Science fiction"}, b.Subjects)
+	require.NotNil(t, b.Translation)
+	assert.Equal(t, "de", b.Translation.Language)
+	assert.Equal(t, "de:A DESERT PLANET.", *b.Translation.Description)
+	assert.Equal(t, []string{"de:FICTION", "de:SCIENCE FICTION"}, b.Translation.Subjects)
+
+	// a failed translation keeps the previous one
+	svc.Translator = upperTranslator{fail: true}
+	b, err = svc.EnrichBook(ctx, b.ID, true)
+	require.NoError(t, err)
+	require.NotNil(t, b.Translation)
+	assert.Equal(t, "de:A DESERT PLANET.", *b.Translation.Description)
+
+	// updates keep enrichment-only fields
+	b, err = svc.PatchBook(ctx, b.ID, model.BookPatch{Title: util.GetPtr("Dune (1965)")})
+	require.NoError(t, err)
+	assert.Equal(t, "A desert planet.", *b.Description)
+	assert.NotNil(t, b.Translation)
+}
+
+func TestTranslate_OffWithoutTargetLanguage(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), describedEnrich{})
+	svc.Translator = upperTranslator{}
+
+	b, err := svc.CreateBook(context.Background(), model.CreateBookInput{ISBN: util.GetPtr("9780441013593"), Enrich: true})
+	require.NoError(t, err)
+	assert.Nil(t, b.Translation)
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+	"fmt"
+	"time"
+)
+
+// TrashedISBNPolicy is what giving a book the ISBN of a book in the trash
+// does.
+type TrashedISBNPolicy string
+
+const (
+	// TrashedISBNConflict fails with a model.TrashedDuplicateError naming
+	// the trashed book, which has to be restored or purged first.
+	TrashedISBNConflict TrashedISBNPolicy = "conflict"
+	// TrashedISBNAllow lets the ISBN be reused; the trashed book can then
+	// only be restored once the ISBN is free again.
+	TrashedISBNAllow TrashedISBNPolicy = "allow"
+	// TrashedISBNRestore makes creating a book with the ISBN restore the
+	// trashed book and replace its fields with the new book's; other
+	// changes reuse the ISBN as with allow.
+	TrashedISBNRestore TrashedISBNPolicy = "restore"
+)
+
+// ParseTrashedISBNPolicy reads a policy by name.
+func ParseTrashedISBNPolicy(s string) (TrashedISBNPolicy, error) {
+	switch p := TrashedISBNPolicy(s); p {
+	case TrashedISBNConflict, TrashedISBNAllow, TrashedISBNRestore:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown trashed ISBN policy %q, want conflict, allow or restore", s)
+}
+
+// checkISBN checks that no other book of owner than the one with id has
+// isbn. A book in the trash with it is a conflict under
+// TrashedISBNConflict and returned otherwise.
+func (s *Service) checkISBN(ctx context.Context, owner, isbn, id string) (*model.Book, error) {
+	other, err := s.Repo.GetByISBN(ctx, owner, isbn)
+	switch {
+	case err != nil || other.ID == id:
+		return nil, nil
+	case other.DeletedAt == nil:
+		return nil, model.ErrConflict
+	case s.TrashedISBNs == "" || s.TrashedISBNs == TrashedISBNConflict:
+		return nil, &model.TrashedDuplicateError{ISBN: isbn, BookID: other.ID}
+	}
+	return &other, nil
+}
+
+// DeleteBook moves a book to the trash: it keeps its data, covers and ISBN
+// but is no longer listed or found until it is restored, and is gone for
+// good once purged.
+func (s *Service) DeleteBook(ctx context.Context, id string) (err error) {
+	ctx, span := tracer.Start(ctx, "Service.DeleteBook", bookID(id))
+	defer func() { endSpan(span, err) }()
+	b, err := s.getBook(ctx, id)
+	if err != nil {
+		return model.ErrNotFound
+	}
+	before := b
+	now := touch(before, &b)
+	b.DeletedAt = &now
+	ctx = s.stage(ctx, model.AuditDelete)
+	if b, err = s.Repo.Update(ctx, b); err != nil {
+		return err
+	}
+	s.vectors.remove(id)
+	s.events.publish(b.Tenant, model.Event{Type: model.EventBookDeleted, BookID: id, Book: b, At: now})
+	s.audit(ctx, model.AuditDelete, &before, &b)
+	return nil
+}
+
+// RestoreBook takes a book out of the trash.
+func (s *Service) RestoreBook(ctx context.Context, id string) (model.Book, error) {
+	b, err := s.Repo.GetByID(ctx, id)
+	if err != nil || !s.visible(ctx, b) {
+		return model.Book{}, model.ErrNotFound
+	}
+	if b.DeletedAt == nil {
+		return model.Book{}, fmt.Errorf("%w: book is not deleted", model.ErrConflict)
+	}
+	before := b
+	b.DeletedAt = nil
+	touch(before, &b)
+	ctx = s.stage(ctx, model.AuditRestore)
+	if b, err = s.Repo.Update(ctx, b); err != nil {
+		return model.Book{}, err
+	}
+	if b.Embedding != nil {
+		s.vectors.put(b.Embedding.Model, b.Tenant, b.ID, b.Embedding.Vector)
+	}
+	s.events.publish(b.Tenant, model.Event{Type: model.EventBookRestored, BookID: id, Book: b, At: b.UpdatedAt})
+	s.audit(ctx, model.AuditRestore, &before, &b)
+	return b, nil
+}
+
+// restoreWith restores the trashed book with id for CreateBook and gives it
+// the fields of b, the book that was to be created. A field b leaves empty
+// is cleared, as by UpdateBook, except a title enrichment could not fill.
+func (s *Service) restoreWith(ctx context.Context, id string, b model.Book) (model.Book, error) {
+	restored, err := s.RestoreBook(ctx, id)
+	if err != nil {
+		return model.Book{}, err
+	}
+	title := b.Title
+	if title == "" {
+		title = restored.Title
+	}
+	return s.replaceBook(ctx, restored, model.UpdateBookInput{
+		ISBN:          b.ISBN,
+		Title:         &title,
+		Subtitle:      b.Subtitle,
+		PublishedYear: b.PublishedYear,
+		PageCount:     b.PageCount,
+		CoverURL:      b.CoverURL,
+		Tags:          b.Tags,
+		Authors:       b.Authors,
+		Forthcoming:   b.Forthcoming,
+		ReleaseDate:   b.ReleaseDate,
+		PriceTarget:   b.PriceTarget,
+	})
+}
+
+// PurgeBooks permanently removes the books deleted before the given time,
+// or every book in the trash when before is zero, and returns how many.
+func (s *Service) PurgeBooks(ctx context.Context, before time.Time) (int, error) {
+	var trashed []model.Book
+	err := s.WalkBooks(ctx, model.ListQuery{IncludeDeleted: true}, func(b model.Book) error {
+		if b.DeletedAt != nil && (before.IsZero() || b.DeletedAt.Before(before)) {
+			trashed = append(trashed, b)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	for _, b := range trashed {
+		wctx := s.stage(ctx, model.AuditPurge)
+		if err := s.Repo.Delete(wctx, b.ID); err != nil {
+			continue // purged meanwhile
+		}
+		s.deleteCovers(ctx, b.ID)
+		purged++
+		s.audit(wctx, model.AuditPurge, &b, nil)
+	}
+	return purged, nil
+}
@@ -0,0 +1,152 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrash(t *testing.T) {
+	ctx := context.Background()
+	repo := adapter.NewBookRepo()
+	svc := NewService(repo, mockEnrich{hit: false})
+	isbn := "9780000000002"
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{ISBN: util.GetPtr(isbn), Title: util.GetPtr("Kept")})
+	require.NoError(t, err)
+	other, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Other")})
+	require.NoError(t, err)
+
+	_, err = svc.RestoreBook(ctx, b.ID)
+	assert.ErrorIs(t, err, model.ErrConflict, "not in the trash")
+
+	require.NoError(t, svc.DeleteBook(ctx, b.ID))
+	assert.ErrorIs(t, svc.DeleteBook(ctx, b.ID), model.ErrNotFound, "already in the trash")
+	_, err = svc.GetBook(ctx, b.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	page, err := svc.ListBooks(ctx, model.ListQuery{})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, other.ID, page.Data[0].ID)
+	page, err = svc.ListBooks(ctx, model.ListQuery{IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.Len(t, page.Data, 2)
+
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{ISBN: util.GetPtr(isbn), Title: util.GetPtr("Again")})
+	var dup *model.TrashedDuplicateError
+	require.ErrorAs(t, err, &dup, "a trashed book keeps its ISBN")
+	assert.ErrorIs(t, err, model.ErrConflict)
+	assert.Equal(t, b.ID, dup.BookID)
+
+	got, err := svc.RestoreBook(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.DeletedAt)
+	_, err = svc.GetBook(ctx, b.ID)
+	require.NoError(t, err)
+
+	// purge only takes books deleted before the cut-off, and only trashed ones
+	require.NoError(t, svc.DeleteBook(ctx, b.ID))
+	n, err := svc.PurgeBooks(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	n, err = svc.PurgeBooks(ctx, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	_, err = svc.RestoreBook(ctx, b.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	_, err = svc.GetBook(ctx, other.ID)
+	require.NoError(t, err)
+
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{ISBN: util.GetPtr(isbn), Title: util.GetPtr("Again")})
+	assert.NoError(t, err, "purging frees the ISBN")
+}
+
+func TestTrash_ISBNPolicies(t *testing.T) {
+	ctx := context.Background()
+	isbn := "9780000000002"
+	setup := func(policy TrashedISBNPolicy) (*Service, model.Book) {
+		svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+		svc.TrashedISBNs = policy
+		b, err := svc.CreateBook(ctx, model.CreateBookInput{ISBN: util.GetPtr(isbn), Title: util.GetPtr("Kept")})
+		require.NoError(t, err)
+		require.NoError(t, svc.DeleteBook(ctx, b.ID))
+		return svc, b
+	}
+
+	t.Run("allow", func(t *testing.T) {
+		svc, b := setup(TrashedISBNAllow)
+		again, err := svc.CreateBook(ctx, model.CreateBookInput{ISBN: util.GetPtr(isbn), Title: util.GetPtr("Again")})
+		require.NoError(t, err)
+		assert.NotEqual(t, b.ID, again.ID)
+		// the trashed book waits for its ISBN to be free again
+		_, err = svc.RestoreBook(ctx, b.ID)
+		assert.ErrorIs(t, err, model.ErrConflict)
+		require.NoError(t, svc.DeleteBook(ctx, again.ID))
+		_, err = svc.RestoreBook(ctx, b.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		svc, b := setup(TrashedISBNRestore)
+		got, err := svc.CreateBook(ctx, model.CreateBookInput{ISBN: util.GetPtr(isbn), Title: util.GetPtr("Again"), Tags: []string{"sf"}})
+		require.NoError(t, err)
+		assert.Equal(t, b.ID, got.ID)
+		assert.Equal(t, "Again", got.Title, "the new input is applied")
+		assert.Equal(t, []string{"sf"}, got.Tags)
+		assert.Nil(t, got.DeletedAt)
+		stored, err := svc.GetBook(ctx, b.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Again", stored.Title)
+		// other books may take the ISBN of a trashed one
+		require.NoError(t, svc.DeleteBook(ctx, b.ID))
+		other, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Other")})
+		require.NoError(t, err)
+		_, err = svc.PatchBook(ctx, other.ID, model.BookPatch{ISBN: util.GetPtr(isbn)})
+		assert.NoError(t, err)
+	})
+
+	_, err := ParseTrashedISBNPolicy("keep")
+	assert.Error(t, err)
+}
+
+func TestTrash_ExcludedEverywhere(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	gone, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Gone"), Tags: []string{"lost/found"}, Authors: []string{"Ghost Writer"}})
+	require.NoError(t, err)
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Kept"), Tags: []string{"kept"}})
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteBook(ctx, gone.ID))
+
+	tags, err := svc.ListTags(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.TagCount{{Tag: "kept", Books: 1}}, tags)
+	tree, err := svc.TagTree(ctx)
+	require.NoError(t, err)
+	require.Len(t, tree, 1)
+	assert.Equal(t, "kept", tree[0].Tag)
+
+	c, err := svc.CatalogCounters(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, c.TotalBooks)
+	assert.Equal(t, 1, c.AddedToday)
+
+	var titles []string
+	collect := func(b model.Book) error { titles = append(titles, b.Title); return nil }
+	require.NoError(t, svc.ExportBooks(ctx, model.ListQuery{}, collect))
+	assert.Equal(t, []string{"Kept"}, titles)
+	titles = nil
+	require.NoError(t, svc.ExportBooks(ctx, model.ListQuery{IncludeDeleted: true}, collect))
+	assert.Equal(t, []string{"Gone", "Kept"}, titles, "only when asked for")
+
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("New"), Authors: []string{"Ghost Writr"}})
+	require.NoError(t, err)
+	assert.Empty(t, b.Suggestions, "trashed books suggest no spellings")
+}
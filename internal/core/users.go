@@ -0,0 +1,32 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"context"
+)
+
+// owner is the owner of the books created for ctx's user: the user when
+// books are kept per user, otherwise no one.
+func (s *Service) owner(ctx context.Context) string {
+	if !s.PerUser {
+		return ""
+	}
+	u, _ := model.UserFromContext(ctx)
+	return u.ID
+}
+
+// ownerFilter limits listings to the books of ctx's user; it is nil when
+// the user may see every book, as admins and work outside requests do.
+func (s *Service) ownerFilter(ctx context.Context) *string {
+	u, ok := model.UserFromContext(ctx)
+	if !s.PerUser || !ok || u.Admin {
+		return nil
+	}
+	return &u.ID
+}
+
+// visible reports whether ctx's user may see b.
+func (s *Service) visible(ctx context.Context, b model.Book) bool {
+	owner := s.ownerFilter(ctx)
+	return owner == nil || b.Owner == *owner
+}
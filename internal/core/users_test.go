@@ -0,0 +1,66 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerUserBooks(t *testing.T) {
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	ada := model.WithUser(context.Background(), model.User{ID: "jwt:ada"})
+	bob := model.WithUser(context.Background(), model.User{ID: "jwt:bob"})
+	admin := model.WithUser(context.Background(), model.User{ID: "jwt:root", Admin: true})
+
+	shared, err := svc.CreateBook(ada, model.CreateBookInput{Title: util.GetPtr("Emma")})
+	require.NoError(t, err)
+	assert.Empty(t, shared.Owner, "books are shared unless kept per user")
+
+	svc.PerUser = true
+	b, err := svc.CreateBook(ada, model.CreateBookInput{Title: util.GetPtr("Dune"), ISBN: util.GetPtr("9780441013593")})
+	require.NoError(t, err)
+	assert.Equal(t, "jwt:ada", b.Owner)
+	_, err = svc.CreateBook(ada, model.CreateBookInput{Title: util.GetPtr("Dune"), ISBN: util.GetPtr("9780441013593")})
+	assert.ErrorIs(t, err, model.ErrConflict)
+	theirs, err := svc.CreateBook(bob, model.CreateBookInput{Title: util.GetPtr("Dune"), ISBN: util.GetPtr("9780441013593")})
+	require.NoError(t, err, "ISBNs are unique per user")
+
+	_, err = svc.GetBook(bob, b.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	_, err = svc.PatchBook(bob, b.ID, model.BookPatch{Title: util.GetPtr("Mine")})
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	assert.ErrorIs(t, svc.DeleteBook(bob, b.ID), model.ErrNotFound)
+	_, err = svc.GetBook(admin, b.ID)
+	assert.NoError(t, err)
+	_, err = svc.GetBook(context.Background(), b.ID)
+	assert.NoError(t, err, "work outside requests sees every book")
+
+	page, err := svc.ListBooks(ada, model.ListQuery{Page: 1, PageSize: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+	assert.Empty(t, page.NextCursor)
+	page, err = svc.ListBooks(admin, model.ListQuery{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 3, page.Total, "admins see every book, the unowned too")
+
+	page, err = svc.ListBooks(admin, model.ListQuery{Page: 1, PageSize: 1})
+	require.NoError(t, err)
+	page, err = svc.ListBooks(bob, model.ListQuery{Cursor: page.NextCursor, PageSize: 10})
+	require.NoError(t, err, "a cursor does not carry the user")
+	for _, got := range page.Data {
+		assert.Equal(t, theirs.ID, got.ID)
+	}
+
+	require.NoError(t, svc.DeleteBook(ada, b.ID))
+	_, err = svc.RestoreBook(bob, b.ID)
+	assert.ErrorIs(t, err, model.ErrNotFound)
+	_, err = svc.RestoreBook(ada, b.ID)
+	assert.NoError(t, err)
+}
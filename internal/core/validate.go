@@ -0,0 +1,96 @@
+package core
+
+import (
+	"book-manager/internal/core/model"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	maxTitleLen = 500 // characters
+	maxBookTags = 50
+)
+
+// validator collects the field errors of a request so that all of them
+// are reported at once instead of the first.
+type validator struct {
+	errs model.ValidationErrors
+}
+
+// check records reason for field unless ok.
+func (v *validator) check(ok bool, field, reason string) {
+	if !ok {
+		v.errs = append(v.errs, &model.FieldError{Field: field, Reason: reason})
+	}
+}
+
+// add records err, the result of a check that fails with a
+// *model.FieldError or model.ValidationErrors; it reports whether err was
+// nil.
+func (v *validator) add(err error) bool {
+	if err == nil {
+		return true
+	}
+	var ve model.ValidationErrors
+	if errors.As(err, &ve) {
+		v.errs = append(v.errs, ve...)
+		return false
+	}
+	var fe *model.FieldError
+	if !errors.As(err, &fe) {
+		fe = &model.FieldError{Field: "", Reason: err.Error()}
+	}
+	v.errs = append(v.errs, fe)
+	return false
+}
+
+// err returns the collected errors as model.ValidationErrors, or nil.
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// bookFields are the fields of a created or replaced book that are
+// checked together. ISBN, PriceTarget and Tags are normalized in place;
+// KeepTags are tags the book already had, see tagPaths.
+type bookFields struct {
+	Title         *string
+	TitleRequired bool
+	ISBN          **string
+	PageCount     *int
+	PublishedYear *int
+	PriceTarget   *model.Price
+	Tags          *[]string
+	KeepTags      []string
+}
+
+// validateBook checks every field of f and reports all failures as
+// model.ValidationErrors.
+func validateBook(f bookFields) error {
+	var v validator
+	if f.Title == nil || *f.Title == "" {
+		v.check(!f.TitleRequired, "title", "must not be empty")
+	} else {
+		v.check(utf8.RuneCountInString(*f.Title) <= maxTitleLen, "title", fmt.Sprintf("must be at most %d characters", maxTitleLen))
+	}
+	v.add(normalizeISBNPtr(f.ISBN))
+	v.add(validateNumbers(f.PageCount, f.PublishedYear))
+	v.add(normalizePrice(f.PriceTarget))
+	if tags, err := tagPaths(*f.Tags, f.KeepTags); v.add(err) {
+		*f.Tags = tags
+		v.check(len(tags) <= maxBookTags, "tags", fmt.Sprintf("must be at most %d tags", maxBookTags))
+	}
+	return v.err()
+}
+
+// validateNumbers checks a page count and publication year; either may be
+// nil.
+func validateNumbers(pageCount, year *int) error {
+	var v validator
+	v.check(pageCount == nil || *pageCount >= 1, "page_count", "must be at least 1")
+	v.check(year == nil || (*year >= 1450 && *year <= 3000), "published_year", "must be between 1450 and 3000")
+	return v.err()
+}
@@ -0,0 +1,52 @@
+//go:build unit
+
+package core
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/util"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBook_ReportsEveryField(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(adapter.NewBookRepo(), mockEnrich{hit: false})
+	tags := make([]string, maxBookTags+1)
+	for i := range tags {
+		tags[i] = fmt.Sprintf("t%d", i)
+	}
+	_, err := svc.CreateBook(ctx, model.CreateBookInput{
+		Title:         util.GetPtr(strings.Repeat("x", maxTitleLen+1)),
+		ISBN:          util.GetPtr("123"),
+		PublishedYear: util.GetPtr(1200),
+		PageCount:     util.GetPtr(0),
+		Tags:          tags,
+	})
+	require.ErrorIs(t, err, model.ErrValidation)
+	var ve model.ValidationErrors
+	require.True(t, errors.As(err, &ve))
+	fields := make([]string, len(ve))
+	for i, fe := range ve {
+		fields[i] = fe.Field
+	}
+	assert.Equal(t, []string{"title", "isbn", "page_count", "published_year", "tags"}, fields)
+	var fe *model.FieldError
+	require.True(t, errors.As(err, &fe))
+	assert.Equal(t, "title", fe.Field, "the first failure")
+
+	b, err := svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr(strings.Repeat("é", maxTitleLen)), Tags: tags[:maxBookTags]})
+	require.NoError(t, err, "limits are inclusive and count characters")
+
+	_, err = svc.UpdateBook(ctx, b.ID, model.UpdateBookInput{Title: util.GetPtr(""), PageCount: util.GetPtr(-1)})
+	require.True(t, errors.As(err, &ve))
+	assert.Len(t, ve, 2)
+	assert.Equal(t, "validation: title: must not be empty; page_count: must be at least 1", err.Error())
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+const (
+	// lshTables hash tables of lshBits random hyperplanes each; more tables
+	// raise recall, more bits make buckets smaller.
+	lshTables = 8
+	lshBits   = 12
+	// below this many vectors a query scores every one, which is exact and
+	// still fast
+	exactSearchBelow = 2000
+)
+
+// vectorIndex finds approximate nearest neighbours of unit vectors by
+// random-hyperplane locality-sensitive hashing: vectors at a small angle
+// mostly fall on the same side of a random hyperplane, so they share
+// buckets. Queries probe the query's bucket and the buckets one bit away in
+// every table and score the candidates exactly. Every vector is kept with
+// its book's tenant, and a query of a tenant only finds that tenant's
+// books. The zero value is empty and ready to use; it holds vectors of one
+// model and resets when another one is put.
+type vectorIndex struct {
+	mu      sync.RWMutex
+	model   string
+	dims    int
+	planes  [][]float32 // lshTables*lshBits hyperplane normals
+	vecs    map[string][]float32
+	tenants map[string]string // by id
+	sigs    map[string][lshTables]uint32
+	tables  [lshTables]map[uint32]map[string]struct{}
+}
+
+// put adds or replaces the vector of a book of tenant.
+func (x *vectorIndex) put(model, tenant, id string, v []float32) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if model != x.model || len(v) != x.dims {
+		x.reset(model, len(v))
+	}
+	x.removeLocked(id)
+	sig := x.signature(v)
+	for t, h := range sig {
+		bucket := x.tables[t][h]
+		if bucket == nil {
+			bucket = make(map[string]struct{})
+			x.tables[t][h] = bucket
+		}
+		bucket[id] = struct{}{}
+	}
+	x.vecs[id] = v
+	x.tenants[id] = tenant
+	x.sigs[id] = sig
+}
+
+func (x *vectorIndex) remove(id string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.removeLocked(id)
+}
+
+// nearest returns up to k ids of tenant closest to v, best first, leaving
+// out exclude; tenant "" searches every tenant. Ids are only as current as
+// the last put; callers re-read them.
+func (x *vectorIndex) nearest(model, tenant string, v []float32, k int, exclude string) []string {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	if model != x.model || len(v) != x.dims || len(x.vecs) == 0 {
+		return nil
+	}
+	var candidates map[string]struct{}
+	if len(x.vecs) < exactSearchBelow {
+		candidates = make(map[string]struct{}, len(x.vecs))
+		for id := range x.vecs {
+			candidates[id] = struct{}{}
+		}
+	} else {
+		candidates = make(map[string]struct{})
+		for t, h := range x.signature(v) {
+			for _, probe := range probes(h) {
+				for id := range x.tables[t][probe] {
+					candidates[id] = struct{}{}
+				}
+			}
+		}
+	}
+	delete(candidates, exclude)
+	if tenant != "" {
+		for id := range candidates {
+			if x.tenants[id] != tenant {
+				delete(candidates, id)
+			}
+		}
+	}
+
+	type hit struct {
+		id    string
+		score float64
+	}
+	hits := make([]hit, 0, len(candidates))
+	for id := range candidates {
+		hits = append(hits, hit{id, dot(v, x.vecs[id])})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].id < hits[j].id
+	})
+	out := make([]string, 0, min(k, len(hits)))
+	for _, h := range hits[:min(k, len(hits))] {
+		out = append(out, h.id)
+	}
+	return out
+}
+
+// reset empties the index for vectors of another model. Callers hold mu.
+func (x *vectorIndex) reset(model string, dims int) {
+	x.model, x.dims = model, dims
+	x.vecs = make(map[string][]float32)
+	x.tenants = make(map[string]string)
+	x.sigs = make(map[string][lshTables]uint32)
+	for t := range x.tables {
+		x.tables[t] = make(map[uint32]map[string]struct{})
+	}
+	// a fixed seed keeps bucket layout, and so results, reproducible
+	rnd := rand.New(rand.NewSource(1))
+	x.planes = make([][]float32, lshTables*lshBits)
+	for i := range x.planes {
+		p := make([]float32, dims)
+		for j := range p {
+			p[j] = float32(rnd.NormFloat64())
+		}
+		x.planes[i] = p
+	}
+}
+
+// removeLocked drops id from the index. Callers hold mu.
+func (x *vectorIndex) removeLocked(id string) {
+	sig, ok := x.sigs[id]
+	if !ok {
+		return
+	}
+	for t, h := range sig {
+		delete(x.tables[t][h], id)
+		if len(x.tables[t][h]) == 0 {
+			delete(x.tables[t], h)
+		}
+	}
+	delete(x.sigs, id)
+	delete(x.vecs, id)
+	delete(x.tenants, id)
+}
+
+// signature has one bit per hyperplane, set when v is on its positive side.
+func (x *vectorIndex) signature(v []float32) [lshTables]uint32 {
+	var sig [lshTables]uint32
+	for t := range sig {
+		for b := 0; b < lshBits; b++ {
+			if dot(v, x.planes[t*lshBits+b]) >= 0 {
+				sig[t] |= 1 << b
+			}
+		}
+	}
+	return sig
+}
+
+// probes is h and every bucket one bit away from it.
+func probes(h uint32) []uint32 {
+	out := make([]uint32, 0, lshBits+1)
+	out = append(out, h)
+	for b := 0; b < lshBits; b++ {
+		out = append(out, h^(1<<b))
+	}
+	return out
+}
@@ -0,0 +1,65 @@
+//go:build unit
+
+package core
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectorIndex_ApproximateNeighbours(t *testing.T) {
+	rnd := rand.New(rand.NewSource(7))
+	random := func() []float32 {
+		v := make([]float32, 64)
+		for i := range v {
+			v[i] = float32(rnd.NormFloat64())
+		}
+		return normalize(v)
+	}
+	var x vectorIndex
+	n := exactSearchBelow * 2 // large enough to search by hashing
+	for i := 0; i < n; i++ {
+		x.put("m", "", fmt.Sprint(i), random())
+	}
+
+	// near-duplicates of indexed vectors are found as their neighbour
+	found := 0
+	for i := 0; i < 50; i++ {
+		id := fmt.Sprint(rnd.Intn(n))
+		q := make([]float32, 64)
+		copy(q, x.vecs[id])
+		for j := range q {
+			q[j] += float32(rnd.NormFloat64()) * 0.03
+		}
+		if got := x.nearest("m", "", normalize(q), 1, ""); len(got) == 1 && got[0] == id {
+			found++
+		}
+	}
+	assert.GreaterOrEqual(t, found, 45, "recall of close neighbours")
+
+	x.remove("0")
+	assert.NotContains(t, x.nearest("m", "", x.vecs["1"], n, ""), "0")
+	assert.NotContains(t, x.nearest("m", "", x.vecs["1"], 5, "1"), "1", "excluded id")
+	assert.Nil(t, x.nearest("other", "", x.vecs["1"], 5, ""), "vectors of another model")
+
+	x.put("other", "", "a", random())
+	require.Len(t, x.vecs, 1, "another model resets the index")
+}
+
+func TestVectorIndex_Tenants(t *testing.T) {
+	var x vectorIndex
+	x.put("m", "a", "a1", normalize([]float32{1, 0}))
+	x.put("m", "b", "b1", normalize([]float32{1, 0.1}))
+	x.put("m", "a", "a2", normalize([]float32{0, 1}))
+
+	assert.Equal(t, []string{"a1", "a2"}, x.nearest("m", "a", []float32{1, 0}, 5, ""))
+	assert.Equal(t, []string{"b1"}, x.nearest("m", "b", []float32{1, 0}, 5, ""))
+	assert.Equal(t, []string{"a1", "b1", "a2"}, x.nearest("m", "", []float32{1, 0}, 5, ""), "outside a tenant")
+
+	x.remove("b1")
+	assert.Empty(t, x.nearest("m", "b", []float32{1, 0}, 5, ""))
+}
@@ -0,0 +1,159 @@
+// Package ratelimit limits how fast each client may call the API, with a
+// token bucket per API key, token subject or client IP.
+package ratelimit
+
+import (
+	"book-manager/internal/auth"
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limiter gives every client a bucket of burst tokens that refills at rate
+// tokens per second; each request takes one, and a request finding the
+// bucket empty is answered with 429 Too Many Requests. Responses carry
+// X-RateLimit-Limit (the burst), X-RateLimit-Remaining and X-RateLimit-Reset
+// (seconds until the bucket is full again); 429s also carry Retry-After.
+// A rate of 0 lets every request through.
+//
+// The limiter runs before authentication, so that requests failing it are
+// limited too: Identify names the caller of a request with valid
+// credentials, and every other request counts against its client's IP.
+type Limiter struct {
+	// Identify returns the caller whose credentials a request carries;
+	// nil takes the caller an earlier middleware put in the context.
+	Identify func(r *http.Request) (auth.Principal, bool)
+	// WriteError answers a limited request; nil writes a plain JSON error.
+	WriteError func(w http.ResponseWriter, r *http.Request, status int, code, msg string)
+
+	mu        sync.Mutex
+	rate      float64 // tokens per second
+	burst     int
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	at     time.Time
+}
+
+func New(rate float64, burst int) *Limiter {
+	l := &Limiter{buckets: make(map[string]*bucket), now: time.Now}
+	l.SetLimit(rate, burst)
+	return l
+}
+
+// SetLimit changes the rate and burst, e.g. on a config reload. A burst
+// below 1 is one second's worth of requests. Buckets keep their tokens, up
+// to the new burst.
+func (l *Limiter) SetLimit(rate float64, burst int) {
+	if burst < 1 {
+		burst = max(1, int(math.Ceil(rate)))
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate, l.burst = max(rate, 0), burst
+}
+
+// Limit returns the rate and burst in effect.
+func (l *Limiter) Limit() (float64, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rate, l.burst
+}
+
+// sweepEvery is how often buckets that refilled completely are dropped;
+// a full bucket is the same as none.
+const sweepEvery = time.Minute
+
+// take takes a token from the client's bucket. It returns whether there
+// was one, the burst, the tokens left, the time until the bucket is full
+// and the time until the next token.
+func (l *Limiter) take(client string) (allowed bool, burst int, left float64, reset, next time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if now.Sub(l.lastSweep) >= sweepEvery {
+		for k, b := range l.buckets {
+			if l.refill(b, now) >= float64(l.burst) {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), at: now}
+		l.buckets[client] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.at = now
+	allowed = b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return allowed, l.burst, b.tokens, l.secondsFor(float64(l.burst) - b.tokens), l.secondsFor(1 - b.tokens)
+}
+
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	return math.Min(float64(l.burst), b.tokens+now.Sub(b.at).Seconds()*l.rate)
+}
+
+func (l *Limiter) secondsFor(tokens float64) time.Duration {
+	if tokens <= 0 || l.rate == 0 {
+		return 0
+	}
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rate, _ := l.Limit(); rate == 0 || r.URL.Path == "/healthz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		allowed, burst, left, reset, wait := l.take(l.clientKey(r))
+		h := w.Header()
+		h.Set("X-RateLimit-Limit", strconv.Itoa(burst))
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(int(left)))
+		h.Set("X-RateLimit-Reset", strconv.Itoa(int(math.Ceil(reset.Seconds()))))
+		if !allowed {
+			h.Set("Retry-After", strconv.Itoa(max(1, int(math.Ceil(wait.Seconds())))))
+			l.writeError(w, r, "rate limit exceeded, retry later")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (l *Limiter) writeError(w http.ResponseWriter, r *http.Request, msg string) {
+	if l.WriteError != nil {
+		l.WriteError(w, r, http.StatusTooManyRequests, "RATE_LIMITED", msg)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]string{"code": "RATE_LIMITED", "message": msg}})
+}
+
+// clientKey is the authenticated caller, or else the client's IP.
+func (l *Limiter) clientKey(r *http.Request) string {
+	p, ok := auth.FromContext(r.Context())
+	if l.Identify != nil {
+		p, ok = l.Identify(r)
+	}
+	if ok {
+		return p.Method + ":" + p.ID
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
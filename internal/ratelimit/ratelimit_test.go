@@ -0,0 +1,129 @@
+//go:build unit
+
+package ratelimit
+
+import (
+	"book-manager/internal/auth"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := New(2, 3)
+	l.now = func() time.Time { return now }
+	h := l.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	do := func(ip string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/books", nil)
+		r.RemoteAddr = ip + ":1234"
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	for i := 2; i >= 0; i-- {
+		w := do("10.0.0.1")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "3", w.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, strconv.Itoa(i), w.Header().Get("X-RateLimit-Remaining"))
+	}
+	w := do("10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.Equal(t, "2", w.Header().Get("X-RateLimit-Reset"), "3 tokens at 2/s")
+	assert.JSONEq(t, `{"error":{"code":"RATE_LIMITED","message":"rate limit exceeded, retry later"}}`, w.Body.String())
+
+	// other clients have their own bucket
+	assert.Equal(t, http.StatusOK, do("10.0.0.2").Code)
+
+	now = now.Add(500 * time.Millisecond)
+	assert.Equal(t, http.StatusOK, do("10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, do("10.0.0.1").Code)
+
+	// full buckets are dropped
+	now = now.Add(time.Hour)
+	do("10.0.0.3")
+	assert.Len(t, l.buckets, 1)
+}
+
+func TestLimiter_HealthIsNotLimited(t *testing.T) {
+	l := New(1, 1)
+	h := l.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
+
+func TestLimiter_KeysByCaller(t *testing.T) {
+	l := New(1, 1)
+	h := l.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	do := func(caller string) int {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/books", nil)
+		r = r.WithContext(auth.NewContext(r.Context(), auth.Principal{ID: caller, Method: "api-key"}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+	// same IP, different keys
+	assert.Equal(t, http.StatusOK, do("ci"))
+	assert.Equal(t, http.StatusOK, do("admin"))
+	assert.Equal(t, http.StatusTooManyRequests, do("ci"))
+}
+
+func TestLimiter_SetLimit(t *testing.T) {
+	l := New(0, 0)
+	h := l.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	do := func() *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/books", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+	// a rate of 0 limits nothing
+	for i := 0; i < 3; i++ {
+		w := do()
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("X-RateLimit-Limit"))
+	}
+
+	l.SetLimit(1, 2)
+	assert.Equal(t, http.StatusOK, do().Code)
+	assert.Equal(t, "2", do().Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, http.StatusTooManyRequests, do().Code)
+
+	l.SetLimit(0, 0)
+	assert.Equal(t, http.StatusOK, do().Code)
+}
+
+func TestLimiter_LimitsUnauthenticated(t *testing.T) {
+	authn := auth.NewAuthenticator(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	authn.SetKeys(map[string]string{"ci": "s3cret"}, false)
+	l := New(1, 3)
+	l.Identify = authn.Identify
+	h := l.Middleware(authn.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})))
+	do := func(key string) int {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/books", nil)
+		r.RemoteAddr = "10.0.0.1:1234"
+		if key != "" {
+			r.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// guessing keys uses up the IP's bucket
+	codes := []int{do("guess-1"), do("guess-2"), do(""), do("guess-3")}
+	assert.Equal(t, []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests}, codes)
+	// a valid key from the same IP has a bucket of its own
+	assert.Equal(t, http.StatusOK, do("s3cret"))
+}
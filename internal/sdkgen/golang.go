@@ -0,0 +1,421 @@
+package sdkgen
+
+import (
+	"book-manager/internal/apispec"
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"strings"
+)
+
+// Go writes a Go client package named pkg for the API d describes: a type
+// per schema and a Client method per operation. It needs nothing but the
+// standard library.
+func Go(d *apispec.Document, pkg string) ([]byte, error) {
+	ops, err := operations(d)
+	if err != nil {
+		return nil, err
+	}
+	g := &goGen{doc: d, declared: map[string]bool{}}
+	for _, name := range d.SchemaNames() {
+		g.declared[exportedName(name)] = true
+	}
+	for _, name := range d.SchemaNames() {
+		if err := g.typeDecl(exportedName(name), d.Components.Schemas[name]); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+	var methods bytes.Buffer
+	for _, op := range ops {
+		if err := g.method(&methods, op); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", op.Method, op.Path, err)
+		}
+	}
+	for len(g.pending) > 0 {
+		p := g.pending[0]
+		g.pending = g.pending[1:]
+		if err := g.typeDecl(p.name, p.schema); err != nil {
+			return nil, fmt.Errorf("type %s: %w", p.name, err)
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by sdkgen from the %s OpenAPI document, version %s. DO NOT EDIT.\n\n", d.Info.Title, d.Info.Version)
+	fmt.Fprintf(&out, "// Package %s is a client for the %s.\npackage %s\n\n", pkg, d.Info.Title, pkg)
+	out.WriteString("import (\n\t\"bytes\"\n\t\"context\"\n\t\"encoding/json\"\n\t\"fmt\"\n\t\"io\"\n\t\"net/http\"\n\t\"net/url\"\n")
+	if g.usesStrconv {
+		out.WriteString("\t\"strconv\"\n")
+	}
+	out.WriteString("\t\"strings\"\n")
+	if g.usesTime {
+		out.WriteString("\t\"time\"\n")
+	}
+	out.WriteString(")\n")
+	out.WriteString(goClient)
+	out.Write(methods.Bytes())
+	out.Write(g.types.Bytes())
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated Go does not parse: %w", err)
+	}
+	return src, nil
+}
+
+type goGen struct {
+	doc         *apispec.Document
+	types       bytes.Buffer
+	declared    map[string]bool // type names taken
+	pending     []pendingType   // inline schemas still to declare
+	usesTime    bool
+	usesStrconv bool
+}
+
+type pendingType struct {
+	name   string
+	schema *apispec.Schema
+}
+
+// named declares the inline schema s as the type name, later.
+func (g *goGen) named(name string, s *apispec.Schema) (string, error) {
+	if g.declared[name] {
+		return "", fmt.Errorf("inline type %s clashes with another type", name)
+	}
+	g.declared[name] = true
+	g.pending = append(g.pending, pendingType{name, s})
+	return name, nil
+}
+
+// typeDecl declares the type name for s.
+func (g *goGen) typeDecl(name string, s *apispec.Schema) error {
+	w := &g.types
+	writeComment(w, "", name, s.Description)
+	switch {
+	case len(s.Enum) > 0:
+		fmt.Fprintf(w, "type %s string\n\n// Values of %s.\nconst (\n", name, name)
+		for _, v := range s.Enum {
+			fmt.Fprintf(w, "\t%s%s %s = %q\n", name, exportedName(v), name, v)
+		}
+		w.WriteString(")\n\n")
+	case s.Type == "object" && len(s.Properties) > 0:
+		fmt.Fprintf(w, "type %s struct {\n", name)
+		props := make([]string, 0, len(s.Properties))
+		for p := range s.Properties {
+			props = append(props, p)
+		}
+		slices.Sort(props)
+		for _, p := range props {
+			ps := s.Properties[p]
+			t, err := g.goType(ps, name+exportedName(p))
+			if err != nil {
+				return fmt.Errorf("property %s: %w", p, err)
+			}
+			required := s.IsRequired(p)
+			tag := p
+			if !required {
+				tag += ",omitempty"
+			}
+			if (!required || ps.Nullable) && !isReferenceType(t) {
+				t = "*" + t
+			}
+			writeComment(w, "\t", exportedName(p), ps.Description)
+			fmt.Fprintf(w, "\t%s %s `json:%q`\n", exportedName(p), t, tag)
+		}
+		w.WriteString("}\n\n")
+	default:
+		t, err := g.goType(s, name+"Item")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "type %s %s\n\n", name, t)
+	}
+	return nil
+}
+
+// goType is the Go type of s, declaring inline objects and enums as the
+// type name.
+func (g *goGen) goType(s *apispec.Schema, name string) (string, error) {
+	switch {
+	case s == nil:
+		return "any", nil
+	case s.Ref != "":
+		return exportedName(s.RefName()), nil
+	case len(s.Enum) > 0 || s.Type == "object" && len(s.Properties) > 0:
+		return g.named(name, s)
+	}
+	switch s.Type {
+	case "array":
+		t, err := g.goType(s.Items, name+"Item")
+		return "[]" + t, err
+	case "object", "":
+		if s.AdditionalProperties == nil {
+			return "map[string]any", nil
+		}
+		t, err := g.goType(s.AdditionalProperties, name+"Value")
+		return "map[string]" + t, err
+	case "string":
+		switch s.Format {
+		case "date-time":
+			g.usesTime = true
+			return "time.Time", nil
+		case "binary":
+			return "[]byte", nil
+		}
+		return "string", nil
+	case "integer":
+		if s.Format == "int64" {
+			return "int64", nil
+		}
+		return "int", nil
+	case "number":
+		if s.Format == "float" {
+			return "float32", nil
+		}
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	}
+	return "", fmt.Errorf("type %q is not supported", s.Type)
+}
+
+// isReferenceType reports whether the zero value of t is nil already, so
+// an optional t needs no pointer.
+func isReferenceType(t string) bool {
+	return t == "any" || strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[")
+}
+
+// writeComment writes desc as the doc comment of name, indented.
+func writeComment(w *bytes.Buffer, indent, name, desc string) {
+	desc = strings.TrimSpace(desc)
+	if desc == "" {
+		return
+	}
+	for i, line := range strings.Split(desc, "\n") {
+		if i == 0 {
+			line = name + " " + line
+		}
+		fmt.Fprintf(w, "%s// %s\n", indent, strings.TrimSpace(line))
+	}
+}
+
+// goKeywords may not name arguments.
+var goKeywords = map[string]bool{"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true,
+	"defer": true, "else": true, "fallthrough": true, "for": true, "func": true, "go": true, "goto": true, "if": true,
+	"import": true, "interface": true, "map": true, "package": true, "range": true, "return": true, "select": true,
+	"struct": true, "switch": true, "type": true, "var": true}
+
+// method writes the Client method of op, and its Params type.
+func (g *goGen) method(w *bytes.Buffer, op operation) error {
+	args := []string{"ctx context.Context"}
+	path := fmt.Sprintf("%q", op.Path)
+	for _, p := range op.PathParams {
+		arg := camelName(p.Name)
+		if goKeywords[arg] || arg == "ctx" || arg == "params" || arg == "body" || arg == "c" {
+			arg += "Arg"
+		}
+		args = append(args, arg+" string")
+		path = strings.Replace(path, "{"+p.Name+"}", `"+url.PathEscape(`+arg+`)+"`, 1)
+	}
+	path = strings.TrimSuffix(path, `+""`)
+
+	var setParams bytes.Buffer
+	if len(op.Params) > 0 {
+		args = append(args, "params *"+op.Name+"Params")
+		fmt.Fprintf(&g.types, "// %sParams are the query and header parameters of Client.%s.\ntype %sParams struct {\n", op.Name, op.Name, op.Name)
+		for _, p := range op.Params {
+			field := exportedName(p.Name)
+			t, err := g.goType(p.Schema, op.Name+"Params"+field)
+			if err != nil {
+				return fmt.Errorf("parameter %s: %w", p.Name, err)
+			}
+			str, err := g.formatValue(t)
+			if err != nil {
+				return fmt.Errorf("parameter %s: %w", p.Name, err)
+			}
+			writeComment(&g.types, "\t", field, p.Description)
+			fmt.Fprintf(&g.types, "\t%s *%s // %s %s\n", field, t, p.In, p.Name)
+			set := fmt.Sprintf("query.Set(%q, %s)", p.Name, str)
+			if p.In == "header" {
+				set = fmt.Sprintf("header.Set(%q, %s)", p.Name, str)
+			}
+			fmt.Fprintf(&setParams, "\t\tif v := params.%s; v != nil {\n\t\t\t%s\n\t\t}\n", field, set)
+		}
+		g.types.WriteString("}\n\n")
+	}
+
+	body := "nil"
+	var encodeBody string
+	switch {
+	case op.BodyType == "application/json":
+		t, err := g.goType(op.Body, op.Name+"Body")
+		if err != nil {
+			return fmt.Errorf("request body: %w", err)
+		}
+		args = append(args, "body "+t)
+		encodeBody = "\tdata, err := json.Marshal(body)\n\tif err != nil {\n\t\treturn %s\n\t}\n\theader.Set(\"Content-Type\", \"application/json\")\n"
+		body = "bytes.NewReader(data)"
+	case op.BodyType == "multipart/form-data":
+		args = append(args, "body io.Reader", "contentType string")
+		encodeBody = "\theader.Set(\"Content-Type\", contentType)\n"
+		body = "body"
+	case op.BodyType != "":
+		args = append(args, "body io.Reader")
+		encodeBody = fmt.Sprintf("\theader.Set(\"Content-Type\", %q)\n", op.BodyType)
+		body = "body"
+	}
+
+	var result, zero, ret string
+	switch {
+	case isJSON(op.ResultType):
+		t, err := g.goType(op.Result, op.Name+"Result")
+		if err != nil {
+			return fmt.Errorf("response: %w", err)
+		}
+		if isReferenceType(t) {
+			result, zero, ret = t, "nil", "out"
+		} else {
+			result, zero, ret = "*"+t, "nil", "&out"
+		}
+	case op.ResultType != "":
+		result, zero = "[]byte", "nil"
+	}
+
+	doc := op.Summary
+	if doc == "" {
+		doc = op.Name
+	}
+	fmt.Fprintf(w, "// %s calls %s %s: %s\n", op.Name, op.Method, op.Path, strings.TrimSpace(doc))
+	if result != "" {
+		fmt.Fprintf(w, "func (c *Client) %s(%s) (%s, error) {\n", op.Name, strings.Join(args, ", "), result)
+	} else {
+		fmt.Fprintf(w, "func (c *Client) %s(%s) error {\n", op.Name, strings.Join(args, ", "))
+	}
+	fail := "err"
+	if result != "" {
+		fail = zero + ", err"
+	}
+	w.WriteString("\tquery, header := url.Values{}, http.Header{}\n")
+	if op.Accept != "" {
+		fmt.Fprintf(w, "\theader.Set(\"Accept\", %q)\n", op.Accept)
+	}
+	if setParams.Len() > 0 {
+		w.WriteString("\tif params != nil {\n")
+		w.Write(setParams.Bytes())
+		w.WriteString("\t}\n")
+	}
+	if encodeBody != "" {
+		if strings.Contains(encodeBody, "%s") {
+			encodeBody = fmt.Sprintf(encodeBody, fail)
+		}
+		w.WriteString(encodeBody)
+	}
+	call := fmt.Sprintf("c.do(ctx, %q, %s, query, header, %s", op.Method, path, body)
+	switch {
+	case isJSON(op.ResultType):
+		fmt.Fprintf(w, "\tvar out %s\n\tif _, err := %s, &out); err != nil {\n\t\treturn nil, err\n\t}\n\treturn %s, nil\n", strings.TrimPrefix(result, "*"), call, ret)
+	case result != "":
+		fmt.Fprintf(w, "\treturn %s, nil)\n", call)
+	default:
+		fmt.Fprintf(w, "\t_, err := %s, nil)\n\treturn err\n", call)
+	}
+	w.WriteString("}\n\n")
+	return nil
+}
+
+// formatValue is the Go expression writing the parameter v, a *t, as a
+// string.
+func (g *goGen) formatValue(t string) (string, error) {
+	switch t {
+	case "string":
+		return "*v", nil
+	case "bool":
+		g.usesStrconv = true
+		return "strconv.FormatBool(*v)", nil
+	case "int":
+		g.usesStrconv = true
+		return "strconv.Itoa(*v)", nil
+	case "int64":
+		g.usesStrconv = true
+		return "strconv.FormatInt(*v, 10)", nil
+	case "float64":
+		g.usesStrconv = true
+		return "strconv.FormatFloat(*v, 'f', -1, 64)", nil
+	case "time.Time":
+		return "v.Format(time.RFC3339)", nil
+	}
+	if g.declared[t] {
+		return "string(*v)", nil
+	}
+	return "", fmt.Errorf("parameters of type %s are not supported", t)
+}
+
+// goClient is the part of the Go client that does not depend on the
+// document.
+const goClient = `
+// Client calls the API at BaseURL, e.g. http://localhost:8080.
+type Client struct {
+	BaseURL string
+	// HTTPClient sends the requests; nil uses http.DefaultClient.
+	HTTPClient *http.Client
+	// Header is sent with every request, e.g. X-API-Key or Authorization.
+	Header http.Header
+}
+
+// NewClient returns a Client for the API at baseURL.
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/"), Header: http.Header{}}
+}
+
+// APIError is an answer outside 2xx. Body is the response body, for most
+// errors an ErrorResponse in JSON.
+type APIError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), bytes.TrimSpace(e.Body))
+}
+
+// do sends a request and returns the body of a 2xx answer, after decoding
+// it into out unless out is nil.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, body io.Reader, out any) ([]byte, error) {
+	u := strings.TrimSuffix(c.BaseURL, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range c.Header {
+		req.Header[k] = vs
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, &APIError{StatusCode: res.StatusCode, Body: data}
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return nil, fmt.Errorf("%s %s: decode response: %w", method, path, err)
+		}
+	}
+	return data, nil
+}
+
+`
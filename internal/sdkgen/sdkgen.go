@@ -0,0 +1,182 @@
+// Package sdkgen generates typed client SDKs for the API from its OpenAPI
+// document: a Go package (Go) and a TypeScript module (TypeScript), each
+// with a type per schema and a method per operation. cmd/sdkgen runs it;
+// see make sdk.
+package sdkgen
+
+import (
+	"book-manager/internal/apispec"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// initialisms are the words Go names write in capitals.
+var initialisms = map[string]bool{"api": true, "csv": true, "http": true, "id": true, "isbn": true, "json": true, "pdf": true, "uri": true, "url": true}
+
+// words splits a name in any case, like page_size, Idempotency-Key or
+// getBookById, into its words.
+func words(name string) []string {
+	var out []string
+	var cur []rune
+	rs := []rune(name)
+	for i, r := range rs {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			if len(cur) > 0 {
+				out, cur = append(out, string(cur)), nil
+			}
+			continue
+		case unicode.IsUpper(r) && len(cur) > 0 && (unicode.IsLower(rs[i-1]) || unicode.IsDigit(rs[i-1])):
+			out, cur = append(out, string(cur)), nil
+		}
+		cur = append(cur, r)
+	}
+	if len(cur) > 0 {
+		out = append(out, string(cur))
+	}
+	return out
+}
+
+// exportedName is name as an exported Go identifier.
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, w := range words(name) {
+		if initialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		rs := []rune(w)
+		b.WriteRune(unicode.ToUpper(rs[0]))
+		b.WriteString(string(rs[1:]))
+	}
+	if b.Len() == 0 {
+		return "X"
+	}
+	return b.String()
+}
+
+// camelName is name in lower camel case, as TypeScript names members and
+// Go names arguments.
+func camelName(name string) string {
+	ws := words(name)
+	if len(ws) == 0 {
+		return "x"
+	}
+	var b strings.Builder
+	b.WriteString(strings.ToLower(ws[0]))
+	for _, w := range ws[1:] {
+		rs := []rune(w)
+		b.WriteRune(unicode.ToUpper(rs[0]))
+		b.WriteString(string(rs[1:]))
+	}
+	return b.String()
+}
+
+// pathParam matches a parameter in a path template, like {id}.
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// operation is a route as the generators see it: the parameters by kind
+// and the one request body and response they use.
+type operation struct {
+	apispec.Route
+	Name       string               // exported name
+	PathParams []*apispec.Parameter // in path order
+	Params     []*apispec.Parameter // query and header parameters
+	BodyType   string               // content type of the request body, "" for none
+	Body       *apispec.Schema
+	ResultType string // content type of the success response, "" for none
+	Result     *apispec.Schema
+	Accept     string // every content type of the success response, ResultType first
+}
+
+// operations are the routes of d that answer with a 2xx status, which
+// the clients can call; the WebSocket at /ws, answering 101, is left out.
+// JSON is preferred where a body or response allows several types.
+func operations(d *apispec.Document) ([]operation, error) {
+	var out []operation
+	seen := map[string]bool{}
+	for _, rt := range d.Routes() {
+		op := operation{Route: rt, Name: exportedName(rt.OperationID)}
+		if rt.OperationID == "" || seen[op.Name] {
+			return nil, fmt.Errorf("%s %s: missing or duplicate operationId", rt.Method, rt.Path)
+		}
+		seen[op.Name] = true
+		code := ""
+		for c := range rt.Responses {
+			if strings.HasPrefix(c, "2") && (code == "" || c < code) {
+				code = c
+			}
+		}
+		if code == "" {
+			continue
+		}
+		byName := map[string]*apispec.Parameter{}
+		for _, p := range rt.Parameters {
+			switch p.In {
+			case "path":
+				byName[p.Name] = p
+			case "query", "header":
+				op.Params = append(op.Params, p)
+			default:
+				return nil, fmt.Errorf("%s %s: parameter %s in %s is not supported", rt.Method, rt.Path, p.Name, p.In)
+			}
+		}
+		for _, m := range pathParam.FindAllStringSubmatch(rt.Path, -1) {
+			p := byName[m[1]]
+			if p == nil {
+				p = &apispec.Parameter{Name: m[1], In: "path", Required: true, Schema: &apispec.Schema{Type: "string"}}
+			}
+			op.PathParams = append(op.PathParams, p)
+		}
+		if rb := rt.RequestBody; rb != nil {
+			op.BodyType, op.Body = pickContent(rb.Content)
+		}
+		op.ResultType, op.Result = pickContent(rt.Responses[code].Content)
+		op.Accept = acceptTypes(op.ResultType, rt.Responses[code].Content)
+		out = append(out, op)
+	}
+	return out, nil
+}
+
+// pickContent is the content type to use of those offered and its schema:
+// JSON if offered, else the first in order.
+func pickContent(content map[string]apispec.MediaType) (string, *apispec.Schema) {
+	if mt, ok := content["application/json"]; ok {
+		return "application/json", mt.Schema
+	}
+	best := ""
+	for ct := range content {
+		if best == "" || ct < best {
+			best = ct
+		}
+	}
+	if best == "" {
+		return "", nil
+	}
+	return best, content[best].Schema
+}
+
+// isJSON reports whether the content type ct is JSON, plain or with a
+// +json suffix like application/schema+json.
+func isJSON(ct string) bool {
+	return ct == "application/json" || strings.HasSuffix(ct, "+json")
+}
+
+// acceptTypes is the Accept header for a response with content: first
+// the type picked, then the others.
+func acceptTypes(picked string, content map[string]apispec.MediaType) string {
+	var types []string
+	for ct := range content {
+		if ct != picked {
+			types = append(types, ct)
+		}
+	}
+	slices.Sort(types)
+	if picked != "" {
+		types = append([]string{picked}, types...)
+	}
+	return strings.Join(types, ", ")
+}
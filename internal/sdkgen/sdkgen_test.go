@@ -0,0 +1,65 @@
+//go:build unit
+
+package sdkgen
+
+import (
+	"book-manager/api"
+	"book-manager/internal/apispec"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, "GetBookByID", exportedName("getBookById"))
+	assert.Equal(t, "IdempotencyKey", exportedName("Idempotency-Key"))
+	assert.Equal(t, "PageSize", exportedName("page_size"))
+	assert.Equal(t, "ISBN", exportedName("isbn"))
+	assert.Equal(t, "pageSize", camelName("page_size"))
+	assert.Equal(t, "ifNoneMatch", camelName("If-None-Match"))
+}
+
+func TestGo(t *testing.T) {
+	d, err := apispec.Parse(api.Spec)
+	require.NoError(t, err)
+	src, err := Go(d, "bookmanager")
+	require.NoError(t, err)
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "client.go", src, parser.ParseComments)
+	require.NoError(t, err)
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("bookmanager", fset, []*ast.File{f}, nil)
+	require.NoError(t, err, "the client compiles")
+
+	client := pkg.Scope().Lookup("Client")
+	require.NotNil(t, client)
+	methods := types.NewMethodSet(types.NewPointer(client.Type()))
+	for _, name := range []string{"CreateBook", "ListBooks", "GetBookByID", "DeleteBookByID", "UpdateBook", "UploadBookCover", "ListSchemas"} {
+		assert.NotNil(t, methods.Lookup(pkg, name), name)
+	}
+	assert.Nil(t, methods.Lookup(pkg, "LiveCounters"), "the WebSocket is left out")
+	for _, name := range d.SchemaNames() {
+		assert.NotNil(t, pkg.Scope().Lookup(exportedName(name)), name)
+	}
+}
+
+func TestTypeScript(t *testing.T) {
+	d, err := apispec.Parse(api.Spec)
+	require.NoError(t, err)
+	src, err := TypeScript(d)
+	require.NoError(t, err)
+	ts := string(src)
+	assert.Contains(t, ts, "export class Client {")
+	assert.Contains(t, ts, "async createBook(body: BookCreate, params: CreateBookParams = {}): Promise<Book> {")
+	assert.Contains(t, ts, "async getSchema(name: string): Promise<Record<string, unknown>> {")
+	assert.Contains(t, ts, "  isbn?: string | null;")
+	assert.Contains(t, ts, `export type ItemCondition = "new" | "good" | "fair" | "poor" | "damaged" | "lost" | "missing";`)
+	assert.NotContains(t, ts, "liveCounters")
+}
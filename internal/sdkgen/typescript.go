@@ -0,0 +1,311 @@
+package sdkgen
+
+import (
+	"book-manager/internal/apispec"
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// TypeScript writes a TypeScript client module for the API d describes: an
+// interface or type per schema and a Client method per operation, on the
+// Fetch API of browsers and Node.js 18 and later.
+func TypeScript(d *apispec.Document) ([]byte, error) {
+	ops, err := operations(d)
+	if err != nil {
+		return nil, err
+	}
+	g := &tsGen{declared: map[string]bool{}}
+	for _, name := range d.SchemaNames() {
+		g.declared[exportedName(name)] = true
+	}
+	for _, name := range d.SchemaNames() {
+		if err := g.typeDecl(exportedName(name), d.Components.Schemas[name]); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+	var methods bytes.Buffer
+	for _, op := range ops {
+		if err := g.method(&methods, op); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", op.Method, op.Path, err)
+		}
+	}
+	for len(g.pending) > 0 {
+		p := g.pending[0]
+		g.pending = g.pending[1:]
+		if err := g.typeDecl(p.name, p.schema); err != nil {
+			return nil, fmt.Errorf("type %s: %w", p.name, err)
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by sdkgen from the %s OpenAPI document, version %s. DO NOT EDIT.\n", d.Info.Title, d.Info.Version)
+	out.WriteString(tsClientHead)
+	out.Write(methods.Bytes())
+	out.WriteString("}\n\n")
+	out.Write(bytes.TrimRight(g.types.Bytes(), "\n"))
+	out.WriteString("\n")
+	return out.Bytes(), nil
+}
+
+type tsGen struct {
+	types    bytes.Buffer
+	declared map[string]bool
+	pending  []pendingType
+}
+
+func (g *tsGen) named(name string, s *apispec.Schema) (string, error) {
+	if g.declared[name] {
+		return "", fmt.Errorf("inline type %s clashes with another type", name)
+	}
+	g.declared[name] = true
+	g.pending = append(g.pending, pendingType{name, s})
+	return name, nil
+}
+
+func (g *tsGen) typeDecl(name string, s *apispec.Schema) error {
+	w := &g.types
+	writeJSDoc(w, "", s.Description)
+	if s.Type == "object" && len(s.Properties) > 0 && len(s.Enum) == 0 {
+		fmt.Fprintf(w, "export interface %s {\n", name)
+		props := make([]string, 0, len(s.Properties))
+		for p := range s.Properties {
+			props = append(props, p)
+		}
+		slices.Sort(props)
+		for _, p := range props {
+			ps := s.Properties[p]
+			t, err := g.tsType(ps, name+exportedName(p))
+			if err != nil {
+				return fmt.Errorf("property %s: %w", p, err)
+			}
+			opt := "?"
+			if s.IsRequired(p) {
+				opt = ""
+			}
+			writeJSDoc(w, "  ", ps.Description)
+			fmt.Fprintf(w, "  %s%s: %s;\n", tsKey(p), opt, t)
+		}
+		w.WriteString("}\n\n")
+		return nil
+	}
+	if len(s.Enum) > 0 {
+		quoted := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			quoted[i] = fmt.Sprintf("%q", v)
+		}
+		fmt.Fprintf(w, "export type %s = %s;\n\n", name, strings.Join(quoted, " | "))
+		return nil
+	}
+	t, err := g.tsType(s, name+"Item")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "export type %s = %s;\n\n", name, t)
+	return nil
+}
+
+// tsType is the TypeScript type of s, declaring inline objects and enums
+// as the type name.
+func (g *tsGen) tsType(s *apispec.Schema, name string) (string, error) {
+	var t string
+	var err error
+	switch {
+	case s == nil:
+		return "unknown", nil
+	case s.Ref != "":
+		t = exportedName(s.RefName())
+	case len(s.Enum) > 0 || s.Type == "object" && len(s.Properties) > 0:
+		t, err = g.named(name, s)
+	case s.Type == "array":
+		t, err = g.tsType(s.Items, name+"Item")
+		if strings.Contains(t, " ") {
+			t = "(" + t + ")"
+		}
+		t += "[]"
+	case s.Type == "object" || s.Type == "":
+		v := "unknown"
+		if s.AdditionalProperties != nil {
+			v, err = g.tsType(s.AdditionalProperties, name+"Value")
+		}
+		t = "Record<string, " + v + ">"
+	case s.Type == "string" && s.Format == "binary":
+		t = "Blob"
+	case s.Type == "string":
+		t = "string"
+	case s.Type == "integer" || s.Type == "number":
+		t = "number"
+	case s.Type == "boolean":
+		t = "boolean"
+	default:
+		return "", fmt.Errorf("type %q is not supported", s.Type)
+	}
+	if s.Nullable {
+		t += " | null"
+	}
+	return t, err
+}
+
+// tsIdent matches the names TypeScript takes unquoted.
+var tsIdent = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsKey is the property name as an object key.
+func tsKey(name string) string {
+	if tsIdent.MatchString(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+// writeJSDoc writes desc as a JSDoc comment, indented.
+func writeJSDoc(w *bytes.Buffer, indent, desc string) {
+	desc = strings.TrimSpace(desc)
+	if desc == "" {
+		return
+	}
+	lines := strings.Split(desc, "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(w, "%s/** %s */\n", indent, strings.ReplaceAll(desc, "*/", "*\\/"))
+		return
+	}
+	fmt.Fprintf(w, "%s/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(w, "%s * %s\n", indent, strings.ReplaceAll(strings.TrimSpace(line), "*/", "*\\/"))
+	}
+	fmt.Fprintf(w, "%s */\n", indent)
+}
+
+// method writes the Client method of op, and its Params interface.
+func (g *tsGen) method(w *bytes.Buffer, op operation) error {
+	name := camelName(op.OperationID)
+	var args []string
+	path := op.Path
+	for _, p := range op.PathParams {
+		arg := camelName(p.Name)
+		args = append(args, arg+": string")
+		path = strings.Replace(path, "{"+p.Name+"}", "${encodeURIComponent("+arg+")}", 1)
+	}
+
+	body, contentType := "undefined", "undefined"
+	switch {
+	case op.BodyType == "application/json":
+		t, err := g.tsType(op.Body, op.Name+"Body")
+		if err != nil {
+			return fmt.Errorf("request body: %w", err)
+		}
+		args = append(args, "body: "+t)
+		body, contentType = "JSON.stringify(body)", `"application/json"`
+	case op.BodyType == "multipart/form-data":
+		// fetch sets the content type with the boundary
+		args = append(args, "body: FormData")
+		body = "body"
+	case op.BodyType != "":
+		args = append(args, "body: string")
+		body, contentType = "body", fmt.Sprintf("%q", op.BodyType)
+	}
+
+	var query, header []string
+	if len(op.Params) > 0 {
+		fmt.Fprintf(&g.types, "/** The query and header parameters of Client.%s. */\nexport interface %sParams {\n", name, op.Name)
+		for _, p := range op.Params {
+			t, err := g.tsType(p.Schema, op.Name+"Params"+exportedName(p.Name))
+			if err != nil {
+				return fmt.Errorf("parameter %s: %w", p.Name, err)
+			}
+			field := camelName(p.Name)
+			writeJSDoc(&g.types, "  ", p.Description)
+			fmt.Fprintf(&g.types, "  %s?: %s;\n", field, t)
+			entry := fmt.Sprintf("%s: params.%s", tsKey(p.Name), field)
+			if p.In == "header" {
+				header = append(header, entry)
+			} else {
+				query = append(query, entry)
+			}
+		}
+		g.types.WriteString("}\n\n")
+		args = append(args, "params: "+op.Name+"Params = {}")
+	}
+
+	result, read := "void", ""
+	switch {
+	case isJSON(op.ResultType):
+		t, err := g.tsType(op.Result, op.Name+"Result")
+		if err != nil {
+			return fmt.Errorf("response: %w", err)
+		}
+		result, read = t, fmt.Sprintf("return (await res.json()) as %s;", t)
+	case strings.HasPrefix(op.ResultType, "text/"):
+		result, read = "string", "return res.text();"
+	case op.ResultType != "":
+		result, read = "Blob", "return res.blob();"
+	}
+
+	doc := strings.TrimSpace(op.Summary)
+	if doc == "" {
+		doc = op.Name
+	}
+	fmt.Fprintf(w, "  /** %s: %s %s */\n", strings.ReplaceAll(doc, "*/", "*\\/"), op.Method, op.Path)
+	fmt.Fprintf(w, "  async %s(%s): Promise<%s> {\n", name, strings.Join(args, ", "), result)
+	headers := header
+	if op.Accept != "" {
+		headers = append([]string{fmt.Sprintf("Accept: %q", op.Accept)}, header...)
+	}
+	res := "const res = "
+	if read == "" {
+		res = ""
+	}
+	fmt.Fprintf(w, "    %sawait this.request(%q, `%s`, {%s}, {%s}, %s, %s);\n", res, op.Method, path,
+		strings.Join(query, ", "), strings.Join(headers, ", "), body, contentType)
+	if read != "" {
+		fmt.Fprintf(w, "    %s\n", read)
+	}
+	w.WriteString("  }\n\n")
+	return nil
+}
+
+// tsClientHead is the part of the TypeScript client that does not depend
+// on the document, up to the generated methods.
+const tsClientHead = `
+export interface ClientOptions {
+  /** Where the API is, e.g. http://localhost:8080. */
+  baseUrl: string;
+  /** Sent with every request, e.g. { "X-API-Key": "..." }. */
+  headers?: Record<string, string>;
+  /** Sends the requests; globalThis.fetch by default. */
+  fetch?: typeof fetch;
+}
+
+/** An answer outside 2xx; body is usually an ErrorResponse in JSON. */
+export class ApiError extends globalThis.Error {
+  constructor(
+    readonly status: number,
+    readonly body: string,
+  ) {
+    super(` + "`${status}: ${body}`" + `);
+    this.name = "ApiError";
+  }
+}
+
+type Values = Record<string, string | number | boolean | null | undefined>;
+
+export class Client {
+  constructor(private readonly options: ClientOptions) {}
+
+  private async request(method: string, path: string, query: Values, headers: Values, body?: BodyInit, contentType?: string): Promise<Response> {
+    const url = new URL(this.options.baseUrl.replace(/\/$/, "") + path);
+    for (const [k, v] of Object.entries(query)) {
+      if (v !== undefined && v !== null) url.searchParams.set(k, String(v));
+    }
+    const h: Record<string, string> = { ...this.options.headers };
+    for (const [k, v] of Object.entries(headers)) {
+      if (v !== undefined && v !== null) h[k] = String(v);
+    }
+    if (contentType) h["Content-Type"] = contentType;
+    const res = await (this.options.fetch ?? fetch)(url, { method, headers: h, body });
+    if (!res.ok) throw new ApiError(res.status, await res.text());
+    return res;
+  }
+
+`
@@ -0,0 +1,219 @@
+// Package tracing exports OpenTelemetry traces of the request path: a span
+// per HTTP request, with spans of the service, the book repository and
+// enrichment beneath it. Outgoing calls of http_client.CreateHTTPClient
+// carry the trace on in their traceparent header. It is off unless an OTLP
+// endpoint is configured.
+package tracing
+
+import (
+	"book-manager/internal/core"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/buildinfo"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Config configures the OTLP/HTTP exporter. An empty Endpoint turns
+// tracing off.
+type Config struct {
+	// Endpoint is the collector's base URL, e.g. http://localhost:4318;
+	// spans are sent to its /v1/traces.
+	Endpoint string
+	Headers  map[string]string // sent with every export, e.g. for auth
+	// SampleRatio is the share of new traces recorded, in [0,1]; requests
+	// that carry a sampled traceparent are recorded regardless.
+	SampleRatio float64
+	ServiceName string
+	Timeout     time.Duration // of an export; 0 uses the exporter's
+}
+
+func (c Config) Enabled() bool {
+	return c.Endpoint != ""
+}
+
+// Setup installs the global tracer provider and the W3C trace context
+// propagator. The returned function flushes the spans still buffered and
+// stops the exporter; it is a no-op when c is not enabled.
+func Setup(ctx context.Context, c Config) (shutdown func(context.Context) error, err error) {
+	if !c.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return nil, fmt.Errorf("tracing: sample ratio %v is not in [0,1]", c.SampleRatio)
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(c.Endpoint)}
+	if len(c.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(c.Headers))
+	}
+	if c.Timeout > 0 {
+		opts = append(opts, otlptracehttp.WithTimeout(c.Timeout))
+	}
+	exp, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: %w", err)
+	}
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(c.ServiceName),
+		semconv.ServiceVersion(buildinfo.Get().Version),
+	)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return tp.Shutdown, nil
+}
+
+// Middleware starts the server span of each request, continuing the trace
+// of its traceparent header. The span is named after the route once the
+// router has matched it, e.g. "POST /api/v1/books".
+func Middleware(next http.Handler) http.Handler {
+	routed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+		if route := routePattern(r); route != "" {
+			trace.SpanFromContext(r.Context()).SetAttributes(semconv.HTTPRoute(route))
+		}
+	})
+	// otelhttp names the span again after serving
+	return otelhttp.NewHandler(routed, "http.server", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		if route := routePattern(r); route != "" {
+			return r.Method + " " + route
+		}
+		return r.Method
+	}))
+}
+
+func routePattern(r *http.Request) string {
+	if rc := chi.RouteContext(r.Context()); rc != nil {
+		return rc.RoutePattern()
+	}
+	return ""
+}
+
+var tracer = otel.Tracer("book-manager/internal/tracing")
+
+// end records err on span, unless it is a miss that callers expect, and
+// ends it.
+func end(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, model.ErrNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Repo decorates a BookRepository with a span for each of its methods.
+type Repo struct {
+	core.BookRepository
+}
+
+// Unwrap returns the decorated repository, so the service still finds
+// what it implements besides BookRepository.
+func (r Repo) Unwrap() core.BookRepository {
+	return r.BookRepository
+}
+
+func (r Repo) start(ctx context.Context, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "BookRepository."+op, trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(attrs...))
+}
+
+func (r Repo) Create(ctx context.Context, b model.Book) (_ model.Book, err error) {
+	ctx, span := r.start(ctx, "Create", attribute.String("book.id", b.ID))
+	defer func() { end(span, err) }()
+	return r.BookRepository.Create(ctx, b)
+}
+
+func (r Repo) CreateCapped(ctx context.Context, b model.Book, limit int) (_ model.Book, err error) {
+	ctx, span := r.start(ctx, "CreateCapped", attribute.String("book.id", b.ID), attribute.Int("limit", limit))
+	defer func() { end(span, err) }()
+	return r.BookRepository.CreateCapped(ctx, b, limit)
+}
+
+func (r Repo) Update(ctx context.Context, b model.Book) (_ model.Book, err error) {
+	ctx, span := r.start(ctx, "Update", attribute.String("book.id", b.ID))
+	defer func() { end(span, err) }()
+	return r.BookRepository.Update(ctx, b)
+}
+
+func (r Repo) GetByID(ctx context.Context, id string) (_ model.Book, err error) {
+	ctx, span := r.start(ctx, "GetByID", attribute.String("book.id", id))
+	defer func() { end(span, err) }()
+	return r.BookRepository.GetByID(ctx, id)
+}
+
+func (r Repo) GetByISBN(ctx context.Context, owner, isbn string) (_ model.Book, err error) {
+	ctx, span := r.start(ctx, "GetByISBN", attribute.String("book.isbn", isbn))
+	defer func() { end(span, err) }()
+	return r.BookRepository.GetByISBN(ctx, owner, isbn)
+}
+
+func (r Repo) List(ctx context.Context, q model.ListQuery) (_ model.Page[model.Book], err error) {
+	ctx, span := r.start(ctx, "List")
+	defer func() { end(span, err) }()
+	return r.BookRepository.List(ctx, q)
+}
+
+func (r Repo) Count(ctx context.Context, q model.ListQuery) (_ int, err error) {
+	ctx, span := r.start(ctx, "Count")
+	defer func() { end(span, err) }()
+	return r.BookRepository.Count(ctx, q)
+}
+
+func (r Repo) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := r.start(ctx, "Delete", attribute.String("book.id", id))
+	defer func() { end(span, err) }()
+	return r.BookRepository.Delete(ctx, id)
+}
+
+func (r Repo) RenameAuthor(ctx context.Context, rn model.AuthorRename) (_ model.AuthorRename, err error) {
+	ctx, span := r.start(ctx, "RenameAuthor")
+	defer func() { end(span, err) }()
+	return r.BookRepository.RenameAuthor(ctx, rn)
+}
+
+func (r Repo) ListAuthorRenames(ctx context.Context) (_ []model.AuthorRename, err error) {
+	ctx, span := r.start(ctx, "ListAuthorRenames")
+	defer func() { end(span, err) }()
+	return r.BookRepository.ListAuthorRenames(ctx)
+}
+
+func (r Repo) RenameTags(ctx context.Context, rn model.TagRename) (_ model.TagRename, err error) {
+	ctx, span := r.start(ctx, "RenameTags")
+	defer func() { end(span, err) }()
+	return r.BookRepository.RenameTags(ctx, rn)
+}
+
+// Enrichment decorates an EnrichmentClient with a span for each lookup;
+// the HTTP calls it makes are spans of their own beneath it.
+type Enrichment struct {
+	core.EnrichmentClient
+}
+
+func (e Enrichment) FetchByISBN(ctx context.Context, isbn string) (_ model.EnrichedBook, err error) {
+	ctx, span := tracer.Start(ctx, "Enrichment.FetchByISBN", trace.WithAttributes(attribute.String("book.isbn", isbn)))
+	defer func() {
+		end(span, err)
+	}()
+	b, err := e.EnrichmentClient.FetchByISBN(ctx, isbn)
+	if err == nil {
+		span.SetAttributes(attribute.String("enrichment.source", b.Source))
+	}
+	return b, err
+}
@@ -0,0 +1,127 @@
+//go:build unit
+
+package tracing
+
+import (
+	"book-manager/api"
+	"book-manager/internal/adapter"
+	"book-manager/internal/core"
+	"book-manager/internal/core/model"
+	"book-manager/pkg/http_client"
+	"book-manager/pkg/util"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// record installs a provider that keeps every span for the test.
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+	return rec
+}
+
+func TestTrace_RequestToOpenLibrary(t *testing.T) {
+	rec := record(t)
+	var traceparent string
+	openLibrary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		http.NotFound(w, r)
+	}))
+	defer openLibrary.Close()
+
+	svc := core.NewService(Repo{adapter.NewBookRepo()},
+		Enrichment{adapter.NewOpenLibraryClient(openLibrary.URL, 0, http_client.CreateHTTPClient())})
+	router := chi.NewRouter()
+	router.Use(Middleware)
+	api.HandlerFromMux(adapter.NewHTTPHandler(svc, slog.New(slog.NewTextHandler(io.Discard, nil))), router)
+
+	// the caller's trace is continued
+	const callerTrace = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/books?enrich=true", strings.NewReader(`{"title":"Refactoring","isbn":"9780134757599"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", "00-"+callerTrace+"-00f067aa0ba902b7-01")
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	require.Equal(t, http.StatusCreated, res.Code, res.Body.String())
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range rec.Ended() {
+		assert.Equal(t, callerTrace, s.SpanContext().TraceID().String(), s.Name())
+		spans[s.Name()] = s
+	}
+	parent := func(child, parent string) {
+		t.Helper()
+		require.Contains(t, spans, child)
+		require.Contains(t, spans, parent)
+		assert.Equal(t, spans[parent].SpanContext().SpanID(), spans[child].Parent().SpanID(), "%s under %s", child, parent)
+	}
+	server := spans["POST /api/v1/books"]
+	require.NotNil(t, server, "span named after the route")
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	parent("Service.CreateBook", "POST /api/v1/books")
+	parent("BookRepository.GetByISBN", "Service.CreateBook")
+	parent("BookRepository.Create", "Service.CreateBook")
+	parent("Enrichment.FetchByISBN", "Service.CreateBook")
+	parent("HTTP GET", "Enrichment.FetchByISBN")
+
+	client := spans["HTTP GET"]
+	assert.Equal(t, trace.SpanKindClient, client.SpanKind())
+	assert.Equal(t, "00-"+callerTrace+"-"+client.SpanContext().SpanID().String()+"-01", traceparent,
+		"Open Library sees the client span as the caller")
+}
+
+// stagingRepo notes whether creates are given the event to store with the
+// book.
+type stagingRepo struct {
+	*adapter.BoltBookRepo
+	staged bool
+}
+
+func (r *stagingRepo) Create(ctx context.Context, b model.Book) (model.Book, error) {
+	r.staged = model.EventStageFromContext(ctx) != nil
+	return r.BoltBookRepo.Create(ctx, b)
+}
+
+func TestRepo_KeepsOutboxStaging(t *testing.T) {
+	record(t)
+	ctx := context.Background()
+	db, err := adapter.OpenBoltDB(filepath.Join(t.TempDir(), "books.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	bolt, err := adapter.OpenBoltBookRepo(db)
+	require.NoError(t, err)
+	repo := &stagingRepo{BoltBookRepo: bolt}
+	svc := core.NewService(Repo{repo}, nil)
+	svc.Outbox = repo
+
+	_, err = svc.CreateBook(ctx, model.CreateBookInput{Title: util.GetPtr("Dune")})
+	require.NoError(t, err)
+	assert.True(t, repo.staged, "the event is written with the book through the decorator")
+	events, err := repo.Pending(ctx, 10)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
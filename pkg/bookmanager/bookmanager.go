@@ -0,0 +1,209 @@
+// Package bookmanager embeds the book catalog in another Go program,
+// without running the HTTP server: the same service, validation, storage
+// and enrichment, called directly.
+//
+//	cat, err := bookmanager.New(
+//		bookmanager.WithFileStorage("books.journal"),
+//		bookmanager.WithEnrichment(bookmanager.OpenLibrary("")),
+//	)
+//	defer cat.Close()
+//	b, err := cat.Create(ctx, bookmanager.CreateBookInput{ISBN: &isbn, Enrich: true})
+//
+// The types are those of the service, under names of this package, and so
+// are the interfaces a program implements to bring its own storage
+// (BookRepository) or metadata source (EnrichmentClient). Service gives
+// access to every other feature of the catalog, such as branches or
+// lending, once their repositories are set on it.
+package bookmanager
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/internal/core"
+	"book-manager/internal/core/model"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// The catalog's data, as the service takes and returns it.
+type (
+	Book            = model.Book
+	CreateBookInput = model.CreateBookInput
+	UpdateBookInput = model.UpdateBookInput
+	BookPatch       = model.BookPatch
+	ListQuery       = model.ListQuery
+	SortKey         = model.SortKey
+	BookPage        = model.Page[model.Book]
+	Price           = model.Price
+	EnrichedBook    = model.EnrichedBook
+	AuthorRename    = model.AuthorRename
+	BookChange      = model.BookChange
+	TagRename       = model.TagRename
+
+	// ValidationErrors lists every field of a book that failed validation;
+	// it matches ErrValidation.
+	ValidationErrors = model.ValidationErrors
+	FieldError       = model.FieldError
+)
+
+// The interfaces a program implements to replace the storage of the books
+// or the source of their metadata.
+type (
+	BookRepository   = core.BookRepository
+	EnrichmentClient = core.EnrichmentClient
+)
+
+// Service is the catalog's service, with every feature of the server.
+type Service = core.Service
+
+// Errors returned by the catalog, to be checked with errors.Is.
+var (
+	ErrNotFound   = model.ErrNotFound
+	ErrConflict   = model.ErrConflict // duplicate ISBN, or a stale Version
+	ErrValidation = model.ErrValidation
+	ErrUpstream   = model.ErrUpstream // required enrichment failed
+)
+
+// Catalog is an embedded catalog. It is safe for concurrent use.
+type Catalog struct {
+	svc    *core.Service
+	closer io.Closer // the storage, when New opened it
+}
+
+type config struct {
+	repo   BookRepository
+	file   string
+	enrich EnrichmentClient
+}
+
+// Option configures New.
+type Option func(*config)
+
+// WithRepository keeps the books in r instead of in memory.
+func WithRepository(r BookRepository) Option { return func(c *config) { c.repo = r } }
+
+// WithFileStorage keeps the books in the journal file at path, as the
+// server does with -storage=file, creating it when missing. Close closes
+// it.
+func WithFileStorage(path string) Option { return func(c *config) { c.file = path } }
+
+// WithEnrichment looks up books created with Enrich set in e; see
+// OpenLibrary and Chain. Without it enrichment finds nothing.
+func WithEnrichment(e EnrichmentClient) Option { return func(c *config) { c.enrich = e } }
+
+// New returns a catalog, by default kept in memory and without
+// enrichment.
+func New(opts ...Option) (*Catalog, error) {
+	var c config
+	for _, o := range opts {
+		o(&c)
+	}
+	cat := &Catalog{}
+	switch {
+	case c.repo != nil && c.file != "":
+		return nil, errors.New("bookmanager: WithRepository and WithFileStorage exclude each other")
+	case c.file != "":
+		r, err := adapter.OpenFileBookRepo(c.file)
+		if err != nil {
+			return nil, fmt.Errorf("bookmanager: open %s: %w", c.file, err)
+		}
+		c.repo, cat.closer = r, r
+	case c.repo == nil:
+		c.repo = adapter.NewBookRepo()
+	}
+	if c.enrich == nil {
+		c.enrich = noEnrichment{}
+	}
+	cat.svc = core.NewService(c.repo, c.enrich)
+	return cat, nil
+}
+
+// noEnrichment knows no book.
+type noEnrichment struct{}
+
+func (noEnrichment) FetchByISBN(context.Context, string) (EnrichedBook, error) {
+	return EnrichedBook{}, fmt.Errorf("%w: no enrichment source", ErrNotFound)
+}
+
+// Close closes the storage New opened; a repository passed in is left to
+// its owner.
+func (c *Catalog) Close() error {
+	if c.closer == nil {
+		return nil
+	}
+	return c.closer.Close()
+}
+
+// Service is the service behind the catalog, for the features beyond
+// books. Its optional repositories may be set before the catalog is used.
+func (c *Catalog) Service() *Service { return c.svc }
+
+// Create adds a book; its ISBN, when given, must not be in the catalog
+// yet (ErrConflict).
+func (c *Catalog) Create(ctx context.Context, in CreateBookInput) (Book, error) {
+	return c.svc.CreateBook(ctx, in)
+}
+
+// Get returns the book with id, or ErrNotFound.
+func (c *Catalog) Get(ctx context.Context, id string) (Book, error) {
+	return c.svc.GetBook(ctx, id)
+}
+
+// List returns the page of books q selects, 20 per page unless
+// q.PageSize says otherwise.
+func (c *Catalog) List(ctx context.Context, q ListQuery) (BookPage, error) {
+	return c.svc.ListBooks(ctx, q)
+}
+
+// All yields every book q selects, walking the pages by cursor; q's Page
+// and Cursor are ignored. The walk stops after the first error, which is
+// yielded.
+func (c *Catalog) All(ctx context.Context, q ListQuery) iter.Seq2[Book, error] {
+	return func(yield func(Book, error) bool) {
+		q.Page, q.Cursor, q.SkipCount = 1, "", true
+		for {
+			page, err := c.svc.ListBooks(ctx, q)
+			if err != nil {
+				yield(Book{}, err)
+				return
+			}
+			for _, b := range page.Data {
+				if !yield(b, nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" || len(page.Data) == 0 {
+				return
+			}
+			q.Cursor = page.NextCursor
+		}
+	}
+}
+
+// Update replaces the editable fields of the book with id.
+func (c *Catalog) Update(ctx context.Context, id string, in UpdateBookInput) (Book, error) {
+	return c.svc.UpdateBook(ctx, id, in)
+}
+
+// Patch changes the fields of the book with id that p sets.
+func (c *Catalog) Patch(ctx context.Context, id string, p BookPatch) (Book, error) {
+	return c.svc.PatchBook(ctx, id, p)
+}
+
+// Delete moves the book with id to the trash, from which Restore takes
+// it back.
+func (c *Catalog) Delete(ctx context.Context, id string) error {
+	return c.svc.DeleteBook(ctx, id)
+}
+
+func (c *Catalog) Restore(ctx context.Context, id string) (Book, error) {
+	return c.svc.RestoreBook(ctx, id)
+}
+
+// Enrich looks the book with id up by its ISBN and fills in what it lacks,
+// or with overwrite replaces its fields by what the source has.
+func (c *Catalog) Enrich(ctx context.Context, id string, overwrite bool) (Book, error) {
+	return c.svc.EnrichBook(ctx, id, overwrite)
+}
@@ -0,0 +1,123 @@
+//go:build unit
+
+// The tests use the package from outside, as an embedding program does.
+package bookmanager_test
+
+import (
+	"book-manager/pkg/bookmanager"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// catalogSource is an EnrichmentClient of the embedding program.
+type catalogSource map[string]string // isbn -> title
+
+func (s catalogSource) FetchByISBN(_ context.Context, isbn string) (bookmanager.EnrichedBook, error) {
+	title, ok := s[isbn]
+	if !ok {
+		return bookmanager.EnrichedBook{}, bookmanager.ErrNotFound
+	}
+	return bookmanager.EnrichedBook{Title: &title, Authors: []string{"Frank Herbert"}}, nil
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestCatalog(t *testing.T) {
+	ctx := context.Background()
+	cat, err := bookmanager.New(bookmanager.WithEnrichment(bookmanager.Chain(
+		bookmanager.Source{Name: "empty", Client: catalogSource{}},
+		bookmanager.Source{Name: "shelf", Client: catalogSource{"9780441013593": "Dune"}},
+	)))
+	require.NoError(t, err)
+	defer cat.Close()
+
+	b, err := cat.Create(ctx, bookmanager.CreateBookInput{ISBN: ptr("0-441-01359-7"), Enrich: true, Tags: []string{"sf"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Dune", b.Title)
+	assert.Equal(t, []string{"Frank Herbert"}, b.Authors)
+	assert.Equal(t, "shelf", b.Enrichment.Source)
+
+	_, err = cat.Create(ctx, bookmanager.CreateBookInput{ISBN: ptr("9780441013593"), Title: ptr("Dune again")})
+	assert.ErrorIs(t, err, bookmanager.ErrConflict)
+
+	_, err = cat.Create(ctx, bookmanager.CreateBookInput{Title: ptr(""), PageCount: ptr(0)})
+	var ve bookmanager.ValidationErrors
+	require.ErrorAs(t, err, &ve)
+	assert.ErrorIs(t, err, bookmanager.ErrValidation)
+	assert.Len(t, ve, 2)
+
+	for i := range 24 {
+		_, err := cat.Create(ctx, bookmanager.CreateBookInput{Title: ptr(fmt.Sprintf("Volume %02d", i)), Tags: []string{"sf"}})
+		require.NoError(t, err)
+	}
+	page, err := cat.List(ctx, bookmanager.ListQuery{Tag: ptr("sf")})
+	require.NoError(t, err)
+	assert.Len(t, page.Data, 20)
+	assert.Equal(t, 25, page.Total)
+
+	n := 0
+	for b, err := range cat.All(ctx, bookmanager.ListQuery{Tag: ptr("sf"), PageSize: 7, Sort: []bookmanager.SortKey{{Field: "title"}}}) {
+		require.NoError(t, err)
+		if n == 0 {
+			assert.Equal(t, "Dune", b.Title)
+		}
+		n++
+	}
+	assert.Equal(t, 25, n)
+
+	got, err := cat.Patch(ctx, b.ID, bookmanager.BookPatch{PageCount: ptr(412)})
+	require.NoError(t, err)
+	assert.Equal(t, 412, *got.PageCount)
+	got, err = cat.Update(ctx, b.ID, bookmanager.UpdateBookInput{Title: ptr("Dune"), ISBN: b.ISBN, Version: ptr(got.Version)})
+	require.NoError(t, err)
+	assert.Nil(t, got.PageCount)
+	_, err = cat.Update(ctx, b.ID, bookmanager.UpdateBookInput{Title: ptr("Dune"), Version: ptr(1)})
+	assert.ErrorIs(t, err, bookmanager.ErrConflict)
+
+	require.NoError(t, cat.Delete(ctx, b.ID))
+	_, err = cat.Get(ctx, b.ID)
+	assert.ErrorIs(t, err, bookmanager.ErrNotFound)
+	_, err = cat.Restore(ctx, b.ID)
+	require.NoError(t, err)
+	_, err = cat.Get(ctx, b.ID)
+	assert.NoError(t, err)
+
+	assert.NotNil(t, cat.Service().Repo)
+}
+
+func TestCatalog_FileStorage(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "books.journal")
+	cat, err := bookmanager.New(bookmanager.WithFileStorage(path))
+	require.NoError(t, err)
+	b, err := cat.Create(ctx, bookmanager.CreateBookInput{Title: ptr("Walden")})
+	require.NoError(t, err)
+	require.NoError(t, cat.Close())
+
+	cat, err = bookmanager.New(bookmanager.WithFileStorage(path))
+	require.NoError(t, err)
+	defer cat.Close()
+	got, err := cat.Get(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Walden", got.Title)
+}
+
+func TestNew_Options(t *testing.T) {
+	_, err := bookmanager.New(bookmanager.WithRepository(bookmanager.MemoryRepository()), bookmanager.WithFileStorage("x"))
+	assert.Error(t, err)
+
+	// without a source, enrichment finds nothing and the book is kept as given
+	cat, err := bookmanager.New()
+	require.NoError(t, err)
+	b, err := cat.Create(context.Background(), bookmanager.CreateBookInput{Title: ptr("Emma"), ISBN: ptr("9780141439587"), Enrich: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Emma", b.Title)
+	_, err = cat.Create(context.Background(), bookmanager.CreateBookInput{ISBN: ptr("9780441013593"), Enrich: true, RequireEnrichment: true})
+	assert.True(t, errors.Is(err, bookmanager.ErrUpstream), "%v", err)
+}
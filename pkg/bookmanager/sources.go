@@ -0,0 +1,30 @@
+package bookmanager
+
+import (
+	"book-manager/internal/adapter"
+	"book-manager/pkg/http_client"
+)
+
+// MemoryRepository is the repository New uses by default, for a program
+// that wraps it in its own BookRepository.
+func MemoryRepository() BookRepository { return adapter.NewBookRepo() }
+
+// OpenLibrary looks books up in Open Library at baseURL, or its public
+// API for "", retrying failures three times.
+func OpenLibrary(baseURL string) EnrichmentClient {
+	return adapter.NewOpenLibraryClient(baseURL, 3, http_client.CreateHTTPClient())
+}
+
+// GoogleBooks looks books up in the Google Books API at baseURL, or its
+// public API for ""; apiKey may be empty for low volumes.
+func GoogleBooks(baseURL, apiKey string) EnrichmentClient {
+	return adapter.NewGoogleBooksClient(baseURL, apiKey, 3, http_client.CreateHTTPClient())
+}
+
+// Source is one source of a Chain: its Name, reported in
+// EnrichedBook.Source, its Client and a Timeout (0 for none).
+type Source = adapter.ChainSource
+
+// Chain asks its sources in order and returns the first answer, as the
+// server does with -enrichment-source.
+func Chain(sources ...Source) EnrichmentClient { return adapter.NewEnrichmentChain(sources...) }
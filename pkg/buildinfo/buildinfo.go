@@ -0,0 +1,119 @@
+// Package buildinfo describes the running binary: its version, the commit
+// it was built from and when. Releases stamp these at link time:
+//
+//	go build -ldflags "-X book-manager/pkg/buildinfo.version=v1.4.0 \
+//	    -X book-manager/pkg/buildinfo.commit=$(git rev-parse HEAD) \
+//	    -X book-manager/pkg/buildinfo.date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Without them the VCS information the go command embeds is used, so a
+// plain go build from a checkout still reports its commit.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// set with -ldflags -X
+var (
+	version string
+	commit  string
+	date    string
+)
+
+// Project is the product name used in User-Agent strings.
+const Project = "book-manager"
+
+// ProjectURL lets operators of services we call find out who is calling.
+const ProjectURL = "https://github.com/Venkatpandey/book-manager"
+
+type Info struct {
+	Version   string // semantic version, e.g. v1.4.0; "dev" for unreleased builds
+	Commit    string // VCS revision; empty when unknown
+	Date      string // build or commit time, RFC 3339; empty when unknown
+	Modified  bool   // built from a checkout with uncommitted changes
+	GoVersion string
+	Platform  string // GOOS/GOARCH
+}
+
+var (
+	once sync.Once
+	info Info
+)
+
+// Get returns the build information of the running binary.
+func Get() Info {
+	once.Do(func() {
+		info = read(version, commit, date, debug.ReadBuildInfo)
+	})
+	return info
+}
+
+func read(version, commit, date string, readBuild func() (*debug.BuildInfo, bool)) Info {
+	i := Info{
+		Version:   version,
+		Commit:    commit,
+		Date:      date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if bi, ok := readBuild(); ok {
+		if i.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			i.Version = bi.Main.Version
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if i.Commit == "" {
+					i.Commit = s.Value
+				}
+			case "vcs.time":
+				if i.Date == "" {
+					i.Date = s.Value
+				}
+			case "vcs.modified":
+				i.Modified = s.Value == "true" && commit == ""
+			}
+		}
+	}
+	if i.Version == "" {
+		i.Version = "dev"
+	}
+	return i
+}
+
+// ShortCommit is the first 12 characters of the commit.
+func (i Info) ShortCommit() string {
+	if len(i.Commit) > 12 {
+		return i.Commit[:12]
+	}
+	return i.Commit
+}
+
+// String reads e.g. "v1.4.0 (3f2c1a9b0d4e, 2026-10-16T09:00:00Z, go1.24.2 linux/arm64)".
+func (i Info) String() string {
+	parts := make([]string, 0, 4)
+	if c := i.ShortCommit(); c != "" {
+		if i.Modified {
+			c += "-dirty"
+		}
+		parts = append(parts, c)
+	}
+	if i.Date != "" {
+		parts = append(parts, i.Date)
+	}
+	parts = append(parts, i.GoVersion+" "+i.Platform)
+	return i.Version + " (" + strings.Join(parts, ", ") + ")"
+}
+
+// UserAgent names a component of the project and the running version,
+// e.g. "book-manager/v1.4.0 (+https://github.com/Venkatpandey/book-manager)"
+// for component "", or "bookctl/v1.4.0 (...)".
+func UserAgent(component string) string {
+	if component == "" {
+		component = Project
+	}
+	return component + "/" + Get().Version + " (+" + ProjectURL + ")"
+}
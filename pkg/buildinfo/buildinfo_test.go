@@ -0,0 +1,48 @@
+//go:build unit
+
+package buildinfo
+
+import (
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func vcsBuild() (*debug.BuildInfo, bool) {
+	return &debug.BuildInfo{
+		Main: debug.Module{Version: "(devel)"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "3f2c1a9b0d4e5f60718293a4b5c6d7e8f9012345"},
+			{Key: "vcs.time", Value: "2026-10-01T12:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}, true
+}
+
+func TestRead_LinkerFlagsWin(t *testing.T) {
+	i := read("v1.4.0", "abcdef", "2026-10-16T09:00:00Z", vcsBuild)
+	assert.Equal(t, "v1.4.0", i.Version)
+	assert.Equal(t, "abcdef", i.Commit)
+	assert.Equal(t, "2026-10-16T09:00:00Z", i.Date)
+	assert.False(t, i.Modified, "the stamped commit is what was built")
+	assert.True(t, strings.HasPrefix(i.String(), "v1.4.0 (abcdef, 2026-10-16T09:00:00Z, go"), i.String())
+}
+
+func TestRead_FallsBackToVCS(t *testing.T) {
+	i := read("", "", "", vcsBuild)
+	assert.Equal(t, "dev", i.Version)
+	assert.Equal(t, "3f2c1a9b0d4e", i.ShortCommit())
+	assert.Equal(t, "2026-10-01T12:00:00Z", i.Date)
+	assert.True(t, strings.HasPrefix(i.String(), "dev (3f2c1a9b0d4e-dirty, 2026-10-01T12:00:00Z, go"), i.String())
+
+	i = read("", "", "", func() (*debug.BuildInfo, bool) { return nil, false })
+	assert.Equal(t, "dev", i.Version)
+	assert.Empty(t, i.Commit)
+}
+
+func TestUserAgent(t *testing.T) {
+	assert.Equal(t, "bookctl/"+Get().Version+" (+"+ProjectURL+")", UserAgent("bookctl"))
+	assert.True(t, strings.HasPrefix(UserAgent(""), "book-manager/"))
+}
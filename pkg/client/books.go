@@ -0,0 +1,130 @@
+package client
+
+import (
+	"book-manager/api"
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+const booksPath = "/api/v1/books"
+
+// CreateBook adds a book. params may be nil; unless it names an
+// Idempotency-Key, one is made up, so that a retried create returns the
+// book created first instead of a duplicate.
+func (c *Client) CreateBook(ctx context.Context, in api.BookCreate, params *api.CreateBookParams) (*api.Book, error) {
+	p := api.CreateBookParams{}
+	if params != nil {
+		p = *params
+	}
+	if p.IdempotencyKey == nil {
+		key := uuid.NewString()
+		p.IdempotencyKey = &key
+	}
+	var b api.Book
+	if err := c.do(ctx, request{method: http.MethodPost, path: booksPath, params: &p, body: in}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// GetBook returns the book with id; IsNotFound tells a missing one.
+func (c *Client) GetBook(ctx context.Context, id string) (*api.Book, error) {
+	var b api.Book
+	if err := c.do(ctx, request{method: http.MethodGet, path: bookPath(id)}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// ListBooks returns one page of books; params may be nil. See Books for
+// all of them.
+func (c *Client) ListBooks(ctx context.Context, params *api.ListBooksParams) (*api.PaginatedBooks, error) {
+	var page api.PaginatedBooks
+	if err := c.do(ctx, request{method: http.MethodGet, path: booksPath, params: params}, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// Books yields every book params selects, fetching page after page with
+// the cursor of the one before, so no book is skipped or repeated while
+// others are added or removed. params may be nil; its Page and Cursor are
+// ignored. The walk stops after the first error, which is yielded.
+func (c *Client) Books(ctx context.Context, params *api.ListBooksParams) iter.Seq2[api.Book, error] {
+	return func(yield func(api.Book, error) bool) {
+		p := api.ListBooksParams{}
+		if params != nil {
+			p = *params
+		}
+		p.Page, p.Cursor = nil, nil
+		for {
+			page, err := c.ListBooks(ctx, &p)
+			if err != nil {
+				yield(api.Book{}, err)
+				return
+			}
+			for _, b := range page.Data {
+				if !yield(b, nil) {
+					return
+				}
+			}
+			if page.NextCursor == nil || len(page.Data) == 0 {
+				return
+			}
+			p.Cursor = page.NextCursor
+		}
+	}
+}
+
+// UpdateBook replaces the fields of the book with id. When in.Version is
+// set and the book has changed since, it fails with a conflict
+// (IsConflict) and nothing is written.
+func (c *Client) UpdateBook(ctx context.Context, id string, in api.BookCreate) (*api.Book, error) {
+	var b api.Book
+	if err := c.do(ctx, request{method: http.MethodPut, path: bookPath(id), body: in}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// PatchBook changes the fields of the book with id that p sets.
+func (c *Client) PatchBook(ctx context.Context, id string, p api.BookPatch) (*api.Book, error) {
+	var b api.Book
+	if err := c.do(ctx, request{method: http.MethodPatch, path: bookPath(id), body: p}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// DeleteBook moves the book with id to the trash.
+func (c *Client) DeleteBook(ctx context.Context, id string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: bookPath(id)}, nil)
+}
+
+// ExportBooks streams the books params selects as CSV, Markdown or
+// Org-mode (params.Format, CSV by default). The caller closes the reader.
+func (c *Client) ExportBooks(ctx context.Context, params *api.ExportBooksParams) (io.ReadCloser, error) {
+	res, err := c.send(ctx, request{method: http.MethodGet, path: booksPath + "/export", params: params})
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// CompareBooks reports which of isbns are in the catalog, with the ids of
+// their books, and which are missing.
+func (c *Client) CompareBooks(ctx context.Context, isbns []string) (*api.CompareResult, error) {
+	var out api.CompareResult
+	r := request{method: http.MethodPost, path: booksPath + "/compare", body: api.CompareRequest{Isbns: isbns}, safe: true}
+	if err := c.do(ctx, r, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func bookPath(id string) string { return booksPath + "/" + url.PathEscape(id) }
@@ -0,0 +1,315 @@
+// Package client is a typed Go client for the book-manager API, for Go
+// services that want to work with a catalog without writing the HTTP calls
+// themselves. Requests and responses are the types of package api, the ones
+// the server is generated from.
+//
+//	c := client.New("http://localhost:8080", client.WithAPIKey(key))
+//	b, err := c.CreateBook(ctx, api.BookCreate{Title: "Dune"})
+//	for b, err := range c.Books(ctx, &api.ListBooksParams{Tag: &tag}) {
+//		...
+//	}
+//
+// Requests that are safe to repeat (GET, PUT and DELETE, and creates, which
+// carry an Idempotency-Key) are retried when the connection fails or the
+// server answers 429, 502, 503 or 504; see WithRetry. Answers outside 2xx
+// are returned as *Error.
+package client
+
+import (
+	"book-manager/api"
+	"book-manager/pkg/buildinfo"
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client calls the API at one base URL. It is safe for concurrent use.
+type Client struct {
+	baseURL string
+	hc      *http.Client
+	header  http.Header
+	retry   RetryPolicy
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// New returns a Client for the API at baseURL, e.g. http://localhost:8080.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		hc:      &http.Client{Timeout: time.Minute},
+		header:  http.Header{"User-Agent": {buildinfo.UserAgent("book-manager-client")}},
+		retry:   DefaultRetryPolicy,
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// WithHTTPClient sends the requests with hc instead of a client with a
+// one-minute timeout.
+func WithHTTPClient(hc *http.Client) Option { return func(c *Client) { c.hc = hc } }
+
+// WithAPIKey authenticates as the holder of an API key (X-API-Key).
+func WithAPIKey(key string) Option { return WithHeader("X-API-Key", key) }
+
+// WithBearerToken authenticates with a token, such as an OIDC access token
+// or a JWT.
+func WithBearerToken(token string) Option { return WithHeader("Authorization", "Bearer "+token) }
+
+// WithHeader sends a header with every request, e.g. X-Tenant-ID or
+// User-Agent.
+func WithHeader(name, value string) Option { return func(c *Client) { c.header.Set(name, value) } }
+
+// WithRetry replaces DefaultRetryPolicy; RetryPolicy{} turns retries off.
+func WithRetry(p RetryPolicy) Option { return func(c *Client) { c.retry = p } }
+
+// RetryPolicy says how often a request is retried and how long to wait in
+// between: from Initial, doubling up to Max, each wait randomized by ±50%
+// so clients that failed together do not retry together. A Retry-After
+// answer replaces the computed wait.
+type RetryPolicy struct {
+	Retries int // attempts after the first
+	Initial time.Duration
+	Max     time.Duration // 0 is no cap
+}
+
+// DefaultRetryPolicy retries three times, after about 200ms, 400ms and 800ms.
+var DefaultRetryPolicy = RetryPolicy{Retries: 3, Initial: 200 * time.Millisecond, Max: 5 * time.Second}
+
+// backoff is the wait after the given failed attempt (0 is the first).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.Initial << min(attempt, 20)
+	if p.Max > 0 && (d > p.Max || d <= 0) {
+		d = p.Max
+	}
+	return time.Duration(float64(d) * (0.5 + rand.Float64()))
+}
+
+// Error is an answer outside 2xx, with the error body the API sends.
+// Answers without one, e.g. from a proxy, leave Code empty and carry their
+// body as Message.
+type Error struct {
+	StatusCode int
+	Code       api.ErrorCode
+	Message    string
+	Details    map[string]any // see api.Error
+	RequestID  string
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Code, msg)
+	}
+	return fmt.Sprintf("%d: %s", e.StatusCode, msg)
+}
+
+// IsNotFound reports whether err is a 404 answer.
+func IsNotFound(err error) bool { return hasStatus(err, http.StatusNotFound) }
+
+// IsConflict reports whether err is a 409 answer: a duplicate ISBN, or an
+// update based on a version that is no longer current.
+func IsConflict(err error) bool { return hasStatus(err, http.StatusConflict) }
+
+func hasStatus(err error, status int) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == status
+}
+
+// request is one call; params is a generated Params struct or nil.
+type request struct {
+	method string
+	path   string
+	params any
+	body   any  // sent as JSON unless nil
+	safe   bool // a POST that changes nothing, retried like a GET
+}
+
+// do sends r and decodes a 2xx answer into out, unless out is nil.
+func (c *Client) do(ctx context.Context, r request, out any) error {
+	res, err := c.send(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if out == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s %s: decode response: %w", r.method, r.path, err)
+	}
+	return nil
+}
+
+// send sends r, retrying as the policy allows, and returns a 2xx answer
+// with its body still to be read.
+func (c *Client) send(ctx context.Context, r request) (*http.Response, error) {
+	query, header, err := encodeParams(r.params)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", r.method, r.path, err)
+	}
+	var body []byte
+	if r.body != nil {
+		if body, err = json.Marshal(r.body); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", r.method, r.path, err)
+		}
+	}
+	u := c.baseURL + r.path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	retryable := r.safe || r.method == http.MethodGet || r.method == http.MethodPut || r.method == http.MethodDelete ||
+		header.Get("Idempotency-Key") != ""
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, r.method, u, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range c.header {
+			req.Header[k] = v
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		res, err := c.hc.Do(req)
+		var wait time.Duration
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+		case res.StatusCode < 300:
+			return res, nil
+		default:
+			err = readError(res)
+			wait = retryAfter(res)
+			if !temporary(res.StatusCode) {
+				return nil, err
+			}
+		}
+		if !retryable || attempt >= c.retry.Retries {
+			return nil, err
+		}
+		if wait == 0 {
+			wait = c.retry.backoff(attempt)
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// temporary reports whether an answer may be different on a retry.
+func temporary(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter is the wait the Retry-After header of res asks for, given in
+// seconds or as an HTTP date, or 0.
+func retryAfter(res *http.Response) time.Duration {
+	v := res.Header.Get("Retry-After")
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
+
+// readError reads the error answer res and closes its body.
+func readError(res *http.Response) error {
+	defer res.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(res.Body, 64<<10))
+	e := &Error{StatusCode: res.StatusCode}
+	var body api.ErrorResponse
+	if json.Unmarshal(data, &body) == nil && body.Error.Code != "" {
+		e.Code, e.Message = body.Error.Code, body.Error.Message
+		if body.Error.Details != nil {
+			e.Details = *body.Error.Details
+		}
+		if body.Error.RequestId != nil {
+			e.RequestID = *body.Error.RequestId
+		}
+	} else {
+		e.Message = strings.TrimSpace(string(data))
+	}
+	return e
+}
+
+// encodeParams turns a generated Params struct into the query (fields
+// with a form tag) and the headers (the other fields, named by their json
+// tag) of a request. Nil fields are left out.
+func encodeParams(params any) (url.Values, http.Header, error) {
+	query, header := url.Values{}, http.Header{}
+	v := reflect.Indirect(reflect.ValueOf(params))
+	if !v.IsValid() {
+		return query, header, nil
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("parameters of type %s are not supported", v.Type())
+	}
+	for i := range v.NumField() {
+		f := v.Type().Field(i)
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		var values []string
+		if fv.Kind() == reflect.Slice {
+			for j := range fv.Len() {
+				values = append(values, formatParam(fv.Index(j)))
+			}
+		} else {
+			values = []string{formatParam(fv)}
+		}
+		if name, _, _ := strings.Cut(f.Tag.Get("form"), ","); name != "" {
+			query[name] = values
+		} else if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" {
+			header[http.CanonicalHeaderKey(name)] = values
+		}
+	}
+	return query, header, nil
+}
+
+func formatParam(v reflect.Value) string {
+	switch x := v.Interface().(type) {
+	case time.Time:
+		return x.Format(time.RFC3339Nano)
+	case encoding.TextMarshaler:
+		b, _ := x.MarshalText()
+		return string(b)
+	case fmt.Stringer:
+		return x.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
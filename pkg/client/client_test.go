@@ -0,0 +1,214 @@
+//go:build unit
+
+package client
+
+import (
+	"book-manager/api"
+	"book-manager/internal/adapter"
+	"book-manager/internal/core"
+	"book-manager/internal/core/model"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noEnrich struct{}
+
+func (noEnrich) FetchByISBN(context.Context, string) (model.EnrichedBook, error) {
+	return model.EnrichedBook{}, model.ErrNotFound
+}
+
+// newAPI serves the API on an in-memory catalog.
+func newAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	svc := core.NewService(adapter.NewBookRepo(), noEnrich{})
+	r := chi.NewRouter()
+	api.HandlerFromMux(adapter.NewHTTPHandler(svc, slog.New(slog.NewTextHandler(io.Discard, nil))), r)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestBooks(t *testing.T) {
+	ctx := context.Background()
+	c := New(newAPI(t).URL)
+
+	start := time.Now().Add(-time.Second)
+	var ids []string
+	for _, title := range []string{"Dune", "Emma", "Ulysses", "Walden", "Beloved"} {
+		b, err := c.CreateBook(ctx, api.BookCreate{Title: title, Tags: &[]string{"classic"}}, nil)
+		require.NoError(t, err)
+		ids = append(ids, b.Id)
+	}
+	_, err := c.CreateBook(ctx, api.BookCreate{Title: "Other"}, nil)
+	require.NoError(t, err)
+
+	t.Run("get", func(t *testing.T) {
+		b, err := c.GetBook(ctx, ids[0])
+		require.NoError(t, err)
+		assert.Equal(t, "Dune", b.Title)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		page, err := c.ListBooks(ctx, &api.ListBooksParams{Tag: ptr("classic"), PageSize: ptr(2), Sort: ptr("title")})
+		require.NoError(t, err)
+		require.Len(t, page.Data, 2)
+		assert.Equal(t, "Beloved", page.Data[0].Title)
+		require.NotNil(t, page.Total)
+		assert.Equal(t, 5, *page.Total)
+	})
+
+	t.Run("iterator walks every page", func(t *testing.T) {
+		var titles []string
+		for b, err := range c.Books(ctx, &api.ListBooksParams{Tag: ptr("classic"), PageSize: ptr(2), Sort: ptr("title")}) {
+			require.NoError(t, err)
+			titles = append(titles, b.Title)
+		}
+		assert.Equal(t, []string{"Beloved", "Dune", "Emma", "Ulysses", "Walden"}, titles)
+
+		n := 0
+		for range c.Books(ctx, &api.ListBooksParams{UpdatedSince: &start, PageSize: ptr(1)}) {
+			if n++; n == 3 {
+				break
+			}
+		}
+		assert.Equal(t, 3, n, "breaking out stops the walk")
+	})
+
+	t.Run("update and patch", func(t *testing.T) {
+		b, err := c.UpdateBook(ctx, ids[1], api.BookCreate{Title: "Emma", PublishedYear: ptr(1815)})
+		require.NoError(t, err)
+		assert.Equal(t, 1815, *b.PublishedYear)
+
+		b, err = c.PatchBook(ctx, ids[1], api.BookPatch{PageCount: ptr(474)})
+		require.NoError(t, err)
+		assert.Equal(t, 474, *b.PageCount)
+		assert.Equal(t, 1815, *b.PublishedYear)
+
+		_, err = c.UpdateBook(ctx, ids[1], api.BookCreate{Title: "Emma", Version: ptr(1)})
+		assert.True(t, IsConflict(err), "%v", err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, c.DeleteBook(ctx, ids[4]))
+		_, err := c.GetBook(ctx, ids[4])
+		require.True(t, IsNotFound(err), "%v", err)
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, api.ErrorCode("NOT_FOUND"), e.Code)
+	})
+
+	t.Run("validation errors carry their details", func(t *testing.T) {
+		_, err := c.CreateBook(ctx, api.BookCreate{Title: "Bad", Isbn: ptr("9780000000000")}, nil)
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, http.StatusBadRequest, e.StatusCode)
+		assert.Contains(t, e.Details, "isbn")
+	})
+
+	t.Run("export", func(t *testing.T) {
+		body, err := c.ExportBooks(ctx, &api.ExportBooksParams{Format: ptr("markdown"), Tag: ptr("classic")})
+		require.NoError(t, err)
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "| Dune")
+		assert.NotContains(t, string(data), "Other")
+	})
+}
+
+func TestRetries(t *testing.T) {
+	var calls atomic.Int32
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id":"b1","title":"Dune"}`)
+	}))
+	defer srv.Close()
+	c := New(srv.URL, WithRetry(RetryPolicy{Retries: 3, Initial: time.Millisecond}))
+	ctx := context.Background()
+
+	t.Run("reads", func(t *testing.T) {
+		calls.Store(0)
+		b, err := c.GetBook(ctx, "b1")
+		require.NoError(t, err)
+		assert.Equal(t, "Dune", b.Title)
+		assert.EqualValues(t, 3, calls.Load())
+	})
+
+	t.Run("creates keep their idempotency key", func(t *testing.T) {
+		calls.Store(0)
+		keys = nil
+		_, err := c.CreateBook(ctx, api.BookCreate{Title: "Dune"}, nil)
+		require.NoError(t, err)
+		require.Len(t, keys, 3)
+		assert.NotEmpty(t, keys[0])
+		assert.Equal(t, keys[0], keys[2])
+	})
+
+	t.Run("patches are not retried", func(t *testing.T) {
+		calls.Store(0)
+		_, err := c.PatchBook(ctx, "b1", api.BookPatch{})
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, http.StatusServiceUnavailable, e.StatusCode)
+		assert.Equal(t, "busy", e.Message)
+		assert.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("give up", func(t *testing.T) {
+		calls.Store(-10)
+		_, err := New(srv.URL, WithRetry(RetryPolicy{Retries: 1, Initial: time.Millisecond})).GetBook(ctx, "b1")
+		require.Error(t, err)
+		assert.EqualValues(t, -8, calls.Load())
+	})
+}
+
+func TestAuthAndParams(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		_, _ = io.WriteString(w, `{"data":[],"page":1,"page_size":20,"next_cursor":null}`)
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	_, err := New(srv.URL, WithAPIKey("s3cret"), WithHeader("X-Tenant-ID", "east")).ListBooks(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", got.Header.Get("X-API-Key"))
+	assert.Equal(t, "east", got.Header.Get("X-Tenant-ID"))
+	assert.True(t, strings.HasPrefix(got.Header.Get("User-Agent"), "book-manager-client/"))
+	assert.Empty(t, got.URL.RawQuery)
+
+	since := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	_, err = New(srv.URL, WithBearerToken("t0ken")).ListBooks(ctx, &api.ListBooksParams{
+		Q: ptr("dune"), YearFrom: ptr(1960), Available: ptr(true), UpdatedSince: &since, IfNoneMatch: ptr(`"v1"`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer t0ken", got.Header.Get("Authorization"))
+	assert.Equal(t, `"v1"`, got.Header.Get("If-None-Match"))
+	q := got.URL.Query()
+	assert.Equal(t, "dune", q.Get("q"))
+	assert.Equal(t, "1960", q.Get("year_from"))
+	assert.Equal(t, "true", q.Get("available"))
+	assert.Equal(t, "2026-05-01T12:00:00Z", q.Get("updated_since"))
+	assert.NotContains(t, q, "If-None-Match")
+}
@@ -0,0 +1,269 @@
+// Package factory builds test data: books, create inputs and enrichment
+// results that look like a real catalog, differ from call to call, and are
+// the same on every run for the same seed, so scenario tests can ask for
+// "fifty books by three authors" instead of spelling each one out.
+//
+//	f := factory.New(1)
+//	in := f.CreateBookInput(factory.WithTags("to-read"))
+//	books := f.Books(50, factory.WithAuthors("Ann Author"))
+//
+// Options are applied after the random fields are drawn, so the n-th book
+// of a factory is the same whatever options earlier calls passed.
+package factory
+
+import (
+	"book-manager/internal/core/model"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Epoch is the CreatedAt of a factory's first book; each further book is
+// created a minute later.
+var Epoch = time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+// Factory hands out deterministic test data. It is not safe for concurrent
+// use.
+type Factory struct {
+	rng *rand.Rand
+	n   int
+}
+
+func New(seed uint64) *Factory {
+	return &Factory{rng: rand.New(rand.NewPCG(seed, 0x626f6f6b))}
+}
+
+// Option adjusts a book after its random fields are drawn. The same
+// options serve Book, CreateBookInput and EnrichedBook.
+type Option func(*model.Book)
+
+func WithID(id string) Option { return func(b *model.Book) { b.ID = id } }
+
+func WithTitle(title string) Option { return func(b *model.Book) { b.Title = title } }
+
+func WithSubtitle(s string) Option { return func(b *model.Book) { b.Subtitle = &s } }
+
+func WithISBN(isbn string) Option { return func(b *model.Book) { b.ISBN = &isbn } }
+
+func WithoutISBN() Option { return func(b *model.Book) { b.ISBN = nil } }
+
+func WithAuthors(names ...string) Option { return func(b *model.Book) { b.Authors = names } }
+
+func WithTags(tags ...string) Option { return func(b *model.Book) { b.Tags = tags } }
+
+func WithYear(year int) Option { return func(b *model.Book) { b.PublishedYear = &year } }
+
+func WithPages(n int) Option { return func(b *model.Book) { b.PageCount = &n } }
+
+func WithCover(url string) Option { return func(b *model.Book) { b.CoverURL = &url } }
+
+func WithDescription(d string) Option { return func(b *model.Book) { b.Description = &d } }
+
+func WithSubjects(subjects ...string) Option { return func(b *model.Book) { b.Subjects = subjects } }
+
+func WithCreatedAt(t time.Time) Option {
+	return func(b *model.Book) { b.CreatedAt, b.UpdatedAt = t, t }
+}
+
+// Forthcoming marks the book as not yet released, due on release.
+func Forthcoming(release time.Time) Option {
+	return func(b *model.Book) {
+		day := release.UTC().Truncate(24 * time.Hour)
+		b.Forthcoming = true
+		b.ReleaseDate = &day
+		b.PublishedYear = nil
+	}
+}
+
+// WithPriceTarget watches the book's price; amount is in minor units,
+// e.g. cents.
+func WithPriceTarget(amount int64, currency string) Option {
+	return func(b *model.Book) { b.PriceTarget = &model.Price{Amount: amount, Currency: currency} }
+}
+
+// Enriched marks the book as enriched by source; for EnrichedBook it is
+// the source that answered.
+func Enriched(source string) Option {
+	return func(b *model.Book) {
+		b.Enrichment = model.EnrichmentMeta{Attempted: true, Source: source, Status: model.EnrichmentOK}
+		if b.ISBN != nil {
+			b.Enrichment.LookedUpISBN = *b.ISBN
+		}
+	}
+}
+
+// Book returns the next book, as a repository would store it at version 1.
+// Description and subjects are only set by options; see EnrichedBook.
+func (f *Factory) Book(opts ...Option) model.Book {
+	b := f.draw()
+	b.Description, b.Subjects = nil, nil
+	for _, o := range opts {
+		o(&b)
+	}
+	return b
+}
+
+// Books returns the next n books, each with opts.
+func (f *Factory) Books(n int, opts ...Option) []model.Book {
+	out := make([]model.Book, n)
+	for i := range out {
+		out[i] = f.Book(opts...)
+	}
+	return out
+}
+
+// CreateBookInput returns the user-supplied fields of the next book.
+func (f *Factory) CreateBookInput(opts ...Option) model.CreateBookInput {
+	b := f.Book(opts...)
+	return model.CreateBookInput{
+		ISBN:          b.ISBN,
+		Title:         &b.Title,
+		Subtitle:      b.Subtitle,
+		PublishedYear: b.PublishedYear,
+		PageCount:     b.PageCount,
+		CoverURL:      b.CoverURL,
+		Tags:          b.Tags,
+		Authors:       b.Authors,
+		Forthcoming:   b.Forthcoming,
+		ReleaseDate:   b.ReleaseDate,
+		PriceTarget:   b.PriceTarget,
+	}
+}
+
+// CreateBookInputs returns inputs for the next n books, each with opts.
+func (f *Factory) CreateBookInputs(n int, opts ...Option) []model.CreateBookInput {
+	out := make([]model.CreateBookInput, n)
+	for i := range out {
+		out[i] = f.CreateBookInput(opts...)
+	}
+	return out
+}
+
+// EnrichedBook returns what an enrichment source could answer for the
+// next book, with a description and subjects; the source is "openlibrary"
+// unless Enriched names another.
+func (f *Factory) EnrichedBook(opts ...Option) model.EnrichedBook {
+	b := f.draw()
+	b.Enrichment.Source = "openlibrary"
+	for _, o := range opts {
+		o(&b)
+	}
+	return model.EnrichedBook{
+		Source:        b.Enrichment.Source,
+		Title:         &b.Title,
+		Subtitle:      b.Subtitle,
+		PublishedYear: b.PublishedYear,
+		PageCount:     b.PageCount,
+		CoverURL:      b.CoverURL,
+		Authors:       b.Authors,
+		ReleaseDate:   b.ReleaseDate,
+		Description:   b.Description,
+		Subjects:      b.Subjects,
+	}
+}
+
+// ISBN returns a random ISBN-13 with a valid check digit.
+func (f *Factory) ISBN() string {
+	digits := make([]byte, 0, 13)
+	digits = append(digits, "978"...)
+	for range 9 {
+		digits = append(digits, byte('0'+f.rng.IntN(10)))
+	}
+	sum := 0
+	for i, d := range digits {
+		w := 1
+		if i%2 == 1 {
+			w = 3
+		}
+		sum += int(d-'0') * w
+	}
+	return string(append(digits, byte('0'+(10-sum%10)%10)))
+}
+
+// draw makes the next book. Every field is drawn on every call, in the
+// same order, which keeps the sequence stable.
+func (f *Factory) draw() model.Book {
+	f.n++
+	var id uuid.UUID
+	for i := range id {
+		id[i] = byte(f.rng.UintN(256))
+	}
+	id[6] = id[6]&0x0f | 0x40 // version 4
+	id[8] = id[8]&0x3f | 0x80 // RFC 4122 variant
+
+	isbn := f.ISBN()
+	title := f.title()
+	var subtitle *string
+	if f.rng.IntN(3) == 0 {
+		s := pick(f.rng, subtitles)
+		subtitle = &s
+	}
+	authors := []string{f.name()}
+	if f.rng.IntN(4) == 0 {
+		authors = append(authors, f.name())
+	}
+	tags := []string{pick(f.rng, tagWords)}
+	if t := pick(f.rng, tagWords); t != tags[0] && f.rng.IntN(2) == 0 {
+		tags = append(tags, t)
+	}
+	year := 1950 + f.rng.IntN(75)
+	pages := 90 + f.rng.IntN(810)
+	subject := pick(f.rng, subjects)
+	description := fmt.Sprintf("%s is a %s about %s, first published in %d.",
+		title, pick(f.rng, kinds), strings.ToLower(subject), year)
+	created := Epoch.Add(time.Duration(f.n-1) * time.Minute)
+
+	return model.Book{
+		ID:            id.String(),
+		Version:       1,
+		ISBN:          &isbn,
+		Title:         title,
+		Subtitle:      subtitle,
+		PublishedYear: &year,
+		PageCount:     &pages,
+		Tags:          tags,
+		Authors:       authors,
+		Description:   &description,
+		Subjects:      []string{subject},
+		Enrichment:    model.EnrichmentMeta{Status: model.EnrichmentNotRequested},
+		CreatedAt:     created,
+		UpdatedAt:     created,
+	}
+}
+
+func (f *Factory) title() string {
+	switch f.rng.IntN(3) {
+	case 0:
+		return "The " + pick(f.rng, adjectives) + " " + pick(f.rng, nouns)
+	case 1:
+		a, b := f.rng.IntN(len(nouns)), f.rng.IntN(len(nouns)-1)
+		if b >= a {
+			b++ // never "Garden of the Garden"
+		}
+		return nouns[a] + " of the " + nouns[b]
+	default:
+		return pick(f.rng, adjectives) + " " + pick(f.rng, nouns)
+	}
+}
+
+func (f *Factory) name() string {
+	return pick(f.rng, givenNames) + " " + pick(f.rng, surnames)
+}
+
+func pick(rng *rand.Rand, words []string) string {
+	return words[rng.IntN(len(words))]
+}
+
+var (
+	adjectives = []string{"Silent", "Hidden", "Last", "Broken", "Golden", "Distant", "Quiet", "Burning", "Forgotten", "Endless", "Crimson", "Wandering"}
+	nouns      = []string{"Harbor", "Garden", "River", "Empire", "Clockmaker", "Orchard", "Lighthouse", "Archive", "Winter", "Compass", "Atlas", "Tide"}
+	subtitles  = []string{"A Novel", "A History", "Stories", "A Memoir", "Collected Essays", "An Introduction"}
+	givenNames = []string{"Ann", "Ben", "Clara", "David", "Elena", "Farid", "Grace", "Hiro", "Ines", "Jonas", "Kemi", "Lars"}
+	surnames   = []string{"Author", "Baker", "Castillo", "Dubois", "Eriksen", "Fischer", "Gupta", "Hayes", "Ivanova", "Jensen", "Kowalski", "Lindqvist"}
+	tagWords   = []string{"fiction", "history", "science", "classics", "to-read", "poetry", "travel", "biography"}
+	subjects   = []string{"Maritime history", "Family life", "Artificial intelligence", "Exploration", "Botany", "War", "Music", "Cities"}
+	kinds      = []string{"novel", "study", "chronicle", "collection", "memoir"}
+)
@@ -0,0 +1,74 @@
+//go:build unit
+
+package factory
+
+import (
+	"book-manager/internal/core"
+	"book-manager/internal/core/model"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactory_Deterministic(t *testing.T) {
+	a, b := New(7), New(7)
+	assert.Equal(t, a.Books(20), b.Books(20))
+	assert.Equal(t, a.EnrichedBook(), b.EnrichedBook())
+	assert.NotEqual(t, New(8).Book(), New(7).Book())
+}
+
+func TestFactory_OptionsDoNotShiftTheSequence(t *testing.T) {
+	plain, opted := New(1), New(1)
+	plain.Book()
+	opted.Book(WithTitle("Dune"), WithoutISBN(), Enriched("isbndb"))
+	assert.Equal(t, plain.Book(), opted.Book())
+}
+
+func TestFactory_Book(t *testing.T) {
+	f := New(3)
+	books := f.Books(50)
+	ids := map[string]bool{}
+	for i, b := range books {
+		require.NotNil(t, b.ISBN)
+		_, err := core.NormalizeISBN(*b.ISBN)
+		assert.NoError(t, err, *b.ISBN)
+		u, err := uuid.Parse(b.ID)
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Version(4), u.Version())
+		ids[b.ID] = true
+		assert.NotEmpty(t, b.Title)
+		assert.NotEmpty(t, b.Authors)
+		assert.Equal(t, 1, b.Version)
+		assert.Equal(t, Epoch.Add(time.Duration(i)*time.Minute), b.CreatedAt)
+		assert.Nil(t, b.Description)
+	}
+	assert.Len(t, ids, 50)
+
+	release := time.Date(2030, 5, 1, 15, 0, 0, 0, time.UTC)
+	b := f.Book(WithAuthors("Ann Author"), WithTags("seed"), Forthcoming(release), WithPriceTarget(999, "EUR"))
+	assert.Equal(t, []string{"Ann Author"}, b.Authors)
+	assert.Equal(t, []string{"seed"}, b.Tags)
+	assert.True(t, b.Forthcoming)
+	assert.Equal(t, time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC), *b.ReleaseDate)
+	assert.Nil(t, b.PublishedYear)
+	assert.Equal(t, &model.Price{Amount: 999, Currency: "EUR"}, b.PriceTarget)
+}
+
+func TestFactory_CreateBookInputAndEnrichedBook(t *testing.T) {
+	f := New(5)
+	in := f.CreateBookInput(WithTitle("Dune"), WithoutISBN())
+	assert.Equal(t, "Dune", *in.Title)
+	assert.Nil(t, in.ISBN)
+	assert.NotEmpty(t, in.Authors)
+	assert.Len(t, f.CreateBookInputs(3), 3)
+
+	e := f.EnrichedBook()
+	assert.Equal(t, "openlibrary", e.Source)
+	require.NotNil(t, e.Description)
+	assert.Contains(t, *e.Description, *e.Title)
+	assert.NotEmpty(t, e.Subjects)
+	assert.Equal(t, "isbndb", f.EnrichedBook(Enriched("isbndb")).Source)
+}
@@ -1,9 +1,12 @@
 package http_client
 
 import (
+	"book-manager/pkg/buildinfo"
 	"net"
 	"net/http"
 	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 func CreateHTTPClient() *http.Client {
@@ -20,9 +23,30 @@ func CreateHTTPClient() *http.Client {
 		}).DialContext,
 	}
 	cli := &http.Client{
-		Timeout:   2 * time.Second,
-		Transport: tr,
+		Timeout: 2 * time.Second,
+		// a client span per request, its trace passed on in traceparent
+		Transport: otelhttp.NewTransport(WithUserAgent(tr, buildinfo.UserAgent(""))),
 	}
 
 	return cli
 }
+
+// WithUserAgent sets ua on requests through rt that do not set their own,
+// so upstream APIs can tell our traffic and version apart.
+func WithUserAgent(rt http.RoundTripper, ua string) http.RoundTripper {
+	return userAgent{rt: rt, ua: ua}
+}
+
+type userAgent struct {
+	rt http.RoundTripper
+	ua string
+}
+
+func (t userAgent) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Header.Get("User-Agent") != "" {
+		return t.rt.RoundTrip(r)
+	}
+	r = r.Clone(r.Context())
+	r.Header.Set("User-Agent", t.ua)
+	return t.rt.RoundTrip(r)
+}
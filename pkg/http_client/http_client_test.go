@@ -0,0 +1,35 @@
+//go:build unit
+
+package http_client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateHTTPClient_UserAgent(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("User-Agent"))
+	}))
+	defer srv.Close()
+	c := CreateHTTPClient()
+
+	resp, err := c.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("User-Agent", "custom/1")
+	resp, err = c.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Len(t, got, 2)
+	assert.True(t, strings.HasPrefix(got[0], "book-manager/"), got[0])
+	assert.Equal(t, "custom/1", got[1], "callers' own user agent is kept")
+}
@@ -0,0 +1,27 @@
+//go:build unit
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevenshtein(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"architecture", "architecture", 0},
+		{"architecure", "architecture", 1},
+		{"arhcitecture", "architecture", 2},
+		{"kitten", "sitting", 3},
+		{"résumé", "resume", 2},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, Levenshtein(c.a, c.b), "%q vs %q", c.a, c.b)
+	}
+}
@@ -0,0 +1,224 @@
+//go:build e2e
+
+// Package e2e drives a running API (see docker-compose.yml) over HTTP.
+// Point E2E_BASE_URL elsewhere to run the suite against another deployment.
+package e2e
+
+import (
+	"book-manager/api"
+	"book-manager/pkg/factory"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var baseURL = "http://localhost:18080"
+
+func TestMain(m *testing.M) {
+	if v := os.Getenv("E2E_BASE_URL"); v != "" {
+		baseURL = v
+	}
+	if err := waitReady(30 * time.Second); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+func waitReady(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		resp, err := http.Get(baseURL + "/api/v1/books?page_size=1")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("api at %s not ready after %s: %v", baseURL, timeout, err)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+func TestEnrichThenSearch(t *testing.T) {
+	tag := uniqueTag()
+	var created api.Book
+	status := do(t, http.MethodPost, "/api/v1/books?enrich=true&require_enrichment=true",
+		map[string]any{"isbn": "9780441013593", "tags": []string{tag}}, &created)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "Dune", created.Title)
+	require.NotNil(t, created.Enrichment)
+	assert.Equal(t, api.Ok, created.Enrichment.Status)
+	require.Len(t, created.Authors, 1)
+	assert.Equal(t, "Frank Herbert", created.Authors[0].Name)
+
+	var page api.PaginatedBooks
+	q := url.Values{"q": {"dune"}, "tag": {tag}, "author": {"herbert"}}
+	status = do(t, http.MethodGet, "/api/v1/books?"+q.Encode(), nil, &page)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, created.Id, page.Data[0].Id)
+
+	status = do(t, http.MethodDelete, "/api/v1/books/"+created.Id, nil, nil)
+	assert.Equal(t, http.StatusNoContent, status)
+	status = do(t, http.MethodGet, "/api/v1/books/"+created.Id, nil, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	var restored api.Book
+	status = do(t, http.MethodPost, "/api/v1/books/"+created.Id+"/restore", nil, &restored)
+	require.Equal(t, http.StatusOK, status)
+	assert.Nil(t, restored.DeletedAt)
+	status = do(t, http.MethodDelete, "/api/v1/books/"+created.Id, nil, nil)
+	assert.Equal(t, http.StatusNoContent, status)
+	// the trashed book keeps its ISBN; purge it so the suite can run again
+	var purged api.PurgeResult
+	status = do(t, http.MethodPost, "/api/v1/admin/books/purge", nil, &purged)
+	require.Equal(t, http.StatusOK, status)
+	assert.GreaterOrEqual(t, purged.Purged, 1)
+}
+
+func TestRequiredEnrichmentUnavailable(t *testing.T) {
+	status := do(t, http.MethodPost, "/api/v1/books?enrich=true&require_enrichment=true",
+		map[string]any{"isbn": "9790000000001"}, nil)
+	assert.Equal(t, http.StatusBadGateway, status)
+}
+
+func TestSnapshotPagination(t *testing.T) {
+	tag := uniqueTag()
+	// no ISBNs: they would conflict when the suite runs again on the same deployment
+	var titles []string
+	for _, in := range factory.New(1).CreateBookInputs(3, factory.WithTags(tag), factory.WithoutISBN()) {
+		status := do(t, http.MethodPost, "/api/v1/books",
+			map[string]any{"title": *in.Title, "authors": in.Authors, "tags": in.Tags}, nil)
+		require.Equal(t, http.StatusCreated, status)
+		titles = append(titles, *in.Title)
+	}
+	slices.Sort(titles)
+	var first api.PaginatedBooks
+	status := do(t, http.MethodGet, "/api/v1/books?snapshot=true&page_size=2&sort=title&tag="+tag, nil, &first)
+	require.Equal(t, http.StatusOK, status)
+	require.NotNil(t, first.SnapshotId)
+	require.NotNil(t, first.Total)
+	assert.Equal(t, 3, *first.Total)
+
+	var second api.PaginatedBooks
+	status = do(t, http.MethodGet, "/api/v1/books?page=2&page_size=2&snapshot_id="+*first.SnapshotId, nil, &second)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, second.Data, 1)
+	assert.Equal(t, titles[2], second.Data[0].Title)
+}
+
+func TestImportEnrichSearchExport(t *testing.T) {
+	tag := uniqueTag()
+	csvIn := "isbn,title,tags\n9780134494166,Clean Architecture," + tag + "\n,Field Notes," + tag + "\n"
+	status, body := send(t, http.MethodPost, "/api/v1/books/import?enrich=true", "text/csv", []byte(csvIn))
+	require.Equal(t, http.StatusAccepted, status, string(body))
+	var job api.ImportJob
+	require.NoError(t, json.Unmarshal(body, &job))
+	assert.Equal(t, 2, job.Rows)
+
+	// the import runs in the background
+	require.Eventually(t, func() bool {
+		return do(t, http.MethodGet, "/api/v1/imports/"+job.Id, nil, &job) == http.StatusOK && job.Status == api.Done
+	}, 10*time.Second, 200*time.Millisecond, "the import did not finish")
+	assert.Equal(t, 2, job.Created)
+	assert.Empty(t, job.Errors)
+
+	// imports enrich in the background
+	var page api.PaginatedBooks
+	require.Eventually(t, func() bool {
+		page = api.PaginatedBooks{}
+		q := url.Values{"tag": {tag}, "author": {"martin"}}
+		return do(t, http.MethodGet, "/api/v1/books?"+q.Encode(), nil, &page) == http.StatusOK && len(page.Data) == 1
+	}, 10*time.Second, 200*time.Millisecond, "the imported book was not enriched")
+	enriched := page.Data[0]
+	assert.Equal(t, "Clean Architecture", enriched.Title)
+	require.NotNil(t, enriched.PageCount)
+	assert.Equal(t, 432, *enriched.PageCount)
+
+	status, body = send(t, http.MethodGet, "/api/v1/books/export?sort=title&tag="+tag, "", nil)
+	require.Equal(t, http.StatusOK, status)
+	rows, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3, "header and both books")
+	assert.Equal(t, "id", rows[0][0])
+	assert.Equal(t, []string{enriched.Id, "9780134494166", "Clean Architecture"}, rows[1][:3])
+	assert.Contains(t, rows[1], "Robert C. Martin")
+	assert.Equal(t, "Field Notes", rows[2][2])
+
+	// the imported ISBN would conflict when the suite runs again
+	for _, row := range rows[1:] {
+		assert.Equal(t, http.StatusNoContent, do(t, http.MethodDelete, "/api/v1/books/"+row[0], nil, nil))
+	}
+	status = do(t, http.MethodPost, "/api/v1/admin/books/purge", nil, nil)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestHealthAndMetrics(t *testing.T) {
+	var health api.Health
+	status := do(t, http.MethodGet, "/healthz", nil, &health)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", health.Status)
+	assert.Nil(t, health.Error)
+
+	status, body := send(t, http.MethodGet, "/metrics", "", nil)
+	require.Equal(t, http.StatusOK, status)
+	metrics := string(body)
+	assert.Contains(t, metrics, "# TYPE bookmanager_circuit_breaker_state gauge")
+	assert.Contains(t, metrics, `bookmanager_circuit_breaker_state{name="openlibrary",state="closed"}`)
+	assert.Contains(t, metrics, `bookmanager_circuit_breaker_failures_total{name="openlibrary"}`)
+}
+
+func uniqueTag() string {
+	return fmt.Sprintf("e2e-%d", time.Now().UnixNano())
+}
+
+// send sends body, of contentType when given, and returns the response.
+func send(t *testing.T, method, path, contentType string, body []byte) (int, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, baseURL+path, bytes.NewReader(body))
+	require.NoError(t, err)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	out, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, out
+}
+
+// do sends body as JSON and decodes the response into out when given.
+func do(t *testing.T, method, path string, body, out any) int {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+	req, err := http.NewRequest(method, baseURL+path, &buf)
+	require.NoError(t, err)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if out != nil && resp.StatusCode < 300 {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
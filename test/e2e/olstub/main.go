@@ -0,0 +1,54 @@
+// Command olstub is a stand-in for the Open Library API used by the e2e
+// suite. It serves a fixed set of /isbn/{isbn}.json documents.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+)
+
+var books = map[string]map[string]any{
+	"9780441013593": {
+		"title":           "Dune",
+		"number_of_pages": 604,
+		"publish_date":    "2005",
+		"covers":          []int{8231856},
+		"authors":         []map[string]any{{"name": "Frank Herbert"}},
+	},
+	"9780134494166": {
+		"title":           "Clean Architecture",
+		"subtitle":        "A Craftsman's Guide to Software Structure and Design",
+		"number_of_pages": 432,
+		"publish_date":    "Sep 10, 2017",
+		"authors":         []map[string]any{{"name": "Robert C. Martin"}},
+	},
+}
+
+// unavailable always answers 503 so retries and require_enrichment can be
+// exercised.
+const unavailable = "9790000000001"
+
+func main() {
+	listen := flag.String("listen", ":8080", "Listen address")
+	flag.Parse()
+
+	http.HandleFunc("GET /isbn/{file}", func(w http.ResponseWriter, r *http.Request) {
+		isbn, ok := strings.CutSuffix(r.PathValue("file"), ".json")
+		switch {
+		case !ok:
+			http.NotFound(w, r)
+		case isbn == unavailable:
+			http.Error(w, "upstream unavailable", http.StatusServiceUnavailable)
+		case books[isbn] == nil:
+			http.NotFound(w, r)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(books[isbn])
+		}
+	})
+	log.Printf("open library stub listening on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, nil))
+}